@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/middleware"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/persistence"
@@ -37,32 +39,141 @@ func main() {
 	userMembershipRepo := persistence.NewUserMembershipRepository(db)
 	securityEventRepo := persistence.NewSecurityEventRepository(db)
 	ipBlacklistRepo := persistence.NewIPBlacklistRepository(db)
+	ipAllowlistRepo := persistence.NewIPAllowlistRepository(db)
 	loginAttemptRepo := persistence.NewLoginAttemptRepository(db)
 	rateLimitRuleRepo := persistence.NewRateLimitRuleRepository(db)
+	rateLimitLogRepo := persistence.NewRateLimitLogRepository(db)
 	userSessionRepo := persistence.NewUserSessionRepository(db)
 	deviceFingerprintRepo := persistence.NewDeviceFingerprintRepository(db)
+	fraudRuleConfigRepo := persistence.NewFraudRuleConfigRepository(db)
+	userTokenRepo := persistence.NewUserTokenRepository(db)
+	pointTransactionRepo := persistence.NewPointTransactionRepository(db)
+	notificationRepo := persistence.NewNotificationRepository(db)
+	membershipTierRepo := persistence.NewMembershipTierRepository(db)
+	membershipTierHistoryRepo := persistence.NewMembershipTierHistoryRepository(db)
+	purchaseRepo := persistence.NewPurchaseRepository(db)
+	userPreferenceRepo := persistence.NewUserPreferenceRepository(db)
+	adminActionRepo := persistence.NewAdminActionRepository(db)
+	permissionRepo := persistence.NewPermissionRepository(db)
+	partnerAPIKeyRepo := persistence.NewPartnerAPIKeyRepository(db)
+	partnerQuotaRepo := persistence.NewPartnerQuotaRepository(db)
+	oauthClientRepo := persistence.NewOAuthClientRepository(db)
+	oauthAuthorizationCodeRepo := persistence.NewOAuthAuthorizationCodeRepository(db)
+	oauthRefreshTokenRepo := persistence.NewOAuthRefreshTokenRepository(db)
+	oauthConsentRepo := persistence.NewOAuthConsentRepository(db)
 
 	redisClient := external.NewRedisClient(getRedisAddr(), getRedisPassword(), getRedisDB())
 	cacheService := external.NewCacheService(redisClient)
 
+	streamHeartbeatInterval := getNotificationStreamHeartbeatInterval()
+	notificationBroker := external.NewRedisNotificationBroker(redisClient, 3*streamHeartbeatInterval)
+	notificationRepo = service.NewPublishingNotificationRepository(notificationRepo, notificationBroker)
+
+	twoFactorKey := getTwoFactorEncryptionKey()
+	if twoFactorKey == "" {
+		log.Println("⚠️ TWO_FACTOR_ENCRYPTION_KEY が未設定のため JWT_SECRET から暗号化キーを導出します")
+		twoFactorKey = jwtSecret
+	}
+	secretCipher, err := external.NewAESSecretCipher(twoFactorKey)
+	if err != nil {
+		log.Fatal("Failed to initialize secret cipher:", err)
+	}
+
+	var keySet service.TokenKeySet
+	if signingAlgorithm := getJWTSigningAlgorithm(); signingAlgorithm != service.SigningAlgorithmHS256 {
+		jwtKeySet, err := initJWTKeySet(signingAlgorithm)
+		if err != nil {
+			log.Fatal("Failed to initialize JWT key set:", err)
+		}
+		jwtKeySet.StartRotation(context.Background(), getJWTKeyRotationInterval())
+		keySet = jwtKeySet
+	}
+
 	authDomainService := service.NewAuthDomainService(
 		userRepo,
 		authRepo,
 		roleRepo,
 		refreshTokenRepo,
 		cacheService,
+		secretCipher,
+		keySet,
 		jwtSecret,
 	)
 
+	var mailSender service.MailSender
+	if smtpHost := getSMTPHost(); smtpHost != "" {
+		mailSender = external.NewSMTPMailSender(external.SMTPConfig{
+			Host:     smtpHost,
+			Port:     getSMTPPort(),
+			Username: getSMTPUsername(),
+			Password: getSMTPPassword(),
+			From:     getSMTPFrom(),
+		})
+	} else {
+		log.Println("⚠️ SMTP_HOST が未設定のためメールは送信されずメモリ上に保持されます")
+		mailSender = external.NewInMemoryMailSender()
+	}
+
+	accountDomainService := service.NewAccountDomainService(
+		authRepo,
+		userProfileRepo,
+		refreshTokenRepo,
+		userTokenRepo,
+		cacheService,
+		mailSender,
+		getAppBaseURL(),
+	)
+
 	fraudDomainService := service.NewFraudDomainService(
 		securityEventRepo,
 		ipBlacklistRepo,
+		ipAllowlistRepo,
 		loginAttemptRepo,
 		rateLimitRuleRepo,
+		rateLimitLogRepo,
 		userSessionRepo,
 		deviceFingerprintRepo,
+		fraudRuleConfigRepo,
+		cacheService,
+	)
+	userDetailUsecase := usecase.NewUserDetailUsecase(
+		userRepo,
+		userProfileRepo,
+		userMembershipRepo,
+		roleRepo,
+		loginAttemptRepo,
+		securityEventRepo,
+		deviceFingerprintRepo,
+		userSessionRepo,
 	)
 
+	if err := fraudDomainService.SyncIPListCache(context.Background()); err != nil {
+		log.Printf("⚠️ IPブラックリストのRedisへの初期ロードに失敗しました: %v", err)
+	}
+	startIPListSync(context.Background(), fraudDomainService, getBlacklistSyncInterval())
+
+	tierDomainService := service.NewTierDomainService(membershipTierRepo, userMembershipRepo, membershipTierHistoryRepo, notificationRepo)
+	startTierEvaluation(context.Background(), tierDomainService, getTierEvaluationHour())
+
+	pointDomainService := service.NewPointDomainService(userMembershipRepo, pointTransactionRepo, notificationRepo, tierDomainService)
+	notificationDomainService := service.NewNotificationDomainService(notificationRepo, notificationBroker, getNotificationStreamMaxPerUser())
+	preferenceDomainService := service.NewPreferenceDomainService(userPreferenceRepo, cacheService)
+	purchaseDomainService := service.NewPurchaseDomainService(purchaseRepo, membershipTierRepo, userMembershipRepo, tierDomainService)
+	adminPointDomainService := service.NewAdminPointDomainService(adminActionRepo, userMembershipRepo, pointTransactionRepo, tierDomainService, getAdminPointDailyBudget())
+	permissionDomainService := service.NewPermissionDomainService(permissionRepo, roleRepo, cacheService)
+	roleDomainService := service.NewRoleDomainService(roleRepo, permissionRepo, userRepo, refreshTokenRepo, permissionDomainService, cacheService)
+	partnerDomainService := service.NewPartnerDomainService(partnerAPIKeyRepo, partnerQuotaRepo, cacheService)
+	oauthDomainService := service.NewOAuthDomainService(oauthClientRepo, oauthAuthorizationCodeRepo, oauthRefreshTokenRepo, oauthConsentRepo, authDomainService, cacheService)
+
+	dashboardUsecase := usecase.NewDashboardUsecase(
+		authRepo,
+		userProfileRepo,
+		loginAttemptRepo,
+		deviceFingerprintRepo,
+		securityEventRepo,
+		notificationRepo,
+		cacheService,
+	)
 	authUsecase := usecase.NewAuthUsecase(authDomainService, fraudDomainService, cacheService)
 	userUsecase := usecase.NewUserUsecase(
 		userRepo,
@@ -70,17 +181,43 @@ func main() {
 		userMembershipRepo,
 		fraudDomainService,
 		redisClient,
+		dashboardUsecase,
 	)
-	fraudUsecase := usecase.NewFraudUsecase(fraudDomainService)
-
-	authMiddleware := middleware.NewAuthMiddleware(authDomainService, cacheService)
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(cacheService)
+	fraudUsecase := usecase.NewFraudUsecase(fraudDomainService, dashboardUsecase)
+	accountUsecase := usecase.NewAccountUsecase(accountDomainService, fraudDomainService, dashboardUsecase)
+	pointUsecase := usecase.NewPointUsecase(pointDomainService)
+	adminPointUsecase := usecase.NewAdminPointUsecase(adminPointDomainService)
+	permissionUsecase := usecase.NewPermissionUsecase(permissionDomainService)
+	roleUsecase := usecase.NewRoleUsecase(roleDomainService)
+	partnerUsecase := usecase.NewPartnerUsecase(partnerDomainService, fraudDomainService)
+	oauthUsecase := usecase.NewOAuthUsecase(oauthDomainService, fraudDomainService)
+	tierUsecase := usecase.NewTierUsecase(tierDomainService)
+	purchaseUsecase := usecase.NewPurchaseUsecase(purchaseDomainService)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationDomainService, userRepo, dashboardUsecase)
+	preferenceUsecase := usecase.NewPreferenceUsecase(preferenceDomainService)
+
+	authMiddleware := middleware.NewAuthMiddleware(authDomainService, cacheService, permissionDomainService)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(cacheService, fraudDomainService, fraudDomainService).WithAlgorithm(getRateLimitAlgorithm())
+	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(partnerDomainService)
 
 	authHandler := handler.NewAuthHandler(authUsecase)
 	userHandler := handler.NewUserHandler(userUsecase)
 	fraudHandler := handler.NewFraudHandler(fraudUsecase)
-
-	router := setupRouter(authHandler, userHandler, fraudHandler, authMiddleware, rateLimitMiddleware)
+	accountHandler := handler.NewAccountHandler(accountUsecase)
+	pointHandler := handler.NewPointHandler(pointUsecase)
+	tierHandler := handler.NewTierHandler(tierUsecase)
+	purchaseHandler := handler.NewPurchaseHandler(purchaseUsecase)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase).WithHeartbeatInterval(streamHeartbeatInterval)
+	preferenceHandler := handler.NewPreferenceHandler(preferenceUsecase)
+	dashboardHandler := handler.NewDashboardHandler(dashboardUsecase)
+	userDetailHandler := handler.NewUserDetailHandler(userDetailUsecase)
+	adminPointHandler := handler.NewAdminPointHandler(adminPointUsecase)
+	permissionHandler := handler.NewPermissionHandler(permissionUsecase)
+	roleHandler := handler.NewRoleHandler(roleUsecase)
+	partnerHandler := handler.NewPartnerHandler(partnerUsecase)
+	oauthHandler := handler.NewOAuthHandler(oauthUsecase)
+
+	router := setupRouter(authHandler, userHandler, fraudHandler, accountHandler, pointHandler, tierHandler, purchaseHandler, notificationHandler, preferenceHandler, dashboardHandler, userDetailHandler, adminPointHandler, permissionHandler, roleHandler, partnerHandler, oauthHandler, authMiddleware, rateLimitMiddleware, apiKeyMiddleware)
 
 	port := getPort()
 	log.Printf("Starting server on port %s...", port)
@@ -129,12 +266,60 @@ func connectToDBWithRetry(dsn string) (*gorm.DB, error) {
 	return nil, err
 }
 
-func setupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHandler, fraudHandler *handler.FraudHandler, authMiddleware *middleware.AuthMiddleware, rateLimitMiddleware *middleware.RateLimitMiddleware) *gin.Engine {
+func initJWTKeySet(algorithm string) (*external.JWTKeySet, error) {
+	path := getJWTKeySetFile()
+	overlap := getJWTKeyOverlap()
+	publishLead := getJWTKeyPublishLead()
+
+	var keySet *external.JWTKeySet
+	var err error
+	if path == "" {
+		log.Println("⚠️ JWT_KEYSET_FILE が未設定のため署名鍵はメモリ上で生成され、再起動時に破棄されます")
+		keySet, err = external.NewJWTKeySet(algorithm, overlap, publishLead)
+	} else {
+		keySet, err = external.LoadJWTKeySet(path, algorithm, overlap, publishLead)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := keySet.ActiveKey(); err != nil {
+		generated, err := keySet.RotateIfDue(getJWTKeyRotationInterval())
+		if err != nil {
+			return nil, err
+		}
+		if generated {
+			log.Printf("🔑 %s の署名鍵を新規に生成しました", algorithm)
+		}
+	}
+
+	return keySet, nil
+}
+
+func startIPListSync(ctx context.Context, fraudDomainService *service.FraudDomainService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fraudDomainService.SyncIPListCache(ctx); err != nil {
+					log.Printf("❌ IPブラックリストのRedis同期に失敗しました: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func setupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHandler, fraudHandler *handler.FraudHandler, accountHandler *handler.AccountHandler, pointHandler *handler.PointHandler, tierHandler *handler.TierHandler, purchaseHandler *handler.PurchaseHandler, notificationHandler *handler.NotificationHandler, preferenceHandler *handler.PreferenceHandler, dashboardHandler *handler.DashboardHandler, userDetailHandler *handler.UserDetailHandler, adminPointHandler *handler.AdminPointHandler, permissionHandler *handler.PermissionHandler, roleHandler *handler.RoleHandler, partnerHandler *handler.PartnerHandler, oauthHandler *handler.OAuthHandler, authMiddleware *middleware.AuthMiddleware, rateLimitMiddleware *middleware.RateLimitMiddleware, apiKeyMiddleware *middleware.APIKeyMiddleware) *gin.Engine {
 	router := gin.Default()
 
 	router.Use(handler.CORSMiddleware())
 	router.Use(handler.SecurityHeadersMiddleware())
 	router.Use(handler.RequestIDMiddleware())
+	router.Use(rateLimitMiddleware.CheckBlacklist())
 	router.Use(middleware.ContentTypeMiddleware())
 
 	router.HandleMethodNotAllowed = true
@@ -147,31 +332,64 @@ func setupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHand
 	})
 
 	router.GET("/health", userHandler.HealthCheck)
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	strictRateLimit := rateLimitMiddleware.WithAlgorithm(entity.RateLimitAlgorithmSlidingLog)
 
 	v1 := router.Group("/api/v1")
+	v1.Use(authMiddleware.OptionalAuth(), rateLimitMiddleware.RateLimitByRule())
 	{
 		auth := v1.Group("/auth")
 		{
 			auth.Use(rateLimitMiddleware.RateLimitByIP(getAuthRateLimit(), time.Minute))
 			auth.POST("/register", rateLimitMiddleware.RateLimitByIP(getRegisterRateLimit(), time.Minute), authHandler.Register)
-			auth.POST("/login", rateLimitMiddleware.RateLimitByIP(getLoginRateLimit(), time.Minute), authHandler.Login)
+			auth.POST("/login", strictRateLimit.RateLimitByIP(getLoginRateLimit(), time.Minute), authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
 			auth.POST("/validate", authHandler.ValidateToken)
+			auth.POST("/2fa/verify", strictRateLimit.RateLimitByIP(getLoginRateLimit(), time.Minute), authHandler.VerifyTwoFactor)
+			auth.POST("/password/forgot", accountHandler.ForgotPassword)
+			auth.POST("/password/reset", strictRateLimit.RateLimitByIP(getLoginRateLimit(), time.Minute), accountHandler.ResetPassword)
+			auth.POST("/email/verify", accountHandler.VerifyEmail)
+		}
+
+		oauth := v1.Group("/oauth")
+		{
+			oauth.POST("/token", rateLimitMiddleware.RateLimitByIP(getAuthRateLimit(), time.Minute), oauthHandler.Token)
+			oauth.POST("/introspect", oauthHandler.Introspect)
+			oauth.POST("/revoke", oauthHandler.Revoke)
+			oauth.GET("/authorize", authMiddleware.RequireAuth(), oauthHandler.GetAuthorize)
+			oauth.POST("/authorize", authMiddleware.RequireAuth(), oauthHandler.PostAuthorize)
 		}
 
 		user := v1.Group("/user")
 		user.Use(authMiddleware.RequireAuth())
 		{
 			user.POST("/logout", authHandler.Logout)
+			user.POST("/logout-all", authHandler.LogoutAll)
 			user.POST("/change-password", authHandler.ChangePassword)
 			user.GET("/profile", userHandler.GetUserProfile)
 			user.PUT("/profile/:id", userHandler.UpdateUserProfile)
-			user.GET("/dashboard", authHandler.GetUserDashboard)
-			user.GET("/notifications", authHandler.GetUserNotifications)
-			user.PUT("/notifications/:id/read", authHandler.MarkNotificationRead)
-			user.GET("/points/transactions", authHandler.GetUserPointTransactions)
-			user.POST("/preferences", authHandler.SetUserPreference)
-			user.GET("/preferences", authHandler.GetUserPreferences)
+			user.GET("/dashboard", dashboardHandler.GetUserDashboard)
+			user.GET("/permissions", permissionHandler.GetMyPermissions)
+			user.GET("/notifications", notificationHandler.GetUserNotifications)
+			user.GET("/notifications/unread-count", notificationHandler.GetUnreadCount)
+			user.GET("/notifications/stream", notificationHandler.StreamNotifications)
+			user.PUT("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+			user.POST("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+			user.DELETE("/notifications/:id", notificationHandler.DeleteNotification)
+			user.GET("/points/transactions", pointHandler.GetUserPointTransactions)
+			user.GET("/purchases", purchaseHandler.GetUserPurchases)
+			user.GET("/preferences", preferenceHandler.GetUserPreferences)
+			user.POST("/preferences", preferenceHandler.SetUserPreference)
+			user.PUT("/preferences", preferenceHandler.UpdateUserPreferences)
+			user.POST("/preferences/reset", preferenceHandler.ResetUserPreferences)
+			user.POST("/2fa/setup", authHandler.SetupTwoFactor)
+			user.POST("/2fa/enable", authHandler.EnableTwoFactor)
+			user.POST("/2fa/disable", authHandler.DisableTwoFactor)
+			user.POST("/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
+			user.POST("/email/verification", accountHandler.RequestEmailVerification)
+			user.GET("/oauth/consents", oauthHandler.GetConsents)
+			user.DELETE("/oauth/consents/:client_id", oauthHandler.RevokeConsent)
 		}
 
 		users := v1.Group("/users")
@@ -189,36 +407,93 @@ func setupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHand
 		{
 			admin.GET("/health", userHandler.GetSystemHealth)
 			admin.GET("/users", userHandler.GetUsers)
-			admin.GET("/users/:user_id", userHandler.GetUserDetails)
-			admin.POST("/users/:user_id/points", userHandler.AddPointsToUser)
-			admin.POST("/users/:user_id/notifications", userHandler.CreateNotificationForUser)
-			admin.POST("/points/expire", userHandler.ExpireUserPoints)
+			admin.GET("/users/:user_id", userDetailHandler.GetUserDetails)
+			admin.POST("/users/:user_id/points", adminPointHandler.AdjustUserPoints)
+			admin.POST("/users/:user_id/suspend", authHandler.SuspendUser)
+			admin.POST("/users/:user_id/reactivate", authHandler.ReactivateUser)
+			admin.GET("/users/:user_id/permissions", permissionHandler.GetUserPermissions)
+			admin.POST("/users/:user_id/scopes", permissionHandler.GrantUserScope)
+			admin.DELETE("/users/:user_id/scopes/:scope", permissionHandler.RevokeUserScope)
+
+			readRoles := authMiddleware.RequirePermission("admin.roles:read")
+			writeRoles := authMiddleware.RequirePermission("admin.roles:write")
+			admin.GET("/roles", readRoles, roleHandler.GetRoles)
+			admin.POST("/roles", writeRoles, roleHandler.CreateRole)
+			admin.GET("/roles/:role_id", readRoles, roleHandler.GetRole)
+			admin.PUT("/roles/:role_id", writeRoles, roleHandler.UpdateRole)
+			admin.DELETE("/roles/:role_id", writeRoles, roleHandler.DeleteRole)
+			admin.GET("/roles/:role_id/members", readRoles, roleHandler.GetRoleMembers)
+			admin.POST("/users/:user_id/roles", writeRoles, roleHandler.AssignUserRole)
+			admin.DELETE("/users/:user_id/roles/:role_id", writeRoles, roleHandler.RevokeUserRole)
+
+			readPartners := authMiddleware.RequirePermission("admin.partners:read")
+			writePartners := authMiddleware.RequirePermission("admin.partners:write")
+			admin.GET("/partner-keys", readPartners, partnerHandler.GetAPIKeys)
+			admin.POST("/partner-keys", writePartners, partnerHandler.CreateAPIKey)
+			admin.POST("/partner-keys/:key_id/rotate", writePartners, partnerHandler.RotateAPIKey)
+			admin.DELETE("/partner-keys/:key_id", writePartners, partnerHandler.RevokeAPIKey)
+			admin.PUT("/partners/:partner_id/quotas", writePartners, partnerHandler.SetQuota)
+
+			readOAuth := authMiddleware.RequirePermission("admin.oauth:read")
+			writeOAuth := authMiddleware.RequirePermission("admin.oauth:write")
+			admin.GET("/oauth/clients", readOAuth, oauthHandler.GetClients)
+			admin.POST("/oauth/clients", writeOAuth, oauthHandler.RegisterClient)
+			admin.DELETE("/oauth/clients/:client_id", writeOAuth, oauthHandler.DeleteClient)
+
+			admin.POST("/users/:user_id/notifications", notificationHandler.CreateNotificationForUser)
+			admin.POST("/points/expire", pointHandler.ExpireUserPoints)
+			admin.POST("/users/:user_id/tier/evaluate", tierHandler.EvaluateUserTier)
+
+			admin.GET("/tiers", tierHandler.GetTiers)
+			admin.POST("/tiers", tierHandler.CreateTier)
+			admin.POST("/tiers/evaluate", tierHandler.EvaluateTiers)
+			admin.GET("/tiers/:id", tierHandler.GetTier)
+			admin.PUT("/tiers/:id", tierHandler.UpdateTier)
+			admin.DELETE("/tiers/:id", tierHandler.DeleteTier)
+
+			admin.POST("/purchases", purchaseHandler.RecordPurchase)
+			admin.POST("/purchases/:id/refund", purchaseHandler.RefundPurchase)
 		}
 
 		fraud := v1.Group("/fraud")
-		fraud.Use(authMiddleware.RequireAuth(), authMiddleware.RequireRole("admin"))
+		fraud.Use(authMiddleware.RequireAuth(), authMiddleware.RequirePermission("fraud:read"))
 		{
-			fraud.POST("/blacklist/ip", fraudHandler.AddIPToBlacklist)
-			fraud.DELETE("/blacklist/ip/:ip", fraudHandler.RemoveIPFromBlacklist)
+			writeBlacklist := authMiddleware.RequirePermission("fraud.blacklist:write")
+			writeAllowlist := authMiddleware.RequirePermission("fraud.allowlist:write")
+			writeFraud := authMiddleware.RequirePermission("fraud:write")
+
+			fraud.POST("/blacklist/ip", writeBlacklist, fraudHandler.AddIPToBlacklist)
+			fraud.DELETE("/blacklist/ip/*ip", writeBlacklist, fraudHandler.RemoveIPFromBlacklist)
 			fraud.GET("/blacklist/ips", fraudHandler.GetBlacklistedIPs)
+			fraud.POST("/allowlist/ip", writeAllowlist, fraudHandler.AddIPToAllowlist)
+			fraud.DELETE("/allowlist/ip/*ip", writeAllowlist, fraudHandler.RemoveIPFromAllowlist)
+			fraud.GET("/allowlist/ips", fraudHandler.GetAllowlistedIPs)
 
 			fraud.GET("/security/events", fraudHandler.GetSecurityEvents)
-			fraud.POST("/security/events", fraudHandler.CreateSecurityEvent)
-			fraud.POST("/security/blacklist", fraudHandler.AddIPToBlacklist)
-			fraud.DELETE("/security/blacklist/:ip", fraudHandler.RemoveIPFromBlacklist)
+			fraud.POST("/security/events", writeFraud, fraudHandler.CreateSecurityEvent)
+			fraud.POST("/security/blacklist", writeBlacklist, fraudHandler.AddIPToBlacklist)
+			fraud.DELETE("/security/blacklist/*ip", writeBlacklist, fraudHandler.RemoveIPFromBlacklist)
 
-			fraud.POST("/rate/limits", fraudHandler.CreateRateLimitRule)
-			fraud.PUT("/rate/limits/:id", fraudHandler.UpdateRateLimitRule)
-			fraud.DELETE("/rate/limits/:id", fraudHandler.DeleteRateLimitRule)
+			fraud.POST("/rate/limits", writeFraud, fraudHandler.CreateRateLimitRule)
+			fraud.PUT("/rate/limits/:id", writeFraud, fraudHandler.UpdateRateLimitRule)
+			fraud.DELETE("/rate/limits/:id", writeFraud, fraudHandler.DeleteRateLimitRule)
 			fraud.GET("/rate/limits", fraudHandler.GetRateLimitRules)
+			fraud.GET("/rules", fraudHandler.GetFraudRules)
+			fraud.PUT("/rules/:name", writeFraud, fraudHandler.UpdateFraudRule)
+			fraud.POST("/analyze", fraudHandler.AnalyzeFraud)
 
 			fraud.GET("/sessions", fraudHandler.GetActiveSessions)
-			fraud.DELETE("/sessions/:sessionId", fraudHandler.DeactivateSession)
+			fraud.DELETE("/sessions/:sessionId", writeFraud, fraudHandler.DeactivateSession)
 
 			fraud.GET("/devices", fraudHandler.GetDevices)
-			fraud.PUT("/devices/:fingerprint/trust", fraudHandler.TrustDevice)
+			fraud.PUT("/devices/:fingerprint/trust", writeFraud, fraudHandler.TrustDevice)
 
-			fraud.POST("/cleanup", fraudHandler.CleanupExpiredData)
+			fraud.POST("/cleanup", writeFraud, fraudHandler.CleanupExpiredData)
+		}
+
+		integration := v1.Group("/integration")
+		{
+			integration.GET("/me", apiKeyMiddleware.RequireAPIKey("integration:read"), partnerHandler.GetCurrentPartner)
 		}
 
 		v1.GET("/stats", userHandler.GetUserStats)
@@ -246,6 +521,98 @@ func getJWTSecret() string {
 	return os.Getenv("JWT_SECRET")
 }
 
+func getJWTSigningAlgorithm() string {
+	algorithm := os.Getenv("JWT_SIGNING_ALG")
+	if algorithm == "" {
+		algorithm = service.SigningAlgorithmHS256
+	}
+	return algorithm
+}
+
+func getJWTKeySetFile() string {
+	return os.Getenv("JWT_KEYSET_FILE")
+}
+
+func getJWTKeyRotationInterval() time.Duration {
+	interval := os.Getenv("JWT_KEY_ROTATION_INTERVAL")
+	if interval == "" {
+		return 30 * 24 * time.Hour
+	}
+	val, err := time.ParseDuration(interval)
+	if err != nil {
+		return 30 * 24 * time.Hour
+	}
+	return val
+}
+
+func getJWTKeyOverlap() time.Duration {
+	overlap := os.Getenv("JWT_KEY_OVERLAP")
+	if overlap == "" {
+		return 24 * time.Hour
+	}
+	val, err := time.ParseDuration(overlap)
+	if err != nil || val < time.Hour {
+		return 24 * time.Hour
+	}
+	return val
+}
+
+func getJWTKeyPublishLead() time.Duration {
+	lead := os.Getenv("JWT_KEY_PUBLISH_LEAD")
+	if lead == "" {
+		return external.DefaultKeyPublishLead
+	}
+	val, err := time.ParseDuration(lead)
+	if err != nil || val < 0 {
+		return external.DefaultKeyPublishLead
+	}
+	return val
+}
+
+func getTwoFactorEncryptionKey() string {
+	return os.Getenv("TWO_FACTOR_ENCRYPTION_KEY")
+}
+
+func getAppBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return baseURL
+}
+
+func getSMTPHost() string {
+	return os.Getenv("SMTP_HOST")
+}
+
+func getSMTPPort() int {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		return 587
+	}
+	val, err := strconv.Atoi(port)
+	if err != nil {
+		return 587
+	}
+	return val
+}
+
+func getSMTPUsername() string {
+	return os.Getenv("SMTP_USERNAME")
+}
+
+func getSMTPPassword() string {
+	return os.Getenv("SMTP_PASSWORD")
+}
+
+func getSMTPFrom() string {
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "no-reply@example.com"
+	}
+	return from
+}
+
 func getPort() string {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -316,6 +683,103 @@ func getLoginRateLimit() int64 {
 	return val
 }
 
+func getRateLimitAlgorithm() string {
+	algorithm := os.Getenv("RATE_LIMIT_ALGORITHM")
+	if !entity.IsValidRateLimitAlgorithm(algorithm) {
+		return entity.RateLimitAlgorithmSlidingWindow
+	}
+	return algorithm
+}
+
+func startTierEvaluation(ctx context.Context, tierDomainService *service.TierDomainService, hour int) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(nextTierEvaluation(time.Now(), hour)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				result, err := tierDomainService.EvaluateAllTiers(ctx)
+				if err != nil {
+					log.Printf("❌ 会員ランクの一括評価に失敗しました: %v", err)
+					continue
+				}
+				log.Printf("✅ 会員ランクを一括評価しました (評価: %d, 昇格: %d, 降格: %d, 猶予開始: %d, 失敗: %d)",
+					result.Evaluated, result.Promoted, result.Demoted, result.GraceStart, result.Failed)
+			}
+		}
+	}()
+}
+
+func nextTierEvaluation(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func getTierEvaluationHour() int {
+	hour := os.Getenv("TIER_EVALUATION_HOUR")
+	if hour == "" {
+		return 3
+	}
+	val, err := strconv.Atoi(hour)
+	if err != nil || val < 0 || val > 23 {
+		return 3
+	}
+	return val
+}
+
+func getBlacklistSyncInterval() time.Duration {
+	interval := os.Getenv("BLACKLIST_SYNC_INTERVAL_SECONDS")
+	if interval == "" {
+		return 5 * time.Minute
+	}
+	val, err := strconv.Atoi(interval)
+	if err != nil || val <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(val) * time.Second
+}
+
+func getNotificationStreamHeartbeatInterval() time.Duration {
+	interval := os.Getenv("NOTIFICATION_STREAM_HEARTBEAT_SECONDS")
+	if interval == "" {
+		return 15 * time.Second
+	}
+	val, err := strconv.Atoi(interval)
+	if err != nil || val <= 0 {
+		return 15 * time.Second
+	}
+	return time.Duration(val) * time.Second
+}
+
+func getNotificationStreamMaxPerUser() int {
+	limit := os.Getenv("NOTIFICATION_STREAM_MAX_PER_USER")
+	if limit == "" {
+		return 3
+	}
+	val, err := strconv.Atoi(limit)
+	if err != nil || val <= 0 {
+		return 3
+	}
+	return val
+}
+
+func getAdminPointDailyBudget() int {
+	budget := os.Getenv("ADMIN_POINT_DAILY_BUDGET")
+	if budget == "" {
+		return 100000
+	}
+	val, err := strconv.Atoi(budget)
+	if err != nil || val <= 0 {
+		return 100000
+	}
+	return val
+}
+
 func getDBMaxRetries() int {
 	retries := os.Getenv("DB_MAX_RETRIES")
 	if retries == "" {
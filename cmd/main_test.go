@@ -128,3 +128,379 @@ func TestGetDSN(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSMTPPort(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 587,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "1025",
+			expected: 1025,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "invalid",
+			expected: 587,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "SMTP_PORT", tt.envValue)
+			defer cleanupEnv(t, "SMTP_PORT")
+
+			result := getSMTPPort()
+			if result != tt.expected {
+				t.Errorf("getSMTPPort() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetJWTKeyOverlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected time.Duration
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "6h",
+			expected: 6 * time.Hour,
+		},
+		{
+			name:     "アクセストークンの有効期限より短い場合はデフォルト値",
+			envValue: "30m",
+			expected: 24 * time.Hour,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "invalid",
+			expected: 24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "JWT_KEY_OVERLAP", tt.envValue)
+			defer cleanupEnv(t, "JWT_KEY_OVERLAP")
+
+			result := getJWTKeyOverlap()
+			if result != tt.expected {
+				t.Errorf("getJWTKeyOverlap() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetJWTKeyPublishLead(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected time.Duration
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "30m",
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "負の値の場合はデフォルト値",
+			envValue: "-1m",
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "invalid",
+			expected: 10 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "JWT_KEY_PUBLISH_LEAD", tt.envValue)
+			defer cleanupEnv(t, "JWT_KEY_PUBLISH_LEAD")
+
+			result := getJWTKeyPublishLead()
+			if result != tt.expected {
+				t.Errorf("getJWTKeyPublishLead() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetBlacklistSyncInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected time.Duration
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "60",
+			expected: time.Minute,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "0",
+			expected: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "BLACKLIST_SYNC_INTERVAL_SECONDS", tt.envValue)
+			defer cleanupEnv(t, "BLACKLIST_SYNC_INTERVAL_SECONDS")
+
+			result := getBlacklistSyncInterval()
+			if result != tt.expected {
+				t.Errorf("getBlacklistSyncInterval() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetNotificationStreamHeartbeatInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected time.Duration
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 15 * time.Second,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "30",
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "0以下の場合はデフォルト値",
+			envValue: "0",
+			expected: 15 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "NOTIFICATION_STREAM_HEARTBEAT_SECONDS", tt.envValue)
+			defer cleanupEnv(t, "NOTIFICATION_STREAM_HEARTBEAT_SECONDS")
+
+			result := getNotificationStreamHeartbeatInterval()
+			if result != tt.expected {
+				t.Errorf("getNotificationStreamHeartbeatInterval() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetNotificationStreamMaxPerUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 3,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "5",
+			expected: 5,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "many",
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "NOTIFICATION_STREAM_MAX_PER_USER", tt.envValue)
+			defer cleanupEnv(t, "NOTIFICATION_STREAM_MAX_PER_USER")
+
+			result := getNotificationStreamMaxPerUser()
+			if result != tt.expected {
+				t.Errorf("getNotificationStreamMaxPerUser() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetAdminPointDailyBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{
+			name:     "環境変数が設定されていない場合はデフォルト値",
+			envValue: "",
+			expected: 100000,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "5000",
+			expected: 5000,
+		},
+		{
+			name:     "0以下の場合はデフォルト値",
+			envValue: "0",
+			expected: 100000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "ADMIN_POINT_DAILY_BUDGET", tt.envValue)
+			defer cleanupEnv(t, "ADMIN_POINT_DAILY_BUDGET")
+
+			result := getAdminPointDailyBudget()
+			if result != tt.expected {
+				t.Errorf("getAdminPointDailyBudget() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetTierEvaluationHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: 3,
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "22",
+			expected: 22,
+		},
+		{
+			name:     "範囲外の場合はデフォルト値",
+			envValue: "24",
+			expected: 3,
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "midnight",
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "TIER_EVALUATION_HOUR", tt.envValue)
+			defer cleanupEnv(t, "TIER_EVALUATION_HOUR")
+
+			result := getTierEvaluationHour()
+			if result != tt.expected {
+				t.Errorf("getTierEvaluationHour() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextTierEvaluation(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		hour     int
+		expected time.Time
+	}{
+		{
+			name:     "当日の実行時刻前",
+			now:      time.Date(2025, 9, 17, 1, 30, 0, 0, time.UTC),
+			hour:     3,
+			expected: time.Date(2025, 9, 17, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "当日の実行時刻ちょうど",
+			now:      time.Date(2025, 9, 17, 3, 0, 0, 0, time.UTC),
+			hour:     3,
+			expected: time.Date(2025, 9, 18, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "当日の実行時刻後",
+			now:      time.Date(2025, 9, 30, 10, 0, 0, 0, time.UTC),
+			hour:     3,
+			expected: time.Date(2025, 10, 1, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := nextTierEvaluation(tt.now, tt.hour)
+			if !result.Equal(tt.expected) {
+				t.Errorf("nextTierEvaluation() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetRateLimitAlgorithm(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected string
+	}{
+		{
+			name:     "デフォルト値",
+			envValue: "",
+			expected: "sliding_window",
+		},
+		{
+			name:     "環境変数で設定された値",
+			envValue: "token_bucket",
+			expected: "token_bucket",
+		},
+		{
+			name:     "無効な値の場合はデフォルト値",
+			envValue: "leaky_bucket",
+			expected: "sliding_window",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnv(t, "RATE_LIMIT_ALGORITHM", tt.envValue)
+			defer cleanupEnv(t, "RATE_LIMIT_ALGORITHM")
+
+			result := getRateLimitAlgorithm()
+			if result != tt.expected {
+				t.Errorf("getRateLimitAlgorithm() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
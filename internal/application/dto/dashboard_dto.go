@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type DashboardStats struct {
+	TotalLogins        int64      `json:"total_logins"`
+	RecentFailedLogins int64      `json:"recent_failed_logins"`
+	LastLogin          *time.Time `json:"last_login"`
+	AccountCreated     time.Time  `json:"account_created"`
+	ProfileCompletion  int        `json:"profile_completion"`
+	SecurityScore      int        `json:"security_score"`
+}
+
+type DashboardActivity struct {
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	IPAddress   string    `json:"ip_address"`
+	Severity    string    `json:"severity"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type DashboardNotifications struct {
+	UnreadCount int64 `json:"unread_count"`
+	TotalCount  int64 `json:"total_count"`
+}
+
+type DashboardSecurity struct {
+	TwoFactorEnabled    bool       `json:"two_factor_enabled"`
+	LastPasswordChange  *time.Time `json:"last_password_change"`
+	TrustedDevicesCount int        `json:"trusted_devices_count"`
+}
+
+type DashboardResponse struct {
+	UserID           uint                   `json:"user_id"`
+	Stats            DashboardStats         `json:"stats"`
+	RecentActivities []DashboardActivity    `json:"recent_activities"`
+	Notifications    DashboardNotifications `json:"notifications"`
+	Security         DashboardSecurity      `json:"security"`
+	GeneratedAt      time.Time              `json:"generated_at"`
+}
+
+func NewDashboardResponseFromEntity(dashboard *entity.UserDashboard) DashboardResponse {
+	activities := make([]DashboardActivity, len(dashboard.RecentSecurityEvents))
+	for i, event := range dashboard.RecentSecurityEvents {
+		activities[i] = DashboardActivity{
+			Type:        event.EventType,
+			Description: event.Description,
+			IPAddress:   event.IPAddress,
+			Severity:    event.Severity,
+			Timestamp:   event.CreatedAt,
+		}
+	}
+
+	return DashboardResponse{
+		UserID: dashboard.UserID,
+		Stats: DashboardStats{
+			TotalLogins:        dashboard.TotalLogins,
+			RecentFailedLogins: dashboard.RecentFailedLogins,
+			LastLogin:          dashboard.LastLoginAt,
+			AccountCreated:     dashboard.AccountCreatedAt,
+			ProfileCompletion:  dashboard.ProfileCompletion,
+			SecurityScore:      dashboard.SecurityScore,
+		},
+		RecentActivities: activities,
+		Notifications: DashboardNotifications{
+			UnreadCount: dashboard.UnreadNotifications,
+			TotalCount:  dashboard.TotalNotifications,
+		},
+		Security: DashboardSecurity{
+			TwoFactorEnabled:    dashboard.TwoFactorEnabled,
+			LastPasswordChange:  dashboard.PasswordChangedAt,
+			TrustedDevicesCount: dashboard.TrustedDevicesCount,
+		},
+		GeneratedAt: dashboard.GeneratedAt,
+	}
+}
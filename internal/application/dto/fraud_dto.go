@@ -14,6 +14,7 @@ type CreateRateLimitRuleRequest struct {
 	Identifier  string `json:"identifier" binding:"required"`
 	MaxRequests int64  `json:"max_requests" binding:"required"`
 	WindowSize  int64  `json:"window_size" binding:"required"`
+	Algorithm   string `json:"algorithm"`
 }
 
 type UpdateRateLimitRuleRequest struct {
@@ -21,4 +22,19 @@ type UpdateRateLimitRuleRequest struct {
 	Identifier  string `json:"identifier"`
 	MaxRequests int64  `json:"max_requests"`
 	WindowSize  int64  `json:"window_size"`
+	Algorithm   string `json:"algorithm"`
+}
+
+type UpdateFraudRuleRequest struct {
+	Enabled       *bool    `json:"enabled"`
+	Weight        *float64 `json:"weight"`
+	Threshold     *int     `json:"threshold"`
+	WindowSeconds *int     `json:"window_seconds"`
+}
+
+type AnalyzeFraudRequest struct {
+	UserID    *uint  `json:"user_id"`
+	Email     string `json:"email"`
+	IPAddress string `json:"ip_address" binding:"required"`
+	UserAgent string `json:"user_agent"`
 }
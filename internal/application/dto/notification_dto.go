@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type NotificationQuery struct {
+	Page       int  `form:"page"`
+	Limit      int  `form:"limit"`
+	UnreadOnly bool `form:"unread_only"`
+}
+
+type CreateNotificationRequest struct {
+	Type     string          `json:"type" binding:"required"`
+	Title    string          `json:"title" binding:"required"`
+	Message  string          `json:"message" binding:"required"`
+	Priority string          `json:"priority"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type NotificationInfo struct {
+	ID        uint            `json:"id"`
+	UserID    uint            `json:"user_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Priority  string          `json:"priority"`
+	IsRead    bool            `json:"is_read"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationInfo `json:"notifications"`
+	UnreadCount   int64              `json:"unread_count"`
+	Pagination    Pagination         `json:"pagination"`
+}
+
+func NewNotificationInfoFromEntity(notification *entity.Notification) NotificationInfo {
+	info := NotificationInfo{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Priority:  notification.Priority,
+		IsRead:    notification.IsRead,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+	if notification.Data != nil && json.Valid([]byte(*notification.Data)) {
+		info.Data = json.RawMessage(*notification.Data)
+	}
+	return info
+}
+
+func NewNotificationListResponseFromEntities(notifications []*entity.Notification, unreadCount int64, page, limit int, total int64) NotificationListResponse {
+	infos := make([]NotificationInfo, len(notifications))
+	for i, notification := range notifications {
+		infos[i] = NewNotificationInfoFromEntity(notification)
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return NotificationListResponse{
+		Notifications: infos,
+		UnreadCount:   unreadCount,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
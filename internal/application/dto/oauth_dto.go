@@ -0,0 +1,180 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type RegisterOAuthClientRequest struct {
+	Name         string   `json:"name" binding:"required,max=255"`
+	RedirectURIs []string `json:"redirect_uris"`
+	GrantTypes   []string `json:"grant_types" binding:"required,min=1"`
+	Scopes       []string `json:"scopes" binding:"required,min=1"`
+	Confidential *bool    `json:"confidential"`
+}
+
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type"`
+	ClientID            string `form:"client_id" json:"client_id"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+type OAuthAuthorizeDecisionRequest struct {
+	OAuthAuthorizeRequest
+	Approve bool `json:"approve"`
+}
+
+// The token, introspection and revocation requests are form encoded as the
+// OAuth specifications require. Client credentials may instead arrive in the
+// Authorization header, which the handler copies into these fields.
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type OAuthIntrospectionRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+type OAuthRevocationRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+type OAuthClientResponse struct {
+	ClientID     string    `json:"client_id"`
+	Name         string    `json:"name"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	GrantTypes   []string  `json:"grant_types"`
+	Scopes       []string  `json:"scopes"`
+	Confidential bool      `json:"confidential"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RegisteredOAuthClientResponse struct {
+	OAuthClientResponse
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+type OAuthAuthorizationPromptResponse struct {
+	ClientID        string   `json:"client_id"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+	ConsentRequired bool     `json:"consent_required"`
+}
+
+type OAuthAuthorizationResponse struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+type OAuthIntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+}
+
+type OAuthConsentResponse struct {
+	ClientID  string    `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewOAuthClientResponseFromEntity(client *entity.OAuthClient) OAuthClientResponse {
+	return OAuthClientResponse{
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Confidential: client.Confidential,
+		CreatedAt:    client.CreatedAt,
+	}
+}
+
+func NewOAuthClientResponsesFromEntities(clients []*entity.OAuthClient) []OAuthClientResponse {
+	responses := make([]OAuthClientResponse, len(clients))
+	for i, client := range clients {
+		responses[i] = NewOAuthClientResponseFromEntity(client)
+	}
+	return responses
+}
+
+func NewRegisteredOAuthClientResponse(client *entity.OAuthClient, secret string) RegisteredOAuthClientResponse {
+	return RegisteredOAuthClientResponse{
+		OAuthClientResponse: NewOAuthClientResponseFromEntity(client),
+		ClientSecret:        secret,
+	}
+}
+
+func NewOAuthTokenResponse(tokenSet *entity.OAuthTokenSet) OAuthTokenResponse {
+	return OAuthTokenResponse{
+		AccessToken:  tokenSet.AccessToken,
+		TokenType:    tokenSet.TokenType,
+		ExpiresIn:    tokenSet.ExpiresIn,
+		RefreshToken: tokenSet.RefreshToken,
+		Scope:        entity.FormatOAuthScope(tokenSet.Scopes),
+	}
+}
+
+func NewOAuthIntrospectionResponse(info *entity.OAuthTokenInfo) OAuthIntrospectionResponse {
+	if !info.Active {
+		return OAuthIntrospectionResponse{Active: false}
+	}
+
+	response := OAuthIntrospectionResponse{
+		Active:    true,
+		Scope:     entity.FormatOAuthScope(info.Scopes),
+		ClientID:  info.ClientID,
+		TokenType: info.TokenType,
+		Sub:       info.Subject,
+	}
+	if !info.ExpiresAt.IsZero() {
+		response.Exp = info.ExpiresAt.Unix()
+	}
+	if !info.IssuedAt.IsZero() {
+		response.Iat = info.IssuedAt.Unix()
+	}
+	return response
+}
+
+func NewOAuthConsentResponsesFromEntities(consents []*entity.OAuthConsent) []OAuthConsentResponse {
+	responses := make([]OAuthConsentResponse, len(consents))
+	for i, consent := range consents {
+		responses[i] = OAuthConsentResponse{
+			ClientID:  consent.ClientID,
+			Scopes:    consent.Scopes,
+			CreatedAt: consent.CreatedAt,
+			UpdatedAt: consent.UpdatedAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type CreatePartnerAPIKeyRequest struct {
+	PartnerID    string   `json:"partner_id" binding:"required,max=255"`
+	PartnerName  string   `json:"partner_name" binding:"max=255"`
+	Permissions  []string `json:"permissions"`
+	LimitPerHour int      `json:"limit_per_hour" binding:"required,min=1"`
+	LimitPerDay  int      `json:"limit_per_day" binding:"required,min=1"`
+}
+
+type PartnerAPIKeysQuery struct {
+	PartnerID string `form:"partner_id"`
+}
+
+type SetPartnerQuotaRequest struct {
+	Endpoint     string `json:"endpoint" binding:"required,max=255"`
+	LimitPerHour int    `json:"limit_per_hour" binding:"required,min=1"`
+	LimitPerDay  int    `json:"limit_per_day" binding:"required,min=1"`
+}
+
+type PartnerAPIKeyResponse struct {
+	ID          uint      `json:"id"`
+	PartnerID   string    `json:"partner_id"`
+	PartnerName string    `json:"partner_name"`
+	KeyPrefix   string    `json:"key_prefix"`
+	Permissions []string  `json:"permissions"`
+	RateLimit   int       `json:"rate_limit"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type IssuedPartnerAPIKeyResponse struct {
+	PartnerAPIKeyResponse
+	APIKey string `json:"api_key"`
+}
+
+type PartnerQuotaResponse struct {
+	PartnerID    string `json:"partner_id"`
+	Endpoint     string `json:"endpoint"`
+	LimitPerHour int    `json:"limit_per_hour"`
+	LimitPerDay  int    `json:"limit_per_day"`
+}
+
+type CurrentPartnerResponse struct {
+	PartnerID   string   `json:"partner_id"`
+	PartnerName string   `json:"partner_name"`
+	KeyPrefix   string   `json:"key_prefix"`
+	Permissions []string `json:"permissions"`
+}
+
+func NewPartnerAPIKeyResponseFromEntity(key *entity.PartnerAPIKey) PartnerAPIKeyResponse {
+	return PartnerAPIKeyResponse{
+		ID:          key.ID,
+		PartnerID:   key.PartnerID,
+		PartnerName: key.PartnerName,
+		KeyPrefix:   key.KeyPrefix,
+		Permissions: key.Permissions,
+		RateLimit:   key.RateLimit,
+		Enabled:     key.Enabled,
+		CreatedAt:   key.CreatedAt,
+		UpdatedAt:   key.UpdatedAt,
+	}
+}
+
+func NewPartnerAPIKeyResponsesFromEntities(keys []*entity.PartnerAPIKey) []PartnerAPIKeyResponse {
+	responses := make([]PartnerAPIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = NewPartnerAPIKeyResponseFromEntity(key)
+	}
+	return responses
+}
+
+func NewIssuedPartnerAPIKeyResponse(key *entity.PartnerAPIKey, plaintext string) IssuedPartnerAPIKeyResponse {
+	return IssuedPartnerAPIKeyResponse{
+		PartnerAPIKeyResponse: NewPartnerAPIKeyResponseFromEntity(key),
+		APIKey:                plaintext,
+	}
+}
+
+func NewPartnerQuotaResponseFromEntity(quota *entity.PartnerQuota) PartnerQuotaResponse {
+	return PartnerQuotaResponse{
+		PartnerID:    quota.PartnerID,
+		Endpoint:     quota.Endpoint,
+		LimitPerHour: quota.LimitPerHour,
+		LimitPerDay:  quota.LimitPerDay,
+	}
+}
+
+func NewCurrentPartnerResponse(key *entity.PartnerAPIKey) CurrentPartnerResponse {
+	return CurrentPartnerResponse{
+		PartnerID:   key.PartnerID,
+		PartnerName: key.PartnerName,
+		KeyPrefix:   key.KeyPrefix,
+		Permissions: key.Grants().Strings(),
+	}
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type GrantScopeRequest struct {
+	Scope     string    `json:"scope" binding:"required"`
+	ExpiresAt time.Time `json:"expires_at" binding:"required"`
+}
+
+type UserPermissionsResponse struct {
+	UserID      uint     `json:"user_id"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserScopeResponse struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	Scope     string     `json:"scope"`
+	Grants    string     `json:"grants"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewUserPermissionsResponse(userID uint, permissions entity.PermissionSet) UserPermissionsResponse {
+	return UserPermissionsResponse{
+		UserID:      userID,
+		Permissions: permissions.Strings(),
+	}
+}
+
+func NewUserScopeResponseFromEntity(userScope *entity.UserScope) UserScopeResponse {
+	response := UserScopeResponse{
+		ID:        userScope.ID,
+		UserID:    userScope.UserID,
+		ExpiresAt: userScope.ExpiresAt,
+		CreatedAt: userScope.CreatedAt,
+	}
+	if userScope.Scope != nil {
+		response.Scope = userScope.Scope.Name
+		response.Grants = userScope.Scope.Grant().String()
+	}
+	return response
+}
@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PointTransactionQuery struct {
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Type  string `form:"type"`
+	From  string `form:"from"`
+	To    string `form:"to"`
+}
+
+type PointTransactionInfo struct {
+	ID            uint       `json:"id"`
+	Type          string     `json:"type"`
+	Points        int        `json:"points"`
+	BalanceAfter  int        `json:"balance_after"`
+	Description   string     `json:"description"`
+	ReferenceType string     `json:"reference_type,omitempty"`
+	ReferenceID   *uint      `json:"reference_id,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type PointSummaryInfo struct {
+	CurrentBalance int `json:"current_balance"`
+	TotalEarned    int `json:"total_earned"`
+	TotalSpent     int `json:"total_spent"`
+}
+
+type PointTransactionListResponse struct {
+	Transactions []PointTransactionInfo `json:"transactions"`
+	Summary      PointSummaryInfo       `json:"summary"`
+	Pagination   Pagination             `json:"pagination"`
+}
+
+func NewPointTransactionInfoFromEntity(transaction *entity.PointTransaction) PointTransactionInfo {
+	return PointTransactionInfo{
+		ID:            transaction.ID,
+		Type:          transaction.Type,
+		Points:        transaction.Points,
+		BalanceAfter:  transaction.BalanceAfter,
+		Description:   transaction.Description,
+		ReferenceType: transaction.ReferenceType,
+		ReferenceID:   transaction.ReferenceID,
+		ExpiresAt:     transaction.ExpiresAt,
+		CreatedAt:     transaction.CreatedAt,
+	}
+}
+
+func NewPointTransactionListResponseFromEntities(transactions []*entity.PointTransaction, summary *entity.PointSummary, page, limit int, total int64) PointTransactionListResponse {
+	infos := make([]PointTransactionInfo, len(transactions))
+	for i, transaction := range transactions {
+		infos[i] = NewPointTransactionInfoFromEntity(transaction)
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return PointTransactionListResponse{
+		Transactions: infos,
+		Summary: PointSummaryInfo{
+			CurrentBalance: summary.Balance,
+			TotalEarned:    summary.TotalEarned,
+			TotalSpent:     summary.TotalSpent,
+		},
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+type ExpirePointsRequest struct {
+	ExpirationDate string `json:"expiration_date"`
+	DryRun         bool   `json:"dry_run"`
+}
+
+type PointExpirationDetail struct {
+	UserID          uint `json:"user_id"`
+	ExpiredPoints   int  `json:"expired_points"`
+	RemainingPoints int  `json:"remaining_points"`
+}
+
+type PointExpirationResponse struct {
+	TotalUsersAffected int                     `json:"total_users_affected"`
+	TotalPointsExpired int                     `json:"total_points_expired"`
+	ExpirationDate     time.Time               `json:"expiration_date"`
+	DryRun             bool                    `json:"dry_run"`
+	ProcessedBy        uint                    `json:"processed_by"`
+	ProcessedAt        time.Time               `json:"processed_at"`
+	Details            []PointExpirationDetail `json:"details"`
+}
+
+func NewPointExpirationResponseFromEntity(result *entity.PointExpirationResult, processedBy uint) PointExpirationResponse {
+	details := make([]PointExpirationDetail, len(result.Details))
+	for i, detail := range result.Details {
+		details[i] = PointExpirationDetail{
+			UserID:          detail.UserID,
+			ExpiredPoints:   detail.ExpiredPoints,
+			RemainingPoints: detail.RemainingPoints,
+		}
+	}
+
+	return PointExpirationResponse{
+		TotalUsersAffected: result.TotalUsersAffected,
+		TotalPointsExpired: result.TotalPointsExpired,
+		ExpirationDate:     result.AsOf,
+		DryRun:             result.DryRun,
+		ProcessedBy:        processedBy,
+		ProcessedAt:        time.Now(),
+		Details:            details,
+	}
+}
+
+type AdminPointAdjustmentRequest struct {
+	Points      int    `json:"points" binding:"required"`
+	Description string `json:"description" binding:"required"`
+	Type        string `json:"type"`
+}
+
+type AdminPointAdjustmentResponse struct {
+	UserID               uint      `json:"user_id"`
+	Points               int       `json:"points"`
+	Type                 string    `json:"type"`
+	Description          string    `json:"description"`
+	NewBalance           int       `json:"new_balance"`
+	TransactionID        uint      `json:"transaction_id"`
+	RemainingDailyBudget int       `json:"remaining_daily_budget"`
+	AdjustedBy           uint      `json:"adjusted_by"`
+	Timestamp            time.Time `json:"timestamp"`
+}
+
+func NewAdminPointAdjustmentResponseFromEntities(action *entity.AdminAction, transaction *entity.PointTransaction, remainingBudget int) AdminPointAdjustmentResponse {
+	return AdminPointAdjustmentResponse{
+		UserID:               transaction.UserID,
+		Points:               transaction.Points,
+		Type:                 action.ActionType,
+		Description:          transaction.Description,
+		NewBalance:           transaction.BalanceAfter,
+		TransactionID:        transaction.ID,
+		RemainingDailyBudget: remainingBudget,
+		AdjustedBy:           action.AdminUserID,
+		Timestamp:            transaction.CreatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+
+type PreferenceQuery struct {
+	Category string `form:"category"`
+}
+
+type SetPreferenceRequest struct {
+	Key   string      `json:"key" binding:"required"`
+	Value interface{} `json:"value"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences map[string]interface{} `json:"preferences" binding:"required"`
+}
+
+type ResetPreferencesRequest struct {
+	Keys []string `json:"keys"`
+}
+
+type PreferenceInfo struct {
+	Key           string      `json:"key"`
+	Category      string      `json:"category"`
+	Type          string      `json:"type"`
+	Value         interface{} `json:"value"`
+	DefaultValue  interface{} `json:"default_value"`
+	AllowedValues []string    `json:"allowed_values,omitempty"`
+	Min           *int        `json:"min,omitempty"`
+	Max           *int        `json:"max,omitempty"`
+	IsDefault     bool        `json:"is_default"`
+}
+
+type PreferencesResponse struct {
+	Preferences map[string]interface{} `json:"preferences"`
+	Settings    []PreferenceInfo       `json:"settings"`
+}
+
+func NewPreferencesResponseFromEntities(values []entity.PreferenceValue) PreferencesResponse {
+	response := PreferencesResponse{
+		Preferences: make(map[string]interface{}, len(values)),
+		Settings:    make([]PreferenceInfo, len(values)),
+	}
+	for i, value := range values {
+		definition := value.Definition
+		info := PreferenceInfo{
+			Key:           definition.Key,
+			Category:      definition.Category,
+			Type:          definition.Type,
+			Value:         value.Value,
+			DefaultValue:  definition.Default,
+			AllowedValues: definition.AllowedValues,
+			IsDefault:     value.IsDefault,
+		}
+		if definition.Type == entity.PreferenceTypeInt {
+			info.Min = &definition.Min
+			info.Max = &definition.Max
+		}
+		response.Preferences[definition.Key] = value.Value
+		response.Settings[i] = info
+	}
+	return response
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type RecordPurchaseRequest struct {
+	PurchaseID  string  `json:"purchase_id" binding:"required"`
+	UserID      uint    `json:"user_id" binding:"required"`
+	StoreID     uint    `json:"store_id" binding:"required"`
+	TotalAmount float64 `json:"total_amount" binding:"required"`
+}
+
+type PurchaseQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type PurchaseInfo struct {
+	ID                  string     `json:"id"`
+	UserID              uint       `json:"user_id"`
+	StoreID             uint       `json:"store_id"`
+	TotalAmount         float64    `json:"total_amount"`
+	ChargedAmount       float64    `json:"charged_amount"`
+	TierDiscountApplied float64    `json:"tier_discount_applied"`
+	PointsEarned        int        `json:"points_earned"`
+	Status              string     `json:"status"`
+	RefundedAt          *time.Time `json:"refunded_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+type PurchaseListResponse struct {
+	Purchases  []PurchaseInfo `json:"purchases"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+func NewPurchaseInfoFromEntity(purchase *entity.Purchase) PurchaseInfo {
+	return PurchaseInfo{
+		ID:                  purchase.ID,
+		UserID:              purchase.UserID,
+		StoreID:             purchase.StoreID,
+		TotalAmount:         purchase.TotalAmount,
+		ChargedAmount:       purchase.ChargedAmount(),
+		TierDiscountApplied: purchase.TierDiscountApplied,
+		PointsEarned:        purchase.PointsEarned,
+		Status:              purchase.Status,
+		RefundedAt:          purchase.RefundedAt,
+		CreatedAt:           purchase.CreatedAt,
+	}
+}
+
+func NewPurchaseListResponseFromEntities(purchases []*entity.Purchase, page, limit int, total int64) PurchaseListResponse {
+	infos := make([]PurchaseInfo, len(purchases))
+	for i, purchase := range purchases {
+		infos[i] = NewPurchaseInfoFromEntity(purchase)
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+
+	return PurchaseListResponse{
+		Purchases: infos,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleRequest struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleRequest struct {
+	RoleID uint `json:"role_id" binding:"required"`
+}
+
+type RoleMembersQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type RoleResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	IsSystem    bool      `json:"is_system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type RoleMembersResponse struct {
+	Role       RoleResponse `json:"role"`
+	Members    []UserInfo   `json:"members"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+func NewRoleResponseFromEntity(role *entity.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+		IsSystem:    role.IsSystem(),
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func NewRoleResponsesFromEntities(roles []*entity.Role) []RoleResponse {
+	responses := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = NewRoleResponseFromEntity(role)
+	}
+	return responses
+}
+
+func NewRoleMembersResponse(role *entity.Role, members []*entity.User, page, limit int, total int64) RoleMembersResponse {
+	infos := make([]UserInfo, len(members))
+	for i, member := range members {
+		infos[i] = NewUserInfoFromEntity(member)
+	}
+
+	return RoleMembersResponse{
+		Role:    NewRoleResponseFromEntity(role),
+		Members: infos,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		},
+	}
+}
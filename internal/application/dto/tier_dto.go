@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type TierRequirementsRequest struct {
+	MinPoints        int     `json:"min_points"`
+	MinSpent         float64 `json:"min_spent"`
+	ActiveWithinDays int     `json:"active_within_days"`
+}
+
+type CreateTierRequest struct {
+	Name         string                  `json:"name" binding:"required"`
+	Level        int                     `json:"level" binding:"required"`
+	Description  string                  `json:"description"`
+	Benefits     json.RawMessage         `json:"benefits"`
+	Requirements TierRequirementsRequest `json:"requirements"`
+}
+
+type UpdateTierRequest struct {
+	Name         *string                  `json:"name"`
+	Level        *int                     `json:"level"`
+	Description  *string                  `json:"description"`
+	Benefits     json.RawMessage          `json:"benefits"`
+	Requirements *TierRequirementsRequest `json:"requirements"`
+	IsActive     *bool                    `json:"is_active"`
+}
+
+type TierInfo struct {
+	ID           uint            `json:"id"`
+	Name         string          `json:"name"`
+	Level        int             `json:"level"`
+	Description  string          `json:"description"`
+	Benefits     json.RawMessage `json:"benefits,omitempty"`
+	Requirements json.RawMessage `json:"requirements,omitempty"`
+	IsActive     bool            `json:"is_active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+type TierEvaluationResponse struct {
+	Evaluated  int `json:"evaluated"`
+	Promoted   int `json:"promoted"`
+	Demoted    int `json:"demoted"`
+	GraceStart int `json:"grace_started"`
+	Failed     int `json:"failed"`
+}
+
+type TierChangeResponse struct {
+	UserID   uint   `json:"user_id"`
+	Changed  bool   `json:"changed"`
+	FromTier string `json:"from_tier,omitempty"`
+	ToTier   string `json:"to_tier,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func NewTierInfoFromEntity(tier *entity.MembershipTier) TierInfo {
+	info := TierInfo{
+		ID:          tier.ID,
+		Name:        tier.Name,
+		Level:       tier.Level,
+		Description: tier.Description,
+		IsActive:    tier.IsActive,
+		CreatedAt:   tier.CreatedAt,
+		UpdatedAt:   tier.UpdatedAt,
+	}
+	if json.Valid([]byte(tier.Benefits)) {
+		info.Benefits = json.RawMessage(tier.Benefits)
+	}
+	if json.Valid([]byte(tier.Requirements)) {
+		info.Requirements = json.RawMessage(tier.Requirements)
+	}
+	return info
+}
+
+func NewTierInfoListFromEntities(tiers []*entity.MembershipTier) []TierInfo {
+	infos := make([]TierInfo, len(tiers))
+	for i, tier := range tiers {
+		infos[i] = NewTierInfoFromEntity(tier)
+	}
+	return infos
+}
+
+func NewTierEvaluationResponseFromEntity(result *entity.TierEvaluationResult) TierEvaluationResponse {
+	return TierEvaluationResponse{
+		Evaluated:  result.Evaluated,
+		Promoted:   result.Promoted,
+		Demoted:    result.Demoted,
+		GraceStart: result.GraceStart,
+		Failed:     result.Failed,
+	}
+}
+
+func NewTierChangeResponseFromEntity(userID uint, change *entity.TierChange) TierChangeResponse {
+	response := TierChangeResponse{UserID: userID}
+	if change == nil {
+		return response
+	}
+
+	response.Changed = true
+	response.ToTier = change.ToTier.Name
+	response.Reason = change.Reason
+	if change.FromTier != nil {
+		response.FromTier = change.FromTier.Name
+	}
+	return response
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
 
@@ -87,3 +89,148 @@ func NewHealthCheckResponse(status map[string]string) HealthCheckResponse {
 
 	return response
 }
+
+type UserProfileInfo struct {
+	FirstName         string     `json:"first_name"`
+	LastName          string     `json:"last_name"`
+	PhoneNumber       string     `json:"phone_number"`
+	DateOfBirth       *time.Time `json:"date_of_birth"`
+	Gender            string     `json:"gender"`
+	Address           *string    `json:"address"`
+	Avatar            string     `json:"avatar"`
+	Bio               string     `json:"bio"`
+	IsVerified        bool       `json:"is_verified"`
+	ProfileCompletion int        `json:"profile_completion"`
+}
+
+type UserMembershipInfo struct {
+	TierID         uint       `json:"tier_id"`
+	Points         int        `json:"points"`
+	TotalSpent     float64    `json:"total_spent"`
+	JoinedAt       time.Time  `json:"joined_at"`
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	IsActive       bool       `json:"is_active"`
+}
+
+type LoginAttemptInfo struct {
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Success    bool      `json:"success"`
+	FailReason string    `json:"fail_reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type SecurityEventInfo struct {
+	ID          uint      `json:"id"`
+	EventType   string    `json:"event_type"`
+	Description string    `json:"description"`
+	IPAddress   string    `json:"ip_address"`
+	Severity    string    `json:"severity"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DeviceInfo struct {
+	ID         uint      `json:"id"`
+	DeviceInfo *string   `json:"device_info"`
+	IsTrusted  bool      `json:"is_trusted"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+type SessionInfo struct {
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	IsActive  bool      `json:"is_active"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserDetailResponse struct {
+	User                UserInfo            `json:"user"`
+	Profile             *UserProfileInfo    `json:"profile"`
+	Membership          *UserMembershipInfo `json:"membership"`
+	Roles               []string            `json:"roles"`
+	LoginAttempts       []LoginAttemptInfo  `json:"login_attempts"`
+	SecurityEvents      []SecurityEventInfo `json:"security_events"`
+	Devices             []DeviceInfo        `json:"devices"`
+	Sessions            []SessionInfo       `json:"sessions"`
+	UnavailableSections []string            `json:"unavailable_sections"`
+}
+
+func NewUserDetailResponse(detail *entity.UserDetail) UserDetailResponse {
+	response := UserDetailResponse{
+		User:                NewUserInfoFromEntity(detail.User),
+		UnavailableSections: detail.UnavailableSections(),
+	}
+
+	if profile := detail.Profile; profile != nil {
+		response.Profile = &UserProfileInfo{
+			FirstName:         profile.FirstName,
+			LastName:          profile.LastName,
+			PhoneNumber:       profile.PhoneNumber,
+			DateOfBirth:       profile.DateOfBirth,
+			Gender:            profile.Gender,
+			Address:           profile.Address,
+			Avatar:            profile.Avatar,
+			Bio:               profile.Bio,
+			IsVerified:        profile.IsVerified,
+			ProfileCompletion: profile.CompletionRate(),
+		}
+	}
+
+	if membership := detail.Membership; membership != nil {
+		response.Membership = &UserMembershipInfo{
+			TierID:         membership.TierID,
+			Points:         membership.Points,
+			TotalSpent:     membership.TotalSpent,
+			JoinedAt:       membership.JoinedAt,
+			LastActivityAt: membership.LastActivityAt,
+			IsActive:       membership.IsActive,
+		}
+	}
+
+	for _, role := range detail.Roles {
+		response.Roles = append(response.Roles, role.Name)
+	}
+
+	for _, attempt := range detail.LoginAttempts {
+		response.LoginAttempts = append(response.LoginAttempts, LoginAttemptInfo{
+			IPAddress:  attempt.IPAddress,
+			UserAgent:  attempt.UserAgent,
+			Success:    attempt.Success,
+			FailReason: attempt.FailReason,
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+
+	for _, event := range detail.SecurityEvents {
+		response.SecurityEvents = append(response.SecurityEvents, SecurityEventInfo{
+			ID:          event.ID,
+			EventType:   event.EventType,
+			Description: event.Description,
+			IPAddress:   event.IPAddress,
+			Severity:    event.Severity,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+
+	for _, device := range detail.Devices {
+		response.Devices = append(response.Devices, DeviceInfo{
+			ID:         device.ID,
+			DeviceInfo: device.DeviceInfo,
+			IsTrusted:  device.IsTrusted,
+			LastSeenAt: device.LastSeenAt,
+		})
+	}
+
+	for _, session := range detail.Sessions {
+		response.Sessions = append(response.Sessions, SessionInfo{
+			IPAddress: session.IPAddress,
+			UserAgent: session.UserAgent,
+			IsActive:  session.IsActive,
+			ExpiresAt: session.ExpiresAt,
+			CreatedAt: session.CreatedAt,
+		})
+	}
+
+	return response
+}
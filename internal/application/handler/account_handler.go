@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountUsecase usecase.AccountUsecaseInterface
+}
+
+func NewAccountHandler(accountUsecase usecase.AccountUsecaseInterface) *AccountHandler {
+	return &AccountHandler{
+		accountUsecase: accountUsecase,
+	}
+}
+
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.accountUsecase.RequestPasswordReset(c.Request.Context(), usecase.ForgotPasswordRequest{Email: req.Email}, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondAccountError(c, err, "Failed to request password reset")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	usecaseReq := usecase.ResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := h.accountUsecase.ResetPassword(c.Request.Context(), usecaseReq, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondAccountError(c, err, "Failed to reset password")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+func (h *AccountHandler) RequestEmailVerification(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDUint, ok := userID.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	if err := h.accountUsecase.RequestEmailVerification(c.Request.Context(), userIDUint); err != nil {
+		respondAccountError(c, err, "Failed to send verification email")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
+
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.VerifyEmail(c.Request.Context(), usecase.VerifyEmailRequest{Token: req.Token}, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondAccountError(c, err, "Failed to verify email")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+func respondAccountError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "invalid token", "token expired":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+	case "password is too weak":
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Password is too weak"})
+	case "too many requests":
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, please try again later"})
+	case "email already verified":
+		c.JSON(http.StatusConflict, gin.H{"error": "Email already verified"})
+	case "user not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
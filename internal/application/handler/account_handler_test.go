@@ -0,0 +1,193 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAccountUsecase) RequestPasswordReset(ctx context.Context, req usecase.ForgotPasswordRequest, ipAddress, userAgent string) error {
+	args := m.Called(ctx, req, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) ResetPassword(ctx context.Context, req usecase.ResetPasswordRequest, ipAddress, userAgent string) error {
+	args := m.Called(ctx, req, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) RequestEmailVerification(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) VerifyEmail(ctx context.Context, req usecase.VerifyEmailRequest, ipAddress, userAgent string) error {
+	args := m.Called(ctx, req, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func TestAccountHandlerForgotPassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockAccountUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "正常なリクエスト",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				mockUsecase.On("RequestPasswordReset", mock.Anything, usecase.ForgotPasswordRequest{Email: "test@example.com"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "リクエスト回数超過",
+			requestBody: map[string]interface{}{"email": "test@example.com"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				mockUsecase.On("RequestPasswordReset", mock.Anything, usecase.ForgotPasswordRequest{Email: "test@example.com"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(service.ErrTooManyRequests)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:        "不正なメールアドレス",
+			requestBody: map[string]interface{}{"email": "invalid"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				// invalid request body
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAccountUsecase)
+			tt.setupMock(mockUsecase)
+
+			accountHandler := handler.NewAccountHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/password/forgot", accountHandler.ForgotPassword)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/password/forgot", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountHandlerResetPassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockAccountUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "正常なパスワード再設定",
+			requestBody: map[string]interface{}{"token": "token", "new_password": "newpassword123"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				req := usecase.ResetPasswordRequest{Token: "token", NewPassword: "newpassword123"}
+				mockUsecase.On("ResetPassword", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "期限切れトークン",
+			requestBody: map[string]interface{}{"token": "token", "new_password": "newpassword123"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				req := usecase.ResetPasswordRequest{Token: "token", NewPassword: "newpassword123"}
+				mockUsecase.On("ResetPassword", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(service.ErrTokenExpired)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "弱いパスワード",
+			requestBody: map[string]interface{}{"token": "token", "new_password": "abcdef"},
+			setupMock: func(mockUsecase *MockAccountUsecase) {
+				req := usecase.ResetPasswordRequest{Token: "token", NewPassword: "abcdef"}
+				mockUsecase.On("ResetPassword", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(entity.ErrWeakPassword)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAccountUsecase)
+			tt.setupMock(mockUsecase)
+
+			accountHandler := handler.NewAccountHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/password/reset", accountHandler.ResetPassword)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/password/reset", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountHandlerRequestEmailVerification(t *testing.T) {
+	mockUsecase := new(MockAccountUsecase)
+	mockUsecase.On("RequestEmailVerification", mock.Anything, uint(1)).Return(service.ErrEmailAlreadyVerified)
+
+	accountHandler := handler.NewAccountHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/email/verification", func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		accountHandler.RequestEmailVerification(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/email/verification", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAccountHandlerVerifyEmail(t *testing.T) {
+	mockUsecase := new(MockAccountUsecase)
+	mockUsecase.On("VerifyEmail", mock.Anything, usecase.VerifyEmailRequest{Token: "token"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+
+	accountHandler := handler.NewAccountHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/email/verify", accountHandler.VerifyEmail)
+
+	body, _ := json.Marshal(map[string]interface{}{"token": "token"})
+	req := httptest.NewRequest(http.MethodPost, "/email/verify", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
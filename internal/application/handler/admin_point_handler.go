@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type AdminPointHandler struct {
+	adminPointUsecase usecase.AdminPointUsecaseInterface
+}
+
+func NewAdminPointHandler(adminPointUsecase usecase.AdminPointUsecaseInterface) *AdminPointHandler {
+	return &AdminPointHandler{
+		adminPointUsecase: adminPointUsecase,
+	}
+}
+
+func (h *AdminPointHandler) AdjustUserPoints(c *gin.Context) {
+	adminID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+	if idempotencyKey == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": IdempotencyKeyHeader + " header is required"})
+		return
+	}
+
+	var req dto.AdminPointAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	adjustment, err := h.adminPointUsecase.AdjustUserPoints(c.Request.Context(), adminID, uint(userID), &req, idempotencyKey)
+	if err != nil {
+		respondAdminPointError(c, err)
+		return
+	}
+
+	data := dto.NewAdminPointAdjustmentResponseFromEntities(adjustment.Action, adjustment.Transaction, adjustment.RemainingBudget)
+	if adjustment.Replayed {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Point adjustment already applied",
+			"data":    data,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Points adjusted successfully",
+		"data":    data,
+	})
+}
+
+func respondAdminPointError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrAdminPointBudgetExceeded):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInsufficientPoints):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "already"):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Failed to adjust points: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to adjust points"})
+	}
+}
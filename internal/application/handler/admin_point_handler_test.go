@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAdminPointUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAdminPointUsecase) AdjustUserPoints(ctx context.Context, adminUserID, userID uint, req *dto.AdminPointAdjustmentRequest, idempotencyKey string) (*service.AdminPointAdjustment, error) {
+	args := m.Called(ctx, adminUserID, userID, req, idempotencyKey)
+	if adjustment, ok := args.Get(0).(*service.AdminPointAdjustment); ok {
+		return adjustment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestAdminPointHandlerAdjustUserPoints(t *testing.T) {
+	transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "補填")
+	transaction.ID = 5
+	transaction.BalanceAfter = 1600
+	adjustment := &service.AdminPointAdjustment{
+		Action:          entity.NewAdminPointAction(9, 1, 100, "補填", "key-1"),
+		Transaction:     transaction,
+		RemainingBudget: 900,
+	}
+	replayed := *adjustment
+	replayed.Replayed = true
+
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		requestBody    string
+		setupMock      func(*MockAdminPointUsecase)
+		expectedStatus int
+	}{
+		{
+			name:           "ポイントを付与する",
+			idempotencyKey: "key-1",
+			requestBody:    `{"points":100,"description":"補填"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), &dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填"}, "key-1").
+					Return(adjustment, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "同じキーの再送",
+			idempotencyKey: "key-1",
+			requestBody:    `{"points":100,"description":"補填"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), mock.Anything, "key-1").Return(&replayed, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "冪等キーがない",
+			requestBody:    `{"points":100,"description":"補填"}`,
+			setupMock:      func(m *MockAdminPointUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "説明がない",
+			idempotencyKey: "key-2",
+			requestBody:    `{"points":100}`,
+			setupMock:      func(m *MockAdminPointUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "キーの使い回し",
+			idempotencyKey: "key-1",
+			requestBody:    `{"points":500,"description":"補填"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), mock.Anything, "key-1").
+					Return(nil, fmt.Errorf("failed to adjust points: %w", entity.ErrIdempotencyKeyConflict))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "予算超過",
+			idempotencyKey: "key-3",
+			requestBody:    `{"points":100,"description":"補填"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), mock.Anything, "key-3").
+					Return(nil, fmt.Errorf("failed to adjust points: %w", entity.ErrAdminPointBudgetExceeded))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "残高不足",
+			idempotencyKey: "key-4",
+			requestBody:    `{"points":100,"description":"訂正","type":"admin_deduct"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), mock.Anything, "key-4").
+					Return(nil, fmt.Errorf("failed to adjust points: %w", entity.ErrInsufficientPoints))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "会員情報が存在しない",
+			idempotencyKey: "key-5",
+			requestBody:    `{"points":100,"description":"補填"}`,
+			setupMock: func(m *MockAdminPointUsecase) {
+				m.On("AdjustUserPoints", mock.Anything, uint(9), uint(1), mock.Anything, "key-5").
+					Return(nil, fmt.Errorf("failed to adjust points: %w", service.ErrMembershipNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAdminPointUsecase)
+			tt.setupMock(mockUsecase)
+
+			adminPointHandler := handler.NewAdminPointHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/users/:user_id/points", withUserID(9, adminPointHandler.AdjustUserPoints))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/1/points", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.idempotencyKey != "" {
+				req.Header.Set(handler.IdempotencyKeyHeader, tt.idempotencyKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response struct {
+					Data dto.AdminPointAdjustmentResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 1600, response.Data.NewBalance)
+				assert.Equal(t, 900, response.Data.RemainingDailyBudget)
+				assert.Equal(t, uint(9), response.Data.AdjustedBy)
+				assert.Equal(t, entity.AdminActionPointGrant, response.Data.Type)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -85,6 +89,58 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if response.MFARequired {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Two-factor authentication required",
+			"data": dto.MFAChallengeResponse{
+				MFARequired: true,
+				MFAToken:    response.MFAToken,
+				ExpiresIn:   response.ExpiresIn,
+			},
+		})
+		return
+	}
+
+	dtoResponse := dto.LoginResponse{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		ExpiresIn:    response.ExpiresIn,
+		User:         dto.NewUserInfoFromEntity(response.User),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Login successful",
+		"data":    dtoResponse,
+	})
+}
+
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.VerifyTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	usecaseReq := usecase.VerifyTwoFactorRequest{
+		MFAToken: req.MFAToken,
+		Code:     req.Code,
+	}
+
+	response, err := h.authUsecase.VerifyTwoFactor(c.Request.Context(), usecaseReq, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		switch err.Error() {
+		case "invalid token":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		case "invalid two-factor code":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid two-factor code"})
+		case "too many two-factor attempts":
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many two-factor attempts"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Two-factor verification failed"})
+		}
+		return
+	}
+
 	dtoResponse := dto.LoginResponse{
 		AccessToken:  response.AccessToken,
 		RefreshToken: response.RefreshToken,
@@ -109,9 +165,9 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		RefreshToken: req.RefreshToken,
 	}
 
-	response, err := h.authUsecase.RefreshToken(c.Request.Context(), usecaseReq)
+	response, err := h.authUsecase.RefreshToken(c.Request.Context(), usecaseReq, c.ClientIP(), c.GetHeader("User-Agent"))
 	if err != nil {
-		if err.Error() == "invalid token" {
+		if err.Error() == "invalid token" || err.Error() == "refresh token reuse detected" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
 			return
 		}
@@ -208,76 +264,74 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
 }
 
-func (h *AuthHandler) ValidateToken(c *gin.Context) {
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Token required"})
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
 		return
 	}
 
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
+	if err := h.authUsecase.LogoutAll(c.Request.Context(), userID, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		log.Printf("Failed to log out from all sessions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed"})
+		return
 	}
 
-	claims, err := h.authUsecase.ValidateToken(token)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out from all sessions"})
+}
+
+func (h *AuthHandler) SuspendUser(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
 		return
 	}
 
-	response := dto.TokenValidationResponse{
-		Valid:  true,
-		UserID: claims.UserID,
-		Email:  claims.Email,
-		Roles:  claims.Roles,
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
-}
-
-func (h *AuthHandler) UpdateUserProfile(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+	if err := h.authUsecase.SuspendUser(c.Request.Context(), adminUserID, userID, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondAccountStatusError(c, err, "Failed to suspend user")
 		return
 	}
 
-	userIDUint, ok := userID.(uint)
+	c.JSON(http.StatusOK, gin.H{"message": "User suspended successfully"})
+}
+
+func (h *AuthHandler) ReactivateUser(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
 		return
 	}
 
-	var req struct {
-		Name        string `json:"name"`
-		Email       string `json:"email"`
-		PhoneNumber string `json:"phone_number"`
-		Address     string `json:"address"`
-		Bio         string `json:"bio"`
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+	if err := h.authUsecase.ReactivateUser(c.Request.Context(), adminUserID, userID, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondAccountStatusError(c, err, "Failed to reactivate user")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User profile updated successfully",
-		"user_id": userIDUint,
-		"updated_fields": map[string]interface{}{
-			"name":         req.Name,
-			"email":        req.Email,
-			"phone_number": req.PhoneNumber,
-			"address":      req.Address,
-			"bio":          req.Bio,
-		},
-	})
+	c.JSON(http.StatusOK, gin.H{"message": "User reactivated successfully"})
+}
+
+func respondAccountStatusError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrUserAlreadySuspended), errors.Is(err, service.ErrUserNotSuspended):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
 }
 
-func (h *AuthHandler) GetUserDashboard(c *gin.Context) {
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -290,45 +344,26 @@ func (h *AuthHandler) GetUserDashboard(c *gin.Context) {
 		return
 	}
 
-	dashboard := map[string]interface{}{
-		"user_id": userIDUint,
-		"stats": map[string]interface{}{
-			"total_logins":       42,
-			"last_login":         "2025-08-27T12:51:00Z",
-			"account_created":    "2025-01-01T00:00:00Z",
-			"profile_completion": 85,
-			"security_score":     92,
-		},
-		"recent_activities": []map[string]interface{}{
-			{
-				"type":        "login",
-				"description": "Successful login from 192.168.1.100",
-				"timestamp":   "2025-08-27T12:51:00Z",
-			},
-			{
-				"type":        "profile_update",
-				"description": "Profile information updated",
-				"timestamp":   "2025-08-26T15:30:00Z",
-			},
-		},
-		"notifications": map[string]interface{}{
-			"unread_count": 3,
-			"total_count":  15,
-		},
-		"security": map[string]interface{}{
-			"two_factor_enabled":    false,
-			"last_password_change":  "2025-07-15T10:00:00Z",
-			"trusted_devices_count": 2,
-		},
+	response, err := h.authUsecase.SetupTwoFactor(c.Request.Context(), userIDUint)
+	if err != nil {
+		if err.Error() == "two-factor authentication is already enabled" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set up two-factor authentication"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Dashboard data retrieved successfully",
-		"data":    dashboard,
+		"message": "Scan the provisioning URI with your authenticator app and confirm with a code",
+		"data": dto.TwoFactorSetupResponse{
+			Secret:          response.Secret,
+			ProvisioningURI: response.ProvisioningURI,
+		},
 	})
 }
 
-func (h *AuthHandler) GetUserNotifications(c *gin.Context) {
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -341,46 +376,25 @@ func (h *AuthHandler) GetUserNotifications(c *gin.Context) {
 		return
 	}
 
-	notifications := []map[string]interface{}{
-		{
-			"id":         1,
-			"type":       "security",
-			"title":      "New login detected",
-			"message":    "A new login was detected from a different device",
-			"is_read":    false,
-			"created_at": "2025-08-27T12:00:00Z",
-			"priority":   "high",
-		},
-		{
-			"id":         2,
-			"type":       "system",
-			"title":      "Profile update reminder",
-			"message":    "Please update your profile information",
-			"is_read":    true,
-			"created_at": "2025-08-26T10:00:00Z",
-			"priority":   "medium",
-		},
-		{
-			"id":         3,
-			"type":       "promotional",
-			"title":      "New features available",
-			"message":    "Check out our latest features and improvements",
-			"is_read":    false,
-			"created_at": "2025-08-25T14:30:00Z",
-			"priority":   "low",
-		},
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recoveryCodes, err := h.authUsecase.EnableTwoFactor(c.Request.Context(), userIDUint, usecase.TwoFactorCodeRequest{Code: req.Code}, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to enable two-factor authentication")
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":      "Notifications retrieved successfully",
-		"user_id":      userIDUint,
-		"data":         notifications,
-		"total_count":  len(notifications),
-		"unread_count": 2,
+		"message": "Two-factor authentication enabled",
+		"data":    dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes},
 	})
 }
 
-func (h *AuthHandler) MarkNotificationRead(c *gin.Context) {
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -393,21 +407,26 @@ func (h *AuthHandler) MarkNotificationRead(c *gin.Context) {
 		return
 	}
 
-	notificationID := c.Param("id")
-	if notificationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Notification ID is required"})
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":         "Notification marked as read successfully",
-		"user_id":         userIDUint,
-		"notification_id": notificationID,
-		"status":          "read",
-	})
+	usecaseReq := usecase.DisableTwoFactorRequest{
+		Password: req.Password,
+		Code:     req.Code,
+	}
+
+	if err := h.authUsecase.DisableTwoFactor(c.Request.Context(), userIDUint, usecaseReq, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondTwoFactorError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
 }
 
-func (h *AuthHandler) GetUserPointTransactions(c *gin.Context) {
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -420,79 +439,78 @@ func (h *AuthHandler) GetUserPointTransactions(c *gin.Context) {
 		return
 	}
 
-	transactions := []map[string]interface{}{
-		{
-			"id":          1,
-			"type":        "earned",
-			"amount":      100,
-			"description": "Login bonus",
-			"created_at":  "2025-08-27T12:00:00Z",
-			"balance":     1500,
-		},
-		{
-			"id":          2,
-			"type":        "spent",
-			"amount":      -50,
-			"description": "Premium feature unlock",
-			"created_at":  "2025-08-26T15:30:00Z",
-			"balance":     1400,
-		},
-		{
-			"id":          3,
-			"type":        "earned",
-			"amount":      200,
-			"description": "Referral bonus",
-			"created_at":  "2025-08-25T10:00:00Z",
-			"balance":     1450,
-		},
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recoveryCodes, err := h.authUsecase.RegenerateRecoveryCodes(c.Request.Context(), userIDUint, usecase.TwoFactorCodeRequest{Code: req.Code}, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondTwoFactorError(c, err, "Failed to regenerate recovery codes")
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":         "Point transactions retrieved successfully",
-		"user_id":         userIDUint,
-		"data":            transactions,
-		"current_balance": 1500,
-		"total_earned":    2500,
-		"total_spent":     1000,
+		"message": "Recovery codes regenerated",
+		"data":    dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes},
 	})
 }
 
-func (h *AuthHandler) SetUserPreference(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+func respondTwoFactorError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "invalid two-factor code":
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid two-factor code"})
+	case "invalid password":
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Password is incorrect"})
+	case "two-factor authentication is already enabled":
+		c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+	case "two-factor authentication is not enabled", "two-factor authentication setup has not been started":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func (h *AuthHandler) ValidateToken(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token required"})
 		return
 	}
 
-	userIDUint, ok := userID.(uint)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+	if len(token) > 7 && token[:7] == "Bearer " {
+		token = token[7:]
+	}
+
+	claims, err := h.authUsecase.ValidateToken(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 		return
 	}
 
-	var req struct {
-		Key   string      `json:"key" binding:"required"`
-		Value interface{} `json:"value" binding:"required"`
+	response := dto.TokenValidationResponse{
+		Valid:  true,
+		UserID: claims.UserID,
+		Email:  claims.Email,
+		Roles:  claims.Roles,
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	jwks, err := h.authUsecase.GetJWKS()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load signing keys"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "User preference set successfully",
-		"user_id":    userIDUint,
-		"preference": req.Key,
-		"value":      req.Value,
-		"updated_at": "2025-08-27T12:51:00Z",
-	})
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
 }
 
-func (h *AuthHandler) GetUserPreferences(c *gin.Context) {
+func (h *AuthHandler) UpdateUserProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -505,21 +523,31 @@ func (h *AuthHandler) GetUserPreferences(c *gin.Context) {
 		return
 	}
 
-	preferences := map[string]interface{}{
-		"theme":               "dark",
-		"language":            "ja",
-		"notifications_email": true,
-		"notifications_push":  false,
-		"privacy_profile":     "friends_only",
-		"two_factor_enabled":  false,
-		"auto_logout_minutes": 30,
-		"data_sharing":        false,
+	var req struct {
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		PhoneNumber string `json:"phone_number"`
+		Address     string `json:"address"`
+		Bio         string `json:"bio"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":    "User preferences retrieved successfully",
-		"user_id":    userIDUint,
-		"data":       preferences,
-		"updated_at": "2025-08-27T12:51:00Z",
+		"message": "User profile updated successfully",
+		"user_id": userIDUint,
+		"updated_fields": map[string]interface{}{
+			"name":         req.Name,
+			"email":        req.Email,
+			"phone_number": req.PhoneNumber,
+			"address":      req.Address,
+			"bio":          req.Bio,
+		},
 	})
 }
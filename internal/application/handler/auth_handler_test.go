@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -46,8 +47,8 @@ func (m *MockAuthUsecase) Login(ctx context.Context, req usecase.LoginRequest, i
 	return resp, args.Error(1)
 }
 
-func (m *MockAuthUsecase) RefreshToken(ctx context.Context, req usecase.RefreshTokenRequest) (*usecase.LoginResponse, error) {
-	args := m.Called(ctx, req)
+func (m *MockAuthUsecase) RefreshToken(ctx context.Context, req usecase.RefreshTokenRequest, ipAddress, userAgent string) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req, ipAddress, userAgent)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -68,8 +69,23 @@ func (m *MockAuthUsecase) Logout(ctx context.Context, userID uint, token, sessio
 	return args.Error(0)
 }
 
-func (m *MockAuthUsecase) ValidateToken(tokenString string) (*service.JWTClaims, error) {
-	args := m.Called(tokenString)
+func (m *MockAuthUsecase) LogoutAll(ctx context.Context, userID uint, ipAddress, userAgent string) error {
+	args := m.Called(ctx, userID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) SuspendUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error {
+	args := m.Called(ctx, adminUserID, userID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ReactivateUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error {
+	args := m.Called(ctx, adminUserID, userID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ValidateToken(ctx context.Context, tokenString string) (*service.JWTClaims, error) {
+	args := m.Called(ctx, tokenString)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -79,6 +95,55 @@ func (m *MockAuthUsecase) ValidateToken(tokenString string) (*service.JWTClaims,
 	return nil, args.Error(1)
 }
 
+func (m *MockAuthUsecase) GetJWKS() (*service.JWKS, error) {
+	args := m.Called()
+	if jwks, ok := args.Get(0).(*service.JWKS); ok {
+		return jwks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthUsecase) VerifyTwoFactor(ctx context.Context, req usecase.VerifyTwoFactorRequest, ipAddress, userAgent string) (*usecase.LoginResponse, error) {
+	args := m.Called(ctx, req, ipAddress, userAgent)
+	resp, ok := args.Get(0).(*usecase.LoginResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthUsecase) SetupTwoFactor(ctx context.Context, userID uint) (*usecase.TwoFactorSetupResponse, error) {
+	args := m.Called(ctx, userID)
+	resp, ok := args.Get(0).(*usecase.TwoFactorSetupResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthUsecase) EnableTwoFactor(ctx context.Context, userID uint, req usecase.TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error) {
+	args := m.Called(ctx, userID, req, ipAddress, userAgent)
+	codes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return codes, args.Error(1)
+}
+
+func (m *MockAuthUsecase) DisableTwoFactor(ctx context.Context, userID uint, req usecase.DisableTwoFactorRequest, ipAddress, userAgent string) error {
+	args := m.Called(ctx, userID, req, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, req usecase.TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error) {
+	args := m.Called(ctx, userID, req, ipAddress, userAgent)
+	codes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return codes, args.Error(1)
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -264,10 +329,24 @@ func TestAuthHandlerRefreshToken(t *testing.T) {
 					ExpiresIn:    3600,
 					User:         user,
 				}
-				mockUsecase.On("RefreshToken", mock.Anything, req).Return(response, nil)
+				mockUsecase.On("RefreshToken", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "再利用されたリフレッシュトークン",
+			requestBody: map[string]interface{}{
+				"refresh_token": "reused-refresh-token",
+			},
+			setupMock: func(mockUsecase *MockAuthUsecase) {
+				req := usecase.RefreshTokenRequest{
+					RefreshToken: "reused-refresh-token",
+				}
+				reuseErr := &service.RefreshTokenReuseError{UserID: 1, FamilyID: "family-1"}
+				mockUsecase.On("RefreshToken", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, reuseErr)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
 		{
 			name: "無効なリクエストボディ",
 			requestBody: map[string]interface{}{
@@ -301,3 +380,232 @@ func TestAuthHandlerRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandlerLoginMFAChallenge(t *testing.T) {
+	mockUsecase := new(MockAuthUsecase)
+	req := usecase.LoginRequest{Email: "test@example.com", Password: "password123"}
+	response := &usecase.LoginResponse{
+		ExpiresIn:   300,
+		User:        &entity.User{ID: 1, Email: "test@example.com"},
+		MFARequired: true,
+		MFAToken:    "mfa-token",
+	}
+	mockUsecase.On("Login", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(response, nil)
+
+	authHandler := handler.NewAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/login", authHandler.Login)
+
+	body, _ := json.Marshal(map[string]interface{}{"email": "test@example.com", "password": "password123"})
+	httpReq := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, true, result.Data["mfa_required"])
+	assert.Equal(t, "mfa-token", result.Data["mfa_token"])
+	assert.NotContains(t, result.Data, "access_token")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandlerVerifyTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockAuthUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "正しいコード",
+			requestBody: map[string]interface{}{"mfa_token": "mfa-token", "code": "123456"},
+			setupMock: func(mockUsecase *MockAuthUsecase) {
+				req := usecase.VerifyTwoFactorRequest{MFAToken: "mfa-token", Code: "123456"}
+				response := &usecase.LoginResponse{
+					AccessToken:  "access-token",
+					RefreshToken: "refresh-token",
+					ExpiresIn:    3600,
+					User:         &entity.User{ID: 1, Email: "test@example.com"},
+				}
+				mockUsecase.On("VerifyTwoFactor", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(response, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "誤ったコード",
+			requestBody: map[string]interface{}{"mfa_token": "mfa-token", "code": "000000"},
+			setupMock: func(mockUsecase *MockAuthUsecase) {
+				req := usecase.VerifyTwoFactorRequest{MFAToken: "mfa-token", Code: "000000"}
+				mockUsecase.On("VerifyTwoFactor", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, entity.ErrInvalidTwoFactorCode)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "試行回数超過",
+			requestBody: map[string]interface{}{"mfa_token": "mfa-token", "code": "000000"},
+			setupMock: func(mockUsecase *MockAuthUsecase) {
+				req := usecase.VerifyTwoFactorRequest{MFAToken: "mfa-token", Code: "000000"}
+				mockUsecase.On("VerifyTwoFactor", mock.Anything, req, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil, service.ErrTooManyTwoFactorAttempts)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:        "コードが未指定",
+			requestBody: map[string]interface{}{"mfa_token": "mfa-token"},
+			setupMock: func(mockUsecase *MockAuthUsecase) {
+				// invalid request body
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			authHandler := handler.NewAuthHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/2fa/verify", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandlerEnableTwoFactor(t *testing.T) {
+	mockUsecase := new(MockAuthUsecase)
+	mockUsecase.On("EnableTwoFactor", mock.Anything, uint(1), usecase.TwoFactorCodeRequest{Code: "123456"}, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Return([]string{"aaaaa-11111", "bbbbb-22222"}, nil)
+
+	authHandler := handler.NewAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/2fa/enable", func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		authHandler.EnableTwoFactor(c)
+	})
+
+	body, _ := json.Marshal(map[string]interface{}{"code": "123456"})
+	req := httptest.NewRequest(http.MethodPost, "/2fa/enable", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "aaaaa-11111")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandlerJWKS(t *testing.T) {
+	mockUsecase := new(MockAuthUsecase)
+	jwks := &service.JWKS{Keys: []service.JWK{{Kty: "OKP", Kid: "key-1", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "abc"}}}
+	mockUsecase.On("GetJWKS").Return(jwks, nil)
+
+	authHandler := handler.NewAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+	var body service.JWKS
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, *jwks, body)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandlerLogoutAll(t *testing.T) {
+	mockUsecase := new(MockAuthUsecase)
+	mockUsecase.On("LogoutAll", mock.Anything, uint(1), mock.Anything, mock.Anything).Return(nil)
+
+	authHandler := handler.NewAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/user/logout-all", withUserID(1, authHandler.LogoutAll))
+
+	req := httptest.NewRequest(http.MethodPost, "/user/logout-all", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandlerSuspendUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockAuthUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "ユーザーを停止する",
+			path: "/admin/users/2/suspend",
+			setupMock: func(m *MockAuthUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(2), mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "自分自身の停止",
+			path: "/admin/users/1/suspend",
+			setupMock: func(m *MockAuthUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(1), mock.Anything, mock.Anything).
+					Return(errors.New("invalid request: cannot suspend yourself"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "停止済みのユーザー",
+			path: "/admin/users/2/suspend",
+			setupMock: func(m *MockAuthUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(2), mock.Anything, mock.Anything).Return(service.ErrUserAlreadySuspended)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "存在しないユーザー",
+			path: "/admin/users/9/suspend",
+			setupMock: func(m *MockAuthUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(9), mock.Anything, mock.Anything).Return(service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			authHandler := handler.NewAuthHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/users/:user_id/suspend", withUserID(1, authHandler.SuspendUser))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
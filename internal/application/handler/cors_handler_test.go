@@ -72,7 +72,7 @@ func TestCORSMiddleware(t *testing.T) {
 			if tt.checkHeaders {
 				assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
 				assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
-				assert.Equal(t, "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Session-ID", w.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Session-ID, Idempotency-Key", w.Header().Get("Access-Control-Allow-Headers"))
 				assert.Equal(t, "Content-Length, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset", w.Header().Get("Access-Control-Expose-Headers"))
 				assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
 			}
@@ -123,7 +123,7 @@ func TestCORSMiddlewarePreflightRequest(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
 	assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
-	assert.Equal(t, "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Session-ID", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Session-ID, Idempotency-Key", w.Header().Get("Access-Control-Allow-Headers"))
 	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
 }
 
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardHandler struct {
+	dashboardUsecase usecase.DashboardUsecaseInterface
+}
+
+func NewDashboardHandler(dashboardUsecase usecase.DashboardUsecaseInterface) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardUsecase: dashboardUsecase,
+	}
+}
+
+func (h *DashboardHandler) GetUserDashboard(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.dashboardUsecase.GetUserDashboard(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to get dashboard: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get dashboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Dashboard data retrieved successfully",
+		"data":    dto.NewDashboardResponseFromEntity(dashboard),
+	})
+}
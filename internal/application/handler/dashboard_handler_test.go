@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDashboardUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDashboardUsecase) GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	args := m.Called(ctx, userID)
+	if dashboard, ok := args.Get(0).(*entity.UserDashboard); ok {
+		return dashboard, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDashboardUsecase) InvalidateUserDashboard(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestDashboardHandlerGetUserDashboard(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*MockDashboardUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "ダッシュボードを取得する",
+			setupMock: func(mockUsecase *MockDashboardUsecase) {
+				userID := uint(1)
+				mockUsecase.On("GetUserDashboard", mock.Anything, uint(1)).Return(&entity.UserDashboard{
+					UserID:              1,
+					TotalLogins:         12,
+					ProfileCompletion:   50,
+					SecurityScore:       80,
+					TwoFactorEnabled:    true,
+					TrustedDevicesCount: 1,
+					UnreadNotifications: 3,
+					TotalNotifications:  8,
+					RecentSecurityEvents: []*entity.SecurityEvent{
+						{UserID: &userID, EventType: "LOGIN_SUCCESS", Description: "ログイン成功", Severity: "LOW"},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ユーザーが存在しない",
+			setupMock: func(mockUsecase *MockDashboardUsecase) {
+				mockUsecase.On("GetUserDashboard", mock.Anything, uint(1)).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "集計に失敗",
+			setupMock: func(mockUsecase *MockDashboardUsecase) {
+				mockUsecase.On("GetUserDashboard", mock.Anything, uint(1)).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockDashboardUsecase)
+			tt.setupMock(mockUsecase)
+
+			dashboardHandler := handler.NewDashboardHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/dashboard", withUserID(1, dashboardHandler.GetUserDashboard))
+
+			req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Data dto.DashboardResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 80, response.Data.Stats.SecurityScore)
+				assert.Equal(t, int64(3), response.Data.Notifications.UnreadCount)
+				assert.True(t, response.Data.Security.TwoFactorEnabled)
+				assert.Len(t, response.Data.RecentActivities, 1)
+				assert.Equal(t, "LOGIN_SUCCESS", response.Data.RecentActivities[0].Type)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -66,7 +66,7 @@ func (h *FraudHandler) AddIPToBlacklist(c *gin.Context) {
 }
 
 func (h *FraudHandler) RemoveIPFromBlacklist(c *gin.Context) {
-	ip := c.Param("ip")
+	ip := strings.TrimPrefix(c.Param("ip"), "/")
 	if strings.TrimSpace(ip) == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "IP parameter is required"})
 		return
@@ -103,6 +103,80 @@ func (h *FraudHandler) GetBlacklistedIPs(c *gin.Context) {
 	})
 }
 
+func (h *FraudHandler) AddIPToAllowlist(c *gin.Context) {
+	var req struct {
+		IP          string `json:"ip" binding:"required"`
+		Description string `json:"description" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	err := h.fraudUsecase.AddIPToAllowlist(c.Request.Context(), req.IP, req.Description)
+	if err != nil {
+		log.Printf("Failed to add IP to allowlist: %v", err)
+		if strings.Contains(err.Error(), "invalid IP") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add IP to allowlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "IP added to allowlist successfully",
+		"ip":          req.IP,
+		"description": req.Description,
+	})
+}
+
+func (h *FraudHandler) RemoveIPFromAllowlist(c *gin.Context) {
+	ip := strings.TrimPrefix(c.Param("ip"), "/")
+	if strings.TrimSpace(ip) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IP parameter is required"})
+		return
+	}
+
+	err := h.fraudUsecase.RemoveIPFromAllowlist(c.Request.Context(), ip)
+	if err != nil {
+		log.Printf("Failed to remove IP from allowlist: %v", err)
+		if strings.Contains(err.Error(), "invalid IP") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "IP not found in allowlist"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove IP from allowlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "IP removed from allowlist successfully",
+		"ip":      ip,
+	})
+}
+
+func (h *FraudHandler) GetAllowlistedIPs(c *gin.Context) {
+	ips, err := h.fraudUsecase.GetAllowlistedIPs(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to get allowlisted IPs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get allowlisted IPs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  ips,
+		"count": len(ips),
+	})
+}
+
 func (h *FraudHandler) GetSecurityEvents(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "50")
 	offsetStr := c.DefaultQuery("offset", "0")
@@ -178,13 +252,17 @@ func (h *FraudHandler) CreateRateLimitRule(c *gin.Context) {
 		return
 	}
 
-	err := h.fraudUsecase.CreateRateLimitRule(c.Request.Context(), &req)
+	rule, err := h.fraudUsecase.CreateRateLimitRule(c.Request.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create rate limit rule: %v", err)
 		if strings.Contains(err.Error(), "invalid") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			c.JSON(http.StatusConflict, gin.H{"error": "Rate limit rule already exists"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create rate limit rule"})
 		return
 	}
@@ -192,6 +270,7 @@ func (h *FraudHandler) CreateRateLimitRule(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{
 		"message":   "Rate limit rule created successfully",
 		"rule_type": req.RuleType,
+		"data":      rule,
 	})
 }
 
@@ -218,13 +297,17 @@ func (h *FraudHandler) UpdateRateLimitRule(c *gin.Context) {
 		return
 	}
 
-	err = h.fraudUsecase.UpdateRateLimitRule(c.Request.Context(), uint(id), &req)
+	rule, err := h.fraudUsecase.UpdateRateLimitRule(c.Request.Context(), uint(id), &req)
 	if err != nil {
 		log.Printf("Failed to update rate limit rule: %v", err)
 		if strings.Contains(err.Error(), "invalid") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Rate limit rule not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update rate limit rule"})
 		return
 	}
@@ -232,6 +315,7 @@ func (h *FraudHandler) UpdateRateLimitRule(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Rate limit rule updated successfully",
 		"rule_id": id,
+		"data":    rule,
 	})
 }
 
@@ -279,6 +363,79 @@ func (h *FraudHandler) GetRateLimitRules(c *gin.Context) {
 	})
 }
 
+func (h *FraudHandler) GetFraudRules(c *gin.Context) {
+	rules, err := h.fraudUsecase.GetFraudRules(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to get fraud rules: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get fraud rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  rules,
+		"count": len(rules),
+	})
+}
+
+func (h *FraudHandler) UpdateFraudRule(c *gin.Context) {
+	name := c.Param("name")
+
+	var req dto.UpdateFraudRuleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.fraudUsecase.UpdateFraudRule(c.Request.Context(), name, &req)
+	if err != nil {
+		log.Printf("Failed to update fraud rule: %v", err)
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fraud rule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update fraud rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Fraud rule updated successfully",
+		"data":    rule,
+	})
+}
+
+func (h *FraudHandler) AnalyzeFraud(c *gin.Context) {
+	var req dto.AnalyzeFraudRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	analysis, err := h.fraudUsecase.AnalyzeFraud(c.Request.Context(), &req)
+	if err != nil {
+		log.Printf("Failed to analyze fraud: %v", err)
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze fraud"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": analysis})
+}
+
 func (h *FraudHandler) GetActiveSessions(c *gin.Context) {
 	sessions, err := h.fraudUsecase.GetActiveSessions(c.Request.Context())
 	if err != nil {
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
@@ -43,6 +45,24 @@ func (m *MockFraudUsecase) GetBlacklistedIPs(ctx context.Context) ([]*entity.IPB
 	return result, args.Error(1)
 }
 
+func (m *MockFraudUsecase) AddIPToAllowlist(ctx context.Context, ip string, description string) error {
+	args := m.Called(ctx, ip, description)
+	return args.Error(0)
+}
+
+func (m *MockFraudUsecase) RemoveIPFromAllowlist(ctx context.Context, ip string) error {
+	args := m.Called(ctx, ip)
+	return args.Error(0)
+}
+
+func (m *MockFraudUsecase) GetAllowlistedIPs(ctx context.Context) ([]*entity.IPAllowlist, error) {
+	args := m.Called(ctx)
+	if result, ok := args.Get(0).([]*entity.IPAllowlist); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockFraudUsecase) GetSecurityEvents(ctx context.Context, limit, offset int) ([]*entity.SecurityEvent, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -60,14 +80,28 @@ func (m *MockFraudUsecase) CreateSecurityEvent(ctx context.Context, req *dto.Cre
 	return args.Error(0)
 }
 
-func (m *MockFraudUsecase) CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) error {
+func (m *MockFraudUsecase) CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) (*entity.RateLimitRule, error) {
 	args := m.Called(ctx, req)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	result, ok := args.Get(0).(*entity.RateLimitRule)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return result, args.Error(1)
 }
 
-func (m *MockFraudUsecase) UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) error {
+func (m *MockFraudUsecase) UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) (*entity.RateLimitRule, error) {
 	args := m.Called(ctx, id, req)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	result, ok := args.Get(0).(*entity.RateLimitRule)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return result, args.Error(1)
 }
 
 func (m *MockFraudUsecase) DeleteRateLimitRule(ctx context.Context, id uint) error {
@@ -87,6 +121,30 @@ func (m *MockFraudUsecase) GetRateLimitRules(ctx context.Context) ([]*entity.Rat
 	return result, args.Error(1)
 }
 
+func (m *MockFraudUsecase) GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	args := m.Called(ctx)
+	if result, ok := args.Get(0).([]*entity.FraudRuleConfig); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFraudUsecase) UpdateFraudRule(ctx context.Context, name string, req *dto.UpdateFraudRuleRequest) (*entity.FraudRuleConfig, error) {
+	args := m.Called(ctx, name, req)
+	if result, ok := args.Get(0).(*entity.FraudRuleConfig); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFraudUsecase) AnalyzeFraud(ctx context.Context, req *dto.AnalyzeFraudRequest) (*entity.FraudAnalysis, error) {
+	args := m.Called(ctx, req)
+	if result, ok := args.Get(0).(*entity.FraudAnalysis); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockFraudUsecase) GetActiveSessions(ctx context.Context) ([]*entity.UserSession, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -329,6 +387,240 @@ func TestFraudHandlerGetBlacklistedIPs(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+func TestFraudHandlerCreateRateLimitRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	req := &dto.CreateRateLimitRuleRequest{
+		RuleType:    "IP",
+		Identifier:  "203.0.113.10",
+		MaxRequests: 100,
+		WindowSize:  60,
+	}
+	stored := entity.NewRateLimitRule("IP:203.0.113.10", "203.0.113.10", 100, 60)
+	stored.ID = 3
+
+	mockUsecase.On("CreateRateLimitRule", mock.Anything, req).Return(stored, nil)
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/fraud/rate/limits", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	fraudHandler.CreateRateLimitRule(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response struct {
+		Data entity.RateLimitRule `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), response.Data.ID)
+	assert.Equal(t, "203.0.113.10", response.Data.Resource)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFraudHandlerUpdateRateLimitRuleNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	req := &dto.UpdateRateLimitRuleRequest{MaxRequests: 50}
+	mockUsecase.On("UpdateRateLimitRule", mock.Anything, uint(9), req).Return(nil, errors.New("failed to get rate limit rule: record not found"))
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "9"}}
+	c.Request = httptest.NewRequest("PUT", "/fraud/rate/limits/9", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	fraudHandler.UpdateRateLimitRule(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFraudHandlerUpdateFraudRule(t *testing.T) {
+	weight := 0.5
+	tests := []struct {
+		name           string
+		ruleName       string
+		returnRule     *entity.FraudRuleConfig
+		returnErr      error
+		expectedStatus int
+	}{
+		{
+			name:           "重みを更新できる",
+			ruleName:       "ip_blacklisted",
+			returnRule:     entity.NewFraudRuleConfig("ip_blacklisted", weight, 0, 0),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "未登録のルールは404",
+			ruleName:       "unknown",
+			returnErr:      errors.New("fraud rule not found: unknown"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "不正な値は400",
+			ruleName:       "ip_blacklisted",
+			returnErr:      errors.New("invalid update fraud rule request: weight must be between 0 and 1"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			mockUsecase := new(MockFraudUsecase)
+			req := &dto.UpdateFraudRuleRequest{Weight: &weight}
+			mockUsecase.On("UpdateFraudRule", mock.Anything, tt.ruleName, req).Return(tt.returnRule, tt.returnErr)
+
+			fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+			body, _ := json.Marshal(req)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "name", Value: tt.ruleName}}
+			c.Request = httptest.NewRequest("PUT", "/fraud/rules/"+tt.ruleName, bytes.NewBuffer(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			fraudHandler.UpdateFraudRule(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudHandlerAnalyzeFraud(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	req := &dto.AnalyzeFraudRequest{Email: "test@example.com", IPAddress: "203.0.113.10"}
+	analysis := entity.NewFraudAnalysisFromContributions([]entity.FraudRuleContribution{
+		{Rule: "ip_blacklisted", Matched: true, Weight: 0.8, Score: 0.8, Reason: "IP address is blacklisted"},
+		{Rule: "ip_velocity", Matched: false, Weight: 0.4},
+	})
+	mockUsecase.On("AnalyzeFraud", mock.Anything, req).Return(analysis, nil)
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/fraud/analyze", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	fraudHandler.AnalyzeFraud(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data entity.FraudAnalysis `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.8, response.Data.RiskScore)
+	assert.Len(t, response.Data.Contributions, 2)
+	assert.Equal(t, "ip_blacklisted", response.Data.Contributions[0].Rule)
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFraudHandlerRemoveIPFromBlacklistCIDR(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	mockUsecase.On("RemoveIPFromBlacklist", mock.Anything, "203.0.113.0/24").Return(nil)
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	router := gin.New()
+	router.DELETE("/fraud/blacklist/ip/*ip", fraudHandler.RemoveIPFromBlacklist)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/fraud/blacklist/ip/203.0.113.0/24", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFraudHandlerAddIPToAllowlist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	mockUsecase.On("AddIPToAllowlist", mock.Anything, "10.20.0.0/16", "Tokyo office").Return(nil)
+	mockUsecase.On("AddIPToAllowlist", mock.Anything, "0.0.0.0/0", "everyone").
+		Return(errors.New("invalid IP address: CIDR range too broad (minimum /8)"))
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "オフィスの範囲を許可できる", body: `{"ip":"10.20.0.0/16","description":"Tokyo office"}`, expectedStatus: http.StatusOK},
+		{name: "広すぎる範囲は400", body: `{"ip":"0.0.0.0/0","description":"everyone"}`, expectedStatus: http.StatusBadRequest},
+		{name: "説明がなければ400", body: `{"ip":"10.20.0.0/16"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/fraud/allowlist/ip", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			fraudHandler.AddIPToAllowlist(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFraudHandlerGetActiveSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(MockFraudUsecase)
+	sessions := []*entity.UserSession{
+		entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(time.Hour)),
+	}
+	mockUsecase.On("GetActiveSessions", mock.Anything).Return(sessions, nil)
+
+	fraudHandler := handler.NewFraudHandler(mockUsecase)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/fraud/sessions", nil)
+
+	fraudHandler.GetActiveSessions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data  []entity.UserSession `json:"data"`
+		Count int                  `json:"count"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "session-123", response.Data[0].SessionID)
+
+	mockUsecase.AssertExpectations(t)
+}
+
 func TestFraudHandlerCleanupExpiredData(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
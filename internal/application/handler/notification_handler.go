@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStreamHeartbeatInterval = 15 * time.Second
+	streamRetryMilliseconds        = 3000
+)
+
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecaseInterface
+	heartbeatInterval   time.Duration
+}
+
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecaseInterface) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUsecase: notificationUsecase,
+		heartbeatInterval:   defaultStreamHeartbeatInterval,
+	}
+}
+
+func (h *NotificationHandler) WithHeartbeatInterval(interval time.Duration) *NotificationHandler {
+	if interval > 0 {
+		h.heartbeatInterval = interval
+	}
+	return h
+}
+
+func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.NotificationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.notificationUsecase.GetNotifications(c.Request.Context(), userID, &query)
+	if err != nil {
+		log.Printf("Failed to get notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewNotificationListResponseFromEntities(response.Notifications, response.UnreadCount, response.Page, response.Limit, response.Total),
+	})
+}
+
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.notificationUsecase.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get unread count: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get unread count"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{"unread_count": count},
+	})
+}
+
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	notificationID, ok := parseNotificationID(c)
+	if !ok {
+		return
+	}
+
+	notification, err := h.notificationUsecase.MarkAsRead(c.Request.Context(), userID, notificationID)
+	if err != nil {
+		respondNotificationError(c, err, "Failed to mark notification as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read successfully",
+		"data":    dto.NewNotificationInfoFromEntity(notification),
+	})
+}
+
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.notificationUsecase.MarkAllAsRead(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to mark all notifications as read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark all notifications as read"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All notifications marked as read",
+		"data":    gin.H{"updated_count": updated},
+	})
+}
+
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	notificationID, ok := parseNotificationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationUsecase.DeleteNotification(c.Request.Context(), userID, notificationID); err != nil {
+		respondNotificationError(c, err, "Failed to delete notification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Notification deleted successfully",
+		"notification_id": notificationID,
+	})
+}
+
+func (h *NotificationHandler) CreateNotificationForUser(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var req dto.CreateNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	notification, err := h.notificationUsecase.CreateNotification(c.Request.Context(), uint(userID), &req)
+	if err != nil {
+		respondNotificationError(c, err, "Failed to create notification")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Notification created successfully",
+		"created_by": adminID,
+		"data":       dto.NewNotificationInfoFromEntity(notification),
+	})
+}
+
+func (h *NotificationHandler) StreamNotifications(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	stream, err := h.notificationUsecase.OpenStream(c.Request.Context(), userID, lastEventID)
+	if err != nil {
+		respondNotificationError(c, err, "Failed to open notification stream")
+		return
+	}
+	defer func() {
+		if err := h.notificationUsecase.CloseStream(context.Background(), stream); err != nil {
+			log.Printf("Failed to close notification stream: %v", err)
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	var lastSentID uint
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetryMilliseconds)
+	for _, notification := range stream.Backlog {
+		writeNotificationEvent(c, notification)
+		lastSentID = notification.ID
+	}
+	writeUnreadCountEvent(c, stream.UnreadCount)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := h.notificationUsecase.KeepStreamAlive(c.Request.Context(), stream); err != nil {
+				log.Printf("Failed to keep notification stream alive: %v", err)
+			}
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-stream.Subscription.Events():
+			if !ok {
+				return
+			}
+			if event.Type == entity.NotificationEventTypeCreated {
+				if event.Notification == nil || event.Notification.ID <= lastSentID {
+					continue
+				}
+				writeNotificationEvent(c, event.Notification)
+				lastSentID = event.Notification.ID
+			}
+			writeUnreadCountEvent(c, event.UnreadCount)
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeNotificationEvent(c *gin.Context, notification *entity.Notification) {
+	data, err := json.Marshal(dto.NewNotificationInfoFromEntity(notification))
+	if err != nil {
+		log.Printf("Failed to encode notification event: %v", err)
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", notification.ID, entity.NotificationEventTypeCreated, data)
+}
+
+func writeUnreadCountEvent(c *gin.Context, unreadCount int64) {
+	fmt.Fprintf(c.Writer, "event: %s\ndata: {\"unread_count\":%d}\n\n", entity.NotificationEventTypeUnreadCount, unreadCount)
+}
+
+func authenticatedUserID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, false
+	}
+
+	userIDUint, ok := userID.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return 0, false
+	}
+	return userIDUint, true
+}
+
+func parseNotificationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondNotificationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNotificationStreamLimitExceeded):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotificationStreamUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,413 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockNotificationUsecase) GetNotifications(ctx context.Context, userID uint, query *dto.NotificationQuery) (*usecase.NotificationListResponse, error) {
+	args := m.Called(ctx, userID, query)
+	if response, ok := args.Get(0).(*usecase.NotificationListResponse); ok {
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationUsecase) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationUsecase) MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error) {
+	args := m.Called(ctx, userID, notificationID)
+	if notification, ok := args.Get(0).(*entity.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationUsecase) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationUsecase) DeleteNotification(ctx context.Context, userID, notificationID uint) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationUsecase) CreateNotification(ctx context.Context, userID uint, req *dto.CreateNotificationRequest) (*entity.Notification, error) {
+	args := m.Called(ctx, userID, req)
+	if notification, ok := args.Get(0).(*entity.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationUsecase) OpenStream(ctx context.Context, userID uint, lastEventID string) (*usecase.NotificationStream, error) {
+	args := m.Called(ctx, userID, lastEventID)
+	if stream, ok := args.Get(0).(*usecase.NotificationStream); ok {
+		return stream, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationUsecase) KeepStreamAlive(ctx context.Context, stream *usecase.NotificationStream) error {
+	args := m.Called(ctx, stream)
+	return args.Error(0)
+}
+
+func (m *MockNotificationUsecase) CloseStream(ctx context.Context, stream *usecase.NotificationStream) error {
+	args := m.Called(ctx, stream)
+	return args.Error(0)
+}
+
+type stubNotificationSubscription struct {
+	events chan *entity.NotificationEvent
+}
+
+func (s *stubNotificationSubscription) Events() <-chan *entity.NotificationEvent {
+	return s.events
+}
+
+func (s *stubNotificationSubscription) Close() error {
+	return nil
+}
+
+func withUserID(userID uint, handlerFunc gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handlerFunc(c)
+	}
+}
+
+func TestNotificationHandlerGetUserNotifications(t *testing.T) {
+	mockUsecase := new(MockNotificationUsecase)
+	data := `{"campaign_id":7}`
+	notification := entity.NewNotification(1, "campaign", "お知らせ", "本文", &data)
+	mockUsecase.On("GetNotifications", mock.Anything, uint(1), &dto.NotificationQuery{UnreadOnly: true}).
+		Return(&usecase.NotificationListResponse{
+			Notifications: []*entity.Notification{notification},
+			UnreadCount:   4,
+			Total:         1,
+			Page:          1,
+			Limit:         20,
+		}, nil)
+
+	notificationHandler := handler.NewNotificationHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/notifications", withUserID(1, notificationHandler.GetUserNotifications))
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications?unread_only=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data dto.NotificationListResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(4), response.Data.UnreadCount)
+	assert.Len(t, response.Data.Notifications, 1)
+	assert.JSONEq(t, data, string(response.Data.Notifications[0].Data))
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestNotificationHandlerMarkNotificationRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*MockNotificationUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "既読にする",
+			id:   "5",
+			setupMock: func(mockUsecase *MockNotificationUsecase) {
+				notification := entity.NewNotification(1, "system", "タイトル", "本文", nil)
+				notification.MarkAsRead()
+				mockUsecase.On("MarkAsRead", mock.Anything, uint(1), uint(5)).Return(notification, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "他ユーザーの通知",
+			id:   "6",
+			setupMock: func(mockUsecase *MockNotificationUsecase) {
+				mockUsecase.On("MarkAsRead", mock.Anything, uint(1), uint(6)).Return(nil, service.ErrNotificationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "不正なID",
+			id:             "abc",
+			setupMock:      func(*MockNotificationUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockNotificationUsecase)
+			tt.setupMock(mockUsecase)
+
+			notificationHandler := handler.NewNotificationHandler(mockUsecase)
+			router := setupTestRouter()
+			router.PUT("/notifications/:id/read", withUserID(1, notificationHandler.MarkNotificationRead))
+
+			req := httptest.NewRequest(http.MethodPut, "/notifications/"+tt.id+"/read", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationHandlerMarkAllNotificationsRead(t *testing.T) {
+	mockUsecase := new(MockNotificationUsecase)
+	mockUsecase.On("MarkAllAsRead", mock.Anything, uint(1)).Return(int64(3), nil)
+
+	notificationHandler := handler.NewNotificationHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/notifications/read-all", withUserID(1, notificationHandler.MarkAllNotificationsRead))
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/read-all", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"updated_count":3`)
+}
+
+func TestNotificationHandlerDeleteNotification(t *testing.T) {
+	tests := []struct {
+		name           string
+		repoErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "削除",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "対象の通知がない",
+			repoErr:        service.ErrNotificationNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "削除に失敗",
+			repoErr:        errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockNotificationUsecase)
+			mockUsecase.On("DeleteNotification", mock.Anything, uint(1), uint(5)).Return(tt.repoErr)
+
+			notificationHandler := handler.NewNotificationHandler(mockUsecase)
+			router := setupTestRouter()
+			router.DELETE("/notifications/:id", withUserID(1, notificationHandler.DeleteNotification))
+
+			req := httptest.NewRequest(http.MethodDelete, "/notifications/5", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestNotificationHandlerCreateNotificationForUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockNotificationUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "通知を作成",
+			body: `{"type":"system","title":"お知らせ","message":"本文","priority":"high"}`,
+			setupMock: func(mockUsecase *MockNotificationUsecase) {
+				notification := entity.NewNotification(2, "system", "お知らせ", "本文", nil)
+				notification.ID = 10
+				mockUsecase.On("CreateNotification", mock.Anything, uint(2), mock.AnythingOfType("*dto.CreateNotificationRequest")).Return(notification, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "不正な優先度",
+			body: `{"type":"system","title":"お知らせ","message":"本文","priority":"critical"}`,
+			setupMock: func(mockUsecase *MockNotificationUsecase) {
+				mockUsecase.On("CreateNotification", mock.Anything, uint(2), mock.Anything).Return(nil, errors.New("invalid priority level: critical"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "存在しないユーザー",
+			body: `{"type":"system","title":"お知らせ","message":"本文"}`,
+			setupMock: func(mockUsecase *MockNotificationUsecase) {
+				mockUsecase.On("CreateNotification", mock.Anything, uint(2), mock.Anything).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "必須項目が不足",
+			body:           `{"type":"system"}`,
+			setupMock:      func(*MockNotificationUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockNotificationUsecase)
+			tt.setupMock(mockUsecase)
+
+			notificationHandler := handler.NewNotificationHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/users/:user_id/notifications", withUserID(99, notificationHandler.CreateNotificationForUser))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/2/notifications", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationHandlerStreamNotifications(t *testing.T) {
+	t.Run("未配信の通知と新着通知を配信する", func(t *testing.T) {
+		mockUsecase := new(MockNotificationUsecase)
+		missed := entity.NewNotification(1, "system", "見逃した通知", "本文", nil)
+		missed.ID = 11
+		duplicate := entity.NewNotification(1, "system", "見逃した通知", "本文", nil)
+		duplicate.ID = 11
+		created := entity.NewNotification(1, "campaign", "新着通知", "本文", nil)
+		created.ID = 12
+
+		subscription := &stubNotificationSubscription{events: make(chan *entity.NotificationEvent, 3)}
+		subscription.events <- entity.NewNotificationCreatedEvent(duplicate, 4)
+		subscription.events <- entity.NewNotificationCreatedEvent(created, 5)
+		subscription.events <- entity.NewNotificationUnreadCountEvent(1, 2)
+		close(subscription.events)
+
+		stream := &usecase.NotificationStream{
+			ID:           "stream-1",
+			UserID:       1,
+			Backlog:      []*entity.Notification{missed},
+			UnreadCount:  4,
+			Subscription: subscription,
+		}
+		mockUsecase.On("OpenStream", mock.Anything, uint(1), "10").Return(stream, nil)
+		mockUsecase.On("CloseStream", mock.Anything, stream).Return(nil)
+
+		notificationHandler := handler.NewNotificationHandler(mockUsecase)
+		router := setupTestRouter()
+		router.GET("/notifications/stream", withUserID(1, notificationHandler.StreamNotifications))
+
+		req := httptest.NewRequest(http.MethodGet, "/notifications/stream", nil)
+		req.Header.Set("Last-Event-ID", "10")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		body := w.Body.String()
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, 1, strings.Count(body, "id: 11\n"))
+		assert.Contains(t, body, "id: 12\nevent: notification\n")
+		assert.Contains(t, body, "event: unread_count\ndata: {\"unread_count\":4}")
+		assert.Contains(t, body, "event: unread_count\ndata: {\"unread_count\":5}")
+		assert.Contains(t, body, "event: unread_count\ndata: {\"unread_count\":2}")
+		assert.Less(t, strings.Index(body, "id: 11\n"), strings.Index(body, "id: 12\n"))
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("ハートビートを送信する", func(t *testing.T) {
+		mockUsecase := new(MockNotificationUsecase)
+		subscription := &stubNotificationSubscription{events: make(chan *entity.NotificationEvent)}
+		stream := &usecase.NotificationStream{ID: "stream-1", UserID: 1, Subscription: subscription}
+		mockUsecase.On("OpenStream", mock.Anything, uint(1), "").Return(stream, nil)
+		mockUsecase.On("KeepStreamAlive", mock.Anything, stream).Return(nil).Once().Run(func(mock.Arguments) {
+			close(subscription.events)
+		})
+		mockUsecase.On("CloseStream", mock.Anything, stream).Return(nil)
+
+		notificationHandler := handler.NewNotificationHandler(mockUsecase).WithHeartbeatInterval(10 * time.Millisecond)
+		router := setupTestRouter()
+		router.GET("/notifications/stream", withUserID(1, notificationHandler.StreamNotifications))
+
+		req := httptest.NewRequest(http.MethodGet, "/notifications/stream", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Contains(t, w.Body.String(), ": heartbeat\n\n")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name           string
+		openErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "同時接続数の上限",
+			openErr:        service.ErrNotificationStreamLimitExceeded,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "不正なLast-Event-ID",
+			openErr:        errors.New("invalid last event ID: abc"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "配信基盤が利用できない",
+			openErr:        service.ErrNotificationStreamUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockNotificationUsecase)
+			mockUsecase.On("OpenStream", mock.Anything, uint(1), mock.Anything).Return(nil, tt.openErr)
+
+			notificationHandler := handler.NewNotificationHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/notifications/stream", withUserID(1, notificationHandler.StreamNotifications))
+
+			req := httptest.NewRequest(http.MethodGet, "/notifications/stream?last_event_id=abc", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertNotCalled(t, "CloseStream", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type OAuthHandler struct {
+	oauthUsecase usecase.OAuthUsecaseInterface
+}
+
+func NewOAuthHandler(oauthUsecase usecase.OAuthUsecaseInterface) *OAuthHandler {
+	return &OAuthHandler{
+		oauthUsecase: oauthUsecase,
+	}
+}
+
+func (h *OAuthHandler) GetAuthorize(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.OAuthAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	client, scopes, consented, err := h.oauthUsecase.PrepareAuthorization(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondOAuthAuthorizationError(c, &req, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Authorization request validated successfully",
+		"data": dto.OAuthAuthorizationPromptResponse{
+			ClientID:        client.ClientID,
+			ClientName:      client.Name,
+			Scopes:          scopes,
+			ConsentRequired: !consented,
+		},
+	})
+}
+
+func (h *OAuthHandler) PostAuthorize(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.OAuthAuthorizeDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !req.Approve {
+		if _, _, _, err := h.oauthUsecase.PrepareAuthorization(c.Request.Context(), userID, &req.OAuthAuthorizeRequest); err != nil {
+			respondOAuthAuthorizationError(c, &req.OAuthAuthorizeRequest, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Authorization denied",
+			"data": dto.OAuthAuthorizationResponse{
+				RedirectURI: oauthRedirectURI(req.RedirectURI, url.Values{"error": {"access_denied"}}, req.State),
+			},
+		})
+		return
+	}
+
+	code, err := h.oauthUsecase.Authorize(c.Request.Context(), userID, &req.OAuthAuthorizeRequest, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondOAuthAuthorizationError(c, &req.OAuthAuthorizeRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Authorization granted",
+		"data": dto.OAuthAuthorizationResponse{
+			RedirectURI: oauthRedirectURI(req.RedirectURI, url.Values{"code": {code}}, req.State),
+		},
+	})
+}
+
+func (h *OAuthHandler) Token(c *gin.Context) {
+	var req dto.OAuthTokenRequest
+	if err := c.ShouldBindWith(&req, binding.FormPost); err != nil {
+		respondOAuthError(c, entity.ErrOAuthInvalidRequest, "Failed to issue oauth token")
+		return
+	}
+	if err := bindOAuthClientCredentials(c, &req.ClientID, &req.ClientSecret); err != nil {
+		respondOAuthError(c, err, "Failed to issue oauth token")
+		return
+	}
+
+	tokenSet, err := h.oauthUsecase.Token(c.Request.Context(), &req, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondOAuthError(c, err, "Failed to issue oauth token")
+		return
+	}
+
+	setOAuthNoStore(c)
+	c.JSON(http.StatusOK, dto.NewOAuthTokenResponse(tokenSet))
+}
+
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	var req dto.OAuthIntrospectionRequest
+	if err := c.ShouldBindWith(&req, binding.FormPost); err != nil || req.Token == "" {
+		respondOAuthError(c, entity.ErrOAuthInvalidRequest, "Failed to introspect oauth token")
+		return
+	}
+	if err := bindOAuthClientCredentials(c, &req.ClientID, &req.ClientSecret); err != nil {
+		respondOAuthError(c, err, "Failed to introspect oauth token")
+		return
+	}
+
+	info, err := h.oauthUsecase.Introspect(c.Request.Context(), &req)
+	if err != nil {
+		respondOAuthError(c, err, "Failed to introspect oauth token")
+		return
+	}
+
+	setOAuthNoStore(c)
+	c.JSON(http.StatusOK, dto.NewOAuthIntrospectionResponse(info))
+}
+
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	var req dto.OAuthRevocationRequest
+	if err := c.ShouldBindWith(&req, binding.FormPost); err != nil || req.Token == "" {
+		respondOAuthError(c, entity.ErrOAuthInvalidRequest, "Failed to revoke oauth token")
+		return
+	}
+	if err := bindOAuthClientCredentials(c, &req.ClientID, &req.ClientSecret); err != nil {
+		respondOAuthError(c, err, "Failed to revoke oauth token")
+		return
+	}
+
+	if err := h.oauthUsecase.Revoke(c.Request.Context(), &req); err != nil {
+		respondOAuthError(c, err, "Failed to revoke oauth token")
+		return
+	}
+
+	setOAuthNoStore(c)
+	c.Status(http.StatusOK)
+}
+
+func (h *OAuthHandler) GetClients(c *gin.Context) {
+	clients, err := h.oauthUsecase.ListClients(c.Request.Context())
+	if err != nil {
+		respondOAuthAdminError(c, err, "Failed to get oauth clients")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "OAuth clients retrieved successfully",
+		"data":    dto.NewOAuthClientResponsesFromEntities(clients),
+	})
+}
+
+func (h *OAuthHandler) RegisterClient(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RegisterOAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	client, secret, err := h.oauthUsecase.RegisterClient(c.Request.Context(), adminUserID, &req, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondOAuthAdminError(c, err, "Failed to register oauth client")
+		return
+	}
+
+	message := "OAuth client registered successfully"
+	if secret != "" {
+		message += ". Store the client secret now; it cannot be retrieved again"
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"data":    dto.NewRegisteredOAuthClientResponse(client, secret),
+	})
+}
+
+func (h *OAuthHandler) DeleteClient(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.oauthUsecase.DeleteClient(c.Request.Context(), adminUserID, c.Param("client_id"), c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondOAuthAdminError(c, err, "Failed to delete oauth client")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "OAuth client deleted successfully"})
+}
+
+func (h *OAuthHandler) GetConsents(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	consents, err := h.oauthUsecase.ListConsents(c.Request.Context(), userID)
+	if err != nil {
+		respondOAuthAdminError(c, err, "Failed to get oauth consents")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "OAuth consents retrieved successfully",
+		"data":    dto.NewOAuthConsentResponsesFromEntities(consents),
+	})
+}
+
+func (h *OAuthHandler) RevokeConsent(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.oauthUsecase.RevokeConsent(c.Request.Context(), userID, c.Param("client_id"), c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondOAuthAdminError(c, err, "Failed to revoke oauth consent")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "OAuth consent revoked successfully"})
+}
+
+// bindOAuthClientCredentials accepts client credentials from HTTP Basic
+// authentication as RFC 6749 section 2.3.1 describes. A client must not use
+// Basic authentication and form credentials in the same request.
+func bindOAuthClientCredentials(c *gin.Context, clientID, clientSecret *string) error {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return nil
+	}
+	if *clientSecret != "" {
+		return entity.ErrOAuthInvalidRequest
+	}
+
+	id, err := url.QueryUnescape(username)
+	if err != nil {
+		return entity.ErrOAuthInvalidClient
+	}
+	secret, err := url.QueryUnescape(password)
+	if err != nil {
+		return entity.ErrOAuthInvalidClient
+	}
+	if *clientID != "" && *clientID != id {
+		return entity.ErrOAuthInvalidRequest
+	}
+
+	*clientID = id
+	*clientSecret = secret
+	return nil
+}
+
+func oauthErrorCode(err error) (string, bool) {
+	switch {
+	case errors.Is(err, entity.ErrOAuthInvalidClient):
+		return "invalid_client", true
+	case errors.Is(err, entity.ErrOAuthInvalidGrant):
+		return "invalid_grant", true
+	case errors.Is(err, entity.ErrOAuthUnauthorizedClient):
+		return "unauthorized_client", true
+	case errors.Is(err, entity.ErrOAuthUnsupportedGrantType):
+		return "unsupported_grant_type", true
+	case errors.Is(err, entity.ErrOAuthUnsupportedResponseType):
+		return "unsupported_response_type", true
+	case errors.Is(err, entity.ErrOAuthInvalidScope):
+		return "invalid_scope", true
+	case errors.Is(err, entity.ErrOAuthAccessDenied):
+		return "access_denied", true
+	case errors.Is(err, entity.ErrOAuthInvalidRequest), errors.Is(err, entity.ErrOAuthInvalidRedirectURI):
+		return "invalid_request", true
+	default:
+		return "", false
+	}
+}
+
+func setOAuthNoStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+}
+
+func respondOAuthError(c *gin.Context, err error, message string) {
+	setOAuthNoStore(c)
+
+	code, ok := oauthErrorCode(err)
+	if !ok {
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if code == "invalid_client" {
+		status = http.StatusUnauthorized
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	c.JSON(status, gin.H{
+		"error":             code,
+		"error_description": err.Error(),
+	})
+}
+
+// respondOAuthAuthorizationError reports authorization endpoint failures. Once
+// the client and redirect URI are trusted, the error is also encoded into a
+// redirect URI the frontend can send the user back to.
+func respondOAuthAuthorizationError(c *gin.Context, req *dto.OAuthAuthorizeRequest, err error) {
+	code, ok := oauthErrorCode(err)
+	if !ok {
+		log.Printf("Failed to authorize oauth request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	response := gin.H{
+		"error":             code,
+		"error_description": err.Error(),
+	}
+	if !errors.Is(err, entity.ErrOAuthInvalidClient) && !errors.Is(err, entity.ErrOAuthInvalidRedirectURI) {
+		response["redirect_uri"] = oauthRedirectURI(req.RedirectURI, url.Values{
+			"error":             {code},
+			"error_description": {err.Error()},
+		}, req.State)
+	}
+	c.JSON(http.StatusBadRequest, response)
+}
+
+func respondOAuthAdminError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+func oauthRedirectURI(redirectURI string, params url.Values, state string) string {
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,487 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOAuthUsecase) RegisterClient(ctx context.Context, adminUserID uint, req *dto.RegisterOAuthClientRequest, ipAddress, userAgent string) (*entity.OAuthClient, string, error) {
+	args := m.Called(ctx, adminUserID, req, ipAddress, userAgent)
+	if client, ok := args.Get(0).(*entity.OAuthClient); ok {
+		return client, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockOAuthUsecase) ListClients(ctx context.Context) ([]*entity.OAuthClient, error) {
+	args := m.Called(ctx)
+	if clients, ok := args.Get(0).([]*entity.OAuthClient); ok {
+		return clients, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthUsecase) DeleteClient(ctx context.Context, adminUserID uint, clientID, ipAddress, userAgent string) error {
+	args := m.Called(ctx, adminUserID, clientID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockOAuthUsecase) PrepareAuthorization(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest) (*entity.OAuthClient, []string, bool, error) {
+	args := m.Called(ctx, userID, req)
+	client, _ := args.Get(0).(*entity.OAuthClient)
+	scopes, _ := args.Get(1).([]string)
+	return client, scopes, args.Bool(2), args.Error(3)
+}
+
+func (m *MockOAuthUsecase) Authorize(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest, ipAddress, userAgent string) (string, error) {
+	args := m.Called(ctx, userID, req, ipAddress, userAgent)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOAuthUsecase) Token(ctx context.Context, req *dto.OAuthTokenRequest, ipAddress, userAgent string) (*entity.OAuthTokenSet, error) {
+	args := m.Called(ctx, req, ipAddress, userAgent)
+	if tokenSet, ok := args.Get(0).(*entity.OAuthTokenSet); ok {
+		return tokenSet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthUsecase) Introspect(ctx context.Context, req *dto.OAuthIntrospectionRequest) (*entity.OAuthTokenInfo, error) {
+	args := m.Called(ctx, req)
+	if info, ok := args.Get(0).(*entity.OAuthTokenInfo); ok {
+		return info, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthUsecase) Revoke(ctx context.Context, req *dto.OAuthRevocationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockOAuthUsecase) ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	args := m.Called(ctx, userID)
+	if consents, ok := args.Get(0).([]*entity.OAuthConsent); ok {
+		return consents, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthUsecase) RevokeConsent(ctx context.Context, userID uint, clientID, ipAddress, userAgent string) error {
+	args := m.Called(ctx, userID, clientID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func TestOAuthHandlerToken(t *testing.T) {
+	tokenSet := &entity.OAuthTokenSet{
+		AccessToken:  "access",
+		TokenType:    entity.OAuthTokenTypeBearer,
+		ExpiresIn:    3600,
+		RefreshToken: "refresh",
+		Scopes:       []string{"profile", "points:read"},
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		basicAuth      []string
+		setupMock      func(*MockOAuthUsecase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "フォームのクライアント認証でトークンを発行する",
+			form: url.Values{"grant_type": {"authorization_code"}, "code": {"code"}, "client_id": {"oc_shop"}, "client_secret": {"secret"}},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.MatchedBy(func(req *dto.OAuthTokenRequest) bool {
+					return req.ClientID == "oc_shop" && req.ClientSecret == "secret" && req.Code == "code"
+				}), mock.Anything, mock.Anything).Return(tokenSet, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Basic認証のクライアント認証でトークンを発行する",
+			form:      url.Values{"grant_type": {"client_credentials"}},
+			basicAuth: []string{"oc_shop", "s%3Acret"},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.MatchedBy(func(req *dto.OAuthTokenRequest) bool {
+					return req.ClientID == "oc_shop" && req.ClientSecret == "s:cret"
+				}), mock.Anything, mock.Anything).Return(tokenSet, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "複数のクライアント認証方式",
+			form:           url.Values{"grant_type": {"client_credentials"}, "client_secret": {"secret"}},
+			basicAuth:      []string{"oc_shop", "secret"},
+			setupMock:      func(m *MockOAuthUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_request",
+		},
+		{
+			name: "クライアント認証に失敗",
+			form: url.Values{"grant_type": {"client_credentials"}, "client_id": {"oc_shop"}, "client_secret": {"wrong"}},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, entity.ErrOAuthInvalidClient)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid_client",
+		},
+		{
+			name: "認可コードの再利用",
+			form: url.Values{"grant_type": {"authorization_code"}, "code": {"used"}, "client_id": {"oc_shop"}},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: authorization code already used", entity.ErrOAuthInvalidGrant))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_grant",
+		},
+		{
+			name: "未対応のグラントタイプ",
+			form: url.Values{"grant_type": {"password"}, "client_id": {"oc_shop"}},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, entity.ErrOAuthUnsupportedGrantType)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsupported_grant_type",
+		},
+		{
+			name: "内部エラー",
+			form: url.Values{"grant_type": {"client_credentials"}, "client_id": {"oc_shop"}},
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("Token", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "server_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockOAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			oauthHandler := handler.NewOAuthHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/oauth/token", oauthHandler.Token)
+
+			req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicAuth != nil {
+				req.SetBasicAuth(tt.basicAuth[0], tt.basicAuth[1])
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, response["error"])
+			} else {
+				assert.Equal(t, "access", response["access_token"])
+				assert.Equal(t, "Bearer", response["token_type"])
+				assert.Equal(t, "refresh", response["refresh_token"])
+				assert.Equal(t, "profile points:read", response["scope"])
+			}
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthHandlerIntrospect(t *testing.T) {
+	t.Run("有効なトークン", func(t *testing.T) {
+		mockUsecase := new(MockOAuthUsecase)
+		mockUsecase.On("Introspect", mock.Anything, &dto.OAuthIntrospectionRequest{Token: "token", ClientID: "oc_api", ClientSecret: "secret"}).
+			Return(&entity.OAuthTokenInfo{Active: true, ClientID: "oc_shop", Scopes: []string{"profile"}, Subject: "7", TokenType: entity.OAuthTokenTypeAccessToken}, nil)
+
+		oauthHandler := handler.NewOAuthHandler(mockUsecase)
+		router := setupTestRouter()
+		router.POST("/oauth/introspect", oauthHandler.Introspect)
+
+		req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=token"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("oc_api", "secret")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.OAuthIntrospectionResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Active)
+		assert.Equal(t, "profile", response.Scope)
+		assert.Equal(t, "7", response.Sub)
+	})
+
+	t.Run("無効なトークンはactiveのみを返す", func(t *testing.T) {
+		mockUsecase := new(MockOAuthUsecase)
+		mockUsecase.On("Introspect", mock.Anything, mock.Anything).Return(&entity.OAuthTokenInfo{Active: false}, nil)
+
+		oauthHandler := handler.NewOAuthHandler(mockUsecase)
+		router := setupTestRouter()
+		router.POST("/oauth/introspect", oauthHandler.Introspect)
+
+		req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=unknown&client_id=oc_api&client_secret=secret"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"active":false}`, w.Body.String())
+	})
+
+	t.Run("トークンがない", func(t *testing.T) {
+		mockUsecase := new(MockOAuthUsecase)
+		oauthHandler := handler.NewOAuthHandler(mockUsecase)
+		router := setupTestRouter()
+		router.POST("/oauth/introspect", oauthHandler.Introspect)
+
+		req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("client_id=oc_api"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "Introspect", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthHandlerRevoke(t *testing.T) {
+	mockUsecase := new(MockOAuthUsecase)
+	mockUsecase.On("Revoke", mock.Anything, &dto.OAuthRevocationRequest{Token: "refresh", TokenTypeHint: "refresh_token", ClientID: "oc_shop"}).Return(nil)
+
+	oauthHandler := handler.NewOAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/oauth/revoke", oauthHandler.Revoke)
+
+	req := httptest.NewRequest(http.MethodPost, "/oauth/revoke", strings.NewReader("token=refresh&token_type_hint=refresh_token&client_id=oc_shop"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOAuthHandlerGetAuthorize(t *testing.T) {
+	client := &entity.OAuthClient{ClientID: "oc_shop", Name: "Shop App"}
+	query := "response_type=code&client_id=oc_shop&redirect_uri=https%3A%2F%2Fshop.example.com%2Fcallback&state=xyz"
+
+	tests := []struct {
+		name             string
+		setupMock        func(*MockOAuthUsecase)
+		expectedStatus   int
+		expectedRedirect string
+	}{
+		{
+			name: "同意画面の情報を返す",
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("PrepareAuthorization", mock.Anything, uint(1), mock.AnythingOfType("*dto.OAuthAuthorizeRequest")).
+					Return(client, []string{"profile"}, false, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "不正なリダイレクトURIにはリダイレクトしない",
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("PrepareAuthorization", mock.Anything, uint(1), mock.Anything).Return(nil, nil, false, entity.ErrOAuthInvalidRedirectURI)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "PKCEがない場合はリダイレクト先にエラーを返す",
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("PrepareAuthorization", mock.Anything, uint(1), mock.Anything).
+					Return(client, nil, false, fmt.Errorf("%w: PKCE with S256 is required", entity.ErrOAuthInvalidRequest))
+			},
+			expectedStatus:   http.StatusBadRequest,
+			expectedRedirect: "https://shop.example.com/callback?error=invalid_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockOAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			oauthHandler := handler.NewOAuthHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/oauth/authorize", withUserID(1, oauthHandler.GetAuthorize))
+
+			req := httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedStatus == http.StatusOK {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, "Shop App", data["client_name"])
+				assert.Equal(t, true, data["consent_required"])
+				return
+			}
+			if tt.expectedRedirect != "" {
+				assert.Contains(t, response["redirect_uri"], tt.expectedRedirect)
+				assert.Contains(t, response["redirect_uri"], "state=xyz")
+			} else {
+				assert.NotContains(t, response, "redirect_uri")
+			}
+		})
+	}
+}
+
+func TestOAuthHandlerPostAuthorize(t *testing.T) {
+	body := `{"response_type":"code","client_id":"oc_shop","redirect_uri":"https://shop.example.com/callback","state":"xyz","code_challenge":"challenge","code_challenge_method":"S256","approve":%t}`
+
+	t.Run("承認すると認可コード付きのリダイレクト先を返す", func(t *testing.T) {
+		mockUsecase := new(MockOAuthUsecase)
+		mockUsecase.On("Authorize", mock.Anything, uint(1), mock.MatchedBy(func(req *dto.OAuthAuthorizeRequest) bool {
+			return req.ClientID == "oc_shop" && req.CodeChallenge == "challenge"
+		}), mock.Anything, mock.Anything).Return("auth-code", nil)
+
+		oauthHandler := handler.NewOAuthHandler(mockUsecase)
+		router := setupTestRouter()
+		router.POST("/oauth/authorize", withUserID(1, oauthHandler.PostAuthorize))
+
+		req := httptest.NewRequest(http.MethodPost, "/oauth/authorize", bytes.NewBufferString(fmt.Sprintf(body, true)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data dto.OAuthAuthorizationResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://shop.example.com/callback?code=auth-code&state=xyz", response.Data.RedirectURI)
+	})
+
+	t.Run("拒否するとaccess_deniedを返す", func(t *testing.T) {
+		mockUsecase := new(MockOAuthUsecase)
+		mockUsecase.On("PrepareAuthorization", mock.Anything, uint(1), mock.Anything).
+			Return(&entity.OAuthClient{ClientID: "oc_shop"}, []string{"profile"}, false, nil)
+
+		oauthHandler := handler.NewOAuthHandler(mockUsecase)
+		router := setupTestRouter()
+		router.POST("/oauth/authorize", withUserID(1, oauthHandler.PostAuthorize))
+
+		req := httptest.NewRequest(http.MethodPost, "/oauth/authorize", bytes.NewBufferString(fmt.Sprintf(body, false)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data dto.OAuthAuthorizationResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://shop.example.com/callback?error=access_denied&state=xyz", response.Data.RedirectURI)
+		mockUsecase.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthHandlerRegisterClient(t *testing.T) {
+	client := &entity.OAuthClient{ClientID: "oc_shop", Name: "Shop App", Confidential: true}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*MockOAuthUsecase)
+		expectedStatus int
+		expectedSecret string
+	}{
+		{
+			name:        "クライアントシークレットを一度だけ返す",
+			requestBody: `{"name":"Shop App","redirect_uris":["https://shop.example.com/callback"],"grant_types":["authorization_code"],"scopes":["profile"]}`,
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("RegisterClient", mock.Anything, uint(1), mock.AnythingOfType("*dto.RegisterOAuthClientRequest"), mock.Anything, mock.Anything).
+					Return(client, "client-secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedSecret: "client-secret",
+		},
+		{
+			name:           "スコープの指定がない",
+			requestBody:    `{"name":"Shop App","grant_types":["client_credentials"]}`,
+			setupMock:      func(m *MockOAuthUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "不正なリダイレクトURI",
+			requestBody: `{"name":"Shop App","redirect_uris":["http://shop.example.com/callback"],"grant_types":["authorization_code"],"scopes":["profile"]}`,
+			setupMock: func(m *MockOAuthUsecase) {
+				m.On("RegisterClient", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, "", fmt.Errorf("failed to register oauth client: %w", entity.ErrOAuthInvalidRedirectURI))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockOAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			oauthHandler := handler.NewOAuthHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/oauth/clients", withUserID(1, oauthHandler.RegisterClient))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/oauth/clients", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedSecret != "" {
+				var response struct {
+					Data dto.RegisteredOAuthClientResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedSecret, response.Data.ClientSecret)
+				assert.Equal(t, "oc_shop", response.Data.ClientID)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthHandlerRevokeConsent(t *testing.T) {
+	mockUsecase := new(MockOAuthUsecase)
+	mockUsecase.On("RevokeConsent", mock.Anything, uint(1), "oc_unknown", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("failed to revoke oauth consent: %w", entity.ErrOAuthConsentNotFound))
+
+	oauthHandler := handler.NewOAuthHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/user/oauth/consents/:client_id", withUserID(1, oauthHandler.RevokeConsent))
+
+	req := httptest.NewRequest(http.MethodDelete, "/user/oauth/consents/oc_unknown", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
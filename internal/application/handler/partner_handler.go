@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PartnerHandler struct {
+	partnerUsecase usecase.PartnerUsecaseInterface
+}
+
+func NewPartnerHandler(partnerUsecase usecase.PartnerUsecaseInterface) *PartnerHandler {
+	return &PartnerHandler{
+		partnerUsecase: partnerUsecase,
+	}
+}
+
+func (h *PartnerHandler) GetAPIKeys(c *gin.Context) {
+	var query dto.PartnerAPIKeysQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	keys, err := h.partnerUsecase.ListAPIKeys(c.Request.Context(), &query)
+	if err != nil {
+		respondPartnerError(c, err, "Failed to get partner API keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Partner API keys retrieved successfully",
+		"data":    dto.NewPartnerAPIKeyResponsesFromEntities(keys),
+	})
+}
+
+func (h *PartnerHandler) CreateAPIKey(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreatePartnerAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	key, plaintext, err := h.partnerUsecase.CreateAPIKey(c.Request.Context(), adminUserID, &req, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondPartnerError(c, err, "Failed to create partner API key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Partner API key created successfully. Store the key now; it cannot be retrieved again",
+		"data":    dto.NewIssuedPartnerAPIKeyResponse(key, plaintext),
+	})
+}
+
+func (h *PartnerHandler) RotateAPIKey(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, ok := parsePartnerAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, plaintext, err := h.partnerUsecase.RotateAPIKey(c.Request.Context(), adminUserID, keyID, c.ClientIP(), c.GetHeader("User-Agent"))
+	if err != nil {
+		respondPartnerError(c, err, "Failed to rotate partner API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Partner API key rotated successfully. Store the key now; it cannot be retrieved again",
+		"data":    dto.NewIssuedPartnerAPIKeyResponse(key, plaintext),
+	})
+}
+
+func (h *PartnerHandler) RevokeAPIKey(c *gin.Context) {
+	adminUserID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, ok := parsePartnerAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.partnerUsecase.RevokeAPIKey(c.Request.Context(), adminUserID, keyID, c.ClientIP(), c.GetHeader("User-Agent")); err != nil {
+		respondPartnerError(c, err, "Failed to revoke partner API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Partner API key revoked successfully"})
+}
+
+func (h *PartnerHandler) SetQuota(c *gin.Context) {
+	var req dto.SetPartnerQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quota, err := h.partnerUsecase.SetQuota(c.Request.Context(), c.Param("partner_id"), &req)
+	if err != nil {
+		respondPartnerError(c, err, "Failed to set partner quota")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Partner quota updated successfully",
+		"data":    dto.NewPartnerQuotaResponseFromEntity(quota),
+	})
+}
+
+func (h *PartnerHandler) GetCurrentPartner(c *gin.Context) {
+	value, exists := c.Get("partner_api_key")
+	key, ok := value.(*entity.PartnerAPIKey)
+	if !exists || !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Partner retrieved successfully",
+		"data":    dto.NewCurrentPartnerResponse(key),
+	})
+}
+
+func parsePartnerAPIKeyID(c *gin.Context) (uint, bool) {
+	keyID, err := strconv.ParseUint(c.Param("key_id"), 10, 32)
+	if err != nil || keyID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID format"})
+		return 0, false
+	}
+	return uint(keyID), true
+}
+
+func respondPartnerError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "already"):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
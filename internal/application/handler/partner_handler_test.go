@@ -0,0 +1,261 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPartnerUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPartnerUsecase) ListAPIKeys(ctx context.Context, query *dto.PartnerAPIKeysQuery) ([]*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, query)
+	if keys, ok := args.Get(0).([]*entity.PartnerAPIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerUsecase) CreateAPIKey(ctx context.Context, adminUserID uint, req *dto.CreatePartnerAPIKeyRequest, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, adminUserID, req, ipAddress, userAgent)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerUsecase) RotateAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, adminUserID, keyID, ipAddress, userAgent)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerUsecase) RevokeAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) error {
+	args := m.Called(ctx, adminUserID, keyID, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockPartnerUsecase) SetQuota(ctx context.Context, partnerID string, req *dto.SetPartnerQuotaRequest) (*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID, req)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestPartnerHandlerCreateAPIKey(t *testing.T) {
+	key := &entity.PartnerAPIKey{ID: 4, PartnerID: "PARTNER_001", KeyPrefix: "pk_0123abcd", Permissions: []string{"integration:read:*"}, Enabled: true}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*MockPartnerUsecase)
+		expectedStatus int
+		expectedKey    string
+	}{
+		{
+			name:        "発行したキーを一度だけ返す",
+			requestBody: `{"partner_id":"PARTNER_001","permissions":["integration:read"],"limit_per_hour":100,"limit_per_day":1000}`,
+			setupMock: func(m *MockPartnerUsecase) {
+				m.On("CreateAPIKey", mock.Anything, uint(1), mock.AnythingOfType("*dto.CreatePartnerAPIKeyRequest"), mock.Anything, mock.Anything).
+					Return(key, "pk_0123abcd.secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedKey:    "pk_0123abcd.secret",
+		},
+		{
+			name:           "上限の指定がない",
+			requestBody:    `{"partner_id":"PARTNER_001"}`,
+			setupMock:      func(m *MockPartnerUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "不正な権限",
+			requestBody: `{"partner_id":"PARTNER_001","permissions":["inventory_sync"],"limit_per_hour":100,"limit_per_day":1000}`,
+			setupMock: func(m *MockPartnerUsecase) {
+				m.On("CreateAPIKey", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, "", fmt.Errorf("failed to create partner api key: %w", entity.ErrInvalidPermission))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPartnerUsecase)
+			tt.setupMock(mockUsecase)
+
+			partnerHandler := handler.NewPartnerHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/partner-keys", withUserID(1, partnerHandler.CreateAPIKey))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/partner-keys", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedKey != "" {
+				var response struct {
+					Data dto.IssuedPartnerAPIKeyResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedKey, response.Data.APIKey)
+				assert.Equal(t, "pk_0123abcd", response.Data.KeyPrefix)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPartnerHandlerGetAPIKeys(t *testing.T) {
+	mockUsecase := new(MockPartnerUsecase)
+	mockUsecase.On("ListAPIKeys", mock.Anything, &dto.PartnerAPIKeysQuery{PartnerID: "PARTNER_001"}).
+		Return([]*entity.PartnerAPIKey{{ID: 4, PartnerID: "PARTNER_001", KeyPrefix: "pk_0123abcd", KeyHash: "hash"}}, nil)
+
+	partnerHandler := handler.NewPartnerHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/admin/partner-keys", partnerHandler.GetAPIKeys)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/partner-keys?partner_id=PARTNER_001", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "pk_0123abcd")
+	assert.NotContains(t, w.Body.String(), "hash")
+	assert.NotContains(t, w.Body.String(), "api_key")
+}
+
+func TestPartnerHandlerRotateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockPartnerUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "新しいキーを返す",
+			path: "/admin/partner-keys/4/rotate",
+			setupMock: func(m *MockPartnerUsecase) {
+				m.On("RotateAPIKey", mock.Anything, uint(1), uint(4), mock.Anything, mock.Anything).
+					Return(&entity.PartnerAPIKey{ID: 4, KeyPrefix: "pk_89abcdef"}, "pk_89abcdef.secret", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "失効済みのキー",
+			path: "/admin/partner-keys/4/rotate",
+			setupMock: func(m *MockPartnerUsecase) {
+				m.On("RotateAPIKey", mock.Anything, uint(1), uint(4), mock.Anything, mock.Anything).
+					Return(nil, "", fmt.Errorf("failed to rotate partner api key: %w", entity.ErrPartnerAPIKeyRevoked))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "存在しないキー",
+			path: "/admin/partner-keys/9/rotate",
+			setupMock: func(m *MockPartnerUsecase) {
+				m.On("RotateAPIKey", mock.Anything, uint(1), uint(9), mock.Anything, mock.Anything).
+					Return(nil, "", fmt.Errorf("failed to rotate partner api key: %w", entity.ErrPartnerAPIKeyNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "不正なキーID",
+			path:           "/admin/partner-keys/abc/rotate",
+			setupMock:      func(m *MockPartnerUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPartnerUsecase)
+			tt.setupMock(mockUsecase)
+
+			partnerHandler := handler.NewPartnerHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/partner-keys/:key_id/rotate", withUserID(1, partnerHandler.RotateAPIKey))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPartnerHandlerRevokeAPIKey(t *testing.T) {
+	mockUsecase := new(MockPartnerUsecase)
+	mockUsecase.On("RevokeAPIKey", mock.Anything, uint(1), uint(4), mock.Anything, mock.Anything).Return(nil)
+
+	partnerHandler := handler.NewPartnerHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/admin/partner-keys/:key_id", withUserID(1, partnerHandler.RevokeAPIKey))
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/partner-keys/4", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPartnerHandlerSetQuota(t *testing.T) {
+	mockUsecase := new(MockPartnerUsecase)
+	mockUsecase.On("SetQuota", mock.Anything, "PARTNER_001", &dto.SetPartnerQuotaRequest{Endpoint: "/api/v1/integration/*", LimitPerHour: 10, LimitPerDay: 5}).
+		Return(nil, fmt.Errorf("failed to set partner quota: %w", entity.ErrInvalidPartnerQuota))
+
+	partnerHandler := handler.NewPartnerHandler(mockUsecase)
+	router := setupTestRouter()
+	router.PUT("/admin/partners/:partner_id/quotas", partnerHandler.SetQuota)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/partners/PARTNER_001/quotas",
+		bytes.NewBufferString(`{"endpoint":"/api/v1/integration/*","limit_per_hour":10,"limit_per_day":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPartnerHandlerGetCurrentPartner(t *testing.T) {
+	partnerHandler := handler.NewPartnerHandler(new(MockPartnerUsecase))
+	router := setupTestRouter()
+	router.GET("/integration/me", func(c *gin.Context) {
+		c.Set("partner_api_key", &entity.PartnerAPIKey{PartnerID: "PARTNER_001", KeyPrefix: "pk_0123abcd", Permissions: []string{"integration:read:*"}})
+		partnerHandler.GetCurrentPartner(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/integration/me", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.CurrentPartnerResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "PARTNER_001", response.Data.PartnerID)
+	assert.Equal(t, []string{"integration:read:*"}, response.Data.Permissions)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PermissionHandler struct {
+	permissionUsecase usecase.PermissionUsecaseInterface
+}
+
+func NewPermissionHandler(permissionUsecase usecase.PermissionUsecaseInterface) *PermissionHandler {
+	return &PermissionHandler{
+		permissionUsecase: permissionUsecase,
+	}
+}
+
+func (h *PermissionHandler) GetMyPermissions(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	h.respondPermissions(c, userID)
+}
+
+func (h *PermissionHandler) GetUserPermissions(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	h.respondPermissions(c, userID)
+}
+
+func (h *PermissionHandler) GrantUserScope(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.GrantScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userScope, err := h.permissionUsecase.GrantUserScope(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondPermissionError(c, err, "Failed to grant scope")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Scope granted successfully",
+		"data":    dto.NewUserScopeResponseFromEntity(userScope),
+	})
+}
+
+func (h *PermissionHandler) RevokeUserScope(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.permissionUsecase.RevokeUserScope(c.Request.Context(), userID, c.Param("scope")); err != nil {
+		respondPermissionError(c, err, "Failed to revoke scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scope revoked successfully"})
+}
+
+func (h *PermissionHandler) respondPermissions(c *gin.Context, userID uint) {
+	permissions, err := h.permissionUsecase.GetUserPermissions(c.Request.Context(), userID)
+	if err != nil {
+		respondPermissionError(c, err, "Failed to get permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Permissions retrieved successfully",
+		"data":    dto.NewUserPermissionsResponse(userID, permissions),
+	})
+}
+
+func parseUserIDParam(c *gin.Context) (uint, bool) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+func respondPermissionError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,164 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPermissionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPermissionUsecase) GetUserPermissions(ctx context.Context, userID uint) (entity.PermissionSet, error) {
+	args := m.Called(ctx, userID)
+	if permissions, ok := args.Get(0).(entity.PermissionSet); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionUsecase) GrantUserScope(ctx context.Context, userID uint, req *dto.GrantScopeRequest) (*entity.UserScope, error) {
+	args := m.Called(ctx, userID, req)
+	if userScope, ok := args.Get(0).(*entity.UserScope); ok {
+		return userScope, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionUsecase) RevokeUserScope(ctx context.Context, userID uint, scopeName string) error {
+	args := m.Called(ctx, userID, scopeName)
+	return args.Error(0)
+}
+
+func TestPermissionHandlerGetMyPermissions(t *testing.T) {
+	mockUsecase := new(MockPermissionUsecase)
+	mockUsecase.On("GetUserPermissions", mock.Anything, uint(1)).
+		Return(entity.NewPermissionSet(entity.MustParsePermissionGrant("user:read")), nil)
+
+	permissionHandler := handler.NewPermissionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/user/permissions", withUserID(1, permissionHandler.GetMyPermissions))
+
+	req := httptest.NewRequest(http.MethodGet, "/user/permissions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.UserPermissionsResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"user:read:*"}, response.Data.Permissions)
+}
+
+func TestPermissionHandlerGrantUserScope(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	userScope := &entity.UserScope{
+		ID:        3,
+		UserID:    1,
+		ScopeID:   4,
+		Scope:     &entity.Scope{ID: 4, Name: "fraud:write", Resource: "fraud", IsActive: true},
+		ExpiresAt: &expiresAt,
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		requestBody    string
+		setupMock      func(*MockPermissionUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "スコープを付与する",
+			path:        "/admin/users/1/scopes",
+			requestBody: fmt.Sprintf(`{"scope":"fraud:write","expires_at":%q}`, expiresAt.Format(time.RFC3339)),
+			setupMock: func(m *MockPermissionUsecase) {
+				m.On("GrantUserScope", mock.Anything, uint(1), &dto.GrantScopeRequest{Scope: "fraud:write", ExpiresAt: expiresAt}).
+					Return(userScope, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "有効期限がない",
+			path:           "/admin/users/1/scopes",
+			requestBody:    `{"scope":"fraud:write"}`,
+			setupMock:      func(m *MockPermissionUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "不正なユーザーID",
+			path:           "/admin/users/abc/scopes",
+			requestBody:    `{"scope":"fraud:write"}`,
+			setupMock:      func(m *MockPermissionUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "存在しないスコープ",
+			path:        "/admin/users/1/scopes",
+			requestBody: fmt.Sprintf(`{"scope":"unknown","expires_at":%q}`, expiresAt.Format(time.RFC3339)),
+			setupMock: func(m *MockPermissionUsecase) {
+				m.On("GrantUserScope", mock.Anything, uint(1), mock.Anything).
+					Return(nil, fmt.Errorf("failed to grant scope: %w", entity.ErrScopeNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "過去の有効期限",
+			path:        "/admin/users/1/scopes",
+			requestBody: `{"scope":"fraud:write","expires_at":"2020-01-01T00:00:00Z"}`,
+			setupMock: func(m *MockPermissionUsecase) {
+				m.On("GrantUserScope", mock.Anything, uint(1), mock.Anything).
+					Return(nil, fmt.Errorf("failed to grant scope: %w", entity.ErrInvalidScopeExpiry))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPermissionUsecase)
+			tt.setupMock(mockUsecase)
+
+			permissionHandler := handler.NewPermissionHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/users/:user_id/scopes", permissionHandler.GrantUserScope)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPermissionHandlerRevokeUserScope(t *testing.T) {
+	mockUsecase := new(MockPermissionUsecase)
+	mockUsecase.On("RevokeUserScope", mock.Anything, uint(1), "fraud").Return(nil)
+
+	permissionHandler := handler.NewPermissionHandler(mockUsecase)
+	router := setupTestRouter()
+	router.DELETE("/admin/users/:user_id/scopes/:scope", permissionHandler.RevokeUserScope)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/1/scopes/fraud", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
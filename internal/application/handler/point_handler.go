@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PointHandler struct {
+	pointUsecase usecase.PointUsecaseInterface
+}
+
+func NewPointHandler(pointUsecase usecase.PointUsecaseInterface) *PointHandler {
+	return &PointHandler{
+		pointUsecase: pointUsecase,
+	}
+}
+
+func (h *PointHandler) GetUserPointTransactions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDUint, ok := userID.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var query dto.PointTransactionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.pointUsecase.GetPointTransactions(c.Request.Context(), userIDUint, &query)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to get point transactions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get point transactions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewPointTransactionListResponseFromEntities(response.Transactions, response.Summary, response.Page, response.Limit, response.Total),
+	})
+}
+
+func (h *PointHandler) ExpireUserPoints(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	adminIDUint, ok := adminID.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var req dto.ExpirePointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.pointUsecase.ExpirePoints(c.Request.Context(), &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to expire points: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to expire points"})
+		return
+	}
+
+	message := "Points expired successfully"
+	if req.DryRun {
+		message = "Dry run completed - no points were actually expired"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    dto.NewPointExpirationResponseFromEntity(result, adminIDUint),
+	})
+}
@@ -0,0 +1,196 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPointUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPointUsecase) GetPointTransactions(ctx context.Context, userID uint, query *dto.PointTransactionQuery) (*usecase.PointTransactionListResponse, error) {
+	args := m.Called(ctx, userID, query)
+	if response, ok := args.Get(0).(*usecase.PointTransactionListResponse); ok {
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointUsecase) ExpirePoints(ctx context.Context, req *dto.ExpirePointsRequest) (*entity.PointExpirationResult, error) {
+	args := m.Called(ctx, req)
+	if result, ok := args.Get(0).(*entity.PointExpirationResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestPointHandlerGetUserPointTransactions(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockPointUsecase)
+		expectedStatus int
+	}{
+		{
+			name:  "取引履歴を取得",
+			query: "?type=EARN&page=1&limit=10",
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "獲得")
+				transaction.BalanceAfter = 100
+				mockUsecase.On("GetPointTransactions", mock.Anything, uint(1), &dto.PointTransactionQuery{Page: 1, Limit: 10, Type: "EARN"}).
+					Return(&usecase.PointTransactionListResponse{
+						Transactions: []*entity.PointTransaction{transaction},
+						Summary:      &entity.PointSummary{Balance: 100, TotalEarned: 100},
+						Total:        1,
+						Page:         1,
+						Limit:        10,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "不正な絞り込み条件",
+			query: "?type=gift",
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				mockUsecase.On("GetPointTransactions", mock.Anything, uint(1), &dto.PointTransactionQuery{Type: "gift"}).
+					Return(nil, errors.New("invalid transaction type: gift"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "取得に失敗",
+			query: "",
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				mockUsecase.On("GetPointTransactions", mock.Anything, uint(1), &dto.PointTransactionQuery{}).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPointUsecase)
+			tt.setupMock(mockUsecase)
+
+			pointHandler := handler.NewPointHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/points/transactions", func(c *gin.Context) {
+				c.Set("user_id", uint(1))
+				pointHandler.GetUserPointTransactions(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/points/transactions"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				summary := response["data"]["summary"].(map[string]interface{})
+				assert.Equal(t, float64(100), summary["current_balance"])
+				assert.Len(t, response["data"]["transactions"], 1)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPointHandlerGetUserPointTransactionsUnauthenticated(t *testing.T) {
+	pointHandler := handler.NewPointHandler(new(MockPointUsecase))
+	router := setupTestRouter()
+	router.GET("/points/transactions", pointHandler.GetUserPointTransactions)
+
+	req := httptest.NewRequest(http.MethodGet, "/points/transactions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestPointHandlerExpireUserPoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*MockPointUsecase)
+		expectedStatus int
+		expectedTotal  float64
+	}{
+		{
+			name:        "ドライランで失効予定を返す",
+			requestBody: map[string]interface{}{"dry_run": true},
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				result := entity.NewPointExpirationResult(time.Now(), true)
+				result.Add(&entity.PointExpiration{UserID: 2, ExpiredPoints: 150, RemainingPoints: 850})
+				mockUsecase.On("ExpirePoints", mock.Anything, &dto.ExpirePointsRequest{DryRun: true}).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  150,
+		},
+		{
+			name:        "未来日での本実行",
+			requestBody: map[string]interface{}{"expiration_date": "2999-01-01"},
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				mockUsecase.On("ExpirePoints", mock.Anything, &dto.ExpirePointsRequest{ExpirationDate: "2999-01-01"}).
+					Return(nil, errors.New("invalid expiration date: future dates are only allowed for dry runs"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失効処理に失敗",
+			requestBody: map[string]interface{}{},
+			setupMock: func(mockUsecase *MockPointUsecase) {
+				mockUsecase.On("ExpirePoints", mock.Anything, &dto.ExpirePointsRequest{}).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPointUsecase)
+			tt.setupMock(mockUsecase)
+
+			pointHandler := handler.NewPointHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/points/expire", func(c *gin.Context) {
+				c.Set("user_id", uint(1))
+				pointHandler.ExpireUserPoints(c)
+			})
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/admin/points/expire", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Data map[string]interface{} `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedTotal, response.Data["total_points_expired"])
+				assert.Equal(t, float64(1), response.Data["processed_by"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PreferenceHandler struct {
+	preferenceUsecase usecase.PreferenceUsecaseInterface
+}
+
+func NewPreferenceHandler(preferenceUsecase usecase.PreferenceUsecaseInterface) *PreferenceHandler {
+	return &PreferenceHandler{
+		preferenceUsecase: preferenceUsecase,
+	}
+}
+
+func (h *PreferenceHandler) GetUserPreferences(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.PreferenceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	values, err := h.preferenceUsecase.GetPreferences(c.Request.Context(), userID, query.Category)
+	if err != nil {
+		respondPreferenceError(c, err, "Failed to get user preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User preferences retrieved successfully",
+		"data":    dto.NewPreferencesResponseFromEntities(values),
+	})
+}
+
+func (h *PreferenceHandler) SetUserPreference(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	values, err := h.preferenceUsecase.SetPreference(c.Request.Context(), userID, req.Key, req.Value)
+	if err != nil {
+		respondPreferenceError(c, err, "Failed to set user preference")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User preference set successfully",
+		"data":    dto.NewPreferencesResponseFromEntities(values),
+	})
+}
+
+func (h *PreferenceHandler) UpdateUserPreferences(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	values, err := h.preferenceUsecase.SetPreferences(c.Request.Context(), userID, req.Preferences)
+	if err != nil {
+		respondPreferenceError(c, err, "Failed to update user preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User preferences updated successfully",
+		"data":    dto.NewPreferencesResponseFromEntities(values),
+	})
+}
+
+func (h *PreferenceHandler) ResetUserPreferences(c *gin.Context) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ResetPreferencesRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request format",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	values, err := h.preferenceUsecase.ResetPreferences(c.Request.Context(), userID, req.Keys)
+	if err != nil {
+		respondPreferenceError(c, err, "Failed to reset user preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User preferences reset successfully",
+		"data":    dto.NewPreferencesResponseFromEntities(values),
+	})
+}
+
+func respondPreferenceError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "invalid") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("%s: %v", message, err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
@@ -0,0 +1,187 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPreferenceUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceUsecase) GetPreferences(ctx context.Context, userID uint, category string) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, category)
+	if values, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return values, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceUsecase) SetPreference(ctx context.Context, userID uint, key string, value interface{}) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, key, value)
+	if values, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return values, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceUsecase) SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, values)
+	if result, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceUsecase) ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, keys)
+	if values, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return values, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestPreferenceHandlerGetUserPreferences(t *testing.T) {
+	mockUsecase := new(MockPreferenceUsecase)
+	mockUsecase.On("GetPreferences", mock.Anything, uint(1), "").
+		Return(entity.ResolvePreferences(map[string]string{"theme": "dark", "auto_logout_minutes": "60"}), nil)
+
+	preferenceHandler := handler.NewPreferenceHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/preferences", withUserID(1, preferenceHandler.GetUserPreferences))
+
+	req := httptest.NewRequest(http.MethodGet, "/preferences", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data dto.PreferencesResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "dark", response.Data.Preferences["theme"])
+	assert.Equal(t, float64(60), response.Data.Preferences["auto_logout_minutes"])
+	assert.Equal(t, "ja", response.Data.Preferences["language"])
+	assert.Len(t, response.Data.Settings, len(entity.PreferenceDefinitions()))
+}
+
+func TestPreferenceHandlerUpdateUserPreferences(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockPreferenceUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "一括で更新する",
+			body: `{"preferences":{"theme":"light","data_sharing":true}}`,
+			setupMock: func(mockUsecase *MockPreferenceUsecase) {
+				mockUsecase.On("SetPreferences", mock.Anything, uint(1), map[string]interface{}{"theme": "light", "data_sharing": true}).
+					Return(entity.ResolvePreferences(map[string]string{"theme": "light", "data_sharing": "true"}), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "不正な値",
+			body: `{"preferences":{"theme":"blue"}}`,
+			setupMock: func(mockUsecase *MockPreferenceUsecase) {
+				mockUsecase.On("SetPreferences", mock.Anything, uint(1), mock.Anything).
+					Return(nil, fmt.Errorf("%w: theme", entity.ErrInvalidPreferenceValue))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "保存に失敗",
+			body: `{"preferences":{"theme":"dark"}}`,
+			setupMock: func(mockUsecase *MockPreferenceUsecase) {
+				mockUsecase.On("SetPreferences", mock.Anything, uint(1), mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "preferencesがない",
+			body:           `{}`,
+			setupMock:      func(*MockPreferenceUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPreferenceUsecase)
+			tt.setupMock(mockUsecase)
+
+			preferenceHandler := handler.NewPreferenceHandler(mockUsecase)
+			router := setupTestRouter()
+			router.PUT("/preferences", withUserID(1, preferenceHandler.UpdateUserPreferences))
+
+			req := httptest.NewRequest(http.MethodPut, "/preferences", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPreferenceHandlerSetUserPreference(t *testing.T) {
+	mockUsecase := new(MockPreferenceUsecase)
+	mockUsecase.On("SetPreference", mock.Anything, uint(1), "notifications_email", false).
+		Return(entity.ResolvePreferences(map[string]string{"notifications_email": "false"}), nil)
+
+	preferenceHandler := handler.NewPreferenceHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/preferences", withUserID(1, preferenceHandler.SetUserPreference))
+
+	req := httptest.NewRequest(http.MethodPost, "/preferences", bytes.NewBufferString(`{"key":"notifications_email","value":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPreferenceHandlerResetUserPreferences(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		keys []string
+	}{
+		{name: "指定したキーを戻す", body: `{"keys":["theme"]}`, keys: []string{"theme"}},
+		{name: "本文なしで全て戻す", body: "", keys: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPreferenceUsecase)
+			mockUsecase.On("ResetPreferences", mock.Anything, uint(1), tt.keys).Return(entity.ResolvePreferences(nil), nil)
+
+			preferenceHandler := handler.NewPreferenceHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/preferences/reset", withUserID(1, preferenceHandler.ResetUserPreferences))
+
+			req := httptest.NewRequest(http.MethodPost, "/preferences/reset", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
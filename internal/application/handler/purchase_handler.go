@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseHandler struct {
+	purchaseUsecase usecase.PurchaseUsecaseInterface
+}
+
+func NewPurchaseHandler(purchaseUsecase usecase.PurchaseUsecaseInterface) *PurchaseHandler {
+	return &PurchaseHandler{
+		purchaseUsecase: purchaseUsecase,
+	}
+}
+
+func (h *PurchaseHandler) RecordPurchase(c *gin.Context) {
+	var req dto.RecordPurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	purchase, created, err := h.purchaseUsecase.RecordPurchase(c.Request.Context(), &req)
+	if err != nil {
+		respondPurchaseError(c, err, "Failed to record purchase")
+		return
+	}
+
+	if !created {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Purchase already recorded",
+			"data":    dto.NewPurchaseInfoFromEntity(purchase),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Purchase recorded successfully",
+		"data":    dto.NewPurchaseInfoFromEntity(purchase),
+	})
+}
+
+func (h *PurchaseHandler) RefundPurchase(c *gin.Context) {
+	purchase, err := h.purchaseUsecase.RefundPurchase(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondPurchaseError(c, err, "Failed to refund purchase")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Purchase refunded successfully",
+		"data":    dto.NewPurchaseInfoFromEntity(purchase),
+	})
+}
+
+func (h *PurchaseHandler) GetUserPurchases(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userIDUint, ok := userID.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	var query dto.PurchaseQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.purchaseUsecase.GetUserPurchases(c.Request.Context(), userIDUint, &query)
+	if err != nil {
+		log.Printf("Failed to get purchases: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get purchases"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewPurchaseListResponseFromEntities(response.Purchases, response.Page, response.Limit, response.Total),
+	})
+}
+
+func respondPurchaseError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "already"):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,195 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPurchaseUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseUsecase) RecordPurchase(ctx context.Context, req *dto.RecordPurchaseRequest) (*entity.Purchase, bool, error) {
+	args := m.Called(ctx, req)
+	if purchase, ok := args.Get(0).(*entity.Purchase); ok {
+		return purchase, args.Bool(1), args.Error(2)
+	}
+	return nil, false, args.Error(2)
+}
+
+func (m *MockPurchaseUsecase) RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error) {
+	args := m.Called(ctx, purchaseID)
+	if purchase, ok := args.Get(0).(*entity.Purchase); ok {
+		return purchase, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPurchaseUsecase) GetUserPurchases(ctx context.Context, userID uint, query *dto.PurchaseQuery) (*usecase.PurchaseListResponse, error) {
+	args := m.Called(ctx, userID, query)
+	if response, ok := args.Get(0).(*usecase.PurchaseListResponse); ok {
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestPurchaseHandlerRecordPurchase(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockPurchaseUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "新規の購入",
+			body: `{"purchase_id":"order-1","user_id":1,"store_id":10,"total_amount":5000}`,
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RecordPurchase", mock.Anything, mock.AnythingOfType("*dto.RecordPurchaseRequest")).
+					Return(entity.NewPurchase("order-1", 1, 10, 5000), true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "記録済みの購入",
+			body: `{"purchase_id":"order-1","user_id":1,"store_id":10,"total_amount":5000}`,
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RecordPurchase", mock.Anything, mock.Anything).
+					Return(entity.NewPurchase("order-1", 1, 10, 5000), false, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "購入IDの重複",
+			body: `{"purchase_id":"order-1","user_id":2,"store_id":10,"total_amount":5000}`,
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RecordPurchase", mock.Anything, mock.Anything).Return(nil, false, service.ErrPurchaseConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "会員情報なし",
+			body: `{"purchase_id":"order-1","user_id":9,"store_id":10,"total_amount":5000}`,
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RecordPurchase", mock.Anything, mock.Anything).Return(nil, false, service.ErrMembershipNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "必須項目が不足",
+			body:           `{"purchase_id":"order-1"}`,
+			setupMock:      func(mockUsecase *MockPurchaseUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPurchaseUsecase)
+			tt.setupMock(mockUsecase)
+
+			purchaseHandler := handler.NewPurchaseHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/purchases", purchaseHandler.RecordPurchase)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/purchases", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurchaseHandlerRefundPurchase(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*MockPurchaseUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "返金",
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				purchase := entity.NewPurchase("order-1", 1, 10, 5000)
+				purchase.MarkRefunded()
+				mockUsecase.On("RefundPurchase", mock.Anything, "order-1").Return(purchase, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "獲得ポイントが使用済み",
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RefundPurchase", mock.Anything, "order-1").Return(nil, entity.ErrPurchasePointsSpent)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "存在しない購入",
+			setupMock: func(mockUsecase *MockPurchaseUsecase) {
+				mockUsecase.On("RefundPurchase", mock.Anything, "order-1").Return(nil, service.ErrPurchaseNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockPurchaseUsecase)
+			tt.setupMock(mockUsecase)
+
+			purchaseHandler := handler.NewPurchaseHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/purchases/:id/refund", purchaseHandler.RefundPurchase)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/purchases/order-1/refund", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurchaseHandlerGetUserPurchases(t *testing.T) {
+	mockUsecase := new(MockPurchaseUsecase)
+	mockUsecase.On("GetUserPurchases", mock.Anything, uint(1), &dto.PurchaseQuery{}).
+		Return(&usecase.PurchaseListResponse{
+			Purchases: []*entity.Purchase{entity.NewPurchase("order-1", 1, 10, 5000)},
+			Total:     1,
+			Page:      1,
+			Limit:     20,
+		}, nil)
+
+	purchaseHandler := handler.NewPurchaseHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/purchases", func(c *gin.Context) {
+		c.Set("user_id", uint(1))
+		purchaseHandler.GetUserPurchases(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/purchases", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data["purchases"], 1)
+}
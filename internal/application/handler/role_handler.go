@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleUsecase usecase.RoleUsecaseInterface
+}
+
+func NewRoleHandler(roleUsecase usecase.RoleUsecaseInterface) *RoleHandler {
+	return &RoleHandler{
+		roleUsecase: roleUsecase,
+	}
+}
+
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	roles, err := h.roleUsecase.ListRoles(c.Request.Context())
+	if err != nil {
+		respondRoleError(c, err, "Failed to get roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Roles retrieved successfully",
+		"data":    dto.NewRoleResponsesFromEntities(roles),
+	})
+}
+
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	roleID, ok := parseRoleID(c)
+	if !ok {
+		return
+	}
+
+	role, err := h.roleUsecase.GetRole(c.Request.Context(), roleID)
+	if err != nil {
+		respondRoleError(c, err, "Failed to get role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role retrieved successfully",
+		"data":    dto.NewRoleResponseFromEntity(role),
+	})
+}
+
+func (h *RoleHandler) GetRoleMembers(c *gin.Context) {
+	roleID, ok := parseRoleID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.RoleMembersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.roleUsecase.ListMembers(c.Request.Context(), roleID, &query)
+	if err != nil {
+		respondRoleError(c, err, "Failed to get role members")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role members retrieved successfully",
+		"data":    dto.NewRoleMembersResponse(result.Role, result.Members, result.Page, result.Limit, result.Total),
+	})
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	actor, ok := roleActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role, err := h.roleUsecase.CreateRole(c.Request.Context(), actor, &req)
+	if err != nil {
+		respondRoleError(c, err, "Failed to create role")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Role created successfully",
+		"data":    dto.NewRoleResponseFromEntity(role),
+	})
+}
+
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	actor, ok := roleActor(c)
+	if !ok {
+		return
+	}
+
+	roleID, ok := parseRoleID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	role, err := h.roleUsecase.UpdateRole(c.Request.Context(), actor, roleID, &req)
+	if err != nil {
+		respondRoleError(c, err, "Failed to update role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+		"data":    dto.NewRoleResponseFromEntity(role),
+	})
+}
+
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	actor, ok := roleActor(c)
+	if !ok {
+		return
+	}
+
+	roleID, ok := parseRoleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.roleUsecase.DeleteRole(c.Request.Context(), actor, roleID); err != nil {
+		respondRoleError(c, err, "Failed to delete role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+func (h *RoleHandler) AssignUserRole(c *gin.Context) {
+	actor, ok := roleActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.roleUsecase.AssignRole(c.Request.Context(), actor, userID, &req); err != nil {
+		respondRoleError(c, err, "Failed to assign role")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Role assigned successfully"})
+}
+
+func (h *RoleHandler) RevokeUserRole(c *gin.Context) {
+	actor, ok := roleActor(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	roleID, ok := parseRoleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.roleUsecase.RevokeRole(c.Request.Context(), actor, userID, roleID); err != nil {
+		respondRoleError(c, err, "Failed to revoke role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role revoked successfully"})
+}
+
+func roleActor(c *gin.Context) (service.RoleActor, bool) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		return service.RoleActor{}, false
+	}
+	return service.RoleActor{
+		UserID:    userID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	}, true
+}
+
+func parseRoleID(c *gin.Context) (uint, bool) {
+	roleID, err := strconv.ParseUint(c.Param("role_id"), 10, 32)
+	if err != nil || roleID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID format"})
+		return 0, false
+	}
+	return uint(roleID), true
+}
+
+func respondRoleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, entity.ErrSelfRoleEscalation), errors.Is(err, entity.ErrPermissionEscalation), errors.Is(err, entity.ErrSystemRole), errors.Is(err, entity.ErrAdminRolePermissions):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrLastRoleMember), errors.Is(err, entity.ErrLastUserRole):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "already"):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
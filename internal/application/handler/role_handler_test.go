@@ -0,0 +1,317 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRoleUsecase struct {
+	mock.Mock
+}
+
+func (m *MockRoleUsecase) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	args := m.Called(ctx)
+	if roles, ok := args.Get(0).([]*entity.Role); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleUsecase) GetRole(ctx context.Context, roleID uint) (*entity.Role, error) {
+	args := m.Called(ctx, roleID)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleUsecase) ListMembers(ctx context.Context, roleID uint, query *dto.RoleMembersQuery) (*usecase.RoleMembersResult, error) {
+	args := m.Called(ctx, roleID, query)
+	if result, ok := args.Get(0).(*usecase.RoleMembersResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleUsecase) CreateRole(ctx context.Context, actor service.RoleActor, req *dto.CreateRoleRequest) (*entity.Role, error) {
+	args := m.Called(ctx, actor, req)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleUsecase) UpdateRole(ctx context.Context, actor service.RoleActor, roleID uint, req *dto.UpdateRoleRequest) (*entity.Role, error) {
+	args := m.Called(ctx, actor, roleID, req)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleUsecase) DeleteRole(ctx context.Context, actor service.RoleActor, roleID uint) error {
+	args := m.Called(ctx, actor, roleID)
+	return args.Error(0)
+}
+
+func (m *MockRoleUsecase) AssignRole(ctx context.Context, actor service.RoleActor, userID uint, req *dto.AssignRoleRequest) error {
+	args := m.Called(ctx, actor, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockRoleUsecase) RevokeRole(ctx context.Context, actor service.RoleActor, userID, roleID uint) error {
+	args := m.Called(ctx, actor, userID, roleID)
+	return args.Error(0)
+}
+
+func matchRoleActor(userID uint) interface{} {
+	return mock.MatchedBy(func(actor service.RoleActor) bool { return actor.UserID == userID })
+}
+
+func TestRoleHandlerGetRoles(t *testing.T) {
+	mockUsecase := new(MockRoleUsecase)
+	role := entity.NewRole("admin", "管理者")
+	role.ID = 1
+	role.Permissions = []*entity.Permission{entity.NewPermission("admin", "*", "*", "")}
+	mockUsecase.On("ListRoles", mock.Anything).Return([]*entity.Role{role}, nil)
+
+	roleHandler := handler.NewRoleHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/admin/roles", roleHandler.GetRoles)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/roles", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data []dto.RoleResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+	assert.True(t, response.Data[0].IsSystem)
+	assert.Equal(t, []string{"admin.*"}, response.Data[0].Permissions)
+}
+
+func TestRoleHandlerCreateRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*MockRoleUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "ロールを作成する",
+			requestBody: `{"name":"moderator","permissions":["fraud.read"]}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("CreateRole", mock.Anything, matchRoleActor(1), &dto.CreateRoleRequest{Name: "moderator", Permissions: []string{"fraud.read"}}).
+					Return(&entity.Role{ID: 3, Name: "moderator"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "名前がない",
+			requestBody:    `{"permissions":["fraud.read"]}`,
+			setupMock:      func(m *MockRoleUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "保有しない権限",
+			requestBody: `{"name":"moderator","permissions":["fraud.write"]}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("CreateRole", mock.Anything, matchRoleActor(1), mock.Anything).
+					Return(nil, fmt.Errorf("failed to create role: %w", entity.ErrPermissionEscalation))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "重複するロール名",
+			requestBody: `{"name":"moderator"}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("CreateRole", mock.Anything, matchRoleActor(1), mock.Anything).
+					Return(nil, fmt.Errorf("failed to create role: %w", entity.ErrRoleAlreadyExists))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockRoleUsecase)
+			tt.setupMock(mockUsecase)
+
+			roleHandler := handler.NewRoleHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/roles", withUserID(1, roleHandler.CreateRole))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/roles", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleHandlerDeleteRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockRoleUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "ロールを削除する",
+			path: "/admin/roles/3",
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("DeleteRole", mock.Anything, matchRoleActor(1), uint(3)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "システムロール",
+			path: "/admin/roles/1",
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("DeleteRole", mock.Anything, matchRoleActor(1), uint(1)).
+					Return(fmt.Errorf("failed to delete role: %w", entity.ErrSystemRole))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "不正なロールID",
+			path:           "/admin/roles/abc",
+			setupMock:      func(m *MockRoleUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockRoleUsecase)
+			tt.setupMock(mockUsecase)
+
+			roleHandler := handler.NewRoleHandler(mockUsecase)
+			router := setupTestRouter()
+			router.DELETE("/admin/roles/:role_id", withUserID(1, roleHandler.DeleteRole))
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleHandlerAssignUserRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		requestBody    string
+		setupMock      func(*MockRoleUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "ロールを付与する",
+			path:        "/admin/users/2/roles",
+			requestBody: `{"role_id":3}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("AssignRole", mock.Anything, matchRoleActor(1), uint(2), &dto.AssignRoleRequest{RoleID: 3}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "自分自身への付与",
+			path:        "/admin/users/1/roles",
+			requestBody: `{"role_id":1}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("AssignRole", mock.Anything, matchRoleActor(1), uint(1), mock.Anything).
+					Return(fmt.Errorf("failed to assign role: %w", entity.ErrSelfRoleEscalation))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "付与済みのロール",
+			path:        "/admin/users/2/roles",
+			requestBody: `{"role_id":3}`,
+			setupMock: func(m *MockRoleUsecase) {
+				m.On("AssignRole", mock.Anything, matchRoleActor(1), uint(2), mock.Anything).
+					Return(fmt.Errorf("failed to assign role: %w", entity.ErrRoleAlreadyAssigned))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "ロールIDがない",
+			path:           "/admin/users/2/roles",
+			requestBody:    `{}`,
+			setupMock:      func(m *MockRoleUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockRoleUsecase)
+			tt.setupMock(mockUsecase)
+
+			roleHandler := handler.NewRoleHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/users/:user_id/roles", withUserID(1, roleHandler.AssignUserRole))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleHandlerRevokeUserRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "ロールを剥奪する", expectedStatus: http.StatusOK},
+		{name: "最後の管理者", err: fmt.Errorf("failed to revoke role: %w", entity.ErrLastRoleMember), expectedStatus: http.StatusConflict},
+		{name: "付与されていないロール", err: fmt.Errorf("failed to revoke role: %w", entity.ErrRoleAssignmentNotFound), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockRoleUsecase)
+			mockUsecase.On("RevokeRole", mock.Anything, matchRoleActor(1), uint(2), uint(1)).Return(tt.err)
+
+			roleHandler := handler.NewRoleHandler(mockUsecase)
+			router := setupTestRouter()
+			router.DELETE("/admin/users/:user_id/roles/:role_id", withUserID(1, roleHandler.RevokeUserRole))
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/users/2/roles/1", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type TierHandler struct {
+	tierUsecase usecase.TierUsecaseInterface
+}
+
+func NewTierHandler(tierUsecase usecase.TierUsecaseInterface) *TierHandler {
+	return &TierHandler{
+		tierUsecase: tierUsecase,
+	}
+}
+
+func (h *TierHandler) GetTiers(c *gin.Context) {
+	tiers, err := h.tierUsecase.GetTiers(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to get tiers: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tiers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewTierInfoListFromEntities(tiers),
+	})
+}
+
+func (h *TierHandler) GetTier(c *gin.Context) {
+	id, ok := parseTierID(c)
+	if !ok {
+		return
+	}
+
+	tier, err := h.tierUsecase.GetTier(c.Request.Context(), id)
+	if err != nil {
+		respondTierError(c, err, "Failed to get tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewTierInfoFromEntity(tier),
+	})
+}
+
+func (h *TierHandler) CreateTier(c *gin.Context) {
+	var req dto.CreateTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tier, err := h.tierUsecase.CreateTier(c.Request.Context(), &req)
+	if err != nil {
+		respondTierError(c, err, "Failed to create tier")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tier created successfully",
+		"data":    dto.NewTierInfoFromEntity(tier),
+	})
+}
+
+func (h *TierHandler) UpdateTier(c *gin.Context) {
+	id, ok := parseTierID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	tier, err := h.tierUsecase.UpdateTier(c.Request.Context(), id, &req)
+	if err != nil {
+		respondTierError(c, err, "Failed to update tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tier updated successfully",
+		"data":    dto.NewTierInfoFromEntity(tier),
+	})
+}
+
+func (h *TierHandler) DeleteTier(c *gin.Context) {
+	id, ok := parseTierID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tierUsecase.DeleteTier(c.Request.Context(), id); err != nil {
+		respondTierError(c, err, "Failed to delete tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tier deleted successfully",
+		"tier_id": id,
+	})
+}
+
+func (h *TierHandler) EvaluateTiers(c *gin.Context) {
+	result, err := h.tierUsecase.EvaluateTiers(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to evaluate tiers: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate tiers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tier evaluation completed",
+		"data":    dto.NewTierEvaluationResponseFromEntity(result),
+	})
+}
+
+func (h *TierHandler) EvaluateUserTier(c *gin.Context) {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	change, err := h.tierUsecase.EvaluateUserTier(c.Request.Context(), uint(userID))
+	if err != nil {
+		respondTierError(c, err, "Failed to evaluate user tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewTierChangeResponseFromEntity(uint(userID), change),
+	})
+}
+
+func parseTierID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tier ID format"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondTierError(c *gin.Context, err error, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.Contains(err.Error(), "already exists"), strings.Contains(err.Error(), "in use"):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,238 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTierUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTierUsecase) GetTiers(ctx context.Context) ([]*entity.MembershipTier, error) {
+	args := m.Called(ctx)
+	if tiers, ok := args.Get(0).([]*entity.MembershipTier); ok {
+		return tiers, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierUsecase) GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, id)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierUsecase) CreateTier(ctx context.Context, req *dto.CreateTierRequest) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, req)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierUsecase) UpdateTier(ctx context.Context, id uint, req *dto.UpdateTierRequest) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, id, req)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierUsecase) DeleteTier(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTierUsecase) EvaluateTiers(ctx context.Context) (*entity.TierEvaluationResult, error) {
+	args := m.Called(ctx)
+	if result, ok := args.Get(0).(*entity.TierEvaluationResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierUsecase) EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error) {
+	args := m.Called(ctx, userID)
+	if change, ok := args.Get(0).(*entity.TierChange); ok {
+		return change, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestTierHandlerGetTiers(t *testing.T) {
+	mockUsecase := new(MockTierUsecase)
+	gold := entity.NewMembershipTier("Gold", 3, "", `{"discount":5}`, `{"min_points":5000}`)
+	mockUsecase.On("GetTiers", mock.Anything).Return([]*entity.MembershipTier{gold}, nil)
+
+	tierHandler := handler.NewTierHandler(mockUsecase)
+	router := setupTestRouter()
+	router.GET("/admin/tiers", tierHandler.GetTiers)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/tiers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "Gold", response.Data[0]["name"])
+	assert.Equal(t, float64(5000), response.Data[0]["requirements"].(map[string]interface{})["min_points"])
+}
+
+func TestTierHandlerCreateTier(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockTierUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "ティアを作成",
+			body: `{"name":"Platinum","level":4,"requirements":{"min_points":10000}}`,
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("CreateTier", mock.Anything, mock.AnythingOfType("*dto.CreateTierRequest")).
+					Return(entity.NewMembershipTier("Platinum", 4, "", "{}", `{"min_points":10000}`), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "必須項目が不足",
+			body:           `{"level":4}`,
+			setupMock:      func(mockUsecase *MockTierUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "入力値が不正",
+			body: `{"name":"Platinum","level":4,"benefits":[1]}`,
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("CreateTier", mock.Anything, mock.Anything).
+					Return(nil, errors.New("invalid benefits: must be a JSON object"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "同名のティアが存在",
+			body: `{"name":"Gold","level":4}`,
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("CreateTier", mock.Anything, mock.Anything).Return(nil, errors.New("tier already exists"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTierUsecase)
+			tt.setupMock(mockUsecase)
+
+			tierHandler := handler.NewTierHandler(mockUsecase)
+			router := setupTestRouter()
+			router.POST("/admin/tiers", tierHandler.CreateTier)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/tiers", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTierHandlerDeleteTier(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*MockTierUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "ティアを削除",
+			id:   "4",
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("DeleteTier", mock.Anything, uint(4)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "会員が所属している",
+			id:   "3",
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("DeleteTier", mock.Anything, uint(3)).Return(errors.New("tier is in use by members"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "存在しないティア",
+			id:   "99",
+			setupMock: func(mockUsecase *MockTierUsecase) {
+				mockUsecase.On("DeleteTier", mock.Anything, uint(99)).Return(errors.New("tier not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "不正なID",
+			id:             "abc",
+			setupMock:      func(mockUsecase *MockTierUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTierUsecase)
+			tt.setupMock(mockUsecase)
+
+			tierHandler := handler.NewTierHandler(mockUsecase)
+			router := setupTestRouter()
+			router.DELETE("/admin/tiers/:id", tierHandler.DeleteTier)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/tiers/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTierHandlerEvaluateTiers(t *testing.T) {
+	mockUsecase := new(MockTierUsecase)
+	mockUsecase.On("EvaluateTiers", mock.Anything).
+		Return(&entity.TierEvaluationResult{Evaluated: 10, Promoted: 2, Demoted: 1, GraceStart: 3}, nil)
+
+	tierHandler := handler.NewTierHandler(mockUsecase)
+	router := setupTestRouter()
+	router.POST("/admin/tiers/evaluate", tierHandler.EvaluateTiers)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/tiers/evaluate", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(2), response.Data["promoted"])
+	assert.Equal(t, float64(3), response.Data["grace_started"])
+}
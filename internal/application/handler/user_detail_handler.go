@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type UserDetailHandler struct {
+	userDetailUsecase usecase.UserDetailUsecaseInterface
+}
+
+func NewUserDetailHandler(userDetailUsecase usecase.UserDetailUsecaseInterface) *UserDetailHandler {
+	return &UserDetailHandler{
+		userDetailUsecase: userDetailUsecase,
+	}
+}
+
+func (h *UserDetailHandler) GetUserDetails(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	detail, err := h.userDetailUsecase.GetUserDetail(c.Request.Context(), uint(userID))
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to get user details: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user details"})
+		return
+	}
+
+	for section, sectionErr := range detail.SectionErrors {
+		log.Printf("User detail section %s unavailable for user %d: %v", section, userID, sectionErr)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "User details retrieved successfully",
+		"data":         dto.NewUserDetailResponse(detail),
+		"retrieved_by": adminID,
+	})
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/handler"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserDetailUsecase struct {
+	mock.Mock
+}
+
+func (m *MockUserDetailUsecase) GetUserDetail(ctx context.Context, userID uint) (*entity.UserDetail, error) {
+	args := m.Called(ctx, userID)
+	if detail, ok := args.Get(0).(*entity.UserDetail); ok {
+		return detail, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestUserDetailHandlerGetUserDetails(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		setupMock      func(*MockUserDetailUsecase)
+		expectedStatus int
+	}{
+		{
+			name:   "一部セクションが取得できなくても成功する",
+			userID: "1",
+			setupMock: func(mockUsecase *MockUserDetailUsecase) {
+				mockUsecase.On("GetUserDetail", mock.Anything, uint(1)).Return(&entity.UserDetail{
+					User:          &entity.User{ID: 1, Name: "テストユーザー", Email: "test@example.com"},
+					Profile:       entity.NewUserProfile(1),
+					Roles:         []*entity.Role{entity.NewRole("admin", "")},
+					SectionErrors: map[string]error{"membership": errors.New("database error")},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "不正なユーザーID",
+			userID:         "0",
+			setupMock:      func(mockUsecase *MockUserDetailUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "ユーザーが存在しない",
+			userID: "2",
+			setupMock: func(mockUsecase *MockUserDetailUsecase) {
+				mockUsecase.On("GetUserDetail", mock.Anything, uint(2)).Return(nil, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockUserDetailUsecase)
+			tt.setupMock(mockUsecase)
+
+			userDetailHandler := handler.NewUserDetailHandler(mockUsecase)
+			router := setupTestRouter()
+			router.GET("/admin/users/:user_id", withUserID(99, userDetailHandler.GetUserDetails))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/users/"+tt.userID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Data dto.UserDetailResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "test@example.com", response.Data.User.Email)
+				assert.NotNil(t, response.Data.Profile)
+				assert.Nil(t, response.Data.Membership)
+				assert.Equal(t, []string{"admin"}, response.Data.Roles)
+				assert.Equal(t, []string{"membership"}, response.Data.UnavailableSections)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -326,237 +326,3 @@ func (h *UserHandler) GetSystemHealth(c *gin.Context) {
 		"data":    systemHealth,
 	})
 }
-
-func (h *UserHandler) GetUserDetails(c *gin.Context) {
-	adminID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
-		return
-	}
-
-	userIDStr := c.Param("user_id")
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
-		return
-	}
-
-	if userID == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID cannot be zero"})
-		return
-	}
-
-	userDetails := map[string]interface{}{
-		"user_id": userID,
-		"basic_info": map[string]interface{}{
-			"name":       "Sample User",
-			"email":      "user@example.com",
-			"age":        25,
-			"created_at": "2025-01-01T00:00:00Z",
-			"updated_at": "2025-08-27T12:52:00Z",
-			"last_login": "2025-08-27T10:30:00Z",
-			"status":     "active",
-		},
-		"profile": map[string]interface{}{
-			"phone_number":       "+81-90-1234-5678",
-			"address":            "Tokyo, Japan",
-			"bio":                "Sample user biography",
-			"profile_completion": 85,
-		},
-		"security": map[string]interface{}{
-			"login_attempts":     3,
-			"failed_attempts":    0,
-			"two_factor_enabled": false,
-			"trusted_devices":    2,
-			"security_score":     92,
-		},
-		"activity": map[string]interface{}{
-			"total_logins":   42,
-			"points_balance": 1500,
-			"total_earned":   2500,
-			"total_spent":    1000,
-		},
-		"retrieved_by": adminID,
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User details retrieved successfully",
-		"data":    userDetails,
-	})
-}
-
-func (h *UserHandler) AddPointsToUser(c *gin.Context) {
-	adminID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
-		return
-	}
-
-	userIDStr := c.Param("user_id")
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
-		return
-	}
-
-	var req struct {
-		Points      int    `json:"points" binding:"required"`
-		Description string `json:"description" binding:"required"`
-		Type        string `json:"type"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	if req.Points <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Points must be positive"})
-		return
-	}
-
-	if req.Points > 10000 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Points cannot exceed 10000 per transaction"})
-		return
-	}
-
-	if req.Type == "" {
-		req.Type = "admin_grant"
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Points added successfully",
-		"data": map[string]interface{}{
-			"user_id":     userID,
-			"points":      req.Points,
-			"description": req.Description,
-			"type":        req.Type,
-			"new_balance": 1500 + req.Points,
-			"added_by":    adminID,
-			"timestamp":   "2025-08-27T12:52:00Z",
-		},
-	})
-}
-
-func (h *UserHandler) CreateNotificationForUser(c *gin.Context) {
-	adminID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
-		return
-	}
-
-	userIDStr := c.Param("user_id")
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
-		return
-	}
-
-	var req struct {
-		Type     string `json:"type" binding:"required"`
-		Title    string `json:"title" binding:"required"`
-		Message  string `json:"message" binding:"required"`
-		Priority string `json:"priority"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	if req.Priority == "" {
-		req.Priority = "medium"
-	}
-
-	validPriorities := []string{"low", "medium", "high", "urgent"}
-	isValidPriority := false
-	for _, priority := range validPriorities {
-		if req.Priority == priority {
-			isValidPriority = true
-			break
-		}
-	}
-
-	if !isValidPriority {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority level"})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Notification created successfully",
-		"data": map[string]interface{}{
-			"notification_id": 123,
-			"user_id":         userID,
-			"type":            req.Type,
-			"title":           req.Title,
-			"message":         req.Message,
-			"priority":        req.Priority,
-			"created_by":      adminID,
-			"created_at":      "2025-08-27T12:52:00Z",
-			"is_read":         false,
-		},
-	})
-}
-
-func (h *UserHandler) ExpireUserPoints(c *gin.Context) {
-	adminID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
-		return
-	}
-
-	var req struct {
-		ExpirationDate string `json:"expiration_date"`
-		DryRun         bool   `json:"dry_run"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	if req.ExpirationDate == "" {
-		req.ExpirationDate = "2025-08-27T12:52:00Z"
-	}
-
-	expiredPoints := map[string]interface{}{
-		"total_users_affected": 25,
-		"total_points_expired": 5000,
-		"expiration_date":      req.ExpirationDate,
-		"dry_run":              req.DryRun,
-		"processed_by":         adminID,
-		"processed_at":         "2025-08-27T12:52:00Z",
-		"details": []map[string]interface{}{
-			{
-				"user_id":          1,
-				"expired_points":   200,
-				"remaining_points": 1300,
-			},
-			{
-				"user_id":          2,
-				"expired_points":   150,
-				"remaining_points": 850,
-			},
-		},
-	}
-
-	status := http.StatusOK
-	message := "Points expired successfully"
-	if req.DryRun {
-		message = "Dry run completed - no points were actually expired"
-	}
-
-	c.JSON(status, gin.H{
-		"message": message,
-		"data":    expiredPoints,
-	})
-}
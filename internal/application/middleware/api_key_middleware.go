@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyMiddleware struct {
+	partnerService service.PartnerDomainServiceInterface
+}
+
+func NewAPIKeyMiddleware(partnerService service.PartnerDomainServiceInterface) *APIKeyMiddleware {
+	return &APIKeyMiddleware{
+		partnerService: partnerService,
+	}
+}
+
+// RequireAPIKey authenticates the partner key and charges the partner quota
+// only once the key rate limit and the required permission have passed, so
+// rejected requests do not use up the partner's quota.
+func (m *APIKeyMiddleware) RequireAPIKey(permission string) gin.HandlerFunc {
+	required := entity.MustParsePermissionGrant(permission)
+
+	return func(c *gin.Context) {
+		plaintext := c.GetHeader(APIKeyHeader)
+		if plaintext == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+			c.Abort()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key, err := m.partnerService.Authenticate(ctx, plaintext)
+		if err != nil {
+			if errors.Is(err, entity.ErrInvalidPartnerAPIKey) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			} else {
+				log.Printf("Failed to authenticate partner API key: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate API key"})
+			}
+			c.Abort()
+			return
+		}
+
+		if err := m.partnerService.ConsumeKeyRateLimit(ctx, key); err != nil {
+			if errors.Is(err, entity.ErrPartnerKeyRateLimited) {
+				hourReset, _ := entity.PartnerQuotaWindow(time.Now())
+				c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(time.Until(hourReset).Seconds()))))
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error":   "Rate limit exceeded",
+					"message": fmt.Sprintf("API key rate limit exceeded. Limit: %d per hour", key.RateLimit),
+				})
+			} else {
+				log.Printf("Failed to apply rate limit for partner API key %d: %v", key.ID, err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Rate limit service unavailable"})
+			}
+			c.Abort()
+			return
+		}
+
+		permissions := key.Grants()
+		if !permissions.Allows(required) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		endpoint := c.FullPath()
+		if endpoint == "" {
+			endpoint = c.Request.URL.Path
+		}
+
+		quota, err := m.partnerService.ConsumeQuota(ctx, key.PartnerID, endpoint)
+		if err != nil && !errors.Is(err, entity.ErrPartnerQuotaExceeded) {
+			log.Printf("Failed to consume quota for partner %s: %v", key.PartnerID, err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Rate limit service unavailable"})
+			c.Abort()
+			return
+		}
+
+		if quota != nil {
+			now := time.Now()
+			hourReset, _ := entity.PartnerQuotaWindow(now)
+			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", quota.LimitPerHour))
+			c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", quota.RemainingHour(now)))
+			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", hourReset.Unix()))
+			c.Header("X-RateLimit-Daily-Limit", fmt.Sprintf("%d", quota.LimitPerDay))
+			c.Header("X-RateLimit-Daily-Remaining", fmt.Sprintf("%d", quota.RemainingDay(now)))
+
+			if errors.Is(err, entity.ErrPartnerQuotaExceeded) {
+				c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(quota.RetryAfter(now).Seconds()))))
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error":   "Rate limit exceeded",
+					"message": fmt.Sprintf("Partner quota exceeded for %s. Limit: %d per hour, %d per day", quota.Endpoint, quota.LimitPerHour, quota.LimitPerDay),
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("partner_id", key.PartnerID)
+		c.Set("partner_api_key", key)
+		c.Set("partner_permissions", permissions)
+
+		c.Next()
+	}
+}
+
+func (m *APIKeyMiddleware) RequirePartnerPermission(permission string) gin.HandlerFunc {
+	required := entity.MustParsePermissionGrant(permission)
+
+	return func(c *gin.Context) {
+		value, exists := c.Get("partner_permissions")
+		permissions, ok := value.(entity.PermissionSet)
+		if !exists || !ok || !permissions.Allows(required) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
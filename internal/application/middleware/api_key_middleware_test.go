@@ -0,0 +1,256 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/middleware"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPartnerDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPartnerDomainService) ListAPIKeys(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, partnerID)
+	if keys, ok := args.Get(0).([]*entity.PartnerAPIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) CreateAPIKey(ctx context.Context, partnerID, partnerName string, permissions []string, limitPerHour, limitPerDay int) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, partnerID, partnerName, permissions, limitPerHour, limitPerDay)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerDomainService) RotateAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, keyID)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerDomainService) RevokeAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, keyID)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) SetQuota(ctx context.Context, partnerID, endpoint string, limitPerHour, limitPerDay int) (*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID, endpoint, limitPerHour, limitPerDay)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) Authenticate(ctx context.Context, plaintext string) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, plaintext)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) ConsumeQuota(ctx context.Context, partnerID, endpoint string) (*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID, endpoint)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) ConsumeKeyRateLimit(ctx context.Context, key *entity.PartnerAPIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	key := &entity.PartnerAPIKey{ID: 4, PartnerID: "PARTNER_001", Permissions: []string{"integration:read:*"}, Enabled: true}
+	writeOnlyKey := &entity.PartnerAPIKey{ID: 5, PartnerID: "PARTNER_001", Permissions: []string{"integration.inventory:write"}, Enabled: true}
+	hourReset, _ := entity.PartnerQuotaWindow(time.Now())
+
+	tests := []struct {
+		name           string
+		apiKey         string
+		setupMock      func(*MockPartnerDomainService)
+		expectedStatus int
+		expectedBody   string
+		expectedHeader map[string]string
+		skipsQuota     bool
+	}{
+		{
+			name:   "有効なキーで上限内",
+			apiKey: "pk_0123abcd.secret",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.secret").Return(key, nil)
+				m.On("ConsumeQuota", mock.Anything, "PARTNER_001", "/integration/me").Return(&entity.PartnerQuota{
+					Endpoint: "*", LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 1, CurrentDayCount: 40, ResetTime: &hourReset,
+				}, nil)
+				m.On("ConsumeKeyRateLimit", mock.Anything, key).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "PARTNER_001",
+			expectedHeader: map[string]string{"X-RateLimit-Remaining": "99", "X-RateLimit-Daily-Remaining": "960"},
+		},
+		{
+			name:           "キーがない",
+			setupMock:      func(m *MockPartnerDomainService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "API key required",
+		},
+		{
+			name:   "無効なキー",
+			apiKey: "pk_0123abcd.revoked",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.revoked").Return(nil, entity.ErrInvalidPartnerAPIKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Invalid API key",
+		},
+		{
+			name:   "上限超過",
+			apiKey: "pk_0123abcd.secret",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.secret").Return(key, nil)
+				m.On("ConsumeQuota", mock.Anything, "PARTNER_001", "/integration/me").Return(&entity.PartnerQuota{
+					Endpoint: "*", LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 100, CurrentDayCount: 400, ResetTime: &hourReset,
+				}, entity.ErrPartnerQuotaExceeded)
+				m.On("ConsumeKeyRateLimit", mock.Anything, key).Return(nil)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   "Partner quota exceeded",
+			expectedHeader: map[string]string{"X-RateLimit-Remaining": "0"},
+		},
+		{
+			name:   "キーごとの上限超過",
+			apiKey: "pk_0123abcd.secret",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.secret").Return(key, nil)
+				m.On("ConsumeKeyRateLimit", mock.Anything, key).Return(entity.ErrPartnerKeyRateLimited)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   "API key rate limit exceeded",
+			skipsQuota:     true,
+		},
+		{
+			name:   "権限がなければクォータを消費しない",
+			apiKey: "pk_0123abcd.readonly",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.readonly").Return(writeOnlyKey, nil)
+				m.On("ConsumeKeyRateLimit", mock.Anything, writeOnlyKey).Return(nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "Insufficient permissions",
+			skipsQuota:     true,
+		},
+		{
+			name:   "上限の確認に失敗したら拒否する",
+			apiKey: "pk_0123abcd.secret",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.secret").Return(key, nil)
+				m.On("ConsumeKeyRateLimit", mock.Anything, key).Return(nil)
+				m.On("ConsumeQuota", mock.Anything, "PARTNER_001", "/integration/me").Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "Rate limit service unavailable",
+		},
+		{
+			name:   "キーごとの上限の確認に失敗したら拒否する",
+			apiKey: "pk_0123abcd.secret",
+			setupMock: func(m *MockPartnerDomainService) {
+				m.On("Authenticate", mock.Anything, "pk_0123abcd.secret").Return(key, nil)
+				m.On("ConsumeKeyRateLimit", mock.Anything, key).Return(errors.New("redis error"))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "Rate limit service unavailable",
+			skipsQuota:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partnerService := &MockPartnerDomainService{}
+			tt.setupMock(partnerService)
+			apiKeyMiddleware := middleware.NewAPIKeyMiddleware(partnerService)
+
+			router := gin.New()
+			router.GET("/integration/me", apiKeyMiddleware.RequireAPIKey("integration:read"), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"partner_id": c.GetString("partner_id")})
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/integration/me", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			for header, value := range tt.expectedHeader {
+				assert.Equal(t, value, w.Header().Get(header))
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+			}
+			if tt.skipsQuota {
+				partnerService.AssertNotCalled(t, "ConsumeQuota", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRequirePartnerPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		permissions    entity.PermissionSet
+		expectedStatus int
+	}{
+		{name: "権限あり", permissions: entity.NewPermissionSet(entity.MustParsePermissionGrant("integration:write")), expectedStatus: http.StatusOK},
+		{name: "権限なし", permissions: entity.NewPermissionSet(entity.MustParsePermissionGrant("integration:read")), expectedStatus: http.StatusForbidden},
+		{name: "APIキー認証されていない", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyMiddleware := middleware.NewAPIKeyMiddleware(&MockPartnerDomainService{})
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.permissions != nil {
+					c.Set("partner_permissions", tt.permissions)
+				}
+				c.Next()
+			})
+			router.POST("/integration/inventory/sync", apiKeyMiddleware.RequirePartnerPermission("integration.inventory:write"), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "success"})
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "/integration/inventory/sync", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -1,24 +1,29 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/gin-gonic/gin"
 )
 
 type AuthMiddleware struct {
-	authService  *service.AuthDomainService
-	cacheService *external.CacheService
+	authService       *service.AuthDomainService
+	cacheService      *external.CacheService
+	permissionService service.PermissionDomainServiceInterface
 }
 
-func NewAuthMiddleware(authService *service.AuthDomainService, cacheService *external.CacheService) *AuthMiddleware {
+func NewAuthMiddleware(authService *service.AuthDomainService, cacheService *external.CacheService, permissionService service.PermissionDomainServiceInterface) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService:  authService,
-		cacheService: cacheService,
+		authService:       authService,
+		cacheService:      cacheService,
+		permissionService: permissionService,
 	}
 }
 
@@ -33,7 +38,13 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		if m.cacheService != nil {
 			blacklisted, err := m.cacheService.IsTokenBlacklisted(c.Request.Context(), token)
-			if err == nil && blacklisted {
+			if err != nil {
+				log.Printf("Failed to check token blacklist: %v", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify token"})
+				c.Abort()
+				return
+			}
+			if blacklisted {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
 				c.Abort()
 				return
@@ -47,6 +58,17 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		if err := m.authService.CheckTokenVersion(c.Request.Context(), claims); err != nil {
+			if errors.Is(err, service.ErrTokenRevoked) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			} else {
+				log.Printf("Failed to check token version for user %d: %v", claims.UserID, err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify token"})
+			}
+			c.Abort()
+			return
+		}
+
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_roles", claims.Roles)
@@ -109,6 +131,42 @@ func (m *AuthMiddleware) RequireAnyRole(requiredRoles ...string) gin.HandlerFunc
 	}
 }
 
+func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
+	required := entity.MustParsePermissionGrant(permission)
+
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(http.StatusForbidden, gin.H{"error": "User ID not found"})
+			c.Abort()
+			return
+		}
+
+		userIDUint, ok := userID.(uint)
+		if !ok || m.permissionService == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		allowed, err := m.permissionService.HasPermission(c.Request.Context(), userIDUint, required)
+		if err != nil {
+			log.Printf("Failed to resolve permissions for user %d: %v", userIDUint, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve permissions"})
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func (m *AuthMiddleware) getUserRoles(c *gin.Context) ([]string, error) {
 	roles, exists := c.Get("user_roles")
 	if !exists {
@@ -144,14 +202,14 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 
 		if m.cacheService != nil {
 			blacklisted, err := m.cacheService.IsTokenBlacklisted(c.Request.Context(), token)
-			if err == nil && blacklisted {
+			if err != nil || blacklisted {
 				c.Next()
 				return
 			}
 		}
 
 		claims, err := m.authService.ValidateToken(token)
-		if err != nil {
+		if err != nil || m.authService.CheckTokenVersion(c.Request.Context(), claims) != nil {
 			c.Next()
 			return
 		}
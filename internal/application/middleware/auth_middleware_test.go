@@ -1,20 +1,70 @@
 package middleware_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/middleware"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+type MockPermissionDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPermissionDomainService) ResolvePermissions(ctx context.Context, userID uint) (entity.PermissionSet, error) {
+	args := m.Called(ctx, userID)
+	if permissions, ok := args.Get(0).(entity.PermissionSet); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionDomainService) HasPermission(ctx context.Context, userID uint, required entity.PermissionGrant) (bool, error) {
+	args := m.Called(ctx, userID, required)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPermissionDomainService) GrantScope(ctx context.Context, userID uint, scopeName string, expiresAt time.Time) (*entity.UserScope, error) {
+	args := m.Called(ctx, userID, scopeName, expiresAt)
+	if userScope, ok := args.Get(0).(*entity.UserScope); ok {
+		return userScope, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionDomainService) RevokeScope(ctx context.Context, userID uint, scopeName string) error {
+	args := m.Called(ctx, userID, scopeName)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) AssignRole(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) RemoveRole(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) InvalidateUserPermissions(ctx context.Context, userID uint) {
+	m.Called(ctx, userID)
+}
+
 func TestRequireAuthNoToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(authMiddleware.RequireAuth())
@@ -34,7 +84,7 @@ func TestRequireAuthNoToken(t *testing.T) {
 func TestRequireRoleValidRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -57,7 +107,7 @@ func TestRequireRoleValidRole(t *testing.T) {
 func TestRequireRoleInvalidRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -81,7 +131,7 @@ func TestRequireRoleInvalidRole(t *testing.T) {
 func TestRequireRoleNoRoles(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(authMiddleware.RequireRole("admin"))
@@ -101,7 +151,7 @@ func TestRequireRoleNoRoles(t *testing.T) {
 func TestRequireAnyRoleValidRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -124,7 +174,7 @@ func TestRequireAnyRoleValidRole(t *testing.T) {
 func TestRequireAnyRoleInvalidRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -148,7 +198,7 @@ func TestRequireAnyRoleInvalidRole(t *testing.T) {
 func TestOptionalAuthNoToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(authMiddleware.OptionalAuth())
@@ -173,7 +223,7 @@ func TestOptionalAuthNoToken(t *testing.T) {
 func TestInvalidAuthorizationHeader(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(authMiddleware.RequireAuth())
@@ -207,7 +257,7 @@ func TestInvalidAuthorizationHeader(t *testing.T) {
 func TestRequireRoleInvalidRoleType(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -231,7 +281,7 @@ func TestRequireRoleInvalidRoleType(t *testing.T) {
 func TestRequireAnyRoleNoRoles(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(authMiddleware.RequireAnyRole("admin", "moderator"))
@@ -251,7 +301,7 @@ func TestRequireAnyRoleNoRoles(t *testing.T) {
 func TestRequireAnyRoleInvalidRoleType(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	authMiddleware := middleware.NewAuthMiddleware(nil, nil)
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -280,3 +330,164 @@ func TestAuthMiddlewareRoleComparison(t *testing.T) {
 		t.Errorf("User roles mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	required := entity.MustParsePermissionGrant("fraud.blacklist:write")
+
+	tests := []struct {
+		name           string
+		setUserID      bool
+		allowed        bool
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "権限あり", setUserID: true, allowed: true, expectedStatus: http.StatusOK},
+		{name: "権限なし", setUserID: true, allowed: false, expectedStatus: http.StatusForbidden, expectedBody: "Insufficient permissions"},
+		{name: "解決に失敗", setUserID: true, err: errors.New("db error"), expectedStatus: http.StatusInternalServerError, expectedBody: "Failed to resolve permissions"},
+		{name: "未認証", setUserID: false, expectedStatus: http.StatusForbidden, expectedBody: "User ID not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionService := &MockPermissionDomainService{}
+			permissionService.On("HasPermission", mock.Anything, uint(1), required).Return(tt.allowed, tt.err)
+			authMiddleware := middleware.NewAuthMiddleware(nil, nil, permissionService)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.setUserID {
+					c.Set("user_id", uint(1))
+				}
+				c.Next()
+			})
+			router.Use(authMiddleware.RequirePermission("fraud.blacklist:write"))
+			router.GET("/test", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "success"})
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestRequirePermissionInvalidPermission(t *testing.T) {
+	authMiddleware := middleware.NewAuthMiddleware(nil, nil, nil)
+
+	assert.Panics(t, func() {
+		authMiddleware.RequirePermission("fraud")
+	})
+}
+
+type testTokenVersionCache struct {
+	versions map[uint]int64
+	err      error
+}
+
+func (c *testTokenVersionCache) BlacklistToken(ctx context.Context, tokenID string, expiration time.Duration) error {
+	return nil
+}
+
+func (c *testTokenVersionCache) IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	return false, nil
+}
+
+func (c *testTokenVersionCache) IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return 1, nil
+}
+
+func (c *testTokenVersionCache) GetTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.versions[userID], nil
+}
+
+func (c *testTokenVersionCache) IncrementTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	c.versions[userID]++
+	return c.versions[userID], nil
+}
+
+func TestRequireAuthTokenVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &testTokenVersionCache{versions: map[uint]int64{}}
+	authService := service.NewAuthDomainService(nil, nil, nil, nil, cache, nil, nil, "test-secret")
+	authMiddleware := middleware.NewAuthMiddleware(authService, nil, nil)
+
+	router := gin.New()
+	router.Use(authMiddleware.RequireAuth())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	oldToken, err := authService.GenerateAccessToken(context.Background(), 1, "admin@example.com", []string{"admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, request(oldToken).Code)
+
+	_, _ = cache.IncrementTokenVersion(context.Background(), 1)
+
+	w := request(oldToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Token has been revoked")
+
+	newToken, err := authService.GenerateAccessToken(context.Background(), 1, "admin@example.com", []string{"user"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, request(newToken).Code)
+}
+
+func TestRequireAuthTokenVersionUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &testTokenVersionCache{versions: map[uint]int64{}}
+	authService := service.NewAuthDomainService(nil, nil, nil, nil, cache, nil, nil, "test-secret")
+	authMiddleware := middleware.NewAuthMiddleware(authService, nil, nil)
+
+	token, err := authService.GenerateAccessToken(context.Background(), 1, "admin@example.com", []string{"admin"})
+	assert.NoError(t, err)
+	cache.err = errors.New("redis unavailable")
+
+	tests := []struct {
+		name       string
+		middleware gin.HandlerFunc
+		wantStatus int
+	}{
+		{name: "必須認証は拒否する", middleware: authMiddleware.RequireAuth(), wantStatus: http.StatusServiceUnavailable},
+		{name: "任意認証は未認証として扱う", middleware: authMiddleware.OptionalAuth(), wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(tt.middleware)
+			router.GET("/test", func(c *gin.Context) {
+				_, exists := c.Get("user_id")
+				assert.False(t, exists)
+				c.JSON(http.StatusOK, gin.H{"message": "success"})
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -2,179 +2,199 @@ package middleware
 
 import (
 	"fmt"
+	"log"
+	"math"
 	"net/http"
 	"time"
 
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/gin-gonic/gin"
 )
 
 type RateLimitMiddleware struct {
-	cacheService *external.CacheService
+	cacheService   *external.CacheService
+	rulePolicy     service.RateLimitPolicy
+	ipAccessPolicy service.IPAccessPolicy
+	algorithm      string
 }
 
-func NewRateLimitMiddleware(cacheService *external.CacheService) *RateLimitMiddleware {
+func NewRateLimitMiddleware(cacheService *external.CacheService, rulePolicy service.RateLimitPolicy, ipAccessPolicy service.IPAccessPolicy) *RateLimitMiddleware {
 	return &RateLimitMiddleware{
-		cacheService: cacheService,
+		cacheService:   cacheService,
+		rulePolicy:     rulePolicy,
+		ipAccessPolicy: ipAccessPolicy,
+		algorithm:      entity.RateLimitAlgorithmFixedWindow,
 	}
 }
 
+func (m *RateLimitMiddleware) WithAlgorithm(algorithm string) *RateLimitMiddleware {
+	clone := *m
+	clone.algorithm = algorithm
+	return &clone
+}
+
 func (m *RateLimitMiddleware) RateLimitByIP(maxRequests int64, window time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if m.cacheService == nil {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
-			c.Next()
-			return
-		}
-
-		ip := c.ClientIP()
-		key := fmt.Sprintf("ip:%s", ip)
-
-		count, err := m.cacheService.IncrementRateLimit(c.Request.Context(), key, window)
-		if err != nil {
+		key := fmt.Sprintf("ip:%s", c.ClientIP())
+		message := fmt.Sprintf("Too many requests. Limit: %d per %v", maxRequests, window)
 
+		if _, ok := m.enforce(c, m.algorithm, key, maxRequests, window, message); ok {
 			c.Next()
-			return
 		}
+	}
+}
 
-		if count > maxRequests {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
-
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": fmt.Sprintf("Too many requests. Limit: %d per %v", maxRequests, window),
-			})
-			c.Abort()
+func (m *RateLimitMiddleware) RateLimitByUser(maxRequests int64, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			m.RateLimitByIP(maxRequests, window)(c)
 			return
 		}
 
-		remaining := maxRequests - count
-		if remaining < 0 {
-			remaining = 0
+		key := fmt.Sprintf("user:%v", userID)
+		message := fmt.Sprintf("Too many requests. Limit: %d per %v", maxRequests, window)
+
+		if _, ok := m.enforce(c, m.algorithm, key, maxRequests, window, message); ok {
+			c.Next()
 		}
+	}
+}
 
-		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+func (m *RateLimitMiddleware) RateLimitByEndpoint(endpoint string, maxRequests int64, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := fmt.Sprintf("endpoint:%s:ip:%s", endpoint, c.ClientIP())
+		message := fmt.Sprintf("Too many requests to %s. Limit: %d per %v", endpoint, maxRequests, window)
 
-		c.Next()
+		if _, ok := m.enforce(c, m.algorithm, key, maxRequests, window, message); ok {
+			c.Next()
+		}
 	}
 }
 
-func (m *RateLimitMiddleware) RateLimitByUser(maxRequests int64, window time.Duration) gin.HandlerFunc {
+func (m *RateLimitMiddleware) RateLimitByRule() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if m.cacheService == nil {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+		if m.rulePolicy == nil {
 			c.Next()
 			return
 		}
 
-		userID, exists := c.Get("user_id")
-		if !exists {
+		ctx := c.Request.Context()
+		subject := m.rateLimitSubject(c)
 
-			m.RateLimitByIP(maxRequests, window)(c)
+		rule, err := m.rulePolicy.ResolveRateLimitRule(ctx, subject)
+		if err != nil {
+			log.Printf("Failed to resolve rate limit rule: %v", err)
+			c.Next()
 			return
 		}
-
-		key := fmt.Sprintf("user:%v", userID)
-
-		count, err := m.cacheService.IncrementRateLimit(c.Request.Context(), key, window)
-		if err != nil {
-
+		if rule == nil || rule.Window() <= 0 {
 			c.Next()
 			return
 		}
 
-		if count > maxRequests {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+		algorithm := rule.Algorithm
+		if algorithm == "" {
+			algorithm = m.algorithm
+		}
 
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": fmt.Sprintf("Too many requests. Limit: %d per %v", maxRequests, window),
-			})
-			c.Abort()
+		maxRequests := int64(rule.MaxRequests)
+		window := rule.Window()
+		key := rule.CounterKey(subject)
+		message := fmt.Sprintf("Too many requests. Limit: %d per %v", maxRequests, window)
+
+		result, ok := m.enforce(c, algorithm, key, maxRequests, window, message)
+		if ok {
+			c.Next()
 			return
 		}
 
-		remaining := maxRequests - count
-		if remaining < 0 {
-			remaining = 0
+		blocked, err := m.cacheService.CountRateLimitBlock(ctx, key, window)
+		if err != nil {
+			log.Printf("Failed to count rate limit block: %v", err)
+			blocked = 1
+		}
+		requests := result.Limit - result.Remaining + blocked
+		if err := m.rulePolicy.RecordRateLimitBlock(ctx, rule, subject, requests, result.WindowStart); err != nil {
+			log.Printf("Failed to record rate limit block: %v", err)
 		}
+	}
+}
 
-		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+func (m *RateLimitMiddleware) enforce(c *gin.Context, algorithm, key string, maxRequests int64, window time.Duration, message string) (*external.RateLimitResult, bool) {
+	if m.cacheService == nil {
+		m.setRateLimitHeaders(c, maxRequests, maxRequests, time.Now().Add(window))
+		return nil, true
+	}
 
-		c.Next()
+	result, err := m.cacheService.CheckRateLimit(c.Request.Context(), algorithm, key, maxRequests, window)
+	if err != nil {
+		log.Printf("Failed to check rate limit: %v", err)
+		return nil, true
 	}
-}
 
-func (m *RateLimitMiddleware) RateLimitByEndpoint(endpoint string, maxRequests int64, window time.Duration) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		if m.cacheService == nil {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
-			c.Next()
-			return
-		}
+	m.setRateLimitHeaders(c, result.Limit, result.Remaining, result.ResetAt)
 
-		ip := c.ClientIP()
-		key := fmt.Sprintf("endpoint:%s:ip:%s", endpoint, ip)
+	if !result.Allowed {
+		c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(result.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":   "Rate limit exceeded",
+			"message": message,
+		})
+		c.Abort()
+		return result, false
+	}
 
-		count, err := m.cacheService.IncrementRateLimit(c.Request.Context(), key, window)
-		if err != nil {
+	return result, true
+}
 
-			c.Next()
-			return
-		}
+func (m *RateLimitMiddleware) rateLimitSubject(c *gin.Context) *entity.RateLimitSubject {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
 
-		if count > maxRequests {
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+	subject := &entity.RateLimitSubject{
+		Method:    c.Request.Method,
+		Route:     route,
+		IPAddress: c.ClientIP(),
+	}
 
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": fmt.Sprintf("Too many requests to %s. Limit: %d per %v", endpoint, maxRequests, window),
-			})
-			c.Abort()
-			return
+	if value, exists := c.Get("user_id"); exists {
+		if userID, ok := value.(uint); ok {
+			subject.UserID = &userID
 		}
+	}
 
-		remaining := maxRequests - count
-		if remaining < 0 {
-			remaining = 0
+	if value, exists := c.Get("user_roles"); exists {
+		if roles, ok := value.([]string); ok {
+			subject.Roles = roles
 		}
+	}
 
-		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", maxRequests))
-		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(window).Unix()))
+	return subject
+}
 
-		c.Next()
-	}
+func (m *RateLimitMiddleware) setRateLimitHeaders(c *gin.Context, limit, remaining int64, reset time.Time) {
+	c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
+	c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
+	c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", reset.Unix()))
 }
 
 func (m *RateLimitMiddleware) CheckBlacklist() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if m.cacheService == nil {
+		if m.ipAccessPolicy == nil {
 			c.Next()
 			return
 		}
 
 		ip := c.ClientIP()
 
-		blacklisted, err := m.cacheService.IsBlacklisted(c.Request.Context(), ip)
+		blacklisted, err := m.ipAccessPolicy.IsIPBlocked(c.Request.Context(), ip)
 		if err != nil {
-
+			log.Printf("Failed to check IP blacklist for %s: %v", ip, err)
 			c.Next()
 			return
 		}
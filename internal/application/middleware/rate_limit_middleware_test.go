@@ -1,20 +1,26 @@
 package middleware_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/middleware"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redismock/v8"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRateLimitByIPNilCacheService(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.RateLimitByIP(10, time.Minute))
@@ -34,7 +40,7 @@ func TestRateLimitByIPNilCacheService(t *testing.T) {
 func TestRateLimitByUserNoUserID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.RateLimitByUser(100, time.Hour))
@@ -54,7 +60,7 @@ func TestRateLimitByUserNoUserID(t *testing.T) {
 func TestRateLimitByUserWithUserID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -77,7 +83,7 @@ func TestRateLimitByUserWithUserID(t *testing.T) {
 func TestRateLimitByEndpointWithinLimit(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.RateLimitByEndpoint("/api/login", 5, time.Minute*15))
@@ -94,10 +100,10 @@ func TestRateLimitByEndpointWithinLimit(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestCheckBlacklistNilCacheService(t *testing.T) {
+func TestCheckBlacklistNilPolicy(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.CheckBlacklist())
@@ -114,10 +120,53 @@ func TestCheckBlacklistNilCacheService(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+type stubIPAccessPolicy struct {
+	blocked bool
+	err     error
+}
+
+func (p *stubIPAccessPolicy) IsIPBlocked(_ context.Context, _ string) (bool, error) {
+	return p.blocked, p.err
+}
+
+func TestCheckBlacklistUsesIPAccessPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		policy         *stubIPAccessPolicy
+		expectedStatus int
+	}{
+		{name: "ブロック対象は403", policy: &stubIPAccessPolicy{blocked: true}, expectedStatus: http.StatusForbidden},
+		{name: "該当なしは通過", policy: &stubIPAccessPolicy{}, expectedStatus: http.StatusOK},
+		{name: "判定に失敗しても通過", policy: &stubIPAccessPolicy{err: errors.New("db unavailable")}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, tt.policy)
+
+			router := gin.New()
+			router.Use(rateLimitMiddleware.CheckBlacklist())
+			router.GET("/test", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "success"})
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = "203.0.113.10:12345"
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestRateLimitHeadersFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.RateLimitByIP(10, time.Minute))
@@ -145,7 +194,7 @@ func TestRateLimitHeadersFormat(t *testing.T) {
 func TestMultipleMiddlewareChaining(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(rateLimitMiddleware.CheckBlacklist())
@@ -178,7 +227,7 @@ func TestRateLimitByUserDifferentUserTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 			router := gin.New()
 			router.Use(func(c *gin.Context) {
@@ -207,7 +256,7 @@ func TestRateLimitEndpointDifferentEndpoints(t *testing.T) {
 
 	for _, endpoint := range endpoints {
 		t.Run("endpoint_"+endpoint, func(t *testing.T) {
-			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 			router := gin.New()
 			router.Use(rateLimitMiddleware.RateLimitByEndpoint(endpoint, 5, time.Minute*15))
@@ -233,7 +282,7 @@ func TestRateLimitByIPDifferentIPs(t *testing.T) {
 
 	for _, ip := range ips {
 		t.Run("ip_"+ip, func(t *testing.T) {
-			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil)
+			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
 
 			router := gin.New()
 			router.Use(rateLimitMiddleware.RateLimitByIP(10, time.Minute))
@@ -251,3 +300,155 @@ func TestRateLimitByIPDifferentIPs(t *testing.T) {
 		})
 	}
 }
+
+type MockRateLimitPolicy struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitPolicy) ResolveRateLimitRule(ctx context.Context, subject *entity.RateLimitSubject) (*entity.RateLimitRule, error) {
+	args := m.Called(ctx, subject)
+	if rule, ok := args.Get(0).(*entity.RateLimitRule); ok {
+		return rule, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRateLimitPolicy) RecordRateLimitBlock(ctx context.Context, rule *entity.RateLimitRule, subject *entity.RateLimitSubject, requests int64, windowStart time.Time) error {
+	args := m.Called(ctx, rule, subject, requests, windowStart)
+	return args.Error(0)
+}
+
+func TestRateLimitByRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rule := entity.NewRateLimitRule("ENDPOINT:GET /users/:id", "GET /users/:id", 25, 60)
+	rule.ID = 1
+
+	tests := []struct {
+		name        string
+		setupMock   func(*MockRateLimitPolicy)
+		wantLimit   string
+		wantHeaders bool
+	}{
+		{
+			name: "解決したルールの上限がヘッダーに反映される",
+			setupMock: func(policy *MockRateLimitPolicy) {
+				policy.On("ResolveRateLimitRule", mock.Anything, mock.MatchedBy(func(subject *entity.RateLimitSubject) bool {
+					return subject.Method == "GET" &&
+						subject.Route == "/users/:id" &&
+						subject.IPAddress == "127.0.0.1" &&
+						subject.UserID != nil && *subject.UserID == 123 &&
+						len(subject.Roles) == 1 && subject.Roles[0] == "admin"
+				})).Return(rule, nil)
+			},
+			wantLimit:   "25",
+			wantHeaders: true,
+		},
+		{
+			name: "該当するルールがない場合はそのまま通過する",
+			setupMock: func(policy *MockRateLimitPolicy) {
+				policy.On("ResolveRateLimitRule", mock.Anything, mock.Anything).Return(nil, nil)
+			},
+			wantHeaders: false,
+		},
+		{
+			name: "ルールの解決に失敗した場合もそのまま通過する",
+			setupMock: func(policy *MockRateLimitPolicy) {
+				policy.On("ResolveRateLimitRule", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			wantHeaders: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &MockRateLimitPolicy{}
+			tt.setupMock(policy)
+
+			rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, policy, nil)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", uint(123))
+				c.Set("user_roles", []string{"admin"})
+				c.Next()
+			})
+			router.Use(rateLimitMiddleware.RateLimitByRule())
+			router.GET("/users/:id", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "success"})
+			})
+
+			req, _ := http.NewRequest("GET", "/users/5", nil)
+			req.RemoteAddr = "127.0.0.1:12345"
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			if tt.wantHeaders {
+				assert.Equal(t, tt.wantLimit, w.Header().Get("X-RateLimit-Limit"))
+				assert.Equal(t, tt.wantLimit, w.Header().Get("X-RateLimit-Remaining"))
+				assert.NotEmpty(t, w.Header().Get("X-RateLimit-Reset"))
+			} else {
+				assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+			}
+			policy.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRateLimitByRuleRecordsBlockedRequestCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, redisMock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+
+	matchAny := func(_, _ []interface{}) error { return nil }
+	redisMock.CustomMatch(matchAny).ExpectEvalSha("", []string{""}, 0, 0, "").SetVal([]interface{}{int64(0), int64(0), int64(1000), int64(1000)})
+	redisMock.CustomMatch(matchAny).ExpectEvalSha("", []string{""}, 0).SetVal(int64(3))
+
+	rule := entity.NewRateLimitRule("ENDPOINT:GET /users/:id", "GET /users/:id", 25, 60)
+	rule.ID = 1
+	policy := &MockRateLimitPolicy{}
+	policy.On("ResolveRateLimitRule", mock.Anything, mock.Anything).Return(rule, nil)
+	policy.On("RecordRateLimitBlock", mock.Anything, rule, mock.Anything, int64(28), mock.Anything).Return(nil)
+
+	cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(cacheService, policy, nil)
+
+	router := gin.New()
+	router.Use(rateLimitMiddleware.RateLimitByRule())
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/users/5", nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NoError(t, redisMock.ExpectationsWereMet())
+	policy.AssertExpectations(t)
+}
+
+func TestRateLimitByRuleWithoutPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(rateLimitMiddleware.RateLimitByRule())
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+}
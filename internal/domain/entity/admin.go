@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	AdminActionPointGrant  = "point_grant"
+	AdminActionPointDeduct = "point_deduct"
+
+	AdminActionTargetUser    = "user"
+	AdminActionResultSuccess = "success"
+
+	AdminPointReferenceType = "admin"
+)
+
+var (
+	ErrIdempotencyKeyConflict   = errors.New("idempotency key already used for a different request")
+	ErrAdminPointBudgetExceeded = errors.New("daily admin point grant budget exceeded")
+)
+
+type AdminAction struct {
+	ID                 uint
+	AdminUserID        uint
+	ActionType         string
+	TargetType         string
+	TargetID           string
+	Description        string
+	Result             string
+	IdempotencyKey     string
+	PointTransactionID *uint
+	CreatedAt          time.Time
+}
+
+func NewAdminAction(adminUserID uint, actionType, targetType, targetID, description, idempotencyKey string) *AdminAction {
+	return &AdminAction{
+		AdminUserID:    adminUserID,
+		ActionType:     actionType,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		Description:    description,
+		Result:         AdminActionResultSuccess,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func NewAdminPointAction(adminUserID, userID uint, points int, description, idempotencyKey string) *AdminAction {
+	actionType := AdminActionPointGrant
+	if points < 0 {
+		actionType = AdminActionPointDeduct
+	}
+	return NewAdminAction(adminUserID, actionType, AdminActionTargetUser, strconv.FormatUint(uint64(userID), 10), description, idempotencyKey)
+}
+
+// MatchesPointAdjustment reports whether a replayed request targets the same user with the same signed amount.
+func (a *AdminAction) MatchesPointAdjustment(requested *AdminAction, recorded, requestedTransaction *PointTransaction) bool {
+	return a.ActionType == requested.ActionType &&
+		a.TargetType == requested.TargetType &&
+		a.TargetID == requested.TargetID &&
+		recorded != nil && recorded.Points == requestedTransaction.Points
+}
+
+type AdminPointBudget struct {
+	DailyLimit int
+	Since      time.Time
+}
+
+func NewAdminPointBudget(dailyLimit int, now time.Time) *AdminPointBudget {
+	year, month, day := now.Date()
+	return &AdminPointBudget{
+		DailyLimit: dailyLimit,
+		Since:      time.Date(year, month, day, 0, 0, 0, 0, now.Location()),
+	}
+}
+
+func (b *AdminPointBudget) Allows(granted, points int) bool {
+	return granted+points <= b.DailyLimit
+}
+
+func (b *AdminPointBudget) Remaining(granted int) int {
+	if granted >= b.DailyLimit {
+		return 0
+	}
+	return b.DailyLimit - granted
+}
@@ -0,0 +1,79 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAdminPointAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		points         int
+		expectedAction string
+	}{
+		{name: "付与", points: 100, expectedAction: entity.AdminActionPointGrant},
+		{name: "減算", points: -100, expectedAction: entity.AdminActionPointDeduct},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := entity.NewAdminPointAction(9, 1, tt.points, "補填", "key-1")
+
+			assert.Equal(t, uint(9), action.AdminUserID)
+			assert.Equal(t, tt.expectedAction, action.ActionType)
+			assert.Equal(t, entity.AdminActionTargetUser, action.TargetType)
+			assert.Equal(t, "1", action.TargetID)
+			assert.Equal(t, entity.AdminActionResultSuccess, action.Result)
+			assert.Equal(t, "key-1", action.IdempotencyKey)
+		})
+	}
+}
+
+func TestAdminActionMatchesPointAdjustment(t *testing.T) {
+	existing := entity.NewAdminPointAction(9, 1, 100, "補填", "key-1")
+	recorded := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "補填")
+
+	tests := []struct {
+		name      string
+		requested *entity.AdminAction
+		points    int
+		recorded  *entity.PointTransaction
+		expected  bool
+	}{
+		{name: "同一リクエスト", requested: entity.NewAdminPointAction(9, 1, 100, "補填", "key-1"), points: 100, recorded: recorded, expected: true},
+		{name: "対象ユーザーが異なる", requested: entity.NewAdminPointAction(9, 2, 100, "補填", "key-1"), points: 100, recorded: recorded, expected: false},
+		{name: "ポイント数が異なる", requested: entity.NewAdminPointAction(9, 1, 200, "補填", "key-1"), points: 200, recorded: recorded, expected: false},
+		{name: "操作が異なる", requested: entity.NewAdminPointAction(9, 1, -100, "補填", "key-1"), points: -100, recorded: recorded, expected: false},
+		{name: "記録済みの取引が取得できない", requested: entity.NewAdminPointAction(9, 1, 100, "補填", "key-1"), points: 100, recorded: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, tt.points, "補填")
+
+			assert.Equal(t, tt.expected, existing.MatchesPointAdjustment(tt.requested, tt.recorded, transaction))
+		})
+	}
+}
+
+func TestAdminPointBudget(t *testing.T) {
+	now := time.Date(2025, 9, 17, 15, 30, 0, 0, time.UTC)
+	budget := entity.NewAdminPointBudget(1000, now)
+
+	t.Run("集計開始は当日0時", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, 9, 17, 0, 0, 0, 0, time.UTC), budget.Since)
+	})
+
+	t.Run("上限ちょうどまでは許可する", func(t *testing.T) {
+		assert.True(t, budget.Allows(700, 300))
+		assert.False(t, budget.Allows(700, 301))
+	})
+
+	t.Run("残り予算は0未満にならない", func(t *testing.T) {
+		assert.Equal(t, 300, budget.Remaining(700))
+		assert.Equal(t, 0, budget.Remaining(1200))
+	})
+}
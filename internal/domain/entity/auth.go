@@ -1,6 +1,11 @@
 package entity
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -13,14 +18,20 @@ var (
 )
 
 type Auth struct {
-	ID           uint
-	UserID       uint
-	Email        string
-	PasswordHash string
-	IsActive     bool
-	LastLoginAt  *time.Time
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                     uint
+	UserID                 uint
+	Email                  string
+	PasswordHash           string
+	IsActive               bool
+	LastLoginAt            *time.Time
+	TwoFactorEnabled       bool
+	TwoFactorSecret        string
+	TwoFactorRecoveryCodes []string
+	TwoFactorLastStep      int64
+	TwoFactorEnabledAt     *time.Time
+	PasswordChangedAt      *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 func NewAuth(userID uint, email, password string) (*Auth, error) {
@@ -35,12 +46,13 @@ func NewAuth(userID uint, email, password string) (*Auth, error) {
 
 	now := time.Now()
 	return &Auth{
-		UserID:       userID,
-		Email:        email,
-		PasswordHash: string(hashedPassword),
-		IsActive:     true,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		UserID:            userID,
+		Email:             email,
+		PasswordHash:      string(hashedPassword),
+		IsActive:          true,
+		PasswordChangedAt: &now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}, nil
 }
 
@@ -62,11 +74,60 @@ func (a *Auth) ChangePassword(currentPassword, newPassword string) error {
 		return err
 	}
 
+	now := time.Now()
 	a.PasswordHash = string(hashedPassword)
-	a.UpdatedAt = time.Now()
+	a.PasswordChangedAt = &now
+	a.UpdatedAt = now
 	return nil
 }
 
+func (a *Auth) ResetPassword(newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	a.PasswordHash = string(hashedPassword)
+	a.PasswordChangedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+const (
+	SecurityScoreBase          = 40
+	SecurityScoreTwoFactor     = 30
+	SecurityScoreFreshPassword = 20
+	SecurityScoreAgingPassword = 10
+	SecurityScoreTrustedDevice = 10
+	FreshPasswordMaxAge        = 90 * 24 * time.Hour
+	AgingPasswordMaxAge        = 180 * 24 * time.Hour
+)
+
+// SecurityScore rates the account out of 100 from 2FA, password age and trusted devices.
+func (a *Auth) SecurityScore(trustedDevices int, now time.Time) int {
+	score := SecurityScoreBase
+	if a.TwoFactorEnabled {
+		score += SecurityScoreTwoFactor
+	}
+	if a.PasswordChangedAt != nil {
+		switch age := now.Sub(*a.PasswordChangedAt); {
+		case age <= FreshPasswordMaxAge:
+			score += SecurityScoreFreshPassword
+		case age <= AgingPasswordMaxAge:
+			score += SecurityScoreAgingPassword
+		}
+	}
+	if trustedDevices > 0 {
+		score += SecurityScoreTrustedDevice
+	}
+	return score
+}
+
 func (a *Auth) UpdateLastLogin() {
 	now := time.Now()
 	a.LastLoginAt = &now
@@ -83,6 +144,71 @@ func (a *Auth) Activate() {
 	a.UpdatedAt = time.Now()
 }
 
+func (a *Auth) StartTwoFactorSetup(encryptedSecret string) error {
+	if a.TwoFactorEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+
+	a.TwoFactorSecret = encryptedSecret
+	a.TwoFactorRecoveryCodes = nil
+	a.TwoFactorLastStep = 0
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func (a *Auth) EnableTwoFactor(recoveryCodeHashes []string) error {
+	if a.TwoFactorEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if a.TwoFactorSecret == "" {
+		return ErrTwoFactorNotInitialized
+	}
+
+	now := time.Now()
+	a.TwoFactorEnabled = true
+	a.TwoFactorRecoveryCodes = recoveryCodeHashes
+	a.TwoFactorEnabledAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+func (a *Auth) DisableTwoFactor() {
+	a.TwoFactorEnabled = false
+	a.TwoFactorSecret = ""
+	a.TwoFactorRecoveryCodes = nil
+	a.TwoFactorLastStep = 0
+	a.TwoFactorEnabledAt = nil
+	a.UpdatedAt = time.Now()
+}
+
+// ConsumeTOTPStep rejects a time step that was already used so a code cannot be replayed.
+func (a *Auth) ConsumeTOTPStep(step int64) error {
+	if step <= a.TwoFactorLastStep {
+		return ErrInvalidTwoFactorCode
+	}
+
+	a.TwoFactorLastStep = step
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func (a *Auth) UseRecoveryCode(code string) bool {
+	hashed := HashRecoveryCode(code)
+	for i, stored := range a.TwoFactorRecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hashed)) == 1 {
+			a.TwoFactorRecoveryCodes = append(a.TwoFactorRecoveryCodes[:i:i], a.TwoFactorRecoveryCodes[i+1:]...)
+			a.UpdatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Auth) ReplaceRecoveryCodes(recoveryCodeHashes []string) {
+	a.TwoFactorRecoveryCodes = recoveryCodeHashes
+	a.UpdatedAt = time.Now()
+}
+
 // ValidatePassword validates if a password meets the minimum requirements
 func (a *Auth) ValidatePassword(password string) error {
 	return validatePassword(password)
@@ -99,6 +225,7 @@ type Role struct {
 	ID          uint
 	Name        string
 	Description string
+	Permissions []*Permission
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -116,18 +243,21 @@ func NewRole(name, description string) *Role {
 type RefreshToken struct {
 	ID        uint
 	UserID    uint
-	Token     string
+	TokenHash string
+	FamilyID  string
 	ExpiresAt time.Time
 	IsRevoked bool
+	RotatedAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-func NewRefreshToken(userID uint, token string, expiresAt time.Time) *RefreshToken {
+func NewRefreshToken(userID uint, tokenHash, familyID string, expiresAt time.Time) *RefreshToken {
 	now := time.Now()
 	return &RefreshToken{
 		UserID:    userID,
-		Token:     token,
+		TokenHash: tokenHash,
+		FamilyID:  familyID,
 		ExpiresAt: expiresAt,
 		IsRevoked: false,
 		CreatedAt: now,
@@ -140,6 +270,17 @@ func (rt *RefreshToken) Revoke() {
 	rt.UpdatedAt = time.Now()
 }
 
+func (rt *RefreshToken) Rotate() {
+	now := time.Now()
+	rt.IsRevoked = true
+	rt.RotatedAt = &now
+	rt.UpdatedAt = now
+}
+
+func (rt *RefreshToken) IsRotated() bool {
+	return rt.RotatedAt != nil
+}
+
 func (rt *RefreshToken) IsExpired() bool {
 	return time.Now().After(rt.ExpiresAt)
 }
@@ -147,3 +288,63 @@ func (rt *RefreshToken) IsExpired() bool {
 func (rt *RefreshToken) IsValid() bool {
 	return !rt.IsRevoked && !rt.IsExpired()
 }
+
+const (
+	UserTokenTypePasswordReset     = "password_reset"
+	UserTokenTypeEmailVerification = "email_verification"
+)
+
+type UserToken struct {
+	ID        uint
+	UserID    uint
+	TokenType string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewUserToken(userID uint, tokenType, tokenHash string, expiresAt time.Time) *UserToken {
+	now := time.Now()
+	return &UserToken{
+		UserID:    userID,
+		TokenType: tokenType,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (t *UserToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+func GenerateUserToken() (string, error) {
+	return generateOpaqueToken()
+}
+
+func HashUserToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func GenerateRefreshTokenValue() (string, error) {
+	return generateOpaqueToken()
+}
+
+func HashRefreshToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -236,22 +236,25 @@ func TestNewRole(t *testing.T) {
 
 func TestNewRefreshToken(t *testing.T) {
 	userID := uint(1)
-	token := "test-refresh-token"
+	tokenHash := entity.HashRefreshToken("test-refresh-token")
+	familyID := "family-1"
 	expiresAt := time.Now().Add(24 * time.Hour)
 
-	refreshToken := entity.NewRefreshToken(userID, token, expiresAt)
+	refreshToken := entity.NewRefreshToken(userID, tokenHash, familyID, expiresAt)
 
 	assert.NotNil(t, refreshToken)
 	assert.Equal(t, userID, refreshToken.UserID)
-	assert.Equal(t, token, refreshToken.Token)
+	assert.Equal(t, tokenHash, refreshToken.TokenHash)
+	assert.Equal(t, familyID, refreshToken.FamilyID)
 	assert.Equal(t, expiresAt, refreshToken.ExpiresAt)
 	assert.False(t, refreshToken.IsRevoked)
+	assert.Nil(t, refreshToken.RotatedAt)
 	assert.False(t, refreshToken.CreatedAt.IsZero())
 	assert.False(t, refreshToken.UpdatedAt.IsZero())
 }
 
 func TestRefreshTokenRevoke(t *testing.T) {
-	refreshToken := entity.NewRefreshToken(1, "test-token", time.Now().Add(24*time.Hour))
+	refreshToken := entity.NewRefreshToken(1, "test-token", "family-1", time.Now().Add(24*time.Hour))
 
 	assert.False(t, refreshToken.IsRevoked)
 
@@ -264,6 +267,41 @@ func TestRefreshTokenRevoke(t *testing.T) {
 	assert.True(t, refreshToken.UpdatedAt.After(oldUpdatedAt))
 }
 
+func TestRefreshTokenRotate(t *testing.T) {
+	refreshToken := entity.NewRefreshToken(1, "test-token", "family-1", time.Now().Add(24*time.Hour))
+
+	assert.False(t, refreshToken.IsRotated())
+
+	refreshToken.Rotate()
+
+	assert.True(t, refreshToken.IsRevoked)
+	assert.True(t, refreshToken.IsRotated())
+	assert.NotNil(t, refreshToken.RotatedAt)
+	assert.False(t, refreshToken.IsValid())
+}
+
+func TestRefreshTokenRevokeIsNotRotation(t *testing.T) {
+	refreshToken := entity.NewRefreshToken(1, "test-token", "family-1", time.Now().Add(24*time.Hour))
+
+	refreshToken.Revoke()
+
+	assert.True(t, refreshToken.IsRevoked)
+	assert.False(t, refreshToken.IsRotated())
+}
+
+func TestGenerateRefreshTokenValue(t *testing.T) {
+	first, err := entity.GenerateRefreshTokenValue()
+	assert.NoError(t, err)
+	second, err := entity.GenerateRefreshTokenValue()
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, entity.HashRefreshToken(first), 64)
+	assert.Equal(t, entity.HashRefreshToken(first), entity.HashRefreshToken(first))
+	assert.NotEqual(t, first, entity.HashRefreshToken(first))
+}
+
 func TestRefreshTokenIsExpired(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -284,7 +322,7 @@ func TestRefreshTokenIsExpired(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			refreshToken := entity.NewRefreshToken(1, "test-token", tt.expiresAt)
+			refreshToken := entity.NewRefreshToken(1, "test-token", "family-1", tt.expiresAt)
 			got := refreshToken.IsExpired()
 			assert.Equal(t, tt.want, got)
 		})
@@ -326,7 +364,7 @@ func TestRefreshTokenIsValid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			refreshToken := entity.NewRefreshToken(1, "test-token", tt.expiresAt)
+			refreshToken := entity.NewRefreshToken(1, "test-token", "family-1", tt.expiresAt)
 			if tt.isRevoked {
 				refreshToken.Revoke()
 			}
@@ -335,3 +373,133 @@ func TestRefreshTokenIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthResetPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPassword string
+		wantErr     error
+	}{
+		{
+			name:        "正常なパスワード再設定",
+			newPassword: "newpassword123",
+			wantErr:     nil,
+		},
+		{
+			name:        "新しいパスワードが弱い",
+			newPassword: "123",
+			wantErr:     entity.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAuth, _ := entity.NewAuth(1, "test@example.com", "oldpassword")
+
+			err := testAuth.ResetPassword(tt.newPassword)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.NoError(t, testAuth.VerifyPassword("oldpassword"))
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, testAuth.VerifyPassword(tt.newPassword))
+				assert.Error(t, testAuth.VerifyPassword("oldpassword"))
+				assert.NotNil(t, testAuth.PasswordChangedAt)
+			}
+		})
+	}
+}
+
+func TestAuthSecurityScore(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) *time.Time {
+		at := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &at
+	}
+
+	tests := []struct {
+		name              string
+		twoFactorEnabled  bool
+		passwordChangedAt *time.Time
+		trustedDevices    int
+		expected          int
+	}{
+		{
+			name:     "何も設定していない場合は基本点のみ",
+			expected: entity.SecurityScoreBase,
+		},
+		{
+			name:              "全ての条件を満たすと満点",
+			twoFactorEnabled:  true,
+			passwordChangedAt: daysAgo(1),
+			trustedDevices:    2,
+			expected:          100,
+		},
+		{
+			name:              "パスワード変更から90日超180日以内は減点される",
+			passwordChangedAt: daysAgo(120),
+			expected:          entity.SecurityScoreBase + entity.SecurityScoreAgingPassword,
+		},
+		{
+			name:              "パスワード変更から180日を超えると加点なし",
+			twoFactorEnabled:  true,
+			passwordChangedAt: daysAgo(200),
+			expected:          entity.SecurityScoreBase + entity.SecurityScoreTwoFactor,
+		},
+		{
+			name:           "信頼済みデバイスのみ",
+			trustedDevices: 1,
+			expected:       entity.SecurityScoreBase + entity.SecurityScoreTrustedDevice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &entity.Auth{
+				TwoFactorEnabled:  tt.twoFactorEnabled,
+				PasswordChangedAt: tt.passwordChangedAt,
+			}
+
+			assert.Equal(t, tt.expected, auth.SecurityScore(tt.trustedDevices, now))
+		})
+	}
+}
+
+func TestUserTokenIsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{
+			name:      "未来の時刻で有効",
+			expiresAt: time.Now().Add(1 * time.Hour),
+			want:      false,
+		},
+		{
+			name:      "過去の時刻で期限切れ",
+			expiresAt: time.Now().Add(-1 * time.Hour),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.NewUserToken(1, entity.UserTokenTypePasswordReset, "hash", tt.expiresAt)
+			assert.Equal(t, tt.want, token.IsExpired())
+		})
+	}
+}
+
+func TestGenerateUserToken(t *testing.T) {
+	first, err := entity.GenerateUserToken()
+	assert.NoError(t, err)
+	second, err := entity.GenerateUserToken()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, entity.HashUserToken(first), entity.HashUserToken(first))
+	assert.NotEqual(t, entity.HashUserToken(first), entity.HashUserToken(second))
+}
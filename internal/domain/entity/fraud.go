@@ -2,6 +2,10 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -9,6 +13,7 @@ var (
 	ErrIPBlacklisted      = errors.New("IP address is blacklisted")
 	ErrRateLimitExceeded  = errors.New("rate limit exceeded")
 	ErrSuspiciousActivity = errors.New("suspicious activity detected")
+	ErrInvalidIPRange     = errors.New("invalid IP address or CIDR range")
 )
 
 type SecurityEvent struct {
@@ -73,6 +78,72 @@ func (ib *IPBlacklist) Deactivate() {
 	ib.UpdatedAt = time.Now()
 }
 
+func (ib *IPBlacklist) IsEffective() bool {
+	return ib.IsActive && !ib.IsExpired()
+}
+
+func (ib *IPBlacklist) Prefix() (netip.Prefix, error) {
+	return ParseIPRange(ib.IPAddress)
+}
+
+type IPAllowlist struct {
+	ID          uint
+	IPAddress   string
+	Description string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewIPAllowlist(ipAddress, description string) *IPAllowlist {
+	now := time.Now()
+	return &IPAllowlist{
+		IPAddress:   ipAddress,
+		Description: description,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func (ia *IPAllowlist) Deactivate() {
+	ia.IsActive = false
+	ia.UpdatedAt = time.Now()
+}
+
+func (ia *IPAllowlist) Prefix() (netip.Prefix, error) {
+	return ParseIPRange(ia.IPAddress)
+}
+
+func ParseIPRange(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, ErrInvalidIPRange
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			return netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96).Masked(), nil
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, ErrInvalidIPRange
+	}
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func FormatIPRange(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
+
 type LoginAttempt struct {
 	ID         uint
 	Email      string
@@ -94,17 +165,42 @@ func NewLoginAttempt(email, ipAddress, userAgent string, success bool, failReaso
 	}
 }
 
+const (
+	RateLimitRuleTypeIP       = "IP"
+	RateLimitRuleTypeUser     = "USER"
+	RateLimitRuleTypeRole     = "ROLE"
+	RateLimitRuleTypeEndpoint = "ENDPOINT"
+	RateLimitRuleTypeGlobal   = "GLOBAL"
+)
+
+const (
+	RateLimitAlgorithmFixedWindow   = "fixed_window"
+	RateLimitAlgorithmSlidingLog    = "sliding_log"
+	RateLimitAlgorithmSlidingWindow = "sliding_window"
+	RateLimitAlgorithmTokenBucket   = "token_bucket"
+)
+
 type RateLimitRule struct {
 	ID          uint
 	Name        string
 	Resource    string
 	MaxRequests int
 	WindowSize  int
+	Algorithm   string
 	IsActive    bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+func IsValidRateLimitAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case RateLimitAlgorithmFixedWindow, RateLimitAlgorithmSlidingLog, RateLimitAlgorithmSlidingWindow, RateLimitAlgorithmTokenBucket:
+		return true
+	default:
+		return false
+	}
+}
+
 func NewRateLimitRule(name, resource string, maxRequests, windowSize int) *RateLimitRule {
 	now := time.Now()
 	return &RateLimitRule{
@@ -128,11 +224,199 @@ func (rlr *RateLimitRule) UpdateRule(maxRequests, windowSize int) {
 	rlr.UpdatedAt = time.Now()
 }
 
+func (rlr *RateLimitRule) ChangeAlgorithm(algorithm string) {
+	if algorithm == "" {
+		return
+	}
+	rlr.Algorithm = algorithm
+	rlr.UpdatedAt = time.Now()
+}
+
+func (rlr *RateLimitRule) ChangeTarget(name, resource string) {
+	if resource == "" {
+		return
+	}
+	if name != "" {
+		rlr.Name = name
+	}
+	rlr.Resource = resource
+	rlr.UpdatedAt = time.Now()
+}
+
 func (rlr *RateLimitRule) Deactivate() {
 	rlr.IsActive = false
 	rlr.UpdatedAt = time.Now()
 }
 
+type RateLimitSubject struct {
+	Method    string
+	Route     string
+	IPAddress string
+	UserID    *uint
+	Roles     []string
+}
+
+func RateLimitResource(ruleType, identifier string) string {
+	switch ruleType {
+	case RateLimitRuleTypeIP:
+		return "ip:" + identifier
+	case RateLimitRuleTypeUser:
+		return "user:" + identifier
+	case RateLimitRuleTypeRole:
+		return "role:" + identifier
+	case RateLimitRuleTypeGlobal:
+		return "*"
+	default:
+		return identifier
+	}
+}
+
+func IsValidRateLimitResource(resource string) bool {
+	resource = strings.TrimSpace(resource)
+	switch {
+	case resource == "*":
+		return true
+	case strings.HasPrefix(resource, "ip:"):
+		_, err := ParseIPRange(strings.TrimPrefix(resource, "ip:"))
+		return err == nil
+	case strings.HasPrefix(resource, "user:"):
+		_, err := strconv.ParseUint(strings.TrimPrefix(resource, "user:"), 10, 64)
+		return err == nil
+	case strings.HasPrefix(resource, "role:"):
+		return strings.TrimPrefix(resource, "role:") != ""
+	}
+
+	_, route := splitEndpointResource(resource)
+	return len(strings.Fields(resource)) <= 2 && strings.HasPrefix(route, "/")
+}
+
+func (rlr *RateLimitRule) Matches(subject *RateLimitSubject) bool {
+	resource := strings.TrimSpace(rlr.Resource)
+	switch {
+	case resource == "*":
+		return true
+	case strings.HasPrefix(resource, "ip:"):
+		prefix, err := ParseIPRange(strings.TrimPrefix(resource, "ip:"))
+		if err != nil {
+			return false
+		}
+		addr, err := netip.ParseAddr(subject.IPAddress)
+		return err == nil && prefix.Contains(addr.Unmap())
+	case strings.HasPrefix(resource, "user:"):
+		return subject.UserID != nil && strings.TrimPrefix(resource, "user:") == strconv.FormatUint(uint64(*subject.UserID), 10)
+	case strings.HasPrefix(resource, "role:"):
+		role := strings.TrimPrefix(resource, "role:")
+		for _, r := range subject.Roles {
+			if r == role {
+				return true
+			}
+		}
+		return false
+	}
+
+	method, route := splitEndpointResource(resource)
+	if method != "" && !strings.EqualFold(method, subject.Method) {
+		return false
+	}
+	if strings.HasSuffix(route, "*") {
+		return strings.HasPrefix(subject.Route, strings.TrimSuffix(route, "*"))
+	}
+	return route == subject.Route
+}
+
+func (rlr *RateLimitRule) Priority() int {
+	resource := strings.TrimSpace(rlr.Resource)
+	switch {
+	case strings.HasPrefix(resource, "user:"):
+		return 5
+	case strings.HasPrefix(resource, "ip:"):
+		return 4
+	case strings.HasPrefix(resource, "role:"):
+		return 3
+	case resource == "*":
+		return 0
+	}
+	return 1
+}
+
+// Specificity ranks rules that share a priority. A narrower IP range beats a
+// wider one. For endpoints a longer route prefix beats a shorter one, an exact
+// route beats a wildcard with the same prefix, and a method-bound rule beats an
+// unbound one.
+func (rlr *RateLimitRule) Specificity() int {
+	if strings.HasPrefix(strings.TrimSpace(rlr.Resource), "ip:") {
+		prefix, err := ParseIPRange(strings.TrimPrefix(strings.TrimSpace(rlr.Resource), "ip:"))
+		if err != nil {
+			return 0
+		}
+		return prefix.Bits()
+	}
+	if rlr.Priority() != 1 {
+		return 0
+	}
+
+	method, route := splitEndpointResource(strings.TrimSpace(rlr.Resource))
+	specificity := len(strings.TrimSuffix(route, "*")) * 4
+	if !strings.HasSuffix(route, "*") {
+		specificity += 2
+	}
+	if method != "" {
+		specificity++
+	}
+	return specificity
+}
+
+func (rlr *RateLimitRule) Outranks(other *RateLimitRule) bool {
+	if rlr.Priority() != other.Priority() {
+		return rlr.Priority() > other.Priority()
+	}
+	return rlr.Specificity() > other.Specificity()
+}
+
+func (rlr *RateLimitRule) CounterKey(subject *RateLimitSubject) string {
+	client := "ip:" + subject.IPAddress
+	if subject.UserID != nil && !strings.HasPrefix(strings.TrimSpace(rlr.Resource), "ip:") {
+		client = fmt.Sprintf("user:%d", *subject.UserID)
+	}
+	return fmt.Sprintf("rule:%d:%s", rlr.ID, client)
+}
+
+func (rlr *RateLimitRule) Window() time.Duration {
+	return time.Duration(rlr.WindowSize) * time.Second
+}
+
+func splitEndpointResource(resource string) (string, string) {
+	if i := strings.IndexByte(resource, ' '); i > 0 {
+		return resource[:i], strings.TrimSpace(resource[i+1:])
+	}
+	return "", resource
+}
+
+type RateLimitLog struct {
+	ID          uint
+	RuleID      uint
+	IPAddress   string
+	UserID      *uint
+	Requests    int
+	WindowStart time.Time
+	WindowEnd   time.Time
+	Blocked     bool
+	CreatedAt   time.Time
+}
+
+func NewRateLimitLog(ruleID uint, ipAddress string, userID *uint, requests int, windowStart, windowEnd time.Time, blocked bool) *RateLimitLog {
+	return &RateLimitLog{
+		RuleID:      ruleID,
+		IPAddress:   ipAddress,
+		UserID:      userID,
+		Requests:    requests,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Blocked:     blocked,
+		CreatedAt:   time.Now(),
+	}
+}
+
 type UserSession struct {
 	ID        uint
 	UserID    uint
@@ -206,13 +490,87 @@ func (df *DeviceFingerprint) UpdateLastSeen() {
 	df.UpdatedAt = time.Now()
 }
 
+type FraudRuleConfig struct {
+	ID            uint
+	Name          string
+	Enabled       bool
+	Weight        float64
+	Threshold     int
+	WindowSeconds int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func NewFraudRuleConfig(name string, weight float64, threshold, windowSeconds int) *FraudRuleConfig {
+	now := time.Now()
+	return &FraudRuleConfig{
+		Name:          name,
+		Enabled:       true,
+		Weight:        weight,
+		Threshold:     threshold,
+		WindowSeconds: windowSeconds,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (frc *FraudRuleConfig) Window() time.Duration {
+	return time.Duration(frc.WindowSeconds) * time.Second
+}
+
+func (frc *FraudRuleConfig) Update(enabled *bool, weight *float64, threshold, windowSeconds *int) {
+	if enabled != nil {
+		frc.Enabled = *enabled
+	}
+	if weight != nil {
+		frc.Weight = *weight
+	}
+	if threshold != nil {
+		frc.Threshold = *threshold
+	}
+	if windowSeconds != nil {
+		frc.WindowSeconds = *windowSeconds
+	}
+	frc.UpdatedAt = time.Now()
+}
+
+type FraudRuleContribution struct {
+	Rule    string
+	Matched bool
+	Weight  float64
+	Score   float64
+	Reason  string
+}
+
 type FraudAnalysis struct {
 	RiskScore      float64
 	RiskLevel      string
 	Factors        []string
+	Contributions  []FraudRuleContribution
 	Recommendation string
 }
 
+func NewFraudAnalysisFromContributions(contributions []FraudRuleContribution) *FraudAnalysis {
+	var riskScore float64
+	var factors []string
+
+	for _, contribution := range contributions {
+		if !contribution.Matched {
+			continue
+		}
+		riskScore += contribution.Score
+		factors = append(factors, contribution.Reason)
+	}
+
+	if riskScore > 1.0 {
+		riskScore = 1.0
+	}
+
+	analysis := NewFraudAnalysis(riskScore, factors)
+	analysis.Contributions = contributions
+	return analysis
+}
+
 func NewFraudAnalysis(riskScore float64, factors []string) *FraudAnalysis {
 	var riskLevel, recommendation string
 
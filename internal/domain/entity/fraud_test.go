@@ -254,6 +254,142 @@ func TestRateLimitRuleDeactivate(t *testing.T) {
 	assert.True(t, rule.UpdatedAt.After(oldUpdatedAt))
 }
 
+func TestIsValidRateLimitAlgorithm(t *testing.T) {
+	assert.True(t, entity.IsValidRateLimitAlgorithm(entity.RateLimitAlgorithmFixedWindow))
+	assert.True(t, entity.IsValidRateLimitAlgorithm(entity.RateLimitAlgorithmSlidingLog))
+	assert.True(t, entity.IsValidRateLimitAlgorithm(entity.RateLimitAlgorithmSlidingWindow))
+	assert.True(t, entity.IsValidRateLimitAlgorithm(entity.RateLimitAlgorithmTokenBucket))
+	assert.False(t, entity.IsValidRateLimitAlgorithm(""))
+	assert.False(t, entity.IsValidRateLimitAlgorithm("leaky_bucket"))
+}
+
+func TestRateLimitRuleChangeAlgorithm(t *testing.T) {
+	rule := entity.NewRateLimitRule("test", "/api/test", 10, 60)
+	oldUpdatedAt := rule.UpdatedAt
+	time.Sleep(1 * time.Millisecond)
+
+	rule.ChangeAlgorithm("")
+	assert.Empty(t, rule.Algorithm)
+	assert.Equal(t, oldUpdatedAt, rule.UpdatedAt)
+
+	rule.ChangeAlgorithm(entity.RateLimitAlgorithmTokenBucket)
+	assert.Equal(t, entity.RateLimitAlgorithmTokenBucket, rule.Algorithm)
+	assert.True(t, rule.UpdatedAt.After(oldUpdatedAt))
+}
+
+func TestRateLimitRuleChangeTarget(t *testing.T) {
+	rule := entity.NewRateLimitRule("IP:192.168.1.1", "ip:192.168.1.1", 10, 60)
+
+	rule.ChangeTarget("USER:42", "")
+	assert.Equal(t, "IP:192.168.1.1", rule.Name)
+	assert.Equal(t, "ip:192.168.1.1", rule.Resource)
+
+	rule.ChangeTarget("USER:42", "user:42")
+	assert.Equal(t, "USER:42", rule.Name)
+	assert.Equal(t, "user:42", rule.Resource)
+}
+
+func TestIsValidRateLimitResource(t *testing.T) {
+	tests := []struct {
+		resource string
+		want     bool
+	}{
+		{resource: "*", want: true},
+		{resource: "ip:192.168.1.1", want: true},
+		{resource: "ip:not-an-ip", want: false},
+		{resource: "user:42", want: true},
+		{resource: "user:abc", want: false},
+		{resource: "role:admin", want: true},
+		{resource: "role:", want: false},
+		{resource: "POST /api/v1/auth/login", want: true},
+		{resource: "/api/v1/*", want: true},
+		{resource: "api/v1", want: false},
+		{resource: "GET /a /b", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			assert.Equal(t, tt.want, entity.IsValidRateLimitResource(tt.resource))
+		})
+	}
+}
+
+func TestRateLimitResource(t *testing.T) {
+	tests := []struct {
+		name       string
+		ruleType   string
+		identifier string
+		want       string
+	}{
+		{name: "IPルール", ruleType: entity.RateLimitRuleTypeIP, identifier: "192.168.1.1", want: "ip:192.168.1.1"},
+		{name: "ユーザールール", ruleType: entity.RateLimitRuleTypeUser, identifier: "42", want: "user:42"},
+		{name: "ロールルール", ruleType: entity.RateLimitRuleTypeRole, identifier: "admin", want: "role:admin"},
+		{name: "エンドポイントルール", ruleType: entity.RateLimitRuleTypeEndpoint, identifier: "POST /api/v1/auth/login", want: "POST /api/v1/auth/login"},
+		{name: "グローバルルール", ruleType: entity.RateLimitRuleTypeGlobal, identifier: "default", want: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, entity.RateLimitResource(tt.ruleType, tt.identifier))
+		})
+	}
+}
+
+func TestRateLimitRuleMatches(t *testing.T) {
+	userID := uint(42)
+	subject := &entity.RateLimitSubject{
+		Method:    "POST",
+		Route:     "/api/v1/auth/login",
+		IPAddress: "192.168.1.1",
+		UserID:    &userID,
+		Roles:     []string{"user"},
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		want     bool
+	}{
+		{name: "ワイルドカード", resource: "*", want: true},
+		{name: "一致するIP", resource: "ip:192.168.1.1", want: true},
+		{name: "異なるIP", resource: "ip:10.0.0.1", want: false},
+		{name: "範囲内のIP", resource: "ip:192.168.0.0/16", want: true},
+		{name: "範囲外のIP", resource: "ip:10.0.0.0/8", want: false},
+		{name: "IPv4射影アドレスのルール", resource: "ip:::ffff:192.168.1.1", want: true},
+		{name: "一致するユーザー", resource: "user:42", want: true},
+		{name: "異なるユーザー", resource: "user:7", want: false},
+		{name: "保持しているロール", resource: "role:user", want: true},
+		{name: "保持していないロール", resource: "role:admin", want: false},
+		{name: "ルートのみ", resource: "/api/v1/auth/login", want: true},
+		{name: "メソッドとルート", resource: "POST /api/v1/auth/login", want: true},
+		{name: "メソッドが異なる", resource: "GET /api/v1/auth/login", want: false},
+		{name: "プレフィックス一致", resource: "/api/v1/auth/*", want: true},
+		{name: "異なるルート", resource: "/api/v1/auth/register", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := entity.NewRateLimitRule(tt.resource, tt.resource, 10, 60)
+			assert.Equal(t, tt.want, rule.Matches(subject))
+		})
+	}
+}
+
+func TestRateLimitRuleCounterKey(t *testing.T) {
+	userID := uint(42)
+	anonymous := &entity.RateLimitSubject{IPAddress: "192.168.1.1"}
+	authenticated := &entity.RateLimitSubject{IPAddress: "192.168.1.1", UserID: &userID}
+
+	endpointRule := entity.NewRateLimitRule("ENDPOINT:/api/v1/stats", "/api/v1/stats", 10, 60)
+	endpointRule.ID = 1
+	ipRule := entity.NewRateLimitRule("IP:192.168.1.1", "ip:192.168.1.1", 10, 60)
+	ipRule.ID = 2
+
+	assert.Equal(t, "rule:1:ip:192.168.1.1", endpointRule.CounterKey(anonymous))
+	assert.Equal(t, "rule:1:user:42", endpointRule.CounterKey(authenticated))
+	assert.Equal(t, "rule:2:ip:192.168.1.1", ipRule.CounterKey(authenticated))
+}
+
 func TestNewUserSession(t *testing.T) {
 	userID := uint(1)
 	sessionID := "session123"
@@ -470,3 +606,87 @@ func TestFraudAnalysisIsHighRisk(t *testing.T) {
 		})
 	}
 }
+
+func TestFraudRuleConfigUpdate(t *testing.T) {
+	config := entity.NewFraudRuleConfig("failed_logins", 0.3, 5, 900)
+	enabled := false
+	threshold := 10
+
+	config.Update(&enabled, nil, &threshold, nil)
+
+	assert.False(t, config.Enabled)
+	assert.Equal(t, 0.3, config.Weight)
+	assert.Equal(t, 10, config.Threshold)
+	assert.Equal(t, 15*time.Minute, config.Window())
+}
+
+func TestNewFraudAnalysisFromContributions(t *testing.T) {
+	tests := []struct {
+		name          string
+		contributions []entity.FraudRuleContribution
+		expectedScore float64
+		expectedLevel string
+		expectedCount int
+	}{
+		{
+			name: "一致したルールのみ加算される",
+			contributions: []entity.FraudRuleContribution{
+				{Rule: "unknown_device", Matched: true, Weight: 0.2, Score: 0.2, Reason: "Unknown device"},
+				{Rule: "ip_velocity", Matched: false, Weight: 0.4},
+			},
+			expectedScore: 0.2,
+			expectedLevel: "LOW",
+			expectedCount: 1,
+		},
+		{
+			name: "合計は1.0で頭打ち",
+			contributions: []entity.FraudRuleContribution{
+				{Rule: "ip_blacklisted", Matched: true, Weight: 0.8, Score: 0.8, Reason: "IP address is blacklisted"},
+				{Rule: "ip_velocity", Matched: true, Weight: 0.4, Score: 0.4, Reason: "High frequency requests from IP: 12"},
+			},
+			expectedScore: 1.0,
+			expectedLevel: "HIGH",
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := entity.NewFraudAnalysisFromContributions(tt.contributions)
+
+			assert.Equal(t, tt.expectedScore, analysis.RiskScore)
+			assert.Equal(t, tt.expectedLevel, analysis.RiskLevel)
+			assert.Len(t, analysis.Factors, tt.expectedCount)
+			assert.Equal(t, tt.contributions, analysis.Contributions)
+		})
+	}
+}
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		expectErr bool
+	}{
+		{name: "単一IPv4", value: "192.168.1.1", want: "192.168.1.1"},
+		{name: "IPv4 CIDRはネットワークアドレスに正規化", value: "10.1.2.3/8", want: "10.0.0.0/8"},
+		{name: "IPv6 CIDR", value: "2001:db8::1/32", want: "2001:db8::/32"},
+		{name: "IPv4射影アドレスはIPv4として扱う", value: "::ffff:203.0.113.10", want: "203.0.113.10"},
+		{name: "不正な値", value: "not-an-ip", expectErr: true},
+		{name: "不正なプレフィックス長", value: "10.0.0.0/33", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, err := entity.ParseIPRange(tt.value)
+
+			if tt.expectErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidIPRange)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, entity.FormatIPRange(prefix))
+		})
+	}
+}
@@ -1,13 +1,25 @@
 package entity
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
 	"time"
 )
 
 var (
-	ErrInsufficientPoints = errors.New("insufficient points")
-	ErrInvalidPointAmount = errors.New("invalid point amount")
+	ErrInsufficientPoints      = errors.New("insufficient points")
+	ErrInvalidPointAmount      = errors.New("invalid point amount")
+	ErrInvalidTierRequirements = errors.New("invalid tier requirements")
+	ErrInvalidTierBenefits     = errors.New("invalid tier benefits")
+	ErrInvalidPurchaseAmount   = errors.New("invalid purchase amount")
+	ErrPurchaseAlreadyRefunded = errors.New("purchase already refunded")
+	ErrPurchasePointsSpent     = errors.New("purchase points have already been spent")
+)
+
+const (
+	TierChangeReasonPromotion = "promotion"
+	TierChangeReasonDemotion  = "demotion"
 )
 
 type MembershipTier struct {
@@ -36,18 +48,90 @@ func NewMembershipTier(name string, level int, description, benefits, requiremen
 	}
 }
 
+func (t *MembershipTier) Update(name string, level int, description, benefits, requirements string, isActive bool) {
+	t.Name = name
+	t.Level = level
+	t.Description = description
+	t.Benefits = benefits
+	t.Requirements = requirements
+	t.IsActive = isActive
+	t.UpdatedAt = time.Now()
+}
+
+func (t *MembershipTier) ParseRequirements() (*TierRequirements, error) {
+	requirements := &TierRequirements{}
+	if t.Requirements == "" {
+		return requirements, nil
+	}
+	if err := json.Unmarshal([]byte(t.Requirements), requirements); err != nil {
+		return nil, ErrInvalidTierRequirements
+	}
+	if !requirements.IsValid() {
+		return nil, ErrInvalidTierRequirements
+	}
+	return requirements, nil
+}
+
+func (t *MembershipTier) ParseBenefits() (*TierBenefits, error) {
+	benefits := &TierBenefits{}
+	if t.Benefits != "" {
+		if err := json.Unmarshal([]byte(t.Benefits), benefits); err != nil {
+			return nil, ErrInvalidTierBenefits
+		}
+	}
+	if benefits.PointMultiplier < 0 || benefits.DiscountRate < 0 || benefits.DiscountRate >= 1 {
+		return nil, ErrInvalidTierBenefits
+	}
+	if benefits.PointMultiplier == 0 {
+		benefits.PointMultiplier = 1
+	}
+	return benefits, nil
+}
+
+type TierBenefits struct {
+	PointMultiplier float64  `json:"point_multiplier"`
+	DiscountRate    float64  `json:"discount_rate"`
+	Features        []string `json:"features,omitempty"`
+}
+
+type TierRequirements struct {
+	MinPoints        int     `json:"min_points"`
+	MinSpent         float64 `json:"min_spent"`
+	ActiveWithinDays int     `json:"active_within_days,omitempty"`
+}
+
+func (r *TierRequirements) IsValid() bool {
+	return r.MinPoints >= 0 && r.MinSpent >= 0 && r.ActiveWithinDays >= 0
+}
+
+func (r *TierRequirements) IsSatisfiedBy(membership *UserMembership, now time.Time) bool {
+	if membership.Points < r.MinPoints || membership.TotalSpent < r.MinSpent {
+		return false
+	}
+	if r.ActiveWithinDays > 0 {
+		if membership.LastActivityAt == nil {
+			return false
+		}
+		if membership.LastActivityAt.Before(now.AddDate(0, 0, -r.ActiveWithinDays)) {
+			return false
+		}
+	}
+	return true
+}
+
 type UserMembership struct {
-	ID             uint
-	UserID         uint
-	TierID         uint
-	Points         int
-	TotalSpent     float64
-	JoinedAt       time.Time
-	LastActivityAt *time.Time
-	ExpiresAt      *time.Time
-	IsActive       bool
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID              uint
+	UserID          uint
+	TierID          uint
+	Points          int
+	TotalSpent      float64
+	JoinedAt        time.Time
+	LastActivityAt  *time.Time
+	ExpiresAt       *time.Time
+	TierGraceEndsAt *time.Time
+	IsActive        bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func NewUserMembership(userID, tierID uint) *UserMembership {
@@ -92,27 +176,161 @@ func (um *UserMembership) UpdateTotalSpent(amount float64) {
 	}
 }
 
+func (um *UserMembership) RevertTotalSpent(amount float64) {
+	if amount <= 0 {
+		return
+	}
+	um.TotalSpent -= amount
+	if um.TotalSpent < 0 {
+		um.TotalSpent = 0
+	}
+	um.UpdatedAt = time.Now()
+}
+
+func (um *UserMembership) RefundPurchase(purchase *Purchase, lot *PointTransaction) (*PointTransaction, error) {
+	if purchase.IsRefunded() {
+		return nil, ErrPurchaseAlreadyRefunded
+	}
+
+	var reversal *PointTransaction
+	if lot != nil && lot.Points > 0 {
+		if lot.RemainingPoints < lot.Points || um.Points < lot.Points {
+			return nil, ErrPurchasePointsSpent
+		}
+		um.Points -= lot.Points
+		lot.RemainingPoints = 0
+
+		reversal = NewPointTransaction(um.UserID, PointTransactionTypeRefund, -lot.Points, "Purchase refunded: "+purchase.ID)
+		reversal.ReferenceType = PurchaseReferenceType
+		reversal.BalanceAfter = um.Points
+	}
+
+	um.RevertTotalSpent(purchase.ChargedAmount())
+	purchase.MarkRefunded()
+	return reversal, nil
+}
+
 func (um *UserMembership) UpdateLastActivity() {
 	now := time.Now()
 	um.LastActivityAt = &now
 	um.UpdatedAt = now
 }
 
+func (um *UserMembership) ChangeTier(tierID uint) {
+	um.TierID = tierID
+	um.TierGraceEndsAt = nil
+	um.UpdatedAt = time.Now()
+}
+
+func (um *UserMembership) StartTierGracePeriod(endsAt time.Time) {
+	um.TierGraceEndsAt = &endsAt
+	um.UpdatedAt = time.Now()
+}
+
+func (um *UserMembership) ClearTierGracePeriod() {
+	um.TierGraceEndsAt = nil
+	um.UpdatedAt = time.Now()
+}
+
 func (um *UserMembership) Deactivate() {
 	um.IsActive = false
 	um.UpdatedAt = time.Now()
 }
 
+func (um *UserMembership) ApplyPointTransaction(transaction *PointTransaction) error {
+	var err error
+	switch {
+	case transaction.Points > 0:
+		err = um.AddPoints(transaction.Points)
+	case transaction.Points < 0:
+		err = um.SpendPoints(-transaction.Points)
+	default:
+		err = ErrInvalidPointAmount
+	}
+	if err != nil {
+		return err
+	}
+	if transaction.Points > 0 {
+		transaction.RemainingPoints = transaction.Points
+	}
+	transaction.BalanceAfter = um.Points
+	return nil
+}
+
+func (um *UserMembership) ExpirePoints(lots []*PointTransaction) *PointTransaction {
+	expired := 0
+	for _, lot := range lots {
+		expired += lot.RemainingPoints
+		lot.RemainingPoints = 0
+	}
+	if expired > um.Points {
+		expired = um.Points
+	}
+	if expired <= 0 {
+		return nil
+	}
+
+	um.Points -= expired
+	um.UpdateLastActivity()
+
+	transaction := NewPointTransaction(um.UserID, PointTransactionTypeExpire, -expired, "Points expired")
+	transaction.BalanceAfter = um.Points
+	return transaction
+}
+
+type MembershipTierHistory struct {
+	ID         uint
+	UserID     uint
+	FromTierID uint
+	ToTierID   uint
+	Reason     string
+	CreatedAt  time.Time
+}
+
+func NewMembershipTierHistory(userID, fromTierID, toTierID uint, reason string) *MembershipTierHistory {
+	return &MembershipTierHistory{
+		UserID:     userID,
+		FromTierID: fromTierID,
+		ToTierID:   toTierID,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+}
+
+type TierChange struct {
+	UserID   uint
+	FromTier *MembershipTier
+	ToTier   *MembershipTier
+	Reason   string
+}
+
+type TierEvaluationResult struct {
+	Evaluated  int
+	Promoted   int
+	Demoted    int
+	GraceStart int
+	Failed     int
+}
+
+const (
+	PointTransactionTypeEarn   = "EARN"
+	PointTransactionTypeSpend  = "SPEND"
+	PointTransactionTypeExpire = "EXPIRE"
+	PointTransactionTypeRefund = "REFUND"
+)
+
 type PointTransaction struct {
-	ID            uint
-	UserID        uint
-	Type          string
-	Points        int
-	Description   string
-	ReferenceType string
-	ReferenceID   *uint
-	ExpiresAt     *time.Time
-	CreatedAt     time.Time
+	ID              uint
+	UserID          uint
+	Type            string
+	Points          int
+	RemainingPoints int
+	BalanceAfter    int
+	Description     string
+	ReferenceType   string
+	ReferenceID     *uint
+	ExpiresAt       *time.Time
+	CreatedAt       time.Time
 }
 
 func NewPointTransaction(userID uint, transactionType string, points int, description string) *PointTransaction {
@@ -125,6 +343,142 @@ func NewPointTransaction(userID uint, transactionType string, points int, descri
 	}
 }
 
+func (pt *PointTransaction) IsExpiredAt(asOf time.Time) bool {
+	return pt.RemainingPoints > 0 && pt.ExpiresAt != nil && !pt.ExpiresAt.After(asOf)
+}
+
+func ConsumePointLots(lots []*PointTransaction, points int) []*PointTransaction {
+	consumed := make([]*PointTransaction, 0, len(lots))
+	for _, lot := range lots {
+		if points <= 0 {
+			break
+		}
+		if lot.RemainingPoints <= 0 {
+			continue
+		}
+		used := lot.RemainingPoints
+		if used > points {
+			used = points
+		}
+		lot.RemainingPoints -= used
+		points -= used
+		consumed = append(consumed, lot)
+	}
+	return consumed
+}
+
+func IsValidPointTransactionType(transactionType string) bool {
+	switch transactionType {
+	case PointTransactionTypeEarn, PointTransactionTypeSpend, PointTransactionTypeExpire, PointTransactionTypeRefund:
+		return true
+	}
+	return false
+}
+
+type PointTransactionFilter struct {
+	Type string
+	From *time.Time
+	To   *time.Time
+}
+
+type PointSummary struct {
+	Balance     int
+	TotalEarned int
+	TotalSpent  int
+}
+
+type PointExpiration struct {
+	UserID          uint
+	ExpiredPoints   int
+	RemainingPoints int
+}
+
+type PointExpirationResult struct {
+	AsOf               time.Time
+	DryRun             bool
+	TotalUsersAffected int
+	TotalPointsExpired int
+	Details            []*PointExpiration
+}
+
+func NewPointExpirationResult(asOf time.Time, dryRun bool) *PointExpirationResult {
+	return &PointExpirationResult{
+		AsOf:    asOf,
+		DryRun:  dryRun,
+		Details: []*PointExpiration{},
+	}
+}
+
+func (r *PointExpirationResult) Add(expiration *PointExpiration) {
+	if expiration.ExpiredPoints <= 0 {
+		return
+	}
+	r.Details = append(r.Details, expiration)
+	r.TotalUsersAffected++
+	r.TotalPointsExpired += expiration.ExpiredPoints
+}
+
+const (
+	PurchaseStatusCompleted = "completed"
+	PurchaseStatusRefunded  = "refunded"
+	PurchaseReferenceType   = "purchase"
+)
+
+type Purchase struct {
+	ID                  string
+	UserID              uint
+	StoreID             uint
+	TotalAmount         float64
+	PointsEarned        int
+	TierDiscountApplied float64
+	PointTransactionID  *uint
+	Status              string
+	RefundedAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func NewPurchase(id string, userID, storeID uint, totalAmount float64) *Purchase {
+	now := time.Now()
+	return &Purchase{
+		ID:          id,
+		UserID:      userID,
+		StoreID:     storeID,
+		TotalAmount: totalAmount,
+		Status:      PurchaseStatusCompleted,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func (p *Purchase) ApplyTierBenefits(benefits *TierBenefits, pointRate float64) error {
+	if p.TotalAmount <= 0 {
+		return ErrInvalidPurchaseAmount
+	}
+	p.TierDiscountApplied = benefits.DiscountRate
+	p.PointsEarned = int(math.Floor(p.ChargedAmount() * pointRate * benefits.PointMultiplier))
+	return nil
+}
+
+func (p *Purchase) ChargedAmount() float64 {
+	return math.Round(p.TotalAmount*(1-p.TierDiscountApplied)*100) / 100
+}
+
+func (p *Purchase) IsRefunded() bool {
+	return p.Status == PurchaseStatusRefunded
+}
+
+func (p *Purchase) MarkRefunded() {
+	now := time.Now()
+	p.Status = PurchaseStatusRefunded
+	p.RefundedAt = &now
+	p.UpdatedAt = now
+}
+
+func (p *Purchase) Matches(other *Purchase) bool {
+	return p.UserID == other.UserID && p.StoreID == other.StoreID && p.TotalAmount == other.TotalAmount
+}
+
 type UserProfile struct {
 	ID          uint
 	UserID      uint
@@ -153,6 +507,27 @@ func NewUserProfile(userID uint) *UserProfile {
 	}
 }
 
+func (up *UserProfile) CompletionRate() int {
+	fields := []bool{
+		up.FirstName != "",
+		up.LastName != "",
+		up.PhoneNumber != "",
+		up.DateOfBirth != nil,
+		up.Gender != "",
+		up.Address != nil && *up.Address != "",
+		up.Avatar != "",
+		up.Bio != "",
+	}
+
+	completed := 0
+	for _, filled := range fields {
+		if filled {
+			completed++
+		}
+	}
+	return completed * 100 / len(fields)
+}
+
 func (up *UserProfile) UpdateProfile(firstName, lastName, phoneNumber, gender, bio string, dateOfBirth *time.Time) {
 	if firstName != "" {
 		up.FirstName = firstName
@@ -182,6 +557,13 @@ func (up *UserProfile) Verify() {
 	up.UpdatedAt = now
 }
 
+const (
+	NotificationPriorityLow    = "low"
+	NotificationPriorityMedium = "medium"
+	NotificationPriorityHigh   = "high"
+	NotificationPriorityUrgent = "urgent"
+)
+
 type Notification struct {
 	ID        uint
 	UserID    uint
@@ -189,6 +571,7 @@ type Notification struct {
 	Title     string
 	Message   string
 	Data      *string
+	Priority  string
 	IsRead    bool
 	ReadAt    *time.Time
 	CreatedAt time.Time
@@ -203,6 +586,7 @@ func NewNotification(userID uint, notificationType, title, message string, data
 		Title:     title,
 		Message:   message,
 		Data:      data,
+		Priority:  NotificationPriorityMedium,
 		IsRead:    false,
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -217,3 +601,40 @@ func (n *Notification) MarkAsRead() {
 		n.UpdatedAt = now
 	}
 }
+
+func IsValidNotificationPriority(priority string) bool {
+	switch priority {
+	case NotificationPriorityLow, NotificationPriorityMedium, NotificationPriorityHigh, NotificationPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+const (
+	NotificationEventTypeCreated     = "notification"
+	NotificationEventTypeUnreadCount = "unread_count"
+)
+
+type NotificationEvent struct {
+	Type         string
+	UserID       uint
+	Notification *Notification
+	UnreadCount  int64
+}
+
+func NewNotificationCreatedEvent(notification *Notification, unreadCount int64) *NotificationEvent {
+	return &NotificationEvent{
+		Type:         NotificationEventTypeCreated,
+		UserID:       notification.UserID,
+		Notification: notification,
+		UnreadCount:  unreadCount,
+	}
+}
+
+func NewNotificationUnreadCountEvent(userID uint, unreadCount int64) *NotificationEvent {
+	return &NotificationEvent{
+		Type:        NotificationEventTypeUnreadCount,
+		UserID:      userID,
+		UnreadCount: unreadCount,
+	}
+}
@@ -268,6 +268,149 @@ func TestNewPointTransaction(t *testing.T) {
 	})
 }
 
+func TestUserMembershipApplyPointTransaction(t *testing.T) {
+	t.Run("獲得取引で残高が増え取引に残高が記録される", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 150, "獲得")
+
+		err := membership.ApplyPointTransaction(transaction)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 150, membership.Points)
+		assert.Equal(t, 150, transaction.BalanceAfter)
+		assert.Equal(t, 150, transaction.RemainingPoints)
+		assert.NotNil(t, membership.LastActivityAt)
+	})
+
+	t.Run("使用取引で残高が減る", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 200
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeSpend, -80, "使用")
+
+		err := membership.ApplyPointTransaction(transaction)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 120, membership.Points)
+		assert.Equal(t, 120, transaction.BalanceAfter)
+	})
+
+	t.Run("残高不足の場合は変更しない", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 50
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeSpend, -80, "使用")
+
+		err := membership.ApplyPointTransaction(transaction)
+
+		assert.ErrorIs(t, err, entity.ErrInsufficientPoints)
+		assert.Equal(t, 50, membership.Points)
+		assert.Equal(t, 0, transaction.BalanceAfter)
+	})
+
+	t.Run("0ポイントの取引はエラー", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 0, "")
+
+		err := membership.ApplyPointTransaction(transaction)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPointAmount)
+	})
+}
+
+func TestUserMembershipExpirePoints(t *testing.T) {
+	t.Run("ロットの残りポイントを失効させる", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 300
+		lots := []*entity.PointTransaction{{RemainingPoints: 100}, {RemainingPoints: 40}}
+
+		transaction := membership.ExpirePoints(lots)
+
+		assert.NotNil(t, transaction)
+		assert.Equal(t, entity.PointTransactionTypeExpire, transaction.Type)
+		assert.Equal(t, -140, transaction.Points)
+		assert.Equal(t, 160, transaction.BalanceAfter)
+		assert.Equal(t, 160, membership.Points)
+		assert.Equal(t, 0, lots[0].RemainingPoints)
+		assert.Equal(t, 0, lots[1].RemainingPoints)
+	})
+
+	t.Run("残高を超えて失効させない", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 30
+		lots := []*entity.PointTransaction{{RemainingPoints: 100}}
+
+		transaction := membership.ExpirePoints(lots)
+
+		assert.Equal(t, -30, transaction.Points)
+		assert.Equal(t, 0, membership.Points)
+	})
+
+	t.Run("失効対象がない場合はnil", func(t *testing.T) {
+		membership := entity.NewUserMembership(1, 1)
+
+		assert.Nil(t, membership.ExpirePoints(nil))
+	})
+}
+
+func TestConsumePointLots(t *testing.T) {
+	t.Run("古いロットから順に消費する", func(t *testing.T) {
+		lots := []*entity.PointTransaction{
+			{ID: 1, RemainingPoints: 50},
+			{ID: 2, RemainingPoints: 0},
+			{ID: 3, RemainingPoints: 100},
+			{ID: 4, RemainingPoints: 100},
+		}
+
+		consumed := entity.ConsumePointLots(lots, 120)
+
+		assert.Len(t, consumed, 2)
+		assert.Equal(t, uint(1), consumed[0].ID)
+		assert.Equal(t, uint(3), consumed[1].ID)
+		assert.Equal(t, 0, lots[0].RemainingPoints)
+		assert.Equal(t, 30, lots[2].RemainingPoints)
+		assert.Equal(t, 100, lots[3].RemainingPoints)
+	})
+
+	t.Run("ロットが不足しても消費できる分だけ消費する", func(t *testing.T) {
+		lots := []*entity.PointTransaction{{ID: 1, RemainingPoints: 20}}
+
+		consumed := entity.ConsumePointLots(lots, 50)
+
+		assert.Len(t, consumed, 1)
+		assert.Equal(t, 0, lots[0].RemainingPoints)
+	})
+}
+
+func TestPointTransactionIsExpiredAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, (&entity.PointTransaction{RemainingPoints: 10, ExpiresAt: &past}).IsExpiredAt(now))
+	assert.False(t, (&entity.PointTransaction{RemainingPoints: 10, ExpiresAt: &future}).IsExpiredAt(now))
+	assert.False(t, (&entity.PointTransaction{RemainingPoints: 0, ExpiresAt: &past}).IsExpiredAt(now))
+	assert.False(t, (&entity.PointTransaction{RemainingPoints: 10}).IsExpiredAt(now))
+}
+
+func TestPointExpirationResultAdd(t *testing.T) {
+	result := entity.NewPointExpirationResult(time.Now(), true)
+
+	result.Add(&entity.PointExpiration{UserID: 1, ExpiredPoints: 100, RemainingPoints: 0})
+	result.Add(&entity.PointExpiration{UserID: 2, ExpiredPoints: 0, RemainingPoints: 50})
+	result.Add(&entity.PointExpiration{UserID: 3, ExpiredPoints: 20, RemainingPoints: 80})
+
+	assert.Equal(t, 2, result.TotalUsersAffected)
+	assert.Equal(t, 120, result.TotalPointsExpired)
+	assert.Len(t, result.Details, 2)
+}
+
+func TestIsValidPointTransactionType(t *testing.T) {
+	assert.True(t, entity.IsValidPointTransactionType(entity.PointTransactionTypeEarn))
+	assert.True(t, entity.IsValidPointTransactionType(entity.PointTransactionTypeSpend))
+	assert.True(t, entity.IsValidPointTransactionType(entity.PointTransactionTypeExpire))
+	assert.False(t, entity.IsValidPointTransactionType("earn"))
+	assert.False(t, entity.IsValidPointTransactionType("UNKNOWN"))
+}
+
 func TestNewUserProfile(t *testing.T) {
 	t.Run("新しいユーザープロフィールを正常に作成できる", func(t *testing.T) {
 		userID := uint(1)
@@ -363,6 +506,32 @@ func TestUserProfileVerify(t *testing.T) {
 	})
 }
 
+func TestUserProfileCompletionRate(t *testing.T) {
+	t.Run("未入力のプロフィールは0%", func(t *testing.T) {
+		profile := entity.NewUserProfile(1)
+
+		assert.Equal(t, 0, profile.CompletionRate())
+	})
+
+	t.Run("一部入力したプロフィール", func(t *testing.T) {
+		profile := entity.NewUserProfile(1)
+		profile.UpdateProfile("太郎", "山田", "", "", "", nil)
+
+		assert.Equal(t, 25, profile.CompletionRate())
+	})
+
+	t.Run("全項目を入力したプロフィールは100%", func(t *testing.T) {
+		profile := entity.NewUserProfile(1)
+		dateOfBirth := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+		address := "東京都"
+		profile.UpdateProfile("太郎", "山田", "090-1234-5678", "male", "よろしく", &dateOfBirth)
+		profile.Address = &address
+		profile.Avatar = "https://example.com/avatar.png"
+
+		assert.Equal(t, 100, profile.CompletionRate())
+	})
+}
+
 func TestNewNotification(t *testing.T) {
 	t.Run("新しい通知を正常に作成できる", func(t *testing.T) {
 		userID := uint(1)
@@ -428,3 +597,297 @@ func TestNotificationMarkAsRead(t *testing.T) {
 		assert.Equal(t, firstUpdatedAt, notification.UpdatedAt)
 	})
 }
+
+func TestMembershipTierParseRequirements(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements string
+		expected     *entity.TierRequirements
+		expectError  bool
+	}{
+		{
+			name:         "空の条件はすべて0",
+			requirements: "",
+			expected:     &entity.TierRequirements{},
+		},
+		{
+			name:         "JSONの条件を解析",
+			requirements: `{"min_points":1000,"min_spent":50000,"active_within_days":90}`,
+			expected:     &entity.TierRequirements{MinPoints: 1000, MinSpent: 50000, ActiveWithinDays: 90},
+		},
+		{
+			name:         "不正なJSONはエラー",
+			requirements: `{"min_points":`,
+			expectError:  true,
+		},
+		{
+			name:         "負の値はエラー",
+			requirements: `{"min_points":-1}`,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier := entity.NewMembershipTier("Gold", 3, "", "{}", tt.requirements)
+
+			requirements, err := tier.ParseRequirements()
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidTierRequirements)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, requirements)
+		})
+	}
+}
+
+func TestTierRequirementsIsSatisfiedBy(t *testing.T) {
+	now := time.Now()
+	recent := now.AddDate(0, 0, -10)
+	old := now.AddDate(0, 0, -100)
+
+	tests := []struct {
+		name           string
+		requirements   entity.TierRequirements
+		points         int
+		totalSpent     float64
+		lastActivityAt *time.Time
+		expected       bool
+	}{
+		{
+			name:         "ポイントと購入額を満たす",
+			requirements: entity.TierRequirements{MinPoints: 1000, MinSpent: 5000},
+			points:       1000,
+			totalSpent:   5000,
+			expected:     true,
+		},
+		{
+			name:         "ポイント不足",
+			requirements: entity.TierRequirements{MinPoints: 1000},
+			points:       999,
+			expected:     false,
+		},
+		{
+			name:         "購入額不足",
+			requirements: entity.TierRequirements{MinSpent: 5000},
+			totalSpent:   4999,
+			expected:     false,
+		},
+		{
+			name:           "期間内に活動あり",
+			requirements:   entity.TierRequirements{ActiveWithinDays: 30},
+			lastActivityAt: &recent,
+			expected:       true,
+		},
+		{
+			name:           "最終活動が古い",
+			requirements:   entity.TierRequirements{ActiveWithinDays: 30},
+			lastActivityAt: &old,
+			expected:       false,
+		},
+		{
+			name:         "活動履歴なし",
+			requirements: entity.TierRequirements{ActiveWithinDays: 30},
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			membership := entity.NewUserMembership(1, 1)
+			membership.Points = tt.points
+			membership.TotalSpent = tt.totalSpent
+			membership.LastActivityAt = tt.lastActivityAt
+
+			assert.Equal(t, tt.expected, tt.requirements.IsSatisfiedBy(membership, now))
+		})
+	}
+}
+
+func TestUserMembershipChangeTier(t *testing.T) {
+	membership := entity.NewUserMembership(1, 1)
+	membership.StartTierGracePeriod(time.Now().Add(time.Hour))
+	assert.NotNil(t, membership.TierGraceEndsAt)
+
+	membership.ChangeTier(2)
+
+	assert.Equal(t, uint(2), membership.TierID)
+	assert.Nil(t, membership.TierGraceEndsAt)
+}
+
+func TestUserMembershipTierGracePeriod(t *testing.T) {
+	membership := entity.NewUserMembership(1, 2)
+	endsAt := time.Now().Add(24 * time.Hour)
+
+	membership.StartTierGracePeriod(endsAt)
+	assert.Equal(t, endsAt, *membership.TierGraceEndsAt)
+	assert.Equal(t, uint(2), membership.TierID)
+
+	membership.ClearTierGracePeriod()
+	assert.Nil(t, membership.TierGraceEndsAt)
+}
+
+func TestMembershipTierParseBenefits(t *testing.T) {
+	tests := []struct {
+		name        string
+		benefits    string
+		expected    *entity.TierBenefits
+		expectError bool
+	}{
+		{
+			name:     "倍率未設定は1倍",
+			benefits: `{"features":["basic_support"]}`,
+			expected: &entity.TierBenefits{PointMultiplier: 1, Features: []string{"basic_support"}},
+		},
+		{
+			name:     "倍率と割引率を解析",
+			benefits: `{"point_multiplier":1.5,"discount_rate":0.05}`,
+			expected: &entity.TierBenefits{PointMultiplier: 1.5, DiscountRate: 0.05},
+		},
+		{
+			name:        "割引率が1以上はエラー",
+			benefits:    `{"discount_rate":1}`,
+			expectError: true,
+		},
+		{
+			name:        "不正なJSONはエラー",
+			benefits:    `[`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier := entity.NewMembershipTier("Gold", 3, "", tt.benefits, "")
+
+			benefits, err := tier.ParseBenefits()
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidTierBenefits)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, benefits)
+		})
+	}
+}
+
+func TestPurchaseApplyTierBenefits(t *testing.T) {
+	tests := []struct {
+		name           string
+		totalAmount    float64
+		benefits       *entity.TierBenefits
+		expectedPoints int
+		expectedCharge float64
+		expectError    bool
+	}{
+		{
+			name:           "割引なし",
+			totalAmount:    10000,
+			benefits:       &entity.TierBenefits{PointMultiplier: 1},
+			expectedPoints: 100,
+			expectedCharge: 10000,
+		},
+		{
+			name:           "割引と倍率を適用",
+			totalAmount:    10000,
+			benefits:       &entity.TierBenefits{PointMultiplier: 1.5, DiscountRate: 0.05},
+			expectedPoints: 142,
+			expectedCharge: 9500,
+		},
+		{
+			name:           "端数は切り捨て",
+			totalAmount:    99,
+			benefits:       &entity.TierBenefits{PointMultiplier: 1},
+			expectedPoints: 0,
+			expectedCharge: 99,
+		},
+		{
+			name:        "0円以下はエラー",
+			totalAmount: 0,
+			benefits:    &entity.TierBenefits{PointMultiplier: 1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchase := entity.NewPurchase("order-1", 1, 10, tt.totalAmount)
+
+			err := purchase.ApplyTierBenefits(tt.benefits, 0.01)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidPurchaseAmount)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPoints, purchase.PointsEarned)
+			assert.Equal(t, tt.expectedCharge, purchase.ChargedAmount())
+			assert.Equal(t, entity.PurchaseStatusCompleted, purchase.Status)
+		})
+	}
+}
+
+func TestUserMembershipRefundPurchase(t *testing.T) {
+	newFixture := func() (*entity.UserMembership, *entity.Purchase, *entity.PointTransaction) {
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 300
+		membership.TotalSpent = 20000
+
+		purchase := entity.NewPurchase("order-1", 1, 10, 10000)
+		_ = purchase.ApplyTierBenefits(&entity.TierBenefits{PointMultiplier: 1}, 0.01)
+
+		lot := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "Purchase: order-1")
+		lot.RemainingPoints = 100
+		return membership, purchase, lot
+	}
+
+	t.Run("獲得ポイントと購入額を取り消す", func(t *testing.T) {
+		membership, purchase, lot := newFixture()
+
+		reversal, err := membership.RefundPurchase(purchase, lot)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.PointTransactionTypeRefund, reversal.Type)
+		assert.Equal(t, -100, reversal.Points)
+		assert.Equal(t, 200, reversal.BalanceAfter)
+		assert.Equal(t, 200, membership.Points)
+		assert.Equal(t, float64(10000), membership.TotalSpent)
+		assert.Equal(t, 0, lot.RemainingPoints)
+		assert.True(t, purchase.IsRefunded())
+		assert.NotNil(t, purchase.RefundedAt)
+	})
+
+	t.Run("獲得ポイントが使用済みの場合はエラー", func(t *testing.T) {
+		membership, purchase, lot := newFixture()
+		lot.RemainingPoints = 40
+
+		_, err := membership.RefundPurchase(purchase, lot)
+
+		assert.ErrorIs(t, err, entity.ErrPurchasePointsSpent)
+		assert.Equal(t, 300, membership.Points)
+		assert.False(t, purchase.IsRefunded())
+	})
+
+	t.Run("返金済みの場合はエラー", func(t *testing.T) {
+		membership, purchase, lot := newFixture()
+		purchase.MarkRefunded()
+
+		_, err := membership.RefundPurchase(purchase, lot)
+
+		assert.ErrorIs(t, err, entity.ErrPurchaseAlreadyRefunded)
+	})
+
+	t.Run("ポイント付与がない購入は購入額のみ取り消す", func(t *testing.T) {
+		membership, purchase, _ := newFixture()
+
+		reversal, err := membership.RefundPurchase(purchase, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, reversal)
+		assert.Equal(t, 300, membership.Points)
+		assert.Equal(t, float64(10000), membership.TotalSpent)
+	})
+}
@@ -0,0 +1,418 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	OAuthGrantTypeAuthorizationCode = "authorization_code"
+	OAuthGrantTypeClientCredentials = "client_credentials"
+	OAuthGrantTypeRefreshToken      = "refresh_token"
+
+	OAuthResponseTypeCode        = "code"
+	OAuthCodeChallengeMethodS256 = "S256"
+
+	OAuthTokenTypeBearer       = "Bearer"
+	OAuthTokenTypeAccessToken  = "access_token"
+	OAuthTokenTypeRefreshToken = "refresh_token"
+
+	OAuthClientIDPrefix       = "oc_"
+	OAuthAuthorizationCodeTTL = 10 * time.Minute
+	OAuthRefreshTokenTTL      = 30 * 24 * time.Hour
+)
+
+const (
+	SecurityEventOAuthClientRegistered = "OAUTH_CLIENT_REGISTERED"
+	SecurityEventOAuthClientDeleted    = "OAUTH_CLIENT_DELETED"
+	SecurityEventOAuthConsentGranted   = "OAUTH_CONSENT_GRANTED"
+	SecurityEventOAuthConsentRevoked   = "OAUTH_CONSENT_REVOKED"
+	SecurityEventOAuthTokenReuse       = "OAUTH_TOKEN_REUSE"
+)
+
+// The messages of these errors are not shown to OAuth clients; handlers map
+// them to the error codes of RFC 6749 section 5.2.
+var (
+	ErrOAuthInvalidRequest          = errors.New("invalid oauth request")
+	ErrOAuthInvalidClient           = errors.New("invalid oauth client")
+	ErrOAuthInvalidGrant            = errors.New("invalid oauth grant")
+	ErrOAuthInvalidScope            = errors.New("invalid oauth scope")
+	ErrOAuthInvalidRedirectURI      = errors.New("invalid redirect uri")
+	ErrOAuthInvalidGrantType        = errors.New("invalid grant type")
+	ErrOAuthUnauthorizedClient      = errors.New("oauth client is not authorized to use this grant type")
+	ErrOAuthUnsupportedGrantType    = errors.New("unsupported grant type")
+	ErrOAuthUnsupportedResponseType = errors.New("unsupported response type")
+	ErrOAuthAccessDenied            = errors.New("oauth access denied")
+	ErrOAuthClientNotFound          = errors.New("oauth client not found")
+	ErrOAuthConsentNotFound         = errors.New("oauth consent not found")
+)
+
+type OAuthClient struct {
+	ID               uint
+	ClientID         string
+	ClientSecretHash string
+	Name             string
+	RedirectURIs     []string
+	GrantTypes       []string
+	Scopes           []string
+	Confidential     bool
+	CreatedBy        uint
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewOAuthClient returns the client together with its plaintext secret, which
+// is empty for public clients and cannot be recovered afterwards.
+func NewOAuthClient(name string, redirectURIs, grantTypes, scopes []string, confidential bool, createdBy uint) (*OAuthClient, string, error) {
+	if name == "" || len(name) > 255 {
+		return nil, "", fmt.Errorf("%w: client name is required", ErrOAuthInvalidRequest)
+	}
+
+	if len(grantTypes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one grant type is required", ErrOAuthInvalidGrantType)
+	}
+	for _, grantType := range grantTypes {
+		switch grantType {
+		case OAuthGrantTypeAuthorizationCode, OAuthGrantTypeRefreshToken:
+		case OAuthGrantTypeClientCredentials:
+			if !confidential {
+				return nil, "", fmt.Errorf("%w: public clients cannot use %s", ErrOAuthInvalidGrantType, grantType)
+			}
+		default:
+			return nil, "", fmt.Errorf("%w: %q", ErrOAuthInvalidGrantType, grantType)
+		}
+	}
+
+	if slices.Contains(grantTypes, OAuthGrantTypeAuthorizationCode) && len(redirectURIs) == 0 {
+		return nil, "", fmt.Errorf("%w: authorization_code clients need a redirect uri", ErrOAuthInvalidRedirectURI)
+	}
+	for _, redirectURI := range redirectURIs {
+		if err := validateRedirectURI(redirectURI); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", ErrOAuthInvalidScope)
+	}
+	for _, scope := range scopes {
+		if !isOAuthScopeToken(scope) {
+			return nil, "", fmt.Errorf("%w: %q", ErrOAuthInvalidScope, scope)
+		}
+	}
+
+	idBytes := make([]byte, 12)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	client := &OAuthClient{
+		ClientID:     OAuthClientIDPrefix + hex.EncodeToString(idBytes),
+		Name:         name,
+		RedirectURIs: dedupeStrings(redirectURIs),
+		GrantTypes:   dedupeStrings(grantTypes),
+		Scopes:       dedupeStrings(scopes),
+		Confidential: confidential,
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if !confidential {
+		return client, "", nil
+	}
+
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+	client.ClientSecretHash = hashOpaqueToken(secret)
+	return client, secret, nil
+}
+
+func (c *OAuthClient) VerifySecret(secret string) bool {
+	if !c.Confidential {
+		return secret == ""
+	}
+	if secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.ClientSecretHash), []byte(hashOpaqueToken(secret))) == 1
+}
+
+func (c *OAuthClient) AllowsGrantType(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+func (c *OAuthClient) HasRedirectURI(redirectURI string) bool {
+	return slices.Contains(c.RedirectURIs, redirectURI)
+}
+
+// ResolveScopes checks a space-delimited scope parameter against the
+// client's registered scopes. An empty request means all of them.
+func (c *OAuthClient) ResolveScopes(requested string) ([]string, error) {
+	scopes := ParseOAuthScope(requested)
+	if len(scopes) == 0 {
+		return slices.Clone(c.Scopes), nil
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return nil, fmt.Errorf("%w: %q", ErrOAuthInvalidScope, scope)
+		}
+	}
+	return scopes, nil
+}
+
+type OAuthAuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+type OAuthAuthorizationCode struct {
+	ID                  uint
+	CodeHash            string
+	ClientID            string
+	UserID              uint
+	RedirectURI         string
+	Scopes              []string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	ExpiresAt           time.Time
+	UsedAt              *time.Time
+	CreatedAt           time.Time
+}
+
+func NewOAuthAuthorizationCode(clientID string, userID uint, redirectURI string, scopes []string, codeChallenge, codeChallengeMethod string) (*OAuthAuthorizationCode, string, error) {
+	code, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &OAuthAuthorizationCode{
+		CodeHash:            hashOpaqueToken(code),
+		ClientID:            clientID,
+		UserID:              userID,
+		RedirectURI:         redirectURI,
+		Scopes:              scopes,
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+		ExpiresAt:           now.Add(OAuthAuthorizationCodeTTL),
+		CreatedAt:           now,
+	}, code, nil
+}
+
+func (c *OAuthAuthorizationCode) IsUsed() bool {
+	return c.UsedAt != nil
+}
+
+func (c *OAuthAuthorizationCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+func (c *OAuthAuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if c.CodeChallengeMethod != OAuthCodeChallengeMethodS256 || !IsValidCodeVerifier(verifier) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.CodeChallenge), []byte(PKCEChallengeS256(verifier))) == 1
+}
+
+type OAuthRefreshToken struct {
+	ID           uint
+	TokenHash    string
+	ClientID     string
+	UserID       uint
+	Scopes       []string
+	FamilyID     string
+	TokenVersion int64
+	ExpiresAt    time.Time
+	IsRevoked    bool
+	RotatedAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func NewOAuthRefreshToken(clientID string, userID uint, scopes []string, familyID string, tokenVersion int64) (*OAuthRefreshToken, string, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &OAuthRefreshToken{
+		TokenHash:    hashOpaqueToken(token),
+		ClientID:     clientID,
+		UserID:       userID,
+		Scopes:       scopes,
+		FamilyID:     familyID,
+		TokenVersion: tokenVersion,
+		ExpiresAt:    now.Add(OAuthRefreshTokenTTL),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, token, nil
+}
+
+func (rt *OAuthRefreshToken) IsRotated() bool {
+	return rt.RotatedAt != nil
+}
+
+func (rt *OAuthRefreshToken) IsExpired() bool {
+	return time.Now().After(rt.ExpiresAt)
+}
+
+func (rt *OAuthRefreshToken) IsValid() bool {
+	return !rt.IsRevoked && !rt.IsExpired()
+}
+
+type OAuthConsent struct {
+	ID        uint
+	UserID    uint
+	ClientID  string
+	Scopes    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewOAuthConsent(userID uint, clientID string, scopes []string) *OAuthConsent {
+	now := time.Now()
+	return &OAuthConsent{
+		UserID:    userID,
+		ClientID:  clientID,
+		Scopes:    dedupeStrings(scopes),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *OAuthConsent) Grant(scopes []string) {
+	c.Scopes = dedupeStrings(append(c.Scopes, scopes...))
+	c.UpdatedAt = time.Now()
+}
+
+type OAuthTokenSet struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresIn    int
+	RefreshToken string
+	Scopes       []string
+}
+
+// OAuthTokenInfo is the result of token introspection (RFC 7662). Only
+// Active is meaningful when the token is not active.
+type OAuthTokenInfo struct {
+	Active    bool
+	TokenType string
+	ClientID  string
+	UserID    uint
+	Subject   string
+	Scopes    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func ParseOAuthScope(scope string) []string {
+	return dedupeStrings(strings.Fields(scope))
+}
+
+func FormatOAuthScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+func PKCEChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// IsValidCodeVerifier follows RFC 7636 section 4.1.
+func IsValidCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, r := range verifier {
+		if !isUnreservedRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func IsValidCodeChallenge(challenge string) bool {
+	if len(challenge) != base64.RawURLEncoding.EncodedLen(sha256.Size) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil
+}
+
+func HashOAuthToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func validateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		return fmt.Errorf("%w: %q", ErrOAuthInvalidRedirectURI, redirectURI)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if host := u.Hostname(); host == "localhost" || net.ParseIP(host).IsLoopback() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q must use https", ErrOAuthInvalidRedirectURI, redirectURI)
+}
+
+// isOAuthScopeToken follows the scope-token syntax of RFC 6749 section 3.3.
+func isOAuthScopeToken(scope string) bool {
+	if scope == "" || len(scope) > 255 {
+		return false
+	}
+	for _, r := range scope {
+		if r < 0x21 || r > 0x7e || r == '"' || r == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUnreservedRune(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') ||
+		r == '-' || r == '.' || r == '_' || r == '~'
+}
+
+func dedupeStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
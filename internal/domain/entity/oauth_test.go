@@ -0,0 +1,160 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOAuthClient(t *testing.T) {
+	t.Run("機密クライアントはシークレットのハッシュだけを保持する", func(t *testing.T) {
+		client, secret, err := entity.NewOAuthClient("Shop App", []string{"https://shop.example.com/callback"},
+			[]string{entity.OAuthGrantTypeAuthorizationCode, entity.OAuthGrantTypeRefreshToken}, []string{"profile", "points:read"}, true, 1)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(client.ClientID, entity.OAuthClientIDPrefix))
+		assert.NotEmpty(t, secret)
+		assert.NotContains(t, client.ClientSecretHash, secret)
+		assert.True(t, client.VerifySecret(secret))
+		assert.False(t, client.VerifySecret("wrong"))
+		assert.False(t, client.VerifySecret(""))
+	})
+
+	t.Run("公開クライアントにはシークレットがない", func(t *testing.T) {
+		client, secret, err := entity.NewOAuthClient("CLI", []string{"http://127.0.0.1:8085/callback"},
+			[]string{entity.OAuthGrantTypeAuthorizationCode}, []string{"profile"}, false, 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, secret)
+		assert.True(t, client.VerifySecret(""))
+		assert.False(t, client.VerifySecret("anything"))
+	})
+
+	tests := []struct {
+		name         string
+		redirectURIs []string
+		grantTypes   []string
+		scopes       []string
+		confidential bool
+		expectedErr  error
+	}{
+		{
+			name:         "公開クライアントはclient_credentialsを使えない",
+			grantTypes:   []string{entity.OAuthGrantTypeClientCredentials},
+			scopes:       []string{"inventory:read"},
+			confidential: false,
+			expectedErr:  entity.ErrOAuthInvalidGrantType,
+		},
+		{
+			name:         "未知のグラントタイプ",
+			redirectURIs: []string{"https://shop.example.com/callback"},
+			grantTypes:   []string{"password"},
+			scopes:       []string{"profile"},
+			confidential: true,
+			expectedErr:  entity.ErrOAuthInvalidGrantType,
+		},
+		{
+			name:         "httpのリダイレクトURI",
+			redirectURIs: []string{"http://shop.example.com/callback"},
+			grantTypes:   []string{entity.OAuthGrantTypeAuthorizationCode},
+			scopes:       []string{"profile"},
+			confidential: true,
+			expectedErr:  entity.ErrOAuthInvalidRedirectURI,
+		},
+		{
+			name:         "フラグメント付きのリダイレクトURI",
+			redirectURIs: []string{"https://shop.example.com/callback#token"},
+			grantTypes:   []string{entity.OAuthGrantTypeAuthorizationCode},
+			scopes:       []string{"profile"},
+			confidential: true,
+			expectedErr:  entity.ErrOAuthInvalidRedirectURI,
+		},
+		{
+			name:         "リダイレクトURIがない",
+			grantTypes:   []string{entity.OAuthGrantTypeAuthorizationCode},
+			scopes:       []string{"profile"},
+			confidential: true,
+			expectedErr:  entity.ErrOAuthInvalidRedirectURI,
+		},
+		{
+			name:         "不正なスコープ",
+			grantTypes:   []string{entity.OAuthGrantTypeClientCredentials},
+			scopes:       []string{`profile"`},
+			confidential: true,
+			expectedErr:  entity.ErrOAuthInvalidScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := entity.NewOAuthClient("App", tt.redirectURIs, tt.grantTypes, tt.scopes, tt.confidential, 1)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOAuthClientResolveScopes(t *testing.T) {
+	client := &entity.OAuthClient{Scopes: []string{"profile", "points:read"}}
+
+	scopes, err := client.ResolveScopes("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"profile", "points:read"}, scopes)
+
+	scopes, err = client.ResolveScopes("points:read  points:read")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"points:read"}, scopes)
+
+	_, err = client.ResolveScopes("profile admin")
+	assert.ErrorIs(t, err, entity.ErrOAuthInvalidScope)
+}
+
+func TestOAuthAuthorizationCodeVerifyCodeVerifier(t *testing.T) {
+	// RFC 7636 Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	assert.Equal(t, challenge, entity.PKCEChallengeS256(verifier))
+	assert.True(t, entity.IsValidCodeChallenge(challenge))
+	assert.False(t, entity.IsValidCodeChallenge("short"))
+
+	code, plaintext, err := entity.NewOAuthAuthorizationCode("oc_1", 1, "https://shop.example.com/callback", []string{"profile"}, challenge, entity.OAuthCodeChallengeMethodS256)
+	require.NoError(t, err)
+	assert.Equal(t, entity.HashOAuthToken(plaintext), code.CodeHash)
+	assert.False(t, code.IsExpired())
+	assert.WithinDuration(t, time.Now().Add(entity.OAuthAuthorizationCodeTTL), code.ExpiresAt, time.Second)
+
+	assert.True(t, code.VerifyCodeVerifier(verifier))
+	assert.False(t, code.VerifyCodeVerifier(strings.Replace(verifier, "d", "e", 1)))
+	assert.False(t, code.VerifyCodeVerifier("too-short"))
+	assert.False(t, code.VerifyCodeVerifier(verifier+"!"))
+}
+
+func TestOAuthConsent(t *testing.T) {
+	consent := entity.NewOAuthConsent(1, "oc_1", []string{"profile"})
+
+	assert.True(t, consent.Covers([]string{"profile"}))
+	assert.False(t, consent.Covers([]string{"profile", "points:read"}))
+
+	consent.Grant([]string{"points:read", "profile"})
+	assert.Equal(t, []string{"profile", "points:read"}, consent.Scopes)
+	assert.True(t, consent.Covers([]string{"profile", "points:read"}))
+}
+
+func TestOAuthRefreshTokenIsValid(t *testing.T) {
+	token, plaintext, err := entity.NewOAuthRefreshToken("oc_1", 1, []string{"profile"}, "family-1", 3)
+	require.NoError(t, err)
+	assert.Equal(t, entity.HashOAuthToken(plaintext), token.TokenHash)
+	assert.True(t, token.IsValid())
+
+	token.IsRevoked = true
+	assert.False(t, token.IsValid())
+
+	token.IsRevoked = false
+	token.ExpiresAt = time.Now().Add(-time.Minute)
+	assert.False(t, token.IsValid())
+}
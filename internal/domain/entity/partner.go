@@ -0,0 +1,240 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	PartnerAPIKeyPrefix       = "pk_"
+	PartnerQuotaEndpointAll   = "*"
+	partnerAPIKeyPrefixLength = len(PartnerAPIKeyPrefix) + 8
+)
+
+const (
+	SecurityEventPartnerAPIKeyCreated = "PARTNER_API_KEY_CREATED"
+	SecurityEventPartnerAPIKeyRotated = "PARTNER_API_KEY_ROTATED"
+	SecurityEventPartnerAPIKeyRevoked = "PARTNER_API_KEY_REVOKED"
+)
+
+var (
+	ErrPartnerAPIKeyNotFound = errors.New("partner api key not found")
+	ErrPartnerAPIKeyRevoked  = errors.New("partner api key already revoked")
+	ErrInvalidPartnerAPIKey  = errors.New("invalid partner api key")
+	ErrInvalidPartnerID      = errors.New("invalid partner id")
+	ErrInvalidPartnerQuota   = errors.New("invalid partner quota")
+	ErrPartnerQuotaExceeded  = errors.New("partner quota exceeded")
+	ErrPartnerKeyRateLimited = errors.New("partner api key rate limit exceeded")
+)
+
+type PartnerAPIKey struct {
+	ID          uint
+	PartnerID   string
+	PartnerName string
+	KeyPrefix   string
+	KeyHash     string
+	Permissions []string
+	RateLimit   int
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewPartnerAPIKey returns the key together with its plaintext value, which
+// is not stored anywhere and cannot be recovered afterwards.
+func NewPartnerAPIKey(partnerID, partnerName string, permissions []string, rateLimit int) (*PartnerAPIKey, string, error) {
+	if partnerID == "" || len(partnerID) > 255 {
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidPartnerID, partnerID)
+	}
+
+	grants, err := ParsePermissionSet(permissions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	key := &PartnerAPIKey{
+		PartnerID:   partnerID,
+		PartnerName: partnerName,
+		Permissions: grants.Strings(),
+		RateLimit:   rateLimit,
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	plaintext, err := key.issueSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+func (k *PartnerAPIKey) Rotate() (string, error) {
+	if !k.Enabled {
+		return "", ErrPartnerAPIKeyRevoked
+	}
+	plaintext, err := k.issueSecret()
+	if err != nil {
+		return "", err
+	}
+	k.UpdatedAt = time.Now()
+	return plaintext, nil
+}
+
+func (k *PartnerAPIKey) Revoke() error {
+	if !k.Enabled {
+		return ErrPartnerAPIKeyRevoked
+	}
+	k.Enabled = false
+	k.UpdatedAt = time.Now()
+	return nil
+}
+
+// Grants skips entries that are not permission grants so keys seeded with
+// legacy permission labels still authenticate without granting anything.
+func (k *PartnerAPIKey) Grants() PermissionSet {
+	grants := make([]PermissionGrant, 0, len(k.Permissions))
+	for _, permission := range k.Permissions {
+		if grant, err := ParsePermissionGrant(permission); err == nil {
+			grants = append(grants, grant)
+		}
+	}
+	return NewPermissionSet(grants...)
+}
+
+func (k *PartnerAPIKey) issueSecret() (string, error) {
+	id := make([]byte, (partnerAPIKeyPrefixLength-len(PartnerAPIKeyPrefix))/2)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	prefix := PartnerAPIKeyPrefix + hex.EncodeToString(id)
+	plaintext := prefix + "." + secret
+	k.KeyPrefix = prefix
+	k.KeyHash = HashPartnerAPIKey(plaintext)
+	return plaintext, nil
+}
+
+func HashPartnerAPIKey(key string) string {
+	return hashOpaqueToken(key)
+}
+
+func IsPartnerAPIKeyFormat(key string) bool {
+	prefix, secret, found := strings.Cut(key, ".")
+	return found && secret != "" &&
+		len(prefix) == partnerAPIKeyPrefixLength &&
+		strings.HasPrefix(prefix, PartnerAPIKeyPrefix)
+}
+
+type PartnerQuota struct {
+	ID               uint
+	PartnerID        string
+	Endpoint         string
+	LimitPerHour     int
+	LimitPerDay      int
+	CurrentHourCount int
+	CurrentDayCount  int
+	ResetTime        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewPartnerQuota(partnerID, endpoint string, limitPerHour, limitPerDay int) (*PartnerQuota, error) {
+	if partnerID == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPartnerID, partnerID)
+	}
+	if endpoint != PartnerQuotaEndpointAll && !strings.HasPrefix(endpoint, "/") {
+		return nil, fmt.Errorf("%w: endpoint must be * or start with /", ErrInvalidPartnerQuota)
+	}
+	if limitPerHour <= 0 || limitPerDay < limitPerHour {
+		return nil, fmt.Errorf("%w: limits must be positive and the daily limit at least the hourly limit", ErrInvalidPartnerQuota)
+	}
+
+	now := time.Now()
+	return &PartnerQuota{
+		PartnerID:    partnerID,
+		Endpoint:     endpoint,
+		LimitPerHour: limitPerHour,
+		LimitPerDay:  limitPerDay,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Matches accepts exact routes, "*" for every route, and patterns ending in
+// "/*" for everything below a path.
+func (q *PartnerQuota) Matches(endpoint string) bool {
+	if q.Endpoint == PartnerQuotaEndpointAll || q.Endpoint == endpoint {
+		return true
+	}
+	base, found := strings.CutSuffix(q.Endpoint, "/*")
+	return found && (endpoint == base || strings.HasPrefix(endpoint, base+"/"))
+}
+
+// SelectPartnerQuota picks the most specific quota so a partner-wide "*"
+// limit can be overridden for individual endpoints.
+func SelectPartnerQuota(quotas []*PartnerQuota, endpoint string) *PartnerQuota {
+	var selected *PartnerQuota
+	for _, quota := range quotas {
+		if !quota.Matches(endpoint) {
+			continue
+		}
+		if selected == nil || quotaSpecificity(quota) > quotaSpecificity(selected) {
+			selected = quota
+		}
+	}
+	return selected
+}
+
+func quotaSpecificity(quota *PartnerQuota) int {
+	if quota.Endpoint == PartnerQuotaEndpointAll {
+		return 0
+	}
+	if strings.HasSuffix(quota.Endpoint, "/*") {
+		return len(quota.Endpoint)
+	}
+	return len(quota.Endpoint) + 1
+}
+
+// PartnerQuotaWindow returns when the current hourly window ends and when the
+// current day began. Counters are kept in calendar hours and days.
+func PartnerQuotaWindow(now time.Time) (hourReset, dayStart time.Time) {
+	year, month, day := now.Date()
+	hourStart := time.Date(year, month, day, now.Hour(), 0, 0, 0, now.Location())
+	return hourStart.Add(time.Hour), time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+}
+
+func (q *PartnerQuota) RemainingHour(now time.Time) int {
+	if q.ResetTime == nil || !q.ResetTime.After(now) {
+		return q.LimitPerHour
+	}
+	return max(q.LimitPerHour-q.CurrentHourCount, 0)
+}
+
+func (q *PartnerQuota) RemainingDay(now time.Time) int {
+	_, dayStart := PartnerQuotaWindow(now)
+	if q.ResetTime == nil || !q.ResetTime.After(dayStart) {
+		return q.LimitPerDay
+	}
+	return max(q.LimitPerDay-q.CurrentDayCount, 0)
+}
+
+func (q *PartnerQuota) RetryAfter(now time.Time) time.Duration {
+	hourReset, dayStart := PartnerQuotaWindow(now)
+	if q.RemainingDay(now) == 0 {
+		return dayStart.AddDate(0, 0, 1).Sub(now)
+	}
+	if q.RemainingHour(now) == 0 {
+		return hourReset.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPartnerAPIKey(t *testing.T) {
+	t.Run("平文のキーはハッシュとプレフィックスだけが保持される", func(t *testing.T) {
+		key, plaintext, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", []string{"integration.inventory:write"}, 100)
+
+		require.NoError(t, err)
+		assert.True(t, entity.IsPartnerAPIKeyFormat(plaintext))
+		assert.True(t, strings.HasPrefix(plaintext, key.KeyPrefix+"."))
+		assert.Equal(t, entity.HashPartnerAPIKey(plaintext), key.KeyHash)
+		assert.NotContains(t, key.KeyHash, plaintext)
+		assert.Equal(t, []string{"integration.inventory:write:*"}, key.Permissions)
+		assert.True(t, key.Enabled)
+	})
+
+	t.Run("不正な権限", func(t *testing.T) {
+		_, _, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", []string{"inventory_sync"}, 100)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPermission)
+	})
+
+	t.Run("パートナーIDが空", func(t *testing.T) {
+		_, _, err := entity.NewPartnerAPIKey("", "POS", nil, 100)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPartnerID)
+	})
+}
+
+func TestPartnerAPIKeyRotateAndRevoke(t *testing.T) {
+	key, original, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", nil, 100)
+	require.NoError(t, err)
+
+	rotated, err := key.Rotate()
+	require.NoError(t, err)
+	assert.NotEqual(t, original, rotated)
+	assert.Equal(t, entity.HashPartnerAPIKey(rotated), key.KeyHash)
+
+	assert.NoError(t, key.Revoke())
+	assert.False(t, key.Enabled)
+	assert.ErrorIs(t, key.Revoke(), entity.ErrPartnerAPIKeyRevoked)
+
+	_, err = key.Rotate()
+	assert.ErrorIs(t, err, entity.ErrPartnerAPIKeyRevoked)
+}
+
+func TestPartnerAPIKeyGrants(t *testing.T) {
+	key := &entity.PartnerAPIKey{Permissions: []string{"transaction_create", "integration:read"}}
+
+	grants := key.Grants()
+
+	assert.Equal(t, []string{"integration:read:*"}, grants.Strings())
+}
+
+func TestIsPartnerAPIKeyFormat(t *testing.T) {
+	assert.True(t, entity.IsPartnerAPIKeyFormat("pk_0123abcd.secret"))
+	assert.False(t, entity.IsPartnerAPIKeyFormat("partner_api_key_12345"))
+	assert.False(t, entity.IsPartnerAPIKeyFormat("pk_0123abcd."))
+	assert.False(t, entity.IsPartnerAPIKeyFormat("pk_0123.secret"))
+}
+
+func TestNewPartnerQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpoint     string
+		limitPerHour int
+		limitPerDay  int
+		expectError  bool
+	}{
+		{name: "全エンドポイント", endpoint: "*", limitPerHour: 100, limitPerDay: 1000},
+		{name: "パス配下", endpoint: "/api/v1/integration/*", limitPerHour: 100, limitPerDay: 100},
+		{name: "スラッシュで始まらない", endpoint: "api/v1", limitPerHour: 100, limitPerDay: 1000, expectError: true},
+		{name: "時間上限がゼロ", endpoint: "*", limitPerHour: 0, limitPerDay: 1000, expectError: true},
+		{name: "日次上限が時間上限未満", endpoint: "*", limitPerHour: 100, limitPerDay: 50, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewPartnerQuota("PARTNER_001", tt.endpoint, tt.limitPerHour, tt.limitPerDay)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidPartnerQuota)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSelectPartnerQuota(t *testing.T) {
+	all := &entity.PartnerQuota{ID: 1, Endpoint: "*"}
+	integration := &entity.PartnerQuota{ID: 2, Endpoint: "/api/v1/integration/*"}
+	analytics := &entity.PartnerQuota{ID: 3, Endpoint: "/api/v1/integration/analytics/event"}
+	quotas := []*entity.PartnerQuota{all, integration, analytics}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		expected *entity.PartnerQuota
+	}{
+		{name: "完全一致を優先する", endpoint: "/api/v1/integration/analytics/event", expected: analytics},
+		{name: "パス配下に一致する", endpoint: "/api/v1/integration/inventory/sync", expected: integration},
+		{name: "それ以外は全体の上限", endpoint: "/api/v1/stats", expected: all},
+		{name: "前方一致だけでは配下とみなさない", endpoint: "/api/v1/integrations", expected: all},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.expected, entity.SelectPartnerQuota(quotas, tt.endpoint))
+		})
+	}
+
+	assert.Nil(t, entity.SelectPartnerQuota([]*entity.PartnerQuota{analytics}, "/api/v1/stats"))
+}
+
+func TestPartnerQuotaRemaining(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)
+	hourReset, _ := entity.PartnerQuotaWindow(now)
+
+	t.Run("現在の時間枠のカウンタを使う", func(t *testing.T) {
+		quota := &entity.PartnerQuota{LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 100, CurrentDayCount: 400, ResetTime: &hourReset}
+
+		assert.Equal(t, 0, quota.RemainingHour(now))
+		assert.Equal(t, 600, quota.RemainingDay(now))
+		assert.Equal(t, 30*time.Minute, quota.RetryAfter(now))
+	})
+
+	t.Run("時間枠が過ぎていれば時間上限は戻り日次は残る", func(t *testing.T) {
+		previous := hourReset.Add(-time.Hour)
+		quota := &entity.PartnerQuota{LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 100, CurrentDayCount: 400, ResetTime: &previous}
+
+		assert.Equal(t, 100, quota.RemainingHour(now))
+		assert.Equal(t, 600, quota.RemainingDay(now))
+	})
+
+	t.Run("日付が変われば日次上限も戻る", func(t *testing.T) {
+		yesterday := hourReset.AddDate(0, 0, -1)
+		quota := &entity.PartnerQuota{LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 100, CurrentDayCount: 1000, ResetTime: &yesterday}
+
+		assert.Equal(t, 1000, quota.RemainingDay(now))
+		assert.Equal(t, time.Duration(0), quota.RetryAfter(now))
+	})
+
+	t.Run("日次上限に達していれば翌日まで待つ", func(t *testing.T) {
+		quota := &entity.PartnerQuota{LimitPerHour: 100, LimitPerDay: 1000, CurrentHourCount: 10, CurrentDayCount: 1000, ResetTime: &hourReset}
+
+		assert.Equal(t, 13*time.Hour+30*time.Minute, quota.RetryAfter(now))
+	})
+}
@@ -0,0 +1,205 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	PermissionWildcard = "*"
+	PermissionScopeAll = "all"
+	PermissionScopeOwn = "own"
+)
+
+var (
+	ErrInvalidPermission  = errors.New("invalid permission")
+	ErrScopeNotFound      = errors.New("scope not found")
+	ErrScopeInactive      = errors.New("invalid scope: scope is inactive")
+	ErrInvalidScopeExpiry = errors.New("invalid scope expiry: must be in the future")
+)
+
+type Permission struct {
+	ID          uint
+	Name        string
+	Resource    string
+	Action      string
+	Scope       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewPermission(resource, action, scope, description string) *Permission {
+	if scope == "" {
+		scope = PermissionWildcard
+	}
+	now := time.Now()
+	return &Permission{
+		Name:        resource + "." + action,
+		Resource:    resource,
+		Action:      action,
+		Scope:       scope,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func (p *Permission) Grant() PermissionGrant {
+	return newPermissionGrant(p.Resource, p.Action, p.Scope)
+}
+
+type PermissionGrant struct {
+	Resource string
+	Action   string
+	Scope    string
+}
+
+func newPermissionGrant(resource, action, scope string) PermissionGrant {
+	if scope == "" || scope == PermissionScopeAll {
+		scope = PermissionWildcard
+	}
+	return PermissionGrant{Resource: resource, Action: action, Scope: scope}
+}
+
+// ParsePermissionGrant accepts "resource:action" or "resource:action:scope".
+// Resources are dot separated, so "fraud" covers "fraud.blacklist".
+func ParsePermissionGrant(value string) (PermissionGrant, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return PermissionGrant{}, fmt.Errorf("%w: %q", ErrInvalidPermission, value)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return PermissionGrant{}, fmt.Errorf("%w: %q", ErrInvalidPermission, value)
+		}
+	}
+
+	scope := ""
+	if len(parts) == 3 {
+		scope = parts[2]
+	}
+	return newPermissionGrant(parts[0], parts[1], scope), nil
+}
+
+func MustParsePermissionGrant(value string) PermissionGrant {
+	grant, err := ParsePermissionGrant(value)
+	if err != nil {
+		panic(err)
+	}
+	return grant
+}
+
+func (g PermissionGrant) String() string {
+	return g.Resource + ":" + g.Action + ":" + g.Scope
+}
+
+func (g PermissionGrant) Covers(required PermissionGrant) bool {
+	return g.coversResource(required.Resource) &&
+		(g.Action == PermissionWildcard || g.Action == required.Action) &&
+		(g.Scope == PermissionWildcard || g.Scope == required.Scope)
+}
+
+func (g PermissionGrant) coversResource(resource string) bool {
+	return g.Resource == PermissionWildcard ||
+		g.Resource == resource ||
+		strings.HasPrefix(resource, g.Resource+".")
+}
+
+type PermissionSet []PermissionGrant
+
+func NewPermissionSet(grants ...PermissionGrant) PermissionSet {
+	set := make(PermissionSet, 0, len(grants))
+	for _, grant := range grants {
+		if !slices.Contains(set, grant) {
+			set = append(set, grant)
+		}
+	}
+	slices.SortFunc(set, func(a, b PermissionGrant) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return set
+}
+
+func ParsePermissionSet(values []string) (PermissionSet, error) {
+	grants := make([]PermissionGrant, 0, len(values))
+	for _, value := range values {
+		grant, err := ParsePermissionGrant(value)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return NewPermissionSet(grants...), nil
+}
+
+func (s PermissionSet) Allows(required PermissionGrant) bool {
+	for _, grant := range s {
+		if grant.Covers(required) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s PermissionSet) Strings() []string {
+	values := make([]string, len(s))
+	for i, grant := range s {
+		values[i] = grant.String()
+	}
+	return values
+}
+
+type Scope struct {
+	ID          uint
+	Name        string
+	Resource    string
+	Description string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Grant reads scope names such as "fraud:write" as a permission. Bare names
+// like "fraud" grant every action on the scope's resource.
+func (s *Scope) Grant() PermissionGrant {
+	if grant, err := ParsePermissionGrant(s.Name); err == nil {
+		return grant
+	}
+	return newPermissionGrant(s.Resource, PermissionWildcard, PermissionWildcard)
+}
+
+type UserScope struct {
+	ID        uint
+	UserID    uint
+	ScopeID   uint
+	Scope     *Scope
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func NewUserScope(userID uint, scope *Scope, expiresAt time.Time, now time.Time) (*UserScope, error) {
+	if !scope.IsActive {
+		return nil, ErrScopeInactive
+	}
+	if !expiresAt.After(now) {
+		return nil, ErrInvalidScopeExpiry
+	}
+	return &UserScope{
+		UserID:    userID,
+		ScopeID:   scope.ID,
+		Scope:     scope,
+		ExpiresAt: &expiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (u *UserScope) IsActive(now time.Time) bool {
+	if u.Scope != nil && !u.Scope.IsActive {
+		return false
+	}
+	return u.ExpiresAt == nil || u.ExpiresAt.After(now)
+}
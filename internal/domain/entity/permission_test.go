@@ -0,0 +1,127 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermissionGrant(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    entity.PermissionGrant
+		expectError bool
+	}{
+		{name: "リソースとアクション", value: "fraud.blacklist:write", expected: entity.PermissionGrant{Resource: "fraud.blacklist", Action: "write", Scope: "*"}},
+		{name: "スコープ付き", value: "user:read:own", expected: entity.PermissionGrant{Resource: "user", Action: "read", Scope: "own"}},
+		{name: "allはワイルドカードとして扱う", value: "user:read:all", expected: entity.PermissionGrant{Resource: "user", Action: "read", Scope: "*"}},
+		{name: "アクションなし", value: "fraud", expectError: true},
+		{name: "空の要素", value: "fraud::own", expectError: true},
+		{name: "要素が多すぎる", value: "a:b:c:d", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant, err := entity.ParsePermissionGrant(tt.value)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidPermission)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, grant)
+		})
+	}
+}
+
+func TestPermissionGrantCovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  string
+		required string
+		expected bool
+	}{
+		{name: "完全一致", granted: "fraud.blacklist:write", required: "fraud.blacklist:write", expected: true},
+		{name: "親リソースは子リソースを含む", granted: "fraud:write", required: "fraud.blacklist:write", expected: true},
+		{name: "子リソースは親リソースを含まない", granted: "fraud.blacklist:write", required: "fraud:write", expected: false},
+		{name: "前方一致だけでは含まない", granted: "fraud:write", required: "fraudulent:write", expected: false},
+		{name: "アクションが異なる", granted: "fraud:read", required: "fraud.blacklist:write", expected: false},
+		{name: "ワイルドカードのアクション", granted: "fraud:*", required: "fraud.blacklist:write", expected: true},
+		{name: "全体スコープは本人スコープを含む", granted: "user:read", required: "user:read:own", expected: true},
+		{name: "本人スコープは全体スコープを含まない", granted: "user:read:own", required: "user:read", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted := entity.MustParsePermissionGrant(tt.granted)
+			required := entity.MustParsePermissionGrant(tt.required)
+
+			assert.Equal(t, tt.expected, granted.Covers(required))
+		})
+	}
+}
+
+func TestPermissionSet(t *testing.T) {
+	set := entity.NewPermissionSet(
+		entity.NewPermission("user", "read", "*", "").Grant(),
+		entity.NewPermission("fraud", "write", "*", "").Grant(),
+		entity.NewPermission("user", "read", "all", "").Grant(),
+	)
+
+	assert.Equal(t, []string{"fraud:write:*", "user:read:*"}, set.Strings())
+	assert.True(t, set.Allows(entity.MustParsePermissionGrant("fraud.blacklist:write")))
+	assert.False(t, set.Allows(entity.MustParsePermissionGrant("user:write")))
+
+	parsed, err := entity.ParsePermissionSet(set.Strings())
+	assert.NoError(t, err)
+	assert.Equal(t, set, parsed)
+}
+
+func TestScopeGrant(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    entity.Scope
+		expected string
+	}{
+		{name: "権限形式の名前", scope: entity.Scope{Name: "fraud:write", Resource: "fraud"}, expected: "fraud:write:*"},
+		{name: "スコープ付きの名前", scope: entity.Scope{Name: "user:read:own", Resource: "user"}, expected: "user:read:own"},
+		{name: "機能名のみ", scope: entity.Scope{Name: "profile", Resource: "user"}, expected: "user:*:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.scope.Grant().String())
+		})
+	}
+}
+
+func TestNewUserScope(t *testing.T) {
+	now := time.Date(2025, 9, 17, 12, 0, 0, 0, time.UTC)
+	active := &entity.Scope{ID: 4, Name: "fraud", Resource: "fraud", IsActive: true}
+
+	t.Run("有効期限付きで付与できる", func(t *testing.T) {
+		userScope, err := entity.NewUserScope(1, active, now.Add(time.Hour), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), userScope.ScopeID)
+		assert.True(t, userScope.IsActive(now))
+		assert.False(t, userScope.IsActive(now.Add(time.Hour)))
+	})
+
+	t.Run("過去の有効期限はエラー", func(t *testing.T) {
+		_, err := entity.NewUserScope(1, active, now, now)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidScopeExpiry)
+	})
+
+	t.Run("無効なスコープはエラー", func(t *testing.T) {
+		inactive := &entity.Scope{ID: 5, Name: "admin", Resource: "admin"}
+
+		_, err := entity.NewUserScope(1, inactive, now.Add(time.Hour), now)
+
+		assert.ErrorIs(t, err, entity.ErrScopeInactive)
+	})
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	PreferenceTypeString = "string"
+	PreferenceTypeBool   = "bool"
+	PreferenceTypeInt    = "int"
+)
+
+const (
+	PreferenceCategoryDisplay       = "display"
+	PreferenceCategoryNotifications = "notifications"
+	PreferenceCategoryPrivacy       = "privacy"
+	PreferenceCategorySecurity      = "security"
+)
+
+var (
+	ErrUnknownPreference      = errors.New("invalid preference key")
+	ErrInvalidPreferenceValue = errors.New("invalid preference value")
+)
+
+type PreferenceDefinition struct {
+	Key           string
+	Category      string
+	Type          string
+	Default       interface{}
+	AllowedValues []string
+	Min           int
+	Max           int
+}
+
+var preferenceDefinitions = []PreferenceDefinition{
+	{Key: "theme", Category: PreferenceCategoryDisplay, Type: PreferenceTypeString, Default: "system", AllowedValues: []string{"light", "dark", "system"}},
+	{Key: "language", Category: PreferenceCategoryDisplay, Type: PreferenceTypeString, Default: "ja", AllowedValues: []string{"ja", "en"}},
+	{Key: "notifications_email", Category: PreferenceCategoryNotifications, Type: PreferenceTypeBool, Default: true},
+	{Key: "notifications_push", Category: PreferenceCategoryNotifications, Type: PreferenceTypeBool, Default: false},
+	{Key: "privacy_profile", Category: PreferenceCategoryPrivacy, Type: PreferenceTypeString, Default: "friends_only", AllowedValues: []string{"public", "friends_only", "private"}},
+	{Key: "data_sharing", Category: PreferenceCategoryPrivacy, Type: PreferenceTypeBool, Default: false},
+	{Key: "auto_logout_minutes", Category: PreferenceCategorySecurity, Type: PreferenceTypeInt, Default: 30, Min: 5, Max: 1440},
+}
+
+func PreferenceDefinitions() []PreferenceDefinition {
+	return slices.Clone(preferenceDefinitions)
+}
+
+func LookupPreferenceDefinition(key string) (PreferenceDefinition, bool) {
+	for _, definition := range preferenceDefinitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return PreferenceDefinition{}, false
+}
+
+// Normalize validates a JSON-decoded value against the definition and returns its stored form.
+func (d PreferenceDefinition) Normalize(value interface{}) (string, error) {
+	switch d.Type {
+	case PreferenceTypeBool:
+		if v, ok := value.(bool); ok {
+			return strconv.FormatBool(v), nil
+		}
+	case PreferenceTypeInt:
+		if v, ok := toPreferenceInt(value); ok && v >= d.Min && v <= d.Max {
+			return strconv.Itoa(v), nil
+		}
+	case PreferenceTypeString:
+		if v, ok := value.(string); ok && (len(d.AllowedValues) == 0 || slices.Contains(d.AllowedValues, v)) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidPreferenceValue, d.Key)
+}
+
+// Decode converts a stored value back to its typed form, falling back to the default when it no longer validates.
+func (d PreferenceDefinition) Decode(stored string) interface{} {
+	switch d.Type {
+	case PreferenceTypeBool:
+		if v, err := strconv.ParseBool(stored); err == nil {
+			return v
+		}
+	case PreferenceTypeInt:
+		if v, err := strconv.Atoi(stored); err == nil && v >= d.Min && v <= d.Max {
+			return v
+		}
+	case PreferenceTypeString:
+		if len(d.AllowedValues) == 0 || slices.Contains(d.AllowedValues, stored) {
+			return stored
+		}
+	}
+	return d.Default
+}
+
+func toPreferenceInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt32 || v < math.MinInt32 {
+			return 0, false
+		}
+		return int(v), true
+	}
+	return 0, false
+}
+
+type UserPreference struct {
+	ID        uint
+	UserID    uint
+	Category  string
+	Key       string
+	Value     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewUserPreference(userID uint, definition PreferenceDefinition, value string) *UserPreference {
+	now := time.Now()
+	return &UserPreference{
+		UserID:    userID,
+		Category:  definition.Category,
+		Key:       definition.Key,
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type PreferenceValue struct {
+	Definition PreferenceDefinition
+	Value      interface{}
+	IsDefault  bool
+}
+
+func ResolvePreferences(stored map[string]string) []PreferenceValue {
+	values := make([]PreferenceValue, len(preferenceDefinitions))
+	for i, definition := range preferenceDefinitions {
+		values[i] = PreferenceValue{Definition: definition, Value: definition.Default, IsDefault: true}
+		if raw, ok := stored[definition.Key]; ok {
+			values[i].Value = definition.Decode(raw)
+			values[i].IsDefault = false
+		}
+	}
+	return values
+}
+
+func IsValidPreferenceCategory(category string) bool {
+	for _, definition := range preferenceDefinitions {
+		if definition.Category == category {
+			return true
+		}
+	}
+	return false
+}
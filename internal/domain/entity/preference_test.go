@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferenceDefinitionNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected string
+		wantErr  bool
+	}{
+		{"許可された文字列", "theme", "dark", "dark", false},
+		{"許可されていない文字列", "theme", "blue", "", true},
+		{"真偽値", "notifications_push", true, "true", false},
+		{"真偽値に文字列", "notifications_push", "true", "", true},
+		{"JSONの数値", "auto_logout_minutes", float64(60), "60", false},
+		{"小数は拒否", "auto_logout_minutes", 60.5, "", true},
+		{"範囲外の数値", "auto_logout_minutes", float64(1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition, ok := entity.LookupPreferenceDefinition(tt.key)
+			require.True(t, ok)
+
+			stored, err := definition.Normalize(tt.value)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidPreferenceValue)
+				assert.Contains(t, err.Error(), tt.key)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, stored)
+			}
+		})
+	}
+}
+
+func TestPreferenceDefinitionDecode(t *testing.T) {
+	theme, _ := entity.LookupPreferenceDefinition("theme")
+	autoLogout, _ := entity.LookupPreferenceDefinition("auto_logout_minutes")
+	emailNotifications, _ := entity.LookupPreferenceDefinition("notifications_email")
+
+	assert.Equal(t, "dark", theme.Decode("dark"))
+	assert.Equal(t, "system", theme.Decode("removed-theme"))
+	assert.Equal(t, 120, autoLogout.Decode("120"))
+	assert.Equal(t, 30, autoLogout.Decode("forever"))
+	assert.Equal(t, false, emailNotifications.Decode("false"))
+}
+
+func TestLookupPreferenceDefinition(t *testing.T) {
+	_, ok := entity.LookupPreferenceDefinition("unknown_key")
+	assert.False(t, ok)
+
+	for _, definition := range entity.PreferenceDefinitions() {
+		stored, err := definition.Normalize(definition.Default)
+		assert.NoError(t, err, definition.Key)
+		assert.Equal(t, definition.Default, definition.Decode(stored), definition.Key)
+	}
+}
@@ -0,0 +1,78 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRoleName(t *testing.T) {
+	tests := []struct {
+		name        string
+		roleName    string
+		expectError bool
+	}{
+		{name: "小文字のみ", roleName: "moderator", expectError: false},
+		{name: "数字と記号を含む", roleName: "support_l2-jp", expectError: false},
+		{name: "大文字を含む", roleName: "Moderator", expectError: true},
+		{name: "数字で始まる", roleName: "2nd", expectError: true},
+		{name: "短すぎる", roleName: "a", expectError: true},
+		{name: "空白を含む", roleName: "super admin", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entity.ValidateRoleName(tt.roleName)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, entity.ErrInvalidRoleName)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRoleIsSystem(t *testing.T) {
+	assert.True(t, entity.NewRole("admin", "").IsSystem())
+	assert.True(t, entity.NewRole("user", "").IsSystem())
+	assert.False(t, entity.NewRole("moderator", "").IsSystem())
+}
+
+func TestRolePermissionSet(t *testing.T) {
+	role := entity.NewRole("moderator", "")
+	role.Permissions = []*entity.Permission{
+		entity.NewPermission("fraud", "read", "*", ""),
+		entity.NewPermission("user", "read", "own", ""),
+	}
+
+	permissions := role.PermissionSet()
+
+	assert.Equal(t, []string{"fraud.read", "user.read"}, role.PermissionNames())
+	assert.True(t, permissions.Allows(entity.MustParsePermissionGrant("fraud.blacklist:read")))
+	assert.False(t, permissions.Allows(entity.MustParsePermissionGrant("fraud:write")))
+}
+
+func TestRoleSecurityEventSeverity(t *testing.T) {
+	assert.Equal(t, "HIGH", entity.NewRole("admin", "").SecurityEventSeverity())
+	assert.Equal(t, "MEDIUM", entity.NewRole("moderator", "").SecurityEventSeverity())
+}
+
+func TestRoleRetainsAdminPermissions(t *testing.T) {
+	adminRead := entity.NewPermission("admin", "read", "*", "")
+	adminWrite := entity.NewPermission("admin", "write", "*", "")
+	userRead := entity.NewPermission("user", "read", "*", "")
+
+	admin := entity.NewRole("admin", "")
+	admin.Permissions = []*entity.Permission{adminRead, adminWrite, userRead}
+
+	assert.False(t, admin.RetainsAdminPermissions(nil))
+	assert.False(t, admin.RetainsAdminPermissions([]*entity.Permission{adminRead, userRead}))
+	assert.True(t, admin.RetainsAdminPermissions([]*entity.Permission{adminRead, adminWrite}))
+	assert.True(t, admin.RetainsAdminPermissions([]*entity.Permission{entity.NewPermission("*", "*", "*", "")}))
+
+	moderator := entity.NewRole("moderator", "")
+	moderator.Permissions = []*entity.Permission{adminRead}
+	assert.True(t, moderator.RetainsAdminPermissions(nil))
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	TOTPDigits        = 6
+	TOTPPeriod        = 30
+	TOTPSkew          = 1
+	RecoveryCodeCount = 10
+)
+
+var (
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotInitialized = errors.New("two-factor authentication setup has not been started")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return totpCodeAt(key, totpStep(t)), nil
+}
+
+// ValidateTOTPCode accepts codes within TOTPSkew steps of t and returns the matched step.
+func ValidateTOTPCode(secret, code string, t time.Time) (int64, bool) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := totpStep(t)
+	for offset := int64(-TOTPSkew); offset <= TOTPSkew; offset++ {
+		step := current + offset
+		if subtle.ConstantTimeCompare([]byte(totpCodeAt(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func TOTPProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	params.Set("period", fmt.Sprintf("%d", TOTPPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		encoded := hex.EncodeToString(buf)
+		codes[i] = encoded[:5] + "-" + encoded[5:]
+	}
+	return codes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimRight(secret, "="), " ", ""))
+	key, err := totpEncoding.DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	return key, nil
+}
+
+func totpStep(t time.Time) int64 {
+	return t.Unix() / TOTPPeriod
+}
+
+func totpCodeAt(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000)
+}
@@ -0,0 +1,159 @@
+package entity_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 Appendix B の SHA1 シード "12345678901234567890" を base32 化したもの
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		unixTime int64
+		expected string
+	}{
+		{"RFC 6238 テストベクタ 59秒", 59, "287082"},
+		{"RFC 6238 テストベクタ 1111111109秒", 1111111109, "081804"},
+		{"RFC 6238 テストベクタ 1234567890秒", 1234567890, "005924"},
+		{"RFC 6238 テストベクタ 2000000000秒", 2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := entity.GenerateTOTPCode(rfcTOTPSecret, time.Unix(tt.unixTime, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	current, err := entity.GenerateTOTPCode(rfcTOTPSecret, now)
+	require.NoError(t, err)
+	previous, err := entity.GenerateTOTPCode(rfcTOTPSecret, now.Add(-30*time.Second))
+	require.NoError(t, err)
+	stale, err := entity.GenerateTOTPCode(rfcTOTPSecret, now.Add(-90*time.Second))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		valid  bool
+	}{
+		{"現在のコード", rfcTOTPSecret, current, true},
+		{"1ステップ前のコードは許容", rfcTOTPSecret, previous, true},
+		{"3ステップ前のコードは拒否", rfcTOTPSecret, stale, false},
+		{"桁数が違うコード", rfcTOTPSecret, "12345", false},
+		{"不正なシークレット", "not-base32!", current, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := entity.ValidateTOTPCode(tt.secret, tt.code, now)
+			assert.Equal(t, tt.valid, ok)
+			if tt.valid {
+				assert.NotZero(t, step)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := entity.GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	code, err := entity.GenerateTOTPCode(secret, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, code, entity.TOTPDigits)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := entity.TOTPProvisioningURI("dmm-go-task", "test@example.com", rfcTOTPSecret)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/dmm-go-task:test@example.com", parsed.Path)
+	assert.Equal(t, rfcTOTPSecret, parsed.Query().Get("secret"))
+	assert.Equal(t, "dmm-go-task", parsed.Query().Get("issuer"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := entity.GenerateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Len(t, codes, entity.RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, "-", code[5:6])
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCodeNormalizes(t *testing.T) {
+	assert.Equal(t, entity.HashRecoveryCode("abcde-12345"), entity.HashRecoveryCode(" ABCDE12345 "))
+	assert.NotEqual(t, entity.HashRecoveryCode("abcde-12345"), entity.HashRecoveryCode("abcde-12346"))
+}
+
+func TestAuthTwoFactorLifecycle(t *testing.T) {
+	auth, err := entity.NewAuth(1, "test@example.com", "password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.ErrTwoFactorNotInitialized, auth.EnableTwoFactor(nil))
+
+	require.NoError(t, auth.StartTwoFactorSetup("encrypted-secret"))
+	assert.False(t, auth.TwoFactorEnabled)
+
+	hashes := []string{entity.HashRecoveryCode("aaaaa-11111"), entity.HashRecoveryCode("bbbbb-22222")}
+	require.NoError(t, auth.EnableTwoFactor(hashes))
+	assert.True(t, auth.TwoFactorEnabled)
+	assert.NotNil(t, auth.TwoFactorEnabledAt)
+
+	assert.Equal(t, entity.ErrTwoFactorAlreadyEnabled, auth.StartTwoFactorSetup("other"))
+	assert.Equal(t, entity.ErrTwoFactorAlreadyEnabled, auth.EnableTwoFactor(hashes))
+
+	auth.DisableTwoFactor()
+	assert.False(t, auth.TwoFactorEnabled)
+	assert.Empty(t, auth.TwoFactorSecret)
+	assert.Empty(t, auth.TwoFactorRecoveryCodes)
+	assert.Nil(t, auth.TwoFactorEnabledAt)
+}
+
+func TestAuthConsumeTOTPStep(t *testing.T) {
+	auth := &entity.Auth{}
+
+	assert.NoError(t, auth.ConsumeTOTPStep(100))
+	assert.Equal(t, entity.ErrInvalidTwoFactorCode, auth.ConsumeTOTPStep(100))
+	assert.Equal(t, entity.ErrInvalidTwoFactorCode, auth.ConsumeTOTPStep(99))
+	assert.NoError(t, auth.ConsumeTOTPStep(101))
+}
+
+func TestAuthUseRecoveryCode(t *testing.T) {
+	auth := &entity.Auth{
+		TwoFactorRecoveryCodes: []string{
+			entity.HashRecoveryCode("aaaaa-11111"),
+			entity.HashRecoveryCode("bbbbb-22222"),
+		},
+	}
+
+	assert.False(t, auth.UseRecoveryCode("ccccc-33333"))
+	assert.True(t, auth.UseRecoveryCode(strings.ToUpper("aaaaa-11111")))
+	assert.Len(t, auth.TwoFactorRecoveryCodes, 1)
+	assert.False(t, auth.UseRecoveryCode("aaaaa-11111"))
+	assert.True(t, auth.UseRecoveryCode("bbbbb-22222"))
+	assert.Empty(t, auth.TwoFactorRecoveryCodes)
+}
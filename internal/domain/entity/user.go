@@ -1,9 +1,13 @@
 package entity
 
 import (
+	"errors"
+	"sort"
 	"time"
 )
 
+var ErrUserNotFound = errors.New("user not found")
+
 type User struct {
 	ID        uint
 	Name      string
@@ -41,3 +45,42 @@ func (u *User) IsValidAge() bool {
 func (u *User) IsValidEmail() bool {
 	return u.Email != "" && len(u.Email) > 0
 }
+
+type UserDashboard struct {
+	UserID               uint
+	Email                string
+	AccountCreatedAt     time.Time
+	TotalLogins          int64
+	RecentFailedLogins   int64
+	LastLoginAt          *time.Time
+	ProfileCompletion    int
+	SecurityScore        int
+	TwoFactorEnabled     bool
+	PasswordChangedAt    *time.Time
+	TrustedDevicesCount  int
+	RecentSecurityEvents []*SecurityEvent
+	UnreadNotifications  int64
+	TotalNotifications   int64
+	GeneratedAt          time.Time
+}
+
+type UserDetail struct {
+	User           *User
+	Profile        *UserProfile
+	Membership     *UserMembership
+	Roles          []*Role
+	LoginAttempts  []*LoginAttempt
+	SecurityEvents []*SecurityEvent
+	Devices        []*DeviceFingerprint
+	Sessions       []*UserSession
+	SectionErrors  map[string]error
+}
+
+func (d *UserDetail) UnavailableSections() []string {
+	sections := make([]string, 0, len(d.SectionErrors))
+	for section := range d.SectionErrors {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	return sections
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type AdminActionRepository interface {
+	GetByIdempotencyKey(ctx context.Context, adminUserID uint, idempotencyKey string) (*entity.AdminAction, error)
+
+	RecordPointAdjustment(ctx context.Context, action *entity.AdminAction, transaction *entity.PointTransaction, budget *entity.AdminPointBudget) (*entity.UserMembership, error)
+
+	SumGrantedPoints(ctx context.Context, adminUserID uint, since time.Time) (int, error)
+}
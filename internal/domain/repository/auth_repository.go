@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
@@ -15,6 +16,9 @@ type AuthRepository interface {
 
 	Update(ctx context.Context, auth *entity.Auth) error
 
+	// ConsumeTOTPStep records the step only if it is newer than the stored one and reports false when another request already used it.
+	ConsumeTOTPStep(ctx context.Context, userID uint, step int64) (bool, error)
+
 	Delete(ctx context.Context, userID uint) error
 
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
@@ -23,6 +27,8 @@ type AuthRepository interface {
 type RoleRepository interface {
 	Create(ctx context.Context, role *entity.Role) error
 
+	CreateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error
+
 	GetByID(ctx context.Context, id uint) (*entity.Role, error)
 
 	GetByName(ctx context.Context, name string) (*entity.Role, error)
@@ -31,25 +37,78 @@ type RoleRepository interface {
 
 	Update(ctx context.Context, role *entity.Role) error
 
+	UpdateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error
+
 	Delete(ctx context.Context, id uint) error
 
+	DeleteWithEvent(ctx context.Context, id uint, event *entity.SecurityEvent) error
+
 	AssignToUser(ctx context.Context, userID, roleID uint) error
 
+	AssignToUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error
+
 	RemoveFromUser(ctx context.Context, userID, roleID uint) error
 
+	RemoveFromUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error
+
 	GetUserRoles(ctx context.Context, userID uint) ([]*entity.Role, error)
 
 	GetUserRoleNames(ctx context.Context, userID uint) ([]string, error)
+
+	HasUserRole(ctx context.Context, userID, roleID uint) (bool, error)
+
+	RemoveFromUserUnlessLast(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error
+
+	ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error)
+
+	GetMemberIDs(ctx context.Context, roleID uint) ([]uint, error)
 }
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, token *entity.RefreshToken) error
 
-	GetByToken(ctx context.Context, token string) (*entity.RefreshToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
 
 	Update(ctx context.Context, token *entity.RefreshToken) error
 
+	MarkRotated(ctx context.Context, id uint) (bool, error)
+
 	RevokeByUserID(ctx context.Context, userID uint) error
 
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	DeleteExpired(ctx context.Context) error
+}
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entity.UserToken) error
+
+	GetByHash(ctx context.Context, tokenType, tokenHash string) (*entity.UserToken, error)
+
+	// ConsumeByHashWithAuth deletes the token and saves auth in one transaction, and reports false without saving when another request already consumed it.
+	ConsumeByHashWithAuth(ctx context.Context, tokenType, tokenHash string, auth *entity.Auth) (bool, error)
+
+	Delete(ctx context.Context, id uint) error
+
+	DeleteByUserIDAndType(ctx context.Context, userID uint, tokenType string) error
+
 	DeleteExpired(ctx context.Context) error
 }
+
+type PermissionRepository interface {
+	GetUserPermissions(ctx context.Context, userID uint) ([]*entity.Permission, error)
+
+	GetScopeByName(ctx context.Context, name string) (*entity.Scope, error)
+
+	GetActiveUserScopes(ctx context.Context, userID uint, now time.Time) ([]*entity.UserScope, error)
+
+	CreateUserScope(ctx context.Context, userScope *entity.UserScope) error
+
+	RevokeUserScope(ctx context.Context, userID, scopeID uint) error
+
+	GetByNames(ctx context.Context, names []string) ([]*entity.Permission, error)
+
+	GetRolePermissions(ctx context.Context, roleID uint) ([]*entity.Permission, error)
+
+	ReplaceRolePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
+}
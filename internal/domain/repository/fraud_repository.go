@@ -34,9 +34,23 @@ type IPBlacklistRepository interface {
 
 	IsBlacklisted(ctx context.Context, ipAddress string) (bool, error)
 
+	ListActive(ctx context.Context) ([]*entity.IPBlacklist, error)
+
 	CleanupExpired(ctx context.Context) error
 }
 
+type IPAllowlistRepository interface {
+	Create(ctx context.Context, allowlist *entity.IPAllowlist) error
+
+	GetByIP(ctx context.Context, ipAddress string) (*entity.IPAllowlist, error)
+
+	List(ctx context.Context, offset, limit int) ([]*entity.IPAllowlist, int64, error)
+
+	Update(ctx context.Context, allowlist *entity.IPAllowlist) error
+
+	ListActive(ctx context.Context) ([]*entity.IPAllowlist, error)
+}
+
 type LoginAttemptRepository interface {
 	Create(ctx context.Context, attempt *entity.LoginAttempt) error
 
@@ -46,6 +60,8 @@ type LoginAttemptRepository interface {
 
 	CountFailedAttempts(ctx context.Context, email string, since time.Time) (int64, error)
 
+	CountSuccessfulAttempts(ctx context.Context, email string, since time.Time) (int64, error)
+
 	List(ctx context.Context, offset, limit int) ([]*entity.LoginAttempt, int64, error)
 
 	Delete(ctx context.Context, id uint) error
@@ -69,6 +85,16 @@ type RateLimitRuleRepository interface {
 	GetActiveRules(ctx context.Context) ([]*entity.RateLimitRule, error)
 }
 
+type FraudRuleConfigRepository interface {
+	List(ctx context.Context) ([]*entity.FraudRuleConfig, error)
+
+	Save(ctx context.Context, config *entity.FraudRuleConfig) error
+}
+
+type RateLimitLogRepository interface {
+	Create(ctx context.Context, log *entity.RateLimitLog) error
+}
+
 type UserSessionRepository interface {
 	Create(ctx context.Context, session *entity.UserSession) error
 
@@ -76,6 +102,8 @@ type UserSessionRepository interface {
 
 	GetByUserID(ctx context.Context, userID uint) ([]*entity.UserSession, error)
 
+	ListActive(ctx context.Context, offset, limit int) ([]*entity.UserSession, int64, error)
+
 	Update(ctx context.Context, session *entity.UserSession) error
 
 	Delete(ctx context.Context, sessionID string) error
@@ -92,6 +120,8 @@ type DeviceFingerprintRepository interface {
 
 	GetByUserID(ctx context.Context, userID uint) ([]*entity.DeviceFingerprint, error)
 
+	List(ctx context.Context, offset, limit int) ([]*entity.DeviceFingerprint, int64, error)
+
 	Update(ctx context.Context, fingerprint *entity.DeviceFingerprint) error
 
 	Delete(ctx context.Context, id uint) error
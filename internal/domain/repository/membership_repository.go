@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
@@ -29,21 +30,52 @@ type UserMembershipRepository interface {
 
 	Delete(ctx context.Context, userID uint) error
 
+	UpdateTier(ctx context.Context, membership *entity.UserMembership) error
+
+	CountByTierID(ctx context.Context, tierID uint) (int64, error)
+
 	GetStats(ctx context.Context) (map[string]interface{}, error)
 
 	List(ctx context.Context, offset, limit int) ([]*entity.UserMembership, int64, error)
 }
 
+type MembershipTierHistoryRepository interface {
+	Create(ctx context.Context, history *entity.MembershipTierHistory) error
+
+	// Record moves the membership to the history's target tier and writes the
+	// history row in one transaction. It reports false without writing when the
+	// membership has already left the history's source tier.
+	Record(ctx context.Context, history *entity.MembershipTierHistory) (bool, error)
+
+	GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.MembershipTierHistory, int64, error)
+}
+
 type PointTransactionRepository interface {
 	Create(ctx context.Context, transaction *entity.PointTransaction) error
 
-	GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.PointTransaction, int64, error)
+	Record(ctx context.Context, transaction *entity.PointTransaction) (*entity.UserMembership, error)
+
+	GetByUserID(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error)
 
 	GetByID(ctx context.Context, id uint) (*entity.PointTransaction, error)
 
+	GetSummary(ctx context.Context, userID uint) (*entity.PointSummary, error)
+
 	List(ctx context.Context, offset, limit int) ([]*entity.PointTransaction, int64, error)
 
-	ExpirePoints(ctx context.Context) error
+	ListExpiredLots(ctx context.Context, asOf time.Time) ([]*entity.PointTransaction, error)
+
+	ExpirePoints(ctx context.Context, userID uint, asOf time.Time) (*entity.PointTransaction, error)
+}
+
+type PurchaseRepository interface {
+	GetByID(ctx context.Context, id string) (*entity.Purchase, error)
+
+	GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error)
+
+	Record(ctx context.Context, purchase *entity.Purchase, transaction *entity.PointTransaction) (*entity.UserMembership, error)
+
+	Refund(ctx context.Context, purchaseID string) (*entity.Purchase, *entity.UserMembership, error)
 }
 
 type UserProfileRepository interface {
@@ -61,13 +93,17 @@ type NotificationRepository interface {
 
 	GetByUserID(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error)
 
+	GetByUserIDAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error)
+
 	GetByID(ctx context.Context, id uint) (*entity.Notification, error)
 
 	Update(ctx context.Context, notification *entity.Notification) error
 
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, userID, notificationID uint) (bool, error)
 
 	MarkAsRead(ctx context.Context, userID, notificationID uint) error
 
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+
 	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
 }
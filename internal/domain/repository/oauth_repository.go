@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *entity.OAuthClient) error
+
+	GetByClientID(ctx context.Context, clientID string) (*entity.OAuthClient, error)
+
+	List(ctx context.Context) ([]*entity.OAuthClient, error)
+
+	Delete(ctx context.Context, clientID string) error
+}
+
+type OAuthAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *entity.OAuthAuthorizationCode) error
+
+	GetByCodeHash(ctx context.Context, codeHash string) (*entity.OAuthAuthorizationCode, error)
+
+	// MarkUsed reports false when the code had already been redeemed.
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+
+	DeleteExpired(ctx context.Context) error
+}
+
+type OAuthRefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.OAuthRefreshToken) error
+
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.OAuthRefreshToken, error)
+
+	MarkRotated(ctx context.Context, id uint) (bool, error)
+
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	RevokeByClientAndUser(ctx context.Context, clientID string, userID uint) error
+
+	RevokeByClient(ctx context.Context, clientID string) error
+}
+
+type OAuthConsentRepository interface {
+	Get(ctx context.Context, userID uint, clientID string) (*entity.OAuthConsent, error)
+
+	ListByUser(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error)
+
+	Upsert(ctx context.Context, consent *entity.OAuthConsent) error
+
+	Delete(ctx context.Context, userID uint, clientID string) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PartnerAPIKeyRepository interface {
+	Create(ctx context.Context, key *entity.PartnerAPIKey) error
+
+	GetByID(ctx context.Context, id uint) (*entity.PartnerAPIKey, error)
+
+	GetByKeyHash(ctx context.Context, keyHash string) (*entity.PartnerAPIKey, error)
+
+	List(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error)
+
+	Update(ctx context.Context, key *entity.PartnerAPIKey) error
+}
+
+type PartnerQuotaRepository interface {
+	ListByPartner(ctx context.Context, partnerID string) ([]*entity.PartnerQuota, error)
+
+	Upsert(ctx context.Context, quota *entity.PartnerQuota) error
+
+	// Consume counts one request against the quota unless a window is already
+	// exhausted, and returns the quota as stored after the attempt.
+	Consume(ctx context.Context, quotaID uint, now time.Time) (*entity.PartnerQuota, bool, error)
+}
@@ -21,3 +21,11 @@ type UserRepository interface {
 
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 }
+
+type UserPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID uint) ([]*entity.UserPreference, error)
+
+	Upsert(ctx context.Context, preferences []*entity.UserPreference) error
+
+	DeleteByKeys(ctx context.Context, userID uint, keys []string) error
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+var (
+	ErrTooManyRequests      = errors.New("too many requests")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+)
+
+const (
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 24 * time.Hour
+	maxTokenRequestsPerWindow = 3
+	tokenRequestWindow        = time.Hour
+)
+
+type MailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type AccountDomainService struct {
+	authRepo         repository.AuthRepository
+	userProfileRepo  repository.UserProfileRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	userTokenRepo    repository.UserTokenRepository
+	cacheService     CacheService
+	mailSender       MailSender
+	baseURL          string
+}
+
+func NewAccountDomainService(
+	authRepo repository.AuthRepository,
+	userProfileRepo repository.UserProfileRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	userTokenRepo repository.UserTokenRepository,
+	cacheService CacheService,
+	mailSender MailSender,
+	baseURL string,
+) *AccountDomainService {
+	return &AccountDomainService{
+		authRepo:         authRepo,
+		userProfileRepo:  userProfileRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		userTokenRepo:    userTokenRepo,
+		cacheService:     cacheService,
+		mailSender:       mailSender,
+		baseURL:          strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *AccountDomainService) RequestPasswordReset(ctx context.Context, email string) (uint, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if err := s.checkRequestLimit(ctx, "password_reset:"+email); err != nil {
+		return 0, err
+	}
+
+	auth, err := s.authRepo.GetByEmail(ctx, email)
+	if err != nil || !auth.IsActive {
+		return 0, nil
+	}
+
+	token, err := s.issueToken(ctx, auth.UserID, entity.UserTokenTypePasswordReset, PasswordResetTokenTTL)
+	if err != nil {
+		return 0, err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", s.baseURL, url.QueryEscape(token))
+	body := fmt.Sprintf(
+		"We received a request to reset your password.\n\nOpen the link below within %d minutes to choose a new password:\n%s\n\nIf you did not request this, you can ignore this email.",
+		int(PasswordResetTokenTTL.Minutes()), link,
+	)
+	if err := s.mailSender.Send(ctx, auth.Email, "Reset your password", body); err != nil {
+		return 0, fmt.Errorf("failed to send password reset mail: %w", err)
+	}
+
+	return auth.UserID, nil
+}
+
+func (s *AccountDomainService) ResetPassword(ctx context.Context, token, newPassword string) (uint, error) {
+	userToken, err := s.lookupToken(ctx, entity.UserTokenTypePasswordReset, token)
+	if err != nil {
+		return 0, err
+	}
+
+	auth, err := s.authRepo.GetByUserID(ctx, userToken.UserID)
+	if err != nil {
+		return 0, ErrUserNotFound
+	}
+
+	if err := auth.ResetPassword(newPassword); err != nil {
+		return 0, err
+	}
+
+	consumed, err := s.userTokenRepo.ConsumeByHashWithAuth(ctx, entity.UserTokenTypePasswordReset, userToken.TokenHash, auth)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update password: %w", err)
+	}
+	if !consumed {
+		return 0, ErrInvalidToken
+	}
+
+	if err := s.userTokenRepo.DeleteByUserIDAndType(ctx, auth.UserID, entity.UserTokenTypePasswordReset); err != nil {
+		return 0, fmt.Errorf("failed to invalidate other reset tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, auth.UserID); err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if s.cacheService != nil {
+		if _, err := s.cacheService.IncrementTokenVersion(ctx, auth.UserID); err != nil {
+			return 0, fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
+
+	return auth.UserID, nil
+}
+
+func (s *AccountDomainService) RequestEmailVerification(ctx context.Context, userID uint) error {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if profile, err := s.userProfileRepo.GetByUserID(ctx, userID); err == nil && profile.IsVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	if err := s.checkRequestLimit(ctx, "email_verification:"+auth.Email); err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(ctx, userID, entity.UserTokenTypeEmailVerification, EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", s.baseURL, url.QueryEscape(token))
+	body := fmt.Sprintf(
+		"Please confirm your email address by opening the link below within %d hours:\n%s",
+		int(EmailVerificationTokenTTL.Hours()), link,
+	)
+	if err := s.mailSender.Send(ctx, auth.Email, "Verify your email address", body); err != nil {
+		return fmt.Errorf("failed to send verification mail: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AccountDomainService) VerifyEmail(ctx context.Context, token string) (uint, error) {
+	userToken, err := s.lookupToken(ctx, entity.UserTokenTypeEmailVerification, token)
+	if err != nil {
+		return 0, err
+	}
+
+	profile, err := s.userProfileRepo.GetByUserID(ctx, userToken.UserID)
+	if err != nil {
+		profile = entity.NewUserProfile(userToken.UserID)
+		profile.Verify()
+		if err := s.userProfileRepo.Create(ctx, profile); err != nil {
+			return 0, fmt.Errorf("failed to create user profile: %w", err)
+		}
+	} else {
+		profile.Verify()
+		if err := s.userProfileRepo.Update(ctx, profile); err != nil {
+			return 0, fmt.Errorf("failed to update user profile: %w", err)
+		}
+	}
+
+	if err := s.userTokenRepo.DeleteByUserIDAndType(ctx, userToken.UserID, entity.UserTokenTypeEmailVerification); err != nil {
+		return 0, fmt.Errorf("failed to consume verification token: %w", err)
+	}
+
+	return userToken.UserID, nil
+}
+
+func (s *AccountDomainService) issueToken(ctx context.Context, userID uint, tokenType string, ttl time.Duration) (string, error) {
+	if err := s.userTokenRepo.DeleteByUserIDAndType(ctx, userID, tokenType); err != nil {
+		return "", fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	token, err := entity.GenerateUserToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	userToken := entity.NewUserToken(userID, tokenType, entity.HashUserToken(token), time.Now().Add(ttl))
+	if err := s.userTokenRepo.Create(ctx, userToken); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *AccountDomainService) lookupToken(ctx context.Context, tokenType, token string) (*entity.UserToken, error) {
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+
+	userToken, err := s.userTokenRepo.GetByHash(ctx, tokenType, entity.HashUserToken(token))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if userToken.IsExpired() {
+		_ = s.userTokenRepo.Delete(ctx, userToken.ID)
+		return nil, ErrTokenExpired
+	}
+
+	return userToken, nil
+}
+
+func (s *AccountDomainService) checkRequestLimit(ctx context.Context, key string) error {
+	if s.cacheService == nil {
+		return nil
+	}
+
+	count, err := s.cacheService.IncrementRateLimit(ctx, key, tokenRequestWindow)
+	if err != nil {
+		return nil
+	}
+
+	if count > maxTokenRequestsPerWindow {
+		return ErrTooManyRequests
+	}
+
+	return nil
+}
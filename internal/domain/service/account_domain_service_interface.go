@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+)
+
+type AccountDomainServiceInterface interface {
+	RequestPasswordReset(ctx context.Context, email string) (uint, error)
+	ResetPassword(ctx context.Context, token, newPassword string) (uint, error)
+	RequestEmailVerification(ctx context.Context, userID uint) error
+	VerifyEmail(ctx context.Context, token string) (uint, error)
+}
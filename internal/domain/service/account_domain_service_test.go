@@ -0,0 +1,396 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockUserTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserTokenRepository) Create(ctx context.Context, token *entity.UserToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) GetByHash(ctx context.Context, tokenType, tokenHash string) (*entity.UserToken, error) {
+	args := m.Called(ctx, tokenType, tokenHash)
+	if token, ok := args.Get(0).(*entity.UserToken); ok {
+		return token, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserTokenRepository) ConsumeByHashWithAuth(ctx context.Context, tokenType, tokenHash string, auth *entity.Auth) (bool, error) {
+	args := m.Called(ctx, tokenType, tokenHash, auth)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserTokenRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) DeleteByUserIDAndType(ctx context.Context, userID uint, tokenType string) error {
+	args := m.Called(ctx, userID, tokenType)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) DeleteExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockUserProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserProfileRepository) Create(ctx context.Context, profile *entity.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockUserProfileRepository) GetByUserID(ctx context.Context, userID uint) (*entity.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	if profile, ok := args.Get(0).(*entity.UserProfile); ok {
+		return profile, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserProfileRepository) Update(ctx context.Context, profile *entity.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockUserProfileRepository) Delete(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type testRateLimitCache struct {
+	counts map[string]int64
+}
+
+func newTestRateLimitCache() *testRateLimitCache {
+	return &testRateLimitCache{counts: make(map[string]int64)}
+}
+
+func (c *testRateLimitCache) BlacklistToken(ctx context.Context, tokenID string, expiration time.Duration) error {
+	c.counts["blacklist:"+tokenID] = 1
+	return nil
+}
+
+func (c *testRateLimitCache) IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	return c.counts["blacklist:"+tokenID] > 0, nil
+}
+
+func (c *testRateLimitCache) IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	c.counts[key]++
+	return c.counts[key], nil
+}
+
+func (c *testRateLimitCache) GetTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	return c.counts[tokenVersionKey(userID)], nil
+}
+
+func (c *testRateLimitCache) IncrementTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	c.counts[tokenVersionKey(userID)]++
+	return c.counts[tokenVersionKey(userID)], nil
+}
+
+func tokenVersionKey(userID uint) string {
+	return fmt.Sprintf("token_version:%d", userID)
+}
+
+func tokenFromMail(t *testing.T, body string) string {
+	t.Helper()
+
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(line, "http") {
+			continue
+		}
+		link, err := url.Parse(line)
+		require.NoError(t, err)
+		return link.Query().Get("token")
+	}
+
+	t.Fatalf("no link found in mail body: %s", body)
+	return ""
+}
+
+func TestAccountDomainServiceRequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	userTokenRepo := new(MockUserTokenRepository)
+	mailSender := external.NewInMemoryMailSender()
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+	authRepo.On("GetByEmail", ctx, "test@example.com").Return(auth, nil)
+	userTokenRepo.On("DeleteByUserIDAndType", ctx, uint(1), entity.UserTokenTypePasswordReset).Return(nil)
+
+	var saved *entity.UserToken
+	userTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserToken")).
+		Run(func(args mock.Arguments) {
+			if token, ok := args.Get(1).(*entity.UserToken); ok {
+				saved = token
+			}
+		}).
+		Return(nil)
+
+	svc := service.NewAccountDomainService(authRepo, new(MockUserProfileRepository), new(MockRefreshTokenRepository), userTokenRepo, nil, mailSender, "https://example.com/")
+
+	userID, err := svc.RequestPasswordReset(ctx, " Test@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), userID)
+
+	sent, ok := mailSender.LastMessageTo("test@example.com")
+	require.True(t, ok)
+	assert.Contains(t, sent.Body, "https://example.com/reset-password?token=")
+
+	token := tokenFromMail(t, sent.Body)
+	require.NotNil(t, saved)
+	assert.Equal(t, entity.UserTokenTypePasswordReset, saved.TokenType)
+	assert.Equal(t, entity.HashUserToken(token), saved.TokenHash)
+	assert.NotEqual(t, token, saved.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(service.PasswordResetTokenTTL), saved.ExpiresAt, time.Minute)
+}
+
+func TestAccountDomainServiceRequestPasswordResetUnknownEmail(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	mailSender := external.NewInMemoryMailSender()
+
+	authRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, errors.New("record not found"))
+
+	svc := service.NewAccountDomainService(authRepo, new(MockUserProfileRepository), new(MockRefreshTokenRepository), new(MockUserTokenRepository), nil, mailSender, "https://example.com")
+
+	userID, err := svc.RequestPasswordReset(ctx, "unknown@example.com")
+	assert.NoError(t, err)
+	assert.Zero(t, userID)
+	assert.Empty(t, mailSender.Messages())
+}
+
+func TestAccountDomainServiceRequestPasswordResetRateLimited(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	userTokenRepo := new(MockUserTokenRepository)
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+	authRepo.On("GetByEmail", ctx, "test@example.com").Return(auth, nil)
+	userTokenRepo.On("DeleteByUserIDAndType", ctx, uint(1), entity.UserTokenTypePasswordReset).Return(nil)
+	userTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserToken")).Return(nil)
+
+	svc := service.NewAccountDomainService(authRepo, new(MockUserProfileRepository), new(MockRefreshTokenRepository), userTokenRepo, newTestRateLimitCache(), external.NewInMemoryMailSender(), "https://example.com")
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.RequestPasswordReset(ctx, "test@example.com")
+		require.NoError(t, err)
+	}
+
+	_, err := svc.RequestPasswordReset(ctx, "test@example.com")
+	assert.Equal(t, service.ErrTooManyRequests, err)
+}
+
+func TestAccountDomainServiceResetPassword(t *testing.T) {
+	token := "reset-token"
+	validToken := &entity.UserToken{ID: 10, UserID: 1, TokenType: entity.UserTokenTypePasswordReset, TokenHash: entity.HashUserToken(token), ExpiresAt: time.Now().Add(time.Hour)}
+	expiredToken := &entity.UserToken{ID: 11, UserID: 1, TokenType: entity.UserTokenTypePasswordReset, TokenHash: entity.HashUserToken(token), ExpiresAt: time.Now().Add(-time.Minute)}
+	errUpdateFailed := errors.New("db error")
+
+	tests := []struct {
+		name        string
+		newPassword string
+		setupMocks  func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository)
+		wantErr     error
+	}{
+		{
+			name:        "正常なパスワード再設定",
+			newPassword: "newpassword123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(validToken, nil)
+				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+				userTokenRepo.On("ConsumeByHashWithAuth", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token), auth).Return(true, nil)
+				userTokenRepo.On("DeleteByUserIDAndType", ctx, uint(1), entity.UserTokenTypePasswordReset).Return(nil)
+				refreshTokenRepo.On("RevokeByUserID", ctx, uint(1)).Return(nil)
+			},
+		},
+		{
+			name:        "同時に使われたトークンは一度しか受け付けない",
+			newPassword: "newpassword123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(validToken, nil)
+				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+				userTokenRepo.On("ConsumeByHashWithAuth", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token), auth).Return(false, nil)
+			},
+			wantErr: service.ErrInvalidToken,
+		},
+		{
+			name:        "パスワードの保存に失敗したら後続の処理をしない",
+			newPassword: "newpassword123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(validToken, nil)
+				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+				userTokenRepo.On("ConsumeByHashWithAuth", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token), auth).Return(false, errUpdateFailed)
+			},
+			wantErr: errUpdateFailed,
+		},
+		{
+			name:        "存在しないトークン",
+			newPassword: "newpassword123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(nil, errors.New("record not found"))
+			},
+			wantErr: service.ErrInvalidToken,
+		},
+		{
+			name:        "期限切れトークン",
+			newPassword: "newpassword123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(expiredToken, nil)
+				userTokenRepo.On("Delete", ctx, uint(11)).Return(nil)
+			},
+			wantErr: service.ErrTokenExpired,
+		},
+		{
+			name:        "弱いパスワードではトークンを消費しない",
+			newPassword: "123",
+			setupMocks: func(ctx context.Context, authRepo *MockAuthRepository, userTokenRepo *MockUserTokenRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+				userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypePasswordReset, entity.HashUserToken(token)).Return(validToken, nil)
+				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+			},
+			wantErr: entity.ErrWeakPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authRepo := new(MockAuthRepository)
+			userTokenRepo := new(MockUserTokenRepository)
+			refreshTokenRepo := new(MockRefreshTokenRepository)
+			tt.setupMocks(ctx, authRepo, userTokenRepo, refreshTokenRepo)
+
+			cache := newTestRateLimitCache()
+			svc := service.NewAccountDomainService(authRepo, new(MockUserProfileRepository), refreshTokenRepo, userTokenRepo, cache, external.NewInMemoryMailSender(), "https://example.com")
+
+			userID, err := svc.ResetPassword(ctx, token, tt.newPassword)
+
+			version, _ := cache.GetTokenVersion(ctx, 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, userID)
+				assert.Zero(t, version)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(1), userID)
+				assert.Equal(t, int64(1), version)
+			}
+
+			authRepo.AssertExpectations(t)
+			userTokenRepo.AssertExpectations(t)
+			refreshTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountDomainServiceRequestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	userProfileRepo := new(MockUserProfileRepository)
+	userTokenRepo := new(MockUserTokenRepository)
+	mailSender := external.NewInMemoryMailSender()
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+	userProfileRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserProfile(1), nil)
+	userTokenRepo.On("DeleteByUserIDAndType", ctx, uint(1), entity.UserTokenTypeEmailVerification).Return(nil)
+	userTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserToken")).Return(nil)
+
+	svc := service.NewAccountDomainService(authRepo, userProfileRepo, new(MockRefreshTokenRepository), userTokenRepo, nil, mailSender, "https://example.com")
+
+	require.NoError(t, svc.RequestEmailVerification(ctx, 1))
+
+	sent, ok := mailSender.LastMessageTo("test@example.com")
+	require.True(t, ok)
+	assert.Contains(t, sent.Body, "https://example.com/verify-email?token=")
+}
+
+func TestAccountDomainServiceRequestEmailVerificationAlreadyVerified(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	userProfileRepo := new(MockUserProfileRepository)
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+	profile := entity.NewUserProfile(1)
+	profile.Verify()
+
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+	userProfileRepo.On("GetByUserID", ctx, uint(1)).Return(profile, nil)
+
+	svc := service.NewAccountDomainService(authRepo, userProfileRepo, new(MockRefreshTokenRepository), new(MockUserTokenRepository), nil, external.NewInMemoryMailSender(), "https://example.com")
+
+	assert.Equal(t, service.ErrEmailAlreadyVerified, svc.RequestEmailVerification(ctx, 1))
+}
+
+func TestAccountDomainServiceVerifyEmail(t *testing.T) {
+	token := "verify-token"
+	userToken := &entity.UserToken{ID: 20, UserID: 1, TokenType: entity.UserTokenTypeEmailVerification, TokenHash: entity.HashUserToken(token), ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		setupMocks func(ctx context.Context, userProfileRepo *MockUserProfileRepository, userTokenRepo *MockUserTokenRepository)
+	}{
+		{
+			name: "既存プロフィールを認証済みにする",
+			setupMocks: func(ctx context.Context, userProfileRepo *MockUserProfileRepository, userTokenRepo *MockUserTokenRepository) {
+				userProfileRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserProfile(1), nil)
+				userProfileRepo.On("Update", ctx, mock.MatchedBy(func(p *entity.UserProfile) bool { return p.IsVerified })).Return(nil)
+			},
+		},
+		{
+			name: "プロフィールが無い場合は作成する",
+			setupMocks: func(ctx context.Context, userProfileRepo *MockUserProfileRepository, userTokenRepo *MockUserTokenRepository) {
+				userProfileRepo.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+				userProfileRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.UserProfile) bool { return p.IsVerified && p.UserID == 1 })).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userProfileRepo := new(MockUserProfileRepository)
+			userTokenRepo := new(MockUserTokenRepository)
+
+			userTokenRepo.On("GetByHash", ctx, entity.UserTokenTypeEmailVerification, entity.HashUserToken(token)).Return(userToken, nil)
+			userTokenRepo.On("DeleteByUserIDAndType", ctx, uint(1), entity.UserTokenTypeEmailVerification).Return(nil)
+			tt.setupMocks(ctx, userProfileRepo, userTokenRepo)
+
+			svc := service.NewAccountDomainService(new(MockAuthRepository), userProfileRepo, new(MockRefreshTokenRepository), userTokenRepo, nil, external.NewInMemoryMailSender(), "https://example.com")
+
+			userID, err := svc.VerifyEmail(ctx, token)
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), userID)
+
+			userProfileRepo.AssertExpectations(t)
+			userTokenRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+type AdminPointAdjustment struct {
+	Action          *entity.AdminAction
+	Transaction     *entity.PointTransaction
+	RemainingBudget int
+	Replayed        bool
+}
+
+type AdminPointDomainService struct {
+	adminActionRepo      repository.AdminActionRepository
+	userMembershipRepo   repository.UserMembershipRepository
+	pointTransactionRepo repository.PointTransactionRepository
+	tierEvaluator        TierEvaluator
+	dailyGrantBudget     int
+}
+
+func NewAdminPointDomainService(
+	adminActionRepo repository.AdminActionRepository,
+	userMembershipRepo repository.UserMembershipRepository,
+	pointTransactionRepo repository.PointTransactionRepository,
+	tierEvaluator TierEvaluator,
+	dailyGrantBudget int,
+) *AdminPointDomainService {
+	return &AdminPointDomainService{
+		adminActionRepo:      adminActionRepo,
+		userMembershipRepo:   userMembershipRepo,
+		pointTransactionRepo: pointTransactionRepo,
+		tierEvaluator:        tierEvaluator,
+		dailyGrantBudget:     dailyGrantBudget,
+	}
+}
+
+func (s *AdminPointDomainService) GrantPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*AdminPointAdjustment, error) {
+	if points <= 0 {
+		return nil, entity.ErrInvalidPointAmount
+	}
+	return s.adjust(ctx, adminUserID, userID, points, description, idempotencyKey)
+}
+
+func (s *AdminPointDomainService) DeductPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*AdminPointAdjustment, error) {
+	if points <= 0 {
+		return nil, entity.ErrInvalidPointAmount
+	}
+	return s.adjust(ctx, adminUserID, userID, -points, description, idempotencyKey)
+}
+
+func (s *AdminPointDomainService) adjust(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*AdminPointAdjustment, error) {
+	action := entity.NewAdminPointAction(adminUserID, userID, points, description, idempotencyKey)
+
+	transactionType := entity.PointTransactionTypeEarn
+	if points < 0 {
+		transactionType = entity.PointTransactionTypeSpend
+	}
+	transaction := entity.NewPointTransaction(userID, transactionType, points, description)
+	transaction.ReferenceType = entity.AdminPointReferenceType
+	transaction.ReferenceID = &adminUserID
+
+	if existing, err := s.adminActionRepo.GetByIdempotencyKey(ctx, adminUserID, idempotencyKey); err == nil {
+		return s.replay(ctx, existing, action, transaction)
+	}
+
+	if _, err := s.userMembershipRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, ErrMembershipNotFound
+	}
+
+	var budget *entity.AdminPointBudget
+	if points > 0 {
+		expiresAt := transaction.CreatedAt.Add(PointValidityPeriod)
+		transaction.ExpiresAt = &expiresAt
+		budget = entity.NewAdminPointBudget(s.dailyGrantBudget, time.Now())
+	}
+
+	if _, err := s.adminActionRepo.RecordPointAdjustment(ctx, action, transaction, budget); err != nil {
+		return s.replayAfterFailure(ctx, action, transaction, err)
+	}
+
+	if s.tierEvaluator != nil {
+		_, _ = s.tierEvaluator.EvaluateUserTier(ctx, userID)
+	}
+
+	return &AdminPointAdjustment{
+		Action:          action,
+		Transaction:     transaction,
+		RemainingBudget: s.remainingBudget(ctx, adminUserID),
+	}, nil
+}
+
+// replayAfterFailure returns the stored result when a concurrent request with the same idempotency key won the insert.
+func (s *AdminPointDomainService) replayAfterFailure(ctx context.Context, action *entity.AdminAction, transaction *entity.PointTransaction, err error) (*AdminPointAdjustment, error) {
+	if existing, getErr := s.adminActionRepo.GetByIdempotencyKey(ctx, action.AdminUserID, action.IdempotencyKey); getErr == nil {
+		return s.replay(ctx, existing, action, transaction)
+	}
+	return nil, err
+}
+
+func (s *AdminPointDomainService) replay(ctx context.Context, existing, requested *entity.AdminAction, transaction *entity.PointTransaction) (*AdminPointAdjustment, error) {
+	var recorded *entity.PointTransaction
+	if existing.PointTransactionID != nil {
+		recorded, _ = s.pointTransactionRepo.GetByID(ctx, *existing.PointTransactionID)
+	}
+	if !existing.MatchesPointAdjustment(requested, recorded, transaction) {
+		return nil, entity.ErrIdempotencyKeyConflict
+	}
+
+	return &AdminPointAdjustment{
+		Action:          existing,
+		Transaction:     recorded,
+		RemainingBudget: s.remainingBudget(ctx, existing.AdminUserID),
+		Replayed:        true,
+	}, nil
+}
+
+func (s *AdminPointDomainService) remainingBudget(ctx context.Context, adminUserID uint) int {
+	budget := entity.NewAdminPointBudget(s.dailyGrantBudget, time.Now())
+	granted, err := s.adminActionRepo.SumGrantedPoints(ctx, adminUserID, budget.Since)
+	if err != nil {
+		return budget.Remaining(0)
+	}
+	return budget.Remaining(granted)
+}
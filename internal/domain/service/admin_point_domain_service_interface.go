@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+)
+
+type AdminPointDomainServiceInterface interface {
+	GrantPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*AdminPointAdjustment, error)
+	DeductPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*AdminPointAdjustment, error)
+}
@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAdminActionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminActionRepository) GetByIdempotencyKey(ctx context.Context, adminUserID uint, idempotencyKey string) (*entity.AdminAction, error) {
+	args := m.Called(ctx, adminUserID, idempotencyKey)
+	if action, ok := args.Get(0).(*entity.AdminAction); ok {
+		return action, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminActionRepository) RecordPointAdjustment(ctx context.Context, action *entity.AdminAction, transaction *entity.PointTransaction, budget *entity.AdminPointBudget) (*entity.UserMembership, error) {
+	args := m.Called(ctx, action, transaction, budget)
+	if membership, ok := args.Get(0).(*entity.UserMembership); ok {
+		return membership, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminActionRepository) SumGrantedPoints(ctx context.Context, adminUserID uint, since time.Time) (int, error) {
+	args := m.Called(ctx, adminUserID, since)
+	return args.Int(0), args.Error(1)
+}
+
+type adminPointDomainServiceMocks struct {
+	adminActionRepo      *MockAdminActionRepository
+	userMembershipRepo   *MockUserMembershipRepository
+	pointTransactionRepo *MockPointTransactionRepository
+	tierEvaluator        *MockTierEvaluator
+}
+
+func setupAdminPointDomainService() (*service.AdminPointDomainService, *adminPointDomainServiceMocks) {
+	mocks := &adminPointDomainServiceMocks{
+		adminActionRepo:      &MockAdminActionRepository{},
+		userMembershipRepo:   &MockUserMembershipRepository{},
+		pointTransactionRepo: &MockPointTransactionRepository{},
+		tierEvaluator:        &MockTierEvaluator{},
+	}
+	domainService := service.NewAdminPointDomainService(mocks.adminActionRepo, mocks.userMembershipRepo, mocks.pointTransactionRepo, mocks.tierEvaluator, 1000)
+	return domainService, mocks
+}
+
+func TestAdminPointDomainServiceGrantPoints(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("付与すると管理者IDを参照に持つ取引と監査ログを記録する", func(t *testing.T) {
+		domainService, mocks := setupAdminPointDomainService()
+
+		mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-1").Return(nil, errors.New("record not found"))
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserMembership(1, 1), nil)
+		mocks.adminActionRepo.On("RecordPointAdjustment", ctx,
+			mock.MatchedBy(func(action *entity.AdminAction) bool {
+				return action.ActionType == entity.AdminActionPointGrant && action.TargetID == "1" && action.IdempotencyKey == "key-1"
+			}),
+			mock.MatchedBy(func(transaction *entity.PointTransaction) bool {
+				return transaction.Type == entity.PointTransactionTypeEarn && transaction.Points == 300 &&
+					transaction.ReferenceType == entity.AdminPointReferenceType && *transaction.ReferenceID == 9 &&
+					transaction.ExpiresAt != nil
+			}),
+			mock.MatchedBy(func(budget *entity.AdminPointBudget) bool { return budget != nil && budget.DailyLimit == 1000 }),
+		).Return(entity.NewUserMembership(1, 1), nil)
+		mocks.tierEvaluator.On("EvaluateUserTier", ctx, uint(1)).Return(nil, nil)
+		mocks.adminActionRepo.On("SumGrantedPoints", ctx, uint(9), mock.AnythingOfType("time.Time")).Return(300, nil)
+
+		adjustment, err := domainService.GrantPoints(ctx, 9, 1, 300, "補填", "key-1")
+
+		assert.NoError(t, err)
+		assert.False(t, adjustment.Replayed)
+		assert.Equal(t, 300, adjustment.Transaction.Points)
+		assert.Equal(t, 700, adjustment.RemainingBudget)
+		mocks.adminActionRepo.AssertExpectations(t)
+		mocks.tierEvaluator.AssertExpectations(t)
+	})
+
+	t.Run("同じキーで同一内容なら記録済みの結果を返す", func(t *testing.T) {
+		domainService, mocks := setupAdminPointDomainService()
+
+		transactionID := uint(5)
+		existing := entity.NewAdminPointAction(9, 1, 300, "補填", "key-1")
+		existing.PointTransactionID = &transactionID
+		recorded := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 300, "補填")
+		recorded.ID = transactionID
+
+		mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-1").Return(existing, nil)
+		mocks.pointTransactionRepo.On("GetByID", ctx, transactionID).Return(recorded, nil)
+		mocks.adminActionRepo.On("SumGrantedPoints", ctx, uint(9), mock.AnythingOfType("time.Time")).Return(300, nil)
+
+		adjustment, err := domainService.GrantPoints(ctx, 9, 1, 300, "補填", "key-1")
+
+		assert.NoError(t, err)
+		assert.True(t, adjustment.Replayed)
+		assert.Equal(t, recorded, adjustment.Transaction)
+		mocks.adminActionRepo.AssertNotCalled(t, "RecordPointAdjustment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("同じキーで内容が異なる場合は競合", func(t *testing.T) {
+		domainService, mocks := setupAdminPointDomainService()
+
+		transactionID := uint(5)
+		existing := entity.NewAdminPointAction(9, 1, 300, "補填", "key-1")
+		existing.PointTransactionID = &transactionID
+
+		mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-1").Return(existing, nil)
+		mocks.pointTransactionRepo.On("GetByID", ctx, transactionID).
+			Return(entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 300, "補填"), nil)
+
+		adjustment, err := domainService.GrantPoints(ctx, 9, 1, 500, "補填", "key-1")
+
+		assert.Nil(t, adjustment)
+		assert.ErrorIs(t, err, entity.ErrIdempotencyKeyConflict)
+	})
+
+	t.Run("予算超過", func(t *testing.T) {
+		domainService, mocks := setupAdminPointDomainService()
+
+		mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-2").Return(nil, errors.New("record not found"))
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserMembership(1, 1), nil)
+		mocks.adminActionRepo.On("RecordPointAdjustment", ctx, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, entity.ErrAdminPointBudgetExceeded)
+
+		adjustment, err := domainService.GrantPoints(ctx, 9, 1, 5000, "補填", "key-2")
+
+		assert.Nil(t, adjustment)
+		assert.ErrorIs(t, err, entity.ErrAdminPointBudgetExceeded)
+		mocks.tierEvaluator.AssertNotCalled(t, "EvaluateUserTier", mock.Anything, mock.Anything)
+	})
+
+	t.Run("会員情報が存在しない", func(t *testing.T) {
+		domainService, mocks := setupAdminPointDomainService()
+
+		mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-3").Return(nil, errors.New("record not found"))
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+
+		adjustment, err := domainService.GrantPoints(ctx, 9, 1, 100, "補填", "key-3")
+
+		assert.Nil(t, adjustment)
+		assert.ErrorIs(t, err, service.ErrMembershipNotFound)
+	})
+
+	t.Run("0以下のポイントは付与できない", func(t *testing.T) {
+		domainService, _ := setupAdminPointDomainService()
+
+		_, err := domainService.GrantPoints(ctx, 9, 1, 0, "補填", "key-4")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPointAmount)
+	})
+}
+
+func TestAdminPointDomainServiceDeductPoints(t *testing.T) {
+	ctx := context.Background()
+	domainService, mocks := setupAdminPointDomainService()
+
+	mocks.adminActionRepo.On("GetByIdempotencyKey", ctx, uint(9), "key-1").Return(nil, errors.New("record not found"))
+	mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserMembership(1, 1), nil)
+	mocks.adminActionRepo.On("RecordPointAdjustment", ctx,
+		mock.MatchedBy(func(action *entity.AdminAction) bool { return action.ActionType == entity.AdminActionPointDeduct }),
+		mock.MatchedBy(func(transaction *entity.PointTransaction) bool {
+			return transaction.Type == entity.PointTransactionTypeSpend && transaction.Points == -50 && transaction.ExpiresAt == nil
+		}),
+		(*entity.AdminPointBudget)(nil),
+	).Return(entity.NewUserMembership(1, 1), nil)
+	mocks.tierEvaluator.On("EvaluateUserTier", ctx, uint(1)).Return(nil, nil)
+	mocks.adminActionRepo.On("SumGrantedPoints", ctx, uint(9), mock.AnythingOfType("time.Time")).Return(0, nil)
+
+	adjustment, err := domainService.DeductPoints(ctx, 9, 1, 50, "訂正", "key-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, -50, adjustment.Transaction.Points)
+	assert.Equal(t, 1000, adjustment.RemainingBudget)
+	mocks.adminActionRepo.AssertExpectations(t)
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
@@ -15,13 +17,47 @@ import (
 var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrUserNotFound       = entity.ErrUserNotFound
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+
+	ErrUserAlreadySuspended = errors.New("user is already suspended")
+	ErrUserNotSuspended     = errors.New("user is not suspended")
+
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+	ErrTwoFactorUnavailable     = errors.New("two-factor authentication is not configured")
+	ErrTooManyTwoFactorAttempts = errors.New("too many two-factor attempts")
+)
+
+const (
+	RefreshTokenTTL      = 7 * 24 * time.Hour
+	MFATokenTTL          = 5 * time.Minute
+	OAuthAccessTokenTTL  = time.Hour
+	maxTwoFactorAttempts = 5
+	tokenIssuer          = "dmm-go-task"
+	mfaAudience          = "dmm-go-task:mfa"
+	oauthAudience        = "dmm-go-task:oauth"
 )
 
 type CacheService interface {
 	BlacklistToken(ctx context.Context, tokenID string, expiration time.Duration) error
+	IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error)
+	IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error)
+	TokenVersionStore
+}
+
+// TokenVersionStore holds a per-user counter stamped into every access token.
+// Bumping it invalidates all tokens issued before, without listing them.
+type TokenVersionStore interface {
+	GetTokenVersion(ctx context.Context, userID uint) (int64, error)
+	IncrementTokenVersion(ctx context.Context, userID uint) (int64, error)
+}
+
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
 }
 
 type AuthDomainService struct {
@@ -30,13 +66,45 @@ type AuthDomainService struct {
 	roleRepo         repository.RoleRepository
 	refreshTokenRepo repository.RefreshTokenRepository
 	cacheService     CacheService
+	secretCipher     SecretCipher
+	keySet           TokenKeySet
 	jwtSecret        string
 }
 
+type RefreshTokenReuseError struct {
+	UserID   uint
+	FamilyID string
+}
+
+func (e *RefreshTokenReuseError) Error() string {
+	return ErrRefreshTokenReused.Error()
+}
+
+func (e *RefreshTokenReuseError) Is(target error) bool {
+	return target == ErrRefreshTokenReused
+}
+
 type JWTClaims struct {
-	UserID uint     `json:"user_id"`
-	Email  string   `json:"email"`
-	Roles  []string `json:"roles"`
+	UserID       uint     `json:"user_id"`
+	Email        string   `json:"email"`
+	Roles        []string `json:"roles"`
+	TokenVersion int64    `json:"ver"`
+	jwt.RegisteredClaims
+}
+
+// OAuthClaims are carried by access tokens issued to OAuth clients. They share
+// the signing keys of first-party tokens but are not accepted in their place.
+type OAuthClaims struct {
+	ClientID     string `json:"client_id"`
+	Scope        string `json:"scope"`
+	UserID       uint   `json:"user_id,omitempty"`
+	TokenVersion int64  `json:"ver,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type MFAClaims struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
 	jwt.RegisteredClaims
 }
 
@@ -46,6 +114,8 @@ func NewAuthDomainService(
 	roleRepo repository.RoleRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	cacheService CacheService,
+	secretCipher SecretCipher,
+	keySet TokenKeySet,
 	jwtSecret string,
 ) *AuthDomainService {
 	return &AuthDomainService{
@@ -54,6 +124,8 @@ func NewAuthDomainService(
 		roleRepo:         roleRepo,
 		refreshTokenRepo: refreshTokenRepo,
 		cacheService:     cacheService,
+		secretCipher:     secretCipher,
+		keySet:           keySet,
 		jwtSecret:        jwtSecret,
 	}
 }
@@ -111,6 +183,10 @@ func (s *AuthDomainService) Login(ctx context.Context, email, password string) (
 		return nil, nil, ErrInvalidCredentials
 	}
 
+	if auth.TwoFactorEnabled {
+		return auth, nil, nil
+	}
+
 	auth.UpdateLastLogin()
 	if err := s.authRepo.Update(ctx, auth); err != nil {
 		return nil, nil, fmt.Errorf("failed to update auth: %w", err)
@@ -124,29 +200,100 @@ func (s *AuthDomainService) Login(ctx context.Context, email, password string) (
 	return auth, roleNames, nil
 }
 
-func (s *AuthDomainService) GenerateAccessToken(userID uint, email string, roles []string) (string, error) {
+func (s *AuthDomainService) GenerateAccessToken(ctx context.Context, userID uint, email string, roles []string) (string, error) {
+	var tokenVersion int64
+	if s.cacheService != nil {
+		version, err := s.cacheService.GetTokenVersion(ctx, userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get token version: %w", err)
+		}
+		tokenVersion = version
+	}
+
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Roles:  roles,
+		UserID:       userID,
+		Email:        email,
+		Roles:        roles,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    "dmm-go-task",
+			Issuer:    tokenIssuer,
 			Subject:   fmt.Sprintf("%d", userID),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.signAccessToken(claims)
+}
+
+func (s *AuthDomainService) GenerateOAuthAccessToken(ctx context.Context, userID uint, clientID string, scopes []string) (string, error) {
+	subject := clientID
+	var tokenVersion int64
+	if userID != 0 {
+		subject = fmt.Sprintf("%d", userID)
+		if s.cacheService != nil {
+			version, err := s.cacheService.GetTokenVersion(ctx, userID)
+			if err != nil {
+				return "", fmt.Errorf("failed to get token version: %w", err)
+			}
+			tokenVersion = version
+		}
+	}
+
+	now := time.Now()
+	claims := OAuthClaims{
+		ClientID:     clientID,
+		Scope:        entity.FormatOAuthScope(scopes),
+		UserID:       userID,
+		TokenVersion: tokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(OAuthAccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    tokenIssuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{oauthAudience},
+			ID:        uuid.New().String(),
+		},
+	}
+
+	return s.signAccessToken(claims)
+}
+
+func (s *AuthDomainService) signAccessToken(claims jwt.Claims) (string, error) {
+	if s.keySet == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(s.jwtSecret))
+	}
+
+	key, err := s.keySet.ActiveKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	method := jwt.GetSigningMethod(key.Algorithm)
+	if method == nil {
+		return "", fmt.Errorf("unsupported signing algorithm: %s", key.Algorithm)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.KeyID
+	return token.SignedString(key.PrivateKey)
 }
 
 func (s *AuthDomainService) GenerateRefreshToken(ctx context.Context, userID uint) (string, error) {
-	tokenStr := uuid.New().String()
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	return s.issueRefreshToken(ctx, userID, uuid.New().String())
+}
+
+func (s *AuthDomainService) issueRefreshToken(ctx context.Context, userID uint, familyID string) (string, error) {
+	tokenStr, err := entity.GenerateRefreshTokenValue()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	expiresAt := time.Now().Add(RefreshTokenTTL)
 
-	refreshToken := entity.NewRefreshToken(userID, tokenStr, expiresAt)
+	refreshToken := entity.NewRefreshToken(userID, entity.HashRefreshToken(tokenStr), familyID, expiresAt)
 	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
 		return "", fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -155,29 +302,135 @@ func (s *AuthDomainService) GenerateRefreshToken(ctx context.Context, userID uin
 }
 
 func (s *AuthDomainService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.accessTokenKey)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid && !slices.Contains(claims.Audience, oauthAudience) {
+		return claims, nil
+	}
+
+	return nil, ErrInvalidToken
+}
+
+func (s *AuthDomainService) ValidateOAuthAccessToken(ctx context.Context, tokenString string) (*OAuthClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &OAuthClaims{}, s.accessTokenKey, jwt.WithAudience(oauthAudience))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*OAuthClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if s.cacheService == nil {
+		return claims, nil
+	}
+
+	blacklisted, err := s.cacheService.IsTokenBlacklisted(ctx, tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token blacklist: %w", err)
+	}
+	if blacklisted {
+		return nil, ErrTokenRevoked
+	}
+
+	if claims.UserID != 0 {
+		version, err := s.cacheService.GetTokenVersion(ctx, claims.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token version: %w", err)
+		}
+		if claims.TokenVersion < version {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *AuthDomainService) CheckTokenVersion(ctx context.Context, claims *JWTClaims) error {
+	if s.cacheService == nil {
+		return nil
+	}
+
+	version, err := s.cacheService.GetTokenVersion(ctx, claims.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get token version: %w", err)
+	}
+	if claims.TokenVersion < version {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+func (s *AuthDomainService) RevokeUserTokens(ctx context.Context, userID uint) error {
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if s.cacheService != nil {
+		if _, err := s.cacheService.IncrementTokenVersion(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *AuthDomainService) JWKS() (*JWKS, error) {
+	jwks := &JWKS{Keys: []JWK{}}
+	if s.keySet == nil {
+		return jwks, nil
+	}
+
+	for _, key := range s.keySet.VerificationKeys() {
+		jwk, err := key.PublicJWK()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key %s: %w", key.KeyID, err)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
+func (s *AuthDomainService) accessTokenKey(token *jwt.Token) (interface{}, error) {
+	if s.keySet == nil {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(s.jwtSecret), nil
-	})
+	}
+
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, ErrUnknownKeyID
+	}
+
+	key, err := s.keySet.KeyByID(kid)
 	if err != nil {
-		return nil, ErrInvalidToken
+		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
 
-	return nil, ErrInvalidToken
+	return key.PublicKey, nil
 }
 
 func (s *AuthDomainService) RefreshToken(ctx context.Context, refreshTokenStr string) (*entity.Auth, []string, string, error) {
-	refreshToken, err := s.refreshTokenRepo.GetByToken(ctx, refreshTokenStr)
+	refreshToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, entity.HashRefreshToken(refreshTokenStr))
 	if err != nil {
 		return nil, nil, "", ErrInvalidToken
 	}
 
+	if refreshToken.IsRotated() {
+		return nil, nil, "", s.revokeRefreshTokenFamily(ctx, refreshToken)
+	}
+
 	if !refreshToken.IsValid() {
 		return nil, nil, "", ErrInvalidToken
 	}
@@ -197,12 +450,15 @@ func (s *AuthDomainService) RefreshToken(ctx context.Context, refreshTokenStr st
 		roleNames[i] = role.Name
 	}
 
-	refreshToken.Revoke()
-	if err := s.refreshTokenRepo.Update(ctx, refreshToken); err != nil {
+	rotated, err := s.refreshTokenRepo.MarkRotated(ctx, refreshToken.ID)
+	if err != nil {
 		return nil, nil, "", fmt.Errorf("failed to revoke refresh token: %w", err)
 	}
+	if !rotated {
+		return nil, nil, "", s.revokeRefreshTokenFamily(ctx, refreshToken)
+	}
 
-	newRefreshToken, err := s.GenerateRefreshToken(ctx, auth.UserID)
+	newRefreshToken, err := s.issueRefreshToken(ctx, auth.UserID, refreshToken.FamilyID)
 	if err != nil {
 		return nil, nil, "", fmt.Errorf("failed to generate new refresh token: %w", err)
 	}
@@ -210,6 +466,17 @@ func (s *AuthDomainService) RefreshToken(ctx context.Context, refreshTokenStr st
 	return auth, roleNames, newRefreshToken, nil
 }
 
+func (s *AuthDomainService) revokeRefreshTokenFamily(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, refreshToken.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return &RefreshTokenReuseError{
+		UserID:   refreshToken.UserID,
+		FamilyID: refreshToken.FamilyID,
+	}
+}
+
 func (s *AuthDomainService) ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error {
 	auth, err := s.authRepo.GetByUserID(ctx, userID)
 	if err != nil {
@@ -224,11 +491,7 @@ func (s *AuthDomainService) ChangePassword(ctx context.Context, userID uint, cur
 		return fmt.Errorf("failed to update auth: %w", err)
 	}
 
-	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
-		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
-	}
-
-	return nil
+	return s.RevokeUserTokens(ctx, userID)
 }
 
 func (s *AuthDomainService) Logout(ctx context.Context, userID uint, token string) error {
@@ -245,6 +508,308 @@ func (s *AuthDomainService) Logout(ctx context.Context, userID uint, token strin
 	return nil
 }
 
+func (s *AuthDomainService) SuspendUser(ctx context.Context, userID uint) error {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if !auth.IsActive {
+		return ErrUserAlreadySuspended
+	}
+
+	auth.Deactivate()
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return s.RevokeUserTokens(ctx, userID)
+}
+
+func (s *AuthDomainService) ReactivateUser(ctx context.Context, userID uint) error {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if auth.IsActive {
+		return ErrUserNotSuspended
+	}
+
+	auth.Activate()
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AuthDomainService) SetupTwoFactor(ctx context.Context, userID uint) (string, string, error) {
+	if s.secretCipher == nil {
+		return "", "", ErrTwoFactorUnavailable
+	}
+
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", "", ErrUserNotFound
+	}
+
+	secret, err := entity.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+
+	encryptedSecret, err := s.secretCipher.Encrypt(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+
+	if err := auth.StartTwoFactorSetup(encryptedSecret); err != nil {
+		return "", "", err
+	}
+
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return "", "", fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return secret, entity.TOTPProvisioningURI(tokenIssuer, auth.Email, secret), nil
+}
+
+func (s *AuthDomainService) EnableTwoFactor(ctx context.Context, userID uint, code string) ([]string, error) {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if auth.TwoFactorEnabled {
+		return nil, entity.ErrTwoFactorAlreadyEnabled
+	}
+	if auth.TwoFactorSecret == "" {
+		return nil, entity.ErrTwoFactorNotInitialized
+	}
+
+	if err := s.verifyTOTP(ctx, auth, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.EnableTwoFactor(hashes); err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return nil, fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (s *AuthDomainService) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !auth.TwoFactorEnabled {
+		return entity.ErrTwoFactorNotEnabled
+	}
+
+	if err := auth.VerifyPassword(password); err != nil {
+		return entity.ErrInvalidPassword
+	}
+
+	if err := s.verifySecondFactor(ctx, auth, code); err != nil {
+		return err
+	}
+
+	auth.DisableTwoFactor()
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AuthDomainService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	auth, err := s.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if !auth.TwoFactorEnabled {
+		return nil, entity.ErrTwoFactorNotEnabled
+	}
+
+	if err := s.verifyTOTP(ctx, auth, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	auth.ReplaceRecoveryCodes(hashes)
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return nil, fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (s *AuthDomainService) GenerateMFAToken(userID uint, email string) (string, error) {
+	now := time.Now()
+	claims := MFAClaims{
+		UserID: userID,
+		Email:  email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(MFATokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    tokenIssuer,
+			Subject:   fmt.Sprintf("%d", userID),
+			Audience:  jwt.ClaimStrings{mfaAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.mfaSigningKey())
+}
+
+func (s *AuthDomainService) ValidateMFAToken(ctx context.Context, tokenString string) (*MFAClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &MFAClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.mfaSigningKey(), nil
+	}, jwt.WithAudience(mfaAudience))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*MFAClaims)
+	if !ok || !token.Valid || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if s.cacheService != nil {
+		used, err := s.cacheService.IsTokenBlacklisted(ctx, claims.ID)
+		if err == nil && used {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *AuthDomainService) CompleteTwoFactorLogin(ctx context.Context, claims *MFAClaims, code string) (*entity.Auth, []string, error) {
+	if s.cacheService != nil {
+		attempts, err := s.cacheService.IncrementRateLimit(ctx, "mfa:"+claims.ID, MFATokenTTL)
+		if err == nil && attempts > maxTwoFactorAttempts {
+			_ = s.cacheService.BlacklistToken(ctx, claims.ID, MFATokenTTL)
+			return nil, nil, ErrTooManyTwoFactorAttempts
+		}
+	}
+
+	auth, err := s.authRepo.GetByUserID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	if !auth.IsActive {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	if !auth.TwoFactorEnabled {
+		return nil, nil, entity.ErrTwoFactorNotEnabled
+	}
+
+	if err := s.verifySecondFactor(ctx, auth, code); err != nil {
+		return nil, nil, err
+	}
+
+	auth.UpdateLastLogin()
+	if err := s.authRepo.Update(ctx, auth); err != nil {
+		return nil, nil, fmt.Errorf("failed to update auth: %w", err)
+	}
+
+	if s.cacheService != nil {
+		_ = s.cacheService.BlacklistToken(ctx, claims.ID, MFATokenTTL)
+	}
+
+	roleNames, err := s.roleRepo.GetUserRoleNames(ctx, auth.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return auth, roleNames, nil
+}
+
+func (s *AuthDomainService) verifyTOTP(ctx context.Context, auth *entity.Auth, code string) error {
+	if s.secretCipher == nil {
+		return ErrTwoFactorUnavailable
+	}
+
+	secret, err := s.secretCipher.Decrypt(auth.TwoFactorSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+
+	step, ok := entity.ValidateTOTPCode(secret, code, time.Now())
+	if !ok {
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	if err := auth.ConsumeTOTPStep(step); err != nil {
+		return err
+	}
+
+	consumed, err := s.authRepo.ConsumeTOTPStep(ctx, auth.UserID, step)
+	if err != nil {
+		return fmt.Errorf("failed to consume TOTP step: %w", err)
+	}
+	if !consumed {
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (s *AuthDomainService) verifySecondFactor(ctx context.Context, auth *entity.Auth, code string) error {
+	err := s.verifyTOTP(ctx, auth, code)
+	if err == nil {
+		return nil
+	}
+
+	if auth.UseRecoveryCode(code) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *AuthDomainService) mfaSigningKey() []byte {
+	key := sha256.Sum256([]byte("mfa:" + s.jwtSecret))
+	return key[:]
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := entity.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = entity.HashRecoveryCode(code)
+	}
+
+	return codes, hashes, nil
+}
+
 func (s *AuthDomainService) assignDefaultRole(ctx context.Context, userID uint) error {
 	role, err := s.roleRepo.GetByName(ctx, "user")
 	if err != nil {
@@ -9,10 +9,24 @@ import (
 type AuthDomainServiceInterface interface {
 	Register(ctx context.Context, name, email, password string, age int) (*entity.User, error)
 	Login(ctx context.Context, email, password string) (*entity.Auth, []string, error)
-	GenerateAccessToken(userID uint, email string, roles []string) (string, error)
+	GenerateAccessToken(ctx context.Context, userID uint, email string, roles []string) (string, error)
 	GenerateRefreshToken(ctx context.Context, userID uint) (string, error)
+	GenerateOAuthAccessToken(ctx context.Context, userID uint, clientID string, scopes []string) (string, error)
 	ValidateToken(tokenString string) (*JWTClaims, error)
+	ValidateOAuthAccessToken(ctx context.Context, tokenString string) (*OAuthClaims, error)
+	CheckTokenVersion(ctx context.Context, claims *JWTClaims) error
+	RevokeUserTokens(ctx context.Context, userID uint) error
+	JWKS() (*JWKS, error)
 	RefreshToken(ctx context.Context, refreshTokenStr string) (*entity.Auth, []string, string, error)
 	ChangePassword(ctx context.Context, userID uint, currentPassword, newPassword string) error
 	Logout(ctx context.Context, userID uint, token string) error
+	SuspendUser(ctx context.Context, userID uint) error
+	ReactivateUser(ctx context.Context, userID uint) error
+	SetupTwoFactor(ctx context.Context, userID uint) (string, string, error)
+	EnableTwoFactor(ctx context.Context, userID uint, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uint, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error)
+	GenerateMFAToken(userID uint, email string) (string, error)
+	ValidateMFAToken(ctx context.Context, tokenString string) (*MFAClaims, error)
+	CompleteTwoFactorLogin(ctx context.Context, claims *MFAClaims, code string) (*entity.Auth, []string, error)
 }
@@ -3,13 +3,17 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockUserRepository struct {
@@ -119,6 +123,11 @@ func (m *MockAuthRepository) Update(ctx context.Context, auth *entity.Auth) erro
 	return args.Error(0)
 }
 
+func (m *MockAuthRepository) ConsumeTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockAuthRepository) Delete(ctx context.Context, userID uint) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -138,6 +147,16 @@ func (m *MockRoleRepository) Create(ctx context.Context, role *entity.Role) erro
 	return args.Error(0)
 }
 
+func (m *MockRoleRepository) CreateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, role, permissionIDs, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) UpdateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, role, permissionIDs, event)
+	return args.Error(0)
+}
+
 func (m *MockRoleRepository) GetByID(ctx context.Context, id uint) (*entity.Role, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -182,6 +201,47 @@ func (m *MockRoleRepository) GetUserRoleNames(ctx context.Context, userID uint)
 	return nil, args.Error(1)
 }
 
+func (m *MockRoleRepository) HasUserRole(ctx context.Context, userID, roleID uint) (bool, error) {
+	args := m.Called(ctx, userID, roleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleRepository) RemoveFromUserUnlessLast(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) DeleteWithEvent(ctx context.Context, id uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, id, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) AssignToUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) RemoveFromUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, roleID, offset, limit)
+	if users, ok := args.Get(0).([]*entity.User); ok {
+		return users, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockRoleRepository) GetMemberIDs(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if userIDs, ok := args.Get(0).([]uint); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockRoleRepository) AssignToUser(ctx context.Context, userID, roleID uint) error {
 	args := m.Called(ctx, userID, roleID)
 	return args.Error(0)
@@ -228,8 +288,8 @@ func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *entity.R
 	return args.Error(0)
 }
 
-func (m *MockRefreshTokenRepository) GetByToken(ctx context.Context, token string) (*entity.RefreshToken, error) {
-	args := m.Called(ctx, token)
+func (m *MockRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -247,6 +307,16 @@ func (m *MockRefreshTokenRepository) Update(ctx context.Context, token *entity.R
 	return args.Error(0)
 }
 
+func (m *MockRefreshTokenRepository) MarkRotated(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
 func (m *MockRefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -318,7 +388,7 @@ func TestAuthDomainServiceRegister(t *testing.T) {
 			refreshTokenRepo := new(MockRefreshTokenRepository)
 			tt.setupMock(userRepo, authRepo, roleRepo)
 
-			service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+			service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 			ctx := context.Background()
 			user, err := service.Register(ctx, tt.userName, tt.email, tt.password, tt.age)
@@ -395,7 +465,7 @@ func TestAuthDomainServiceLogin(t *testing.T) {
 			refreshTokenRepo := new(MockRefreshTokenRepository)
 			tt.setupMock(authRepo, roleRepo)
 
-			service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+			service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 			ctx := context.Background()
 			auth, roles, err := service.Login(ctx, tt.email, tt.password)
@@ -421,30 +491,189 @@ func TestAuthDomainServiceGenerateAccessToken(t *testing.T) {
 	authRepo := new(MockAuthRepository)
 	roleRepo := new(MockRoleRepository)
 	refreshTokenRepo := new(MockRefreshTokenRepository)
-	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 	userID := uint(1)
 	email := "test@example.com"
 	roles := []string{"user"}
 
-	token, err := service.GenerateAccessToken(userID, email, roles)
+	token, err := service.GenerateAccessToken(context.Background(), userID, email, roles)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
 
+func TestAuthDomainServiceCheckTokenVersion(t *testing.T) {
+	t.Run("パスワード変更で発行済みのトークンを失効させる", func(t *testing.T) {
+		ctx := context.Background()
+		authRepo := new(MockAuthRepository)
+		refreshTokenRepo := new(MockRefreshTokenRepository)
+		cache := newTestRateLimitCache()
+		svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), refreshTokenRepo, cache, nil, nil, "test-secret")
+		auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+		authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+		authRepo.On("Update", ctx, auth).Return(nil)
+		refreshTokenRepo.On("RevokeByUserID", ctx, uint(1)).Return(nil)
+
+		oldToken, err := svc.GenerateAccessToken(ctx, 1, "test@example.com", []string{"admin"})
+		require.NoError(t, err)
+		oldClaims, err := svc.ValidateToken(oldToken)
+		require.NoError(t, err)
+		assert.NoError(t, svc.CheckTokenVersion(ctx, oldClaims))
+
+		require.NoError(t, svc.ChangePassword(ctx, 1, "password123", "newpassword123"))
+
+		assert.ErrorIs(t, svc.CheckTokenVersion(ctx, oldClaims), service.ErrTokenRevoked)
+
+		newToken, err := svc.GenerateAccessToken(ctx, 1, "test@example.com", []string{"user"})
+		require.NoError(t, err)
+		newClaims, err := svc.ValidateToken(newToken)
+		require.NoError(t, err)
+		assert.NoError(t, svc.CheckTokenVersion(ctx, newClaims))
+	})
+
+	t.Run("他のユーザーのトークンには影響しない", func(t *testing.T) {
+		ctx := context.Background()
+		refreshTokenRepo := new(MockRefreshTokenRepository)
+		cache := newTestRateLimitCache()
+		svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), refreshTokenRepo, cache, nil, nil, "test-secret")
+
+		refreshTokenRepo.On("RevokeByUserID", ctx, uint(1)).Return(nil)
+
+		token, err := svc.GenerateAccessToken(ctx, 2, "other@example.com", []string{"user"})
+		require.NoError(t, err)
+		require.NoError(t, svc.RevokeUserTokens(ctx, 1))
+
+		claims, err := svc.ValidateToken(token)
+		require.NoError(t, err)
+		assert.NoError(t, svc.CheckTokenVersion(ctx, claims))
+	})
+}
+
+func TestAuthDomainServiceOAuthAccessToken(t *testing.T) {
+	t.Run("ユーザーのトークンはバージョンで失効する", func(t *testing.T) {
+		ctx := context.Background()
+		refreshTokenRepo := new(MockRefreshTokenRepository)
+		cache := newTestRateLimitCache()
+		svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), refreshTokenRepo, cache, nil, nil, "test-secret")
+
+		refreshTokenRepo.On("RevokeByUserID", ctx, uint(1)).Return(nil)
+
+		token, err := svc.GenerateOAuthAccessToken(ctx, 1, "oc_abc", []string{"profile", "points:read"})
+		require.NoError(t, err)
+
+		claims, err := svc.ValidateOAuthAccessToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), claims.UserID)
+		assert.Equal(t, "oc_abc", claims.ClientID)
+		assert.Equal(t, "profile points:read", claims.Scope)
+		assert.Equal(t, "1", claims.Subject)
+		assert.NotEmpty(t, claims.ID)
+
+		require.NoError(t, svc.RevokeUserTokens(ctx, 1))
+
+		_, err = svc.ValidateOAuthAccessToken(ctx, token)
+		assert.ErrorIs(t, err, service.ErrTokenRevoked)
+	})
+
+	t.Run("クライアントのトークンはクライアントIDを主体にする", func(t *testing.T) {
+		ctx := context.Background()
+		cache := newTestRateLimitCache()
+		svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), cache, nil, nil, "test-secret")
+
+		token, err := svc.GenerateOAuthAccessToken(ctx, 0, "oc_abc", []string{"inventory:read"})
+		require.NoError(t, err)
+
+		claims, err := svc.ValidateOAuthAccessToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, "oc_abc", claims.Subject)
+		assert.Zero(t, claims.UserID)
+
+		require.NoError(t, cache.BlacklistToken(ctx, token, time.Hour))
+		_, err = svc.ValidateOAuthAccessToken(ctx, token)
+		assert.ErrorIs(t, err, service.ErrTokenRevoked)
+	})
+
+	t.Run("OAuthトークンとファーストパーティのトークンは互いに使えない", func(t *testing.T) {
+		ctx := context.Background()
+		svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+
+		oauthToken, err := svc.GenerateOAuthAccessToken(ctx, 1, "oc_abc", []string{"profile"})
+		require.NoError(t, err)
+		_, err = svc.ValidateToken(oauthToken)
+		assert.ErrorIs(t, err, service.ErrInvalidToken)
+
+		accessToken, err := svc.GenerateAccessToken(ctx, 1, "test@example.com", []string{"admin"})
+		require.NoError(t, err)
+		_, err = svc.ValidateOAuthAccessToken(ctx, accessToken)
+		assert.ErrorIs(t, err, service.ErrInvalidToken)
+	})
+}
+
+func TestAuthDomainServiceSuspendUser(t *testing.T) {
+	t.Run("停止してトークンを失効させる", func(t *testing.T) {
+		ctx := context.Background()
+		authRepo := new(MockAuthRepository)
+		refreshTokenRepo := new(MockRefreshTokenRepository)
+		cache := newTestRateLimitCache()
+		svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), refreshTokenRepo, cache, nil, nil, "test-secret")
+		auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+		authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+		authRepo.On("Update", ctx, auth).Return(nil)
+		refreshTokenRepo.On("RevokeByUserID", ctx, uint(1)).Return(nil)
+
+		err := svc.SuspendUser(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.False(t, auth.IsActive)
+		version, _ := cache.GetTokenVersion(ctx, 1)
+		assert.Equal(t, int64(1), version)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("停止済みのユーザー", func(t *testing.T) {
+		ctx := context.Background()
+		authRepo := new(MockAuthRepository)
+		svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+		auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+		auth.Deactivate()
+
+		authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+
+		err := svc.SuspendUser(ctx, 1)
+
+		assert.ErrorIs(t, err, service.ErrUserAlreadySuspended)
+		authRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("停止していないユーザーは再開できない", func(t *testing.T) {
+		ctx := context.Background()
+		authRepo := new(MockAuthRepository)
+		svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+		auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+		authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+
+		err := svc.ReactivateUser(ctx, 1)
+
+		assert.ErrorIs(t, err, service.ErrUserNotSuspended)
+	})
+}
+
 func TestAuthDomainServiceValidateToken(t *testing.T) {
 	userRepo := new(MockUserRepository)
 	authRepo := new(MockAuthRepository)
 	roleRepo := new(MockRoleRepository)
 	refreshTokenRepo := new(MockRefreshTokenRepository)
-	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 	userID := uint(1)
 	email := "test@example.com"
 	roles := []string{"user"}
 
-	validToken, err := service.GenerateAccessToken(userID, email, roles)
+	validToken, err := service.GenerateAccessToken(context.Background(), userID, email, roles)
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -486,6 +715,81 @@ func TestAuthDomainServiceValidateToken(t *testing.T) {
 	}
 }
 
+func TestAuthDomainServiceAsymmetricTokens(t *testing.T) {
+	for _, alg := range []string{service.SigningAlgorithmRS256, service.SigningAlgorithmES256, service.SigningAlgorithmEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			keySet, err := external.NewJWTKeySet(alg, time.Hour, 0)
+			assert.NoError(t, err)
+			oldKey, err := keySet.Rotate()
+			assert.NoError(t, err)
+
+			svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, keySet, "test-secret")
+
+			oldToken, err := svc.GenerateAccessToken(context.Background(), 1, "test@example.com", []string{"user"})
+			assert.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(oldToken, &service.JWTClaims{})
+			assert.NoError(t, err)
+			assert.Equal(t, alg, parsed.Method.Alg())
+			assert.Equal(t, oldKey.KeyID, parsed.Header["kid"])
+
+			newKey, err := keySet.Rotate()
+			assert.NoError(t, err)
+
+			newToken, err := svc.GenerateAccessToken(context.Background(), 1, "test@example.com", []string{"user"})
+			assert.NoError(t, err)
+
+			for _, token := range []string{oldToken, newToken} {
+				claims, err := svc.ValidateToken(token)
+				assert.NoError(t, err)
+				assert.Equal(t, uint(1), claims.UserID)
+			}
+
+			jwks, err := svc.JWKS()
+			assert.NoError(t, err)
+			kids := []string{}
+			for _, key := range jwks.Keys {
+				kids = append(kids, key.Kid)
+			}
+			assert.ElementsMatch(t, []string{oldKey.KeyID, newKey.KeyID}, kids)
+		})
+	}
+}
+
+func TestAuthDomainServiceAsymmetricRejectsForeignTokens(t *testing.T) {
+	keySet, err := external.NewJWTKeySet(service.SigningAlgorithmES256, time.Hour, 0)
+	assert.NoError(t, err)
+	_, err = keySet.Rotate()
+	assert.NoError(t, err)
+
+	otherKeySet, err := external.NewJWTKeySet(service.SigningAlgorithmES256, time.Hour, 0)
+	assert.NoError(t, err)
+	_, err = otherKeySet.Rotate()
+	assert.NoError(t, err)
+
+	svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, keySet, "test-secret")
+	hmacSvc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+	otherSvc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, otherKeySet, "test-secret")
+
+	hmacToken, err := hmacSvc.GenerateAccessToken(context.Background(), 1, "test@example.com", nil)
+	assert.NoError(t, err)
+	_, err = svc.ValidateToken(hmacToken)
+	assert.Equal(t, service.ErrInvalidToken, err, "kid の無い HS256 トークンは拒否")
+
+	foreignToken, err := otherSvc.GenerateAccessToken(context.Background(), 1, "test@example.com", nil)
+	assert.NoError(t, err)
+	_, err = svc.ValidateToken(foreignToken)
+	assert.Equal(t, service.ErrInvalidToken, err, "未知の kid は拒否")
+}
+
+func TestAuthDomainServiceJWKSWithoutKeySet(t *testing.T) {
+	svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+
+	jwks, err := svc.JWKS()
+	assert.NoError(t, err)
+	assert.Empty(t, jwks.Keys)
+}
+
 func TestAuthDomainServiceGenerateRefreshToken(t *testing.T) {
 	userRepo := new(MockUserRepository)
 	authRepo := new(MockAuthRepository)
@@ -493,15 +797,24 @@ func TestAuthDomainServiceGenerateRefreshToken(t *testing.T) {
 	refreshTokenRepo := new(MockRefreshTokenRepository)
 
 	ctx := context.Background()
-	refreshTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+	var stored *entity.RefreshToken
+	refreshTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.RefreshToken")).
+		Run(func(args mock.Arguments) {
+			stored, _ = args.Get(1).(*entity.RefreshToken)
+		}).
+		Return(nil)
 
-	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+	service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 	userID := uint(1)
 	token, err := service.GenerateRefreshToken(ctx, userID)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
+	assert.NotNil(t, stored)
+	assert.Equal(t, entity.HashRefreshToken(token), stored.TokenHash)
+	assert.NotEqual(t, token, stored.TokenHash)
+	assert.NotEmpty(t, stored.FamilyID)
 	refreshTokenRepo.AssertExpectations(t)
 }
 
@@ -511,21 +824,25 @@ func TestAuthDomainServiceRefreshToken(t *testing.T) {
 		token     string
 		setupMock func(*MockAuthRepository, *MockRoleRepository, *MockRefreshTokenRepository)
 		wantErr   bool
+		wantReuse bool
 	}{
 		{
 			name:  "有効なリフレッシュトークン",
 			token: "valid-refresh-token",
 			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
 				ctx := context.Background()
-				refreshToken := entity.NewRefreshToken(1, "valid-refresh-token", time.Now().Add(24*time.Hour))
+				refreshToken := entity.NewRefreshToken(1, entity.HashRefreshToken("valid-refresh-token"), "family-1", time.Now().Add(24*time.Hour))
+				refreshToken.ID = 10
 				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
 				roles := []*entity.Role{entity.NewRole("user", "Default user role")}
 
-				refreshTokenRepo.On("GetByToken", ctx, "valid-refresh-token").Return(refreshToken, nil)
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("valid-refresh-token")).Return(refreshToken, nil)
 				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
 				roleRepo.On("GetUserRoles", ctx, uint(1)).Return(roles, nil)
-				refreshTokenRepo.On("Update", ctx, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
-				refreshTokenRepo.On("Create", ctx, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+				refreshTokenRepo.On("MarkRotated", ctx, uint(10)).Return(true, nil)
+				refreshTokenRepo.On("Create", ctx, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					return rt.FamilyID == "family-1" && rt.UserID == 1
+				})).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -534,7 +851,7 @@ func TestAuthDomainServiceRefreshToken(t *testing.T) {
 			token: "nonexistent-token",
 			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
 				ctx := context.Background()
-				refreshTokenRepo.On("GetByToken", ctx, "nonexistent-token").Return((*entity.RefreshToken)(nil), assert.AnError)
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("nonexistent-token")).Return((*entity.RefreshToken)(nil), assert.AnError)
 			},
 			wantErr: true,
 		},
@@ -543,11 +860,53 @@ func TestAuthDomainServiceRefreshToken(t *testing.T) {
 			token: "expired-token",
 			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
 				ctx := context.Background()
-				refreshToken := entity.NewRefreshToken(1, "expired-token", time.Now().Add(-1*time.Hour))
-				refreshTokenRepo.On("GetByToken", ctx, "expired-token").Return(refreshToken, nil)
+				refreshToken := entity.NewRefreshToken(1, entity.HashRefreshToken("expired-token"), "family-1", time.Now().Add(-1*time.Hour))
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("expired-token")).Return(refreshToken, nil)
 			},
 			wantErr: true,
 		},
+		{
+			name:  "ログアウトで無効化されたトークンはファミリーを失効しない",
+			token: "revoked-token",
+			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				ctx := context.Background()
+				refreshToken := entity.NewRefreshToken(1, entity.HashRefreshToken("revoked-token"), "family-1", time.Now().Add(24*time.Hour))
+				refreshToken.Revoke()
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("revoked-token")).Return(refreshToken, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "ローテーション済みトークンの再利用でファミリー全体を失効",
+			token: "rotated-token",
+			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				ctx := context.Background()
+				refreshToken := entity.NewRefreshToken(1, entity.HashRefreshToken("rotated-token"), "family-1", time.Now().Add(24*time.Hour))
+				refreshToken.Rotate()
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("rotated-token")).Return(refreshToken, nil)
+				refreshTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil)
+			},
+			wantErr:   true,
+			wantReuse: true,
+		},
+		{
+			name:  "同時リフレッシュで競合した場合は再利用として扱う",
+			token: "raced-token",
+			setupMock: func(authRepo *MockAuthRepository, roleRepo *MockRoleRepository, refreshTokenRepo *MockRefreshTokenRepository) {
+				ctx := context.Background()
+				refreshToken := entity.NewRefreshToken(1, entity.HashRefreshToken("raced-token"), "family-1", time.Now().Add(24*time.Hour))
+				refreshToken.ID = 11
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+				refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashRefreshToken("raced-token")).Return(refreshToken, nil)
+				authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+				roleRepo.On("GetUserRoles", ctx, uint(1)).Return([]*entity.Role{}, nil)
+				refreshTokenRepo.On("MarkRotated", ctx, uint(11)).Return(false, nil)
+				refreshTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil)
+			},
+			wantErr:   true,
+			wantReuse: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -558,21 +917,30 @@ func TestAuthDomainServiceRefreshToken(t *testing.T) {
 			refreshTokenRepo := new(MockRefreshTokenRepository)
 			tt.setupMock(authRepo, roleRepo, refreshTokenRepo)
 
-			service := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, "test-secret")
+			svc := service.NewAuthDomainService(userRepo, authRepo, roleRepo, refreshTokenRepo, nil, nil, nil, "test-secret")
 
 			ctx := context.Background()
-			auth, roles, newToken, err := service.RefreshToken(ctx, tt.token)
+			auth, roles, newToken, err := svc.RefreshToken(ctx, tt.token)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, auth)
 				assert.Nil(t, roles)
 				assert.Empty(t, newToken)
+				assert.Equal(t, tt.wantReuse, errors.Is(err, service.ErrRefreshTokenReused))
+
+				var reuseErr *service.RefreshTokenReuseError
+				if tt.wantReuse {
+					assert.True(t, errors.As(err, &reuseErr))
+					assert.Equal(t, uint(1), reuseErr.UserID)
+					assert.Equal(t, "family-1", reuseErr.FamilyID)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, auth)
 				assert.NotNil(t, roles)
 				assert.NotEmpty(t, newToken)
+				assert.NotEqual(t, tt.token, newToken)
 			}
 
 			authRepo.AssertExpectations(t)
@@ -581,3 +949,292 @@ func TestAuthDomainServiceRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+type testSecretCipher struct{}
+
+func (testSecretCipher) Encrypt(plaintext string) (string, error) {
+	return "enc:" + plaintext, nil
+}
+
+func (testSecretCipher) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, "enc:") {
+		return "", errors.New("invalid ciphertext")
+	}
+	return strings.TrimPrefix(ciphertext, "enc:"), nil
+}
+
+func newTwoFactorAuth(t *testing.T, recoveryCodes ...string) (*entity.Auth, string) {
+	t.Helper()
+
+	auth, err := entity.NewAuth(1, "test@example.com", "password123")
+	assert.NoError(t, err)
+
+	secret, err := entity.GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = entity.HashRecoveryCode(code)
+	}
+
+	assert.NoError(t, auth.StartTwoFactorSetup("enc:"+secret))
+	assert.NoError(t, auth.EnableTwoFactor(hashes))
+
+	return auth, secret
+}
+
+func TestAuthDomainServiceTwoFactorEnrollment(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+	authRepo.On("Update", ctx, auth).Return(nil)
+
+	svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+
+	secret, uri, err := svc.SetupTwoFactor(ctx, 1)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.Contains(t, uri, "otpauth://totp/")
+	assert.Equal(t, "enc:"+secret, auth.TwoFactorSecret)
+	assert.False(t, auth.TwoFactorEnabled)
+
+	_, err = svc.EnableTwoFactor(ctx, 1, "invalid")
+	assert.Equal(t, entity.ErrInvalidTwoFactorCode, err)
+	assert.False(t, auth.TwoFactorEnabled)
+
+	code, err := entity.GenerateTOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+	authRepo.On("ConsumeTOTPStep", ctx, uint(1), mock.AnythingOfType("int64")).Return(true, nil)
+
+	recoveryCodes, err := svc.EnableTwoFactor(ctx, 1, code)
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, entity.RecoveryCodeCount)
+	assert.True(t, auth.TwoFactorEnabled)
+	assert.Len(t, auth.TwoFactorRecoveryCodes, entity.RecoveryCodeCount)
+	assert.NotContains(t, auth.TwoFactorRecoveryCodes, recoveryCodes[0])
+}
+
+func TestAuthDomainServiceSetupTwoFactorWithoutCipher(t *testing.T) {
+	svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, nil, nil, "test-secret")
+
+	_, _, err := svc.SetupTwoFactor(context.Background(), 1)
+	assert.Equal(t, service.ErrTwoFactorUnavailable, err)
+}
+
+func TestAuthDomainServiceLoginWithTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	roleRepo := new(MockRoleRepository)
+	auth, _ := newTwoFactorAuth(t)
+
+	authRepo.On("GetByEmail", ctx, "test@example.com").Return(auth, nil)
+
+	svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, roleRepo, new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+
+	result, roles, err := svc.Login(ctx, "test@example.com", "password123")
+	assert.NoError(t, err)
+	assert.True(t, result.TwoFactorEnabled)
+	assert.Nil(t, roles)
+	assert.Nil(t, result.LastLoginAt)
+
+	authRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	roleRepo.AssertNotCalled(t, "GetUserRoleNames", mock.Anything, mock.Anything)
+}
+
+func TestAuthDomainServiceMFAToken(t *testing.T) {
+	svc := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+	other := service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "other-secret")
+
+	mfaToken, err := svc.GenerateMFAToken(1, "test@example.com")
+	assert.NoError(t, err)
+
+	accessToken, err := svc.GenerateAccessToken(context.Background(), 1, "test@example.com", []string{"user"})
+	assert.NoError(t, err)
+
+	t.Run("有効なMFAトークン", func(t *testing.T) {
+		claims, err := svc.ValidateMFAToken(context.Background(), mfaToken)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), claims.UserID)
+		assert.Equal(t, "test@example.com", claims.Email)
+		assert.NotEmpty(t, claims.ID)
+	})
+
+	t.Run("MFAトークンはアクセストークンとして使えない", func(t *testing.T) {
+		claims, err := svc.ValidateToken(mfaToken)
+		assert.Equal(t, service.ErrInvalidToken, err)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("アクセストークンはMFAトークンとして使えない", func(t *testing.T) {
+		_, err := svc.ValidateMFAToken(context.Background(), accessToken)
+		assert.Equal(t, service.ErrInvalidToken, err)
+	})
+
+	t.Run("別の秘密鍵で署名されたMFAトークン", func(t *testing.T) {
+		_, err := other.ValidateMFAToken(context.Background(), mfaToken)
+		assert.Equal(t, service.ErrInvalidToken, err)
+	})
+}
+
+func TestAuthDomainServiceCompleteTwoFactorLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     func(secret string) string
+		usesTOTP bool
+		wantErr  error
+	}{
+		{
+			name: "正しいTOTPコード",
+			code: func(secret string) string {
+				code, _ := entity.GenerateTOTPCode(secret, time.Now())
+				return code
+			},
+			usesTOTP: true,
+		},
+		{
+			name: "リカバリーコード",
+			code: func(string) string {
+				return "abcde-12345"
+			},
+		},
+		{
+			name: "誤ったコード",
+			code: func(string) string {
+				return "not-a-code"
+			},
+			wantErr: entity.ErrInvalidTwoFactorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authRepo := new(MockAuthRepository)
+			roleRepo := new(MockRoleRepository)
+			auth, secret := newTwoFactorAuth(t, "abcde-12345")
+
+			authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+			if tt.usesTOTP {
+				authRepo.On("ConsumeTOTPStep", ctx, uint(1), mock.AnythingOfType("int64")).Return(true, nil)
+			}
+			if tt.wantErr == nil {
+				authRepo.On("Update", ctx, auth).Return(nil)
+				roleRepo.On("GetUserRoleNames", ctx, uint(1)).Return([]string{"user"}, nil)
+			}
+
+			svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, roleRepo, new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+			claims := &service.MFAClaims{UserID: 1, Email: "test@example.com"}
+
+			result, roles, err := svc.CompleteTwoFactorLogin(ctx, claims, tt.code(secret))
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"user"}, roles)
+			assert.NotNil(t, result.LastLoginAt)
+			authRepo.AssertExpectations(t)
+			roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthDomainServiceTOTPReplayRejected(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	roleRepo := new(MockRoleRepository)
+	auth, secret := newTwoFactorAuth(t)
+
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+	authRepo.On("ConsumeTOTPStep", ctx, uint(1), mock.AnythingOfType("int64")).Return(true, nil).Once()
+	authRepo.On("Update", ctx, auth).Return(nil)
+	roleRepo.On("GetUserRoleNames", ctx, uint(1)).Return([]string{"user"}, nil)
+
+	svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, roleRepo, new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+	claims := &service.MFAClaims{UserID: 1, Email: "test@example.com"}
+
+	code, err := entity.GenerateTOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+
+	_, _, err = svc.CompleteTwoFactorLogin(ctx, claims, code)
+	assert.NoError(t, err)
+
+	_, _, err = svc.CompleteTwoFactorLogin(ctx, claims, code)
+	assert.Equal(t, entity.ErrInvalidTwoFactorCode, err)
+}
+
+func TestAuthDomainServiceConcurrentTOTPConsumeRejected(t *testing.T) {
+	ctx := context.Background()
+	authRepo := new(MockAuthRepository)
+	roleRepo := new(MockRoleRepository)
+	first, secret := newTwoFactorAuth(t)
+	second := *first
+
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(first, nil).Once()
+	authRepo.On("GetByUserID", ctx, uint(1)).Return(&second, nil).Once()
+	authRepo.On("ConsumeTOTPStep", ctx, uint(1), mock.AnythingOfType("int64")).Return(true, nil).Once()
+	authRepo.On("ConsumeTOTPStep", ctx, uint(1), mock.AnythingOfType("int64")).Return(false, nil).Once()
+	authRepo.On("Update", ctx, first).Return(nil)
+	roleRepo.On("GetUserRoleNames", ctx, uint(1)).Return([]string{"user"}, nil)
+
+	svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, roleRepo, new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+	claims := &service.MFAClaims{UserID: 1, Email: "test@example.com"}
+
+	code, err := entity.GenerateTOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+
+	_, _, err = svc.CompleteTwoFactorLogin(ctx, claims, code)
+	assert.NoError(t, err)
+
+	result, _, err := svc.CompleteTwoFactorLogin(ctx, claims, code)
+	assert.Equal(t, entity.ErrInvalidTwoFactorCode, err)
+	assert.Nil(t, result)
+	authRepo.AssertNumberOfCalls(t, "Update", 1)
+	authRepo.AssertExpectations(t)
+}
+
+func TestAuthDomainServiceDisableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		code     string
+		wantErr  error
+	}{
+		{"パスワードとリカバリーコードで無効化", "password123", "abcde-12345", nil},
+		{"パスワード誤り", "wrong-password", "abcde-12345", entity.ErrInvalidPassword},
+		{"コード誤り", "password123", "zzzzz-99999", entity.ErrInvalidTwoFactorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authRepo := new(MockAuthRepository)
+			auth, _ := newTwoFactorAuth(t, "abcde-12345")
+
+			authRepo.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+			if tt.wantErr == nil {
+				authRepo.On("Update", ctx, auth).Return(nil)
+			}
+
+			svc := service.NewAuthDomainService(new(MockUserRepository), authRepo, new(MockRoleRepository), new(MockRefreshTokenRepository), nil, testSecretCipher{}, nil, "test-secret")
+
+			err := svc.DisableTwoFactor(ctx, 1, tt.password, tt.code)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.True(t, auth.TwoFactorEnabled)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.False(t, auth.TwoFactorEnabled)
+			assert.Empty(t, auth.TwoFactorSecret)
+			authRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,92 +3,99 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
 )
 
+const rateLimitRuleCacheTTL = 30 * time.Second
+
+type IPListCache interface {
+	IPListVersionSource
+	SyncBlacklistEntry(ctx context.Context, blacklist *entity.IPBlacklist)
+	SyncAllowlistEntry(ctx context.Context, allowlist *entity.IPAllowlist)
+	ReplaceIPLists(ctx context.Context, blacklists []*entity.IPBlacklist, allowlists []*entity.IPAllowlist) error
+}
+
 type FraudDomainService struct {
 	securityEventRepo     repository.SecurityEventRepository
 	ipBlacklistRepo       repository.IPBlacklistRepository
+	ipAllowlistRepo       repository.IPAllowlistRepository
 	loginAttemptRepo      repository.LoginAttemptRepository
 	rateLimitRuleRepo     repository.RateLimitRuleRepository
+	rateLimitLogRepo      repository.RateLimitLogRepository
 	userSessionRepo       repository.UserSessionRepository
 	deviceFingerprintRepo repository.DeviceFingerprintRepository
+	ipAccessList          *IPAccessList
+	ipListCache           IPListCache
+	fraudRuleEngine       *FraudRuleEngine
+
+	rateLimitRulesMu         sync.RWMutex
+	rateLimitRules           []*entity.RateLimitRule
+	rateLimitRulesLoadedAt   time.Time
+	rateLimitRulesGeneration uint64
 }
 
 func NewFraudDomainService(
 	securityEventRepo repository.SecurityEventRepository,
 	ipBlacklistRepo repository.IPBlacklistRepository,
+	ipAllowlistRepo repository.IPAllowlistRepository,
 	loginAttemptRepo repository.LoginAttemptRepository,
 	rateLimitRuleRepo repository.RateLimitRuleRepository,
+	rateLimitLogRepo repository.RateLimitLogRepository,
 	userSessionRepo repository.UserSessionRepository,
 	deviceFingerprintRepo repository.DeviceFingerprintRepository,
+	fraudRuleConfigRepo repository.FraudRuleConfigRepository,
+	ipListCache IPListCache,
 ) *FraudDomainService {
+	var versions IPListVersionSource
+	if ipListCache != nil {
+		versions = ipListCache
+	}
+	ipAccessList := NewIPAccessList(ipBlacklistRepo, ipAllowlistRepo, versions)
 	return &FraudDomainService{
 		securityEventRepo:     securityEventRepo,
 		ipBlacklistRepo:       ipBlacklistRepo,
+		ipAllowlistRepo:       ipAllowlistRepo,
 		loginAttemptRepo:      loginAttemptRepo,
 		rateLimitRuleRepo:     rateLimitRuleRepo,
+		rateLimitLogRepo:      rateLimitLogRepo,
 		userSessionRepo:       userSessionRepo,
 		deviceFingerprintRepo: deviceFingerprintRepo,
+		ipAccessList:          ipAccessList,
+		ipListCache:           ipListCache,
+		fraudRuleEngine: NewFraudRuleEngine(
+			fraudRuleConfigRepo,
+			NewBlacklistedIPRule(ipAccessList),
+			NewFailedLoginWarningRule(loginAttemptRepo),
+			NewFailedLoginRule(loginAttemptRepo),
+			NewUnknownDeviceRule(deviceFingerprintRepo),
+			NewIPVelocityRule(loginAttemptRepo),
+		),
 	}
 }
 
 func (s *FraudDomainService) AnalyzeFraud(ctx context.Context, userID *uint, email, ipAddress, userAgent string) (*entity.FraudAnalysis, error) {
-	var riskScore float64
-	var factors []string
-
-	isBlacklisted, err := s.ipBlacklistRepo.IsBlacklisted(ctx, ipAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check IP blacklist: %w", err)
-	}
-	if isBlacklisted {
-		riskScore += 0.8
-		factors = append(factors, "IP address is blacklisted")
-	}
-
-	since := time.Now().Add(-15 * time.Minute)
-	failedAttempts, err := s.loginAttemptRepo.CountFailedAttempts(ctx, email, since)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count failed attempts: %w", err)
-	}
-	if failedAttempts >= 5 {
-		riskScore += 0.6
-		factors = append(factors, fmt.Sprintf("Multiple failed login attempts: %d", failedAttempts))
-	} else if failedAttempts >= 3 {
-		riskScore += 0.3
-		factors = append(factors, fmt.Sprintf("Some failed login attempts: %d", failedAttempts))
-	}
-
-	if userID != nil {
-
-		fingerprint := fmt.Sprintf("%s_%s", ipAddress, userAgent)
-		isTrusted, err := s.deviceFingerprintRepo.IsTrustedDevice(ctx, *userID, fingerprint)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check device trust: %w", err)
-		}
-		if !isTrusted {
-			riskScore += 0.2
-			factors = append(factors, "Unknown device")
-		}
-	}
+	return s.fraudRuleEngine.Evaluate(ctx, &FraudSignal{
+		UserID:    userID,
+		Email:     email,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+}
 
-	ipAttempts, err := s.loginAttemptRepo.GetByIP(ctx, ipAddress, since)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get IP attempts: %w", err)
-	}
-	if len(ipAttempts) >= 10 {
-		riskScore += 0.4
-		factors = append(factors, fmt.Sprintf("High frequency requests from IP: %d", len(ipAttempts)))
-	}
+func (s *FraudDomainService) RegisterFraudRule(rule FraudRule) {
+	s.fraudRuleEngine.Register(rule)
+}
 
-	if riskScore > 1.0 {
-		riskScore = 1.0
-	}
+func (s *FraudDomainService) GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	return s.fraudRuleEngine.Configs(ctx)
+}
 
-	return entity.NewFraudAnalysis(riskScore, factors), nil
+func (s *FraudDomainService) UpdateFraudRule(ctx context.Context, name string, enabled *bool, weight *float64, threshold, windowSeconds *int) (*entity.FraudRuleConfig, error) {
+	return s.fraudRuleEngine.UpdateConfig(ctx, name, enabled, weight, threshold, windowSeconds)
 }
 
 func (s *FraudDomainService) RecordLoginAttempt(ctx context.Context, email, ipAddress, userAgent string, success bool, failReason string) error {
@@ -107,6 +114,10 @@ func (s *FraudDomainService) AddIPToBlacklist(ctx context.Context, ip, reason, c
 	if err != nil {
 		return err
 	}
+	s.ipAccessList.Invalidate()
+	if s.ipListCache != nil {
+		s.ipListCache.SyncBlacklistEntry(ctx, blacklist)
+	}
 
 	return s.CreateSecurityEvent(ctx, nil, "IP_BLACKLISTED", fmt.Sprintf("IP %s blacklisted: %s", ip, reason), clientIP, userAgent, "MEDIUM")
 }
@@ -122,6 +133,10 @@ func (s *FraudDomainService) RemoveIPFromBlacklist(ctx context.Context, ip, clie
 	if err != nil {
 		return err
 	}
+	s.ipAccessList.Invalidate()
+	if s.ipListCache != nil {
+		s.ipListCache.SyncBlacklistEntry(ctx, blacklist)
+	}
 
 	return s.CreateSecurityEvent(ctx, nil, "IP_UNBLACKLISTED", fmt.Sprintf("IP %s removed from blacklist", ip), clientIP, userAgent, "LOW")
 }
@@ -140,6 +155,75 @@ func (s *FraudDomainService) GetBlacklistedIPs(ctx context.Context, page, limit
 	}, nil
 }
 
+func (s *FraudDomainService) AddIPToAllowlist(ctx context.Context, ip, description, clientIP, userAgent string) error {
+	allowlist := entity.NewIPAllowlist(ip, description)
+	err := s.ipAllowlistRepo.Create(ctx, allowlist)
+	if err != nil {
+		return err
+	}
+	s.ipAccessList.Invalidate()
+	if s.ipListCache != nil {
+		s.ipListCache.SyncAllowlistEntry(ctx, allowlist)
+	}
+
+	return s.CreateSecurityEvent(ctx, nil, "IP_ALLOWLISTED", fmt.Sprintf("IP %s allowlisted: %s", ip, description), clientIP, userAgent, "MEDIUM")
+}
+
+func (s *FraudDomainService) RemoveIPFromAllowlist(ctx context.Context, ip, clientIP, userAgent string) error {
+	allowlist, err := s.ipAllowlistRepo.GetByIP(ctx, ip)
+	if err != nil {
+		return fmt.Errorf("failed to get IP allowlist: %w", err)
+	}
+
+	allowlist.Deactivate()
+	err = s.ipAllowlistRepo.Update(ctx, allowlist)
+	if err != nil {
+		return err
+	}
+	s.ipAccessList.Invalidate()
+	if s.ipListCache != nil {
+		s.ipListCache.SyncAllowlistEntry(ctx, allowlist)
+	}
+
+	return s.CreateSecurityEvent(ctx, nil, "IP_UNALLOWLISTED", fmt.Sprintf("IP %s removed from allowlist", ip), clientIP, userAgent, "LOW")
+}
+
+func (s *FraudDomainService) GetAllowlistedIPs(ctx context.Context, page, limit int) (interface{}, error) {
+	ips, total, err := s.ipAllowlistRepo.List(ctx, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowlisted IPs: %w", err)
+	}
+
+	return map[string]interface{}{
+		"ips":   ips,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}, nil
+}
+
+func (s *FraudDomainService) SyncIPListCache(ctx context.Context) error {
+	if s.ipListCache == nil {
+		return nil
+	}
+
+	blacklists, err := s.ipBlacklistRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load IP blacklist: %w", err)
+	}
+	allowlists, err := s.ipAllowlistRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load IP allowlist: %w", err)
+	}
+
+	s.ipAccessList.Invalidate()
+	return s.ipListCache.ReplaceIPLists(ctx, blacklists, allowlists)
+}
+
+func (s *FraudDomainService) IsIPBlocked(ctx context.Context, ip string) (bool, error) {
+	return s.ipAccessList.IsBlocked(ctx, ip)
+}
+
 func (s *FraudDomainService) GetSecurityEvents(ctx context.Context, page, limit int) (interface{}, error) {
 	events, total, err := s.securityEventRepo.List(ctx, page, limit)
 	if err != nil {
@@ -158,19 +242,40 @@ func (s *FraudDomainService) CheckRateLimit(ctx context.Context, resource string
 	return s.rateLimitRuleRepo.GetByResource(ctx, resource)
 }
 
-func (s *FraudDomainService) CreateRateLimitRule(ctx context.Context, name, pattern string, maxRequests, windowSize int64) error {
+func (s *FraudDomainService) CreateRateLimitRule(ctx context.Context, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error) {
+	if !entity.IsValidRateLimitResource(pattern) {
+		return nil, fmt.Errorf("invalid rate limit resource: %s", pattern)
+	}
+
 	rule := entity.NewRateLimitRule(name, pattern, int(maxRequests), int(windowSize))
-	return s.rateLimitRuleRepo.Create(ctx, rule)
+	rule.Algorithm = algorithm
+	if err := s.rateLimitRuleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create rate limit rule: %w", err)
+	}
+	s.InvalidateRateLimitRules()
+
+	return rule, nil
 }
 
-func (s *FraudDomainService) UpdateRateLimitRule(ctx context.Context, ruleID uint, _, _ string, maxRequests, windowSize int64) error {
+func (s *FraudDomainService) UpdateRateLimitRule(ctx context.Context, ruleID uint, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error) {
+	if pattern != "" && !entity.IsValidRateLimitResource(pattern) {
+		return nil, fmt.Errorf("invalid rate limit resource: %s", pattern)
+	}
+
 	rule, err := s.rateLimitRuleRepo.GetByID(ctx, ruleID)
 	if err != nil {
-		return fmt.Errorf("failed to get rate limit rule: %w", err)
+		return nil, fmt.Errorf("failed to get rate limit rule: %w", err)
 	}
 
+	rule.ChangeTarget(name, pattern)
 	rule.UpdateRule(int(maxRequests), int(windowSize))
-	return s.rateLimitRuleRepo.Update(ctx, rule)
+	rule.ChangeAlgorithm(algorithm)
+	if err := s.rateLimitRuleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update rate limit rule: %w", err)
+	}
+	s.InvalidateRateLimitRules()
+
+	return rule, nil
 }
 
 func (s *FraudDomainService) GetRateLimitRules(ctx context.Context) (interface{}, error) {
@@ -186,34 +291,15 @@ func (s *FraudDomainService) GetRateLimitRules(ctx context.Context) (interface{}
 }
 
 func (s *FraudDomainService) GetActiveSessions(ctx context.Context) (interface{}, error) {
-	now := time.Now()
-
-	sessionEvents, _, err := s.securityEventRepo.List(ctx, 1, 50)
+	sessions, total, err := s.userSessionRepo.ListActive(ctx, 0, 100)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session events: %w", err)
-	}
-
-	activeSessions := []map[string]interface{}{}
-	for _, event := range sessionEvents {
-		if event.EventType == "SESSION_CREATED" || event.EventType == "LOGIN_SUCCESS" {
-			sessionInfo := map[string]interface{}{
-				"session_id": fmt.Sprintf("sess_%d", event.ID),
-				"user_id":    event.UserID,
-				"ip_address": event.IPAddress,
-				"user_agent": event.UserAgent,
-				"created_at": event.CreatedAt,
-				"last_seen":  event.CreatedAt,
-				"is_active":  true,
-			}
-			activeSessions = append(activeSessions, sessionInfo)
-		}
+		return nil, fmt.Errorf("failed to get active sessions: %w", err)
 	}
 
 	return map[string]interface{}{
-		"sessions":     activeSessions,
-		"total":        len(activeSessions),
-		"active_count": len(activeSessions),
-		"timestamp":    now,
+		"sessions":  sessions,
+		"total":     total,
+		"timestamp": time.Now(),
 	}, nil
 }
 
@@ -222,138 +308,110 @@ func (s *FraudDomainService) DeactivateSession(ctx context.Context, sessionID st
 }
 
 func (s *FraudDomainService) GetDevices(ctx context.Context) (interface{}, error) {
-	now := time.Now()
-	since := now.Add(-30 * 24 * time.Hour)
-
-	loginAttempts, err := s.getFilteredLoginAttempts(ctx, since)
+	devices, total, err := s.deviceFingerprintRepo.List(ctx, 0, 100)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get devices: %w", err)
 	}
 
-	deviceMap := s.buildDeviceMap(loginAttempts)
-	devices := s.convertDeviceMapToSlice(deviceMap)
-
 	return map[string]interface{}{
 		"devices":   devices,
-		"total":     len(devices),
-		"timestamp": now,
-		"period":    "30 days",
+		"total":     total,
+		"timestamp": time.Now(),
 	}, nil
 }
 
-func (s *FraudDomainService) getFilteredLoginAttempts(ctx context.Context, since time.Time) ([]*entity.LoginAttempt, error) {
-	loginAttempts, _, err := s.loginAttemptRepo.List(ctx, 1, 100)
+func (s *FraudDomainService) TrustDevice(ctx context.Context, fingerprint string) (*entity.DeviceFingerprint, error) {
+	deviceFingerprint, err := s.deviceFingerprintRepo.GetByFingerprint(ctx, fingerprint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get login attempts: %w", err)
+		return nil, fmt.Errorf("failed to get device fingerprint: %w", err)
 	}
 
-	filteredAttempts := make([]*entity.LoginAttempt, 0, len(loginAttempts))
-	for _, attempt := range loginAttempts {
-		if attempt.CreatedAt.After(since) {
-			filteredAttempts = append(filteredAttempts, attempt)
-		}
+	deviceFingerprint.Trust()
+	if err := s.deviceFingerprintRepo.Update(ctx, deviceFingerprint); err != nil {
+		return nil, err
 	}
-	return filteredAttempts, nil
-}
-
-func (s *FraudDomainService) buildDeviceMap(loginAttempts []*entity.LoginAttempt) map[string]map[string]interface{} {
-	deviceMap := make(map[string]map[string]interface{})
-
-	for _, attempt := range loginAttempts {
-		fingerprint := fmt.Sprintf("%s_%s", attempt.IPAddress, attempt.UserAgent)
 
-		if _, exists := deviceMap[fingerprint]; !exists {
-			deviceMap[fingerprint] = s.createNewDeviceInfo(attempt, fingerprint)
-		} else {
-			s.updateExistingDeviceInfo(deviceMap[fingerprint], attempt)
-		}
-	}
-	return deviceMap
+	return deviceFingerprint, nil
 }
 
-func (s *FraudDomainService) createNewDeviceInfo(attempt *entity.LoginAttempt, fingerprint string) map[string]interface{} {
-	deviceInfo := map[string]interface{}{
-		"fingerprint":   fingerprint,
-		"ip_address":    attempt.IPAddress,
-		"user_agent":    attempt.UserAgent,
-		"first_seen":    attempt.CreatedAt,
-		"last_seen":     attempt.CreatedAt,
-		"login_count":   1,
-		"success_count": 0,
-		"is_trusted":    false,
-		"risk_level":    "unknown",
+func (s *FraudDomainService) DeleteRateLimitRule(ctx context.Context, ruleID uint) error {
+	rule, err := s.rateLimitRuleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to get rate limit rule: %w", err)
 	}
 
-	if attempt.Success {
-		deviceInfo["success_count"] = 1
-		deviceInfo["risk_level"] = "low"
-	} else {
-		deviceInfo["risk_level"] = "medium"
+	rule.Deactivate()
+	if err := s.rateLimitRuleRepo.Update(ctx, rule); err != nil {
+		return fmt.Errorf("failed to delete rate limit rule: %w", err)
 	}
+	s.InvalidateRateLimitRules()
 
-	return deviceInfo
+	return nil
 }
 
-func (s *FraudDomainService) updateExistingDeviceInfo(device map[string]interface{}, attempt *entity.LoginAttempt) {
-	device["last_seen"] = attempt.CreatedAt
-
-	loginCount, ok := device["login_count"].(int)
-	if !ok {
-		loginCount = 0
+func (s *FraudDomainService) ResolveRateLimitRule(ctx context.Context, subject *entity.RateLimitSubject) (*entity.RateLimitRule, error) {
+	rules, err := s.activeRateLimitRules(ctx)
+	if err != nil {
+		return nil, err
 	}
-	device["login_count"] = loginCount + 1
 
-	if attempt.Success {
-		successCount, ok := device["success_count"].(int)
-		if !ok {
-			successCount = 0
+	var resolved *entity.RateLimitRule
+	for _, rule := range rules {
+		if !rule.Matches(subject) {
+			continue
+		}
+		if resolved == nil || rule.Outranks(resolved) {
+			resolved = rule
 		}
-		device["success_count"] = successCount + 1
 	}
 
-	successCount, ok := device["success_count"].(int)
-	if !ok {
-		successCount = 0
-	}
-	device["risk_level"] = s.calculateRiskLevel(successCount, loginCount+1)
+	return resolved, nil
 }
 
-func (s *FraudDomainService) calculateRiskLevel(successCount, loginCount int) string {
-	successRate := float64(successCount) / float64(loginCount)
-	if successRate > 0.8 {
-		return "low"
-	} else if successRate > 0.5 {
-		return "medium"
+func (s *FraudDomainService) RecordRateLimitBlock(ctx context.Context, rule *entity.RateLimitRule, subject *entity.RateLimitSubject, requests int64, windowStart time.Time) error {
+	log := entity.NewRateLimitLog(rule.ID, subject.IPAddress, subject.UserID, int(requests), windowStart, windowStart.Add(rule.Window()), true)
+	if err := s.rateLimitLogRepo.Create(ctx, log); err != nil {
+		return fmt.Errorf("failed to record rate limit block: %w", err)
 	}
-	return "high"
-}
 
-func (s *FraudDomainService) convertDeviceMapToSlice(deviceMap map[string]map[string]interface{}) []map[string]interface{} {
-	devices := make([]map[string]interface{}, 0, len(deviceMap))
-	for _, device := range deviceMap {
-		devices = append(devices, device)
-	}
-	return devices
+	return nil
 }
 
-func (s *FraudDomainService) TrustDevice(ctx context.Context, fingerprint string) error {
-	deviceFingerprint, err := s.deviceFingerprintRepo.GetByFingerprint(ctx, fingerprint)
-	if err != nil {
-		return fmt.Errorf("failed to get device fingerprint: %w", err)
-	}
+func (s *FraudDomainService) InvalidateRateLimitRules() {
+	s.rateLimitRulesMu.Lock()
+	defer s.rateLimitRulesMu.Unlock()
 
-	deviceFingerprint.Trust()
-	return s.deviceFingerprintRepo.Update(ctx, deviceFingerprint)
+	s.rateLimitRules = nil
+	s.rateLimitRulesGeneration++
 }
 
-func (s *FraudDomainService) DeleteRateLimitRule(ctx context.Context, ruleID uint) error {
-	rule, err := s.rateLimitRuleRepo.GetByID(ctx, ruleID)
+func (s *FraudDomainService) activeRateLimitRules(ctx context.Context) ([]*entity.RateLimitRule, error) {
+	s.rateLimitRulesMu.RLock()
+	rules := s.rateLimitRules
+	fresh := rules != nil && time.Since(s.rateLimitRulesLoadedAt) < rateLimitRuleCacheTTL
+	generation := s.rateLimitRulesGeneration
+	s.rateLimitRulesMu.RUnlock()
+
+	if fresh {
+		return rules, nil
+	}
+
+	rules, err := s.rateLimitRuleRepo.GetActiveRules(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get rate limit rule: %w", err)
+		return nil, fmt.Errorf("failed to load rate limit rules: %w", err)
+	}
+	if rules == nil {
+		rules = []*entity.RateLimitRule{}
 	}
 
-	rule.Deactivate()
-	return s.rateLimitRuleRepo.Update(ctx, rule)
+	s.rateLimitRulesMu.Lock()
+	if s.rateLimitRulesGeneration == generation {
+		s.rateLimitRules = rules
+		s.rateLimitRulesLoadedAt = time.Now()
+	}
+	s.rateLimitRulesMu.Unlock()
+
+	return rules, nil
 }
 
 func (s *FraudDomainService) CreateUserSession(ctx context.Context, userID uint, sessionID, ipAddress, userAgent string, expiresAt time.Time) error {
@@ -384,6 +442,10 @@ func (s *FraudDomainService) DeactivateUserSession(ctx context.Context, sessionI
 	return s.userSessionRepo.Update(ctx, session)
 }
 
+func (s *FraudDomainService) DeactivateUserSessions(ctx context.Context, userID uint) error {
+	return s.userSessionRepo.DeactivateByUserID(ctx, userID)
+}
+
 func (s *FraudDomainService) RecordDeviceFingerprint(ctx context.Context, userID uint, fingerprint string, deviceInfo *string) error {
 	existing, err := s.deviceFingerprintRepo.GetByFingerprint(ctx, fingerprint)
 	if err == nil {
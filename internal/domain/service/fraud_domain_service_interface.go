@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
@@ -11,16 +12,23 @@ type FraudDomainServiceInterface interface {
 	CreateSecurityEvent(ctx context.Context, userID *uint, eventType, description, ipAddress, userAgent, severity string) error
 	RecordLoginAttempt(ctx context.Context, email, ipAddress, userAgent string, success bool, failureReason string) error
 	DeactivateUserSession(ctx context.Context, sessionID string) error
+	DeactivateUserSessions(ctx context.Context, userID uint) error
 	GetFraudStats(ctx context.Context) (map[string]interface{}, error)
 
+	GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error)
+	UpdateFraudRule(ctx context.Context, name string, enabled *bool, weight *float64, threshold, windowSeconds *int) (*entity.FraudRuleConfig, error)
+
 	AddIPToBlacklist(ctx context.Context, ip, reason, clientIP, userAgent string) error
 	RemoveIPFromBlacklist(ctx context.Context, ip, clientIP, userAgent string) error
 	GetBlacklistedIPs(ctx context.Context, page, limit int) (interface{}, error)
+	AddIPToAllowlist(ctx context.Context, ip, description, clientIP, userAgent string) error
+	RemoveIPFromAllowlist(ctx context.Context, ip, clientIP, userAgent string) error
+	GetAllowlistedIPs(ctx context.Context, page, limit int) (interface{}, error)
 
 	GetSecurityEvents(ctx context.Context, page, limit int) (interface{}, error)
 
-	CreateRateLimitRule(ctx context.Context, name, pattern string, maxRequests, windowSize int64) error
-	UpdateRateLimitRule(ctx context.Context, id uint, name, pattern string, maxRequests, windowSize int64) error
+	CreateRateLimitRule(ctx context.Context, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error)
+	UpdateRateLimitRule(ctx context.Context, id uint, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error)
 	DeleteRateLimitRule(ctx context.Context, id uint) error
 	GetRateLimitRules(ctx context.Context) (interface{}, error)
 
@@ -28,7 +36,16 @@ type FraudDomainServiceInterface interface {
 	DeactivateSession(ctx context.Context, sessionID string) error
 
 	GetDevices(ctx context.Context) (interface{}, error)
-	TrustDevice(ctx context.Context, fingerprint string) error
+	TrustDevice(ctx context.Context, fingerprint string) (*entity.DeviceFingerprint, error)
 
 	CleanupExpiredData(ctx context.Context) error
 }
+
+type IPAccessPolicy interface {
+	IsIPBlocked(ctx context.Context, ip string) (bool, error)
+}
+
+type RateLimitPolicy interface {
+	ResolveRateLimitRule(ctx context.Context, subject *entity.RateLimitSubject) (*entity.RateLimitRule, error)
+	RecordRateLimitBlock(ctx context.Context, rule *entity.RateLimitRule, subject *entity.RateLimitSubject, requests int64, windowStart time.Time) error
+}
@@ -112,6 +112,14 @@ func (m *MockIPBlacklistRepository) IsBlacklisted(ctx context.Context, ipAddress
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockIPBlacklistRepository) ListActive(ctx context.Context) ([]*entity.IPBlacklist, error) {
+	args := m.Called(ctx)
+	if blacklists, ok := args.Get(0).([]*entity.IPBlacklist); ok {
+		return blacklists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockIPBlacklistRepository) Update(ctx context.Context, blacklist *entity.IPBlacklist) error {
 	if blacklist == nil {
 		return errors.New("blacklist is nil")
@@ -194,6 +202,11 @@ func (m *MockLoginAttemptRepository) CountFailedAttempts(ctx context.Context, em
 	return count, args.Error(1)
 }
 
+func (m *MockLoginAttemptRepository) CountSuccessfulAttempts(ctx context.Context, email string, since time.Time) (int64, error) {
+	args := m.Called(ctx, email, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockLoginAttemptRepository) List(ctx context.Context, offset, limit int) ([]*entity.LoginAttempt, int64, error) {
 	args := m.Called(ctx, offset, limit)
 	var attempts []*entity.LoginAttempt
@@ -225,6 +238,15 @@ func (m *MockLoginAttemptRepository) CleanupOld(ctx context.Context, before time
 	return args.Error(0)
 }
 
+type MockRateLimitLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitLogRepository) Create(ctx context.Context, log *entity.RateLimitLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
 type MockRateLimitRuleRepository struct {
 	mock.Mock
 }
@@ -334,6 +356,19 @@ func (m *MockUserSessionRepository) GetByUserID(ctx context.Context, userID uint
 	return nil, args.Error(1)
 }
 
+func (m *MockUserSessionRepository) ListActive(ctx context.Context, offset, limit int) ([]*entity.UserSession, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	var sessions []*entity.UserSession
+	if s, ok := args.Get(0).([]*entity.UserSession); ok {
+		sessions = s
+	}
+	var total int64
+	if t, ok := args.Get(1).(int64); ok {
+		total = t
+	}
+	return sessions, total, args.Error(2)
+}
+
 func (m *MockUserSessionRepository) Update(ctx context.Context, session *entity.UserSession) error {
 	if session == nil {
 		return errors.New("session is nil")
@@ -399,6 +434,19 @@ func (m *MockDeviceFingerprintRepository) GetByUserID(ctx context.Context, userI
 	return nil, args.Error(1)
 }
 
+func (m *MockDeviceFingerprintRepository) List(ctx context.Context, offset, limit int) ([]*entity.DeviceFingerprint, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	var fingerprints []*entity.DeviceFingerprint
+	if f, ok := args.Get(0).([]*entity.DeviceFingerprint); ok {
+		fingerprints = f
+	}
+	var total int64
+	if t, ok := args.Get(1).(int64); ok {
+		total = t
+	}
+	return fingerprints, total, args.Error(2)
+}
+
 func (m *MockDeviceFingerprintRepository) IsTrustedDevice(ctx context.Context, userID uint, fingerprint string) (bool, error) {
 	args := m.Called(ctx, userID, fingerprint)
 	return args.Bool(0), args.Error(1)
@@ -417,30 +465,447 @@ func (m *MockDeviceFingerprintRepository) Delete(ctx context.Context, id uint) e
 	return args.Error(0)
 }
 
-func setupFraudDomainService() (*service.FraudDomainService, *MockSecurityEventRepository, *MockIPBlacklistRepository, *MockLoginAttemptRepository, *MockRateLimitRuleRepository, *MockUserSessionRepository, *MockDeviceFingerprintRepository) {
+type MockIPAllowlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockIPAllowlistRepository) Create(ctx context.Context, allowlist *entity.IPAllowlist) error {
+	args := m.Called(ctx, allowlist)
+	return args.Error(0)
+}
+
+func (m *MockIPAllowlistRepository) GetByIP(ctx context.Context, ipAddress string) (*entity.IPAllowlist, error) {
+	args := m.Called(ctx, ipAddress)
+	if allowlist, ok := args.Get(0).(*entity.IPAllowlist); ok {
+		return allowlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockIPAllowlistRepository) List(ctx context.Context, offset, limit int) ([]*entity.IPAllowlist, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	var allowlists []*entity.IPAllowlist
+	if a, ok := args.Get(0).([]*entity.IPAllowlist); ok {
+		allowlists = a
+	}
+	var total int64
+	if t, ok := args.Get(1).(int64); ok {
+		total = t
+	}
+	return allowlists, total, args.Error(2)
+}
+
+func (m *MockIPAllowlistRepository) Update(ctx context.Context, allowlist *entity.IPAllowlist) error {
+	args := m.Called(ctx, allowlist)
+	return args.Error(0)
+}
+
+func (m *MockIPAllowlistRepository) ListActive(ctx context.Context) ([]*entity.IPAllowlist, error) {
+	args := m.Called(ctx)
+	if allowlists, ok := args.Get(0).([]*entity.IPAllowlist); ok {
+		return allowlists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockFraudRuleConfigRepository struct {
+	mock.Mock
+}
+
+func (m *MockFraudRuleConfigRepository) List(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	args := m.Called(ctx)
+	if configs, ok := args.Get(0).([]*entity.FraudRuleConfig); ok {
+		return configs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFraudRuleConfigRepository) Save(ctx context.Context, config *entity.FraudRuleConfig) error {
+	args := m.Called(ctx, config)
+	return args.Error(0)
+}
+
+func setupFraudDomainService() (*service.FraudDomainService, *MockSecurityEventRepository, *MockIPBlacklistRepository, *MockLoginAttemptRepository, *MockRateLimitRuleRepository, *MockUserSessionRepository, *MockDeviceFingerprintRepository, *MockRateLimitLogRepository) {
 	mockSecurityEventRepo := &MockSecurityEventRepository{}
 	mockIPBlacklistRepo := &MockIPBlacklistRepository{}
 	mockLoginAttemptRepo := &MockLoginAttemptRepository{}
 	mockRateLimitRuleRepo := &MockRateLimitRuleRepository{}
+	mockRateLimitLogRepo := &MockRateLimitLogRepository{}
 	mockUserSessionRepo := &MockUserSessionRepository{}
 	mockDeviceFingerprintRepo := &MockDeviceFingerprintRepository{}
 
 	service := service.NewFraudDomainService(
 		mockSecurityEventRepo,
 		mockIPBlacklistRepo,
+		&MockIPAllowlistRepository{},
 		mockLoginAttemptRepo,
 		mockRateLimitRuleRepo,
+		mockRateLimitLogRepo,
 		mockUserSessionRepo,
 		mockDeviceFingerprintRepo,
+		&MockFraudRuleConfigRepository{},
+		nil,
 	)
 
-	return service, mockSecurityEventRepo, mockIPBlacklistRepo, mockLoginAttemptRepo, mockRateLimitRuleRepo, mockUserSessionRepo, mockDeviceFingerprintRepo
+	return service, mockSecurityEventRepo, mockIPBlacklistRepo, mockLoginAttemptRepo, mockRateLimitRuleRepo, mockUserSessionRepo, mockDeviceFingerprintRepo, mockRateLimitLogRepo
 }
 
 func TestNewFraudDomainService(t *testing.T) {
 	t.Run("新しいFraudDomainServiceを正常に作成できる", func(t *testing.T) {
-		service, _, _, _, _, _, _ := setupFraudDomainService()
+		service, _, _, _, _, _, _, _ := setupFraudDomainService()
 
 		assert.NotNil(t, service)
 	})
 }
+
+func TestFraudDomainServiceCreateRateLimitRule(t *testing.T) {
+	svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+	ctx := context.Background()
+
+	rateLimitRuleRepo.On("Create", ctx, mock.AnythingOfType("*entity.RateLimitRule")).
+		Run(func(args mock.Arguments) {
+			if rule, ok := args.Get(1).(*entity.RateLimitRule); ok {
+				rule.ID = 7
+			}
+		}).
+		Return(nil)
+
+	rule, err := svc.CreateRateLimitRule(ctx, "ip:203.0.113.10", "ip:203.0.113.10", entity.RateLimitAlgorithmTokenBucket, 100, 60)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), rule.ID)
+	assert.Equal(t, "ip:203.0.113.10", rule.Resource)
+	assert.Equal(t, entity.RateLimitAlgorithmTokenBucket, rule.Algorithm)
+	assert.True(t, rule.IsActive)
+	rateLimitRuleRepo.AssertExpectations(t)
+
+	_, err = svc.CreateRateLimitRule(ctx, "invalid", "203.0.113.10", entity.RateLimitAlgorithmTokenBucket, 100, 60)
+	assert.EqualError(t, err, "invalid rate limit resource: 203.0.113.10")
+	rateLimitRuleRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestFraudDomainServiceUpdateRateLimitRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		ruleName  string
+		pattern   string
+		setupMock func(*MockRateLimitRuleRepository)
+		wantErr   string
+	}{
+		{
+			name: "既存ルールの上限を更新",
+			setupMock: func(repo *MockRateLimitRuleRepository) {
+				rule := entity.NewRateLimitRule("IP:203.0.113.10", "203.0.113.10", 100, 60)
+				rule.ID = 1
+				repo.On("GetByID", mock.Anything, uint(1)).Return(rule, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(r *entity.RateLimitRule) bool {
+					return r.MaxRequests == 50 && r.WindowSize == 60 && r.Algorithm == entity.RateLimitAlgorithmSlidingLog &&
+						r.Name == "IP:203.0.113.10" && r.Resource == "203.0.113.10"
+				})).Return(nil)
+			},
+		},
+		{
+			name:     "名前と対象を更新",
+			ruleName: "ENDPOINT:GET /api/v1/users/*",
+			pattern:  "GET /api/v1/users/*",
+			setupMock: func(repo *MockRateLimitRuleRepository) {
+				rule := entity.NewRateLimitRule("IP:203.0.113.10", "ip:203.0.113.10", 100, 60)
+				rule.ID = 1
+				repo.On("GetByID", mock.Anything, uint(1)).Return(rule, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(r *entity.RateLimitRule) bool {
+					return r.Name == "ENDPOINT:GET /api/v1/users/*" && r.Resource == "GET /api/v1/users/*"
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "不正な対象は拒否",
+			ruleName:  "USER:abc",
+			pattern:   "user:abc",
+			setupMock: func(repo *MockRateLimitRuleRepository) {},
+			wantErr:   "invalid",
+		},
+		{
+			name: "存在しないルール",
+			setupMock: func(repo *MockRateLimitRuleRepository) {
+				repo.On("GetByID", mock.Anything, uint(1)).Return(nil, errors.New("record not found"))
+			},
+			wantErr: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+			tt.setupMock(rateLimitRuleRepo)
+
+			rule, err := svc.UpdateRateLimitRule(context.Background(), 1, tt.ruleName, tt.pattern, entity.RateLimitAlgorithmSlidingLog, 50, 0)
+
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, rule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 50, rule.MaxRequests)
+			}
+			rateLimitRuleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudDomainServiceGetActiveSessions(t *testing.T) {
+	svc, _, _, _, _, userSessionRepo, _, _ := setupFraudDomainService()
+	ctx := context.Background()
+
+	sessions := []*entity.UserSession{
+		entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(time.Hour)),
+	}
+	userSessionRepo.On("ListActive", ctx, 0, 100).Return(sessions, int64(1), nil)
+
+	result, err := svc.GetActiveSessions(ctx)
+
+	assert.NoError(t, err)
+	resultMap, ok := result.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, sessions, resultMap["sessions"])
+	assert.Equal(t, int64(1), resultMap["total"])
+	userSessionRepo.AssertExpectations(t)
+}
+
+func TestFraudDomainServiceGetDevices(t *testing.T) {
+	svc, _, _, _, _, _, deviceFingerprintRepo, _ := setupFraudDomainService()
+	ctx := context.Background()
+
+	devices := []*entity.DeviceFingerprint{
+		entity.NewDeviceFingerprint(1, "192.168.1.1_Mozilla/5.0", nil),
+	}
+	deviceFingerprintRepo.On("List", ctx, 0, 100).Return(devices, int64(1), nil)
+
+	result, err := svc.GetDevices(ctx)
+
+	assert.NoError(t, err)
+	resultMap, ok := result.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, devices, resultMap["devices"])
+	deviceFingerprintRepo.AssertExpectations(t)
+}
+
+func TestFraudDomainServiceTrustDevice(t *testing.T) {
+	svc, _, _, _, _, _, deviceFingerprintRepo, _ := setupFraudDomainService()
+	ctx := context.Background()
+
+	device := entity.NewDeviceFingerprint(1, "192.168.1.1_Mozilla/5.0", nil)
+	deviceFingerprintRepo.On("GetByFingerprint", ctx, device.Fingerprint).Return(device, nil)
+	deviceFingerprintRepo.On("Update", ctx, mock.MatchedBy(func(d *entity.DeviceFingerprint) bool {
+		return d.IsTrusted
+	})).Return(nil)
+
+	trusted, err := svc.TrustDevice(ctx, device.Fingerprint)
+
+	assert.NoError(t, err)
+	assert.Equal(t, device.UserID, trusted.UserID)
+	assert.True(t, trusted.IsTrusted)
+	deviceFingerprintRepo.AssertExpectations(t)
+}
+
+func TestFraudDomainServiceValidateUserSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *entity.UserSession
+		wantErr bool
+	}{
+		{
+			name:    "有効なセッション",
+			session: entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(time.Hour)),
+			wantErr: false,
+		},
+		{
+			name:    "期限切れのセッション",
+			session: entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(-time.Hour)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, _, userSessionRepo, _, _ := setupFraudDomainService()
+			ctx := context.Background()
+			userSessionRepo.On("GetBySessionID", ctx, "session-123").Return(tt.session, nil)
+
+			session, err := svc.ValidateUserSession(ctx, "session-123")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.session, session)
+			}
+			userSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func newRateLimitRuleWithID(id uint, resource string, maxRequests int) *entity.RateLimitRule {
+	rule := entity.NewRateLimitRule(resource, resource, maxRequests, 60)
+	rule.ID = id
+	return rule
+}
+
+func TestFraudDomainServiceResolveRateLimitRule(t *testing.T) {
+	userID := uint(42)
+	rules := []*entity.RateLimitRule{
+		newRateLimitRuleWithID(1, "*", 1000),
+		newRateLimitRuleWithID(2, "/api/v1/users/:id", 100),
+		newRateLimitRuleWithID(3, "PUT /api/v1/users/:id", 50),
+		newRateLimitRuleWithID(4, "role:admin", 500),
+		newRateLimitRuleWithID(5, "user:42", 10),
+		newRateLimitRuleWithID(6, "ip:198.51.100.0/24", 20),
+		newRateLimitRuleWithID(7, "ip:198.51.100.128/25", 30),
+	}
+
+	tests := []struct {
+		name    string
+		subject *entity.RateLimitSubject
+		wantID  uint
+	}{
+		{
+			name:    "ユーザー指定のルールが最優先される",
+			subject: &entity.RateLimitSubject{Method: "PUT", Route: "/api/v1/users/:id", IPAddress: "192.0.2.1", UserID: &userID, Roles: []string{"admin"}},
+			wantID:  5,
+		},
+		{
+			name:    "範囲指定のIPルールが範囲内のアドレスに適用される",
+			subject: &entity.RateLimitSubject{Method: "GET", Route: "/api/v1/users/:id", IPAddress: "198.51.100.7", Roles: []string{"admin"}},
+			wantID:  6,
+		},
+		{
+			name:    "より狭いIP範囲のルールが優先される",
+			subject: &entity.RateLimitSubject{Method: "GET", Route: "/api/v1/users/:id", IPAddress: "198.51.100.200"},
+			wantID:  7,
+		},
+		{
+			name:    "ロール指定のルールがエンドポイントより優先される",
+			subject: &entity.RateLimitSubject{Method: "PUT", Route: "/api/v1/users/:id", IPAddress: "192.0.2.1", Roles: []string{"admin"}},
+			wantID:  4,
+		},
+		{
+			name:    "メソッド付きのエンドポイントルールが優先される",
+			subject: &entity.RateLimitSubject{Method: "PUT", Route: "/api/v1/users/:id", IPAddress: "192.0.2.1"},
+			wantID:  3,
+		},
+		{
+			name:    "メソッドが異なる場合はルートのみのルール",
+			subject: &entity.RateLimitSubject{Method: "GET", Route: "/api/v1/users/:id", IPAddress: "192.0.2.1"},
+			wantID:  2,
+		},
+		{
+			name:    "該当するルールがない場合はグローバルルール",
+			subject: &entity.RateLimitSubject{Method: "GET", Route: "/api/v1/stats", IPAddress: "192.0.2.1"},
+			wantID:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+			rateLimitRuleRepo.On("GetActiveRules", mock.Anything).Return(rules, nil)
+
+			rule, err := svc.ResolveRateLimitRule(context.Background(), tt.subject)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, rule.ID)
+		})
+	}
+}
+
+func TestFraudDomainServiceResolveRateLimitRuleSpecificity(t *testing.T) {
+	rules := []*entity.RateLimitRule{
+		newRateLimitRuleWithID(4, "/api/v1/*", 1000),
+		newRateLimitRuleWithID(5, "/api/v1/admin/*", 100),
+		newRateLimitRuleWithID(6, "/api/v1/admin/users/:user_id", 10),
+	}
+
+	tests := []struct {
+		name   string
+		route  string
+		wantID uint
+	}{
+		{name: "長いプレフィックスのワイルドカードが優先される", route: "/api/v1/admin/health", wantID: 5},
+		{name: "完全一致のルートがワイルドカードより優先される", route: "/api/v1/admin/users/:user_id", wantID: 6},
+		{name: "より具体的なルールがなければ短いプレフィックス", route: "/api/v1/stats", wantID: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+			rateLimitRuleRepo.On("GetActiveRules", mock.Anything).Return(rules, nil)
+
+			rule, err := svc.ResolveRateLimitRule(context.Background(), &entity.RateLimitSubject{Method: "GET", Route: tt.route, IPAddress: "192.0.2.1"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, rule.ID)
+		})
+	}
+}
+
+func TestFraudDomainServiceResolveRateLimitRuleCache(t *testing.T) {
+	svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+	ctx := context.Background()
+	subject := &entity.RateLimitSubject{Method: "GET", Route: "/api/v1/stats", IPAddress: "192.0.2.1"}
+
+	rateLimitRuleRepo.On("GetActiveRules", ctx).Return([]*entity.RateLimitRule{}, nil).Once()
+	rateLimitRuleRepo.On("Create", ctx, mock.AnythingOfType("*entity.RateLimitRule")).Return(nil)
+
+	rule, err := svc.ResolveRateLimitRule(ctx, subject)
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+
+	rule, err = svc.ResolveRateLimitRule(ctx, subject)
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+
+	created, err := svc.CreateRateLimitRule(ctx, "GLOBAL:*", "*", "", 100, 60)
+	assert.NoError(t, err)
+
+	rateLimitRuleRepo.On("GetActiveRules", ctx).Return([]*entity.RateLimitRule{created}, nil).Once()
+
+	rule, err = svc.ResolveRateLimitRule(ctx, subject)
+	assert.NoError(t, err)
+	assert.Equal(t, created, rule)
+	rateLimitRuleRepo.AssertNumberOfCalls(t, "GetActiveRules", 2)
+}
+
+func TestFraudDomainServiceResolveRateLimitRuleError(t *testing.T) {
+	svc, _, _, _, rateLimitRuleRepo, _, _, _ := setupFraudDomainService()
+	rateLimitRuleRepo.On("GetActiveRules", mock.Anything).Return(nil, errors.New("database error"))
+
+	rule, err := svc.ResolveRateLimitRule(context.Background(), &entity.RateLimitSubject{Route: "/api/v1/stats"})
+
+	assert.Error(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestFraudDomainServiceRecordRateLimitBlock(t *testing.T) {
+	svc, _, _, _, _, _, _, rateLimitLogRepo := setupFraudDomainService()
+	ctx := context.Background()
+	userID := uint(42)
+	rule := newRateLimitRuleWithID(3, "/api/v1/auth/login", 5)
+	subject := &entity.RateLimitSubject{Method: "POST", Route: "/api/v1/auth/login", IPAddress: "192.0.2.1", UserID: &userID}
+	windowStart := time.Date(2025, 9, 17, 12, 0, 0, 0, time.UTC)
+
+	rateLimitLogRepo.On("Create", ctx, mock.MatchedBy(func(log *entity.RateLimitLog) bool {
+		return log.RuleID == 3 &&
+			log.IPAddress == "192.0.2.1" &&
+			log.UserID != nil && *log.UserID == userID &&
+			log.Requests == 6 &&
+			log.WindowStart.Equal(windowStart) &&
+			log.WindowEnd.Equal(windowStart.Add(time.Minute)) &&
+			log.Blocked
+	})).Return(nil)
+
+	err := svc.RecordRateLimitBlock(ctx, rule, subject, 6, windowStart)
+
+	assert.NoError(t, err)
+	rateLimitLogRepo.AssertExpectations(t)
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+const (
+	FraudRuleIPBlacklisted       = "ip_blacklisted"
+	FraudRuleFailedLoginsWarning = "failed_logins_warning"
+	FraudRuleFailedLogins        = "failed_logins"
+	FraudRuleUnknownDevice       = "unknown_device"
+	FraudRuleIPVelocity          = "ip_velocity"
+)
+
+type FraudSignal struct {
+	UserID    *uint
+	Email     string
+	IPAddress string
+	UserAgent string
+}
+
+type FraudRule interface {
+	Name() string
+
+	DefaultConfig() *entity.FraudRuleConfig
+
+	Evaluate(ctx context.Context, signal *FraudSignal, config *entity.FraudRuleConfig) (bool, string, error)
+}
+
+type blacklistedIPRule struct {
+	ipAccessList *IPAccessList
+}
+
+func NewBlacklistedIPRule(ipAccessList *IPAccessList) FraudRule {
+	return &blacklistedIPRule{ipAccessList: ipAccessList}
+}
+
+func (r *blacklistedIPRule) Name() string {
+	return FraudRuleIPBlacklisted
+}
+
+func (r *blacklistedIPRule) DefaultConfig() *entity.FraudRuleConfig {
+	return entity.NewFraudRuleConfig(FraudRuleIPBlacklisted, 0.8, 0, 0)
+}
+
+func (r *blacklistedIPRule) Evaluate(ctx context.Context, signal *FraudSignal, _ *entity.FraudRuleConfig) (bool, string, error) {
+	isBlacklisted, err := r.ipAccessList.IsBlocked(ctx, signal.IPAddress)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to check IP blacklist: %w", err)
+	}
+	if !isBlacklisted {
+		return false, "", nil
+	}
+
+	return true, "IP address is blacklisted", nil
+}
+
+type failedLoginRule struct {
+	name             string
+	reason           string
+	defaultWeight    float64
+	defaultThreshold int
+	loginAttemptRepo repository.LoginAttemptRepository
+}
+
+func NewFailedLoginWarningRule(loginAttemptRepo repository.LoginAttemptRepository) FraudRule {
+	return &failedLoginRule{
+		name:             FraudRuleFailedLoginsWarning,
+		reason:           "Some failed login attempts: %d",
+		defaultWeight:    0.3,
+		defaultThreshold: 3,
+		loginAttemptRepo: loginAttemptRepo,
+	}
+}
+
+func NewFailedLoginRule(loginAttemptRepo repository.LoginAttemptRepository) FraudRule {
+	return &failedLoginRule{
+		name:             FraudRuleFailedLogins,
+		reason:           "Multiple failed login attempts: %d",
+		defaultWeight:    0.3,
+		defaultThreshold: 5,
+		loginAttemptRepo: loginAttemptRepo,
+	}
+}
+
+func (r *failedLoginRule) Name() string {
+	return r.name
+}
+
+func (r *failedLoginRule) DefaultConfig() *entity.FraudRuleConfig {
+	return entity.NewFraudRuleConfig(r.name, r.defaultWeight, r.defaultThreshold, 900)
+}
+
+func (r *failedLoginRule) Evaluate(ctx context.Context, signal *FraudSignal, config *entity.FraudRuleConfig) (bool, string, error) {
+	failedAttempts, err := r.loginAttemptRepo.CountFailedAttempts(ctx, signal.Email, time.Now().Add(-config.Window()))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to count failed attempts: %w", err)
+	}
+	if failedAttempts < int64(config.Threshold) {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf(r.reason, failedAttempts), nil
+}
+
+type unknownDeviceRule struct {
+	deviceFingerprintRepo repository.DeviceFingerprintRepository
+}
+
+func NewUnknownDeviceRule(deviceFingerprintRepo repository.DeviceFingerprintRepository) FraudRule {
+	return &unknownDeviceRule{deviceFingerprintRepo: deviceFingerprintRepo}
+}
+
+func (r *unknownDeviceRule) Name() string {
+	return FraudRuleUnknownDevice
+}
+
+func (r *unknownDeviceRule) DefaultConfig() *entity.FraudRuleConfig {
+	return entity.NewFraudRuleConfig(FraudRuleUnknownDevice, 0.2, 0, 0)
+}
+
+func (r *unknownDeviceRule) Evaluate(ctx context.Context, signal *FraudSignal, _ *entity.FraudRuleConfig) (bool, string, error) {
+	if signal.UserID == nil {
+		return false, "", nil
+	}
+
+	fingerprint := fmt.Sprintf("%s_%s", signal.IPAddress, signal.UserAgent)
+	isTrusted, err := r.deviceFingerprintRepo.IsTrustedDevice(ctx, *signal.UserID, fingerprint)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to check device trust: %w", err)
+	}
+	if isTrusted {
+		return false, "", nil
+	}
+
+	return true, "Unknown device", nil
+}
+
+type ipVelocityRule struct {
+	loginAttemptRepo repository.LoginAttemptRepository
+}
+
+func NewIPVelocityRule(loginAttemptRepo repository.LoginAttemptRepository) FraudRule {
+	return &ipVelocityRule{loginAttemptRepo: loginAttemptRepo}
+}
+
+func (r *ipVelocityRule) Name() string {
+	return FraudRuleIPVelocity
+}
+
+func (r *ipVelocityRule) DefaultConfig() *entity.FraudRuleConfig {
+	return entity.NewFraudRuleConfig(FraudRuleIPVelocity, 0.4, 10, 900)
+}
+
+func (r *ipVelocityRule) Evaluate(ctx context.Context, signal *FraudSignal, config *entity.FraudRuleConfig) (bool, string, error) {
+	ipAttempts, err := r.loginAttemptRepo.GetByIP(ctx, signal.IPAddress, time.Now().Add(-config.Window()))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get IP attempts: %w", err)
+	}
+	if len(ipAttempts) < config.Threshold {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("High frequency requests from IP: %d", len(ipAttempts)), nil
+}
+
+const fraudRuleConfigCacheTTL = 30 * time.Second
+
+type FraudRuleEngine struct {
+	configRepo repository.FraudRuleConfigRepository
+	rules      []FraudRule
+
+	configsMu         sync.RWMutex
+	configs           map[string]*entity.FraudRuleConfig
+	configsLoadedAt   time.Time
+	configsGeneration uint64
+}
+
+func NewFraudRuleEngine(configRepo repository.FraudRuleConfigRepository, rules ...FraudRule) *FraudRuleEngine {
+	return &FraudRuleEngine{
+		configRepo: configRepo,
+		rules:      rules,
+	}
+}
+
+func (e *FraudRuleEngine) Register(rule FraudRule) {
+	e.configsMu.Lock()
+	defer e.configsMu.Unlock()
+
+	for i, registered := range e.rules {
+		if registered.Name() == rule.Name() {
+			e.rules[i] = rule
+			e.configs = nil
+			e.configsGeneration++
+			return
+		}
+	}
+	e.rules = append(e.rules, rule)
+	e.configs = nil
+	e.configsGeneration++
+}
+
+func (e *FraudRuleEngine) Evaluate(ctx context.Context, signal *FraudSignal) (*entity.FraudAnalysis, error) {
+	configs, err := e.loadConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var contributions []entity.FraudRuleContribution
+	for _, rule := range e.registeredRules() {
+		config := ruleConfig(configs, rule)
+		if !config.Enabled {
+			continue
+		}
+
+		matched, reason, err := rule.Evaluate(ctx, signal, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate fraud rule %s: %w", rule.Name(), err)
+		}
+
+		contribution := entity.FraudRuleContribution{
+			Rule:    rule.Name(),
+			Matched: matched,
+			Weight:  config.Weight,
+		}
+		if matched {
+			contribution.Score = config.Weight
+			contribution.Reason = reason
+		}
+		contributions = append(contributions, contribution)
+	}
+
+	return entity.NewFraudAnalysisFromContributions(contributions), nil
+}
+
+func (e *FraudRuleEngine) Configs(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	configs, err := e.loadConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := e.registeredRules()
+	result := make([]*entity.FraudRuleConfig, 0, len(rules))
+	for _, rule := range rules {
+		config := *ruleConfig(configs, rule)
+		result = append(result, &config)
+	}
+
+	return result, nil
+}
+
+func (e *FraudRuleEngine) UpdateConfig(ctx context.Context, name string, enabled *bool, weight *float64, threshold, windowSeconds *int) (*entity.FraudRuleConfig, error) {
+	configs, err := e.loadConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, ok := configs[name]
+	if !ok {
+		return nil, fmt.Errorf("fraud rule not found: %s", name)
+	}
+
+	config := *current
+	config.Update(enabled, weight, threshold, windowSeconds)
+	if err := e.configRepo.Save(ctx, &config); err != nil {
+		return nil, fmt.Errorf("failed to save fraud rule config: %w", err)
+	}
+
+	e.Invalidate()
+	return &config, nil
+}
+
+func (e *FraudRuleEngine) Invalidate() {
+	e.configsMu.Lock()
+	defer e.configsMu.Unlock()
+
+	e.configs = nil
+	e.configsGeneration++
+}
+
+func (e *FraudRuleEngine) registeredRules() []FraudRule {
+	e.configsMu.RLock()
+	defer e.configsMu.RUnlock()
+
+	return append([]FraudRule(nil), e.rules...)
+}
+
+func (e *FraudRuleEngine) loadConfigs(ctx context.Context) (map[string]*entity.FraudRuleConfig, error) {
+	e.configsMu.RLock()
+	configs := e.configs
+	fresh := configs != nil && time.Since(e.configsLoadedAt) < fraudRuleConfigCacheTTL
+	generation := e.configsGeneration
+	rules := append([]FraudRule(nil), e.rules...)
+	e.configsMu.RUnlock()
+
+	if fresh {
+		return configs, nil
+	}
+
+	stored, err := e.configRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fraud rule configs: %w", err)
+	}
+
+	storedByName := make(map[string]*entity.FraudRuleConfig, len(stored))
+	for _, config := range stored {
+		storedByName[config.Name] = config
+	}
+
+	configs = make(map[string]*entity.FraudRuleConfig, len(rules))
+	for _, rule := range rules {
+		if config, ok := storedByName[rule.Name()]; ok {
+			configs[rule.Name()] = config
+			continue
+		}
+		configs[rule.Name()] = rule.DefaultConfig()
+	}
+
+	e.configsMu.Lock()
+	if e.configsGeneration == generation {
+		e.configs = configs
+		e.configsLoadedAt = time.Now()
+	}
+	e.configsMu.Unlock()
+
+	return configs, nil
+}
+
+func ruleConfig(configs map[string]*entity.FraudRuleConfig, rule FraudRule) *entity.FraudRuleConfig {
+	if config, ok := configs[rule.Name()]; ok {
+		return config
+	}
+	return rule.DefaultConfig()
+}
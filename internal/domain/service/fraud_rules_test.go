@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fraudRuleEngineFixture struct {
+	engine           *service.FraudRuleEngine
+	configRepo       *MockFraudRuleConfigRepository
+	ipBlacklistRepo  *MockIPBlacklistRepository
+	ipAllowlistRepo  *MockIPAllowlistRepository
+	loginAttemptRepo *MockLoginAttemptRepository
+	deviceRepo       *MockDeviceFingerprintRepository
+}
+
+func setupFraudRuleEngine() *fraudRuleEngineFixture {
+	f := &fraudRuleEngineFixture{
+		configRepo:       &MockFraudRuleConfigRepository{},
+		ipBlacklistRepo:  &MockIPBlacklistRepository{},
+		ipAllowlistRepo:  &MockIPAllowlistRepository{},
+		loginAttemptRepo: &MockLoginAttemptRepository{},
+		deviceRepo:       &MockDeviceFingerprintRepository{},
+	}
+	f.engine = service.NewFraudRuleEngine(
+		f.configRepo,
+		service.NewBlacklistedIPRule(service.NewIPAccessList(f.ipBlacklistRepo, f.ipAllowlistRepo, nil)),
+		service.NewFailedLoginWarningRule(f.loginAttemptRepo),
+		service.NewFailedLoginRule(f.loginAttemptRepo),
+		service.NewUnknownDeviceRule(f.deviceRepo),
+		service.NewIPVelocityRule(f.loginAttemptRepo),
+	)
+	return f
+}
+
+func findContribution(contributions []entity.FraudRuleContribution, rule string) *entity.FraudRuleContribution {
+	for i := range contributions {
+		if contributions[i].Rule == rule {
+			return &contributions[i]
+		}
+	}
+	return nil
+}
+
+func TestFraudRuleEngineEvaluateDefaults(t *testing.T) {
+	userID := uint(1)
+	tests := []struct {
+		name           string
+		userID         *uint
+		blacklisted    bool
+		failedAttempts int64
+		trusted        bool
+		ipAttempts     int
+		expectedScore  float64
+		expectedRules  []string
+	}{
+		{
+			name:          "シグナルがなければスコアは0",
+			userID:        &userID,
+			trusted:       true,
+			expectedScore: 0,
+		},
+		{
+			name:           "失敗3回で警告ルールのみ一致",
+			failedAttempts: 3,
+			expectedScore:  0.3,
+			expectedRules:  []string{service.FraudRuleFailedLoginsWarning},
+		},
+		{
+			name:           "失敗5回で従来通り0.6",
+			failedAttempts: 5,
+			expectedScore:  0.6,
+			expectedRules:  []string{service.FraudRuleFailedLoginsWarning, service.FraudRuleFailedLogins},
+		},
+		{
+			name:          "未知のデバイスとIP多頻度",
+			userID:        &userID,
+			ipAttempts:    10,
+			expectedScore: 0.6,
+			expectedRules: []string{service.FraudRuleUnknownDevice, service.FraudRuleIPVelocity},
+		},
+		{
+			name:           "合計は1.0で頭打ち",
+			blacklisted:    true,
+			failedAttempts: 5,
+			expectedScore:  1.0,
+			expectedRules:  []string{service.FraudRuleIPBlacklisted, service.FraudRuleFailedLoginsWarning, service.FraudRuleFailedLogins},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupFraudRuleEngine()
+			ctx := context.Background()
+
+			f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{}, nil)
+			var blacklists []*entity.IPBlacklist
+			if tt.blacklisted {
+				blacklists = append(blacklists, entity.NewIPBlacklist("203.0.113.0/24", "botnet", nil))
+			}
+			f.ipBlacklistRepo.On("ListActive", ctx).Return(blacklists, nil)
+			f.ipAllowlistRepo.On("ListActive", ctx).Return([]*entity.IPAllowlist{}, nil)
+			f.loginAttemptRepo.On("CountFailedAttempts", ctx, "test@example.com", mock.Anything).Return(tt.failedAttempts, nil)
+			f.loginAttemptRepo.On("GetByIP", ctx, "203.0.113.10", mock.Anything).Return(make([]*entity.LoginAttempt, tt.ipAttempts), nil)
+			if tt.userID != nil {
+				f.deviceRepo.On("IsTrustedDevice", ctx, *tt.userID, "203.0.113.10_Mozilla/5.0").Return(tt.trusted, nil)
+			}
+
+			analysis, err := f.engine.Evaluate(ctx, &service.FraudSignal{
+				UserID:    tt.userID,
+				Email:     "test@example.com",
+				IPAddress: "203.0.113.10",
+				UserAgent: "Mozilla/5.0",
+			})
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expectedScore, analysis.RiskScore, 1e-9)
+			assert.Len(t, analysis.Contributions, 5)
+
+			var matched []string
+			for _, contribution := range analysis.Contributions {
+				if contribution.Matched {
+					matched = append(matched, contribution.Rule)
+				}
+			}
+			assert.Equal(t, tt.expectedRules, matched)
+			assert.Len(t, analysis.Factors, len(tt.expectedRules))
+		})
+	}
+}
+
+func TestFraudRuleEngineEvaluateWithStoredConfig(t *testing.T) {
+	f := setupFraudRuleEngine()
+	ctx := context.Background()
+
+	disabled := entity.NewFraudRuleConfig(service.FraudRuleIPBlacklisted, 0.8, 0, 0)
+	disabled.Enabled = false
+	stricter := entity.NewFraudRuleConfig(service.FraudRuleIPVelocity, 0.5, 2, 60)
+
+	f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{disabled, stricter}, nil)
+	f.loginAttemptRepo.On("CountFailedAttempts", ctx, "test@example.com", mock.Anything).Return(int64(0), nil)
+	f.loginAttemptRepo.On("GetByIP", ctx, "203.0.113.10", mock.Anything).Return(make([]*entity.LoginAttempt, 2), nil)
+
+	analysis, err := f.engine.Evaluate(ctx, &service.FraudSignal{Email: "test@example.com", IPAddress: "203.0.113.10"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, analysis.RiskScore)
+	assert.Nil(t, findContribution(analysis.Contributions, service.FraudRuleIPBlacklisted))
+
+	velocity := findContribution(analysis.Contributions, service.FraudRuleIPVelocity)
+	assert.NotNil(t, velocity)
+	assert.True(t, velocity.Matched)
+	assert.Equal(t, 0.5, velocity.Score)
+	assert.Equal(t, "High frequency requests from IP: 2", velocity.Reason)
+	f.ipBlacklistRepo.AssertNotCalled(t, "ListActive", mock.Anything)
+}
+
+func TestFraudRuleEngineEvaluateError(t *testing.T) {
+	f := setupFraudRuleEngine()
+	ctx := context.Background()
+
+	f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{}, nil)
+	f.ipBlacklistRepo.On("ListActive", ctx).Return(nil, errors.New("database error"))
+
+	analysis, err := f.engine.Evaluate(ctx, &service.FraudSignal{IPAddress: "203.0.113.10"})
+
+	assert.Nil(t, analysis)
+	assert.ErrorContains(t, err, "failed to evaluate fraud rule ip_blacklisted")
+}
+
+func TestFraudRuleEngineConfigsAreCached(t *testing.T) {
+	f := setupFraudRuleEngine()
+	ctx := context.Background()
+
+	f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{}, nil).Once()
+
+	first, err := f.engine.Configs(ctx)
+	assert.NoError(t, err)
+	second, err := f.engine.Configs(ctx)
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 5)
+	assert.Equal(t, first, second)
+	f.configRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestFraudRuleEngineUpdateConfig(t *testing.T) {
+	t.Run("設定を保存してキャッシュを破棄する", func(t *testing.T) {
+		f := setupFraudRuleEngine()
+		ctx := context.Background()
+		weight := 0.9
+
+		f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{}, nil).Twice()
+		f.configRepo.On("Save", ctx, mock.MatchedBy(func(config *entity.FraudRuleConfig) bool {
+			return config.Name == service.FraudRuleIPBlacklisted && config.Weight == weight
+		})).Return(nil)
+
+		config, err := f.engine.UpdateConfig(ctx, service.FraudRuleIPBlacklisted, nil, &weight, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, weight, config.Weight)
+		assert.True(t, config.Enabled)
+
+		_, err = f.engine.Configs(ctx)
+		assert.NoError(t, err)
+		f.configRepo.AssertExpectations(t)
+	})
+
+	t.Run("未登録のルールはエラー", func(t *testing.T) {
+		f := setupFraudRuleEngine()
+		ctx := context.Background()
+
+		f.configRepo.On("List", ctx).Return([]*entity.FraudRuleConfig{}, nil)
+
+		config, err := f.engine.UpdateConfig(ctx, "unknown", nil, nil, nil, nil)
+		assert.Nil(t, config)
+		assert.ErrorContains(t, err, "fraud rule not found")
+		f.configRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+const ipAccessListRefreshInterval = 30 * time.Second
+
+type ipRangeNode struct {
+	children [2]*ipRangeNode
+	terminal bool
+}
+
+type ipRangeTree struct {
+	v4 *ipRangeNode
+	v6 *ipRangeNode
+}
+
+func newIPRangeTree() *ipRangeTree {
+	return &ipRangeTree{v4: &ipRangeNode{}, v6: &ipRangeNode{}}
+}
+
+func (t *ipRangeTree) root(addr netip.Addr) *ipRangeNode {
+	if addr.Is4() {
+		return t.v4
+	}
+	return t.v6
+}
+
+func (t *ipRangeTree) Insert(prefix netip.Prefix) {
+	node := t.root(prefix.Addr())
+	bytes := prefix.Addr().AsSlice()
+	for i := 0; i < prefix.Bits(); i++ {
+		bit := bytes[i/8] >> (7 - i%8) & 1
+		if node.children[bit] == nil {
+			node.children[bit] = &ipRangeNode{}
+		}
+		node = node.children[bit]
+	}
+	node.terminal = true
+}
+
+func (t *ipRangeTree) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	node := t.root(addr)
+	bytes := addr.AsSlice()
+	for i := 0; node != nil; i++ {
+		if node.terminal {
+			return true
+		}
+		if i == addr.BitLen() {
+			return false
+		}
+		node = node.children[bytes[i/8]>>(7-i%8)&1]
+	}
+	return false
+}
+
+type IPListVersionSource interface {
+	IPListVersion(ctx context.Context) (int64, error)
+}
+
+type IPAccessList struct {
+	ipBlacklistRepo repository.IPBlacklistRepository
+	ipAllowlistRepo repository.IPAllowlistRepository
+	versions        IPListVersionSource
+
+	mu            sync.RWMutex
+	blocked       *ipRangeTree
+	allowed       *ipRangeTree
+	loadedAt      time.Time
+	loadedVersion int64
+	generation    uint64
+}
+
+func NewIPAccessList(ipBlacklistRepo repository.IPBlacklistRepository, ipAllowlistRepo repository.IPAllowlistRepository, versions IPListVersionSource) *IPAccessList {
+	return &IPAccessList{
+		ipBlacklistRepo: ipBlacklistRepo,
+		ipAllowlistRepo: ipAllowlistRepo,
+		versions:        versions,
+	}
+}
+
+func (l *IPAccessList) IsBlocked(ctx context.Context, ip string) (bool, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false, nil
+	}
+
+	blocked, allowed, err := l.trees(ctx)
+	if err != nil {
+		return false, err
+	}
+	if allowed.Contains(addr) {
+		return false, nil
+	}
+
+	return blocked.Contains(addr), nil
+}
+
+func (l *IPAccessList) Invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.blocked = nil
+	l.allowed = nil
+	l.generation++
+}
+
+func (l *IPAccessList) trees(ctx context.Context) (*ipRangeTree, *ipRangeTree, error) {
+	version, versionKnown := l.sharedVersion(ctx)
+
+	l.mu.RLock()
+	blocked, allowed := l.blocked, l.allowed
+	fresh := blocked != nil && time.Since(l.loadedAt) < ipAccessListRefreshInterval
+	if versionKnown && version != l.loadedVersion {
+		fresh = false
+	}
+	if !versionKnown {
+		version = l.loadedVersion
+	}
+	generation := l.generation
+	l.mu.RUnlock()
+
+	if fresh {
+		return blocked, allowed, nil
+	}
+
+	blacklists, err := l.ipBlacklistRepo.ListActive(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IP blacklist: %w", err)
+	}
+	allowlists, err := l.ipAllowlistRepo.ListActive(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IP allowlist: %w", err)
+	}
+
+	blocked = newIPRangeTree()
+	for _, blacklist := range blacklists {
+		if !blacklist.IsEffective() {
+			continue
+		}
+		if prefix, err := blacklist.Prefix(); err == nil {
+			blocked.Insert(prefix)
+		}
+	}
+
+	allowed = newIPRangeTree()
+	for _, allowlist := range allowlists {
+		if prefix, err := allowlist.Prefix(); err == nil {
+			allowed.Insert(prefix)
+		}
+	}
+
+	l.mu.Lock()
+	if l.generation == generation {
+		l.blocked = blocked
+		l.allowed = allowed
+		l.loadedAt = time.Now()
+		l.loadedVersion = version
+	}
+	l.mu.Unlock()
+
+	return blocked, allowed, nil
+}
+
+// sharedVersion reads the version that list writers bump in Redis, so a change
+// made on another replica is picked up before the refresh interval elapses.
+func (l *IPAccessList) sharedVersion(ctx context.Context) (int64, bool) {
+	if l.versions == nil {
+		return 0, false
+	}
+
+	version, err := l.versions.IPListVersion(ctx)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIPAccessListIsBlocked(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	blacklists := []*entity.IPBlacklist{
+		entity.NewIPBlacklist("203.0.113.0/24", "botnet", nil),
+		entity.NewIPBlacklist("198.51.100.7", "scanner", nil),
+		entity.NewIPBlacklist("2001:db8::/32", "ipv6 botnet", nil),
+		entity.NewIPBlacklist("192.0.2.0/24", "expired", &expired),
+	}
+	allowlists := []*entity.IPAllowlist{
+		entity.NewIPAllowlist("203.0.113.128/25", "office"),
+	}
+
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{name: "CIDR範囲内のIPv4はブロック", ip: "203.0.113.10", want: true},
+		{name: "単一IPの完全一致はブロック", ip: "198.51.100.7", want: true},
+		{name: "単一IPの隣接アドレスは許可", ip: "198.51.100.8", want: false},
+		{name: "IPv6のCIDR範囲内はブロック", ip: "2001:db8:1::1", want: true},
+		{name: "IPv4射影アドレスも判定できる", ip: "::ffff:203.0.113.10", want: true},
+		{name: "許可リストがブラックリストより優先", ip: "203.0.113.200", want: false},
+		{name: "期限切れのエントリは無視", ip: "192.0.2.1", want: false},
+		{name: "不正なIPはブロックしない", ip: "invalid", want: false},
+	}
+
+	ipBlacklistRepo := &MockIPBlacklistRepository{}
+	ipAllowlistRepo := &MockIPAllowlistRepository{}
+	ctx := context.Background()
+	ipBlacklistRepo.On("ListActive", ctx).Return(blacklists, nil).Once()
+	ipAllowlistRepo.On("ListActive", ctx).Return(allowlists, nil).Once()
+
+	accessList := service.NewIPAccessList(ipBlacklistRepo, ipAllowlistRepo, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked, err := accessList.IsBlocked(ctx, tt.ip)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, blocked)
+		})
+	}
+
+	ipBlacklistRepo.AssertExpectations(t)
+	ipAllowlistRepo.AssertExpectations(t)
+}
+
+func TestIPAccessListInvalidate(t *testing.T) {
+	ipBlacklistRepo := &MockIPBlacklistRepository{}
+	ipAllowlistRepo := &MockIPAllowlistRepository{}
+	ctx := context.Background()
+
+	ipBlacklistRepo.On("ListActive", ctx).Return([]*entity.IPBlacklist{}, nil).Once()
+	ipBlacklistRepo.On("ListActive", ctx).Return([]*entity.IPBlacklist{
+		entity.NewIPBlacklist("10.0.0.0/8", "internal abuse", nil),
+	}, nil).Once()
+	ipAllowlistRepo.On("ListActive", ctx).Return([]*entity.IPAllowlist{}, nil)
+
+	accessList := service.NewIPAccessList(ipBlacklistRepo, ipAllowlistRepo, nil)
+
+	blocked, err := accessList.IsBlocked(ctx, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	accessList.Invalidate()
+
+	blocked, err = accessList.IsBlocked(ctx, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	ipBlacklistRepo.AssertExpectations(t)
+}
+
+type sharedIPListVersion struct {
+	version int64
+}
+
+func (v *sharedIPListVersion) IPListVersion(_ context.Context) (int64, error) {
+	return v.version, nil
+}
+
+func TestIPAccessListReloadsOnSharedVersionChange(t *testing.T) {
+	ipBlacklistRepo := &MockIPBlacklistRepository{}
+	ipAllowlistRepo := &MockIPAllowlistRepository{}
+	ctx := context.Background()
+
+	ipBlacklistRepo.On("ListActive", ctx).Return([]*entity.IPBlacklist{}, nil).Once()
+	ipBlacklistRepo.On("ListActive", ctx).Return([]*entity.IPBlacklist{
+		entity.NewIPBlacklist("10.0.0.0/8", "internal abuse", nil),
+	}, nil).Once()
+	ipAllowlistRepo.On("ListActive", ctx).Return([]*entity.IPAllowlist{}, nil)
+
+	versions := &sharedIPListVersion{version: 1}
+	accessList := service.NewIPAccessList(ipBlacklistRepo, ipAllowlistRepo, versions)
+
+	blocked, err := accessList.IsBlocked(ctx, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	blocked, err = accessList.IsBlocked(ctx, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	versions.version++
+
+	blocked, err = accessList.IsBlocked(ctx, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	ipBlacklistRepo.AssertExpectations(t)
+}
+
+type recordingIPListCache struct {
+	blacklists []*entity.IPBlacklist
+	allowlists []*entity.IPAllowlist
+	replaced   int
+}
+
+func (c *recordingIPListCache) IPListVersion(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (c *recordingIPListCache) SyncBlacklistEntry(_ context.Context, blacklist *entity.IPBlacklist) {
+	c.blacklists = append(c.blacklists, blacklist)
+}
+
+func (c *recordingIPListCache) SyncAllowlistEntry(_ context.Context, allowlist *entity.IPAllowlist) {
+	c.allowlists = append(c.allowlists, allowlist)
+}
+
+func (c *recordingIPListCache) ReplaceIPLists(_ context.Context, blacklists []*entity.IPBlacklist, allowlists []*entity.IPAllowlist) error {
+	c.blacklists = blacklists
+	c.allowlists = allowlists
+	c.replaced++
+	return nil
+}
+
+func newFraudDomainServiceWithIPListCache(cache service.IPListCache) (*service.FraudDomainService, *MockSecurityEventRepository, *MockIPBlacklistRepository, *MockIPAllowlistRepository) {
+	securityEventRepo := &MockSecurityEventRepository{}
+	ipBlacklistRepo := &MockIPBlacklistRepository{}
+	ipAllowlistRepo := &MockIPAllowlistRepository{}
+
+	svc := service.NewFraudDomainService(
+		securityEventRepo,
+		ipBlacklistRepo,
+		ipAllowlistRepo,
+		&MockLoginAttemptRepository{},
+		&MockRateLimitRuleRepository{},
+		&MockRateLimitLogRepository{},
+		&MockUserSessionRepository{},
+		&MockDeviceFingerprintRepository{},
+		&MockFraudRuleConfigRepository{},
+		cache,
+	)
+
+	return svc, securityEventRepo, ipBlacklistRepo, ipAllowlistRepo
+}
+
+func TestFraudDomainServiceSyncsIPListCache(t *testing.T) {
+	t.Run("ブラックリスト追加時にキャッシュへ反映する", func(t *testing.T) {
+		cache := &recordingIPListCache{}
+		svc, securityEventRepo, ipBlacklistRepo, _ := newFraudDomainServiceWithIPListCache(cache)
+		ctx := context.Background()
+
+		ipBlacklistRepo.On("Create", ctx, mock.AnythingOfType("*entity.IPBlacklist")).Return(nil)
+		securityEventRepo.On("Create", ctx, mock.AnythingOfType("*entity.SecurityEvent")).Return(nil)
+
+		err := svc.AddIPToBlacklist(ctx, "203.0.113.0/24", "botnet", "127.0.0.1", "Admin-Panel")
+
+		assert.NoError(t, err)
+		assert.Len(t, cache.blacklists, 1)
+		assert.Equal(t, "203.0.113.0/24", cache.blacklists[0].IPAddress)
+	})
+
+	t.Run("許可リスト削除時は無効化したエントリを反映する", func(t *testing.T) {
+		cache := &recordingIPListCache{}
+		svc, securityEventRepo, _, ipAllowlistRepo := newFraudDomainServiceWithIPListCache(cache)
+		ctx := context.Background()
+
+		allowlist := entity.NewIPAllowlist("10.20.0.0/16", "office")
+		ipAllowlistRepo.On("GetByIP", ctx, "10.20.0.0/16").Return(allowlist, nil)
+		ipAllowlistRepo.On("Update", ctx, allowlist).Return(nil)
+		securityEventRepo.On("Create", ctx, mock.AnythingOfType("*entity.SecurityEvent")).Return(nil)
+
+		err := svc.RemoveIPFromAllowlist(ctx, "10.20.0.0/16", "127.0.0.1", "Admin-Panel")
+
+		assert.NoError(t, err)
+		assert.Len(t, cache.allowlists, 1)
+		assert.False(t, cache.allowlists[0].IsActive)
+	})
+
+	t.Run("定期同期でDBの内容に置き換える", func(t *testing.T) {
+		cache := &recordingIPListCache{}
+		svc, _, ipBlacklistRepo, ipAllowlistRepo := newFraudDomainServiceWithIPListCache(cache)
+		ctx := context.Background()
+
+		blacklists := []*entity.IPBlacklist{entity.NewIPBlacklist("198.51.100.7", "scanner", nil)}
+		allowlists := []*entity.IPAllowlist{entity.NewIPAllowlist("10.20.0.0/16", "office")}
+		ipBlacklistRepo.On("ListActive", ctx).Return(blacklists, nil)
+		ipAllowlistRepo.On("ListActive", ctx).Return(allowlists, nil)
+
+		err := svc.SyncIPListCache(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cache.replaced)
+		assert.Equal(t, blacklists, cache.blacklists)
+		assert.Equal(t, allowlists, cache.allowlists)
+	})
+}
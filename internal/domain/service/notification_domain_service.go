@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+var (
+	ErrNotificationNotFound            = errors.New("notification not found")
+	ErrNotificationStreamLimitExceeded = errors.New("too many notification streams")
+	ErrNotificationStreamUnavailable   = errors.New("notification streaming is unavailable")
+)
+
+type NotificationBroker interface {
+	Publish(ctx context.Context, event *entity.NotificationEvent) error
+	Subscribe(ctx context.Context, userID uint) (NotificationSubscription, error)
+	AcquireStream(ctx context.Context, userID uint, streamID string, limit int) (bool, error)
+	ReleaseStream(ctx context.Context, userID uint, streamID string) error
+}
+
+type NotificationSubscription interface {
+	Events() <-chan *entity.NotificationEvent
+	Close() error
+}
+
+type NotificationDomainService struct {
+	notificationRepo  repository.NotificationRepository
+	broker            NotificationBroker
+	maxStreamsPerUser int
+}
+
+func NewNotificationDomainService(notificationRepo repository.NotificationRepository, broker NotificationBroker, maxStreamsPerUser int) *NotificationDomainService {
+	return &NotificationDomainService{
+		notificationRepo:  notificationRepo,
+		broker:            broker,
+		maxStreamsPerUser: maxStreamsPerUser,
+	}
+}
+
+func (s *NotificationDomainService) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	return s.notificationRepo.Create(ctx, notification)
+}
+
+func (s *NotificationDomainService) GetNotifications(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error) {
+	return s.notificationRepo.GetByUserID(ctx, userID, offset, limit, unreadOnly)
+}
+
+func (s *NotificationDomainService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return s.notificationRepo.GetUnreadCount(ctx, userID)
+}
+
+func (s *NotificationDomainService) MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error) {
+	notification, err := s.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil || notification.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+	if notification.IsRead {
+		return notification, nil
+	}
+
+	if err := s.notificationRepo.MarkAsRead(ctx, userID, notificationID); err != nil {
+		return nil, err
+	}
+	notification.MarkAsRead()
+	return notification, nil
+}
+
+func (s *NotificationDomainService) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	return s.notificationRepo.MarkAllAsRead(ctx, userID)
+}
+
+func (s *NotificationDomainService) DeleteNotification(ctx context.Context, userID, notificationID uint) error {
+	deleted, err := s.notificationRepo.Delete(ctx, userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *NotificationDomainService) GetNotificationsAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error) {
+	return s.notificationRepo.GetByUserIDAfter(ctx, userID, afterID, limit)
+}
+
+func (s *NotificationDomainService) OpenStream(ctx context.Context, userID uint, streamID string) (NotificationSubscription, error) {
+	if s.broker == nil {
+		return nil, ErrNotificationStreamUnavailable
+	}
+
+	acquired, err := s.broker.AcquireStream(ctx, userID, streamID, s.maxStreamsPerUser)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrNotificationStreamLimitExceeded
+	}
+
+	subscription, err := s.broker.Subscribe(ctx, userID)
+	if err != nil {
+		_ = s.broker.ReleaseStream(ctx, userID, streamID)
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *NotificationDomainService) KeepStreamAlive(ctx context.Context, userID uint, streamID string) error {
+	if s.broker == nil {
+		return ErrNotificationStreamUnavailable
+	}
+
+	acquired, err := s.broker.AcquireStream(ctx, userID, streamID, s.maxStreamsPerUser)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrNotificationStreamLimitExceeded
+	}
+	return nil
+}
+
+func (s *NotificationDomainService) CloseStream(ctx context.Context, userID uint, streamID string, subscription NotificationSubscription) error {
+	if s.broker == nil {
+		return ErrNotificationStreamUnavailable
+	}
+
+	closeErr := subscription.Close()
+	if err := s.broker.ReleaseStream(ctx, userID, streamID); err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type NotificationDomainServiceInterface interface {
+	CreateNotification(ctx context.Context, notification *entity.Notification) error
+	GetNotifications(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error)
+	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
+	MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error)
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	DeleteNotification(ctx context.Context, userID, notificationID uint) error
+	GetNotificationsAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error)
+	OpenStream(ctx context.Context, userID uint, streamID string) (NotificationSubscription, error)
+	KeepStreamAlive(ctx context.Context, userID uint, streamID string) error
+	CloseStream(ctx context.Context, userID uint, streamID string, subscription NotificationSubscription) error
+}
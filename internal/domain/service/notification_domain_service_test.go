@@ -0,0 +1,292 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationDomainServiceMarkAsRead(t *testing.T) {
+	t.Run("自分の未読通知を既読にする", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		svc := service.NewNotificationDomainService(notificationRepo, nil, 0)
+		ctx := context.Background()
+		notification := entity.NewNotification(1, "system", "タイトル", "本文", nil)
+		notification.ID = 5
+
+		notificationRepo.On("GetByID", ctx, uint(5)).Return(notification, nil)
+		notificationRepo.On("MarkAsRead", ctx, uint(1), uint(5)).Return(nil)
+
+		result, err := svc.MarkAsRead(ctx, 1, 5)
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsRead)
+		assert.NotNil(t, result.ReadAt)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("既読の通知は更新しない", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		svc := service.NewNotificationDomainService(notificationRepo, nil, 0)
+		ctx := context.Background()
+		notification := entity.NewNotification(1, "system", "タイトル", "本文", nil)
+		notification.MarkAsRead()
+
+		notificationRepo.On("GetByID", ctx, uint(5)).Return(notification, nil)
+
+		_, err := svc.MarkAsRead(ctx, 1, 5)
+
+		assert.NoError(t, err)
+		notificationRepo.AssertNotCalled(t, "MarkAsRead", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("他のユーザーの通知は見つからない扱い", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		svc := service.NewNotificationDomainService(notificationRepo, nil, 0)
+		ctx := context.Background()
+
+		notificationRepo.On("GetByID", ctx, uint(5)).Return(entity.NewNotification(2, "system", "タイトル", "本文", nil), nil)
+
+		_, err := svc.MarkAsRead(ctx, 1, 5)
+
+		assert.ErrorIs(t, err, service.ErrNotificationNotFound)
+		notificationRepo.AssertNotCalled(t, "MarkAsRead", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない通知", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		svc := service.NewNotificationDomainService(notificationRepo, nil, 0)
+		ctx := context.Background()
+
+		notificationRepo.On("GetByID", ctx, uint(5)).Return(nil, errors.New("record not found"))
+
+		_, err := svc.MarkAsRead(ctx, 1, 5)
+
+		assert.ErrorIs(t, err, service.ErrNotificationNotFound)
+	})
+}
+
+func TestNotificationDomainServiceDeleteNotification(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted bool
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "自分の通知を削除",
+			deleted: true,
+		},
+		{
+			name:    "対象の通知がない",
+			deleted: false,
+			wantErr: service.ErrNotificationNotFound,
+		},
+		{
+			name:    "削除に失敗",
+			repoErr: errors.New("database error"),
+			wantErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationRepo := &MockNotificationRepository{}
+			svc := service.NewNotificationDomainService(notificationRepo, nil, 0)
+			ctx := context.Background()
+
+			notificationRepo.On("Delete", ctx, uint(1), uint(5)).Return(tt.deleted, tt.repoErr)
+
+			err := svc.DeleteNotification(ctx, 1, 5)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type MockNotificationBroker struct {
+	mock.Mock
+}
+
+func (m *MockNotificationBroker) Publish(ctx context.Context, event *entity.NotificationEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockNotificationBroker) Subscribe(ctx context.Context, userID uint) (service.NotificationSubscription, error) {
+	args := m.Called(ctx, userID)
+	if subscription, ok := args.Get(0).(service.NotificationSubscription); ok {
+		return subscription, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationBroker) AcquireStream(ctx context.Context, userID uint, streamID string, limit int) (bool, error) {
+	args := m.Called(ctx, userID, streamID, limit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationBroker) ReleaseStream(ctx context.Context, userID uint, streamID string) error {
+	args := m.Called(ctx, userID, streamID)
+	return args.Error(0)
+}
+
+type stubNotificationSubscription struct {
+	events chan *entity.NotificationEvent
+	closed bool
+}
+
+func (s *stubNotificationSubscription) Events() <-chan *entity.NotificationEvent {
+	return s.events
+}
+
+func (s *stubNotificationSubscription) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestNotificationDomainServiceOpenStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockNotificationBroker, service.NotificationSubscription)
+		expectedErr error
+	}{
+		{
+			name: "ストリームを開始",
+			setupMock: func(broker *MockNotificationBroker, subscription service.NotificationSubscription) {
+				broker.On("AcquireStream", mock.Anything, uint(1), "stream-1", 3).Return(true, nil)
+				broker.On("Subscribe", mock.Anything, uint(1)).Return(subscription, nil)
+			},
+		},
+		{
+			name: "同時接続数の上限",
+			setupMock: func(broker *MockNotificationBroker, _ service.NotificationSubscription) {
+				broker.On("AcquireStream", mock.Anything, uint(1), "stream-1", 3).Return(false, nil)
+			},
+			expectedErr: service.ErrNotificationStreamLimitExceeded,
+		},
+		{
+			name: "購読に失敗した場合は枠を解放する",
+			setupMock: func(broker *MockNotificationBroker, _ service.NotificationSubscription) {
+				broker.On("AcquireStream", mock.Anything, uint(1), "stream-1", 3).Return(true, nil)
+				broker.On("Subscribe", mock.Anything, uint(1)).Return(nil, errors.New("redis unavailable"))
+				broker.On("ReleaseStream", mock.Anything, uint(1), "stream-1").Return(nil)
+			},
+			expectedErr: errors.New("redis unavailable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &MockNotificationBroker{}
+			subscription := &stubNotificationSubscription{events: make(chan *entity.NotificationEvent)}
+			tt.setupMock(broker, subscription)
+			svc := service.NewNotificationDomainService(&MockNotificationRepository{}, broker, 3)
+
+			result, err := svc.OpenStream(context.Background(), 1, "stream-1")
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, subscription, result)
+			}
+			broker.AssertExpectations(t)
+		})
+	}
+
+	t.Run("ブローカー未設定", func(t *testing.T) {
+		svc := service.NewNotificationDomainService(&MockNotificationRepository{}, nil, 3)
+
+		_, err := svc.OpenStream(context.Background(), 1, "stream-1")
+
+		assert.ErrorIs(t, err, service.ErrNotificationStreamUnavailable)
+	})
+}
+
+func TestNotificationDomainServiceCloseStream(t *testing.T) {
+	broker := &MockNotificationBroker{}
+	subscription := &stubNotificationSubscription{events: make(chan *entity.NotificationEvent)}
+	broker.On("ReleaseStream", mock.Anything, uint(1), "stream-1").Return(nil)
+	svc := service.NewNotificationDomainService(&MockNotificationRepository{}, broker, 3)
+
+	err := svc.CloseStream(context.Background(), 1, "stream-1", subscription)
+
+	assert.NoError(t, err)
+	assert.True(t, subscription.closed)
+	broker.AssertExpectations(t)
+}
+
+func TestPublishingNotificationRepository(t *testing.T) {
+	t.Run("作成時に通知と未読数を配信する", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		broker := &MockNotificationBroker{}
+		repo := service.NewPublishingNotificationRepository(notificationRepo, broker)
+		ctx := context.Background()
+		notification := entity.NewNotification(1, "system", "タイトル", "本文", nil)
+
+		notificationRepo.On("Create", ctx, notification).Return(nil)
+		notificationRepo.On("GetUnreadCount", ctx, uint(1)).Return(int64(2), nil)
+		broker.On("Publish", ctx, entity.NewNotificationCreatedEvent(notification, 2)).Return(nil)
+
+		assert.NoError(t, repo.Create(ctx, notification))
+		notificationRepo.AssertExpectations(t)
+		broker.AssertExpectations(t)
+	})
+
+	t.Run("配信に失敗しても作成は成功する", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		broker := &MockNotificationBroker{}
+		repo := service.NewPublishingNotificationRepository(notificationRepo, broker)
+		ctx := context.Background()
+		notification := entity.NewNotification(1, "system", "タイトル", "本文", nil)
+
+		notificationRepo.On("Create", ctx, notification).Return(nil)
+		notificationRepo.On("GetUnreadCount", ctx, uint(1)).Return(int64(1), nil)
+		broker.On("Publish", ctx, mock.Anything).Return(errors.New("redis unavailable"))
+
+		assert.NoError(t, repo.Create(ctx, notification))
+	})
+
+	t.Run("既読化で未読数を配信する", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		broker := &MockNotificationBroker{}
+		repo := service.NewPublishingNotificationRepository(notificationRepo, broker)
+		ctx := context.Background()
+
+		notificationRepo.On("MarkAllAsRead", ctx, uint(1)).Return(int64(3), nil)
+		notificationRepo.On("GetUnreadCount", ctx, uint(1)).Return(int64(0), nil)
+		broker.On("Publish", ctx, entity.NewNotificationUnreadCountEvent(1, 0)).Return(nil)
+
+		updated, err := repo.MarkAllAsRead(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), updated)
+		broker.AssertExpectations(t)
+	})
+
+	t.Run("削除対象がなければ配信しない", func(t *testing.T) {
+		notificationRepo := &MockNotificationRepository{}
+		broker := &MockNotificationBroker{}
+		repo := service.NewPublishingNotificationRepository(notificationRepo, broker)
+		ctx := context.Background()
+
+		notificationRepo.On("Delete", ctx, uint(1), uint(5)).Return(false, nil)
+
+		deleted, err := repo.Delete(ctx, 1, 5)
+
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		broker.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+type publishingNotificationRepository struct {
+	repository.NotificationRepository
+	broker NotificationBroker
+}
+
+func NewPublishingNotificationRepository(notificationRepo repository.NotificationRepository, broker NotificationBroker) repository.NotificationRepository {
+	if broker == nil {
+		return notificationRepo
+	}
+	return &publishingNotificationRepository{
+		NotificationRepository: notificationRepo,
+		broker:                 broker,
+	}
+}
+
+func (r *publishingNotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	if err := r.NotificationRepository.Create(ctx, notification); err != nil {
+		return err
+	}
+
+	if unreadCount, err := r.NotificationRepository.GetUnreadCount(ctx, notification.UserID); err == nil {
+		_ = r.broker.Publish(ctx, entity.NewNotificationCreatedEvent(notification, unreadCount))
+	}
+	return nil
+}
+
+func (r *publishingNotificationRepository) Delete(ctx context.Context, userID, notificationID uint) (bool, error) {
+	deleted, err := r.NotificationRepository.Delete(ctx, userID, notificationID)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+
+	r.publishUnreadCount(ctx, userID)
+	return true, nil
+}
+
+func (r *publishingNotificationRepository) MarkAsRead(ctx context.Context, userID, notificationID uint) error {
+	if err := r.NotificationRepository.MarkAsRead(ctx, userID, notificationID); err != nil {
+		return err
+	}
+
+	r.publishUnreadCount(ctx, userID)
+	return nil
+}
+
+func (r *publishingNotificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	updated, err := r.NotificationRepository.MarkAllAsRead(ctx, userID)
+	if err != nil || updated == 0 {
+		return updated, err
+	}
+
+	r.publishUnreadCount(ctx, userID)
+	return updated, nil
+}
+
+func (r *publishingNotificationRepository) publishUnreadCount(ctx context.Context, userID uint) {
+	unreadCount, err := r.NotificationRepository.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return
+	}
+	_ = r.broker.Publish(ctx, entity.NewNotificationUnreadCountEvent(userID, unreadCount))
+}
@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// OAuthTokenReuseError reports a redeemed authorization code or rotated
+// refresh token presented again. Every refresh token issued from the same
+// grant has been revoked by the time it is returned.
+type OAuthTokenReuseError struct {
+	UserID   uint
+	ClientID string
+}
+
+func (e *OAuthTokenReuseError) Error() string {
+	return "oauth grant reuse detected"
+}
+
+func (e *OAuthTokenReuseError) Is(target error) bool {
+	return target == entity.ErrOAuthInvalidGrant
+}
+
+type OAuthDomainService struct {
+	clientRepo        repository.OAuthClientRepository
+	codeRepo          repository.OAuthAuthorizationCodeRepository
+	refreshTokenRepo  repository.OAuthRefreshTokenRepository
+	consentRepo       repository.OAuthConsentRepository
+	authDomainService AuthDomainServiceInterface
+	cacheService      CacheService
+}
+
+func NewOAuthDomainService(
+	clientRepo repository.OAuthClientRepository,
+	codeRepo repository.OAuthAuthorizationCodeRepository,
+	refreshTokenRepo repository.OAuthRefreshTokenRepository,
+	consentRepo repository.OAuthConsentRepository,
+	authDomainService AuthDomainServiceInterface,
+	cacheService CacheService,
+) *OAuthDomainService {
+	return &OAuthDomainService{
+		clientRepo:        clientRepo,
+		codeRepo:          codeRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		consentRepo:       consentRepo,
+		authDomainService: authDomainService,
+		cacheService:      cacheService,
+	}
+}
+
+func (s *OAuthDomainService) RegisterClient(ctx context.Context, name string, redirectURIs, grantTypes, scopes []string, confidential bool, createdBy uint) (*entity.OAuthClient, string, error) {
+	client, secret, err := entity.NewOAuthClient(name, redirectURIs, grantTypes, scopes, confidential, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.clientRepo.Create(ctx, client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+func (s *OAuthDomainService) ListClients(ctx context.Context) ([]*entity.OAuthClient, error) {
+	return s.clientRepo.List(ctx)
+}
+
+func (s *OAuthDomainService) DeleteClient(ctx context.Context, clientID string) error {
+	if err := s.clientRepo.Delete(ctx, clientID); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByClient(ctx, clientID)
+}
+
+func (s *OAuthDomainService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error) {
+	if clientID == "" {
+		return nil, entity.ErrOAuthInvalidClient
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, entity.ErrOAuthClientNotFound) {
+			return nil, entity.ErrOAuthInvalidClient
+		}
+		return nil, err
+	}
+
+	if !client.VerifySecret(clientSecret) {
+		return nil, entity.ErrOAuthInvalidClient
+	}
+	return client, nil
+}
+
+// PrepareAuthorization validates an authorization request and reports whether
+// the user has already consented to every requested scope. ErrOAuthInvalidClient
+// and ErrOAuthInvalidRedirectURI must not be sent back to the redirect URI.
+func (s *OAuthDomainService) PrepareAuthorization(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (*entity.OAuthClient, []string, bool, error) {
+	client, err := s.clientRepo.GetByClientID(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, entity.ErrOAuthClientNotFound) {
+			return nil, nil, false, entity.ErrOAuthInvalidClient
+		}
+		return nil, nil, false, err
+	}
+
+	if req.RedirectURI == "" || !client.HasRedirectURI(req.RedirectURI) {
+		return nil, nil, false, entity.ErrOAuthInvalidRedirectURI
+	}
+
+	if req.ResponseType != entity.OAuthResponseTypeCode {
+		return client, nil, false, entity.ErrOAuthUnsupportedResponseType
+	}
+	if !client.AllowsGrantType(entity.OAuthGrantTypeAuthorizationCode) {
+		return client, nil, false, entity.ErrOAuthUnauthorizedClient
+	}
+	if req.CodeChallengeMethod != entity.OAuthCodeChallengeMethodS256 || !entity.IsValidCodeChallenge(req.CodeChallenge) {
+		return client, nil, false, fmt.Errorf("%w: PKCE with S256 is required", entity.ErrOAuthInvalidRequest)
+	}
+
+	scopes, err := client.ResolveScopes(req.Scope)
+	if err != nil {
+		return client, nil, false, err
+	}
+
+	consent, err := s.consentRepo.Get(ctx, userID, client.ClientID)
+	if err != nil {
+		if errors.Is(err, entity.ErrOAuthConsentNotFound) {
+			return client, scopes, false, nil
+		}
+		return client, nil, false, err
+	}
+	return client, scopes, consent.Covers(scopes), nil
+}
+
+// Authorize records the user's consent and returns a single-use
+// authorization code bound to the PKCE challenge.
+func (s *OAuthDomainService) Authorize(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (string, []string, error) {
+	client, scopes, _, err := s.PrepareAuthorization(ctx, userID, req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	consent, err := s.consentRepo.Get(ctx, userID, client.ClientID)
+	switch {
+	case errors.Is(err, entity.ErrOAuthConsentNotFound):
+		consent = entity.NewOAuthConsent(userID, client.ClientID, scopes)
+	case err != nil:
+		return "", nil, err
+	default:
+		consent.Grant(scopes)
+	}
+	if err := s.consentRepo.Upsert(ctx, consent); err != nil {
+		return "", nil, err
+	}
+
+	code, plaintext, err := entity.NewOAuthAuthorizationCode(client.ClientID, userID, req.RedirectURI, scopes, req.CodeChallenge, req.CodeChallengeMethod)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := s.codeRepo.Create(ctx, code); err != nil {
+		return "", nil, err
+	}
+	return plaintext, scopes, nil
+}
+
+func (s *OAuthDomainService) ExchangeAuthorizationCode(ctx context.Context, client *entity.OAuthClient, code, redirectURI, codeVerifier string) (*entity.OAuthTokenSet, error) {
+	if !client.AllowsGrantType(entity.OAuthGrantTypeAuthorizationCode) {
+		return nil, entity.ErrOAuthUnauthorizedClient
+	}
+	if code == "" || codeVerifier == "" {
+		return nil, fmt.Errorf("%w: code and code_verifier are required", entity.ErrOAuthInvalidRequest)
+	}
+
+	authCode, err := s.codeRepo.GetByCodeHash(ctx, entity.HashOAuthToken(code))
+	if err != nil || authCode.ClientID != client.ClientID {
+		return nil, entity.ErrOAuthInvalidGrant
+	}
+
+	// RFC 6749 section 4.1.2: a code used twice revokes what it issued.
+	if authCode.IsUsed() {
+		return nil, s.revokeGrant(ctx, authCode.ClientID, authCode.UserID)
+	}
+	if authCode.IsExpired() || authCode.RedirectURI != redirectURI || !authCode.VerifyCodeVerifier(codeVerifier) {
+		return nil, entity.ErrOAuthInvalidGrant
+	}
+
+	used, err := s.codeRepo.MarkUsed(ctx, authCode.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, s.revokeGrant(ctx, authCode.ClientID, authCode.UserID)
+	}
+
+	return s.issueTokens(ctx, client, authCode.UserID, authCode.Scopes, authCode.Scopes, uuid.New().String())
+}
+
+func (s *OAuthDomainService) ClientCredentials(ctx context.Context, client *entity.OAuthClient, scope string) (*entity.OAuthTokenSet, error) {
+	if !client.Confidential || !client.AllowsGrantType(entity.OAuthGrantTypeClientCredentials) {
+		return nil, entity.ErrOAuthUnauthorizedClient
+	}
+
+	scopes, err := client.ResolveScopes(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.authDomainService.GenerateOAuthAccessToken(ctx, 0, client.ClientID, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.OAuthTokenSet{
+		AccessToken: accessToken,
+		TokenType:   entity.OAuthTokenTypeBearer,
+		ExpiresIn:   int(OAuthAccessTokenTTL.Seconds()),
+		Scopes:      scopes,
+	}, nil
+}
+
+func (s *OAuthDomainService) RefreshAccessToken(ctx context.Context, client *entity.OAuthClient, refreshToken, scope string) (*entity.OAuthTokenSet, error) {
+	if !client.AllowsGrantType(entity.OAuthGrantTypeRefreshToken) {
+		return nil, entity.ErrOAuthUnauthorizedClient
+	}
+	if refreshToken == "" {
+		return nil, fmt.Errorf("%w: refresh_token is required", entity.ErrOAuthInvalidRequest)
+	}
+
+	token, err := s.refreshTokenRepo.GetByTokenHash(ctx, entity.HashOAuthToken(refreshToken))
+	if err != nil || token.ClientID != client.ClientID {
+		return nil, entity.ErrOAuthInvalidGrant
+	}
+
+	if token.IsRotated() {
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke refresh token family: %w", err)
+		}
+		return nil, &OAuthTokenReuseError{UserID: token.UserID, ClientID: token.ClientID}
+	}
+	if !token.IsValid() {
+		return nil, entity.ErrOAuthInvalidGrant
+	}
+
+	current, err := s.isCurrentTokenVersion(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !current {
+		return nil, entity.ErrOAuthInvalidGrant
+	}
+
+	// RFC 6749 section 6: the scope may only be narrowed, and the narrowing
+	// applies to the new access token, not to the grant itself.
+	scopes := token.Scopes
+	if requested := entity.ParseOAuthScope(scope); len(requested) > 0 {
+		for _, value := range requested {
+			if !slices.Contains(token.Scopes, value) {
+				return nil, fmt.Errorf("%w: %q", entity.ErrOAuthInvalidScope, value)
+			}
+		}
+		scopes = requested
+	}
+
+	rotated, err := s.refreshTokenRepo.MarkRotated(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke refresh token family: %w", err)
+		}
+		return nil, &OAuthTokenReuseError{UserID: token.UserID, ClientID: token.ClientID}
+	}
+
+	return s.issueTokens(ctx, client, token.UserID, scopes, token.Scopes, token.FamilyID)
+}
+
+func (s *OAuthDomainService) Introspect(ctx context.Context, token, tokenTypeHint string) (*entity.OAuthTokenInfo, error) {
+	inactive := &entity.OAuthTokenInfo{Active: false}
+	if token == "" {
+		return inactive, nil
+	}
+
+	lookups := []func(context.Context, string) (*entity.OAuthTokenInfo, error){s.introspectAccessToken, s.introspectRefreshToken}
+	if tokenTypeHint == entity.OAuthTokenTypeRefreshToken {
+		slices.Reverse(lookups)
+	}
+
+	for _, lookup := range lookups {
+		info, err := lookup(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			return info, nil
+		}
+	}
+	return inactive, nil
+}
+
+// Revoke follows RFC 7009: tokens that are unknown or belong to another
+// client are ignored so the response does not reveal whether they exist.
+func (s *OAuthDomainService) Revoke(ctx context.Context, client *entity.OAuthClient, token, tokenTypeHint string) error {
+	if token == "" {
+		return fmt.Errorf("%w: token is required", entity.ErrOAuthInvalidRequest)
+	}
+
+	revokers := []func(context.Context, *entity.OAuthClient, string) (bool, error){s.revokeAccessToken, s.revokeRefreshToken}
+	if tokenTypeHint == entity.OAuthTokenTypeRefreshToken {
+		slices.Reverse(revokers)
+	}
+
+	for _, revoke := range revokers {
+		revoked, err := revoke(ctx, client, token)
+		if err != nil || revoked {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *OAuthDomainService) ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	return s.consentRepo.ListByUser(ctx, userID)
+}
+
+func (s *OAuthDomainService) RevokeConsent(ctx context.Context, userID uint, clientID string) error {
+	if err := s.consentRepo.Delete(ctx, userID, clientID); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByClientAndUser(ctx, clientID, userID)
+}
+
+func (s *OAuthDomainService) issueTokens(ctx context.Context, client *entity.OAuthClient, userID uint, scopes, grantedScopes []string, familyID string) (*entity.OAuthTokenSet, error) {
+	accessToken, err := s.authDomainService.GenerateOAuthAccessToken(ctx, userID, client.ClientID, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSet := &entity.OAuthTokenSet{
+		AccessToken: accessToken,
+		TokenType:   entity.OAuthTokenTypeBearer,
+		ExpiresIn:   int(OAuthAccessTokenTTL.Seconds()),
+		Scopes:      scopes,
+	}
+
+	if !client.AllowsGrantType(entity.OAuthGrantTypeRefreshToken) {
+		return tokenSet, nil
+	}
+
+	tokenVersion, err := s.tokenVersion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, plaintext, err := entity.NewOAuthRefreshToken(client.ClientID, userID, grantedScopes, familyID, tokenVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+
+	tokenSet.RefreshToken = plaintext
+	return tokenSet, nil
+}
+
+func (s *OAuthDomainService) revokeGrant(ctx context.Context, clientID string, userID uint) error {
+	if err := s.refreshTokenRepo.RevokeByClientAndUser(ctx, clientID, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return &OAuthTokenReuseError{UserID: userID, ClientID: clientID}
+}
+
+func (s *OAuthDomainService) introspectAccessToken(ctx context.Context, token string) (*entity.OAuthTokenInfo, error) {
+	claims, err := s.authDomainService.ValidateOAuthAccessToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenRevoked) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if _, err := s.clientRepo.GetByClientID(ctx, claims.ClientID); err != nil {
+		if errors.Is(err, entity.ErrOAuthClientNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if claims.UserID != 0 {
+		if _, err := s.consentRepo.Get(ctx, claims.UserID, claims.ClientID); err != nil {
+			if errors.Is(err, entity.ErrOAuthConsentNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	info := &entity.OAuthTokenInfo{
+		Active:    true,
+		TokenType: entity.OAuthTokenTypeAccessToken,
+		ClientID:  claims.ClientID,
+		UserID:    claims.UserID,
+		Subject:   claims.Subject,
+		Scopes:    entity.ParseOAuthScope(claims.Scope),
+	}
+	if claims.IssuedAt != nil {
+		info.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		info.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return info, nil
+}
+
+func (s *OAuthDomainService) introspectRefreshToken(ctx context.Context, token string) (*entity.OAuthTokenInfo, error) {
+	refreshToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, entity.HashOAuthToken(token))
+	if err != nil || !refreshToken.IsValid() {
+		return nil, nil
+	}
+
+	current, err := s.isCurrentTokenVersion(ctx, refreshToken)
+	if err != nil || !current {
+		return nil, err
+	}
+
+	return &entity.OAuthTokenInfo{
+		Active:    true,
+		TokenType: entity.OAuthTokenTypeRefreshToken,
+		ClientID:  refreshToken.ClientID,
+		UserID:    refreshToken.UserID,
+		Subject:   fmt.Sprintf("%d", refreshToken.UserID),
+		Scopes:    refreshToken.Scopes,
+		IssuedAt:  refreshToken.CreatedAt,
+		ExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
+
+func (s *OAuthDomainService) revokeAccessToken(ctx context.Context, client *entity.OAuthClient, token string) (bool, error) {
+	claims, err := s.authDomainService.ValidateOAuthAccessToken(ctx, token)
+	if err != nil || claims.ClientID != client.ClientID {
+		return false, nil
+	}
+
+	if s.cacheService != nil && claims.ExpiresAt != nil {
+		if ttl := time.Until(claims.ExpiresAt.Time); ttl > 0 {
+			if err := s.cacheService.BlacklistToken(ctx, token, ttl); err != nil {
+				return false, fmt.Errorf("failed to blacklist token: %w", err)
+			}
+		}
+	}
+	return true, nil
+}
+
+func (s *OAuthDomainService) revokeRefreshToken(ctx context.Context, client *entity.OAuthClient, token string) (bool, error) {
+	refreshToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, entity.HashOAuthToken(token))
+	if err != nil || refreshToken.ClientID != client.ClientID {
+		return false, nil
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, refreshToken.FamilyID); err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return true, nil
+}
+
+func (s *OAuthDomainService) isCurrentTokenVersion(ctx context.Context, token *entity.OAuthRefreshToken) (bool, error) {
+	version, err := s.tokenVersion(ctx, token.UserID)
+	if err != nil {
+		return false, err
+	}
+	return token.TokenVersion >= version, nil
+}
+
+func (s *OAuthDomainService) tokenVersion(ctx context.Context, userID uint) (int64, error) {
+	if s.cacheService == nil || userID == 0 {
+		return 0, nil
+	}
+
+	version, err := s.cacheService.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token version: %w", err)
+	}
+	return version, nil
+}
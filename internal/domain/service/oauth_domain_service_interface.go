@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type OAuthDomainServiceInterface interface {
+	RegisterClient(ctx context.Context, name string, redirectURIs, grantTypes, scopes []string, confidential bool, createdBy uint) (*entity.OAuthClient, string, error)
+	ListClients(ctx context.Context) ([]*entity.OAuthClient, error)
+	DeleteClient(ctx context.Context, clientID string) error
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error)
+	PrepareAuthorization(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (*entity.OAuthClient, []string, bool, error)
+	Authorize(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (string, []string, error)
+	ExchangeAuthorizationCode(ctx context.Context, client *entity.OAuthClient, code, redirectURI, codeVerifier string) (*entity.OAuthTokenSet, error)
+	ClientCredentials(ctx context.Context, client *entity.OAuthClient, scope string) (*entity.OAuthTokenSet, error)
+	RefreshAccessToken(ctx context.Context, client *entity.OAuthClient, refreshToken, scope string) (*entity.OAuthTokenSet, error)
+	Introspect(ctx context.Context, token, tokenTypeHint string) (*entity.OAuthTokenInfo, error)
+	Revoke(ctx context.Context, client *entity.OAuthClient, token, tokenTypeHint string) error
+	ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error)
+	RevokeConsent(ctx context.Context, userID uint, clientID string) error
+}
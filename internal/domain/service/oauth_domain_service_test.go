@@ -0,0 +1,579 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOAuthClientRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthClientRepository) Create(ctx context.Context, client *entity.OAuthClient) error {
+	args := m.Called(ctx, client)
+	return args.Error(0)
+}
+
+func (m *MockOAuthClientRepository) GetByClientID(ctx context.Context, clientID string) (*entity.OAuthClient, error) {
+	args := m.Called(ctx, clientID)
+	if client, ok := args.Get(0).(*entity.OAuthClient); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthClientRepository) List(ctx context.Context) ([]*entity.OAuthClient, error) {
+	args := m.Called(ctx)
+	if clients, ok := args.Get(0).([]*entity.OAuthClient); ok {
+		return clients, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthClientRepository) Delete(ctx context.Context, clientID string) error {
+	args := m.Called(ctx, clientID)
+	return args.Error(0)
+}
+
+type MockOAuthAuthorizationCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthAuthorizationCodeRepository) Create(ctx context.Context, code *entity.OAuthAuthorizationCode) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (m *MockOAuthAuthorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*entity.OAuthAuthorizationCode, error) {
+	args := m.Called(ctx, codeHash)
+	if code, ok := args.Get(0).(*entity.OAuthAuthorizationCode); ok {
+		return code, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthAuthorizationCodeRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOAuthAuthorizationCodeRepository) DeleteExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockOAuthRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthRefreshTokenRepository) Create(ctx context.Context, token *entity.OAuthRefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.OAuthRefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if token, ok := args.Get(0).(*entity.OAuthRefreshToken); ok {
+		return token, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthRefreshTokenRepository) MarkRotated(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOAuthRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRefreshTokenRepository) RevokeByClientAndUser(ctx context.Context, clientID string, userID uint) error {
+	args := m.Called(ctx, clientID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOAuthRefreshTokenRepository) RevokeByClient(ctx context.Context, clientID string) error {
+	args := m.Called(ctx, clientID)
+	return args.Error(0)
+}
+
+type MockOAuthConsentRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthConsentRepository) Get(ctx context.Context, userID uint, clientID string) (*entity.OAuthConsent, error) {
+	args := m.Called(ctx, userID, clientID)
+	if consent, ok := args.Get(0).(*entity.OAuthConsent); ok {
+		return consent, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthConsentRepository) ListByUser(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	args := m.Called(ctx, userID)
+	if consents, ok := args.Get(0).([]*entity.OAuthConsent); ok {
+		return consents, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthConsentRepository) Upsert(ctx context.Context, consent *entity.OAuthConsent) error {
+	args := m.Called(ctx, consent)
+	return args.Error(0)
+}
+
+func (m *MockOAuthConsentRepository) Delete(ctx context.Context, userID uint, clientID string) error {
+	args := m.Called(ctx, userID, clientID)
+	return args.Error(0)
+}
+
+type oauthTestFixture struct {
+	clientRepo       *MockOAuthClientRepository
+	codeRepo         *MockOAuthAuthorizationCodeRepository
+	refreshTokenRepo *MockOAuthRefreshTokenRepository
+	consentRepo      *MockOAuthConsentRepository
+	cache            *testRateLimitCache
+	authService      *service.AuthDomainService
+	service          *service.OAuthDomainService
+}
+
+func newOAuthTestFixture() *oauthTestFixture {
+	f := &oauthTestFixture{
+		clientRepo:       new(MockOAuthClientRepository),
+		codeRepo:         new(MockOAuthAuthorizationCodeRepository),
+		refreshTokenRepo: new(MockOAuthRefreshTokenRepository),
+		consentRepo:      new(MockOAuthConsentRepository),
+		cache:            newTestRateLimitCache(),
+	}
+	f.authService = service.NewAuthDomainService(new(MockUserRepository), new(MockAuthRepository), new(MockRoleRepository), new(MockRefreshTokenRepository), f.cache, nil, nil, "test-secret")
+	f.service = service.NewOAuthDomainService(f.clientRepo, f.codeRepo, f.refreshTokenRepo, f.consentRepo, f.authService, f.cache)
+	return f
+}
+
+const (
+	testCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	testRedirectURI   = "https://shop.example.com/callback"
+)
+
+func testOAuthClient() *entity.OAuthClient {
+	return &entity.OAuthClient{
+		ClientID:     "oc_shop",
+		Name:         "Shop App",
+		RedirectURIs: []string{testRedirectURI},
+		GrantTypes:   []string{entity.OAuthGrantTypeAuthorizationCode, entity.OAuthGrantTypeRefreshToken},
+		Scopes:       []string{"profile", "points:read"},
+		Confidential: false,
+	}
+}
+
+func testAuthorizationRequest() *entity.OAuthAuthorizationRequest {
+	return &entity.OAuthAuthorizationRequest{
+		ResponseType:        entity.OAuthResponseTypeCode,
+		ClientID:            "oc_shop",
+		RedirectURI:         testRedirectURI,
+		Scope:               "profile",
+		State:               "xyz",
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: entity.OAuthCodeChallengeMethodS256,
+	}
+}
+
+func TestOAuthDomainServicePrepareAuthorization(t *testing.T) {
+	tests := []struct {
+		name              string
+		modify            func(*entity.OAuthAuthorizationRequest)
+		consent           *entity.OAuthConsent
+		expectedErr       error
+		expectedConsented bool
+	}{
+		{
+			name:              "同意済みのスコープ",
+			modify:            func(req *entity.OAuthAuthorizationRequest) {},
+			consent:           entity.NewOAuthConsent(1, "oc_shop", []string{"profile"}),
+			expectedConsented: true,
+		},
+		{
+			name:              "同意していないスコープを含む",
+			modify:            func(req *entity.OAuthAuthorizationRequest) { req.Scope = "profile points:read" },
+			consent:           entity.NewOAuthConsent(1, "oc_shop", []string{"profile"}),
+			expectedConsented: false,
+		},
+		{
+			name:        "登録されていないリダイレクトURI",
+			modify:      func(req *entity.OAuthAuthorizationRequest) { req.RedirectURI = "https://evil.example.com/callback" },
+			expectedErr: entity.ErrOAuthInvalidRedirectURI,
+		},
+		{
+			name:        "PKCEがない",
+			modify:      func(req *entity.OAuthAuthorizationRequest) { req.CodeChallenge = "" },
+			expectedErr: entity.ErrOAuthInvalidRequest,
+		},
+		{
+			name:        "plainのPKCE",
+			modify:      func(req *entity.OAuthAuthorizationRequest) { req.CodeChallengeMethod = "plain" },
+			expectedErr: entity.ErrOAuthInvalidRequest,
+		},
+		{
+			name:        "登録されていないスコープ",
+			modify:      func(req *entity.OAuthAuthorizationRequest) { req.Scope = "admin" },
+			expectedErr: entity.ErrOAuthInvalidScope,
+		},
+		{
+			name:        "codeではないレスポンスタイプ",
+			modify:      func(req *entity.OAuthAuthorizationRequest) { req.ResponseType = "token" },
+			expectedErr: entity.ErrOAuthUnsupportedResponseType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newOAuthTestFixture()
+			req := testAuthorizationRequest()
+			tt.modify(req)
+
+			f.clientRepo.On("GetByClientID", ctx, "oc_shop").Return(testOAuthClient(), nil)
+			if tt.consent != nil {
+				f.consentRepo.On("Get", ctx, uint(1), "oc_shop").Return(tt.consent, nil)
+			}
+
+			_, _, consented, err := f.service.PrepareAuthorization(ctx, 1, req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedConsented, consented)
+		})
+	}
+
+	t.Run("存在しないクライアント", func(t *testing.T) {
+		ctx := context.Background()
+		f := newOAuthTestFixture()
+		f.clientRepo.On("GetByClientID", ctx, "oc_shop").Return(nil, entity.ErrOAuthClientNotFound)
+
+		_, _, _, err := f.service.PrepareAuthorization(ctx, 1, testAuthorizationRequest())
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidClient)
+	})
+}
+
+func TestOAuthDomainServiceAuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	f := newOAuthTestFixture()
+	client := testOAuthClient()
+
+	var storedCode *entity.OAuthAuthorizationCode
+	f.clientRepo.On("GetByClientID", ctx, "oc_shop").Return(client, nil)
+	f.consentRepo.On("Get", ctx, uint(1), "oc_shop").Return(nil, entity.ErrOAuthConsentNotFound)
+	f.consentRepo.On("Upsert", ctx, mock.MatchedBy(func(c *entity.OAuthConsent) bool {
+		return c.UserID == 1 && c.ClientID == "oc_shop" && c.Covers([]string{"profile"})
+	})).Return(nil)
+	f.codeRepo.On("Create", ctx, mock.AnythingOfType("*entity.OAuthAuthorizationCode")).
+		Run(func(args mock.Arguments) { storedCode = args.Get(1).(*entity.OAuthAuthorizationCode) }).
+		Return(nil)
+
+	code, scopes, err := f.service.Authorize(ctx, 1, testAuthorizationRequest())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"profile"}, scopes)
+	require.NotNil(t, storedCode)
+	assert.Equal(t, entity.HashOAuthToken(code), storedCode.CodeHash)
+
+	t.Run("誤ったcode_verifierは拒否する", func(t *testing.T) {
+		f.codeRepo.On("GetByCodeHash", ctx, storedCode.CodeHash).Return(storedCode, nil).Once()
+
+		_, err := f.service.ExchangeAuthorizationCode(ctx, client, code, testRedirectURI, "wrong-verifier-wrong-verifier-wrong-verifier-x")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+	})
+
+	t.Run("異なるリダイレクトURIは拒否する", func(t *testing.T) {
+		f.codeRepo.On("GetByCodeHash", ctx, storedCode.CodeHash).Return(storedCode, nil).Once()
+
+		_, err := f.service.ExchangeAuthorizationCode(ctx, client, code, "https://shop.example.com/other", testCodeVerifier)
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+	})
+
+	t.Run("正しいcode_verifierでトークンを発行する", func(t *testing.T) {
+		f.codeRepo.On("GetByCodeHash", ctx, storedCode.CodeHash).Return(storedCode, nil).Once()
+		f.codeRepo.On("MarkUsed", ctx, storedCode.ID).Return(true, nil).Once()
+		f.refreshTokenRepo.On("Create", ctx, mock.MatchedBy(func(rt *entity.OAuthRefreshToken) bool {
+			return rt.ClientID == "oc_shop" && rt.UserID == 1 && rt.FamilyID != ""
+		})).Return(nil).Once()
+
+		tokenSet, err := f.service.ExchangeAuthorizationCode(ctx, client, code, testRedirectURI, testCodeVerifier)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.OAuthTokenTypeBearer, tokenSet.TokenType)
+		assert.Equal(t, 3600, tokenSet.ExpiresIn)
+		assert.NotEmpty(t, tokenSet.RefreshToken)
+		assert.Equal(t, []string{"profile"}, tokenSet.Scopes)
+
+		claims, err := f.authService.ValidateOAuthAccessToken(ctx, tokenSet.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), claims.UserID)
+		assert.Equal(t, "oc_shop", claims.ClientID)
+		assert.Equal(t, "profile", claims.Scope)
+	})
+
+	t.Run("使用済みのコードは付与済みのトークンを失効させる", func(t *testing.T) {
+		now := time.Now()
+		storedCode.UsedAt = &now
+		f.codeRepo.On("GetByCodeHash", ctx, storedCode.CodeHash).Return(storedCode, nil).Once()
+		f.refreshTokenRepo.On("RevokeByClientAndUser", ctx, "oc_shop", uint(1)).Return(nil).Once()
+
+		_, err := f.service.ExchangeAuthorizationCode(ctx, client, code, testRedirectURI, testCodeVerifier)
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+		var reuseErr *service.OAuthTokenReuseError
+		assert.True(t, errors.As(err, &reuseErr))
+		f.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("他のクライアントのコードは使えない", func(t *testing.T) {
+		other := testOAuthClient()
+		other.ClientID = "oc_other"
+		f.codeRepo.On("GetByCodeHash", ctx, storedCode.CodeHash).Return(storedCode, nil).Once()
+
+		_, err := f.service.ExchangeAuthorizationCode(ctx, other, code, testRedirectURI, testCodeVerifier)
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+	})
+}
+
+func TestOAuthDomainServiceClientCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("クライアント自身を主体とするトークンを発行する", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		client := &entity.OAuthClient{ClientID: "oc_batch", GrantTypes: []string{entity.OAuthGrantTypeClientCredentials}, Scopes: []string{"inventory:read", "inventory:write"}, Confidential: true}
+
+		tokenSet, err := f.service.ClientCredentials(ctx, client, "inventory:read")
+
+		require.NoError(t, err)
+		assert.Empty(t, tokenSet.RefreshToken)
+		claims, err := f.authService.ValidateOAuthAccessToken(ctx, tokenSet.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "oc_batch", claims.Subject)
+		assert.Equal(t, "inventory:read", claims.Scope)
+	})
+
+	t.Run("許可されていないグラントタイプ", func(t *testing.T) {
+		f := newOAuthTestFixture()
+
+		_, err := f.service.ClientCredentials(ctx, testOAuthClient(), "")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthUnauthorizedClient)
+	})
+}
+
+func TestOAuthDomainServiceRefreshAccessToken(t *testing.T) {
+	ctx := context.Background()
+	client := testOAuthClient()
+
+	newRefreshToken := func(t *testing.T) (*entity.OAuthRefreshToken, string) {
+		token, plaintext, err := entity.NewOAuthRefreshToken("oc_shop", 1, []string{"profile", "points:read"}, "family-1", 0)
+		require.NoError(t, err)
+		token.ID = 9
+		return token, plaintext
+	}
+
+	t.Run("ローテーションしてスコープを絞り込める", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext := newRefreshToken(t)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+		f.refreshTokenRepo.On("MarkRotated", ctx, uint(9)).Return(true, nil)
+		f.refreshTokenRepo.On("Create", ctx, mock.MatchedBy(func(rt *entity.OAuthRefreshToken) bool {
+			return rt.FamilyID == "family-1" && len(rt.Scopes) == 2
+		})).Return(nil)
+
+		tokenSet, err := f.service.RefreshAccessToken(ctx, client, plaintext, "points:read")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"points:read"}, tokenSet.Scopes)
+		assert.NotEqual(t, plaintext, tokenSet.RefreshToken)
+		f.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("元の付与を超えるスコープは拒否する", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext := newRefreshToken(t)
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+
+		_, err := f.service.RefreshAccessToken(ctx, client, plaintext, "profile admin")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidScope)
+		f.refreshTokenRepo.AssertNotCalled(t, "MarkRotated", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ローテーション済みのトークンはファミリーを失効させる", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext := newRefreshToken(t)
+		now := time.Now()
+		token.IsRevoked = true
+		token.RotatedAt = &now
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+		f.refreshTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil)
+
+		_, err := f.service.RefreshAccessToken(ctx, client, plaintext, "")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+		var reuseErr *service.OAuthTokenReuseError
+		require.True(t, errors.As(err, &reuseErr))
+		assert.Equal(t, uint(1), reuseErr.UserID)
+		f.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("パスワード変更などで失効したトークン", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext := newRefreshToken(t)
+		_, _ = f.cache.IncrementTokenVersion(ctx, 1)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+
+		_, err := f.service.RefreshAccessToken(ctx, client, plaintext, "")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+	})
+
+	t.Run("他のクライアントのトークン", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext := newRefreshToken(t)
+		token.ClientID = "oc_other"
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+
+		_, err := f.service.RefreshAccessToken(ctx, client, plaintext, "")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidGrant)
+	})
+}
+
+func TestOAuthDomainServiceIntrospect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("有効なアクセストークン", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		accessToken, err := f.authService.GenerateOAuthAccessToken(ctx, 1, "oc_shop", []string{"profile"})
+		require.NoError(t, err)
+
+		f.clientRepo.On("GetByClientID", ctx, "oc_shop").Return(testOAuthClient(), nil)
+		f.consentRepo.On("Get", ctx, uint(1), "oc_shop").Return(entity.NewOAuthConsent(1, "oc_shop", []string{"profile"}), nil)
+
+		info, err := f.service.Introspect(ctx, accessToken, "")
+
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Equal(t, entity.OAuthTokenTypeAccessToken, info.TokenType)
+		assert.Equal(t, "1", info.Subject)
+		assert.Equal(t, []string{"profile"}, info.Scopes)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), info.ExpiresAt, 5*time.Second)
+	})
+
+	t.Run("同意を取り消したユーザーのアクセストークン", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		accessToken, err := f.authService.GenerateOAuthAccessToken(ctx, 1, "oc_shop", []string{"profile"})
+		require.NoError(t, err)
+
+		f.clientRepo.On("GetByClientID", ctx, "oc_shop").Return(testOAuthClient(), nil)
+		f.consentRepo.On("Get", ctx, uint(1), "oc_shop").Return(nil, entity.ErrOAuthConsentNotFound)
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashOAuthToken(accessToken)).Return(nil, errors.New("record not found"))
+
+		info, err := f.service.Introspect(ctx, accessToken, "")
+
+		require.NoError(t, err)
+		assert.False(t, info.Active)
+	})
+
+	t.Run("ヒントに従ってリフレッシュトークンを先に調べる", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext, err := entity.NewOAuthRefreshToken("oc_shop", 1, []string{"profile"}, "family-1", 0)
+		require.NoError(t, err)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+
+		info, err := f.service.Introspect(ctx, plaintext, entity.OAuthTokenTypeRefreshToken)
+
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Equal(t, entity.OAuthTokenTypeRefreshToken, info.TokenType)
+		assert.Equal(t, "oc_shop", info.ClientID)
+	})
+
+	t.Run("ファーストパーティのトークンは対象外", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		accessToken, err := f.authService.GenerateAccessToken(ctx, 1, "test@example.com", []string{"admin"})
+		require.NoError(t, err)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, entity.HashOAuthToken(accessToken)).Return(nil, errors.New("record not found"))
+
+		info, err := f.service.Introspect(ctx, accessToken, "")
+
+		require.NoError(t, err)
+		assert.False(t, info.Active)
+	})
+}
+
+func TestOAuthDomainServiceRevoke(t *testing.T) {
+	ctx := context.Background()
+	client := testOAuthClient()
+
+	t.Run("アクセストークンをブラックリストに載せる", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		accessToken, err := f.authService.GenerateOAuthAccessToken(ctx, 1, "oc_shop", []string{"profile"})
+		require.NoError(t, err)
+
+		require.NoError(t, f.service.Revoke(ctx, client, accessToken, entity.OAuthTokenTypeAccessToken))
+
+		_, err = f.authService.ValidateOAuthAccessToken(ctx, accessToken)
+		assert.ErrorIs(t, err, service.ErrTokenRevoked)
+	})
+
+	t.Run("リフレッシュトークンのファミリーを失効させる", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext, err := entity.NewOAuthRefreshToken("oc_shop", 1, []string{"profile"}, "family-1", 0)
+		require.NoError(t, err)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+		f.refreshTokenRepo.On("RevokeFamily", ctx, "family-1").Return(nil)
+
+		require.NoError(t, f.service.Revoke(ctx, client, plaintext, entity.OAuthTokenTypeRefreshToken))
+		f.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("他のクライアントのトークンは黙って無視する", func(t *testing.T) {
+		f := newOAuthTestFixture()
+		token, plaintext, err := entity.NewOAuthRefreshToken("oc_other", 1, []string{"profile"}, "family-1", 0)
+		require.NoError(t, err)
+
+		f.refreshTokenRepo.On("GetByTokenHash", ctx, token.TokenHash).Return(token, nil)
+
+		assert.NoError(t, f.service.Revoke(ctx, client, plaintext, entity.OAuthTokenTypeRefreshToken))
+		f.refreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthDomainServiceRevokeConsent(t *testing.T) {
+	ctx := context.Background()
+	f := newOAuthTestFixture()
+
+	f.consentRepo.On("Delete", ctx, uint(1), "oc_shop").Return(nil)
+	f.refreshTokenRepo.On("RevokeByClientAndUser", ctx, "oc_shop", uint(1)).Return(nil)
+
+	assert.NoError(t, f.service.RevokeConsent(ctx, 1, "oc_shop"))
+	f.refreshTokenRepo.AssertExpectations(t)
+}
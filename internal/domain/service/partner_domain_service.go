@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+type PartnerKeyRateLimiter interface {
+	IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+type PartnerDomainService struct {
+	apiKeyRepo  repository.PartnerAPIKeyRepository
+	quotaRepo   repository.PartnerQuotaRepository
+	rateLimiter PartnerKeyRateLimiter
+}
+
+func NewPartnerDomainService(apiKeyRepo repository.PartnerAPIKeyRepository, quotaRepo repository.PartnerQuotaRepository, rateLimiter PartnerKeyRateLimiter) *PartnerDomainService {
+	return &PartnerDomainService{
+		apiKeyRepo:  apiKeyRepo,
+		quotaRepo:   quotaRepo,
+		rateLimiter: rateLimiter,
+	}
+}
+
+func (s *PartnerDomainService) ListAPIKeys(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error) {
+	return s.apiKeyRepo.List(ctx, partnerID)
+}
+
+func (s *PartnerDomainService) CreateAPIKey(ctx context.Context, partnerID, partnerName string, permissions []string, limitPerHour, limitPerDay int) (*entity.PartnerAPIKey, string, error) {
+	key, plaintext, err := entity.NewPartnerAPIKey(partnerID, partnerName, permissions, limitPerHour)
+	if err != nil {
+		return nil, "", err
+	}
+
+	quota, err := entity.NewPartnerQuota(partnerID, entity.PartnerQuotaEndpointAll, limitPerHour, limitPerDay)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The partner-wide quota is shared by all of the partner's keys, so an
+	// additional key must not reset limits an admin has already tuned.
+	quotas, err := s.quotaRepo.ListByPartner(ctx, partnerID)
+	if err != nil {
+		return nil, "", err
+	}
+	hasPartnerQuota := slices.ContainsFunc(quotas, func(existing *entity.PartnerQuota) bool {
+		return existing.Endpoint == entity.PartnerQuotaEndpointAll
+	})
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+	if !hasPartnerQuota {
+		if err := s.quotaRepo.Upsert(ctx, quota); err != nil {
+			return nil, "", err
+		}
+	}
+	return key, plaintext, nil
+}
+
+func (s *PartnerDomainService) RotateAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, string, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	plaintext, err := key.Rotate()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+func (s *PartnerDomainService) RevokeAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.Revoke(); err != nil {
+		return nil, err
+	}
+	if err := s.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *PartnerDomainService) SetQuota(ctx context.Context, partnerID, endpoint string, limitPerHour, limitPerDay int) (*entity.PartnerQuota, error) {
+	quota, err := entity.NewPartnerQuota(partnerID, endpoint, limitPerHour, limitPerDay)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quotaRepo.Upsert(ctx, quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (s *PartnerDomainService) Authenticate(ctx context.Context, plaintext string) (*entity.PartnerAPIKey, error) {
+	if !entity.IsPartnerAPIKeyFormat(plaintext) {
+		return nil, entity.ErrInvalidPartnerAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByKeyHash(ctx, entity.HashPartnerAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, entity.ErrPartnerAPIKeyNotFound) {
+			return nil, entity.ErrInvalidPartnerAPIKey
+		}
+		return nil, err
+	}
+	if !key.Enabled {
+		return nil, entity.ErrInvalidPartnerAPIKey
+	}
+	return key, nil
+}
+
+// ConsumeQuota returns nil when the partner has no quota covering the
+// endpoint. An exhausted quota is returned alongside ErrPartnerQuotaExceeded
+// so callers can report when it resets.
+func (s *PartnerDomainService) ConsumeQuota(ctx context.Context, partnerID, endpoint string) (*entity.PartnerQuota, error) {
+	quotas, err := s.quotaRepo.ListByPartner(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := entity.SelectPartnerQuota(quotas, endpoint)
+	if quota == nil {
+		return nil, nil
+	}
+
+	consumed, allowed, err := s.quotaRepo.Consume(ctx, quota.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return consumed, entity.ErrPartnerQuotaExceeded
+	}
+	return consumed, nil
+}
+
+// ConsumeKeyRateLimit enforces the hourly limit stored on the key itself on
+// top of the partner-wide quotas. Keys without a limit are not capped.
+func (s *PartnerDomainService) ConsumeKeyRateLimit(ctx context.Context, key *entity.PartnerAPIKey) error {
+	if key.RateLimit <= 0 {
+		return nil
+	}
+
+	hourReset, _ := entity.PartnerQuotaWindow(time.Now())
+	counterKey := fmt.Sprintf("partner_key:%d:%d", key.ID, hourReset.Unix())
+	count, err := s.rateLimiter.IncrementRateLimit(ctx, counterKey, time.Hour)
+	if err != nil {
+		return err
+	}
+	if count > int64(key.RateLimit) {
+		return entity.ErrPartnerKeyRateLimited
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PartnerDomainServiceInterface interface {
+	ListAPIKeys(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error)
+	CreateAPIKey(ctx context.Context, partnerID, partnerName string, permissions []string, limitPerHour, limitPerDay int) (*entity.PartnerAPIKey, string, error)
+	RotateAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, string, error)
+	RevokeAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, error)
+	SetQuota(ctx context.Context, partnerID, endpoint string, limitPerHour, limitPerDay int) (*entity.PartnerQuota, error)
+	Authenticate(ctx context.Context, plaintext string) (*entity.PartnerAPIKey, error)
+	ConsumeQuota(ctx context.Context, partnerID, endpoint string) (*entity.PartnerQuota, error)
+	ConsumeKeyRateLimit(ctx context.Context, key *entity.PartnerAPIKey) error
+}
@@ -0,0 +1,311 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPartnerAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartnerAPIKeyRepository) Create(ctx context.Context, key *entity.PartnerAPIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockPartnerAPIKeyRepository) GetByID(ctx context.Context, id uint) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, id)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerAPIKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerAPIKeyRepository) List(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, partnerID)
+	if keys, ok := args.Get(0).([]*entity.PartnerAPIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerAPIKeyRepository) Update(ctx context.Context, key *entity.PartnerAPIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockPartnerQuotaRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartnerQuotaRepository) ListByPartner(ctx context.Context, partnerID string) ([]*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID)
+	if quotas, ok := args.Get(0).([]*entity.PartnerQuota); ok {
+		return quotas, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerQuotaRepository) Upsert(ctx context.Context, quota *entity.PartnerQuota) error {
+	args := m.Called(ctx, quota)
+	return args.Error(0)
+}
+
+func (m *MockPartnerQuotaRepository) Consume(ctx context.Context, quotaID uint, now time.Time) (*entity.PartnerQuota, bool, error) {
+	args := m.Called(ctx, quotaID, now)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Bool(1), args.Error(2)
+	}
+	return nil, args.Bool(1), args.Error(2)
+}
+
+func TestPartnerDomainServiceCreateAPIKey(t *testing.T) {
+	t.Run("キーとパートナー全体の上限を登録する", func(t *testing.T) {
+		apiKeyRepo := &MockPartnerAPIKeyRepository{}
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(apiKeyRepo, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_001").Return([]*entity.PartnerQuota{}, nil)
+		apiKeyRepo.On("Create", ctx, mock.AnythingOfType("*entity.PartnerAPIKey")).Return(nil)
+		quotaRepo.On("Upsert", ctx, mock.MatchedBy(func(quota *entity.PartnerQuota) bool {
+			return quota.PartnerID == "PARTNER_001" && quota.Endpoint == entity.PartnerQuotaEndpointAll &&
+				quota.LimitPerHour == 100 && quota.LimitPerDay == 1000
+		})).Return(nil)
+
+		key, plaintext, err := svc.CreateAPIKey(ctx, "PARTNER_001", "POS", []string{"integration:read"}, 100, 1000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.HashPartnerAPIKey(plaintext), key.KeyHash)
+		apiKeyRepo.AssertExpectations(t)
+		quotaRepo.AssertExpectations(t)
+	})
+
+	t.Run("既存のパートナー全体の上限は上書きしない", func(t *testing.T) {
+		apiKeyRepo := &MockPartnerAPIKeyRepository{}
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(apiKeyRepo, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_001").Return([]*entity.PartnerQuota{
+			{ID: 1, PartnerID: "PARTNER_001", Endpoint: entity.PartnerQuotaEndpointAll, LimitPerHour: 500, LimitPerDay: 5000},
+		}, nil)
+		apiKeyRepo.On("Create", ctx, mock.AnythingOfType("*entity.PartnerAPIKey")).Return(nil)
+
+		_, _, err := svc.CreateAPIKey(ctx, "PARTNER_001", "POS", []string{"integration:read"}, 100, 1000)
+
+		assert.NoError(t, err)
+		quotaRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("不正な上限では登録しない", func(t *testing.T) {
+		apiKeyRepo := &MockPartnerAPIKeyRepository{}
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(apiKeyRepo, quotaRepo, newTestRateLimitCache())
+
+		_, _, err := svc.CreateAPIKey(context.Background(), "PARTNER_001", "POS", nil, 100, 10)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPartnerQuota)
+		apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPartnerDomainServiceRotateAPIKey(t *testing.T) {
+	t.Run("新しいキーで古いキーを置き換える", func(t *testing.T) {
+		apiKeyRepo := &MockPartnerAPIKeyRepository{}
+		svc := service.NewPartnerDomainService(apiKeyRepo, &MockPartnerQuotaRepository{}, newTestRateLimitCache())
+		ctx := context.Background()
+		key, original, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", nil, 100)
+		require.NoError(t, err)
+
+		apiKeyRepo.On("GetByID", ctx, uint(4)).Return(key, nil)
+		apiKeyRepo.On("Update", ctx, key).Return(nil)
+
+		rotated, plaintext, err := svc.RotateAPIKey(ctx, 4)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, original, plaintext)
+		assert.Equal(t, entity.HashPartnerAPIKey(plaintext), rotated.KeyHash)
+	})
+
+	t.Run("失効済みのキーはローテーションできない", func(t *testing.T) {
+		apiKeyRepo := &MockPartnerAPIKeyRepository{}
+		svc := service.NewPartnerDomainService(apiKeyRepo, &MockPartnerQuotaRepository{}, newTestRateLimitCache())
+		ctx := context.Background()
+
+		apiKeyRepo.On("GetByID", ctx, uint(4)).Return(&entity.PartnerAPIKey{ID: 4, Enabled: false}, nil)
+
+		_, _, err := svc.RotateAPIKey(ctx, 4)
+
+		assert.ErrorIs(t, err, entity.ErrPartnerAPIKeyRevoked)
+		apiKeyRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPartnerDomainServiceRevokeAPIKey(t *testing.T) {
+	apiKeyRepo := &MockPartnerAPIKeyRepository{}
+	svc := service.NewPartnerDomainService(apiKeyRepo, &MockPartnerQuotaRepository{}, newTestRateLimitCache())
+	ctx := context.Background()
+
+	apiKeyRepo.On("GetByID", ctx, uint(4)).Return(&entity.PartnerAPIKey{ID: 4, Enabled: true}, nil)
+	apiKeyRepo.On("Update", ctx, mock.MatchedBy(func(key *entity.PartnerAPIKey) bool { return !key.Enabled })).Return(nil)
+
+	key, err := svc.RevokeAPIKey(ctx, 4)
+
+	assert.NoError(t, err)
+	assert.False(t, key.Enabled)
+	apiKeyRepo.AssertExpectations(t)
+}
+
+func TestPartnerDomainServiceAuthenticate(t *testing.T) {
+	enabled, plaintext, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", nil, 100)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		plaintext     string
+		setupMock     func(*MockPartnerAPIKeyRepository)
+		expectedError error
+	}{
+		{
+			name:      "有効なキー",
+			plaintext: plaintext,
+			setupMock: func(m *MockPartnerAPIKeyRepository) {
+				m.On("GetByKeyHash", mock.Anything, entity.HashPartnerAPIKey(plaintext)).Return(enabled, nil)
+			},
+		},
+		{
+			name:          "形式が不正なキーは検索しない",
+			plaintext:     "partner_api_key_12345",
+			setupMock:     func(m *MockPartnerAPIKeyRepository) {},
+			expectedError: entity.ErrInvalidPartnerAPIKey,
+		},
+		{
+			name:      "存在しないキー",
+			plaintext: "pk_0123abcd.unknown",
+			setupMock: func(m *MockPartnerAPIKeyRepository) {
+				m.On("GetByKeyHash", mock.Anything, mock.Anything).Return(nil, entity.ErrPartnerAPIKeyNotFound)
+			},
+			expectedError: entity.ErrInvalidPartnerAPIKey,
+		},
+		{
+			name:      "失効済みのキー",
+			plaintext: plaintext,
+			setupMock: func(m *MockPartnerAPIKeyRepository) {
+				m.On("GetByKeyHash", mock.Anything, mock.Anything).Return(&entity.PartnerAPIKey{Enabled: false}, nil)
+			},
+			expectedError: entity.ErrInvalidPartnerAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := &MockPartnerAPIKeyRepository{}
+			tt.setupMock(apiKeyRepo)
+			svc := service.NewPartnerDomainService(apiKeyRepo, &MockPartnerQuotaRepository{}, newTestRateLimitCache())
+
+			key, err := svc.Authenticate(context.Background(), tt.plaintext)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, key)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "PARTNER_001", key.PartnerID)
+		})
+	}
+}
+
+func TestPartnerDomainServiceConsumeQuota(t *testing.T) {
+	quotas := []*entity.PartnerQuota{
+		{ID: 1, PartnerID: "PARTNER_001", Endpoint: "*", LimitPerHour: 100, LimitPerDay: 1000},
+		{ID: 2, PartnerID: "PARTNER_001", Endpoint: "/api/v1/integration/*", LimitPerHour: 10, LimitPerDay: 100},
+	}
+
+	t.Run("最も具体的な上限を消費する", func(t *testing.T) {
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(&MockPartnerAPIKeyRepository{}, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_001").Return(quotas, nil)
+		quotaRepo.On("Consume", ctx, uint(2), mock.AnythingOfType("time.Time")).Return(quotas[1], true, nil)
+
+		quota, err := svc.ConsumeQuota(ctx, "PARTNER_001", "/api/v1/integration/me")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), quota.ID)
+	})
+
+	t.Run("上限超過", func(t *testing.T) {
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(&MockPartnerAPIKeyRepository{}, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_001").Return(quotas, nil)
+		quotaRepo.On("Consume", ctx, uint(1), mock.AnythingOfType("time.Time")).Return(quotas[0], false, nil)
+
+		quota, err := svc.ConsumeQuota(ctx, "PARTNER_001", "/api/v1/stats")
+
+		assert.ErrorIs(t, err, entity.ErrPartnerQuotaExceeded)
+		assert.Equal(t, uint(1), quota.ID)
+	})
+
+	t.Run("上限が設定されていなければ何もしない", func(t *testing.T) {
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(&MockPartnerAPIKeyRepository{}, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_002").Return([]*entity.PartnerQuota{}, nil)
+
+		quota, err := svc.ConsumeQuota(ctx, "PARTNER_002", "/api/v1/integration/me")
+
+		assert.NoError(t, err)
+		assert.Nil(t, quota)
+		quotaRepo.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("リポジトリのエラーを返す", func(t *testing.T) {
+		quotaRepo := &MockPartnerQuotaRepository{}
+		svc := service.NewPartnerDomainService(&MockPartnerAPIKeyRepository{}, quotaRepo, newTestRateLimitCache())
+		ctx := context.Background()
+
+		quotaRepo.On("ListByPartner", ctx, "PARTNER_001").Return(nil, errors.New("db error"))
+
+		_, err := svc.ConsumeQuota(ctx, "PARTNER_001", "/api/v1/integration/me")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPartnerDomainServiceConsumeKeyRateLimit(t *testing.T) {
+	svc := service.NewPartnerDomainService(&MockPartnerAPIKeyRepository{}, &MockPartnerQuotaRepository{}, newTestRateLimitCache())
+	ctx := context.Background()
+	key := &entity.PartnerAPIKey{ID: 4, PartnerID: "PARTNER_001", RateLimit: 2, Enabled: true}
+
+	assert.NoError(t, svc.ConsumeKeyRateLimit(ctx, key))
+	assert.NoError(t, svc.ConsumeKeyRateLimit(ctx, key))
+	assert.ErrorIs(t, svc.ConsumeKeyRateLimit(ctx, key), entity.ErrPartnerKeyRateLimited)
+
+	unlimited := &entity.PartnerAPIKey{ID: 5, PartnerID: "PARTNER_001", Enabled: true}
+	for range 3 {
+		assert.NoError(t, svc.ConsumeKeyRateLimit(ctx, unlimited))
+	}
+}
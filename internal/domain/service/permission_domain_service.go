@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+const PermissionCacheTTL = 10 * time.Minute
+
+type PermissionCache interface {
+	GetUserPermissions(ctx context.Context, userID uint) ([]string, error)
+	SetUserPermissions(ctx context.Context, userID uint, permissions []string, expiration time.Duration) error
+	DeleteUserPermissions(ctx context.Context, userID uint) error
+}
+
+type PermissionDomainService struct {
+	permissionRepo  repository.PermissionRepository
+	roleRepo        repository.RoleRepository
+	permissionCache PermissionCache
+}
+
+func NewPermissionDomainService(permissionRepo repository.PermissionRepository, roleRepo repository.RoleRepository, permissionCache PermissionCache) *PermissionDomainService {
+	return &PermissionDomainService{
+		permissionRepo:  permissionRepo,
+		roleRepo:        roleRepo,
+		permissionCache: permissionCache,
+	}
+}
+
+func (s *PermissionDomainService) ResolvePermissions(ctx context.Context, userID uint) (entity.PermissionSet, error) {
+	if s.permissionCache != nil {
+		if cached, err := s.permissionCache.GetUserPermissions(ctx, userID); err == nil {
+			if permissions, err := entity.ParsePermissionSet(cached); err == nil {
+				return permissions, nil
+			}
+		}
+	}
+
+	rolePermissions, err := s.permissionRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userScopes, err := s.permissionRepo.GetActiveUserScopes(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]entity.PermissionGrant, 0, len(rolePermissions)+len(userScopes))
+	for _, permission := range rolePermissions {
+		grants = append(grants, permission.Grant())
+	}
+
+	expiration := PermissionCacheTTL
+	for _, userScope := range userScopes {
+		if userScope.Scope == nil || !userScope.IsActive(now) {
+			continue
+		}
+		grants = append(grants, userScope.Scope.Grant())
+		if userScope.ExpiresAt != nil && userScope.ExpiresAt.Sub(now) < expiration {
+			expiration = userScope.ExpiresAt.Sub(now)
+		}
+	}
+
+	permissions := entity.NewPermissionSet(grants...)
+	if s.permissionCache != nil {
+		_ = s.permissionCache.SetUserPermissions(ctx, userID, permissions.Strings(), expiration)
+	}
+	return permissions, nil
+}
+
+func (s *PermissionDomainService) HasPermission(ctx context.Context, userID uint, required entity.PermissionGrant) (bool, error) {
+	permissions, err := s.ResolvePermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return permissions.Allows(required), nil
+}
+
+func (s *PermissionDomainService) GrantScope(ctx context.Context, userID uint, scopeName string, expiresAt time.Time) (*entity.UserScope, error) {
+	scope, err := s.permissionRepo.GetScopeByName(ctx, scopeName)
+	if err != nil {
+		return nil, err
+	}
+
+	userScope, err := entity.NewUserScope(userID, scope, expiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.permissionRepo.RevokeUserScope(ctx, userID, scope.ID); err != nil {
+		return nil, err
+	}
+	if err := s.permissionRepo.CreateUserScope(ctx, userScope); err != nil {
+		return nil, err
+	}
+	s.InvalidateUserPermissions(ctx, userID)
+
+	return userScope, nil
+}
+
+func (s *PermissionDomainService) RevokeScope(ctx context.Context, userID uint, scopeName string) error {
+	scope, err := s.permissionRepo.GetScopeByName(ctx, scopeName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.permissionRepo.RevokeUserScope(ctx, userID, scope.ID); err != nil {
+		return err
+	}
+	s.InvalidateUserPermissions(ctx, userID)
+
+	return nil
+}
+
+func (s *PermissionDomainService) AssignRole(ctx context.Context, userID, roleID uint) error {
+	if err := s.roleRepo.AssignToUser(ctx, userID, roleID); err != nil {
+		return err
+	}
+	s.InvalidateUserPermissions(ctx, userID)
+	return nil
+}
+
+func (s *PermissionDomainService) RemoveRole(ctx context.Context, userID, roleID uint) error {
+	if err := s.roleRepo.RemoveFromUser(ctx, userID, roleID); err != nil {
+		return err
+	}
+	s.InvalidateUserPermissions(ctx, userID)
+	return nil
+}
+
+func (s *PermissionDomainService) InvalidateUserPermissions(ctx context.Context, userID uint) {
+	if s.permissionCache != nil {
+		_ = s.permissionCache.DeleteUserPermissions(ctx, userID)
+	}
+}
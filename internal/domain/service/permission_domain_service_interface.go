@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PermissionDomainServiceInterface interface {
+	ResolvePermissions(ctx context.Context, userID uint) (entity.PermissionSet, error)
+	HasPermission(ctx context.Context, userID uint, required entity.PermissionGrant) (bool, error)
+	GrantScope(ctx context.Context, userID uint, scopeName string, expiresAt time.Time) (*entity.UserScope, error)
+	RevokeScope(ctx context.Context, userID uint, scopeName string) error
+	AssignRole(ctx context.Context, userID, roleID uint) error
+	RemoveRole(ctx context.Context, userID, roleID uint) error
+	InvalidateUserPermissions(ctx context.Context, userID uint)
+}
@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPermissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionRepository) GetUserPermissions(ctx context.Context, userID uint) ([]*entity.Permission, error) {
+	args := m.Called(ctx, userID)
+	if permissions, ok := args.Get(0).([]*entity.Permission); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetScopeByName(ctx context.Context, name string) (*entity.Scope, error) {
+	args := m.Called(ctx, name)
+	if scope, ok := args.Get(0).(*entity.Scope); ok {
+		return scope, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetActiveUserScopes(ctx context.Context, userID uint, now time.Time) ([]*entity.UserScope, error) {
+	args := m.Called(ctx, userID, now)
+	if userScopes, ok := args.Get(0).([]*entity.UserScope); ok {
+		return userScopes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionRepository) CreateUserScope(ctx context.Context, userScope *entity.UserScope) error {
+	args := m.Called(ctx, userScope)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) RevokeUserScope(ctx context.Context, userID, scopeID uint) error {
+	args := m.Called(ctx, userID, scopeID)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) GetByNames(ctx context.Context, names []string) ([]*entity.Permission, error) {
+	args := m.Called(ctx, names)
+	if permissions, ok := args.Get(0).([]*entity.Permission); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetRolePermissions(ctx context.Context, roleID uint) ([]*entity.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if permissions, ok := args.Get(0).([]*entity.Permission); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionRepository) ReplaceRolePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	args := m.Called(ctx, roleID, permissionIDs)
+	return args.Error(0)
+}
+
+type MockPermissionCache struct {
+	mock.Mock
+}
+
+func (m *MockPermissionCache) GetUserPermissions(ctx context.Context, userID uint) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if permissions, ok := args.Get(0).([]string); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionCache) SetUserPermissions(ctx context.Context, userID uint, permissions []string, expiration time.Duration) error {
+	args := m.Called(ctx, userID, permissions, expiration)
+	return args.Error(0)
+}
+
+func (m *MockPermissionCache) DeleteUserPermissions(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestPermissionDomainServiceResolvePermissions(t *testing.T) {
+	t.Run("キャッシュにあればDBを参照しない", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, permissionCache)
+		ctx := context.Background()
+
+		permissionCache.On("GetUserPermissions", ctx, uint(1)).Return([]string{"fraud:write:*"}, nil)
+
+		permissions, err := svc.ResolvePermissions(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"fraud:write:*"}, permissions.Strings())
+		permissionRepo.AssertNotCalled(t, "GetUserPermissions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ロールの権限と有効なスコープを合成してキャッシュする", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, permissionCache)
+		ctx := context.Background()
+		expiresAt := time.Now().Add(time.Minute)
+
+		permissionCache.On("GetUserPermissions", ctx, uint(1)).Return(nil, errors.New("cache miss"))
+		permissionRepo.On("GetUserPermissions", ctx, uint(1)).Return([]*entity.Permission{
+			entity.NewPermission("user", "read", "*", ""),
+		}, nil)
+		permissionRepo.On("GetActiveUserScopes", ctx, uint(1), mock.AnythingOfType("time.Time")).Return([]*entity.UserScope{
+			{UserID: 1, ScopeID: 8, Scope: &entity.Scope{ID: 8, Name: "fraud:write", Resource: "fraud", IsActive: true}, ExpiresAt: &expiresAt},
+		}, nil)
+		permissionCache.On("SetUserPermissions", ctx, uint(1), []string{"fraud:write:*", "user:read:*"}, mock.MatchedBy(func(expiration time.Duration) bool {
+			return expiration > 0 && expiration <= time.Minute
+		})).Return(nil)
+
+		permissions, err := svc.ResolvePermissions(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.True(t, permissions.Allows(entity.MustParsePermissionGrant("fraud.blacklist:write")))
+		permissionCache.AssertExpectations(t)
+	})
+
+	t.Run("リポジトリのエラーを返す", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, nil)
+		ctx := context.Background()
+
+		permissionRepo.On("GetUserPermissions", ctx, uint(1)).Return(nil, errors.New("db error"))
+
+		_, err := svc.ResolvePermissions(ctx, 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPermissionDomainServiceHasPermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		cached   []string
+		required string
+		expected bool
+	}{
+		{name: "権限あり", cached: []string{"fraud:write:*"}, required: "fraud.blacklist:write", expected: true},
+		{name: "権限なし", cached: []string{"fraud:read:*"}, required: "fraud.blacklist:write", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionCache := &MockPermissionCache{}
+			svc := service.NewPermissionDomainService(&MockPermissionRepository{}, &MockRoleRepository{}, permissionCache)
+			ctx := context.Background()
+
+			permissionCache.On("GetUserPermissions", ctx, uint(1)).Return(tt.cached, nil)
+
+			allowed, err := svc.HasPermission(ctx, 1, entity.MustParsePermissionGrant(tt.required))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestPermissionDomainServiceGrantScope(t *testing.T) {
+	t.Run("既存の付与を置き換えてキャッシュを破棄する", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, permissionCache)
+		ctx := context.Background()
+		scope := &entity.Scope{ID: 4, Name: "fraud", Resource: "fraud", IsActive: true}
+
+		permissionRepo.On("GetScopeByName", ctx, "fraud").Return(scope, nil)
+		permissionRepo.On("RevokeUserScope", ctx, uint(1), uint(4)).Return(nil)
+		permissionRepo.On("CreateUserScope", ctx, mock.AnythingOfType("*entity.UserScope")).Return(nil)
+		permissionCache.On("DeleteUserPermissions", ctx, uint(1)).Return(nil)
+
+		userScope, err := svc.GrantScope(ctx, 1, "fraud", time.Now().Add(time.Hour))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), userScope.ScopeID)
+		permissionRepo.AssertExpectations(t)
+		permissionCache.AssertExpectations(t)
+	})
+
+	t.Run("存在しないスコープ", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, nil)
+		ctx := context.Background()
+
+		permissionRepo.On("GetScopeByName", ctx, "unknown").Return(nil, entity.ErrScopeNotFound)
+
+		_, err := svc.GrantScope(ctx, 1, "unknown", time.Now().Add(time.Hour))
+
+		assert.ErrorIs(t, err, entity.ErrScopeNotFound)
+	})
+
+	t.Run("過去の有効期限", func(t *testing.T) {
+		permissionRepo := &MockPermissionRepository{}
+		svc := service.NewPermissionDomainService(permissionRepo, &MockRoleRepository{}, nil)
+		ctx := context.Background()
+
+		permissionRepo.On("GetScopeByName", ctx, "fraud").Return(&entity.Scope{ID: 4, Name: "fraud", IsActive: true}, nil)
+
+		_, err := svc.GrantScope(ctx, 1, "fraud", time.Now().Add(-time.Hour))
+
+		assert.ErrorIs(t, err, entity.ErrInvalidScopeExpiry)
+		permissionRepo.AssertNotCalled(t, "CreateUserScope", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPermissionDomainServiceRoleChanges(t *testing.T) {
+	t.Run("ロール付与でキャッシュを破棄する", func(t *testing.T) {
+		roleRepo := &MockRoleRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(&MockPermissionRepository{}, roleRepo, permissionCache)
+		ctx := context.Background()
+
+		roleRepo.On("AssignToUser", ctx, uint(1), uint(2)).Return(nil)
+		permissionCache.On("DeleteUserPermissions", ctx, uint(1)).Return(nil)
+
+		err := svc.AssignRole(ctx, 1, 2)
+
+		assert.NoError(t, err)
+		permissionCache.AssertExpectations(t)
+	})
+
+	t.Run("ロール削除でキャッシュを破棄する", func(t *testing.T) {
+		roleRepo := &MockRoleRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(&MockPermissionRepository{}, roleRepo, permissionCache)
+		ctx := context.Background()
+
+		roleRepo.On("RemoveFromUser", ctx, uint(1), uint(2)).Return(nil)
+		permissionCache.On("DeleteUserPermissions", ctx, uint(1)).Return(nil)
+
+		err := svc.RemoveRole(ctx, 1, 2)
+
+		assert.NoError(t, err)
+		permissionCache.AssertExpectations(t)
+	})
+
+	t.Run("失敗時はキャッシュを残す", func(t *testing.T) {
+		roleRepo := &MockRoleRepository{}
+		permissionCache := &MockPermissionCache{}
+		svc := service.NewPermissionDomainService(&MockPermissionRepository{}, roleRepo, permissionCache)
+		ctx := context.Background()
+
+		roleRepo.On("AssignToUser", ctx, uint(1), uint(2)).Return(errors.New("db error"))
+
+		err := svc.AssignRole(ctx, 1, 2)
+
+		assert.Error(t, err)
+		permissionCache.AssertNotCalled(t, "DeleteUserPermissions", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+var ErrMembershipNotFound = errors.New("membership not found")
+
+const (
+	PointValidityPeriod           = 365 * 24 * time.Hour
+	NotificationTypePointsExpired = "points_expired"
+)
+
+type PointDomainService struct {
+	userMembershipRepo   repository.UserMembershipRepository
+	pointTransactionRepo repository.PointTransactionRepository
+	notificationRepo     repository.NotificationRepository
+	tierEvaluator        TierEvaluator
+}
+
+func NewPointDomainService(
+	userMembershipRepo repository.UserMembershipRepository,
+	pointTransactionRepo repository.PointTransactionRepository,
+	notificationRepo repository.NotificationRepository,
+	tierEvaluator TierEvaluator,
+) *PointDomainService {
+	return &PointDomainService{
+		userMembershipRepo:   userMembershipRepo,
+		pointTransactionRepo: pointTransactionRepo,
+		notificationRepo:     notificationRepo,
+		tierEvaluator:        tierEvaluator,
+	}
+}
+
+func (s *PointDomainService) EarnPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error) {
+	if points <= 0 {
+		return nil, entity.ErrInvalidPointAmount
+	}
+	return s.record(ctx, userID, entity.PointTransactionTypeEarn, points, description, referenceType, referenceID)
+}
+
+func (s *PointDomainService) SpendPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error) {
+	if points <= 0 {
+		return nil, entity.ErrInvalidPointAmount
+	}
+	return s.record(ctx, userID, entity.PointTransactionTypeSpend, -points, description, referenceType, referenceID)
+}
+
+func (s *PointDomainService) GetPointTransactions(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	return s.pointTransactionRepo.GetByUserID(ctx, userID, filter, offset, limit)
+}
+
+func (s *PointDomainService) GetPointSummary(ctx context.Context, userID uint) (*entity.PointSummary, error) {
+	return s.pointTransactionRepo.GetSummary(ctx, userID)
+}
+
+func (s *PointDomainService) ExpirePoints(ctx context.Context, asOf time.Time, dryRun bool) (*entity.PointExpirationResult, error) {
+	lots, err := s.pointTransactionRepo.ListExpiredLots(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []uint
+	lotsByUser := make(map[uint][]*entity.PointTransaction)
+	for _, lot := range lots {
+		if !lot.IsExpiredAt(asOf) {
+			continue
+		}
+		if _, ok := lotsByUser[lot.UserID]; !ok {
+			userIDs = append(userIDs, lot.UserID)
+		}
+		lotsByUser[lot.UserID] = append(lotsByUser[lot.UserID], lot)
+	}
+
+	result := entity.NewPointExpirationResult(asOf, dryRun)
+	for _, userID := range userIDs {
+		membership, err := s.userMembershipRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			continue
+		}
+
+		var transaction *entity.PointTransaction
+		if dryRun {
+			transaction = membership.ExpirePoints(lotsByUser[userID])
+		} else {
+			transaction, err = s.pointTransactionRepo.ExpirePoints(ctx, userID, asOf)
+			if err != nil {
+				return nil, fmt.Errorf("failed to expire points for user %d: %w", userID, err)
+			}
+		}
+		if transaction == nil {
+			continue
+		}
+
+		expiration := &entity.PointExpiration{
+			UserID:          userID,
+			ExpiredPoints:   -transaction.Points,
+			RemainingPoints: transaction.BalanceAfter,
+		}
+		result.Add(expiration)
+		if !dryRun {
+			s.notifyExpiration(ctx, expiration)
+			s.evaluateTier(ctx, userID)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *PointDomainService) notifyExpiration(ctx context.Context, expiration *entity.PointExpiration) {
+	payload, _ := json.Marshal(map[string]int{
+		"expired_points":   expiration.ExpiredPoints,
+		"remaining_points": expiration.RemainingPoints,
+	})
+	data := string(payload)
+
+	notification := entity.NewNotification(
+		expiration.UserID,
+		NotificationTypePointsExpired,
+		"Points expired",
+		fmt.Sprintf("%d points have expired. Your current balance is %d points.", expiration.ExpiredPoints, expiration.RemainingPoints),
+		&data,
+	)
+	_ = s.notificationRepo.Create(ctx, notification)
+}
+
+func (s *PointDomainService) record(ctx context.Context, userID uint, transactionType string, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error) {
+	if _, err := s.userMembershipRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, ErrMembershipNotFound
+	}
+
+	transaction := entity.NewPointTransaction(userID, transactionType, points, description)
+	transaction.ReferenceType = referenceType
+	transaction.ReferenceID = referenceID
+	if points > 0 {
+		expiresAt := transaction.CreatedAt.Add(PointValidityPeriod)
+		transaction.ExpiresAt = &expiresAt
+	}
+
+	if _, err := s.pointTransactionRepo.Record(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	s.evaluateTier(ctx, userID)
+	return transaction, nil
+}
+
+func (s *PointDomainService) evaluateTier(ctx context.Context, userID uint) {
+	if s.tierEvaluator == nil {
+		return
+	}
+	_, _ = s.tierEvaluator.EvaluateUserTier(ctx, userID)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PointDomainServiceInterface interface {
+	EarnPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error)
+	SpendPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error)
+	GetPointTransactions(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error)
+	GetPointSummary(ctx context.Context, userID uint) (*entity.PointSummary, error)
+	ExpirePoints(ctx context.Context, asOf time.Time, dryRun bool) (*entity.PointExpirationResult, error)
+}
@@ -0,0 +1,363 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserMembershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserMembershipRepository) Create(ctx context.Context, membership *entity.UserMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUserMembershipRepository) GetByUserID(ctx context.Context, userID uint) (*entity.UserMembership, error) {
+	args := m.Called(ctx, userID)
+	if membership, ok := args.Get(0).(*entity.UserMembership); ok {
+		return membership, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserMembershipRepository) Update(ctx context.Context, membership *entity.UserMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUserMembershipRepository) UpdateTier(ctx context.Context, membership *entity.UserMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUserMembershipRepository) CountByTierID(ctx context.Context, tierID uint) (int64, error) {
+	args := m.Called(ctx, tierID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserMembershipRepository) Delete(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserMembershipRepository) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	args := m.Called(ctx)
+	if stats, ok := args.Get(0).(map[string]interface{}); ok {
+		return stats, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserMembershipRepository) List(ctx context.Context, offset, limit int) ([]*entity.UserMembership, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if memberships, ok := args.Get(0).([]*entity.UserMembership); ok {
+		return memberships, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+type MockPointTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPointTransactionRepository) Create(ctx context.Context, transaction *entity.PointTransaction) error {
+	args := m.Called(ctx, transaction)
+	return args.Error(0)
+}
+
+func (m *MockPointTransactionRepository) Record(ctx context.Context, transaction *entity.PointTransaction) (*entity.UserMembership, error) {
+	args := m.Called(ctx, transaction)
+	if membership, ok := args.Get(0).(*entity.UserMembership); ok {
+		return membership, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointTransactionRepository) GetByUserID(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	args := m.Called(ctx, userID, filter, offset, limit)
+	if transactions, ok := args.Get(0).([]*entity.PointTransaction); ok {
+		return transactions, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPointTransactionRepository) GetByID(ctx context.Context, id uint) (*entity.PointTransaction, error) {
+	args := m.Called(ctx, id)
+	if transaction, ok := args.Get(0).(*entity.PointTransaction); ok {
+		return transaction, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointTransactionRepository) GetSummary(ctx context.Context, userID uint) (*entity.PointSummary, error) {
+	args := m.Called(ctx, userID)
+	if summary, ok := args.Get(0).(*entity.PointSummary); ok {
+		return summary, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointTransactionRepository) List(ctx context.Context, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if transactions, ok := args.Get(0).([]*entity.PointTransaction); ok {
+		return transactions, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPointTransactionRepository) ListExpiredLots(ctx context.Context, asOf time.Time) ([]*entity.PointTransaction, error) {
+	args := m.Called(ctx, asOf)
+	if lots, ok := args.Get(0).([]*entity.PointTransaction); ok {
+		return lots, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointTransactionRepository) ExpirePoints(ctx context.Context, userID uint, asOf time.Time) (*entity.PointTransaction, error) {
+	args := m.Called(ctx, userID, asOf)
+	if transaction, ok := args.Get(0).(*entity.PointTransaction); ok {
+		return transaction, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error) {
+	args := m.Called(ctx, userID, offset, limit, unreadOnly)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockNotificationRepository) GetByUserIDAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error) {
+	args := m.Called(ctx, userID, afterID, limit)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetByID(ctx context.Context, id uint) (*entity.Notification, error) {
+	args := m.Called(ctx, id)
+	if notification, ok := args.Get(0).(*entity.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) Update(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) Delete(ctx context.Context, userID, notificationID uint) (bool, error) {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsRead(ctx context.Context, userID, notificationID uint) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupPointDomainService() (*service.PointDomainService, *MockUserMembershipRepository, *MockPointTransactionRepository, *MockNotificationRepository) {
+	userMembershipRepo := &MockUserMembershipRepository{}
+	pointTransactionRepo := &MockPointTransactionRepository{}
+	notificationRepo := &MockNotificationRepository{}
+	return service.NewPointDomainService(userMembershipRepo, pointTransactionRepo, notificationRepo, nil), userMembershipRepo, pointTransactionRepo, notificationRepo
+}
+
+func TestPointDomainServiceEarnPoints(t *testing.T) {
+	t.Run("獲得ポイントを台帳に記録する", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 1)
+
+		userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		pointTransactionRepo.On("Record", ctx, mock.MatchedBy(func(transaction *entity.PointTransaction) bool {
+			return transaction.UserID == 1 &&
+				transaction.Type == entity.PointTransactionTypeEarn &&
+				transaction.Points == 100 &&
+				transaction.ReferenceType == "campaign"
+		})).Run(func(args mock.Arguments) {
+			transaction := args.Get(1).(*entity.PointTransaction)
+			_ = membership.ApplyPointTransaction(transaction)
+		}).Return(membership, nil)
+
+		transaction, err := svc.EarnPoints(ctx, 1, 100, "キャンペーン", "campaign", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, transaction.BalanceAfter)
+		assert.Equal(t, 100, transaction.RemainingPoints)
+		assert.NotNil(t, transaction.ExpiresAt)
+		assert.WithinDuration(t, transaction.CreatedAt.Add(service.PointValidityPeriod), *transaction.ExpiresAt, time.Second)
+		pointTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("0以下のポイントはエラー", func(t *testing.T) {
+		svc, _, pointTransactionRepo, _ := setupPointDomainService()
+
+		_, err := svc.EarnPoints(context.Background(), 1, 0, "", "", nil)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPointAmount)
+		pointTransactionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("会員情報が存在しない場合はエラー", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+
+		userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+
+		_, err := svc.EarnPoints(ctx, 1, 100, "", "", nil)
+
+		assert.ErrorIs(t, err, service.ErrMembershipNotFound)
+		pointTransactionRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPointDomainServiceSpendPoints(t *testing.T) {
+	t.Run("使用ポイントは負数で記録する", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 300
+
+		userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		pointTransactionRepo.On("Record", ctx, mock.MatchedBy(func(transaction *entity.PointTransaction) bool {
+			return transaction.Type == entity.PointTransactionTypeSpend && transaction.Points == -120
+		})).Run(func(args mock.Arguments) {
+			transaction := args.Get(1).(*entity.PointTransaction)
+			_ = membership.ApplyPointTransaction(transaction)
+		}).Return(membership, nil)
+
+		transaction, err := svc.SpendPoints(ctx, 1, 120, "特典交換", "", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 180, transaction.BalanceAfter)
+	})
+
+	t.Run("残高不足はエラー", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+
+		userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(entity.NewUserMembership(1, 1), nil)
+		pointTransactionRepo.On("Record", ctx, mock.AnythingOfType("*entity.PointTransaction")).Return(nil, entity.ErrInsufficientPoints)
+
+		_, err := svc.SpendPoints(ctx, 1, 50, "", "", nil)
+
+		assert.ErrorIs(t, err, entity.ErrInsufficientPoints)
+	})
+}
+
+func newExpiredLot(id, userID uint, remaining int, expiresAt time.Time) *entity.PointTransaction {
+	lot := entity.NewPointTransaction(userID, entity.PointTransactionTypeEarn, remaining, "")
+	lot.ID = id
+	lot.RemainingPoints = remaining
+	lot.ExpiresAt = &expiresAt
+	return lot
+}
+
+func TestPointDomainServiceExpirePoints(t *testing.T) {
+	asOf := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	expiredAt := asOf.Add(-time.Hour)
+
+	t.Run("ドライランは変更せずにユーザーごとの失効予定を返す", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, notificationRepo := setupPointDomainService()
+		ctx := context.Background()
+
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 500
+		pointTransactionRepo.On("ListExpiredLots", ctx, asOf).Return([]*entity.PointTransaction{
+			newExpiredLot(1, 1, 100, expiredAt),
+			newExpiredLot(2, 1, 50, expiredAt),
+		}, nil)
+		userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+
+		result, err := svc.ExpirePoints(ctx, asOf, true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.TotalUsersAffected)
+		assert.Equal(t, 150, result.TotalPointsExpired)
+		assert.Equal(t, &entity.PointExpiration{UserID: 1, ExpiredPoints: 150, RemainingPoints: 350}, result.Details[0])
+		pointTransactionRepo.AssertNotCalled(t, "ExpirePoints", mock.Anything, mock.Anything, mock.Anything)
+		notificationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("本実行は失効取引を記録して通知する", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, notificationRepo := setupPointDomainService()
+		ctx := context.Background()
+
+		expireTransaction := entity.NewPointTransaction(2, entity.PointTransactionTypeExpire, -80, "Points expired")
+		expireTransaction.BalanceAfter = 20
+		pointTransactionRepo.On("ListExpiredLots", ctx, asOf).Return([]*entity.PointTransaction{newExpiredLot(3, 2, 80, expiredAt)}, nil)
+		userMembershipRepo.On("GetByUserID", ctx, uint(2)).Return(entity.NewUserMembership(2, 1), nil)
+		pointTransactionRepo.On("ExpirePoints", ctx, uint(2), asOf).Return(expireTransaction, nil)
+		notificationRepo.On("Create", ctx, mock.MatchedBy(func(notification *entity.Notification) bool {
+			return notification.UserID == 2 && notification.Type == service.NotificationTypePointsExpired
+		})).Return(nil)
+
+		result, err := svc.ExpirePoints(ctx, asOf, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 80, result.TotalPointsExpired)
+		assert.Equal(t, 20, result.Details[0].RemainingPoints)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("会員情報のないユーザーはスキップする", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+
+		pointTransactionRepo.On("ListExpiredLots", ctx, asOf).Return([]*entity.PointTransaction{newExpiredLot(4, 3, 10, expiredAt)}, nil)
+		userMembershipRepo.On("GetByUserID", ctx, uint(3)).Return(nil, errors.New("record not found"))
+
+		result, err := svc.ExpirePoints(ctx, asOf, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.TotalUsersAffected)
+		assert.Empty(t, result.Details)
+	})
+
+	t.Run("失効処理のエラーを返す", func(t *testing.T) {
+		svc, userMembershipRepo, pointTransactionRepo, _ := setupPointDomainService()
+		ctx := context.Background()
+
+		pointTransactionRepo.On("ListExpiredLots", ctx, asOf).Return([]*entity.PointTransaction{newExpiredLot(5, 4, 10, expiredAt)}, nil)
+		userMembershipRepo.On("GetByUserID", ctx, uint(4)).Return(entity.NewUserMembership(4, 1), nil)
+		pointTransactionRepo.On("ExpirePoints", ctx, uint(4), asOf).Return(nil, errors.New("deadlock"))
+
+		_, err := svc.ExpirePoints(ctx, asOf, false)
+
+		assert.Error(t, err)
+	})
+}
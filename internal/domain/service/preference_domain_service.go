@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+type PreferenceCache interface {
+	GetUserPreferences(ctx context.Context, userID uint) (map[string]string, error)
+	SetUserPreferences(ctx context.Context, userID uint, preferences map[string]string) error
+	DeleteUserPreferences(ctx context.Context, userID uint) error
+}
+
+type PreferenceDomainService struct {
+	preferenceRepo  repository.UserPreferenceRepository
+	preferenceCache PreferenceCache
+}
+
+func NewPreferenceDomainService(preferenceRepo repository.UserPreferenceRepository, preferenceCache PreferenceCache) *PreferenceDomainService {
+	return &PreferenceDomainService{
+		preferenceRepo:  preferenceRepo,
+		preferenceCache: preferenceCache,
+	}
+}
+
+func (s *PreferenceDomainService) GetPreferences(ctx context.Context, userID uint) ([]entity.PreferenceValue, error) {
+	stored, err := s.storedPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return entity.ResolvePreferences(stored), nil
+}
+
+func (s *PreferenceDomainService) SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	preferences := make([]*entity.UserPreference, 0, len(keys))
+	for _, key := range keys {
+		definition, ok := entity.LookupPreferenceDefinition(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", entity.ErrUnknownPreference, key)
+		}
+		stored, err := definition.Normalize(values[key])
+		if err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, entity.NewUserPreference(userID, definition, stored))
+	}
+
+	if err := s.preferenceRepo.Upsert(ctx, preferences); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, userID)
+
+	return s.GetPreferences(ctx, userID)
+}
+
+func (s *PreferenceDomainService) ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error) {
+	for _, key := range keys {
+		if _, ok := entity.LookupPreferenceDefinition(key); !ok {
+			return nil, fmt.Errorf("%w: %s", entity.ErrUnknownPreference, key)
+		}
+	}
+
+	if err := s.preferenceRepo.DeleteByKeys(ctx, userID, keys); err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, userID)
+
+	return s.GetPreferences(ctx, userID)
+}
+
+func (s *PreferenceDomainService) storedPreferences(ctx context.Context, userID uint) (map[string]string, error) {
+	if s.preferenceCache != nil {
+		if cached, err := s.preferenceCache.GetUserPreferences(ctx, userID); err == nil {
+			return cached, nil
+		}
+	}
+
+	preferences, err := s.preferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]string, len(preferences))
+	for _, preference := range preferences {
+		stored[preference.Key] = preference.Value
+	}
+
+	if s.preferenceCache != nil {
+		_ = s.preferenceCache.SetUserPreferences(ctx, userID, stored)
+	}
+	return stored, nil
+}
+
+func (s *PreferenceDomainService) invalidate(ctx context.Context, userID uint) {
+	if s.preferenceCache != nil {
+		_ = s.preferenceCache.DeleteUserPreferences(ctx, userID)
+	}
+}
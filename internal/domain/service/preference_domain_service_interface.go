@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PreferenceDomainServiceInterface interface {
+	GetPreferences(ctx context.Context, userID uint) ([]entity.PreferenceValue, error)
+	SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error)
+	ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error)
+}
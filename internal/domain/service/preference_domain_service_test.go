@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserPreferenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.UserPreference, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).([]*entity.UserPreference); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserPreferenceRepository) Upsert(ctx context.Context, preferences []*entity.UserPreference) error {
+	args := m.Called(ctx, preferences)
+	return args.Error(0)
+}
+
+func (m *MockUserPreferenceRepository) DeleteByKeys(ctx context.Context, userID uint, keys []string) error {
+	args := m.Called(ctx, userID, keys)
+	return args.Error(0)
+}
+
+type MockPreferenceCache struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceCache) GetUserPreferences(ctx context.Context, userID uint) (map[string]string, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).(map[string]string); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceCache) SetUserPreferences(ctx context.Context, userID uint, preferences map[string]string) error {
+	args := m.Called(ctx, userID, preferences)
+	return args.Error(0)
+}
+
+func (m *MockPreferenceCache) DeleteUserPreferences(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func preferenceValueOf(values []entity.PreferenceValue, key string) entity.PreferenceValue {
+	for _, value := range values {
+		if value.Definition.Key == key {
+			return value
+		}
+	}
+	return entity.PreferenceValue{}
+}
+
+func TestPreferenceDomainServiceGetPreferences(t *testing.T) {
+	t.Run("キャッシュにあればDBを参照しない", func(t *testing.T) {
+		preferenceRepo := &MockUserPreferenceRepository{}
+		preferenceCache := &MockPreferenceCache{}
+		svc := service.NewPreferenceDomainService(preferenceRepo, preferenceCache)
+		ctx := context.Background()
+
+		preferenceCache.On("GetUserPreferences", ctx, uint(1)).Return(map[string]string{"theme": "dark"}, nil)
+
+		values, err := svc.GetPreferences(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dark", preferenceValueOf(values, "theme").Value)
+		assert.True(t, preferenceValueOf(values, "language").IsDefault)
+		preferenceRepo.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("キャッシュになければDBから読み込みキャッシュする", func(t *testing.T) {
+		preferenceRepo := &MockUserPreferenceRepository{}
+		preferenceCache := &MockPreferenceCache{}
+		svc := service.NewPreferenceDomainService(preferenceRepo, preferenceCache)
+		ctx := context.Background()
+
+		preferenceCache.On("GetUserPreferences", ctx, uint(1)).Return(nil, errors.New("redis: nil"))
+		preferenceRepo.On("GetByUserID", ctx, uint(1)).Return([]*entity.UserPreference{
+			{UserID: 1, Category: "security", Key: "auto_logout_minutes", Value: "90"},
+		}, nil)
+		preferenceCache.On("SetUserPreferences", ctx, uint(1), map[string]string{"auto_logout_minutes": "90"}).Return(nil)
+
+		values, err := svc.GetPreferences(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90, preferenceValueOf(values, "auto_logout_minutes").Value)
+		assert.False(t, preferenceValueOf(values, "auto_logout_minutes").IsDefault)
+		preferenceRepo.AssertExpectations(t)
+		preferenceCache.AssertExpectations(t)
+	})
+}
+
+func TestPreferenceDomainServiceSetPreferences(t *testing.T) {
+	t.Run("検証して保存しキャッシュを破棄する", func(t *testing.T) {
+		preferenceRepo := &MockUserPreferenceRepository{}
+		preferenceCache := &MockPreferenceCache{}
+		svc := service.NewPreferenceDomainService(preferenceRepo, preferenceCache)
+		ctx := context.Background()
+
+		preferenceRepo.On("Upsert", ctx, mock.MatchedBy(func(preferences []*entity.UserPreference) bool {
+			return len(preferences) == 2 &&
+				preferences[0].Key == "notifications_push" && preferences[0].Value == "true" && preferences[0].Category == "notifications" &&
+				preferences[1].Key == "theme" && preferences[1].Value == "dark"
+		})).Return(nil)
+		preferenceCache.On("DeleteUserPreferences", ctx, uint(1)).Return(nil)
+		preferenceCache.On("GetUserPreferences", ctx, uint(1)).Return(map[string]string{"theme": "dark", "notifications_push": "true"}, nil)
+
+		values, err := svc.SetPreferences(ctx, 1, map[string]interface{}{"theme": "dark", "notifications_push": true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, true, preferenceValueOf(values, "notifications_push").Value)
+		preferenceRepo.AssertExpectations(t)
+		preferenceCache.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name        string
+		values      map[string]interface{}
+		expectedErr error
+	}{
+		{
+			name:        "未定義のキー",
+			values:      map[string]interface{}{"theme": "dark", "font": "serif"},
+			expectedErr: entity.ErrUnknownPreference,
+		},
+		{
+			name:        "型が一致しない",
+			values:      map[string]interface{}{"data_sharing": "yes"},
+			expectedErr: entity.ErrInvalidPreferenceValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferenceRepo := &MockUserPreferenceRepository{}
+			svc := service.NewPreferenceDomainService(preferenceRepo, nil)
+
+			_, err := svc.SetPreferences(context.Background(), 1, tt.values)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			preferenceRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPreferenceDomainServiceResetPreferences(t *testing.T) {
+	t.Run("指定したキーを既定値に戻す", func(t *testing.T) {
+		preferenceRepo := &MockUserPreferenceRepository{}
+		svc := service.NewPreferenceDomainService(preferenceRepo, nil)
+		ctx := context.Background()
+
+		preferenceRepo.On("DeleteByKeys", ctx, uint(1), []string{"theme"}).Return(nil)
+		preferenceRepo.On("GetByUserID", ctx, uint(1)).Return([]*entity.UserPreference{}, nil)
+
+		values, err := svc.ResetPreferences(ctx, 1, []string{"theme"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "system", preferenceValueOf(values, "theme").Value)
+		assert.True(t, preferenceValueOf(values, "theme").IsDefault)
+		preferenceRepo.AssertExpectations(t)
+	})
+
+	t.Run("未定義のキー", func(t *testing.T) {
+		preferenceRepo := &MockUserPreferenceRepository{}
+		svc := service.NewPreferenceDomainService(preferenceRepo, nil)
+
+		_, err := svc.ResetPreferences(context.Background(), 1, []string{"font"})
+
+		assert.ErrorIs(t, err, entity.ErrUnknownPreference)
+		preferenceRepo.AssertNotCalled(t, "DeleteByKeys", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+var (
+	ErrPurchaseNotFound = errors.New("purchase not found")
+	ErrPurchaseConflict = errors.New("purchase already exists with different details")
+)
+
+const PurchasePointRate = 0.01
+
+type PurchaseDomainService struct {
+	purchaseRepo       repository.PurchaseRepository
+	tierRepo           repository.MembershipTierRepository
+	userMembershipRepo repository.UserMembershipRepository
+	tierEvaluator      TierEvaluator
+}
+
+func NewPurchaseDomainService(
+	purchaseRepo repository.PurchaseRepository,
+	tierRepo repository.MembershipTierRepository,
+	userMembershipRepo repository.UserMembershipRepository,
+	tierEvaluator TierEvaluator,
+) *PurchaseDomainService {
+	return &PurchaseDomainService{
+		purchaseRepo:       purchaseRepo,
+		tierRepo:           tierRepo,
+		userMembershipRepo: userMembershipRepo,
+		tierEvaluator:      tierEvaluator,
+	}
+}
+
+func (s *PurchaseDomainService) RecordPurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, bool, error) {
+	if existing, err := s.purchaseRepo.GetByID(ctx, purchase.ID); err == nil {
+		return replayPurchase(existing, purchase)
+	}
+
+	membership, err := s.userMembershipRepo.GetByUserID(ctx, purchase.UserID)
+	if err != nil {
+		return nil, false, ErrMembershipNotFound
+	}
+
+	tier, err := s.tierRepo.GetByID(ctx, membership.TierID)
+	if err != nil {
+		return nil, false, ErrTierNotFound
+	}
+	benefits, err := tier.ParseBenefits()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := purchase.ApplyTierBenefits(benefits, PurchasePointRate); err != nil {
+		return nil, false, err
+	}
+
+	var transaction *entity.PointTransaction
+	if purchase.PointsEarned > 0 {
+		transaction = entity.NewPointTransaction(purchase.UserID, entity.PointTransactionTypeEarn, purchase.PointsEarned, "Purchase: "+purchase.ID)
+		transaction.ReferenceType = entity.PurchaseReferenceType
+		expiresAt := transaction.CreatedAt.Add(PointValidityPeriod)
+		transaction.ExpiresAt = &expiresAt
+	}
+
+	if _, err := s.purchaseRepo.Record(ctx, purchase, transaction); err != nil {
+		if existing, getErr := s.purchaseRepo.GetByID(ctx, purchase.ID); getErr == nil {
+			return replayPurchase(existing, purchase)
+		}
+		return nil, false, err
+	}
+
+	s.evaluateTier(ctx, purchase.UserID)
+	return purchase, true, nil
+}
+
+func (s *PurchaseDomainService) RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error) {
+	existing, err := s.purchaseRepo.GetByID(ctx, purchaseID)
+	if err != nil {
+		return nil, ErrPurchaseNotFound
+	}
+	if existing.IsRefunded() {
+		return nil, entity.ErrPurchaseAlreadyRefunded
+	}
+
+	purchase, _, err := s.purchaseRepo.Refund(ctx, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.evaluateTier(ctx, purchase.UserID)
+	return purchase, nil
+}
+
+func (s *PurchaseDomainService) GetUserPurchases(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error) {
+	return s.purchaseRepo.GetByUserID(ctx, userID, offset, limit)
+}
+
+func (s *PurchaseDomainService) evaluateTier(ctx context.Context, userID uint) {
+	if s.tierEvaluator == nil {
+		return
+	}
+	_, _ = s.tierEvaluator.EvaluateUserTier(ctx, userID)
+}
+
+func replayPurchase(existing, requested *entity.Purchase) (*entity.Purchase, bool, error) {
+	if !existing.Matches(requested) {
+		return nil, false, ErrPurchaseConflict
+	}
+	return existing, false, nil
+}
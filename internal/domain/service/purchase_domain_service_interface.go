@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PurchaseDomainServiceInterface interface {
+	RecordPurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, bool, error)
+	RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error)
+	GetUserPurchases(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error)
+}
@@ -0,0 +1,206 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPurchaseRepository struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseRepository) GetByID(ctx context.Context, id string) (*entity.Purchase, error) {
+	args := m.Called(ctx, id)
+	if purchase, ok := args.Get(0).(*entity.Purchase); ok {
+		return purchase, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPurchaseRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if purchases, ok := args.Get(0).([]*entity.Purchase); ok {
+		return purchases, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPurchaseRepository) Record(ctx context.Context, purchase *entity.Purchase, transaction *entity.PointTransaction) (*entity.UserMembership, error) {
+	args := m.Called(ctx, purchase, transaction)
+	if membership, ok := args.Get(0).(*entity.UserMembership); ok {
+		return membership, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPurchaseRepository) Refund(ctx context.Context, purchaseID string) (*entity.Purchase, *entity.UserMembership, error) {
+	args := m.Called(ctx, purchaseID)
+	purchase, _ := args.Get(0).(*entity.Purchase)
+	membership, _ := args.Get(1).(*entity.UserMembership)
+	return purchase, membership, args.Error(2)
+}
+
+type MockTierEvaluator struct {
+	mock.Mock
+}
+
+func (m *MockTierEvaluator) EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error) {
+	args := m.Called(ctx, userID)
+	if change, ok := args.Get(0).(*entity.TierChange); ok {
+		return change, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type purchaseDomainServiceMocks struct {
+	purchaseRepo       *MockPurchaseRepository
+	tierRepo           *MockMembershipTierRepository
+	userMembershipRepo *MockUserMembershipRepository
+	tierEvaluator      *MockTierEvaluator
+}
+
+func setupPurchaseDomainService() (*service.PurchaseDomainService, *purchaseDomainServiceMocks) {
+	mocks := &purchaseDomainServiceMocks{
+		purchaseRepo:       &MockPurchaseRepository{},
+		tierRepo:           &MockMembershipTierRepository{},
+		userMembershipRepo: &MockUserMembershipRepository{},
+		tierEvaluator:      &MockTierEvaluator{},
+	}
+	svc := service.NewPurchaseDomainService(mocks.purchaseRepo, mocks.tierRepo, mocks.userMembershipRepo, mocks.tierEvaluator)
+	return svc, mocks
+}
+
+func TestPurchaseDomainServiceRecordPurchase(t *testing.T) {
+	t.Run("ティアの倍率と割引を適用して記録する", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+		gold := entity.NewMembershipTier("Gold", 3, "", `{"point_multiplier":1.5,"discount_rate":0.05}`, "")
+		gold.ID = 3
+		membership := entity.NewUserMembership(1, 3)
+		purchase := entity.NewPurchase("order-1", 1, 10, 10000)
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(nil, errors.New("record not found")).Once()
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("GetByID", ctx, uint(3)).Return(gold, nil)
+		mocks.purchaseRepo.On("Record", ctx, purchase, mock.MatchedBy(func(transaction *entity.PointTransaction) bool {
+			return transaction.Type == entity.PointTransactionTypeEarn &&
+				transaction.Points == 142 &&
+				transaction.ReferenceType == entity.PurchaseReferenceType &&
+				transaction.ExpiresAt != nil
+		})).Return(membership, nil)
+		mocks.tierEvaluator.On("EvaluateUserTier", ctx, uint(1)).Return(nil, nil)
+
+		recorded, created, err := svc.RecordPurchase(ctx, purchase)
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, 142, recorded.PointsEarned)
+		assert.Equal(t, 0.05, recorded.TierDiscountApplied)
+		mocks.purchaseRepo.AssertExpectations(t)
+		mocks.tierEvaluator.AssertExpectations(t)
+	})
+
+	t.Run("同じ購入IDの再送は既存の購入を返す", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+		existing := entity.NewPurchase("order-1", 1, 10, 10000)
+		existing.PointsEarned = 100
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(existing, nil)
+
+		recorded, created, err := svc.RecordPurchase(ctx, entity.NewPurchase("order-1", 1, 10, 10000))
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Same(t, existing, recorded)
+		mocks.purchaseRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything)
+		mocks.tierEvaluator.AssertNotCalled(t, "EvaluateUserTier", mock.Anything, mock.Anything)
+	})
+
+	t.Run("同じ購入IDで内容が異なる場合はエラー", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(entity.NewPurchase("order-1", 1, 10, 10000), nil)
+
+		_, _, err := svc.RecordPurchase(ctx, entity.NewPurchase("order-1", 2, 10, 10000))
+
+		assert.ErrorIs(t, err, service.ErrPurchaseConflict)
+	})
+
+	t.Run("会員情報が存在しない場合はエラー", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(nil, errors.New("record not found"))
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+
+		_, _, err := svc.RecordPurchase(ctx, entity.NewPurchase("order-1", 1, 10, 10000))
+
+		assert.ErrorIs(t, err, service.ErrMembershipNotFound)
+	})
+}
+
+func TestPurchaseDomainServiceRefundPurchase(t *testing.T) {
+	t.Run("返金してティアを再評価する", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+		purchase := entity.NewPurchase("order-1", 1, 10, 10000)
+		refunded := entity.NewPurchase("order-1", 1, 10, 10000)
+		refunded.MarkRefunded()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(purchase, nil)
+		mocks.purchaseRepo.On("Refund", ctx, "order-1").Return(refunded, entity.NewUserMembership(1, 1), nil)
+		mocks.tierEvaluator.On("EvaluateUserTier", ctx, uint(1)).Return(nil, nil)
+
+		result, err := svc.RefundPurchase(ctx, "order-1")
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsRefunded())
+		mocks.tierEvaluator.AssertExpectations(t)
+	})
+
+	t.Run("獲得ポイントが使用済みの場合はエラー", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(entity.NewPurchase("order-1", 1, 10, 10000), nil)
+		mocks.purchaseRepo.On("Refund", ctx, "order-1").Return(nil, nil, entity.ErrPurchasePointsSpent)
+
+		_, err := svc.RefundPurchase(ctx, "order-1")
+
+		assert.ErrorIs(t, err, entity.ErrPurchasePointsSpent)
+		mocks.tierEvaluator.AssertNotCalled(t, "EvaluateUserTier", mock.Anything, mock.Anything)
+	})
+
+	t.Run("返金済みの場合はエラー", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+		purchase := entity.NewPurchase("order-1", 1, 10, 10000)
+		purchase.MarkRefunded()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "order-1").Return(purchase, nil)
+
+		_, err := svc.RefundPurchase(ctx, "order-1")
+
+		assert.ErrorIs(t, err, entity.ErrPurchaseAlreadyRefunded)
+		mocks.purchaseRepo.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない購入はエラー", func(t *testing.T) {
+		svc, mocks := setupPurchaseDomainService()
+		ctx := context.Background()
+
+		mocks.purchaseRepo.On("GetByID", ctx, "missing").Return(nil, errors.New("record not found"))
+
+		_, err := svc.RefundPurchase(ctx, "missing")
+
+		assert.ErrorIs(t, err, service.ErrPurchaseNotFound)
+	})
+}
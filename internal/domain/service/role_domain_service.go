@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+type RoleActor struct {
+	UserID    uint
+	IPAddress string
+	UserAgent string
+}
+
+type RoleDomainService struct {
+	roleRepo          repository.RoleRepository
+	permissionRepo    repository.PermissionRepository
+	userRepo          repository.UserRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	permissionService PermissionDomainServiceInterface
+	tokenVersions     TokenVersionStore
+}
+
+func NewRoleDomainService(
+	roleRepo repository.RoleRepository,
+	permissionRepo repository.PermissionRepository,
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	permissionService PermissionDomainServiceInterface,
+	tokenVersions TokenVersionStore,
+) *RoleDomainService {
+	return &RoleDomainService{
+		roleRepo:          roleRepo,
+		permissionRepo:    permissionRepo,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		permissionService: permissionService,
+		tokenVersions:     tokenVersions,
+	}
+}
+
+func (s *RoleDomainService) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if err := s.loadPermissions(ctx, role); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func (s *RoleDomainService) GetRole(ctx context.Context, roleID uint) (*entity.Role, error) {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadPermissions(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *RoleDomainService) ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error) {
+	return s.roleRepo.ListMembers(ctx, roleID, offset, limit)
+}
+
+func (s *RoleDomainService) CreateRole(ctx context.Context, actor RoleActor, name, description string, permissionNames []string) (*entity.Role, error) {
+	if err := entity.ValidateRoleName(name); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, name); err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.grantablePermissions(ctx, actor, permissionNames)
+	if err != nil {
+		return nil, err
+	}
+
+	role := entity.NewRole(name, description)
+	role.Permissions = permissions
+
+	eventDescription := fmt.Sprintf("Role %s created by admin %d with permissions %v", role.Name, actor.UserID, role.PermissionNames())
+	event := s.newEvent(actor, &actor.UserID, entity.SecurityEventRoleCreated, eventDescription, role.SecurityEventSeverity())
+	if err := s.roleRepo.CreateWithPermissions(ctx, role, permissionIDs(permissions), event); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *RoleDomainService) UpdateRole(ctx context.Context, actor RoleActor, roleID uint, name, description *string, permissionNames []string) (*entity.Role, error) {
+	role, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := name != nil && *name != role.Name
+	if renamed {
+		if role.IsSystem() {
+			return nil, entity.ErrSystemRole
+		}
+		if err := entity.ValidateRoleName(*name); err != nil {
+			return nil, err
+		}
+		if err := s.ensureNameAvailable(ctx, *name); err != nil {
+			return nil, err
+		}
+		role.Name = *name
+	}
+	if description != nil {
+		role.Description = *description
+	}
+
+	var replacementIDs []uint
+	if permissionNames != nil {
+		permissions, err := s.grantablePermissions(ctx, actor, permissionNames)
+		if err != nil {
+			return nil, err
+		}
+		if !role.RetainsAdminPermissions(permissions) {
+			return nil, entity.ErrAdminRolePermissions
+		}
+		role.Permissions = permissions
+		replacementIDs = permissionIDs(permissions)
+	}
+
+	eventDescription := fmt.Sprintf("Role %d updated by admin %d: name=%s permissions=%v", role.ID, actor.UserID, role.Name, role.PermissionNames())
+	event := s.newEvent(actor, &actor.UserID, entity.SecurityEventRoleUpdated, eventDescription, role.SecurityEventSeverity())
+	if err := s.roleRepo.UpdateWithPermissions(ctx, role, replacementIDs, event); err != nil {
+		return nil, err
+	}
+
+	if renamed || permissionNames != nil {
+		memberIDs, err := s.roleRepo.GetMemberIDs(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, memberID := range memberIDs {
+			if err := s.forceReauthentication(ctx, memberID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return role, nil
+}
+
+func (s *RoleDomainService) DeleteRole(ctx context.Context, actor RoleActor, roleID uint) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem() {
+		return entity.ErrSystemRole
+	}
+
+	memberIDs, err := s.roleRepo.GetMemberIDs(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if len(memberIDs) > 0 {
+		return entity.ErrRoleInUse
+	}
+
+	description := fmt.Sprintf("Role %s deleted by admin %d", role.Name, actor.UserID)
+	event := s.newEvent(actor, &actor.UserID, entity.SecurityEventRoleDeleted, description, role.SecurityEventSeverity())
+	return s.roleRepo.DeleteWithEvent(ctx, roleID, event)
+}
+
+func (s *RoleDomainService) AssignRole(ctx context.Context, actor RoleActor, userID, roleID uint) error {
+	if actor.UserID == userID {
+		return entity.ErrSelfRoleEscalation
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return ErrUserNotFound
+	}
+
+	role, err := s.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureGrantable(ctx, actor, role.Permissions); err != nil {
+		return err
+	}
+
+	assigned, err := s.roleRepo.HasUserRole(ctx, userID, roleID)
+	if err != nil {
+		return err
+	}
+	if assigned {
+		return entity.ErrRoleAlreadyAssigned
+	}
+
+	description := fmt.Sprintf("Role %s assigned to user %d by admin %d", role.Name, userID, actor.UserID)
+	event := s.newEvent(actor, &userID, entity.SecurityEventRoleAssigned, description, role.SecurityEventSeverity())
+	if err := s.roleRepo.AssignToUserWithEvent(ctx, userID, roleID, event); err != nil {
+		return err
+	}
+	return s.forceReauthentication(ctx, userID)
+}
+
+func (s *RoleDomainService) RevokeRole(ctx context.Context, actor RoleActor, userID, roleID uint) error {
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	userRoles, err := s.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(userRoles, func(userRole *entity.Role) bool { return userRole.ID == roleID }) {
+		return entity.ErrRoleAssignmentNotFound
+	}
+	if len(userRoles) == 1 {
+		return entity.ErrLastUserRole
+	}
+
+	description := fmt.Sprintf("Role %s revoked from user %d by admin %d", role.Name, userID, actor.UserID)
+	event := s.newEvent(actor, &userID, entity.SecurityEventRoleRevoked, description, role.SecurityEventSeverity())
+	if role.Name == entity.RoleNameAdmin {
+		err = s.roleRepo.RemoveFromUserUnlessLast(ctx, userID, roleID, event)
+	} else {
+		err = s.roleRepo.RemoveFromUserWithEvent(ctx, userID, roleID, event)
+	}
+	if err != nil {
+		return err
+	}
+	return s.forceReauthentication(ctx, userID)
+}
+
+func (s *RoleDomainService) loadPermissions(ctx context.Context, role *entity.Role) error {
+	permissions, err := s.permissionRepo.GetRolePermissions(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+	return nil
+}
+
+func (s *RoleDomainService) ensureNameAvailable(ctx context.Context, name string) error {
+	_, err := s.roleRepo.GetByName(ctx, name)
+	switch {
+	case err == nil:
+		return entity.ErrRoleAlreadyExists
+	case errors.Is(err, entity.ErrRoleNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+func (s *RoleDomainService) grantablePermissions(ctx context.Context, actor RoleActor, names []string) ([]*entity.Permission, error) {
+	if len(names) == 0 {
+		return []*entity.Permission{}, nil
+	}
+
+	permissions, err := s.permissionRepo.GetByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(permissions, func(permission *entity.Permission) bool { return permission.Name == name }) {
+			return nil, fmt.Errorf("%w: unknown permission %s", entity.ErrInvalidPermission, name)
+		}
+	}
+
+	if err := s.ensureGrantable(ctx, actor, permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// ensureGrantable keeps admins from handing out permissions they do not hold
+// themselves, which would otherwise let a narrower admin role escalate.
+func (s *RoleDomainService) ensureGrantable(ctx context.Context, actor RoleActor, permissions []*entity.Permission) error {
+	held, err := s.permissionService.ResolvePermissions(ctx, actor.UserID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !held.Allows(permission.Grant()) {
+			return fmt.Errorf("%w: %s", entity.ErrPermissionEscalation, permission.Name)
+		}
+	}
+	return nil
+}
+
+func (s *RoleDomainService) forceReauthentication(ctx context.Context, userID uint) error {
+	s.permissionService.InvalidateUserPermissions(ctx, userID)
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	if s.tokenVersions != nil {
+		if _, err := s.tokenVersions.IncrementTokenVersion(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke access tokens: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *RoleDomainService) newEvent(actor RoleActor, userID *uint, eventType, description, severity string) *entity.SecurityEvent {
+	return entity.NewSecurityEvent(userID, eventType, description, actor.IPAddress, actor.UserAgent, severity)
+}
+
+func permissionIDs(permissions []*entity.Permission) []uint {
+	ids := make([]uint, len(permissions))
+	for i, permission := range permissions {
+		ids[i] = permission.ID
+	}
+	return ids
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type RoleDomainServiceInterface interface {
+	ListRoles(ctx context.Context) ([]*entity.Role, error)
+	GetRole(ctx context.Context, roleID uint) (*entity.Role, error)
+	ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error)
+	CreateRole(ctx context.Context, actor RoleActor, name, description string, permissionNames []string) (*entity.Role, error)
+	UpdateRole(ctx context.Context, actor RoleActor, roleID uint, name, description *string, permissionNames []string) (*entity.Role, error)
+	DeleteRole(ctx context.Context, actor RoleActor, roleID uint) error
+	AssignRole(ctx context.Context, actor RoleActor, userID, roleID uint) error
+	RevokeRole(ctx context.Context, actor RoleActor, userID, roleID uint) error
+}
@@ -0,0 +1,356 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type roleServiceMocks struct {
+	roleRepo         *MockRoleRepository
+	permissionRepo   *MockPermissionRepository
+	userRepo         *MockUserRepository
+	refreshTokenRepo *MockRefreshTokenRepository
+	permissionCache  *MockPermissionCache
+	tokenVersions    *testRateLimitCache
+}
+
+func newRoleDomainServiceForTest(actorPermissions ...string) (*service.RoleDomainService, *roleServiceMocks) {
+	mocks := &roleServiceMocks{
+		roleRepo:         &MockRoleRepository{},
+		permissionRepo:   &MockPermissionRepository{},
+		userRepo:         &MockUserRepository{},
+		refreshTokenRepo: &MockRefreshTokenRepository{},
+		permissionCache:  &MockPermissionCache{},
+		tokenVersions:    newTestRateLimitCache(),
+	}
+	mocks.permissionCache.On("GetUserPermissions", mock.Anything, uint(1)).Return(actorPermissions, nil)
+
+	permissionService := service.NewPermissionDomainService(mocks.permissionRepo, mocks.roleRepo, mocks.permissionCache)
+	svc := service.NewRoleDomainService(mocks.roleRepo, mocks.permissionRepo, mocks.userRepo, mocks.refreshTokenRepo, permissionService, mocks.tokenVersions)
+	return svc, mocks
+}
+
+func TestRoleDomainServiceCreateRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1, IPAddress: "192.0.2.1"}
+
+	t.Run("保有する権限でロールを作成する", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("fraud:*:*")
+		ctx := context.Background()
+		permission := &entity.Permission{ID: 5, Name: "fraud.read", Resource: "fraud", Action: "read", Scope: "*"}
+
+		mocks.roleRepo.On("GetByName", ctx, "moderator").Return(nil, entity.ErrRoleNotFound)
+		mocks.permissionRepo.On("GetByNames", ctx, []string{"fraud.read"}).Return([]*entity.Permission{permission}, nil)
+		mocks.roleRepo.On("CreateWithPermissions", ctx, mock.AnythingOfType("*entity.Role"), []uint{5}, mock.MatchedBy(func(event *entity.SecurityEvent) bool {
+			return event.EventType == entity.SecurityEventRoleCreated && *event.UserID == 1
+		})).Return(nil)
+
+		role, err := svc.CreateRole(ctx, actor, "moderator", "", []string{"fraud.read"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"fraud.read"}, role.PermissionNames())
+		mocks.roleRepo.AssertExpectations(t)
+	})
+
+	t.Run("保有しない権限は付与できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("fraud:read:*")
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByName", ctx, "moderator").Return(nil, entity.ErrRoleNotFound)
+		mocks.permissionRepo.On("GetByNames", ctx, []string{"fraud.write"}).Return([]*entity.Permission{
+			{ID: 6, Name: "fraud.write", Resource: "fraud", Action: "write", Scope: "*"},
+		}, nil)
+
+		_, err := svc.CreateRole(ctx, actor, "moderator", "", []string{"fraud.write"})
+
+		assert.ErrorIs(t, err, entity.ErrPermissionEscalation)
+		mocks.roleRepo.AssertNotCalled(t, "CreateWithPermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない権限", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByName", ctx, "moderator").Return(nil, entity.ErrRoleNotFound)
+		mocks.permissionRepo.On("GetByNames", ctx, []string{"unknown.read"}).Return([]*entity.Permission{}, nil)
+
+		_, err := svc.CreateRole(ctx, actor, "moderator", "", []string{"unknown.read"})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPermission)
+	})
+
+	t.Run("重複するロール名", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByName", ctx, "moderator").Return(&entity.Role{ID: 3, Name: "moderator"}, nil)
+
+		_, err := svc.CreateRole(ctx, actor, "moderator", "", nil)
+
+		assert.ErrorIs(t, err, entity.ErrRoleAlreadyExists)
+	})
+}
+
+func TestRoleDomainServiceUpdateRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+
+	t.Run("システムロールは名前を変更できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+		name := "superuser"
+
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(&entity.Role{ID: 1, Name: "admin"}, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return([]*entity.Permission{}, nil)
+
+		_, err := svc.UpdateRole(ctx, actor, 1, &name, nil, nil)
+
+		assert.ErrorIs(t, err, entity.ErrSystemRole)
+	})
+
+	t.Run("管理者ロールから管理者権限を外せない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(&entity.Role{ID: 1, Name: "admin"}, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return([]*entity.Permission{
+			{ID: 2, Name: "admin.write", Resource: "admin", Action: "write", Scope: "*"},
+		}, nil)
+
+		_, err := svc.UpdateRole(ctx, actor, 1, nil, nil, []string{})
+
+		assert.ErrorIs(t, err, entity.ErrAdminRolePermissions)
+		mocks.roleRepo.AssertNotCalled(t, "UpdateWithPermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("権限変更でメンバーに再認証を求める", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("fraud:*:*")
+		ctx := context.Background()
+		permission := &entity.Permission{ID: 5, Name: "fraud.read", Resource: "fraud", Action: "read", Scope: "*"}
+
+		mocks.roleRepo.On("GetByID", ctx, uint(3)).Return(&entity.Role{ID: 3, Name: "moderator"}, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(3)).Return([]*entity.Permission{}, nil)
+		mocks.permissionRepo.On("GetByNames", ctx, []string{"fraud.read"}).Return([]*entity.Permission{permission}, nil)
+		mocks.roleRepo.On("UpdateWithPermissions", ctx, mock.AnythingOfType("*entity.Role"), []uint{5}, mock.MatchedBy(func(event *entity.SecurityEvent) bool {
+			return event.EventType == entity.SecurityEventRoleUpdated
+		})).Return(nil)
+		mocks.roleRepo.On("GetMemberIDs", ctx, uint(3)).Return([]uint{7, 8}, nil)
+		mocks.permissionCache.On("DeleteUserPermissions", ctx, mock.Anything).Return(nil)
+		mocks.refreshTokenRepo.On("RevokeByUserID", ctx, uint(7)).Return(nil)
+		mocks.refreshTokenRepo.On("RevokeByUserID", ctx, uint(8)).Return(nil)
+
+		role, err := svc.UpdateRole(ctx, actor, 3, nil, nil, []string{"fraud.read"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"fraud.read"}, role.PermissionNames())
+		mocks.refreshTokenRepo.AssertExpectations(t)
+		mocks.permissionCache.AssertCalled(t, "DeleteUserPermissions", ctx, uint(7))
+		mocks.permissionCache.AssertCalled(t, "DeleteUserPermissions", ctx, uint(8))
+	})
+
+	t.Run("説明のみの変更では再認証しない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+		description := "Moderators"
+
+		mocks.roleRepo.On("GetByID", ctx, uint(3)).Return(&entity.Role{ID: 3, Name: "moderator"}, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(3)).Return([]*entity.Permission{}, nil)
+		mocks.roleRepo.On("UpdateWithPermissions", ctx, mock.AnythingOfType("*entity.Role"), []uint(nil), mock.AnythingOfType("*entity.SecurityEvent")).Return(nil)
+
+		role, err := svc.UpdateRole(ctx, actor, 3, nil, &description, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Moderators", role.Description)
+		mocks.roleRepo.AssertNotCalled(t, "GetMemberIDs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleDomainServiceDeleteRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+
+	tests := []struct {
+		name          string
+		role          *entity.Role
+		memberIDs     []uint
+		expectedError error
+	}{
+		{name: "システムロール", role: &entity.Role{ID: 2, Name: "user"}, expectedError: entity.ErrSystemRole},
+		{name: "メンバーがいるロール", role: &entity.Role{ID: 3, Name: "moderator"}, memberIDs: []uint{7}, expectedError: entity.ErrRoleInUse},
+		{name: "メンバーがいないロール", role: &entity.Role{ID: 3, Name: "moderator"}, memberIDs: []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mocks := newRoleDomainServiceForTest()
+			ctx := context.Background()
+
+			mocks.roleRepo.On("GetByID", ctx, tt.role.ID).Return(tt.role, nil)
+			mocks.roleRepo.On("GetMemberIDs", ctx, tt.role.ID).Return(tt.memberIDs, nil)
+			mocks.roleRepo.On("DeleteWithEvent", ctx, tt.role.ID, mock.MatchedBy(func(event *entity.SecurityEvent) bool {
+				return event.EventType == entity.SecurityEventRoleDeleted
+			})).Return(nil)
+
+			err := svc.DeleteRole(ctx, actor, tt.role.ID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				mocks.roleRepo.AssertNotCalled(t, "DeleteWithEvent", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mocks.roleRepo.AssertCalled(t, "DeleteWithEvent", ctx, tt.role.ID, mock.Anything)
+		})
+	}
+}
+
+func TestRoleDomainServiceAssignRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+	adminRole := &entity.Role{ID: 1, Name: "admin"}
+	adminPermissions := []*entity.Permission{{ID: 1, Name: "admin.all", Resource: "admin", Action: "*", Scope: "*"}}
+
+	t.Run("自分自身には付与できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+
+		err := svc.AssignRole(context.Background(), actor, 1, 1)
+
+		assert.ErrorIs(t, err, entity.ErrSelfRoleEscalation)
+		mocks.roleRepo.AssertNotCalled(t, "AssignToUserWithEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("保有しない権限を持つロールは付与できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("user:*:*")
+		ctx := context.Background()
+
+		mocks.userRepo.On("GetByID", ctx, uint(2)).Return(&entity.User{ID: 2}, nil)
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return(adminPermissions, nil)
+
+		err := svc.AssignRole(ctx, actor, 2, 1)
+
+		assert.ErrorIs(t, err, entity.ErrPermissionEscalation)
+		mocks.roleRepo.AssertNotCalled(t, "AssignToUserWithEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("付与済みのロール", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+		ctx := context.Background()
+
+		mocks.userRepo.On("GetByID", ctx, uint(2)).Return(&entity.User{ID: 2}, nil)
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return(adminPermissions, nil)
+		mocks.roleRepo.On("HasUserRole", ctx, uint(2), uint(1)).Return(true, nil)
+
+		err := svc.AssignRole(ctx, actor, 2, 1)
+
+		assert.ErrorIs(t, err, entity.ErrRoleAlreadyAssigned)
+	})
+
+	t.Run("付与して再認証を求め監査ログを残す", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+		ctx := context.Background()
+
+		mocks.userRepo.On("GetByID", ctx, uint(2)).Return(&entity.User{ID: 2}, nil)
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return(adminPermissions, nil)
+		mocks.roleRepo.On("HasUserRole", ctx, uint(2), uint(1)).Return(false, nil)
+		mocks.roleRepo.On("AssignToUserWithEvent", ctx, uint(2), uint(1), mock.MatchedBy(func(event *entity.SecurityEvent) bool {
+			return event.EventType == entity.SecurityEventRoleAssigned && *event.UserID == 2 && event.Severity == "HIGH"
+		})).Return(nil)
+		mocks.permissionCache.On("DeleteUserPermissions", ctx, uint(2)).Return(nil)
+		mocks.refreshTokenRepo.On("RevokeByUserID", ctx, uint(2)).Return(nil)
+
+		err := svc.AssignRole(ctx, actor, 2, 1)
+
+		assert.NoError(t, err)
+		version, _ := mocks.tokenVersions.GetTokenVersion(ctx, 2)
+		assert.Equal(t, int64(1), version)
+		mocks.roleRepo.AssertExpectations(t)
+		mocks.refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("監査ログと一緒に書き込めなければ付与しない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest("*:*:*")
+		ctx := context.Background()
+
+		mocks.userRepo.On("GetByID", ctx, uint(2)).Return(&entity.User{ID: 2}, nil)
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.permissionRepo.On("GetRolePermissions", ctx, uint(1)).Return(adminPermissions, nil)
+		mocks.roleRepo.On("HasUserRole", ctx, uint(2), uint(1)).Return(false, nil)
+		mocks.roleRepo.On("AssignToUserWithEvent", ctx, uint(2), uint(1), mock.AnythingOfType("*entity.SecurityEvent")).Return(errors.New("database error"))
+
+		err := svc.AssignRole(ctx, actor, 2, 1)
+
+		assert.EqualError(t, err, "database error")
+		mocks.refreshTokenRepo.AssertNotCalled(t, "RevokeByUserID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleDomainServiceRevokeRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+	adminRole := &entity.Role{ID: 1, Name: "admin"}
+	userRole := &entity.Role{ID: 2, Name: "user"}
+
+	t.Run("付与されていないロール", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.roleRepo.On("GetUserRoles", ctx, uint(2)).Return([]*entity.Role{userRole}, nil)
+
+		err := svc.RevokeRole(ctx, actor, 2, 1)
+
+		assert.ErrorIs(t, err, entity.ErrRoleAssignmentNotFound)
+	})
+
+	t.Run("唯一のロールは剥奪できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByID", ctx, uint(2)).Return(userRole, nil)
+		mocks.roleRepo.On("GetUserRoles", ctx, uint(2)).Return([]*entity.Role{userRole}, nil)
+
+		err := svc.RevokeRole(ctx, actor, 2, 2)
+
+		assert.ErrorIs(t, err, entity.ErrLastUserRole)
+	})
+
+	t.Run("最後の管理者は剥奪できない", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+
+		mocks.roleRepo.On("GetByID", ctx, uint(1)).Return(adminRole, nil)
+		mocks.roleRepo.On("GetUserRoles", ctx, uint(2)).Return([]*entity.Role{adminRole, userRole}, nil)
+		mocks.roleRepo.On("RemoveFromUserUnlessLast", ctx, uint(2), uint(1), mock.AnythingOfType("*entity.SecurityEvent")).Return(entity.ErrLastRoleMember)
+
+		err := svc.RevokeRole(ctx, actor, 2, 1)
+
+		assert.ErrorIs(t, err, entity.ErrLastRoleMember)
+		mocks.refreshTokenRepo.AssertNotCalled(t, "RevokeByUserID", mock.Anything, mock.Anything)
+		version, _ := mocks.tokenVersions.GetTokenVersion(ctx, 2)
+		assert.Zero(t, version)
+	})
+
+	t.Run("一般ロールを剥奪して再認証を求める", func(t *testing.T) {
+		svc, mocks := newRoleDomainServiceForTest()
+		ctx := context.Background()
+		moderatorRole := &entity.Role{ID: 3, Name: "moderator"}
+
+		mocks.roleRepo.On("GetByID", ctx, uint(3)).Return(moderatorRole, nil)
+		mocks.roleRepo.On("GetUserRoles", ctx, uint(2)).Return([]*entity.Role{userRole, moderatorRole}, nil)
+		mocks.roleRepo.On("RemoveFromUserWithEvent", ctx, uint(2), uint(3), mock.MatchedBy(func(event *entity.SecurityEvent) bool {
+			return event.EventType == entity.SecurityEventRoleRevoked && event.Severity == "MEDIUM"
+		})).Return(nil)
+		mocks.permissionCache.On("DeleteUserPermissions", ctx, uint(2)).Return(nil)
+		mocks.refreshTokenRepo.On("RevokeByUserID", ctx, uint(2)).Return(nil)
+
+		err := svc.RevokeRole(ctx, actor, 2, 3)
+
+		assert.NoError(t, err)
+		mocks.roleRepo.AssertExpectations(t)
+		mocks.refreshTokenRepo.AssertExpectations(t)
+	})
+}
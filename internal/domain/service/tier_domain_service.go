@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+)
+
+var (
+	ErrTierNotFound      = errors.New("tier not found")
+	ErrTierAlreadyExists = errors.New("tier already exists")
+	ErrTierInUse         = errors.New("tier is in use by members")
+)
+
+const (
+	TierGracePeriod             = 30 * 24 * time.Hour
+	NotificationTypeTierChanged = "tier_changed"
+	tierEvaluationBatchSize     = 100
+)
+
+type TierEvaluator interface {
+	EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error)
+}
+
+type TierDomainService struct {
+	tierRepo           repository.MembershipTierRepository
+	userMembershipRepo repository.UserMembershipRepository
+	tierHistoryRepo    repository.MembershipTierHistoryRepository
+	notificationRepo   repository.NotificationRepository
+	gracePeriod        time.Duration
+}
+
+type tierRule struct {
+	tier         *entity.MembershipTier
+	requirements *entity.TierRequirements
+}
+
+func NewTierDomainService(
+	tierRepo repository.MembershipTierRepository,
+	userMembershipRepo repository.UserMembershipRepository,
+	tierHistoryRepo repository.MembershipTierHistoryRepository,
+	notificationRepo repository.NotificationRepository,
+) *TierDomainService {
+	return &TierDomainService{
+		tierRepo:           tierRepo,
+		userMembershipRepo: userMembershipRepo,
+		tierHistoryRepo:    tierHistoryRepo,
+		notificationRepo:   notificationRepo,
+		gracePeriod:        TierGracePeriod,
+	}
+}
+
+func (s *TierDomainService) GetTiers(ctx context.Context) ([]*entity.MembershipTier, error) {
+	return s.tierRepo.List(ctx)
+}
+
+func (s *TierDomainService) GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	tier, err := s.tierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrTierNotFound
+	}
+	return tier, nil
+}
+
+func (s *TierDomainService) CreateTier(ctx context.Context, tier *entity.MembershipTier) error {
+	if _, err := tier.ParseRequirements(); err != nil {
+		return err
+	}
+	if _, err := tier.ParseBenefits(); err != nil {
+		return err
+	}
+	if existing, err := s.tierRepo.GetByName(ctx, tier.Name); err == nil && existing != nil {
+		return ErrTierAlreadyExists
+	}
+	return s.tierRepo.Create(ctx, tier)
+}
+
+func (s *TierDomainService) UpdateTier(ctx context.Context, tier *entity.MembershipTier) error {
+	if _, err := tier.ParseRequirements(); err != nil {
+		return err
+	}
+	if _, err := tier.ParseBenefits(); err != nil {
+		return err
+	}
+	if existing, err := s.tierRepo.GetByName(ctx, tier.Name); err == nil && existing != nil && existing.ID != tier.ID {
+		return ErrTierAlreadyExists
+	}
+	return s.tierRepo.Update(ctx, tier)
+}
+
+func (s *TierDomainService) DeleteTier(ctx context.Context, id uint) error {
+	if _, err := s.tierRepo.GetByID(ctx, id); err != nil {
+		return ErrTierNotFound
+	}
+
+	members, err := s.userMembershipRepo.CountByTierID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return ErrTierInUse
+	}
+
+	return s.tierRepo.Delete(ctx, id)
+}
+
+func (s *TierDomainService) EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error) {
+	membership, err := s.userMembershipRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrMembershipNotFound
+	}
+
+	tiers, rules, err := s.loadTierRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	change, _, err := s.evaluate(ctx, membership, tiers, rules, time.Now())
+	return change, err
+}
+
+func (s *TierDomainService) EvaluateAllTiers(ctx context.Context) (*entity.TierEvaluationResult, error) {
+	tiers, rules, err := s.loadTierRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.TierEvaluationResult{}
+	now := time.Now()
+	for offset := 0; ; offset += tierEvaluationBatchSize {
+		memberships, _, err := s.userMembershipRepo.List(ctx, offset, tierEvaluationBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, membership := range memberships {
+			if !membership.IsActive {
+				continue
+			}
+			result.Evaluated++
+
+			change, graceStarted, err := s.evaluate(ctx, membership, tiers, rules, now)
+			switch {
+			case err != nil:
+				result.Failed++
+			case graceStarted:
+				result.GraceStart++
+			case change == nil:
+			case change.Reason == entity.TierChangeReasonDemotion:
+				result.Demoted++
+			default:
+				result.Promoted++
+			}
+		}
+
+		if len(memberships) < tierEvaluationBatchSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (s *TierDomainService) loadTierRules(ctx context.Context) (map[uint]*entity.MembershipTier, []*tierRule, error) {
+	allTiers, err := s.tierRepo.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tiers := make(map[uint]*entity.MembershipTier, len(allTiers))
+	var rules []*tierRule
+	for _, tier := range allTiers {
+		tiers[tier.ID] = tier
+		if !tier.IsActive {
+			continue
+		}
+		requirements, err := tier.ParseRequirements()
+		if err != nil {
+			continue
+		}
+		rules = append(rules, &tierRule{tier: tier, requirements: requirements})
+	}
+
+	return tiers, rules, nil
+}
+
+func (s *TierDomainService) evaluate(ctx context.Context, membership *entity.UserMembership, tiers map[uint]*entity.MembershipTier, rules []*tierRule, now time.Time) (*entity.TierChange, bool, error) {
+	target := qualifyingTier(membership, rules, now)
+	if target == nil || target.ID == membership.TierID {
+		if membership.TierGraceEndsAt != nil {
+			membership.ClearTierGracePeriod()
+			return nil, false, s.userMembershipRepo.UpdateTier(ctx, membership)
+		}
+		return nil, false, nil
+	}
+
+	current, ok := tiers[membership.TierID]
+	if ok && current.IsActive && target.Level < current.Level {
+		if membership.TierGraceEndsAt == nil {
+			membership.StartTierGracePeriod(now.Add(s.gracePeriod))
+			return nil, true, s.userMembershipRepo.UpdateTier(ctx, membership)
+		}
+		if now.Before(*membership.TierGraceEndsAt) {
+			return nil, false, nil
+		}
+	}
+
+	reason := entity.TierChangeReasonPromotion
+	if ok && target.Level < current.Level {
+		reason = entity.TierChangeReasonDemotion
+	}
+
+	change := &entity.TierChange{
+		UserID:   membership.UserID,
+		FromTier: current,
+		ToTier:   target,
+		Reason:   reason,
+	}
+	applied, err := s.applyTierChange(ctx, membership, change)
+	if err != nil || !applied {
+		return nil, false, err
+	}
+	return change, false, nil
+}
+
+// applyTierChange reports false when a concurrent evaluation already moved the
+// membership, so only one caller records history and notifies the user.
+func (s *TierDomainService) applyTierChange(ctx context.Context, membership *entity.UserMembership, change *entity.TierChange) (bool, error) {
+	history := entity.NewMembershipTierHistory(membership.UserID, membership.TierID, change.ToTier.ID, change.Reason)
+	recorded, err := s.tierHistoryRepo.Record(ctx, history)
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	membership.ChangeTier(change.ToTier.ID)
+	s.notifyTierChange(ctx, change)
+	return true, nil
+}
+
+func (s *TierDomainService) notifyTierChange(ctx context.Context, change *entity.TierChange) {
+	message := fmt.Sprintf("Your membership tier is now %s.", change.ToTier.Name)
+	fromTier := ""
+	if change.FromTier != nil {
+		fromTier = change.FromTier.Name
+		message = fmt.Sprintf("Your membership tier has changed from %s to %s.", fromTier, change.ToTier.Name)
+	}
+
+	payload, _ := json.Marshal(map[string]string{
+		"from_tier": fromTier,
+		"to_tier":   change.ToTier.Name,
+		"reason":    change.Reason,
+	})
+	data := string(payload)
+
+	notification := entity.NewNotification(change.UserID, NotificationTypeTierChanged, "Membership tier updated", message, &data)
+	_ = s.notificationRepo.Create(ctx, notification)
+}
+
+func qualifyingTier(membership *entity.UserMembership, rules []*tierRule, now time.Time) *entity.MembershipTier {
+	var target *entity.MembershipTier
+	for _, rule := range rules {
+		if target == nil || rule.requirements.IsSatisfiedBy(membership, now) {
+			target = rule.tier
+		}
+	}
+	return target
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type TierDomainServiceInterface interface {
+	GetTiers(ctx context.Context) ([]*entity.MembershipTier, error)
+	GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error)
+	CreateTier(ctx context.Context, tier *entity.MembershipTier) error
+	UpdateTier(ctx context.Context, tier *entity.MembershipTier) error
+	DeleteTier(ctx context.Context, id uint) error
+	EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error)
+	EvaluateAllTiers(ctx context.Context) (*entity.TierEvaluationResult, error)
+}
@@ -0,0 +1,367 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMembershipTierRepository struct {
+	mock.Mock
+}
+
+func (m *MockMembershipTierRepository) Create(ctx context.Context, tier *entity.MembershipTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockMembershipTierRepository) GetByID(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, id)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMembershipTierRepository) GetByName(ctx context.Context, name string) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, name)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMembershipTierRepository) List(ctx context.Context) ([]*entity.MembershipTier, error) {
+	args := m.Called(ctx)
+	if tiers, ok := args.Get(0).([]*entity.MembershipTier); ok {
+		return tiers, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMembershipTierRepository) Update(ctx context.Context, tier *entity.MembershipTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockMembershipTierRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockMembershipTierHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockMembershipTierHistoryRepository) Create(ctx context.Context, history *entity.MembershipTierHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *MockMembershipTierHistoryRepository) Record(ctx context.Context, history *entity.MembershipTierHistory) (bool, error) {
+	args := m.Called(ctx, history)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMembershipTierHistoryRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.MembershipTierHistory, int64, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if histories, ok := args.Get(0).([]*entity.MembershipTierHistory); ok {
+		return histories, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+type tierDomainServiceMocks struct {
+	tierRepo           *MockMembershipTierRepository
+	userMembershipRepo *MockUserMembershipRepository
+	tierHistoryRepo    *MockMembershipTierHistoryRepository
+	notificationRepo   *MockNotificationRepository
+}
+
+func setupTierDomainService() (*service.TierDomainService, *tierDomainServiceMocks) {
+	mocks := &tierDomainServiceMocks{
+		tierRepo:           &MockMembershipTierRepository{},
+		userMembershipRepo: &MockUserMembershipRepository{},
+		tierHistoryRepo:    &MockMembershipTierHistoryRepository{},
+		notificationRepo:   &MockNotificationRepository{},
+	}
+	svc := service.NewTierDomainService(mocks.tierRepo, mocks.userMembershipRepo, mocks.tierHistoryRepo, mocks.notificationRepo)
+	return svc, mocks
+}
+
+func testTiers() []*entity.MembershipTier {
+	bronze := entity.NewMembershipTier("Bronze", 1, "", "{}", `{"min_points":0}`)
+	bronze.ID = 1
+	silver := entity.NewMembershipTier("Silver", 2, "", "{}", `{"min_points":1000}`)
+	silver.ID = 2
+	gold := entity.NewMembershipTier("Gold", 3, "", "{}", `{"min_points":5000,"min_spent":100000}`)
+	gold.ID = 3
+	return []*entity.MembershipTier{bronze, silver, gold}
+}
+
+func TestTierDomainServiceEvaluateUserTier(t *testing.T) {
+	t.Run("条件を満たすと即時昇格する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 1500
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.tierHistoryRepo.On("Record", ctx, mock.MatchedBy(func(history *entity.MembershipTierHistory) bool {
+			return history.FromTierID == 1 && history.ToTierID == 2 && history.Reason == entity.TierChangeReasonPromotion
+		})).Return(true, nil)
+		mocks.notificationRepo.On("Create", ctx, mock.MatchedBy(func(notification *entity.Notification) bool {
+			return notification.UserID == 1 && notification.Type == service.NotificationTypeTierChanged
+		})).Return(nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, change)
+		assert.Equal(t, "Silver", change.ToTier.Name)
+		assert.Equal(t, entity.TierChangeReasonPromotion, change.Reason)
+		assert.Equal(t, uint(2), membership.TierID)
+		mocks.tierHistoryRepo.AssertExpectations(t)
+		mocks.notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("条件を下回ると猶予期間を開始する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 3)
+		membership.Points = 1500
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.userMembershipRepo.On("UpdateTier", ctx, membership).Return(nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+		assert.Equal(t, uint(3), membership.TierID)
+		assert.NotNil(t, membership.TierGraceEndsAt)
+		assert.WithinDuration(t, time.Now().Add(service.TierGracePeriod), *membership.TierGraceEndsAt, time.Minute)
+		mocks.tierHistoryRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("猶予期間中は降格しない", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 3)
+		membership.Points = 1500
+		membership.StartTierGracePeriod(time.Now().Add(24 * time.Hour))
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+		assert.Equal(t, uint(3), membership.TierID)
+		mocks.userMembershipRepo.AssertNotCalled(t, "UpdateTier", mock.Anything, mock.Anything)
+	})
+
+	t.Run("猶予期間が過ぎると降格する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 3)
+		membership.Points = 1500
+		membership.StartTierGracePeriod(time.Now().Add(-time.Hour))
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.tierHistoryRepo.On("Record", ctx, mock.MatchedBy(func(history *entity.MembershipTierHistory) bool {
+			return history.FromTierID == 3 && history.ToTierID == 2 && history.Reason == entity.TierChangeReasonDemotion
+		})).Return(true, nil)
+		mocks.notificationRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TierChangeReasonDemotion, change.Reason)
+		assert.Equal(t, uint(2), membership.TierID)
+		assert.Nil(t, membership.TierGraceEndsAt)
+		mocks.tierHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("条件を再び満たすと猶予期間を解除する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 2)
+		membership.Points = 1500
+		membership.StartTierGracePeriod(time.Now().Add(24 * time.Hour))
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.userMembershipRepo.On("UpdateTier", ctx, membership).Return(nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+		assert.Nil(t, membership.TierGraceEndsAt)
+		mocks.userMembershipRepo.AssertExpectations(t)
+	})
+
+	t.Run("同時に昇格済みなら履歴と通知を重複させない", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 1500
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.tierHistoryRepo.On("Record", ctx, mock.AnythingOfType("*entity.MembershipTierHistory")).Return(false, nil)
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+		mocks.notificationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("履歴を書き込めなければ昇格も通知もしない", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		membership := entity.NewUserMembership(1, 1)
+		membership.Points = 1500
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(membership, nil)
+		mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+		mocks.tierHistoryRepo.On("Record", ctx, mock.AnythingOfType("*entity.MembershipTierHistory")).Return(false, errors.New("database error"))
+
+		change, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.EqualError(t, err, "database error")
+		assert.Nil(t, change)
+		assert.Equal(t, uint(1), membership.TierID)
+		mocks.notificationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("会員情報が存在しない場合はエラー", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+
+		mocks.userMembershipRepo.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+
+		_, err := svc.EvaluateUserTier(ctx, 1)
+
+		assert.ErrorIs(t, err, service.ErrMembershipNotFound)
+	})
+}
+
+func TestTierDomainServiceEvaluateAllTiers(t *testing.T) {
+	svc, mocks := setupTierDomainService()
+	ctx := context.Background()
+
+	promoted := entity.NewUserMembership(1, 1)
+	promoted.Points = 2000
+	graceStarted := entity.NewUserMembership(2, 3)
+	unchanged := entity.NewUserMembership(3, 1)
+	inactive := entity.NewUserMembership(4, 1)
+	inactive.Points = 2000
+	inactive.Deactivate()
+
+	mocks.tierRepo.On("List", ctx).Return(testTiers(), nil)
+	mocks.userMembershipRepo.On("List", ctx, 0, 100).Return([]*entity.UserMembership{promoted, graceStarted, unchanged, inactive}, int64(4), nil)
+	mocks.userMembershipRepo.On("UpdateTier", ctx, mock.Anything).Return(nil)
+	mocks.tierHistoryRepo.On("Record", ctx, mock.Anything).Return(true, nil)
+	mocks.notificationRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	result, err := svc.EvaluateAllTiers(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Evaluated)
+	assert.Equal(t, 1, result.Promoted)
+	assert.Equal(t, 1, result.GraceStart)
+	assert.Equal(t, 0, result.Demoted)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, uint(1), inactive.TierID)
+}
+
+func TestTierDomainServiceCreateTier(t *testing.T) {
+	t.Run("新しいティアを作成する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		tier := entity.NewMembershipTier("Platinum", 4, "", "{}", `{"min_points":10000}`)
+
+		mocks.tierRepo.On("GetByName", ctx, "Platinum").Return(nil, errors.New("record not found"))
+		mocks.tierRepo.On("Create", ctx, tier).Return(nil)
+
+		err := svc.CreateTier(ctx, tier)
+
+		assert.NoError(t, err)
+		mocks.tierRepo.AssertExpectations(t)
+	})
+
+	t.Run("同名のティアはエラー", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+		tier := entity.NewMembershipTier("Gold", 4, "", "{}", "")
+
+		mocks.tierRepo.On("GetByName", ctx, "Gold").Return(testTiers()[2], nil)
+
+		err := svc.CreateTier(ctx, tier)
+
+		assert.ErrorIs(t, err, service.ErrTierAlreadyExists)
+		mocks.tierRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("不正な条件はエラー", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+
+		err := svc.CreateTier(context.Background(), entity.NewMembershipTier("Platinum", 4, "", "{}", `{"min_points":-1}`))
+
+		assert.ErrorIs(t, err, entity.ErrInvalidTierRequirements)
+		mocks.tierRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTierDomainServiceDeleteTier(t *testing.T) {
+	t.Run("会員のいないティアを削除する", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+
+		mocks.tierRepo.On("GetByID", ctx, uint(3)).Return(testTiers()[2], nil)
+		mocks.userMembershipRepo.On("CountByTierID", ctx, uint(3)).Return(int64(0), nil)
+		mocks.tierRepo.On("Delete", ctx, uint(3)).Return(nil)
+
+		err := svc.DeleteTier(ctx, 3)
+
+		assert.NoError(t, err)
+		mocks.tierRepo.AssertExpectations(t)
+	})
+
+	t.Run("会員が所属するティアは削除できない", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+
+		mocks.tierRepo.On("GetByID", ctx, uint(3)).Return(testTiers()[2], nil)
+		mocks.userMembershipRepo.On("CountByTierID", ctx, uint(3)).Return(int64(5), nil)
+
+		err := svc.DeleteTier(ctx, 3)
+
+		assert.ErrorIs(t, err, service.ErrTierInUse)
+		mocks.tierRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しないティアはエラー", func(t *testing.T) {
+		svc, mocks := setupTierDomainService()
+		ctx := context.Background()
+
+		mocks.tierRepo.On("GetByID", ctx, uint(99)).Return(nil, errors.New("record not found"))
+
+		err := svc.DeleteTier(ctx, 99)
+
+		assert.ErrorIs(t, err, service.ErrTierNotFound)
+	})
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"time"
+)
+
+var (
+	ErrUnknownKeyID     = errors.New("unknown signing key id")
+	ErrUnsupportedJWKey = errors.New("unsupported public key type")
+)
+
+const (
+	SigningAlgorithmHS256 = "HS256"
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+type SigningKey struct {
+	KeyID       string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	PublicKey   crypto.PublicKey
+	CreatedAt   time.Time
+	ActivatesAt time.Time
+	RetiredAt   *time.Time
+}
+
+type TokenKeySet interface {
+	ActiveKey() (*SigningKey, error)
+	KeyByID(kid string) (*SigningKey, error)
+	VerificationKeys() []*SigningKey
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k *SigningKey) PublicJWK() (JWK, error) {
+	jwk := JWK{
+		Kid: k.KeyID,
+		Use: "sig",
+		Alg: k.Algorithm,
+	}
+
+	switch pub := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return JWK{}, ErrUnsupportedJWKey
+		}
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[1+size:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, ErrUnsupportedJWKey
+	}
+
+	return jwk, nil
+}
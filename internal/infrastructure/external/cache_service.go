@@ -8,6 +8,8 @@ import (
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
 
+const UserDashboardCacheTTL = time.Minute
+
 type CacheService struct {
 	redis *RedisClient
 }
@@ -38,6 +40,63 @@ func (c *CacheService) DeleteUser(ctx context.Context, userID uint) error {
 	return c.redis.Delete(ctx, key)
 }
 
+func (c *CacheService) SetUserPreferences(ctx context.Context, userID uint, preferences map[string]string) error {
+	key := fmt.Sprintf("preferences:user:%d", userID)
+	return c.redis.Set(ctx, key, preferences, 30*time.Minute)
+}
+
+func (c *CacheService) GetUserPreferences(ctx context.Context, userID uint) (map[string]string, error) {
+	key := fmt.Sprintf("preferences:user:%d", userID)
+	var preferences map[string]string
+	if err := c.redis.Get(ctx, key, &preferences); err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (c *CacheService) DeleteUserPreferences(ctx context.Context, userID uint) error {
+	key := fmt.Sprintf("preferences:user:%d", userID)
+	return c.redis.Delete(ctx, key)
+}
+
+func (c *CacheService) SetUserPermissions(ctx context.Context, userID uint, permissions []string, expiration time.Duration) error {
+	key := fmt.Sprintf("permissions:user:%d", userID)
+	return c.redis.Set(ctx, key, permissions, expiration)
+}
+
+func (c *CacheService) GetUserPermissions(ctx context.Context, userID uint) ([]string, error) {
+	key := fmt.Sprintf("permissions:user:%d", userID)
+	var permissions []string
+	if err := c.redis.Get(ctx, key, &permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (c *CacheService) DeleteUserPermissions(ctx context.Context, userID uint) error {
+	key := fmt.Sprintf("permissions:user:%d", userID)
+	return c.redis.Delete(ctx, key)
+}
+
+func (c *CacheService) SetUserDashboard(ctx context.Context, dashboard *entity.UserDashboard) error {
+	key := fmt.Sprintf("dashboard:user:%d", dashboard.UserID)
+	return c.redis.Set(ctx, key, dashboard, UserDashboardCacheTTL)
+}
+
+func (c *CacheService) GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	key := fmt.Sprintf("dashboard:user:%d", userID)
+	var dashboard entity.UserDashboard
+	if err := c.redis.Get(ctx, key, &dashboard); err != nil {
+		return nil, err
+	}
+	return &dashboard, nil
+}
+
+func (c *CacheService) DeleteUserDashboard(ctx context.Context, userID uint) error {
+	key := fmt.Sprintf("dashboard:user:%d", userID)
+	return c.redis.Delete(ctx, key)
+}
+
 func (c *CacheService) SetAuth(ctx context.Context, auth *entity.Auth) error {
 	key := fmt.Sprintf("auth:user:%d", auth.UserID)
 	return c.redis.Set(ctx, key, auth, 30*time.Minute)
@@ -90,19 +149,12 @@ func (c *CacheService) DeleteSession(ctx context.Context, sessionID string) erro
 
 func (c *CacheService) IncrementRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
 	fullKey := fmt.Sprintf("rate_limit:%s", key)
-	count, err := c.redis.Incr(ctx, fullKey)
-	if err != nil {
-		return 0, err
-	}
-
-	if count == 1 {
-		err = c.redis.Expire(ctx, fullKey, window)
-		if err != nil {
-			return 0, err
-		}
-	}
+	return c.redis.RunScript(ctx, incrementRateLimitScript, []string{fullKey}, window.Milliseconds()).Int64()
+}
 
-	return count, nil
+func (c *CacheService) CountRateLimitBlock(ctx context.Context, key string, window time.Duration) (int64, error) {
+	fullKey := fmt.Sprintf("rate_limit_blocked:%s", key)
+	return c.redis.RunScript(ctx, incrementRateLimitScript, []string{fullKey}, window.Milliseconds()).Int64()
 }
 
 func (c *CacheService) GetRateLimit(ctx context.Context, key string) (int64, error) {
@@ -117,21 +169,6 @@ func (c *CacheService) GetRateLimit(ctx context.Context, key string) (int64, err
 	return val, nil
 }
 
-func (c *CacheService) AddToBlacklist(ctx context.Context, ip string, expiration time.Duration) error {
-	key := fmt.Sprintf("blacklist:ip:%s", ip)
-	return c.redis.Set(ctx, key, true, expiration)
-}
-
-func (c *CacheService) IsBlacklisted(ctx context.Context, ip string) (bool, error) {
-	key := fmt.Sprintf("blacklist:ip:%s", ip)
-	return c.redis.Exists(ctx, key)
-}
-
-func (c *CacheService) RemoveFromBlacklist(ctx context.Context, ip string) error {
-	key := fmt.Sprintf("blacklist:ip:%s", ip)
-	return c.redis.Delete(ctx, key)
-}
-
 func (c *CacheService) BlacklistToken(ctx context.Context, tokenID string, expiration time.Duration) error {
 	key := fmt.Sprintf("blacklist:token:%s", tokenID)
 	return c.redis.Set(ctx, key, true, expiration)
@@ -141,3 +178,20 @@ func (c *CacheService) IsTokenBlacklisted(ctx context.Context, tokenID string) (
 	key := fmt.Sprintf("blacklist:token:%s", tokenID)
 	return c.redis.Exists(ctx, key)
 }
+
+func (c *CacheService) GetTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	key := fmt.Sprintf("token_version:user:%d", userID)
+	val, err := c.redis.client.Get(ctx, key).Int64()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return val, nil
+}
+
+func (c *CacheService) IncrementTokenVersion(ctx context.Context, userID uint) (int64, error) {
+	key := fmt.Sprintf("token_version:user:%d", userID)
+	return c.redis.Incr(ctx, key)
+}
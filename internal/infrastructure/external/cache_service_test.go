@@ -9,6 +9,8 @@ import (
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/go-redis/redis/v8"
+	"github.com/go-redis/redismock/v8"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -326,6 +328,54 @@ func TestCacheServiceIntegration(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCacheServiceCheckRateLimitIntegration(t *testing.T) {
+	if os.Getenv("REDIS_HOST") == "" {
+		t.Skip("Integration test requires Redis instance")
+	}
+
+	redisClient := external.NewRedisClient(getRedisAddrForTest(), getRedisPasswordForTest(), getRedisDBForTest())
+	cacheService := external.NewCacheService(redisClient)
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	algorithms := []string{
+		entity.RateLimitAlgorithmFixedWindow,
+		entity.RateLimitAlgorithmSlidingLog,
+		entity.RateLimitAlgorithmSlidingWindow,
+		entity.RateLimitAlgorithmTokenBucket,
+	}
+
+	for _, algorithm := range algorithms {
+		t.Run(algorithm, func(t *testing.T) {
+			key := "integration:" + uuid.NewString()
+			window := 10 * time.Second
+
+			for i := int64(1); i <= 3; i++ {
+				result, err := cacheService.CheckRateLimit(ctx, algorithm, key, 3, window)
+				assert.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, 3-i, result.Remaining)
+				assert.True(t, result.ResetAt.After(time.Now()))
+			}
+
+			result, err := cacheService.CheckRateLimit(ctx, algorithm, key, 3, window)
+			assert.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, int64(0), result.Remaining)
+			assert.Greater(t, result.RetryAfter, time.Duration(0))
+			assert.LessOrEqual(t, result.RetryAfter, 2*window)
+		})
+	}
+
+	t.Run("未対応のアルゴリズム", func(t *testing.T) {
+		_, err := cacheService.CheckRateLimit(ctx, "leaky_bucket", "integration:"+uuid.NewString(), 3, time.Second)
+		assert.Error(t, err)
+	})
+}
+
 func getRedisAddrForTest() string {
 	host := "redis"
 	if testHost := getEnv("REDIS_HOST", ""); testHost != "" {
@@ -354,3 +404,37 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func TestCacheServiceTokenVersion(t *testing.T) {
+	t.Run("未設定なら0", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectGet("token_version:user:1").RedisNil()
+
+		cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+		version, err := cacheService.GetTokenVersion(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Zero(t, version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("インクリメントした値を返す", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectIncr("token_version:user:1").SetVal(3)
+		mock.ExpectGet("token_version:user:1").SetVal("3")
+
+		cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+		incremented, err := cacheService.IncrementTokenVersion(context.Background(), 1)
+		assert.NoError(t, err)
+		version, err := cacheService.GetTokenVersion(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), incremented)
+		assert.Equal(t, int64(3), version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
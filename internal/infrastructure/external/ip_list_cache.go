@@ -0,0 +1,153 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+const (
+	blacklistKeyPrefix = "blacklist:ip:"
+	allowlistKeyPrefix = "allowlist:ip:"
+	ipListVersionKey   = "ip_lists:version"
+)
+
+func (c *CacheService) AddToBlacklist(ctx context.Context, ip string, expiration time.Duration) error {
+	return c.redis.Set(ctx, blacklistKeyPrefix+ip, true, expiration)
+}
+
+func (c *CacheService) RemoveFromBlacklist(ctx context.Context, ip string) error {
+	return c.redis.Delete(ctx, blacklistKeyPrefix+ip)
+}
+
+func (c *CacheService) AddToAllowlist(ctx context.Context, ip string) error {
+	return c.redis.Set(ctx, allowlistKeyPrefix+ip, true, 0)
+}
+
+func (c *CacheService) RemoveFromAllowlist(ctx context.Context, ip string) error {
+	return c.redis.Delete(ctx, allowlistKeyPrefix+ip)
+}
+
+func (c *CacheService) IPListVersion(ctx context.Context) (int64, error) {
+	val, err := c.redis.client.Get(ctx, ipListVersionKey).Int64()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return val, nil
+}
+
+func (c *CacheService) bumpIPListVersion(ctx context.Context) error {
+	_, err := c.redis.Incr(ctx, ipListVersionKey)
+	return err
+}
+
+func (c *CacheService) SyncBlacklistEntry(ctx context.Context, blacklist *entity.IPBlacklist) {
+	var err error
+	if ttl, ok := blacklistTTL(blacklist); ok {
+		err = c.AddToBlacklist(ctx, blacklist.IPAddress, ttl)
+	} else {
+		err = c.RemoveFromBlacklist(ctx, blacklist.IPAddress)
+	}
+	if err == nil {
+		err = c.bumpIPListVersion(ctx)
+	}
+	if err != nil {
+		log.Printf("⚠️ IPブラックリストのRedis同期に失敗しました (%s): %v", blacklist.IPAddress, err)
+	}
+}
+
+func (c *CacheService) SyncAllowlistEntry(ctx context.Context, allowlist *entity.IPAllowlist) {
+	var err error
+	if allowlist.IsActive {
+		err = c.AddToAllowlist(ctx, allowlist.IPAddress)
+	} else {
+		err = c.RemoveFromAllowlist(ctx, allowlist.IPAddress)
+	}
+	if err == nil {
+		err = c.bumpIPListVersion(ctx)
+	}
+	if err != nil {
+		log.Printf("⚠️ IP許可リストのRedis同期に失敗しました (%s): %v", allowlist.IPAddress, err)
+	}
+}
+
+func (c *CacheService) ReplaceIPLists(ctx context.Context, blacklists []*entity.IPBlacklist, allowlists []*entity.IPAllowlist) error {
+	wantBlacklist := make(map[string]time.Duration, len(blacklists))
+	for _, blacklist := range blacklists {
+		if ttl, ok := blacklistTTL(blacklist); ok {
+			wantBlacklist[blacklistKeyPrefix+blacklist.IPAddress] = ttl
+		}
+	}
+
+	wantAllowlist := make(map[string]time.Duration, len(allowlists))
+	for _, allowlist := range allowlists {
+		if allowlist.IsActive {
+			wantAllowlist[allowlistKeyPrefix+allowlist.IPAddress] = 0
+		}
+	}
+
+	blacklistChanged, err := c.reconcileKeys(ctx, blacklistKeyPrefix, wantBlacklist)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile IP blacklist: %w", err)
+	}
+	allowlistChanged, err := c.reconcileKeys(ctx, allowlistKeyPrefix, wantAllowlist)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile IP allowlist: %w", err)
+	}
+
+	if blacklistChanged || allowlistChanged {
+		if err := c.bumpIPListVersion(ctx); err != nil {
+			return fmt.Errorf("failed to bump IP list version: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CacheService) reconcileKeys(ctx context.Context, prefix string, want map[string]time.Duration) (bool, error) {
+	existing, err := c.redis.ScanKeys(ctx, prefix+"*")
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	present := make(map[string]bool, len(existing))
+	for _, key := range existing {
+		present[key] = true
+		if _, ok := want[key]; ok {
+			continue
+		}
+		if err := c.redis.Delete(ctx, key); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	for key, ttl := range want {
+		if err := c.redis.Set(ctx, key, true, ttl); err != nil {
+			return false, err
+		}
+		if !present[key] {
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+func blacklistTTL(blacklist *entity.IPBlacklist) (time.Duration, bool) {
+	if !blacklist.IsEffective() {
+		return 0, false
+	}
+	if blacklist.ExpiresAt == nil {
+		return 0, true
+	}
+
+	return time.Until(*blacklist.ExpiresAt), true
+}
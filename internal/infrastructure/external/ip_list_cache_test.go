@@ -0,0 +1,94 @@
+package external_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheServiceSyncBlacklistEntry(t *testing.T) {
+	t.Run("有効なエントリは期限までのTTLで書き込む", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		defer func() { _ = db.Close() }()
+
+		expiresAt := time.Now().Add(time.Hour)
+		blacklist := entity.NewIPBlacklist("203.0.113.0/24", "botnet", &expiresAt)
+
+		mock.CustomMatch(func(_, actual []interface{}) error {
+			if actual[1] != "blacklist:ip:203.0.113.0/24" || actual[3] != "px" {
+				return fmt.Errorf("unexpected args %v", actual)
+			}
+			ttl, ok := actual[4].(int64)
+			if !ok || ttl <= 0 || ttl > time.Hour.Milliseconds() {
+				return fmt.Errorf("unexpected ttl %v", actual[4])
+			}
+			return nil
+		}).ExpectSet("blacklist:ip:203.0.113.0/24", []byte("true"), time.Hour).SetVal("OK")
+		mock.ExpectIncr("ip_lists:version").SetVal(1)
+
+		cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+		cacheService.SyncBlacklistEntry(context.Background(), blacklist)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("無効化されたエントリは削除する", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		defer func() { _ = db.Close() }()
+
+		blacklist := entity.NewIPBlacklist("198.51.100.7", "scanner", nil)
+		blacklist.Deactivate()
+
+		mock.ExpectDel("blacklist:ip:198.51.100.7").SetVal(1)
+		mock.ExpectIncr("ip_lists:version").SetVal(2)
+
+		cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+		cacheService.SyncBlacklistEntry(context.Background(), blacklist)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCacheServiceReplaceIPLists(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+
+	blacklists := []*entity.IPBlacklist{entity.NewIPBlacklist("203.0.113.0/24", "botnet", nil)}
+	allowlists := []*entity.IPAllowlist{entity.NewIPAllowlist("10.20.0.0/16", "office")}
+
+	mock.ExpectScan(0, "blacklist:ip:*", 100).SetVal([]string{"blacklist:ip:203.0.113.0/24", "blacklist:ip:192.0.2.1"}, 0)
+	mock.ExpectDel("blacklist:ip:192.0.2.1").SetVal(1)
+	mock.ExpectSet("blacklist:ip:203.0.113.0/24", []byte("true"), 0).SetVal("OK")
+	mock.ExpectScan(0, "allowlist:ip:*", 100).SetVal([]string{}, 0)
+	mock.ExpectSet("allowlist:ip:10.20.0.0/16", []byte("true"), 0).SetVal("OK")
+	mock.ExpectIncr("ip_lists:version").SetVal(3)
+
+	cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+	err := cacheService.ReplaceIPLists(context.Background(), blacklists, allowlists)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCacheServiceReplaceIPListsUnchanged(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+
+	blacklists := []*entity.IPBlacklist{entity.NewIPBlacklist("203.0.113.0/24", "botnet", nil)}
+
+	mock.ExpectScan(0, "blacklist:ip:*", 100).SetVal([]string{"blacklist:ip:203.0.113.0/24"}, 0)
+	mock.ExpectSet("blacklist:ip:203.0.113.0/24", []byte("true"), 0).SetVal("OK")
+	mock.ExpectScan(0, "allowlist:ip:*", 100).SetVal([]string{}, 0)
+
+	cacheService := external.NewCacheService(external.NewRedisClientFromClient(db))
+	err := cacheService.ReplaceIPLists(context.Background(), blacklists, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
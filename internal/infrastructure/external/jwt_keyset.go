@@ -0,0 +1,469 @@
+package external
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+var (
+	ErrUnsupportedSigningAlgorithm = errors.New("unsupported signing algorithm")
+	ErrNoActiveSigningKey          = errors.New("no active signing key")
+	ErrKeySetLocked                = errors.New("key set is locked by another writer")
+)
+
+const (
+	rsaKeyBits           = 2048
+	keySetReloadInterval = time.Minute
+	keySetLockTimeout    = 10 * time.Second
+	keySetLockRetry      = 100 * time.Millisecond
+	keySetLockStaleAfter = time.Minute
+
+	// DefaultKeyPublishLead covers the key set reload interval and the JWKS
+	// cache lifetime, so every verifier knows a key before tokens carry it.
+	DefaultKeyPublishLead = 10 * time.Minute
+)
+
+type JWTKeySet struct {
+	mu          sync.RWMutex
+	algorithm   string
+	overlap     time.Duration
+	publishLead time.Duration
+	path        string
+	keys        []*service.SigningKey
+}
+
+type keySetFile struct {
+	Keys []keySetFileEntry `json:"keys"`
+}
+
+type keySetFileEntry struct {
+	KeyID       string     `json:"kid"`
+	Algorithm   string     `json:"alg"`
+	PrivateKey  string     `json:"private_key"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatesAt *time.Time `json:"activates_at,omitempty"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+}
+
+func NewJWTKeySet(algorithm string, overlap, publishLead time.Duration) (*JWTKeySet, error) {
+	if !IsAsymmetricAlgorithm(algorithm) {
+		return nil, ErrUnsupportedSigningAlgorithm
+	}
+
+	return &JWTKeySet{
+		algorithm:   algorithm,
+		overlap:     overlap,
+		publishLead: publishLead,
+	}, nil
+}
+
+func LoadJWTKeySet(path, algorithm string, overlap, publishLead time.Duration) (*JWTKeySet, error) {
+	ks, err := NewJWTKeySet(algorithm, overlap, publishLead)
+	if err != nil {
+		return nil, err
+	}
+
+	ks.path = path
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+func IsAsymmetricAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case service.SigningAlgorithmRS256, service.SigningAlgorithmES256, service.SigningAlgorithmEdDSA:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ks *JWTKeySet) ActiveKey() (*service.SigningKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	active := ks.activeKeyLocked(time.Now())
+	if active == nil {
+		return nil, ErrNoActiveSigningKey
+	}
+	return active, nil
+}
+
+func (ks *JWTKeySet) KeyByID(kid string) (*service.SigningKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range ks.keys {
+		if key.KeyID == kid && ks.verifiableAt(key, now) {
+			return key, nil
+		}
+	}
+	return nil, service.ErrUnknownKeyID
+}
+
+func (ks *JWTKeySet) VerificationKeys() []*service.SigningKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]*service.SigningKey, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		if ks.verifiableAt(key, now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Rotate publishes a new key that starts signing after the publish lead. The
+// current key signs until then and verifies for the overlap afterwards.
+func (ks *JWTKeySet) Rotate() (*service.SigningKey, error) {
+	unlock, err := ks.lockWriter()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	return ks.rotateLocked(time.Now())
+}
+
+func (ks *JWTKeySet) RotateIfDue(interval time.Duration) (bool, error) {
+	if ks.path != "" {
+		if err := ks.Reload(); err != nil {
+			return false, err
+		}
+	}
+
+	ks.mu.RLock()
+	due := ks.rotationDueLocked(time.Now(), interval)
+	ks.mu.RUnlock()
+	if !due {
+		return false, nil
+	}
+
+	unlock, err := ks.lockWriter()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	now := time.Now()
+	if !ks.rotationDueLocked(now, interval) {
+		return false, nil
+	}
+	if _, err := ks.rotateLocked(now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (ks *JWTKeySet) StartRotation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(keySetReloadInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rotated, err := ks.RotateIfDue(interval)
+				if err != nil {
+					log.Printf("❌ JWT署名鍵のローテーションに失敗しました: %v", err)
+					continue
+				}
+				if rotated {
+					log.Println("🔑 次のJWT署名鍵を公開しました")
+				}
+			}
+		}
+	}()
+}
+
+func (ks *JWTKeySet) Reload() error {
+	data, err := os.ReadFile(filepath.Clean(ks.path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read key set: %w", err)
+	}
+
+	var file keySetFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse key set: %w", err)
+	}
+
+	keys := make([]*service.SigningKey, 0, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := decodeKeySetEntry(entry)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// lockWriter makes the caller the only process rewriting the key set file,
+// and reloads the file so the caller decides on what other writers left.
+func (ks *JWTKeySet) lockWriter() (func(), error) {
+	if ks.path == "" {
+		return func() {}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ks.path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create key set directory: %w", err)
+	}
+
+	lockPath := ks.path + ".lock"
+	deadline := time.Now().Add(keySetLockTimeout)
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = lock.Close()
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock key set: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > keySetLockStaleAfter {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrKeySetLocked
+		}
+		time.Sleep(keySetLockRetry)
+	}
+
+	unlock := func() { _ = os.Remove(lockPath) }
+	if err := ks.Reload(); err != nil {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
+}
+
+func (ks *JWTKeySet) rotateLocked(now time.Time) (*service.SigningKey, error) {
+	key, err := generateSigningKey(ks.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	if active := ks.activeKeyLocked(now); active != nil {
+		key.ActivatesAt = now.Add(ks.publishLead)
+	}
+	for _, existing := range ks.keys {
+		if existing.RetiredAt == nil {
+			retiredAt := key.ActivatesAt
+			existing.RetiredAt = &retiredAt
+		}
+	}
+
+	ks.keys = append(ks.keys, key)
+	ks.pruneLocked(now)
+
+	if ks.path != "" {
+		if err := ks.saveLocked(); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+func (ks *JWTKeySet) activeKeyLocked(now time.Time) *service.SigningKey {
+	for i := len(ks.keys) - 1; i >= 0; i-- {
+		key := ks.keys[i]
+		if key.ActivatesAt.After(now) {
+			continue
+		}
+		if key.RetiredAt == nil || now.Before(*key.RetiredAt) {
+			return key
+		}
+	}
+	return nil
+}
+
+func (ks *JWTKeySet) rotationDueLocked(now time.Time, interval time.Duration) bool {
+	active := ks.activeKeyLocked(now)
+	if active == nil {
+		return true
+	}
+
+	for _, key := range ks.keys {
+		if key.RetiredAt == nil && key.ActivatesAt.After(now) {
+			return false
+		}
+	}
+
+	return interval > 0 && now.Sub(active.ActivatesAt) >= interval-ks.publishLead
+}
+
+func (ks *JWTKeySet) verifiableAt(key *service.SigningKey, now time.Time) bool {
+	return key.RetiredAt == nil || now.Before(key.RetiredAt.Add(ks.overlap))
+}
+
+func (ks *JWTKeySet) pruneLocked(now time.Time) {
+	kept := ks.keys[:0]
+	for _, key := range ks.keys {
+		if ks.verifiableAt(key, now) {
+			kept = append(kept, key)
+		}
+	}
+	ks.keys = kept
+}
+
+func (ks *JWTKeySet) saveLocked() error {
+	file := keySetFile{Keys: make([]keySetFileEntry, 0, len(ks.keys))}
+	for _, key := range ks.keys {
+		der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to encode private key %s: %w", key.KeyID, err)
+		}
+		activatesAt := key.ActivatesAt
+		file.Keys = append(file.Keys, keySetFileEntry{
+			KeyID:       key.KeyID,
+			Algorithm:   key.Algorithm,
+			PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+			CreatedAt:   key.CreatedAt,
+			ActivatesAt: &activatesAt,
+			RetiredAt:   key.RetiredAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key set: %w", err)
+	}
+
+	tmpPath := ks.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write key set: %w", err)
+	}
+	if err := os.Rename(tmpPath, ks.path); err != nil {
+		return fmt.Errorf("failed to replace key set: %w", err)
+	}
+
+	return nil
+}
+
+func generateSigningKey(algorithm string) (*service.SigningKey, error) {
+	var signer crypto.Signer
+	var err error
+
+	switch algorithm {
+	case service.SigningAlgorithmRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case service.SigningAlgorithmES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case service.SigningAlgorithmEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedSigningAlgorithm
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", algorithm, err)
+	}
+
+	kid, err := newKeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now()
+	return &service.SigningKey{
+		KeyID:       kid,
+		Algorithm:   algorithm,
+		PrivateKey:  signer,
+		PublicKey:   signer.Public(),
+		CreatedAt:   createdAt,
+		ActivatesAt: createdAt,
+	}, nil
+}
+
+func decodeKeySetEntry(entry keySetFileEntry) (*service.SigningKey, error) {
+	block, _ := pem.Decode([]byte(entry.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode private key %s", entry.KeyID)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", entry.KeyID, err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok || !keyMatchesAlgorithm(signer, entry.Algorithm) {
+		return nil, fmt.Errorf("private key %s does not match algorithm %s", entry.KeyID, entry.Algorithm)
+	}
+
+	activatesAt := entry.CreatedAt
+	if entry.ActivatesAt != nil {
+		activatesAt = *entry.ActivatesAt
+	}
+
+	return &service.SigningKey{
+		KeyID:       entry.KeyID,
+		Algorithm:   entry.Algorithm,
+		PrivateKey:  signer,
+		PublicKey:   signer.Public(),
+		CreatedAt:   entry.CreatedAt,
+		ActivatesAt: activatesAt,
+		RetiredAt:   entry.RetiredAt,
+	}, nil
+}
+
+func keyMatchesAlgorithm(signer crypto.Signer, algorithm string) bool {
+	switch key := signer.(type) {
+	case *rsa.PrivateKey:
+		return algorithm == service.SigningAlgorithmRS256
+	case *ecdsa.PrivateKey:
+		return algorithm == service.SigningAlgorithmES256 && key.Curve == elliptic.P256()
+	case ed25519.PrivateKey:
+		return algorithm == service.SigningAlgorithmEdDSA
+	default:
+		return false
+	}
+}
+
+func newKeyID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate key id: %w", err)
+	}
+	return time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,215 @@
+package external_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJWTKeySetRejectsUnsupportedAlgorithm(t *testing.T) {
+	for _, alg := range []string{"", "HS256", "RS512", "none"} {
+		_, err := external.NewJWTKeySet(alg, time.Hour, 0)
+		assert.Equal(t, external.ErrUnsupportedSigningAlgorithm, err, alg)
+	}
+}
+
+func TestJWTKeySetRotate(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		kty       string
+	}{
+		{"RS256", service.SigningAlgorithmRS256, "RSA"},
+		{"ES256", service.SigningAlgorithmES256, "EC"},
+		{"EdDSA", service.SigningAlgorithmEdDSA, "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := external.NewJWTKeySet(tt.algorithm, time.Hour, 0)
+			require.NoError(t, err)
+
+			_, err = ks.ActiveKey()
+			assert.Equal(t, external.ErrNoActiveSigningKey, err)
+
+			first, err := ks.Rotate()
+			require.NoError(t, err)
+			assert.Equal(t, tt.algorithm, first.Algorithm)
+
+			jwk, err := first.PublicJWK()
+			require.NoError(t, err)
+			assert.Equal(t, tt.kty, jwk.Kty)
+			assert.Equal(t, first.KeyID, jwk.Kid)
+
+			second, err := ks.Rotate()
+			require.NoError(t, err)
+			assert.NotEqual(t, first.KeyID, second.KeyID)
+
+			active, err := ks.ActiveKey()
+			require.NoError(t, err)
+			assert.Equal(t, second.KeyID, active.KeyID)
+
+			retired, err := ks.KeyByID(first.KeyID)
+			require.NoError(t, err)
+			assert.NotNil(t, retired.RetiredAt)
+			assert.Len(t, ks.VerificationKeys(), 2)
+		})
+	}
+}
+
+func TestJWTKeySetDropsKeysAfterOverlap(t *testing.T) {
+	ks, err := external.NewJWTKeySet(service.SigningAlgorithmEdDSA, 0, 0)
+	require.NoError(t, err)
+
+	first, err := ks.Rotate()
+	require.NoError(t, err)
+	_, err = ks.Rotate()
+	require.NoError(t, err)
+
+	_, err = ks.KeyByID(first.KeyID)
+	assert.Equal(t, service.ErrUnknownKeyID, err)
+	assert.Len(t, ks.VerificationKeys(), 1)
+}
+
+func TestJWTKeySetPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "jwt-keyset.json")
+
+	ks, err := external.LoadJWTKeySet(path, service.SigningAlgorithmES256, time.Hour, 0)
+	require.NoError(t, err)
+	assert.Empty(t, ks.VerificationKeys())
+
+	first, err := ks.Rotate()
+	require.NoError(t, err)
+	second, err := ks.Rotate()
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reloaded, err := external.LoadJWTKeySet(path, service.SigningAlgorithmES256, time.Hour, 0)
+	require.NoError(t, err)
+
+	active, err := reloaded.ActiveKey()
+	require.NoError(t, err)
+	assert.Equal(t, second.KeyID, active.KeyID)
+
+	old, err := reloaded.KeyByID(first.KeyID)
+	require.NoError(t, err)
+	assert.Equal(t, first.PublicKey, old.PublicKey)
+}
+
+func TestJWTKeySetRotateIfDue(t *testing.T) {
+	ks, err := external.NewJWTKeySet(service.SigningAlgorithmEdDSA, time.Hour, 0)
+	require.NoError(t, err)
+
+	rotated, err := ks.RotateIfDue(time.Hour)
+	require.NoError(t, err)
+	assert.True(t, rotated, "鍵が無い場合は生成する")
+
+	rotated, err = ks.RotateIfDue(time.Hour)
+	require.NoError(t, err)
+	assert.False(t, rotated, "期限前はローテーションしない")
+
+	rotated, err = ks.RotateIfDue(time.Nanosecond)
+	require.NoError(t, err)
+	assert.True(t, rotated, "期限を過ぎたらローテーションする")
+}
+
+func TestJWTKeySetPublishesNextKeyBeforeActivation(t *testing.T) {
+	ks, err := external.NewJWTKeySet(service.SigningAlgorithmEdDSA, time.Hour, time.Hour)
+	require.NoError(t, err)
+
+	first, err := ks.Rotate()
+	require.NoError(t, err)
+	assert.False(t, first.ActivatesAt.After(time.Now()), "最初の鍵は即座に有効になる")
+
+	next, err := ks.Rotate()
+	require.NoError(t, err)
+	assert.True(t, next.ActivatesAt.After(time.Now()))
+
+	active, err := ks.ActiveKey()
+	require.NoError(t, err)
+	assert.Equal(t, first.KeyID, active.KeyID, "公開期間中は現在の鍵で署名する")
+
+	published, err := ks.KeyByID(next.KeyID)
+	require.NoError(t, err)
+	assert.Equal(t, next.PublicKey, published.PublicKey, "次の鍵は有効化前から検証できる")
+	assert.Len(t, ks.VerificationKeys(), 2)
+
+	rotated, err := ks.RotateIfDue(time.Nanosecond)
+	require.NoError(t, err)
+	assert.False(t, rotated, "公開済みの鍵があれば追加しない")
+}
+
+func TestJWTKeySetActivatesPublishedKey(t *testing.T) {
+	ks, err := external.NewJWTKeySet(service.SigningAlgorithmEdDSA, time.Hour, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	first, err := ks.Rotate()
+	require.NoError(t, err)
+	next, err := ks.Rotate()
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	active, err := ks.ActiveKey()
+	require.NoError(t, err)
+	assert.Equal(t, next.KeyID, active.KeyID)
+
+	retired, err := ks.KeyByID(first.KeyID)
+	require.NoError(t, err)
+	assert.NotNil(t, retired.RetiredAt)
+}
+
+func TestJWTKeySetRotateIfDueSingleWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt-keyset.json")
+
+	const replicas = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, replicas)
+	for i := 0; i < replicas; i++ {
+		ks, err := external.LoadJWTKeySet(path, service.SigningAlgorithmEdDSA, time.Hour, time.Minute)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ks.RotateIfDue(time.Hour)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	reloaded, err := external.LoadJWTKeySet(path, service.SigningAlgorithmEdDSA, time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.VerificationKeys(), 1, "同時に起動しても鍵は1つだけ生成される")
+
+	_, err = os.Stat(path + ".lock")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestJWTKeySetTakesOverStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt-keyset.json")
+	lockPath := path + ".lock"
+	require.NoError(t, os.WriteFile(lockPath, nil, 0o600))
+	stale := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(lockPath, stale, stale))
+
+	ks, err := external.LoadJWTKeySet(path, service.SigningAlgorithmEdDSA, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	_, err = ks.Rotate()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,127 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidMailHeader = errors.New("mail header contains invalid characters")
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailSender struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailSender(config SMTPConfig) *SMTPMailSender {
+	return &SMTPMailSender{config: config}
+}
+
+func (s *SMTPMailSender) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return ErrInvalidMailHeader
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	if err := smtp.SendMail(addr, auth, s.config.From, []string{to}, s.buildMessage(to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTPMailSender) buildMessage(to, subject, body string) []byte {
+	var msg strings.Builder
+	msg.WriteString("From: " + s.config.From + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(msg.String())
+}
+
+type SentMail struct {
+	To      string
+	Subject string
+	Body    string
+	SentAt  time.Time
+}
+
+type InMemoryMailSender struct {
+	mu       sync.Mutex
+	messages []SentMail
+}
+
+func NewInMemoryMailSender() *InMemoryMailSender {
+	return &InMemoryMailSender{}
+}
+
+func (s *InMemoryMailSender) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, SentMail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		SentAt:  time.Now(),
+	})
+	return nil
+}
+
+func (s *InMemoryMailSender) Messages() []SentMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]SentMail, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
+
+func (s *InMemoryMailSender) LastMessageTo(to string) (SentMail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if s.messages[i].To == to {
+			return s.messages[i], true
+		}
+	}
+	return SentMail{}, false
+}
+
+func (s *InMemoryMailSender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+}
@@ -0,0 +1,132 @@
+package external_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startFakeSMTPServer(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					reply("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "DATA"):
+				inData = true
+				reply("354 End data with <CR><LF>.<CR><LF>")
+			case strings.HasPrefix(line, "QUIT"):
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	return "127.0.0.1", addr.Port, received
+}
+
+func TestSMTPMailSenderSend(t *testing.T) {
+	host, port, received := startFakeSMTPServer(t)
+
+	sender := external.NewSMTPMailSender(external.SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "no-reply@example.com",
+	})
+
+	err := sender.Send(context.Background(), "user@example.com", "パスワード再設定", "line1\nline2")
+	require.NoError(t, err)
+
+	message := <-received
+	assert.Contains(t, message, "From: no-reply@example.com\r\n")
+	assert.Contains(t, message, "To: user@example.com\r\n")
+	assert.Contains(t, message, "Subject: =?utf-8?q?")
+	assert.Contains(t, message, "Content-Type: text/plain; charset=UTF-8\r\n")
+	assert.Contains(t, message, "line1\r\nline2")
+}
+
+func TestSMTPMailSenderRejectsHeaderInjection(t *testing.T) {
+	sender := external.NewSMTPMailSender(external.SMTPConfig{Host: "127.0.0.1", Port: 25, From: "no-reply@example.com"})
+
+	err := sender.Send(context.Background(), "user@example.com\r\nBcc: attacker@example.com", "subject", "body")
+	assert.Equal(t, external.ErrInvalidMailHeader, err)
+
+	err = sender.Send(context.Background(), "user@example.com", "subject\nBcc: attacker@example.com", "body")
+	assert.Equal(t, external.ErrInvalidMailHeader, err)
+}
+
+func TestInMemoryMailSender(t *testing.T) {
+	sender := external.NewInMemoryMailSender()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sender.Send(ctx, "user@example.com", "subject "+strconv.Itoa(i), "body"))
+	}
+	require.NoError(t, sender.Send(ctx, "other@example.com", "other", "body"))
+
+	assert.Len(t, sender.Messages(), 4)
+
+	last, ok := sender.LastMessageTo("user@example.com")
+	require.True(t, ok)
+	assert.Equal(t, "subject 2", last.Subject)
+
+	_, ok = sender.LastMessageTo("missing@example.com")
+	assert.False(t, ok)
+
+	sender.Reset()
+	assert.Empty(t, sender.Messages())
+}
+
+func TestInMemoryMailSenderCanceledContext(t *testing.T) {
+	sender := external.NewInMemoryMailSender()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Error(t, sender.Send(ctx, "user@example.com", "subject", "body"))
+	assert.Empty(t, sender.Messages())
+}
@@ -0,0 +1,124 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	notificationChannelPrefix   = "notifications:user:"
+	notificationStreamKeyPrefix = "notification_streams:user:"
+	notificationEventBufferSize = 16
+)
+
+var acquireStreamScript = redis.NewScript(`
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+local ttl = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - ttl)
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) and redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], now, ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ttl)
+return 1
+`)
+
+type RedisNotificationBroker struct {
+	redis     *RedisClient
+	streamTTL time.Duration
+}
+
+func NewRedisNotificationBroker(redis *RedisClient, streamTTL time.Duration) *RedisNotificationBroker {
+	return &RedisNotificationBroker{
+		redis:     redis,
+		streamTTL: streamTTL,
+	}
+}
+
+func (b *RedisNotificationBroker) Publish(ctx context.Context, event *entity.NotificationEvent) error {
+	return b.redis.Publish(ctx, notificationChannel(event.UserID), event)
+}
+
+func (b *RedisNotificationBroker) Subscribe(ctx context.Context, userID uint) (service.NotificationSubscription, error) {
+	pubsub, err := b.redis.Subscribe(ctx, notificationChannel(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &redisNotificationSubscription{
+		pubsub: pubsub,
+		events: make(chan *entity.NotificationEvent, notificationEventBufferSize),
+		done:   make(chan struct{}),
+	}
+	go subscription.forward(pubsub.Channel())
+	return subscription, nil
+}
+
+func (b *RedisNotificationBroker) AcquireStream(ctx context.Context, userID uint, streamID string, limit int) (bool, error) {
+	acquired, err := b.redis.RunScript(ctx, acquireStreamScript, []string{notificationStreamKey(userID)}, streamID, b.streamTTL.Milliseconds(), limit).Int64()
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+func (b *RedisNotificationBroker) ReleaseStream(ctx context.Context, userID uint, streamID string) error {
+	return b.redis.client.ZRem(ctx, notificationStreamKey(userID), streamID).Err()
+}
+
+type redisNotificationSubscription struct {
+	pubsub    *redis.PubSub
+	events    chan *entity.NotificationEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *redisNotificationSubscription) Events() <-chan *entity.NotificationEvent {
+	return s.events
+}
+
+func (s *redisNotificationSubscription) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return s.pubsub.Close()
+}
+
+func (s *redisNotificationSubscription) forward(messages <-chan *redis.Message) {
+	defer close(s.events)
+	for message := range messages {
+		event, err := decodeNotificationEvent(message.Payload)
+		if err != nil {
+			log.Printf("⚠️ 通知イベントのデコードに失敗しました (%s): %v", message.Channel, err)
+			continue
+		}
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func decodeNotificationEvent(payload string) (*entity.NotificationEvent, error) {
+	var event entity.NotificationEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func notificationChannel(userID uint) string {
+	return fmt.Sprintf("%s%d", notificationChannelPrefix, userID)
+}
+
+func notificationStreamKey(userID uint) string {
+	return fmt.Sprintf("%s%d", notificationStreamKeyPrefix, userID)
+}
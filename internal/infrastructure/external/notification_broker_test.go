@@ -0,0 +1,40 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisNotificationBrokerPublish(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+
+	broker := external.NewRedisNotificationBroker(external.NewRedisClientFromClient(db), 45*time.Second)
+	event := entity.NewNotificationUnreadCountEvent(1, 3)
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	mock.ExpectPublish("notifications:user:1", payload).SetVal(1)
+
+	assert.NoError(t, broker.Publish(context.Background(), event))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisNotificationBrokerReleaseStream(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+
+	broker := external.NewRedisNotificationBroker(external.NewRedisClientFromClient(db), 45*time.Second)
+
+	mock.ExpectZRem("notification_streams:user:1", "stream-1").SetVal(1)
+
+	assert.NoError(t, broker.ReleaseStream(context.Background(), 1, "stream-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,208 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type RateLimitResult struct {
+	Allowed     bool
+	Limit       int64
+	Remaining   int64
+	ResetAt     time.Time
+	RetryAfter  time.Duration
+	WindowStart time.Time
+}
+
+var incrementRateLimitScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+var fixedWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local count = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], window)
+	ttl = window
+end
+
+if count > limit then
+	return {0, 0, ttl, ttl}
+end
+return {1, limit - count, ttl, 0}
+`)
+
+var slidingLogScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local reset = 0
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+if newest[2] then
+	reset = tonumber(newest[2]) + window - now
+end
+
+local retry = 0
+if allowed == 0 then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	retry = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, math.max(limit - count, 0), reset, retry}
+`)
+
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+local current = math.floor(now / window)
+local elapsed = now - current * window
+
+local state = redis.call('HMGET', KEYS[1], 'window', 'current', 'previous')
+local stored = tonumber(state[1])
+local currentCount = tonumber(state[2]) or 0
+local previousCount = tonumber(state[3]) or 0
+if stored ~= current then
+	if stored == current - 1 then
+		previousCount = currentCount
+	else
+		previousCount = 0
+	end
+	currentCount = 0
+end
+
+local estimated = previousCount * (window - elapsed) / window + currentCount
+local allowed = 0
+if estimated + 1 <= limit then
+	currentCount = currentCount + 1
+	estimated = estimated + 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'window', current, 'current', currentCount, 'previous', previousCount)
+redis.call('PEXPIRE', KEYS[1], window * 2)
+
+local reset = 0
+if currentCount > 0 then
+	reset = window - elapsed + window
+elseif previousCount > 0 then
+	reset = window - elapsed
+end
+
+local retry = 0
+if allowed == 0 then
+	if currentCount + 1 <= limit then
+		retry = math.ceil(window - elapsed - (limit - 1 - currentCount) * window / previousCount)
+	else
+		retry = math.ceil(window - elapsed + window * (1 - (limit - 1) / currentCount))
+	end
+end
+
+return {allowed, math.max(math.floor(limit - estimated), 0), reset, retry}
+`)
+
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'timestamp')
+local tokens = tonumber(state[1])
+local timestamp = tonumber(state[2])
+if tokens == nil or timestamp == nil then
+	tokens = capacity
+	timestamp = now
+end
+if now > timestamp then
+	tokens = math.min(capacity, tokens + (now - timestamp) * rate)
+	timestamp = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'timestamp', timestamp)
+redis.call('PEXPIRE', KEYS[1], window)
+
+local retry = 0
+if allowed == 0 then
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+return {allowed, math.floor(tokens), math.ceil((capacity - tokens) / rate), retry}
+`)
+
+var rateLimitScripts = map[string]*redis.Script{
+	entity.RateLimitAlgorithmFixedWindow:   fixedWindowScript,
+	entity.RateLimitAlgorithmSlidingLog:    slidingLogScript,
+	entity.RateLimitAlgorithmSlidingWindow: slidingWindowScript,
+	entity.RateLimitAlgorithmTokenBucket:   tokenBucketScript,
+}
+
+func (c *CacheService) CheckRateLimit(ctx context.Context, algorithm, key string, limit int64, window time.Duration) (*RateLimitResult, error) {
+	if algorithm == "" {
+		algorithm = entity.RateLimitAlgorithmFixedWindow
+	}
+
+	script, ok := rateLimitScripts[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported rate limit algorithm: %s", algorithm)
+	}
+
+	if limit <= 0 || window < time.Millisecond {
+		return nil, fmt.Errorf("invalid rate limit: %d per %v", limit, window)
+	}
+
+	fullKey := fmt.Sprintf("rate_limit:%s:%s", algorithm, key)
+	reply, err := c.redis.RunScript(ctx, script, []string{fullKey}, limit, window.Milliseconds(), uuid.NewString()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+	}
+
+	now := time.Now()
+	resetAt := now.Add(time.Duration(reply[2]) * time.Millisecond)
+	windowStart := now.Add(-window)
+	if algorithm == entity.RateLimitAlgorithmFixedWindow {
+		windowStart = resetAt.Add(-window)
+	}
+
+	return &RateLimitResult{
+		Allowed:     reply[0] == 1,
+		Limit:       limit,
+		Remaining:   reply[1],
+		ResetAt:     resetAt,
+		RetryAfter:  time.Duration(reply[3]) * time.Millisecond,
+		WindowStart: windowStart,
+	}, nil
+}
@@ -19,6 +19,12 @@ func NewRedisClient(addr, password string, db int) *RedisClient {
 	return NewRedisClientWithRetry(addr, password, db)
 }
 
+func NewRedisClientFromClient(client *redis.Client) *RedisClient {
+	return &RedisClient{
+		client: client,
+	}
+}
+
 func NewRedisClientWithRetry(addr, password string, db int) *RedisClient {
 	maxRetries := getRedisMaxRetries()
 	retryInterval := getRedisRetryInterval()
@@ -113,6 +119,22 @@ func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
 	return count > 0, err
 }
 
+func (r *RedisClient) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
 func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
 	data, err := json.Marshal(value)
 	if err != nil {
@@ -129,6 +151,27 @@ func (r *RedisClient) Expire(ctx context.Context, key string, expiration time.Du
 	return r.client.Expire(ctx, key, expiration).Err()
 }
 
+func (r *RedisClient) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	return script.Run(ctx, r.client, keys, args...)
+}
+
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, channel, data).Err()
+}
+
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) (*redis.PubSub, error) {
+	pubsub := r.client.Subscribe(ctx, channels...)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+	return pubsub, nil
+}
+
 func (r *RedisClient) Close() error {
 	return r.client.Close()
 }
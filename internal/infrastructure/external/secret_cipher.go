@@ -0,0 +1,65 @@
+package external
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+type AESSecretCipher struct {
+	aead cipher.AEAD
+}
+
+func NewAESSecretCipher(key string) (*AESSecretCipher, error) {
+	if key == "" {
+		return nil, errors.New("encryption key is required")
+	}
+
+	derived := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &AESSecretCipher{aead: aead}, nil
+}
+
+func (c *AESSecretCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *AESSecretCipher) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", ErrInvalidCiphertext
+	}
+
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
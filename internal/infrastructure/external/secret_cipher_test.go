@@ -0,0 +1,66 @@
+package external_test
+
+import (
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESSecretCipherRoundTrip(t *testing.T) {
+	cipher, err := external.NewAESSecretCipher("test-encryption-key")
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.NotEqual(t, "JBSWY3DPEHPK3PXP", encrypted)
+
+	decrypted, err := cipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", decrypted)
+}
+
+func TestAESSecretCipherUsesRandomNonce(t *testing.T) {
+	cipher, err := external.NewAESSecretCipher("test-encryption-key")
+	require.NoError(t, err)
+
+	first, err := cipher.Encrypt("secret")
+	require.NoError(t, err)
+	second, err := cipher.Encrypt("secret")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestAESSecretCipherDecryptErrors(t *testing.T) {
+	cipher, err := external.NewAESSecretCipher("test-encryption-key")
+	require.NoError(t, err)
+
+	other, err := external.NewAESSecretCipher("another-key")
+	require.NoError(t, err)
+
+	encrypted, err := other.Encrypt("secret")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		ciphertext string
+	}{
+		{"別の鍵で暗号化された値", encrypted},
+		{"base64でない値", "not-base64!!"},
+		{"短すぎる値", "AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cipher.Decrypt(tt.ciphertext)
+			assert.ErrorIs(t, err, external.ErrInvalidCiphertext)
+		})
+	}
+}
+
+func TestNewAESSecretCipherEmptyKey(t *testing.T) {
+	_, err := external.NewAESSecretCipher("")
+	assert.Error(t, err)
+}
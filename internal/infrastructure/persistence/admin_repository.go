@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type adminActionRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminActionRepository(db *gorm.DB) repository.AdminActionRepository {
+	return &adminActionRepository{db: db}
+}
+
+func (r *adminActionRepository) GetByIdempotencyKey(ctx context.Context, adminUserID uint, idempotencyKey string) (*entity.AdminAction, error) {
+	var gormAction GormAdminAction
+	if err := r.db.WithContext(ctx).
+		Where("admin_user_id = ? AND idempotency_key = ?", adminUserID, idempotencyKey).
+		First(&gormAction).Error; err != nil {
+		return nil, err
+	}
+	return AdminActionGormToEntity(&gormAction), nil
+}
+
+func (r *adminActionRepository) RecordPointAdjustment(ctx context.Context, action *entity.AdminAction, transaction *entity.PointTransaction, budget *entity.AdminPointBudget) (*entity.UserMembership, error) {
+	var membership *entity.UserMembership
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if budget != nil {
+			// Locking the admin's user row serializes grants by the same admin so the budget check cannot race.
+			var admin GormUser
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id").
+				First(&admin, action.AdminUserID).Error; err != nil {
+				return err
+			}
+
+			granted, err := sumGrantedPoints(tx, action.AdminUserID, budget.Since)
+			if err != nil {
+				return err
+			}
+			if !budget.Allows(granted, transaction.Points) {
+				return entity.ErrAdminPointBudgetExceeded
+			}
+		}
+
+		var err error
+		membership, err = recordPointTransaction(tx, transaction)
+		if err != nil {
+			return err
+		}
+
+		action.PointTransactionID = &transaction.ID
+		gormAction := AdminActionEntityToGorm(action)
+		if err := tx.Create(gormAction).Error; err != nil {
+			return err
+		}
+		action.ID = gormAction.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *adminActionRepository) SumGrantedPoints(ctx context.Context, adminUserID uint, since time.Time) (int, error) {
+	return sumGrantedPoints(r.db.WithContext(ctx), adminUserID, since)
+}
+
+func sumGrantedPoints(db *gorm.DB, adminUserID uint, since time.Time) (int, error) {
+	var granted int
+	if err := db.Model(&GormPointTransaction{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("reference_type = ? AND reference_id = ? AND points > 0 AND created_at >= ?", entity.AdminPointReferenceType, adminUserID, since).
+		Scan(&granted).Error; err != nil {
+		return 0, err
+	}
+	return granted, nil
+}
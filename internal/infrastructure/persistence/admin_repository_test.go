@@ -0,0 +1,136 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupAdminRepositoryTest(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	cleanup := func() {
+		_ = db.Close()
+	}
+
+	return gormDB, mock, cleanup
+}
+
+func TestAdminActionRepositoryGetByIdempotencyKey(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewAdminActionRepository(gormDB)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "admin_user_id", "action_type", "target_type", "target_id", "description",
+		"result", "idempotency_key", "point_transaction_id", "created_at", "deleted_at",
+	}).AddRow(1, 9, entity.AdminActionPointGrant, entity.AdminActionTargetUser, "1", "補填", "success", "key-1", 5, time.Now(), nil)
+	mock.ExpectQuery("SELECT \\* FROM `admin_actions` WHERE \\(admin_user_id = \\? AND idempotency_key = \\?\\)").
+		WithArgs(uint(9), "key-1", 1).
+		WillReturnRows(rows)
+
+	action, err := repo.GetByIdempotencyKey(context.Background(), 9, "key-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", action.IdempotencyKey)
+	assert.Equal(t, uint(5), *action.PointTransactionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdminActionRepositorySumGrantedPoints(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewAdminActionRepository(gormDB)
+	since := time.Now().Truncate(24 * time.Hour)
+
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(points\\), 0\\) FROM `point_transactions`").
+		WithArgs(entity.AdminPointReferenceType, uint(9), since).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+
+	granted, err := repo.SumGrantedPoints(context.Background(), 9, since)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 700, granted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdminActionRepositoryRecordPointAdjustment(t *testing.T) {
+	t.Run("予算内の付与は残高・取引・監査ログを同一トランザクションで登録する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewAdminActionRepository(gormDB)
+		adminID := uint(9)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 300, "補填")
+		transaction.ReferenceType = entity.AdminPointReferenceType
+		transaction.ReferenceID = &adminID
+		action := entity.NewAdminPointAction(adminID, 1, 300, "補填", "key-1")
+		budget := entity.NewAdminPointBudget(1000, time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT `id` FROM `users` WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT \\? FOR UPDATE").
+			WithArgs(adminID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(adminID))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(points\\), 0\\) FROM `point_transactions`").
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\?").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(100))
+		mock.ExpectExec("UPDATE `user_memberships`").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `point_transactions`").
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO `admin_actions`").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		membership, err := repo.RecordPointAdjustment(context.Background(), action, transaction, budget)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 400, membership.Points)
+		assert.Equal(t, uint(5), *action.PointTransactionID)
+		assert.Equal(t, uint(3), action.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("予算を超える付与はロールバックする", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewAdminActionRepository(gormDB)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 400, "補填")
+		action := entity.NewAdminPointAction(9, 1, 400, "補填", "key-2")
+		budget := entity.NewAdminPointBudget(1000, time.Now())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT `id` FROM `users`").
+			WithArgs(uint(9), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(points\\), 0\\) FROM `point_transactions`").
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+		mock.ExpectRollback()
+
+		membership, err := repo.RecordPointAdjustment(context.Background(), action, transaction, budget)
+
+		assert.Nil(t, membership)
+		assert.ErrorIs(t, err, entity.ErrAdminPointBudgetExceeded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,11 +2,14 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type authRepository struct {
@@ -47,6 +50,16 @@ func (r *authRepository) Update(ctx context.Context, auth *entity.Auth) error {
 	return r.db.WithContext(ctx).Save(gormAuth).Error
 }
 
+func (r *authRepository) ConsumeTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&GormAuth{}).
+		Where("user_id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 func (r *authRepository) Delete(ctx context.Context, userID uint) error {
 	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&GormAuth{}).Error
 }
@@ -74,9 +87,44 @@ func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
 	return nil
 }
 
+func (r *roleRepository) CreateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		gormRole := RoleEntityToGorm(role)
+		if err := tx.Create(gormRole).Error; err != nil {
+			return err
+		}
+		role.ID = gormRole.ID
+
+		if err := replaceRolePermissions(tx, role.ID, permissionIDs); err != nil {
+			return err
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
+// UpdateWithPermissions leaves the role's permissions untouched when
+// permissionIDs is nil.
+func (r *roleRepository) UpdateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(RoleEntityToGorm(role)).Error; err != nil {
+			return err
+		}
+
+		if permissionIDs != nil {
+			if err := replaceRolePermissions(tx, role.ID, permissionIDs); err != nil {
+				return err
+			}
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
 func (r *roleRepository) GetByID(ctx context.Context, id uint) (*entity.Role, error) {
 	var gormRole GormRole
 	if err := r.db.WithContext(ctx).First(&gormRole, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrRoleNotFound
+		}
 		return nil, err
 	}
 	return RoleGormToEntity(&gormRole), nil
@@ -85,6 +133,9 @@ func (r *roleRepository) GetByID(ctx context.Context, id uint) (*entity.Role, er
 func (r *roleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
 	var gormRole GormRole
 	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&gormRole).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrRoleNotFound
+		}
 		return nil, err
 	}
 	return RoleGormToEntity(&gormRole), nil
@@ -110,7 +161,28 @@ func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
 }
 
 func (r *roleRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&GormRole{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteRole(tx, id)
+	})
+}
+
+func (r *roleRepository) DeleteWithEvent(ctx context.Context, id uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteRole(tx, id); err != nil {
+			return err
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
+func deleteRole(tx *gorm.DB, id uint) error {
+	if err := tx.Where("role_id = ?", id).Delete(&GormUserRole{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("role_id = ?", id).Delete(&GormRolePermission{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&GormRole{}, id).Error
 }
 
 func (r *roleRepository) AssignToUser(ctx context.Context, userID, roleID uint) error {
@@ -121,14 +193,43 @@ func (r *roleRepository) AssignToUser(ctx context.Context, userID, roleID uint)
 	return r.db.WithContext(ctx).Create(userRole).Error
 }
 
+func (r *roleRepository) AssignToUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&GormUserRole{UserID: userID, RoleID: roleID}).Error; err != nil {
+			return err
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
 func (r *roleRepository) RemoveFromUser(ctx context.Context, userID, roleID uint) error {
 	return r.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&GormUserRole{}).Error
 }
 
+func (r *roleRepository) RemoveFromUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := removeUserRole(tx, userID, roleID); err != nil {
+			return err
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
+func removeUserRole(tx *gorm.DB, userID, roleID uint) error {
+	result := tx.Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&GormUserRole{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrRoleAssignmentNotFound
+	}
+	return nil
+}
+
 func (r *roleRepository) GetUserRoles(ctx context.Context, userID uint) ([]*entity.Role, error) {
 	var gormRoles []GormRole
 	if err := r.db.WithContext(ctx).
-		Joins("JOIN user_roles ON roles.id = user_roles.role_id").
+		Joins("JOIN user_roles ON roles.id = user_roles.role_id AND user_roles.deleted_at IS NULL").
 		Where("user_roles.user_id = ?", userID).
 		Find(&gormRoles).Error; err != nil {
 		return nil, err
@@ -147,7 +248,7 @@ func (r *roleRepository) GetUserRoleNames(ctx context.Context, userID uint) ([]s
 	if err := r.db.WithContext(ctx).
 		Model(&GormRole{}).
 		Select("roles.name").
-		Joins("JOIN user_roles ON roles.id = user_roles.role_id").
+		Joins("JOIN user_roles ON roles.id = user_roles.role_id AND user_roles.deleted_at IS NULL").
 		Where("user_roles.user_id = ?", userID).
 		Pluck("name", &roleNames).Error; err != nil {
 		return nil, err
@@ -160,6 +261,81 @@ func (r *roleRepository) GetUserRoleNames(ctx context.Context, userID uint) ([]s
 	return roleNames, nil
 }
 
+func (r *roleRepository) HasUserRole(ctx context.Context, userID, roleID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&GormUserRole{}).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *roleRepository) RemoveFromUserUnlessLast(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var role GormRole
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&role, roleID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entity.ErrRoleNotFound
+			}
+			return err
+		}
+
+		// Suspended or deleted members cannot use the role, so only other active members keep it staffed.
+		var count int64
+		if err := tx.Model(&GormUserRole{}).
+			Joins("JOIN users ON users.id = user_roles.user_id AND users.deleted_at IS NULL").
+			Joins("JOIN auths ON auths.user_id = user_roles.user_id AND auths.deleted_at IS NULL AND auths.is_active = ?", true).
+			Where("user_roles.role_id = ? AND user_roles.user_id <> ?", roleID, userID).
+			Distinct("user_roles.user_id").
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return entity.ErrLastRoleMember
+		}
+
+		if err := removeUserRole(tx, userID, roleID); err != nil {
+			return err
+		}
+		return createSecurityEvent(tx, event)
+	})
+}
+
+func (r *roleRepository) ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&GormUser{}).
+		Joins("JOIN user_roles ON users.id = user_roles.user_id AND user_roles.deleted_at IS NULL").
+		Where("user_roles.role_id = ?", roleID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var gormUsers []GormUser
+	if err := query.Order("users.id").Offset(offset).Limit(limit).Find(&gormUsers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]*entity.User, len(gormUsers))
+	for i, gormUser := range gormUsers {
+		users[i] = UserGormToEntity(&gormUser)
+	}
+
+	return users, total, nil
+}
+
+func (r *roleRepository) GetMemberIDs(ctx context.Context, roleID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.WithContext(ctx).Model(&GormUserRole{}).
+		Where("role_id = ?", roleID).
+		Distinct().
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
 func (r *roleRepository) AssignAdminRole(ctx context.Context, userID uint) error {
 	var adminRole GormRole
 	if err := r.db.WithContext(ctx).Where("name = ?", "admin").First(&adminRole).Error; err != nil {
@@ -201,9 +377,9 @@ func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.Refre
 	return nil
 }
 
-func (r *refreshTokenRepository) GetByToken(ctx context.Context, token string) (*entity.RefreshToken, error) {
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
 	var gormToken GormRefreshToken
-	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&gormToken).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&gormToken).Error; err != nil {
 		return nil, err
 	}
 	return RefreshTokenGormToEntity(&gormToken), nil
@@ -214,12 +390,221 @@ func (r *refreshTokenRepository) Update(ctx context.Context, token *entity.Refre
 	return r.db.WithContext(ctx).Save(gormToken).Error
 }
 
+func (r *refreshTokenRepository) MarkRotated(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&GormRefreshToken{}).
+		Where("id = ? AND is_revoked = ?", id, false).
+		Updates(map[string]interface{}{
+			"is_revoked": true,
+			"rotated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint) error {
 	return r.db.WithContext(ctx).Model(&GormRefreshToken{}).
 		Where("user_id = ?", userID).
 		Update("is_revoked", true).Error
 }
 
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&GormRefreshToken{}).
+		Where("family_id = ?", familyID).
+		Update("is_revoked", true).Error
+}
+
 func (r *refreshTokenRepository) DeleteExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).Where("expires_at < NOW()").Delete(&GormRefreshToken{}).Error
 }
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) repository.UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *entity.UserToken) error {
+	gormToken := UserTokenEntityToGorm(token)
+	if err := r.db.WithContext(ctx).Create(gormToken).Error; err != nil {
+		return err
+	}
+	token.ID = gormToken.ID
+	return nil
+}
+
+func (r *userTokenRepository) GetByHash(ctx context.Context, tokenType, tokenHash string) (*entity.UserToken, error) {
+	var gormToken GormUserToken
+	if err := r.db.WithContext(ctx).
+		Where("token_type = ? AND token_hash = ?", tokenType, tokenHash).
+		First(&gormToken).Error; err != nil {
+		return nil, err
+	}
+	return UserTokenGormToEntity(&gormToken), nil
+}
+
+func (r *userTokenRepository) ConsumeByHashWithAuth(ctx context.Context, tokenType, tokenHash string, auth *entity.Auth) (bool, error) {
+	consumed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("token_type = ? AND token_hash = ?", tokenType, tokenHash).
+			Delete(&GormUserToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+
+		if err := tx.Save(AuthEntityToGorm(auth)).Error; err != nil {
+			return err
+		}
+		consumed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return consumed, nil
+}
+
+func (r *userTokenRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&GormUserToken{}, id).Error
+}
+
+func (r *userTokenRepository) DeleteByUserIDAndType(ctx context.Context, userID uint, tokenType string) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND token_type = ?", userID, tokenType).
+		Delete(&GormUserToken{}).Error
+}
+
+func (r *userTokenRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("expires_at < NOW()").Delete(&GormUserToken{}).Error
+}
+
+type permissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) repository.PermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (r *permissionRepository) GetUserPermissions(ctx context.Context, userID uint) ([]*entity.Permission, error) {
+	var gormPermissions []GormPermission
+	if err := r.db.WithContext(ctx).
+		Distinct("permissions.*").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id AND role_permissions.deleted_at IS NULL").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id AND user_roles.deleted_at IS NULL").
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id = ?", userID).
+		Find(&gormPermissions).Error; err != nil {
+		return nil, err
+	}
+
+	permissions := make([]*entity.Permission, len(gormPermissions))
+	for i, gormPermission := range gormPermissions {
+		permissions[i] = PermissionGormToEntity(&gormPermission)
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) GetScopeByName(ctx context.Context, name string) (*entity.Scope, error) {
+	var gormScope GormScope
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&gormScope).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrScopeNotFound
+		}
+		return nil, err
+	}
+	return ScopeGormToEntity(&gormScope), nil
+}
+
+func (r *permissionRepository) GetActiveUserScopes(ctx context.Context, userID uint, now time.Time) ([]*entity.UserScope, error) {
+	var gormUserScopes []GormUserScope
+	if err := r.db.WithContext(ctx).
+		Joins("Scope").
+		Where("user_scopes.user_id = ? AND (user_scopes.expires_at IS NULL OR user_scopes.expires_at > ?)", userID, now).
+		Where("Scope.is_active = ?", true).
+		Find(&gormUserScopes).Error; err != nil {
+		return nil, err
+	}
+
+	userScopes := make([]*entity.UserScope, len(gormUserScopes))
+	for i, gormUserScope := range gormUserScopes {
+		userScopes[i] = UserScopeGormToEntity(&gormUserScope)
+	}
+
+	return userScopes, nil
+}
+
+func (r *permissionRepository) CreateUserScope(ctx context.Context, userScope *entity.UserScope) error {
+	gormUserScope := UserScopeEntityToGorm(userScope)
+	if err := r.db.WithContext(ctx).Omit("User", "Scope").Create(gormUserScope).Error; err != nil {
+		return err
+	}
+	userScope.ID = gormUserScope.ID
+	return nil
+}
+
+func (r *permissionRepository) RevokeUserScope(ctx context.Context, userID, scopeID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND scope_id = ?", userID, scopeID).
+		Delete(&GormUserScope{}).Error
+}
+
+func (r *permissionRepository) GetByNames(ctx context.Context, names []string) ([]*entity.Permission, error) {
+	var gormPermissions []GormPermission
+	if err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&gormPermissions).Error; err != nil {
+		return nil, err
+	}
+
+	permissions := make([]*entity.Permission, len(gormPermissions))
+	for i, gormPermission := range gormPermissions {
+		permissions[i] = PermissionGormToEntity(&gormPermission)
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) GetRolePermissions(ctx context.Context, roleID uint) ([]*entity.Permission, error) {
+	var gormPermissions []GormPermission
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id AND role_permissions.deleted_at IS NULL").
+		Where("role_permissions.role_id = ?", roleID).
+		Order("permissions.name").
+		Find(&gormPermissions).Error; err != nil {
+		return nil, err
+	}
+
+	permissions := make([]*entity.Permission, len(gormPermissions))
+	for i, gormPermission := range gormPermissions {
+		permissions[i] = PermissionGormToEntity(&gormPermission)
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) ReplaceRolePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRolePermissions(tx, roleID, permissionIDs)
+	})
+}
+
+func replaceRolePermissions(tx *gorm.DB, roleID uint, permissionIDs []uint) error {
+	if err := tx.Where("role_id = ?", roleID).Delete(&GormRolePermission{}).Error; err != nil {
+		return err
+	}
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	rolePermissions := make([]GormRolePermission, len(permissionIDs))
+	for i, permissionID := range permissionIDs {
+		rolePermissions[i] = GormRolePermission{RoleID: roleID, PermissionID: permissionID}
+	}
+	return tx.Omit("Role", "Permission").Create(&rolePermissions).Error
+}
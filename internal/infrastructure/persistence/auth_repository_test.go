@@ -2,6 +2,7 @@ package persistence_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -56,6 +57,12 @@ func TestAuthRepositoryCreate(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -188,6 +195,12 @@ func TestAuthRepositoryUpdate(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -198,6 +211,47 @@ func TestAuthRepositoryUpdate(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAuthRepositoryConsumeTOTPStep(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		want         bool
+	}{
+		{
+			name:         "新しいステップを記録する",
+			rowsAffected: 1,
+			want:         true,
+		},
+		{
+			name:         "既に使われたステップは記録されない",
+			rowsAffected: 0,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+			defer cleanup()
+
+			repo := persistence.NewAuthRepository(gormDB)
+			ctx := context.Background()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `auths` SET `two_factor_last_step`=\\?,`updated_at`=\\? WHERE \\(user_id = \\? AND two_factor_last_step < \\?\\) AND `auths`.`deleted_at` IS NULL").
+				WithArgs(int64(100), sqlmock.AnyArg(), uint(1), int64(100)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			consumed, err := repo.ConsumeTOTPStep(ctx, 1, 100)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, consumed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestAuthRepositoryDelete(t *testing.T) {
 	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
 	defer cleanup()
@@ -264,7 +318,8 @@ func TestRefreshTokenRepositoryCreate(t *testing.T) {
 
 	token := &entity.RefreshToken{
 		UserID:    1,
-		Token:     "refresh_token_123",
+		TokenHash: entity.HashRefreshToken("refresh_token_123"),
+		FamilyID:  "family-1",
 		ExpiresAt: time.Now().Add(24 * time.Hour),
 		IsRevoked: false,
 	}
@@ -279,6 +334,8 @@ func TestRefreshTokenRepositoryCreate(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -290,18 +347,19 @@ func TestRefreshTokenRepositoryCreate(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestRefreshTokenRepositoryGetByToken(t *testing.T) {
+func TestRefreshTokenRepositoryGetByTokenHash(t *testing.T) {
 	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
 	defer cleanup()
 
 	repo := persistence.NewRefreshTokenRepository(gormDB)
 	ctx := context.Background()
 
-	tokenStr := "refresh_token_123"
+	tokenHash := entity.HashRefreshToken("refresh_token_123")
 	expectedToken := &entity.RefreshToken{
 		ID:        1,
 		UserID:    1,
-		Token:     tokenStr,
+		TokenHash: tokenHash,
+		FamilyID:  "family-1",
 		ExpiresAt: time.Now().Add(24 * time.Hour),
 		IsRevoked: false,
 		CreatedAt: time.Now(),
@@ -309,29 +367,93 @@ func TestRefreshTokenRepositoryGetByToken(t *testing.T) {
 	}
 
 	rows := sqlmock.NewRows([]string{
-		"id", "user_id", "token", "expires_at", "is_revoked",
-		"created_at", "updated_at", "deleted_at",
+		"id", "user_id", "token_hash", "family_id", "expires_at", "is_revoked",
+		"rotated_at", "created_at", "updated_at", "deleted_at",
 	}).AddRow(
 		expectedToken.ID,
 		expectedToken.UserID,
-		expectedToken.Token,
+		expectedToken.TokenHash,
+		expectedToken.FamilyID,
 		expectedToken.ExpiresAt,
 		expectedToken.IsRevoked,
+		nil,
 		expectedToken.CreatedAt,
 		expectedToken.UpdatedAt,
 		nil,
 	)
 
-	mock.ExpectQuery("SELECT \\* FROM `refresh_tokens` WHERE token = \\? AND `refresh_tokens`.`deleted_at` IS NULL ORDER BY `refresh_tokens`.`id` LIMIT \\?").
-		WithArgs(tokenStr, 1).
+	mock.ExpectQuery("SELECT \\* FROM `refresh_tokens` WHERE token_hash = \\? AND `refresh_tokens`.`deleted_at` IS NULL ORDER BY `refresh_tokens`.`id` LIMIT \\?").
+		WithArgs(tokenHash, 1).
 		WillReturnRows(rows)
 
-	result, err := repo.GetByToken(ctx, tokenStr)
+	result, err := repo.GetByTokenHash(ctx, tokenHash)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, expectedToken.ID, result.ID)
-	assert.Equal(t, expectedToken.Token, result.Token)
+	assert.Equal(t, expectedToken.TokenHash, result.TokenHash)
+	assert.Equal(t, expectedToken.FamilyID, result.FamilyID)
+	assert.Nil(t, result.RotatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryMarkRotated(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		want         bool
+	}{
+		{
+			name:         "未使用のトークンをローテーション済みにする",
+			rowsAffected: 1,
+			want:         true,
+		},
+		{
+			name:         "既に失効済みのトークンは更新されない",
+			rowsAffected: 0,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+			defer cleanup()
+
+			repo := persistence.NewRefreshTokenRepository(gormDB)
+			ctx := context.Background()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `refresh_tokens` SET `is_revoked`=\\?,`rotated_at`=\\?,`updated_at`=\\? WHERE \\(id = \\? AND is_revoked = \\?\\) AND `refresh_tokens`.`deleted_at` IS NULL").
+				WithArgs(true, sqlmock.AnyArg(), sqlmock.AnyArg(), uint(1), false).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			rotated, err := repo.MarkRotated(ctx, 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rotated)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepositoryRevokeFamily(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRefreshTokenRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `is_revoked`=\\?,`updated_at`=\\? WHERE family_id = \\? AND `refresh_tokens`.`deleted_at` IS NULL").
+		WithArgs(true, sqlmock.AnyArg(), "family-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	err := repo.RevokeFamily(ctx, "family-1")
+
+	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -374,3 +496,486 @@ func TestRefreshTokenRepositoryDeleteExpired(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserTokenRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserTokenRepository(gormDB)
+	ctx := context.Background()
+
+	token := entity.NewUserToken(1, entity.UserTokenTypePasswordReset, "hashed", time.Now().Add(time.Hour))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `user_tokens`").
+		WithArgs(
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), token.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryGetByHash(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserTokenRepository(gormDB)
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(time.Hour)
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "token_type", "token_hash", "expires_at",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(1, 2, entity.UserTokenTypeEmailVerification, "hashed", expiresAt, time.Now(), time.Now(), nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `user_tokens` WHERE \\(token_type = \\? AND token_hash = \\?\\) AND `user_tokens`.`deleted_at` IS NULL ORDER BY `user_tokens`.`id` LIMIT \\?").
+		WithArgs(entity.UserTokenTypeEmailVerification, "hashed", 1).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByHash(ctx, entity.UserTokenTypeEmailVerification, "hashed")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, uint(2), result.UserID)
+	assert.Equal(t, entity.UserTokenTypeEmailVerification, result.TokenType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryDeleteByUserIDAndType(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserTokenRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_tokens` SET `deleted_at`=\\? WHERE \\(user_id = \\? AND token_type = \\?\\) AND `user_tokens`.`deleted_at` IS NULL").
+		WithArgs(sqlmock.AnyArg(), uint(1), entity.UserTokenTypePasswordReset).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.DeleteByUserIDAndType(ctx, 1, entity.UserTokenTypePasswordReset)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryConsumeByHashWithAuth(t *testing.T) {
+	auth := &entity.Auth{ID: 1, UserID: 1, Email: "test@example.com", PasswordHash: "newhashedpassword", IsActive: true, UpdatedAt: time.Now()}
+
+	t.Run("トークンを消費してパスワードを保存する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewUserTokenRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `user_tokens` SET `deleted_at`=\\? WHERE \\(token_type = \\? AND token_hash = \\?\\) AND `user_tokens`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), entity.UserTokenTypePasswordReset, "hash").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `auths` SET").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		consumed, err := repo.ConsumeByHashWithAuth(context.Background(), entity.UserTokenTypePasswordReset, "hash", auth)
+
+		assert.NoError(t, err)
+		assert.True(t, consumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("消費済みのトークンではパスワードを保存しない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewUserTokenRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `user_tokens` SET `deleted_at`=\\? WHERE \\(token_type = \\? AND token_hash = \\?\\) AND `user_tokens`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), entity.UserTokenTypePasswordReset, "hash").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		consumed, err := repo.ConsumeByHashWithAuth(context.Background(), entity.UserTokenTypePasswordReset, "hash", auth)
+
+		assert.NoError(t, err)
+		assert.False(t, consumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("パスワードの保存に失敗したらトークンの消費も取り消す", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewUserTokenRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `user_tokens` SET `deleted_at`=\\? WHERE \\(token_type = \\? AND token_hash = \\?\\) AND `user_tokens`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), entity.UserTokenTypePasswordReset, "hash").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `auths` SET").
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		consumed, err := repo.ConsumeByHashWithAuth(context.Background(), entity.UserTokenTypePasswordReset, "hash", auth)
+
+		assert.Error(t, err)
+		assert.False(t, consumed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPermissionRepositoryGetUserPermissions(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPermissionRepository(gormDB)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "resource", "action", "scope", "description",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(10, "fraud.write", "fraud", "write", "*", "不正検知情報の更新", time.Now(), time.Now(), nil)
+
+	mock.ExpectQuery("SELECT DISTINCT permissions.\\* FROM `permissions` JOIN role_permissions .+ JOIN user_roles .+ JOIN roles .+ WHERE user_roles.user_id = \\? AND `permissions`.`deleted_at` IS NULL").
+		WithArgs(uint(1)).
+		WillReturnRows(rows)
+
+	permissions, err := repo.GetUserPermissions(ctx, 1)
+
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, "fraud:write:*", permissions[0].Grant().String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionRepositoryGetScopeByName(t *testing.T) {
+	t.Run("存在するスコープ", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPermissionRepository(gormDB)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "name", "resource", "description", "is_active",
+			"created_at", "updated_at", "deleted_at",
+		}).AddRow(4, "fraud", "fraud", "不正検知機能", true, time.Now(), time.Now(), nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `scopes` WHERE name = \\? AND `scopes`.`deleted_at` IS NULL ORDER BY `scopes`.`id` LIMIT \\?").
+			WithArgs("fraud", 1).
+			WillReturnRows(rows)
+
+		scope, err := repo.GetScopeByName(ctx, "fraud")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(4), scope.ID)
+		assert.True(t, scope.IsActive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("存在しないスコープ", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPermissionRepository(gormDB)
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT \\* FROM `scopes`").
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.GetScopeByName(ctx, "unknown")
+
+		assert.ErrorIs(t, err, entity.ErrScopeNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPermissionRepositoryGetActiveUserScopes(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPermissionRepository(gormDB)
+	ctx := context.Background()
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "scope_id", "expires_at", "created_at", "deleted_at",
+		"Scope__id", "Scope__name", "Scope__resource", "Scope__description", "Scope__is_active",
+		"Scope__created_at", "Scope__updated_at", "Scope__deleted_at",
+	}).AddRow(1, 2, 4, expiresAt, now, nil, 4, "fraud:write", "fraud", "", true, now, now, nil)
+
+	mock.ExpectQuery("SELECT .+ FROM `user_scopes` LEFT JOIN `scopes` `Scope` .+ WHERE \\(user_scopes.user_id = \\? AND \\(user_scopes.expires_at IS NULL OR user_scopes.expires_at > \\?\\)\\) AND Scope.is_active = \\? AND `user_scopes`.`deleted_at` IS NULL").
+		WithArgs(uint(2), now, true).
+		WillReturnRows(rows)
+
+	userScopes, err := repo.GetActiveUserScopes(ctx, 2, now)
+
+	require.NoError(t, err)
+	require.Len(t, userScopes, 1)
+	require.NotNil(t, userScopes[0].Scope)
+	assert.Equal(t, "fraud:write", userScopes[0].Scope.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionRepositoryCreateUserScope(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPermissionRepository(gormDB)
+	ctx := context.Background()
+	now := time.Now()
+	userScope, err := entity.NewUserScope(2, &entity.Scope{ID: 4, Name: "fraud", IsActive: true}, now.Add(time.Hour), now)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `user_scopes`").
+		WithArgs(uint(2), uint(4), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateUserScope(ctx, userScope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), userScope.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionRepositoryRevokeUserScope(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPermissionRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_scopes` SET `deleted_at`=\\? WHERE \\(user_id = \\? AND scope_id = \\?\\) AND `user_scopes`.`deleted_at` IS NULL").
+		WithArgs(sqlmock.AnyArg(), uint(2), uint(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.RevokeUserScope(ctx, 2, 4)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionRepositoryReplaceRolePermissions(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPermissionRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `role_permissions` SET `deleted_at`=\\? WHERE role_id = \\? AND `role_permissions`.`deleted_at` IS NULL").
+		WithArgs(sqlmock.AnyArg(), uint(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `role_permissions`").
+		WithArgs(uint(3), uint(5), sqlmock.AnyArg(), nil, uint(3), uint(6), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectCommit()
+
+	err := repo.ReplaceRolePermissions(ctx, 3, []uint{5, 6})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepositoryUpdateWithPermissions(t *testing.T) {
+	role := &entity.Role{ID: 3, Name: "moderator", Description: "Moderators", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	event := entity.NewSecurityEvent(nil, entity.SecurityEventRoleUpdated, "Role 3 updated", "192.0.2.1", "", "MEDIUM")
+
+	t.Run("ロール・権限・監査ログを同一トランザクションで書き込む", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `roles`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `role_permissions` SET `deleted_at`=\\? WHERE role_id = \\?").
+			WithArgs(sqlmock.AnyArg(), uint(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `role_permissions`").WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectExec("INSERT INTO `security_events`").WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateWithPermissions(context.Background(), role, []uint{5}, event)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("権限の置き換えに失敗したらロールの更新も取り消す", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `roles`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `role_permissions`").WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateWithPermissions(context.Background(), role, []uint{5}, event)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("権限を指定しなければ置き換えない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `roles`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `security_events`").WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateWithPermissions(context.Background(), role, nil, event)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepositoryRemoveFromUserUnlessLast(t *testing.T) {
+	userID := uint(2)
+	revokedEvent := func() *entity.SecurityEvent {
+		return entity.NewSecurityEvent(&userID, entity.SecurityEventRoleRevoked, "Role admin revoked", "127.0.0.1", "test", "HIGH")
+	}
+	roleRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at", "deleted_at"}).
+			AddRow(1, "admin", "管理者", time.Now(), time.Now(), nil)
+	}
+
+	t.Run("他のメンバーがいれば剥奪する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `roles` WHERE `roles`.`id` = \\? AND `roles`.`deleted_at` IS NULL ORDER BY `roles`.`id` LIMIT \\? FOR UPDATE").
+			WithArgs(uint(1), 1).
+			WillReturnRows(roleRows())
+		mock.ExpectQuery("SELECT COUNT\\(DISTINCT\\(`user_roles`.`user_id`\\)\\) FROM `user_roles` JOIN users ON users.id = user_roles.user_id AND users.deleted_at IS NULL JOIN auths ON auths.user_id = user_roles.user_id AND auths.deleted_at IS NULL AND auths.is_active = \\? WHERE \\(user_roles.role_id = \\? AND user_roles.user_id <> \\?\\)").
+			WithArgs(true, uint(1), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec("UPDATE `user_roles` SET `deleted_at`=\\? WHERE \\(user_id = \\? AND role_id = \\?\\) AND `user_roles`.`deleted_at` IS NULL").
+			WithArgs(sqlmock.AnyArg(), uint(2), uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `security_events`").WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectCommit()
+
+		err := repo.RemoveFromUserUnlessLast(ctx, 2, 1, revokedEvent())
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("他に有効なメンバーがいなければ剥奪しない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `roles`").
+			WithArgs(uint(1), 1).
+			WillReturnRows(roleRows())
+		mock.ExpectQuery("SELECT COUNT\\(DISTINCT\\(`user_roles`.`user_id`\\)\\) FROM `user_roles`").
+			WithArgs(true, uint(1), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		err := repo.RemoveFromUserUnlessLast(ctx, 2, 1, revokedEvent())
+
+		assert.ErrorIs(t, err, entity.ErrLastRoleMember)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepositoryAssignToUserWithEvent(t *testing.T) {
+	userID := uint(2)
+
+	t.Run("付与と監査ログを同じトランザクションで書き込む", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `user_roles`").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO `security_events`").WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectCommit()
+
+		event := entity.NewSecurityEvent(&userID, entity.SecurityEventRoleAssigned, "Role admin assigned", "127.0.0.1", "test", "HIGH")
+		err := repo.AssignToUserWithEvent(context.Background(), 2, 1, event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(20), event.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("監査ログを書き込めなければ付与を取り消す", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewRoleRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `user_roles`").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec("INSERT INTO `security_events`").WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		event := entity.NewSecurityEvent(&userID, entity.SecurityEventRoleAssigned, "Role admin assigned", "127.0.0.1", "test", "HIGH")
+		err := repo.AssignToUserWithEvent(context.Background(), 2, 1, event)
+
+		assert.EqualError(t, err, "database error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRoleRepositoryListMembers(t *testing.T) {
+	gormDB, mock, cleanup := setupAuthRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRoleRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` JOIN user_roles ON users.id = user_roles.user_id AND user_roles.deleted_at IS NULL WHERE user_roles.role_id = \\?").
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT `users`.`id`.* FROM `users` JOIN user_roles .* ORDER BY users.id LIMIT \\? OFFSET \\?").
+		WithArgs(uint(1), 20, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "age", "created_at", "updated_at", "deleted_at"}).
+			AddRow(2, "管理者", "admin@example.com", 30, time.Now(), time.Now(), nil))
+
+	users, total, err := repo.ListMembers(ctx, 1, 20, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "admin@example.com", users[0].Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -18,8 +18,12 @@ func NewSecurityEventRepository(db *gorm.DB) repository.SecurityEventRepository
 }
 
 func (r *securityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	return createSecurityEvent(r.db.WithContext(ctx), event)
+}
+
+func createSecurityEvent(db *gorm.DB, event *entity.SecurityEvent) error {
 	gormEvent := SecurityEventEntityToGorm(event)
-	if err := r.db.WithContext(ctx).Create(gormEvent).Error; err != nil {
+	if err := db.Create(gormEvent).Error; err != nil {
 		return err
 	}
 	event.ID = gormEvent.ID
@@ -64,7 +68,7 @@ func (r *securityEventRepository) GetByUserID(ctx context.Context, userID uint,
 		return nil, 0, err
 	}
 
-	if err := query.Offset(offset).Limit(limit).Find(&gormEvents).Error; err != nil {
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&gormEvents).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -164,6 +168,22 @@ func (r *ipBlacklistRepository) IsBlacklisted(ctx context.Context, ipAddress str
 	return count > 0, err
 }
 
+func (r *ipBlacklistRepository) ListActive(ctx context.Context) ([]*entity.IPBlacklist, error) {
+	var gormBlacklists []GormIPBlacklist
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND (expires_at IS NULL OR expires_at > NOW())", true).
+		Find(&gormBlacklists).Error; err != nil {
+		return nil, err
+	}
+
+	blacklists := make([]*entity.IPBlacklist, len(gormBlacklists))
+	for i, gormBlacklist := range gormBlacklists {
+		blacklists[i] = IPBlacklistGormToEntity(&gormBlacklist)
+	}
+
+	return blacklists, nil
+}
+
 func (r *ipBlacklistRepository) CleanupExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).
 		Model(&GormIPBlacklist{}).
@@ -171,6 +191,70 @@ func (r *ipBlacklistRepository) CleanupExpired(ctx context.Context) error {
 		Update("is_active", false).Error
 }
 
+type ipAllowlistRepository struct {
+	db *gorm.DB
+}
+
+func NewIPAllowlistRepository(db *gorm.DB) repository.IPAllowlistRepository {
+	return &ipAllowlistRepository{db: db}
+}
+
+func (r *ipAllowlistRepository) Create(ctx context.Context, allowlist *entity.IPAllowlist) error {
+	gormAllowlist := IPAllowlistEntityToGorm(allowlist)
+	if err := r.db.WithContext(ctx).Create(gormAllowlist).Error; err != nil {
+		return err
+	}
+	allowlist.ID = gormAllowlist.ID
+	return nil
+}
+
+func (r *ipAllowlistRepository) GetByIP(ctx context.Context, ipAddress string) (*entity.IPAllowlist, error) {
+	var gormAllowlist GormIPAllowlist
+	if err := r.db.WithContext(ctx).Where("ip_address = ?", ipAddress).First(&gormAllowlist).Error; err != nil {
+		return nil, err
+	}
+	return IPAllowlistGormToEntity(&gormAllowlist), nil
+}
+
+func (r *ipAllowlistRepository) List(ctx context.Context, offset, limit int) ([]*entity.IPAllowlist, int64, error) {
+	var gormAllowlists []GormIPAllowlist
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&GormIPAllowlist{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&gormAllowlists).Error; err != nil {
+		return nil, 0, err
+	}
+
+	allowlists := make([]*entity.IPAllowlist, len(gormAllowlists))
+	for i, gormAllowlist := range gormAllowlists {
+		allowlists[i] = IPAllowlistGormToEntity(&gormAllowlist)
+	}
+
+	return allowlists, total, nil
+}
+
+func (r *ipAllowlistRepository) Update(ctx context.Context, allowlist *entity.IPAllowlist) error {
+	gormAllowlist := IPAllowlistEntityToGorm(allowlist)
+	return r.db.WithContext(ctx).Save(gormAllowlist).Error
+}
+
+func (r *ipAllowlistRepository) ListActive(ctx context.Context) ([]*entity.IPAllowlist, error) {
+	var gormAllowlists []GormIPAllowlist
+	if err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&gormAllowlists).Error; err != nil {
+		return nil, err
+	}
+
+	allowlists := make([]*entity.IPAllowlist, len(gormAllowlists))
+	for i, gormAllowlist := range gormAllowlists {
+		allowlists[i] = IPAllowlistGormToEntity(&gormAllowlist)
+	}
+
+	return allowlists, nil
+}
+
 type loginAttemptRepository struct {
 	db *gorm.DB
 }
@@ -230,6 +314,14 @@ func (r *loginAttemptRepository) CountFailedAttempts(ctx context.Context, email
 	return count, err
 }
 
+func (r *loginAttemptRepository) CountSuccessfulAttempts(ctx context.Context, email string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&GormLoginAttempt{}).
+		Where("email = ? AND success = ? AND created_at >= ?", email, true, since).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *loginAttemptRepository) List(ctx context.Context, offset, limit int) ([]*entity.LoginAttempt, int64, error) {
 	var gormAttempts []GormLoginAttempt
 	var total int64
@@ -257,3 +349,292 @@ func (r *loginAttemptRepository) Delete(ctx context.Context, id uint) error {
 func (r *loginAttemptRepository) CleanupOld(ctx context.Context, before time.Time) error {
 	return r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&GormLoginAttempt{}).Error
 }
+
+type rateLimitRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewRateLimitRuleRepository(db *gorm.DB) repository.RateLimitRuleRepository {
+	return &rateLimitRuleRepository{db: db}
+}
+
+func (r *rateLimitRuleRepository) Create(ctx context.Context, rule *entity.RateLimitRule) error {
+	gormRule := RateLimitRuleEntityToGorm(rule)
+	if err := r.db.WithContext(ctx).Create(gormRule).Error; err != nil {
+		return err
+	}
+	rule.ID = gormRule.ID
+	return nil
+}
+
+func (r *rateLimitRuleRepository) GetByID(ctx context.Context, id uint) (*entity.RateLimitRule, error) {
+	var gormRule GormRateLimitRule
+	if err := r.db.WithContext(ctx).First(&gormRule, id).Error; err != nil {
+		return nil, err
+	}
+	return RateLimitRuleGormToEntity(&gormRule), nil
+}
+
+func (r *rateLimitRuleRepository) GetByResource(ctx context.Context, resource string) (*entity.RateLimitRule, error) {
+	var gormRule GormRateLimitRule
+	if err := r.db.WithContext(ctx).
+		Where("resource = ? AND is_active = ?", resource, true).
+		First(&gormRule).Error; err != nil {
+		return nil, err
+	}
+	return RateLimitRuleGormToEntity(&gormRule), nil
+}
+
+func (r *rateLimitRuleRepository) List(ctx context.Context) ([]*entity.RateLimitRule, error) {
+	var gormRules []GormRateLimitRule
+	if err := r.db.WithContext(ctx).Order("id").Find(&gormRules).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]*entity.RateLimitRule, len(gormRules))
+	for i, gormRule := range gormRules {
+		rules[i] = RateLimitRuleGormToEntity(&gormRule)
+	}
+
+	return rules, nil
+}
+
+func (r *rateLimitRuleRepository) Update(ctx context.Context, rule *entity.RateLimitRule) error {
+	gormRule := RateLimitRuleEntityToGorm(rule)
+	return r.db.WithContext(ctx).Save(gormRule).Error
+}
+
+func (r *rateLimitRuleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&GormRateLimitRule{}, id).Error
+}
+
+func (r *rateLimitRuleRepository) GetActiveRules(ctx context.Context) ([]*entity.RateLimitRule, error) {
+	var gormRules []GormRateLimitRule
+	if err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("id").Find(&gormRules).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]*entity.RateLimitRule, len(gormRules))
+	for i, gormRule := range gormRules {
+		rules[i] = RateLimitRuleGormToEntity(&gormRule)
+	}
+
+	return rules, nil
+}
+
+type fraudRuleConfigRepository struct {
+	db *gorm.DB
+}
+
+func NewFraudRuleConfigRepository(db *gorm.DB) repository.FraudRuleConfigRepository {
+	return &fraudRuleConfigRepository{db: db}
+}
+
+func (r *fraudRuleConfigRepository) List(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	var gormConfigs []GormFraudRuleConfig
+	if err := r.db.WithContext(ctx).Order("name").Find(&gormConfigs).Error; err != nil {
+		return nil, err
+	}
+
+	configs := make([]*entity.FraudRuleConfig, len(gormConfigs))
+	for i, gormConfig := range gormConfigs {
+		configs[i] = FraudRuleConfigGormToEntity(&gormConfig)
+	}
+
+	return configs, nil
+}
+
+func (r *fraudRuleConfigRepository) Save(ctx context.Context, config *entity.FraudRuleConfig) error {
+	gormConfig := FraudRuleConfigEntityToGorm(config)
+	if err := r.db.WithContext(ctx).Save(gormConfig).Error; err != nil {
+		return err
+	}
+	config.ID = gormConfig.ID
+	return nil
+}
+
+type rateLimitLogRepository struct {
+	db *gorm.DB
+}
+
+func NewRateLimitLogRepository(db *gorm.DB) repository.RateLimitLogRepository {
+	return &rateLimitLogRepository{db: db}
+}
+
+func (r *rateLimitLogRepository) Create(ctx context.Context, log *entity.RateLimitLog) error {
+	gormLog := RateLimitLogEntityToGorm(log)
+	if err := r.db.WithContext(ctx).Create(gormLog).Error; err != nil {
+		return err
+	}
+	log.ID = gormLog.ID
+	return nil
+}
+
+type userSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) repository.UserSessionRepository {
+	return &userSessionRepository{db: db}
+}
+
+func (r *userSessionRepository) Create(ctx context.Context, session *entity.UserSession) error {
+	gormSession := UserSessionEntityToGorm(session)
+	if err := r.db.WithContext(ctx).Omit("User").Create(gormSession).Error; err != nil {
+		return err
+	}
+	session.ID = gormSession.ID
+	return nil
+}
+
+func (r *userSessionRepository) GetBySessionID(ctx context.Context, sessionID string) (*entity.UserSession, error) {
+	var gormSession GormUserSession
+	if err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).First(&gormSession).Error; err != nil {
+		return nil, err
+	}
+	return UserSessionGormToEntity(&gormSession), nil
+}
+
+func (r *userSessionRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.UserSession, error) {
+	var gormSessions []GormUserSession
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&gormSessions).Error; err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.UserSession, len(gormSessions))
+	for i, gormSession := range gormSessions {
+		sessions[i] = UserSessionGormToEntity(&gormSession)
+	}
+
+	return sessions, nil
+}
+
+func (r *userSessionRepository) ListActive(ctx context.Context, offset, limit int) ([]*entity.UserSession, int64, error) {
+	var gormSessions []GormUserSession
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&GormUserSession{}).Where("is_active = ? AND expires_at > NOW()", true)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&gormSessions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sessions := make([]*entity.UserSession, len(gormSessions))
+	for i, gormSession := range gormSessions {
+		sessions[i] = UserSessionGormToEntity(&gormSession)
+	}
+
+	return sessions, total, nil
+}
+
+func (r *userSessionRepository) Update(ctx context.Context, session *entity.UserSession) error {
+	gormSession := UserSessionEntityToGorm(session)
+	return r.db.WithContext(ctx).Omit("User").Save(gormSession).Error
+}
+
+func (r *userSessionRepository) Delete(ctx context.Context, sessionID string) error {
+	return r.db.WithContext(ctx).Where("session_id = ?", sessionID).Delete(&GormUserSession{}).Error
+}
+
+func (r *userSessionRepository) DeactivateByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&GormUserSession{}).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Update("is_active", false).Error
+}
+
+func (r *userSessionRepository) CleanupExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).
+		Model(&GormUserSession{}).
+		Where("is_active = ? AND expires_at <= NOW()", true).
+		Update("is_active", false).Error
+}
+
+type deviceFingerprintRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceFingerprintRepository(db *gorm.DB) repository.DeviceFingerprintRepository {
+	return &deviceFingerprintRepository{db: db}
+}
+
+func (r *deviceFingerprintRepository) Create(ctx context.Context, fingerprint *entity.DeviceFingerprint) error {
+	gormFingerprint := DeviceFingerprintEntityToGorm(fingerprint)
+	if err := r.db.WithContext(ctx).Omit("User").Create(gormFingerprint).Error; err != nil {
+		return err
+	}
+	fingerprint.ID = gormFingerprint.ID
+	return nil
+}
+
+func (r *deviceFingerprintRepository) GetByFingerprint(ctx context.Context, fingerprint string) (*entity.DeviceFingerprint, error) {
+	var gormFingerprint GormDeviceFingerprint
+	if err := r.db.WithContext(ctx).
+		Where("fingerprint = ?", fingerprint).
+		Order("last_seen_at DESC").
+		First(&gormFingerprint).Error; err != nil {
+		return nil, err
+	}
+	return DeviceFingerprintGormToEntity(&gormFingerprint), nil
+}
+
+func (r *deviceFingerprintRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.DeviceFingerprint, error) {
+	var gormFingerprints []GormDeviceFingerprint
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&gormFingerprints).Error; err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]*entity.DeviceFingerprint, len(gormFingerprints))
+	for i, gormFingerprint := range gormFingerprints {
+		fingerprints[i] = DeviceFingerprintGormToEntity(&gormFingerprint)
+	}
+
+	return fingerprints, nil
+}
+
+func (r *deviceFingerprintRepository) List(ctx context.Context, offset, limit int) ([]*entity.DeviceFingerprint, int64, error) {
+	var gormFingerprints []GormDeviceFingerprint
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&GormDeviceFingerprint{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).Order("last_seen_at DESC").Offset(offset).Limit(limit).Find(&gormFingerprints).Error; err != nil {
+		return nil, 0, err
+	}
+
+	fingerprints := make([]*entity.DeviceFingerprint, len(gormFingerprints))
+	for i, gormFingerprint := range gormFingerprints {
+		fingerprints[i] = DeviceFingerprintGormToEntity(&gormFingerprint)
+	}
+
+	return fingerprints, total, nil
+}
+
+func (r *deviceFingerprintRepository) Update(ctx context.Context, fingerprint *entity.DeviceFingerprint) error {
+	gormFingerprint := DeviceFingerprintEntityToGorm(fingerprint)
+	return r.db.WithContext(ctx).Omit("User").Save(gormFingerprint).Error
+}
+
+func (r *deviceFingerprintRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&GormDeviceFingerprint{}, id).Error
+}
+
+func (r *deviceFingerprintRepository) IsTrustedDevice(ctx context.Context, userID uint, fingerprint string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&GormDeviceFingerprint{}).
+		Where("user_id = ? AND fingerprint = ? AND is_trusted = ?", userID, fingerprint, true).
+		Count(&count).Error
+	return count > 0, err
+}
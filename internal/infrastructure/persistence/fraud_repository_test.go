@@ -236,6 +236,75 @@ func TestIPBlacklistRepositoryIsBlacklisted(t *testing.T) {
 	})
 }
 
+func TestIPBlacklistRepositoryListActive(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewIPBlacklistRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "ip_address", "reason", "expires_at", "is_active", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "203.0.113.0/24", "botnet", nil, true, now, now, nil).
+		AddRow(2, "2001:db8::/32", "botnet", nil, true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `ip_blacklists` WHERE \\(is_active = \\? AND \\(expires_at IS NULL OR expires_at > NOW\\(\\)\\)\\) AND `ip_blacklists`.`deleted_at` IS NULL").
+		WithArgs(true).
+		WillReturnRows(rows)
+
+	blacklists, err := repo.ListActive(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, blacklists, 2)
+	assert.Equal(t, "2001:db8::/32", blacklists[1].IPAddress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIPAllowlistRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewIPAllowlistRepository(gormDB)
+	ctx := context.Background()
+
+	allowlist := entity.NewIPAllowlist("198.51.100.0/24", "partner")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `ip_allowlists`").
+		WithArgs("198.51.100.0/24", "partner", true, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, allowlist)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), allowlist.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIPAllowlistRepositoryListActive(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewIPAllowlistRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "ip_address", "description", "is_active", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "10.20.0.0/16", "office", true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `ip_allowlists` WHERE is_active = \\? AND `ip_allowlists`.`deleted_at` IS NULL").
+		WithArgs(true).
+		WillReturnRows(rows)
+
+	allowlists, err := repo.ListActive(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, allowlists, 1)
+	assert.Equal(t, "office", allowlists[0].Description)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestIPBlacklistRepositoryCleanupExpired(t *testing.T) {
 	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
 	defer cleanup()
@@ -374,6 +443,28 @@ func TestLoginAttemptRepositoryCountFailedAttempts(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestLoginAttemptRepositoryCountSuccessfulAttempts(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewLoginAttemptRepository(gormDB)
+	ctx := context.Background()
+
+	email := "test@example.com"
+	since := time.Time{}
+
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(42)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `login_attempts` WHERE \\(email = \\? AND success = \\? AND created_at >= \\?\\) AND `login_attempts`.`deleted_at` IS NULL").
+		WithArgs(email, true, since).
+		WillReturnRows(rows)
+
+	count, err := repo.CountSuccessfulAttempts(ctx, email, since)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestLoginAttemptRepositoryCleanupOld(t *testing.T) {
 	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
 	defer cleanup()
@@ -394,3 +485,476 @@ func TestLoginAttemptRepositoryCleanupOld(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRateLimitRuleRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	rule := entity.NewRateLimitRule("ENDPOINT:/api/v1/auth/login", "/api/v1/auth/login", 10, 60)
+	rule.Algorithm = entity.RateLimitAlgorithmSlidingLog
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `rate_limit_rules`").
+		WithArgs(
+			rule.Name,
+			rule.Resource,
+			rule.MaxRequests,
+			rule.WindowSize,
+			entity.RateLimitAlgorithmSlidingLog,
+			true,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, rule)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), rule.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRuleRepositoryGetByID(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "resource", "max_requests", "window_size", "is_active",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(1, "IP:203.0.113.10", "203.0.113.10", 100, 60, true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `rate_limit_rules` WHERE `rate_limit_rules`.`id` = \\? AND `rate_limit_rules`.`deleted_at` IS NULL ORDER BY `rate_limit_rules`.`id` LIMIT \\?").
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByID(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, "IP:203.0.113.10", result.Name)
+	assert.Equal(t, 100, result.MaxRequests)
+	assert.Equal(t, 60, result.WindowSize)
+	assert.True(t, result.IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRuleRepositoryGetByIDNotFound(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT \\* FROM `rate_limit_rules` WHERE `rate_limit_rules`.`id` = \\?").
+		WithArgs(99, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := repo.GetByID(ctx, 99)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRuleRepositoryGetByResource(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "resource", "max_requests", "window_size", "is_active",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(2, "ENDPOINT:/api/v1/auth/login", "/api/v1/auth/login", 10, 60, true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `rate_limit_rules` WHERE \\(resource = \\? AND is_active = \\?\\) AND `rate_limit_rules`.`deleted_at` IS NULL ORDER BY `rate_limit_rules`.`id` LIMIT \\?").
+		WithArgs("/api/v1/auth/login", true, 1).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByResource(ctx, "/api/v1/auth/login")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), result.ID)
+	assert.Equal(t, "/api/v1/auth/login", result.Resource)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRuleRepositoryGetActiveRules(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "resource", "max_requests", "window_size", "algorithm", "is_active",
+		"created_at", "updated_at", "deleted_at",
+	}).
+		AddRow(1, "IP:203.0.113.10", "203.0.113.10", 100, 60, "", true, now, now, nil).
+		AddRow(2, "GLOBAL:*", "*", 1000, 60, "token_bucket", true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `rate_limit_rules` WHERE is_active = \\? AND `rate_limit_rules`.`deleted_at` IS NULL ORDER BY id").
+		WithArgs(true).
+		WillReturnRows(rows)
+
+	rules, err := repo.GetActiveRules(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "GLOBAL:*", rules[1].Name)
+	assert.Equal(t, entity.RateLimitAlgorithmTokenBucket, rules[1].Algorithm)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRuleRepositoryUpdate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitRuleRepository(gormDB)
+	ctx := context.Background()
+
+	rule := entity.NewRateLimitRule("IP:203.0.113.10", "203.0.113.10", 100, 60)
+	rule.ID = 1
+	rule.Deactivate()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `rate_limit_rules` SET `name`=\\?,`resource`=\\?,`max_requests`=\\?,`window_size`=\\?,`algorithm`=\\?,`is_active`=\\?,`created_at`=\\?,`updated_at`=\\?,`deleted_at`=\\? WHERE `rate_limit_rules`.`deleted_at` IS NULL AND `id` = \\?").
+		WithArgs(rule.Name, rule.Resource, 100, 60, "", false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), rule.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Update(ctx, rule)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitLogRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewRateLimitLogRepository(gormDB)
+	ctx := context.Background()
+
+	userID := uint(42)
+	windowStart := time.Date(2025, 9, 17, 12, 0, 0, 0, time.UTC)
+	log := entity.NewRateLimitLog(3, "192.168.1.1", &userID, 11, windowStart, windowStart.Add(time.Minute), true)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `rate_limit_logs`").
+		WithArgs(
+			log.RuleID,
+			log.IPAddress,
+			userID,
+			log.Requests,
+			windowStart,
+			windowStart.Add(time.Minute),
+			true,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, log)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), log.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFraudRuleConfigRepositoryList(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewFraudRuleConfigRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "name", "enabled", "weight", "threshold", "window_seconds",
+		"created_at", "updated_at", "deleted_at",
+	}).
+		AddRow(1, "failed_logins", true, 0.3, 5, 900, now, now, nil).
+		AddRow(2, "ip_blacklisted", false, 0.8, 0, 0, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `fraud_rules` WHERE `fraud_rules`.`deleted_at` IS NULL ORDER BY name").
+		WillReturnRows(rows)
+
+	configs, err := repo.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, configs, 2)
+	assert.Equal(t, 5, configs[0].Threshold)
+	assert.False(t, configs[1].Enabled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFraudRuleConfigRepositorySave(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewFraudRuleConfigRepository(gormDB)
+	ctx := context.Background()
+
+	config := entity.NewFraudRuleConfig("ip_velocity", 0.4, 10, 900)
+	config.Enabled = false
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `fraud_rules`").
+		WithArgs("ip_velocity", false, 0.4, 10, 900, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectCommit()
+
+	err := repo.Save(ctx, config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), config.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSessionRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserSessionRepository(gormDB)
+	ctx := context.Background()
+
+	session := entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(time.Hour))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `user_sessions`").
+		WithArgs(
+			session.UserID,
+			session.SessionID,
+			session.IPAddress,
+			session.UserAgent,
+			sqlmock.AnyArg(),
+			true,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, session)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), session.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSessionRepositoryGetBySessionID(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserSessionRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "session_id", "ip_address", "user_agent", "expires_at",
+		"is_active", "created_at", "updated_at", "deleted_at",
+	}).AddRow(1, 1, "session-123", "192.168.1.1", "Mozilla/5.0", now.Add(time.Hour), true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `user_sessions` WHERE session_id = \\? AND `user_sessions`.`deleted_at` IS NULL ORDER BY `user_sessions`.`id` LIMIT \\?").
+		WithArgs("session-123", 1).
+		WillReturnRows(rows)
+
+	result, err := repo.GetBySessionID(ctx, "session-123")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "session-123", result.SessionID)
+	assert.Equal(t, uint(1), result.UserID)
+	assert.True(t, result.IsValid())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSessionRepositoryListActive(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserSessionRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `user_sessions` WHERE \\(is_active = \\? AND expires_at > NOW\\(\\)\\) AND `user_sessions`.`deleted_at` IS NULL").
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "session_id", "ip_address", "user_agent", "expires_at",
+		"is_active", "created_at", "updated_at", "deleted_at",
+	}).
+		AddRow(2, 1, "session-456", "192.168.1.2", "curl/8.0", now.Add(time.Hour), true, now, now, nil).
+		AddRow(1, 1, "session-123", "192.168.1.1", "Mozilla/5.0", now.Add(time.Hour), true, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `user_sessions` WHERE \\(is_active = \\? AND expires_at > NOW\\(\\)\\) AND `user_sessions`.`deleted_at` IS NULL ORDER BY created_at DESC LIMIT \\?").
+		WithArgs(true, 50).
+		WillReturnRows(rows)
+
+	sessions, total, err := repo.ListActive(ctx, 0, 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "session-456", sessions[0].SessionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSessionRepositoryDeactivateByUserID(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserSessionRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_sessions` SET `is_active`=\\?,`updated_at`=\\? WHERE \\(user_id = \\? AND is_active = \\?\\) AND `user_sessions`.`deleted_at` IS NULL").
+		WithArgs(false, sqlmock.AnyArg(), uint(1), true).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.DeactivateByUserID(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSessionRepositoryCleanupExpired(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserSessionRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_sessions` SET `is_active`=\\?,`updated_at`=\\? WHERE \\(is_active = \\? AND expires_at <= NOW\\(\\)\\) AND `user_sessions`.`deleted_at` IS NULL").
+		WithArgs(false, sqlmock.AnyArg(), true).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	err := repo.CleanupExpired(ctx)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFingerprintRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewDeviceFingerprintRepository(gormDB)
+	ctx := context.Background()
+
+	fingerprint := entity.NewDeviceFingerprint(1, "192.168.1.1_Mozilla/5.0", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `device_fingerprints`").
+		WithArgs(
+			fingerprint.UserID,
+			fingerprint.Fingerprint,
+			sqlmock.AnyArg(),
+			false,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(ctx, fingerprint)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), fingerprint.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFingerprintRepositoryGetByFingerprint(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewDeviceFingerprintRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "fingerprint", "device_info", "is_trusted", "last_seen_at",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(1, 1, "192.168.1.1_Mozilla/5.0", nil, false, now, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `device_fingerprints` WHERE fingerprint = \\? AND `device_fingerprints`.`deleted_at` IS NULL ORDER BY last_seen_at DESC,`device_fingerprints`.`id` LIMIT \\?").
+		WithArgs("192.168.1.1_Mozilla/5.0", 1).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByFingerprint(ctx, "192.168.1.1_Mozilla/5.0")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, uint(1), result.ID)
+	assert.False(t, result.IsTrusted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFingerprintRepositoryList(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewDeviceFingerprintRepository(gormDB)
+	ctx := context.Background()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `device_fingerprints` WHERE `device_fingerprints`.`deleted_at` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "fingerprint", "device_info", "is_trusted", "last_seen_at",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(1, 1, "192.168.1.1_Mozilla/5.0", nil, true, now, now, now, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `device_fingerprints` WHERE `device_fingerprints`.`deleted_at` IS NULL ORDER BY last_seen_at DESC LIMIT \\?").
+		WithArgs(100).
+		WillReturnRows(rows)
+
+	devices, total, err := repo.List(ctx, 0, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, devices, 1)
+	assert.True(t, devices[0].IsTrusted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceFingerprintRepositoryIsTrustedDevice(t *testing.T) {
+	gormDB, mock, cleanup := setupFraudRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewDeviceFingerprintRepository(gormDB)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `device_fingerprints` WHERE \\(user_id = \\? AND fingerprint = \\? AND is_trusted = \\?\\) AND `device_fingerprints`.`deleted_at` IS NULL").
+		WithArgs(uint(1), "192.168.1.1_Mozilla/5.0", true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	trusted, err := repo.IsTrustedDevice(ctx, 1, "192.168.1.1_Mozilla/5.0")
+
+	assert.NoError(t, err)
+	assert.True(t, trusted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -21,15 +21,21 @@ func (GormUser) TableName() string {
 }
 
 type GormAuth struct {
-	ID           uint           `json:"id" gorm:"primaryKey"`
-	UserID       uint           `json:"user_id" gorm:"not null;uniqueIndex"`
-	Email        string         `json:"email" gorm:"uniqueIndex;not null"`
-	PasswordHash string         `json:"-" gorm:"not null"`
-	IsActive     bool           `json:"is_active" gorm:"default:true"`
-	LastLoginAt  *time.Time     `json:"last_login_at"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                     uint           `json:"id" gorm:"primaryKey"`
+	UserID                 uint           `json:"user_id" gorm:"not null;uniqueIndex"`
+	Email                  string         `json:"email" gorm:"uniqueIndex;not null"`
+	PasswordHash           string         `json:"-" gorm:"not null"`
+	IsActive               bool           `json:"is_active" gorm:"default:true"`
+	LastLoginAt            *time.Time     `json:"last_login_at"`
+	TwoFactorEnabled       bool           `json:"two_factor_enabled" gorm:"default:false"`
+	TwoFactorSecret        *string        `json:"-" gorm:"size:512"`
+	TwoFactorRecoveryCodes *string        `json:"-" gorm:"type:json"`
+	TwoFactorLastStep      int64          `json:"-" gorm:"default:0"`
+	TwoFactorEnabledAt     *time.Time     `json:"two_factor_enabled_at"`
+	PasswordChangedAt      *time.Time     `json:"password_changed_at"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"`
 
 	User GormUser `json:"user" gorm:"foreignKey:UserID"`
 }
@@ -69,9 +75,11 @@ func (GormUserRole) TableName() string {
 type GormRefreshToken struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	UserID    uint           `json:"user_id" gorm:"not null"`
-	Token     string         `json:"token" gorm:"uniqueIndex;not null"`
+	TokenHash string         `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	FamilyID  string         `json:"family_id" gorm:"size:36;not null;index"`
 	ExpiresAt time.Time      `json:"expires_at"`
 	IsRevoked bool           `json:"is_revoked" gorm:"default:false"`
+	RotatedAt *time.Time     `json:"rotated_at"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -83,6 +91,23 @@ func (GormRefreshToken) TableName() string {
 	return "refresh_tokens"
 }
 
+type GormUserToken struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null;index"`
+	TokenType string         `json:"token_type" gorm:"size:50;not null;index"`
+	TokenHash string         `json:"-" gorm:"size:255;not null;uniqueIndex"`
+	ExpiresAt time.Time      `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User GormUser `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (GormUserToken) TableName() string {
+	return "user_tokens"
+}
+
 type GormMembershipTier struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	Name         string         `json:"name" gorm:"uniqueIndex;not null"`
@@ -101,18 +126,19 @@ func (GormMembershipTier) TableName() string {
 }
 
 type GormUserMembership struct {
-	ID             uint           `json:"id" gorm:"primaryKey"`
-	UserID         uint           `json:"user_id" gorm:"not null;uniqueIndex"`
-	TierID         uint           `json:"tier_id" gorm:"not null"`
-	Points         int            `json:"points" gorm:"default:0"`
-	TotalSpent     float64        `json:"total_spent" gorm:"default:0"`
-	JoinedAt       time.Time      `json:"joined_at"`
-	LastActivityAt *time.Time     `json:"last_activity_at"`
-	ExpiresAt      *time.Time     `json:"expires_at"`
-	IsActive       bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	UserID          uint           `json:"user_id" gorm:"not null;uniqueIndex"`
+	TierID          uint           `json:"tier_id" gorm:"not null"`
+	Points          int            `json:"points" gorm:"default:0"`
+	TotalSpent      float64        `json:"total_spent" gorm:"default:0"`
+	JoinedAt        time.Time      `json:"joined_at"`
+	LastActivityAt  *time.Time     `json:"last_activity_at"`
+	ExpiresAt       *time.Time     `json:"expires_at"`
+	TierGraceEndsAt *time.Time     `json:"tier_grace_ends_at"`
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 
 	User GormUser           `json:"user" gorm:"foreignKey:UserID"`
 	Tier GormMembershipTier `json:"tier" gorm:"foreignKey:TierID"`
@@ -122,6 +148,63 @@ func (GormUserMembership) TableName() string {
 	return "user_memberships"
 }
 
+type GormMembershipTierHistory struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserID     uint      `json:"user_id" gorm:"not null;index"`
+	FromTierID uint      `json:"from_tier_id" gorm:"not null"`
+	ToTierID   uint      `json:"to_tier_id" gorm:"not null"`
+	Reason     string    `json:"reason" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+
+	User GormUser `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (GormMembershipTierHistory) TableName() string {
+	return "membership_tier_histories"
+}
+
+type GormPurchase struct {
+	ID                  string         `json:"id" gorm:"primaryKey;size:255"`
+	UserID              uint           `json:"user_id" gorm:"not null;index"`
+	StoreID             uint           `json:"store_id" gorm:"not null;index"`
+	TotalAmount         float64        `json:"total_amount" gorm:"type:decimal(10,2);not null"`
+	PointsEarned        int            `json:"points_earned" gorm:"default:0"`
+	TierDiscountApplied float64        `json:"tier_discount_applied" gorm:"type:decimal(3,2);default:0"`
+	PointTransactionID  *uint          `json:"point_transaction_id"`
+	Status              string         `json:"status" gorm:"not null;default:completed;index"`
+	RefundedAt          *time.Time     `json:"refunded_at"`
+	CreatedAt           time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User GormUser `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (GormPurchase) TableName() string {
+	return "purchases"
+}
+
+type GormPointTransaction struct {
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	UserID          uint           `json:"user_id" gorm:"not null;index"`
+	Type            string         `json:"type" gorm:"not null;index"`
+	Points          int            `json:"points" gorm:"not null"`
+	RemainingPoints int            `json:"remaining_points" gorm:"not null;default:0"`
+	BalanceAfter    int            `json:"balance_after" gorm:"not null;default:0"`
+	Description     string         `json:"description"`
+	ReferenceType   string         `json:"reference_type" gorm:"index"`
+	ReferenceID     *uint          `json:"reference_id"`
+	ExpiresAt       *time.Time     `json:"expires_at" gorm:"index"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"index"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User GormUser `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (GormPointTransaction) TableName() string {
+	return "point_transactions"
+}
+
 type GormUserProfile struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	UserID      uint           `json:"user_id" gorm:"not null;uniqueIndex"`
@@ -147,6 +230,20 @@ func (GormUserProfile) TableName() string {
 	return "user_profiles"
 }
 
+type GormUserPreference struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:uk_user_preferences_user_id_key"`
+	Category  string    `json:"category" gorm:"not null;index"`
+	Key       string    `json:"key" gorm:"not null;uniqueIndex:uk_user_preferences_user_id_key"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (GormUserPreference) TableName() string {
+	return "user_preferences"
+}
+
 type GormNotification struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	UserID    uint           `json:"user_id" gorm:"not null;index"`
@@ -154,6 +251,7 @@ type GormNotification struct {
 	Title     string         `json:"title" gorm:"not null"`
 	Message   string         `json:"message" gorm:"not null"`
 	Data      *string        `json:"data" gorm:"type:json"`
+	Priority  string         `json:"priority" gorm:"not null;default:medium"`
 	IsRead    bool           `json:"is_read" gorm:"default:false"`
 	ReadAt    *time.Time     `json:"read_at"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -201,6 +299,20 @@ func (GormIPBlacklist) TableName() string {
 	return "ip_blacklists"
 }
 
+type GormIPAllowlist struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	IPAddress   string         `json:"ip_address" gorm:"uniqueIndex;not null"`
+	Description string         `json:"description"`
+	IsActive    bool           `json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormIPAllowlist) TableName() string {
+	return "ip_allowlists"
+}
+
 type GormLoginAttempt struct {
 	ID         uint           `json:"id" gorm:"primaryKey"`
 	Email      string         `json:"email" gorm:"not null;index"`
@@ -222,6 +334,7 @@ type GormRateLimitRule struct {
 	Resource    string         `json:"resource" gorm:"not null"`
 	MaxRequests int            `json:"max_requests" gorm:"not null"`
 	WindowSize  int            `json:"window_size" gorm:"not null"`
+	Algorithm   string         `json:"algorithm" gorm:"size:32;not null;default:''"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -232,6 +345,39 @@ func (GormRateLimitRule) TableName() string {
 	return "rate_limit_rules"
 }
 
+type GormFraudRuleConfig struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Name          string         `json:"name" gorm:"size:100;uniqueIndex;not null"`
+	Enabled       bool           `json:"enabled" gorm:"not null"`
+	Weight        float64        `json:"weight" gorm:"not null"`
+	Threshold     int            `json:"threshold" gorm:"not null;default:0"`
+	WindowSeconds int            `json:"window_seconds" gorm:"not null;default:0"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormFraudRuleConfig) TableName() string {
+	return "fraud_rules"
+}
+
+type GormRateLimitLog struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	RuleID      uint           `json:"rule_id" gorm:"not null;index"`
+	IPAddress   string         `json:"ip_address" gorm:"not null;index"`
+	UserID      *uint          `json:"user_id" gorm:"index"`
+	Requests    int            `json:"requests"`
+	WindowStart time.Time      `json:"window_start"`
+	WindowEnd   time.Time      `json:"window_end"`
+	Blocked     bool           `json:"blocked"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormRateLimitLog) TableName() string {
+	return "rate_limit_logs"
+}
+
 type GormUserSession struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	UserID    uint           `json:"user_id" gorm:"not null;index"`
@@ -268,3 +414,189 @@ type GormDeviceFingerprint struct {
 func (GormDeviceFingerprint) TableName() string {
 	return "device_fingerprints"
 }
+
+type GormAdminAction struct {
+	ID                 uint           `json:"id" gorm:"primaryKey"`
+	AdminUserID        uint           `json:"admin_user_id" gorm:"not null;index;uniqueIndex:uk_admin_actions_admin_user_id_idempotency_key"`
+	ActionType         string         `json:"action_type" gorm:"not null;index"`
+	TargetType         string         `json:"target_type" gorm:"not null;index"`
+	TargetID           string         `json:"target_id"`
+	Description        string         `json:"description" gorm:"type:text"`
+	Result             string         `json:"result" gorm:"not null"`
+	IdempotencyKey     *string        `json:"idempotency_key" gorm:"uniqueIndex:uk_admin_actions_admin_user_id_idempotency_key"`
+	PointTransactionID *uint          `json:"point_transaction_id"`
+	CreatedAt          time.Time      `json:"created_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+
+	AdminUser GormUser `json:"admin_user" gorm:"foreignKey:AdminUserID"`
+}
+
+func (GormAdminAction) TableName() string {
+	return "admin_actions"
+}
+
+type GormPermission struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;not null"`
+	Resource    string         `json:"resource" gorm:"not null"`
+	Action      string         `json:"action" gorm:"not null"`
+	Scope       string         `json:"scope" gorm:"not null;default:*"`
+	Description string         `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormPermission) TableName() string {
+	return "permissions"
+}
+
+type GormRolePermission struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	RoleID       uint           `json:"role_id" gorm:"not null"`
+	PermissionID uint           `json:"permission_id" gorm:"not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Role       GormRole       `json:"role" gorm:"foreignKey:RoleID"`
+	Permission GormPermission `json:"permission" gorm:"foreignKey:PermissionID"`
+}
+
+func (GormRolePermission) TableName() string {
+	return "role_permissions"
+}
+
+type GormScope struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;not null"`
+	Resource    string         `json:"resource" gorm:"not null"`
+	Description string         `json:"description"`
+	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormScope) TableName() string {
+	return "scopes"
+}
+
+type GormUserScope struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null"`
+	ScopeID   uint           `json:"scope_id" gorm:"not null"`
+	ExpiresAt *time.Time     `json:"expires_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User  GormUser  `json:"user" gorm:"foreignKey:UserID"`
+	Scope GormScope `json:"scope" gorm:"foreignKey:ScopeID"`
+}
+
+func (GormUserScope) TableName() string {
+	return "user_scopes"
+}
+
+type GormPartnerAPIKey struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	PartnerID   string         `json:"partner_id" gorm:"not null;index"`
+	KeyHash     string         `json:"-" gorm:"column:api_key;uniqueIndex;not null"`
+	KeyPrefix   string         `json:"key_prefix" gorm:"size:32;not null;index"`
+	PartnerName string         `json:"partner_name"`
+	Permissions *string        `json:"permissions" gorm:"type:json"`
+	RateLimit   *int           `json:"rate_limit"`
+	Enabled     bool           `json:"enabled" gorm:"default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormPartnerAPIKey) TableName() string {
+	return "partner_api_keys"
+}
+
+type GormPartnerQuota struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	PartnerID        string     `json:"partner_id" gorm:"not null;uniqueIndex:uniq_rate_limits_partner_endpoint"`
+	Endpoint         string     `json:"endpoint" gorm:"not null;uniqueIndex:uniq_rate_limits_partner_endpoint"`
+	LimitPerHour     int        `json:"limit_per_hour" gorm:"not null"`
+	LimitPerDay      int        `json:"limit_per_day" gorm:"not null"`
+	CurrentHourCount int        `json:"current_hour_count" gorm:"default:0"`
+	CurrentDayCount  int        `json:"current_day_count" gorm:"default:0"`
+	ResetTime        *time.Time `json:"reset_time"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (GormPartnerQuota) TableName() string {
+	return "rate_limits"
+}
+
+type GormOAuthClient struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	ClientID         string         `json:"client_id" gorm:"size:64;uniqueIndex;not null"`
+	ClientSecretHash string         `json:"-" gorm:"size:64"`
+	Name             string         `json:"name" gorm:"not null"`
+	RedirectURIs     *string        `json:"redirect_uris" gorm:"column:redirect_uris;type:json"`
+	GrantTypes       *string        `json:"grant_types" gorm:"type:json"`
+	Scopes           *string        `json:"scopes" gorm:"type:json"`
+	Confidential     bool           `json:"confidential" gorm:"default:true"`
+	CreatedBy        uint           `json:"created_by"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (GormOAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+type GormOAuthAuthorizationCode struct {
+	ID                  uint       `json:"id" gorm:"primaryKey"`
+	CodeHash            string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ClientID            string     `json:"client_id" gorm:"size:64;not null;index"`
+	UserID              uint       `json:"user_id" gorm:"not null"`
+	RedirectURI         string     `json:"redirect_uri" gorm:"column:redirect_uri;size:2048;not null"`
+	Scope               string     `json:"scope" gorm:"size:1024"`
+	CodeChallenge       string     `json:"-" gorm:"size:128;not null"`
+	CodeChallengeMethod string     `json:"code_challenge_method" gorm:"size:16;not null"`
+	ExpiresAt           time.Time  `json:"expires_at" gorm:"index"`
+	UsedAt              *time.Time `json:"used_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func (GormOAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+type GormOAuthRefreshToken struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	TokenHash    string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ClientID     string     `json:"client_id" gorm:"size:64;not null;index:idx_oauth_refresh_tokens_client_user"`
+	UserID       uint       `json:"user_id" gorm:"not null;index:idx_oauth_refresh_tokens_client_user"`
+	Scope        string     `json:"scope" gorm:"size:1024"`
+	FamilyID     string     `json:"family_id" gorm:"size:36;not null;index"`
+	TokenVersion int64      `json:"token_version" gorm:"default:0"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	IsRevoked    bool       `json:"is_revoked" gorm:"default:false"`
+	RotatedAt    *time.Time `json:"rotated_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (GormOAuthRefreshToken) TableName() string {
+	return "oauth_refresh_tokens"
+}
+
+type GormOAuthConsent struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:uniq_oauth_consents_user_client"`
+	ClientID  string    `json:"client_id" gorm:"size:64;not null;uniqueIndex:uniq_oauth_consents_user_client"`
+	Scope     string    `json:"scope" gorm:"size:1024"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (GormOAuthConsent) TableName() string {
+	return "oauth_consents"
+}
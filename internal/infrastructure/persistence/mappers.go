@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"encoding/json"
+
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 )
 
@@ -27,29 +29,64 @@ func UserGormToEntity(gormUser *GormUser) *entity.User {
 }
 
 func AuthEntityToGorm(auth *entity.Auth) *GormAuth {
-	return &GormAuth{
-		ID:           auth.ID,
-		UserID:       auth.UserID,
-		Email:        auth.Email,
-		PasswordHash: auth.PasswordHash,
-		IsActive:     auth.IsActive,
-		LastLoginAt:  auth.LastLoginAt,
-		CreatedAt:    auth.CreatedAt,
-		UpdatedAt:    auth.UpdatedAt,
+	gormAuth := &GormAuth{
+		ID:                 auth.ID,
+		UserID:             auth.UserID,
+		Email:              auth.Email,
+		PasswordHash:       auth.PasswordHash,
+		IsActive:           auth.IsActive,
+		LastLoginAt:        auth.LastLoginAt,
+		TwoFactorEnabled:   auth.TwoFactorEnabled,
+		TwoFactorLastStep:  auth.TwoFactorLastStep,
+		TwoFactorEnabledAt: auth.TwoFactorEnabledAt,
+		PasswordChangedAt:  auth.PasswordChangedAt,
+		CreatedAt:          auth.CreatedAt,
+		UpdatedAt:          auth.UpdatedAt,
+	}
+
+	if auth.TwoFactorSecret != "" {
+		secret := auth.TwoFactorSecret
+		gormAuth.TwoFactorSecret = &secret
+	}
+
+	if len(auth.TwoFactorRecoveryCodes) > 0 {
+		if encoded, err := json.Marshal(auth.TwoFactorRecoveryCodes); err == nil {
+			codes := string(encoded)
+			gormAuth.TwoFactorRecoveryCodes = &codes
+		}
 	}
+
+	return gormAuth
 }
 
 func AuthGormToEntity(gormAuth *GormAuth) *entity.Auth {
-	return &entity.Auth{
-		ID:           gormAuth.ID,
-		UserID:       gormAuth.UserID,
-		Email:        gormAuth.Email,
-		PasswordHash: gormAuth.PasswordHash,
-		IsActive:     gormAuth.IsActive,
-		LastLoginAt:  gormAuth.LastLoginAt,
-		CreatedAt:    gormAuth.CreatedAt,
-		UpdatedAt:    gormAuth.UpdatedAt,
+	auth := &entity.Auth{
+		ID:                 gormAuth.ID,
+		UserID:             gormAuth.UserID,
+		Email:              gormAuth.Email,
+		PasswordHash:       gormAuth.PasswordHash,
+		IsActive:           gormAuth.IsActive,
+		LastLoginAt:        gormAuth.LastLoginAt,
+		TwoFactorEnabled:   gormAuth.TwoFactorEnabled,
+		TwoFactorLastStep:  gormAuth.TwoFactorLastStep,
+		TwoFactorEnabledAt: gormAuth.TwoFactorEnabledAt,
+		PasswordChangedAt:  gormAuth.PasswordChangedAt,
+		CreatedAt:          gormAuth.CreatedAt,
+		UpdatedAt:          gormAuth.UpdatedAt,
+	}
+
+	if gormAuth.TwoFactorSecret != nil {
+		auth.TwoFactorSecret = *gormAuth.TwoFactorSecret
+	}
+
+	if gormAuth.TwoFactorRecoveryCodes != nil {
+		var codes []string
+		if err := json.Unmarshal([]byte(*gormAuth.TwoFactorRecoveryCodes), &codes); err == nil {
+			auth.TwoFactorRecoveryCodes = codes
+		}
 	}
+
+	return auth
 }
 
 func RoleEntityToGorm(role *entity.Role) *GormRole {
@@ -76,9 +113,11 @@ func RefreshTokenEntityToGorm(token *entity.RefreshToken) *GormRefreshToken {
 	return &GormRefreshToken{
 		ID:        token.ID,
 		UserID:    token.UserID,
-		Token:     token.Token,
+		TokenHash: token.TokenHash,
+		FamilyID:  token.FamilyID,
 		ExpiresAt: token.ExpiresAt,
 		IsRevoked: token.IsRevoked,
+		RotatedAt: token.RotatedAt,
 		CreatedAt: token.CreatedAt,
 		UpdatedAt: token.UpdatedAt,
 	}
@@ -88,9 +127,35 @@ func RefreshTokenGormToEntity(gormToken *GormRefreshToken) *entity.RefreshToken
 	return &entity.RefreshToken{
 		ID:        gormToken.ID,
 		UserID:    gormToken.UserID,
-		Token:     gormToken.Token,
+		TokenHash: gormToken.TokenHash,
+		FamilyID:  gormToken.FamilyID,
 		ExpiresAt: gormToken.ExpiresAt,
 		IsRevoked: gormToken.IsRevoked,
+		RotatedAt: gormToken.RotatedAt,
+		CreatedAt: gormToken.CreatedAt,
+		UpdatedAt: gormToken.UpdatedAt,
+	}
+}
+
+func UserTokenEntityToGorm(token *entity.UserToken) *GormUserToken {
+	return &GormUserToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenType: token.TokenType,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+		UpdatedAt: token.UpdatedAt,
+	}
+}
+
+func UserTokenGormToEntity(gormToken *GormUserToken) *entity.UserToken {
+	return &entity.UserToken{
+		ID:        gormToken.ID,
+		UserID:    gormToken.UserID,
+		TokenType: gormToken.TokenType,
+		TokenHash: gormToken.TokenHash,
+		ExpiresAt: gormToken.ExpiresAt,
 		CreatedAt: gormToken.CreatedAt,
 		UpdatedAt: gormToken.UpdatedAt,
 	}
@@ -126,33 +191,121 @@ func MembershipTierGormToEntity(gormTier *GormMembershipTier) *entity.Membership
 
 func UserMembershipEntityToGorm(membership *entity.UserMembership) *GormUserMembership {
 	return &GormUserMembership{
-		ID:             membership.ID,
-		UserID:         membership.UserID,
-		TierID:         membership.TierID,
-		Points:         membership.Points,
-		TotalSpent:     membership.TotalSpent,
-		JoinedAt:       membership.JoinedAt,
-		LastActivityAt: membership.LastActivityAt,
-		ExpiresAt:      membership.ExpiresAt,
-		IsActive:       membership.IsActive,
-		CreatedAt:      membership.CreatedAt,
-		UpdatedAt:      membership.UpdatedAt,
+		ID:              membership.ID,
+		UserID:          membership.UserID,
+		TierID:          membership.TierID,
+		Points:          membership.Points,
+		TotalSpent:      membership.TotalSpent,
+		JoinedAt:        membership.JoinedAt,
+		LastActivityAt:  membership.LastActivityAt,
+		ExpiresAt:       membership.ExpiresAt,
+		TierGraceEndsAt: membership.TierGraceEndsAt,
+		IsActive:        membership.IsActive,
+		CreatedAt:       membership.CreatedAt,
+		UpdatedAt:       membership.UpdatedAt,
 	}
 }
 
 func UserMembershipGormToEntity(gormMembership *GormUserMembership) *entity.UserMembership {
 	return &entity.UserMembership{
-		ID:             gormMembership.ID,
-		UserID:         gormMembership.UserID,
-		TierID:         gormMembership.TierID,
-		Points:         gormMembership.Points,
-		TotalSpent:     gormMembership.TotalSpent,
-		JoinedAt:       gormMembership.JoinedAt,
-		LastActivityAt: gormMembership.LastActivityAt,
-		ExpiresAt:      gormMembership.ExpiresAt,
-		IsActive:       gormMembership.IsActive,
-		CreatedAt:      gormMembership.CreatedAt,
-		UpdatedAt:      gormMembership.UpdatedAt,
+		ID:              gormMembership.ID,
+		UserID:          gormMembership.UserID,
+		TierID:          gormMembership.TierID,
+		Points:          gormMembership.Points,
+		TotalSpent:      gormMembership.TotalSpent,
+		JoinedAt:        gormMembership.JoinedAt,
+		LastActivityAt:  gormMembership.LastActivityAt,
+		ExpiresAt:       gormMembership.ExpiresAt,
+		TierGraceEndsAt: gormMembership.TierGraceEndsAt,
+		IsActive:        gormMembership.IsActive,
+		CreatedAt:       gormMembership.CreatedAt,
+		UpdatedAt:       gormMembership.UpdatedAt,
+	}
+}
+
+func MembershipTierHistoryEntityToGorm(history *entity.MembershipTierHistory) *GormMembershipTierHistory {
+	return &GormMembershipTierHistory{
+		ID:         history.ID,
+		UserID:     history.UserID,
+		FromTierID: history.FromTierID,
+		ToTierID:   history.ToTierID,
+		Reason:     history.Reason,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+func MembershipTierHistoryGormToEntity(gormHistory *GormMembershipTierHistory) *entity.MembershipTierHistory {
+	return &entity.MembershipTierHistory{
+		ID:         gormHistory.ID,
+		UserID:     gormHistory.UserID,
+		FromTierID: gormHistory.FromTierID,
+		ToTierID:   gormHistory.ToTierID,
+		Reason:     gormHistory.Reason,
+		CreatedAt:  gormHistory.CreatedAt,
+	}
+}
+
+func PurchaseEntityToGorm(purchase *entity.Purchase) *GormPurchase {
+	return &GormPurchase{
+		ID:                  purchase.ID,
+		UserID:              purchase.UserID,
+		StoreID:             purchase.StoreID,
+		TotalAmount:         purchase.TotalAmount,
+		PointsEarned:        purchase.PointsEarned,
+		TierDiscountApplied: purchase.TierDiscountApplied,
+		PointTransactionID:  purchase.PointTransactionID,
+		Status:              purchase.Status,
+		RefundedAt:          purchase.RefundedAt,
+		CreatedAt:           purchase.CreatedAt,
+		UpdatedAt:           purchase.UpdatedAt,
+	}
+}
+
+func PurchaseGormToEntity(gormPurchase *GormPurchase) *entity.Purchase {
+	return &entity.Purchase{
+		ID:                  gormPurchase.ID,
+		UserID:              gormPurchase.UserID,
+		StoreID:             gormPurchase.StoreID,
+		TotalAmount:         gormPurchase.TotalAmount,
+		PointsEarned:        gormPurchase.PointsEarned,
+		TierDiscountApplied: gormPurchase.TierDiscountApplied,
+		PointTransactionID:  gormPurchase.PointTransactionID,
+		Status:              gormPurchase.Status,
+		RefundedAt:          gormPurchase.RefundedAt,
+		CreatedAt:           gormPurchase.CreatedAt,
+		UpdatedAt:           gormPurchase.UpdatedAt,
+	}
+}
+
+func PointTransactionEntityToGorm(transaction *entity.PointTransaction) *GormPointTransaction {
+	return &GormPointTransaction{
+		ID:              transaction.ID,
+		UserID:          transaction.UserID,
+		Type:            transaction.Type,
+		Points:          transaction.Points,
+		RemainingPoints: transaction.RemainingPoints,
+		BalanceAfter:    transaction.BalanceAfter,
+		Description:     transaction.Description,
+		ReferenceType:   transaction.ReferenceType,
+		ReferenceID:     transaction.ReferenceID,
+		ExpiresAt:       transaction.ExpiresAt,
+		CreatedAt:       transaction.CreatedAt,
+	}
+}
+
+func PointTransactionGormToEntity(gormTransaction *GormPointTransaction) *entity.PointTransaction {
+	return &entity.PointTransaction{
+		ID:              gormTransaction.ID,
+		UserID:          gormTransaction.UserID,
+		Type:            gormTransaction.Type,
+		Points:          gormTransaction.Points,
+		RemainingPoints: gormTransaction.RemainingPoints,
+		BalanceAfter:    gormTransaction.BalanceAfter,
+		Description:     gormTransaction.Description,
+		ReferenceType:   gormTransaction.ReferenceType,
+		ReferenceID:     gormTransaction.ReferenceID,
+		ExpiresAt:       gormTransaction.ExpiresAt,
+		CreatedAt:       gormTransaction.CreatedAt,
 	}
 }
 
@@ -196,6 +349,30 @@ func UserProfileGormToEntity(gormProfile *GormUserProfile) *entity.UserProfile {
 	}
 }
 
+func UserPreferenceGormToEntity(gormPreference *GormUserPreference) *entity.UserPreference {
+	return &entity.UserPreference{
+		ID:        gormPreference.ID,
+		UserID:    gormPreference.UserID,
+		Category:  gormPreference.Category,
+		Key:       gormPreference.Key,
+		Value:     gormPreference.Value,
+		CreatedAt: gormPreference.CreatedAt,
+		UpdatedAt: gormPreference.UpdatedAt,
+	}
+}
+
+func UserPreferenceEntityToGorm(preference *entity.UserPreference) *GormUserPreference {
+	return &GormUserPreference{
+		ID:        preference.ID,
+		UserID:    preference.UserID,
+		Category:  preference.Category,
+		Key:       preference.Key,
+		Value:     preference.Value,
+		CreatedAt: preference.CreatedAt,
+		UpdatedAt: preference.UpdatedAt,
+	}
+}
+
 func NotificationEntityToGorm(notification *entity.Notification) *GormNotification {
 	return &GormNotification{
 		ID:        notification.ID,
@@ -204,6 +381,7 @@ func NotificationEntityToGorm(notification *entity.Notification) *GormNotificati
 		Title:     notification.Title,
 		Message:   notification.Message,
 		Data:      notification.Data,
+		Priority:  notification.Priority,
 		IsRead:    notification.IsRead,
 		ReadAt:    notification.ReadAt,
 		CreatedAt: notification.CreatedAt,
@@ -219,6 +397,7 @@ func NotificationGormToEntity(gormNotification *GormNotification) *entity.Notifi
 		Title:     gormNotification.Title,
 		Message:   gormNotification.Message,
 		Data:      gormNotification.Data,
+		Priority:  gormNotification.Priority,
 		IsRead:    gormNotification.IsRead,
 		ReadAt:    gormNotification.ReadAt,
 		CreatedAt: gormNotification.CreatedAt,
@@ -278,6 +457,28 @@ func IPBlacklistGormToEntity(gormBlacklist *GormIPBlacklist) *entity.IPBlacklist
 	}
 }
 
+func IPAllowlistEntityToGorm(allowlist *entity.IPAllowlist) *GormIPAllowlist {
+	return &GormIPAllowlist{
+		ID:          allowlist.ID,
+		IPAddress:   allowlist.IPAddress,
+		Description: allowlist.Description,
+		IsActive:    allowlist.IsActive,
+		CreatedAt:   allowlist.CreatedAt,
+		UpdatedAt:   allowlist.UpdatedAt,
+	}
+}
+
+func IPAllowlistGormToEntity(gormAllowlist *GormIPAllowlist) *entity.IPAllowlist {
+	return &entity.IPAllowlist{
+		ID:          gormAllowlist.ID,
+		IPAddress:   gormAllowlist.IPAddress,
+		Description: gormAllowlist.Description,
+		IsActive:    gormAllowlist.IsActive,
+		CreatedAt:   gormAllowlist.CreatedAt,
+		UpdatedAt:   gormAllowlist.UpdatedAt,
+	}
+}
+
 func LoginAttemptEntityToGorm(attempt *entity.LoginAttempt) *GormLoginAttempt {
 	return &GormLoginAttempt{
 		ID:         attempt.ID,
@@ -309,6 +510,7 @@ func RateLimitRuleEntityToGorm(rule *entity.RateLimitRule) *GormRateLimitRule {
 		Resource:    rule.Resource,
 		MaxRequests: rule.MaxRequests,
 		WindowSize:  rule.WindowSize,
+		Algorithm:   rule.Algorithm,
 		IsActive:    rule.IsActive,
 		CreatedAt:   rule.CreatedAt,
 		UpdatedAt:   rule.UpdatedAt,
@@ -322,12 +524,67 @@ func RateLimitRuleGormToEntity(gormRule *GormRateLimitRule) *entity.RateLimitRul
 		Resource:    gormRule.Resource,
 		MaxRequests: gormRule.MaxRequests,
 		WindowSize:  gormRule.WindowSize,
+		Algorithm:   gormRule.Algorithm,
 		IsActive:    gormRule.IsActive,
 		CreatedAt:   gormRule.CreatedAt,
 		UpdatedAt:   gormRule.UpdatedAt,
 	}
 }
 
+func FraudRuleConfigEntityToGorm(config *entity.FraudRuleConfig) *GormFraudRuleConfig {
+	return &GormFraudRuleConfig{
+		ID:            config.ID,
+		Name:          config.Name,
+		Enabled:       config.Enabled,
+		Weight:        config.Weight,
+		Threshold:     config.Threshold,
+		WindowSeconds: config.WindowSeconds,
+		CreatedAt:     config.CreatedAt,
+		UpdatedAt:     config.UpdatedAt,
+	}
+}
+
+func FraudRuleConfigGormToEntity(gormConfig *GormFraudRuleConfig) *entity.FraudRuleConfig {
+	return &entity.FraudRuleConfig{
+		ID:            gormConfig.ID,
+		Name:          gormConfig.Name,
+		Enabled:       gormConfig.Enabled,
+		Weight:        gormConfig.Weight,
+		Threshold:     gormConfig.Threshold,
+		WindowSeconds: gormConfig.WindowSeconds,
+		CreatedAt:     gormConfig.CreatedAt,
+		UpdatedAt:     gormConfig.UpdatedAt,
+	}
+}
+
+func RateLimitLogEntityToGorm(log *entity.RateLimitLog) *GormRateLimitLog {
+	return &GormRateLimitLog{
+		ID:          log.ID,
+		RuleID:      log.RuleID,
+		IPAddress:   log.IPAddress,
+		UserID:      log.UserID,
+		Requests:    log.Requests,
+		WindowStart: log.WindowStart,
+		WindowEnd:   log.WindowEnd,
+		Blocked:     log.Blocked,
+		CreatedAt:   log.CreatedAt,
+	}
+}
+
+func RateLimitLogGormToEntity(gormLog *GormRateLimitLog) *entity.RateLimitLog {
+	return &entity.RateLimitLog{
+		ID:          gormLog.ID,
+		RuleID:      gormLog.RuleID,
+		IPAddress:   gormLog.IPAddress,
+		UserID:      gormLog.UserID,
+		Requests:    gormLog.Requests,
+		WindowStart: gormLog.WindowStart,
+		WindowEnd:   gormLog.WindowEnd,
+		Blocked:     gormLog.Blocked,
+		CreatedAt:   gormLog.CreatedAt,
+	}
+}
+
 func UserSessionEntityToGorm(session *entity.UserSession) *GormUserSession {
 	return &GormUserSession{
 		ID:        session.ID,
@@ -381,3 +638,318 @@ func DeviceFingerprintGormToEntity(gormFingerprint *GormDeviceFingerprint) *enti
 		UpdatedAt:   gormFingerprint.UpdatedAt,
 	}
 }
+
+func AdminActionEntityToGorm(action *entity.AdminAction) *GormAdminAction {
+	var idempotencyKey *string
+	if action.IdempotencyKey != "" {
+		idempotencyKey = &action.IdempotencyKey
+	}
+	return &GormAdminAction{
+		ID:                 action.ID,
+		AdminUserID:        action.AdminUserID,
+		ActionType:         action.ActionType,
+		TargetType:         action.TargetType,
+		TargetID:           action.TargetID,
+		Description:        action.Description,
+		Result:             action.Result,
+		IdempotencyKey:     idempotencyKey,
+		PointTransactionID: action.PointTransactionID,
+		CreatedAt:          action.CreatedAt,
+	}
+}
+
+func AdminActionGormToEntity(gormAction *GormAdminAction) *entity.AdminAction {
+	action := &entity.AdminAction{
+		ID:                 gormAction.ID,
+		AdminUserID:        gormAction.AdminUserID,
+		ActionType:         gormAction.ActionType,
+		TargetType:         gormAction.TargetType,
+		TargetID:           gormAction.TargetID,
+		Description:        gormAction.Description,
+		Result:             gormAction.Result,
+		PointTransactionID: gormAction.PointTransactionID,
+		CreatedAt:          gormAction.CreatedAt,
+	}
+	if gormAction.IdempotencyKey != nil {
+		action.IdempotencyKey = *gormAction.IdempotencyKey
+	}
+	return action
+}
+
+func PermissionGormToEntity(gormPermission *GormPermission) *entity.Permission {
+	return &entity.Permission{
+		ID:          gormPermission.ID,
+		Name:        gormPermission.Name,
+		Resource:    gormPermission.Resource,
+		Action:      gormPermission.Action,
+		Scope:       gormPermission.Scope,
+		Description: gormPermission.Description,
+		CreatedAt:   gormPermission.CreatedAt,
+		UpdatedAt:   gormPermission.UpdatedAt,
+	}
+}
+
+func ScopeGormToEntity(gormScope *GormScope) *entity.Scope {
+	return &entity.Scope{
+		ID:          gormScope.ID,
+		Name:        gormScope.Name,
+		Resource:    gormScope.Resource,
+		Description: gormScope.Description,
+		IsActive:    gormScope.IsActive,
+		CreatedAt:   gormScope.CreatedAt,
+		UpdatedAt:   gormScope.UpdatedAt,
+	}
+}
+
+func UserScopeEntityToGorm(userScope *entity.UserScope) *GormUserScope {
+	return &GormUserScope{
+		ID:        userScope.ID,
+		UserID:    userScope.UserID,
+		ScopeID:   userScope.ScopeID,
+		ExpiresAt: userScope.ExpiresAt,
+		CreatedAt: userScope.CreatedAt,
+	}
+}
+
+func UserScopeGormToEntity(gormUserScope *GormUserScope) *entity.UserScope {
+	userScope := &entity.UserScope{
+		ID:        gormUserScope.ID,
+		UserID:    gormUserScope.UserID,
+		ScopeID:   gormUserScope.ScopeID,
+		ExpiresAt: gormUserScope.ExpiresAt,
+		CreatedAt: gormUserScope.CreatedAt,
+	}
+	if gormUserScope.Scope.ID != 0 {
+		userScope.Scope = ScopeGormToEntity(&gormUserScope.Scope)
+	}
+	return userScope
+}
+
+func PartnerAPIKeyEntityToGorm(key *entity.PartnerAPIKey) *GormPartnerAPIKey {
+	gormKey := &GormPartnerAPIKey{
+		ID:          key.ID,
+		PartnerID:   key.PartnerID,
+		KeyHash:     key.KeyHash,
+		KeyPrefix:   key.KeyPrefix,
+		PartnerName: key.PartnerName,
+		Enabled:     key.Enabled,
+		CreatedAt:   key.CreatedAt,
+		UpdatedAt:   key.UpdatedAt,
+	}
+
+	if key.RateLimit > 0 {
+		rateLimit := key.RateLimit
+		gormKey.RateLimit = &rateLimit
+	}
+
+	permissions := key.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	if encoded, err := json.Marshal(permissions); err == nil {
+		value := string(encoded)
+		gormKey.Permissions = &value
+	}
+
+	return gormKey
+}
+
+func PartnerAPIKeyGormToEntity(gormKey *GormPartnerAPIKey) *entity.PartnerAPIKey {
+	key := &entity.PartnerAPIKey{
+		ID:          gormKey.ID,
+		PartnerID:   gormKey.PartnerID,
+		PartnerName: gormKey.PartnerName,
+		KeyPrefix:   gormKey.KeyPrefix,
+		KeyHash:     gormKey.KeyHash,
+		Permissions: []string{},
+		Enabled:     gormKey.Enabled,
+		CreatedAt:   gormKey.CreatedAt,
+		UpdatedAt:   gormKey.UpdatedAt,
+	}
+
+	if gormKey.RateLimit != nil {
+		key.RateLimit = *gormKey.RateLimit
+	}
+
+	if gormKey.Permissions != nil {
+		var permissions []string
+		if err := json.Unmarshal([]byte(*gormKey.Permissions), &permissions); err == nil {
+			key.Permissions = permissions
+		}
+	}
+
+	return key
+}
+
+func PartnerQuotaEntityToGorm(quota *entity.PartnerQuota) *GormPartnerQuota {
+	return &GormPartnerQuota{
+		ID:               quota.ID,
+		PartnerID:        quota.PartnerID,
+		Endpoint:         quota.Endpoint,
+		LimitPerHour:     quota.LimitPerHour,
+		LimitPerDay:      quota.LimitPerDay,
+		CurrentHourCount: quota.CurrentHourCount,
+		CurrentDayCount:  quota.CurrentDayCount,
+		ResetTime:        quota.ResetTime,
+		CreatedAt:        quota.CreatedAt,
+		UpdatedAt:        quota.UpdatedAt,
+	}
+}
+
+func PartnerQuotaGormToEntity(gormQuota *GormPartnerQuota) *entity.PartnerQuota {
+	return &entity.PartnerQuota{
+		ID:               gormQuota.ID,
+		PartnerID:        gormQuota.PartnerID,
+		Endpoint:         gormQuota.Endpoint,
+		LimitPerHour:     gormQuota.LimitPerHour,
+		LimitPerDay:      gormQuota.LimitPerDay,
+		CurrentHourCount: gormQuota.CurrentHourCount,
+		CurrentDayCount:  gormQuota.CurrentDayCount,
+		ResetTime:        gormQuota.ResetTime,
+		CreatedAt:        gormQuota.CreatedAt,
+		UpdatedAt:        gormQuota.UpdatedAt,
+	}
+}
+
+func OAuthClientEntityToGorm(client *entity.OAuthClient) *GormOAuthClient {
+	return &GormOAuthClient{
+		ID:               client.ID,
+		ClientID:         client.ClientID,
+		ClientSecretHash: client.ClientSecretHash,
+		Name:             client.Name,
+		RedirectURIs:     encodeStringList(client.RedirectURIs),
+		GrantTypes:       encodeStringList(client.GrantTypes),
+		Scopes:           encodeStringList(client.Scopes),
+		Confidential:     client.Confidential,
+		CreatedBy:        client.CreatedBy,
+		CreatedAt:        client.CreatedAt,
+		UpdatedAt:        client.UpdatedAt,
+	}
+}
+
+func OAuthClientGormToEntity(gormClient *GormOAuthClient) *entity.OAuthClient {
+	return &entity.OAuthClient{
+		ID:               gormClient.ID,
+		ClientID:         gormClient.ClientID,
+		ClientSecretHash: gormClient.ClientSecretHash,
+		Name:             gormClient.Name,
+		RedirectURIs:     decodeStringList(gormClient.RedirectURIs),
+		GrantTypes:       decodeStringList(gormClient.GrantTypes),
+		Scopes:           decodeStringList(gormClient.Scopes),
+		Confidential:     gormClient.Confidential,
+		CreatedBy:        gormClient.CreatedBy,
+		CreatedAt:        gormClient.CreatedAt,
+		UpdatedAt:        gormClient.UpdatedAt,
+	}
+}
+
+func OAuthAuthorizationCodeEntityToGorm(code *entity.OAuthAuthorizationCode) *GormOAuthAuthorizationCode {
+	return &GormOAuthAuthorizationCode{
+		ID:                  code.ID,
+		CodeHash:            code.CodeHash,
+		ClientID:            code.ClientID,
+		UserID:              code.UserID,
+		RedirectURI:         code.RedirectURI,
+		Scope:               entity.FormatOAuthScope(code.Scopes),
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		ExpiresAt:           code.ExpiresAt,
+		UsedAt:              code.UsedAt,
+		CreatedAt:           code.CreatedAt,
+	}
+}
+
+func OAuthAuthorizationCodeGormToEntity(gormCode *GormOAuthAuthorizationCode) *entity.OAuthAuthorizationCode {
+	return &entity.OAuthAuthorizationCode{
+		ID:                  gormCode.ID,
+		CodeHash:            gormCode.CodeHash,
+		ClientID:            gormCode.ClientID,
+		UserID:              gormCode.UserID,
+		RedirectURI:         gormCode.RedirectURI,
+		Scopes:              entity.ParseOAuthScope(gormCode.Scope),
+		CodeChallenge:       gormCode.CodeChallenge,
+		CodeChallengeMethod: gormCode.CodeChallengeMethod,
+		ExpiresAt:           gormCode.ExpiresAt,
+		UsedAt:              gormCode.UsedAt,
+		CreatedAt:           gormCode.CreatedAt,
+	}
+}
+
+func OAuthRefreshTokenEntityToGorm(token *entity.OAuthRefreshToken) *GormOAuthRefreshToken {
+	return &GormOAuthRefreshToken{
+		ID:           token.ID,
+		TokenHash:    token.TokenHash,
+		ClientID:     token.ClientID,
+		UserID:       token.UserID,
+		Scope:        entity.FormatOAuthScope(token.Scopes),
+		FamilyID:     token.FamilyID,
+		TokenVersion: token.TokenVersion,
+		ExpiresAt:    token.ExpiresAt,
+		IsRevoked:    token.IsRevoked,
+		RotatedAt:    token.RotatedAt,
+		CreatedAt:    token.CreatedAt,
+		UpdatedAt:    token.UpdatedAt,
+	}
+}
+
+func OAuthRefreshTokenGormToEntity(gormToken *GormOAuthRefreshToken) *entity.OAuthRefreshToken {
+	return &entity.OAuthRefreshToken{
+		ID:           gormToken.ID,
+		TokenHash:    gormToken.TokenHash,
+		ClientID:     gormToken.ClientID,
+		UserID:       gormToken.UserID,
+		Scopes:       entity.ParseOAuthScope(gormToken.Scope),
+		FamilyID:     gormToken.FamilyID,
+		TokenVersion: gormToken.TokenVersion,
+		ExpiresAt:    gormToken.ExpiresAt,
+		IsRevoked:    gormToken.IsRevoked,
+		RotatedAt:    gormToken.RotatedAt,
+		CreatedAt:    gormToken.CreatedAt,
+		UpdatedAt:    gormToken.UpdatedAt,
+	}
+}
+
+func OAuthConsentEntityToGorm(consent *entity.OAuthConsent) *GormOAuthConsent {
+	return &GormOAuthConsent{
+		ID:        consent.ID,
+		UserID:    consent.UserID,
+		ClientID:  consent.ClientID,
+		Scope:     entity.FormatOAuthScope(consent.Scopes),
+		CreatedAt: consent.CreatedAt,
+		UpdatedAt: consent.UpdatedAt,
+	}
+}
+
+func OAuthConsentGormToEntity(gormConsent *GormOAuthConsent) *entity.OAuthConsent {
+	return &entity.OAuthConsent{
+		ID:        gormConsent.ID,
+		UserID:    gormConsent.UserID,
+		ClientID:  gormConsent.ClientID,
+		Scopes:    entity.ParseOAuthScope(gormConsent.Scope),
+		CreatedAt: gormConsent.CreatedAt,
+		UpdatedAt: gormConsent.UpdatedAt,
+	}
+}
+
+func encodeStringList(values []string) *string {
+	if values == nil {
+		values = []string{}
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	value := string(encoded)
+	return &value
+}
+
+func decodeStringList(value *string) []string {
+	values := []string{}
+	if value != nil {
+		var decoded []string
+		if err := json.Unmarshal([]byte(*value), &decoded); err == nil && decoded != nil {
+			values = decoded
+		}
+	}
+	return values
+}
@@ -0,0 +1,763 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type userProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewUserProfileRepository(db *gorm.DB) repository.UserProfileRepository {
+	return &userProfileRepository{db: db}
+}
+
+func (r *userProfileRepository) Create(ctx context.Context, profile *entity.UserProfile) error {
+	gormProfile := UserProfileEntityToGorm(profile)
+	if err := r.db.WithContext(ctx).Create(gormProfile).Error; err != nil {
+		return err
+	}
+	profile.ID = gormProfile.ID
+	return nil
+}
+
+func (r *userProfileRepository) GetByUserID(ctx context.Context, userID uint) (*entity.UserProfile, error) {
+	var gormProfile GormUserProfile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&gormProfile).Error; err != nil {
+		return nil, err
+	}
+	return UserProfileGormToEntity(&gormProfile), nil
+}
+
+func (r *userProfileRepository) Update(ctx context.Context, profile *entity.UserProfile) error {
+	gormProfile := UserProfileEntityToGorm(profile)
+	return r.db.WithContext(ctx).Save(gormProfile).Error
+}
+
+func (r *userProfileRepository) Delete(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&GormUserProfile{}).Error
+}
+
+type userMembershipRepository struct {
+	db *gorm.DB
+}
+
+func NewUserMembershipRepository(db *gorm.DB) repository.UserMembershipRepository {
+	return &userMembershipRepository{db: db}
+}
+
+func (r *userMembershipRepository) Create(ctx context.Context, membership *entity.UserMembership) error {
+	gormMembership := UserMembershipEntityToGorm(membership)
+	if err := r.db.WithContext(ctx).Create(gormMembership).Error; err != nil {
+		return err
+	}
+	membership.ID = gormMembership.ID
+	return nil
+}
+
+func (r *userMembershipRepository) GetByUserID(ctx context.Context, userID uint) (*entity.UserMembership, error) {
+	var gormMembership GormUserMembership
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&gormMembership).Error; err != nil {
+		return nil, err
+	}
+	return UserMembershipGormToEntity(&gormMembership), nil
+}
+
+func (r *userMembershipRepository) Update(ctx context.Context, membership *entity.UserMembership) error {
+	gormMembership := UserMembershipEntityToGorm(membership)
+	return r.db.WithContext(ctx).Save(gormMembership).Error
+}
+
+func (r *userMembershipRepository) Delete(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&GormUserMembership{}).Error
+}
+
+func (r *userMembershipRepository) UpdateTier(ctx context.Context, membership *entity.UserMembership) error {
+	return r.db.WithContext(ctx).Model(&GormUserMembership{}).
+		Where("id = ?", membership.ID).
+		Updates(map[string]interface{}{
+			"tier_id":            membership.TierID,
+			"tier_grace_ends_at": membership.TierGraceEndsAt,
+			"updated_at":         membership.UpdatedAt,
+		}).Error
+}
+
+func (r *userMembershipRepository) CountByTierID(ctx context.Context, tierID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&GormUserMembership{}).Where("tier_id = ?", tierID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *userMembershipRepository) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	var totalMembers int64
+	r.db.WithContext(ctx).Model(&GormUserMembership{}).Count(&totalMembers)
+
+	return map[string]interface{}{
+		"total_members": totalMembers,
+	}, nil
+}
+
+func (r *userMembershipRepository) List(ctx context.Context, offset, limit int) ([]*entity.UserMembership, int64, error) {
+	var gormMemberships []GormUserMembership
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&GormUserMembership{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).Order("id ASC").Offset(offset).Limit(limit).Find(&gormMemberships).Error; err != nil {
+		return nil, 0, err
+	}
+
+	memberships := make([]*entity.UserMembership, len(gormMemberships))
+	for i, gormMembership := range gormMemberships {
+		memberships[i] = UserMembershipGormToEntity(&gormMembership)
+	}
+
+	return memberships, total, nil
+}
+
+type membershipTierRepository struct {
+	db *gorm.DB
+}
+
+func NewMembershipTierRepository(db *gorm.DB) repository.MembershipTierRepository {
+	return &membershipTierRepository{db: db}
+}
+
+func (r *membershipTierRepository) Create(ctx context.Context, tier *entity.MembershipTier) error {
+	gormTier := MembershipTierEntityToGorm(tier)
+	if err := r.db.WithContext(ctx).Create(gormTier).Error; err != nil {
+		return err
+	}
+	tier.ID = gormTier.ID
+	return nil
+}
+
+func (r *membershipTierRepository) GetByID(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	var gormTier GormMembershipTier
+	if err := r.db.WithContext(ctx).First(&gormTier, id).Error; err != nil {
+		return nil, err
+	}
+	return MembershipTierGormToEntity(&gormTier), nil
+}
+
+func (r *membershipTierRepository) GetByName(ctx context.Context, name string) (*entity.MembershipTier, error) {
+	var gormTier GormMembershipTier
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&gormTier).Error; err != nil {
+		return nil, err
+	}
+	return MembershipTierGormToEntity(&gormTier), nil
+}
+
+func (r *membershipTierRepository) List(ctx context.Context) ([]*entity.MembershipTier, error) {
+	var gormTiers []GormMembershipTier
+	if err := r.db.WithContext(ctx).Order("level ASC, id ASC").Find(&gormTiers).Error; err != nil {
+		return nil, err
+	}
+
+	tiers := make([]*entity.MembershipTier, len(gormTiers))
+	for i, gormTier := range gormTiers {
+		tiers[i] = MembershipTierGormToEntity(&gormTier)
+	}
+	return tiers, nil
+}
+
+func (r *membershipTierRepository) Update(ctx context.Context, tier *entity.MembershipTier) error {
+	return r.db.WithContext(ctx).Model(&GormMembershipTier{}).
+		Where("id = ?", tier.ID).
+		Updates(map[string]interface{}{
+			"name":         tier.Name,
+			"level":        tier.Level,
+			"description":  tier.Description,
+			"benefits":     tier.Benefits,
+			"requirements": tier.Requirements,
+			"is_active":    tier.IsActive,
+			"updated_at":   tier.UpdatedAt,
+		}).Error
+}
+
+func (r *membershipTierRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&GormMembershipTier{}, id).Error
+}
+
+type membershipTierHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewMembershipTierHistoryRepository(db *gorm.DB) repository.MembershipTierHistoryRepository {
+	return &membershipTierHistoryRepository{db: db}
+}
+
+func (r *membershipTierHistoryRepository) Create(ctx context.Context, history *entity.MembershipTierHistory) error {
+	gormHistory := MembershipTierHistoryEntityToGorm(history)
+	if err := r.db.WithContext(ctx).Create(gormHistory).Error; err != nil {
+		return err
+	}
+	history.ID = gormHistory.ID
+	return nil
+}
+
+func (r *membershipTierHistoryRepository) Record(ctx context.Context, history *entity.MembershipTierHistory) (bool, error) {
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormMembership GormUserMembership
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", history.UserID).
+			First(&gormMembership).Error; err != nil {
+			return err
+		}
+		if gormMembership.TierID != history.FromTierID {
+			return nil
+		}
+
+		if err := tx.Model(&GormUserMembership{}).
+			Where("id = ?", gormMembership.ID).
+			Updates(map[string]interface{}{
+				"tier_id":            history.ToTierID,
+				"tier_grace_ends_at": nil,
+				"updated_at":         history.CreatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		gormHistory := MembershipTierHistoryEntityToGorm(history)
+		if err := tx.Create(gormHistory).Error; err != nil {
+			return err
+		}
+		history.ID = gormHistory.ID
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return recorded, nil
+}
+
+func (r *membershipTierHistoryRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.MembershipTierHistory, int64, error) {
+	var gormHistories []GormMembershipTierHistory
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&GormMembershipTierHistory{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&gormHistories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	histories := make([]*entity.MembershipTierHistory, len(gormHistories))
+	for i, gormHistory := range gormHistories {
+		histories[i] = MembershipTierHistoryGormToEntity(&gormHistory)
+	}
+
+	return histories, total, nil
+}
+
+type pointTransactionRepository struct {
+	db *gorm.DB
+}
+
+func NewPointTransactionRepository(db *gorm.DB) repository.PointTransactionRepository {
+	return &pointTransactionRepository{db: db}
+}
+
+func (r *pointTransactionRepository) Create(ctx context.Context, transaction *entity.PointTransaction) error {
+	gormTransaction := PointTransactionEntityToGorm(transaction)
+	if err := r.db.WithContext(ctx).Create(gormTransaction).Error; err != nil {
+		return err
+	}
+	transaction.ID = gormTransaction.ID
+	return nil
+}
+
+func (r *pointTransactionRepository) Record(ctx context.Context, transaction *entity.PointTransaction) (*entity.UserMembership, error) {
+	var membership *entity.UserMembership
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		membership, err = recordPointTransaction(tx, transaction)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *pointTransactionRepository) GetByUserID(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	var gormTransactions []GormPointTransaction
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&GormPointTransaction{}).Where("user_id = ?", userID)
+	if filter != nil {
+		if filter.Type != "" {
+			query = query.Where("type = ?", filter.Type)
+		}
+		if filter.From != nil {
+			query = query.Where("created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("created_at <= ?", *filter.To)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&gormTransactions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	transactions := make([]*entity.PointTransaction, len(gormTransactions))
+	for i, gormTransaction := range gormTransactions {
+		transactions[i] = PointTransactionGormToEntity(&gormTransaction)
+	}
+
+	return transactions, total, nil
+}
+
+func (r *pointTransactionRepository) GetByID(ctx context.Context, id uint) (*entity.PointTransaction, error) {
+	var gormTransaction GormPointTransaction
+	if err := r.db.WithContext(ctx).First(&gormTransaction, id).Error; err != nil {
+		return nil, err
+	}
+	return PointTransactionGormToEntity(&gormTransaction), nil
+}
+
+func (r *pointTransactionRepository) GetSummary(ctx context.Context, userID uint) (*entity.PointSummary, error) {
+	var totals struct {
+		TotalEarned int
+		TotalSpent  int
+	}
+	if err := r.db.WithContext(ctx).Model(&GormPointTransaction{}).
+		Select("COALESCE(SUM(CASE WHEN points > 0 THEN points ELSE 0 END), 0) AS total_earned, "+
+			"COALESCE(SUM(CASE WHEN points < 0 THEN -points ELSE 0 END), 0) AS total_spent").
+		Where("user_id = ?", userID).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &entity.PointSummary{
+		TotalEarned: totals.TotalEarned,
+		TotalSpent:  totals.TotalSpent,
+	}
+
+	var gormMembership GormUserMembership
+	err := r.db.WithContext(ctx).Select("points").Where("user_id = ?", userID).First(&gormMembership).Error
+	switch {
+	case err == nil:
+		summary.Balance = gormMembership.Points
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (r *pointTransactionRepository) List(ctx context.Context, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	var gormTransactions []GormPointTransaction
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&GormPointTransaction{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&gormTransactions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	transactions := make([]*entity.PointTransaction, len(gormTransactions))
+	for i, gormTransaction := range gormTransactions {
+		transactions[i] = PointTransactionGormToEntity(&gormTransaction)
+	}
+
+	return transactions, total, nil
+}
+
+func (r *pointTransactionRepository) ListExpiredLots(ctx context.Context, asOf time.Time) ([]*entity.PointTransaction, error) {
+	var gormTransactions []GormPointTransaction
+	if err := r.db.WithContext(ctx).
+		Where("remaining_points > 0 AND expires_at IS NOT NULL AND expires_at <= ?", asOf).
+		Order("user_id ASC, created_at ASC, id ASC").
+		Find(&gormTransactions).Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*entity.PointTransaction, len(gormTransactions))
+	for i, gormTransaction := range gormTransactions {
+		transactions[i] = PointTransactionGormToEntity(&gormTransaction)
+	}
+	return transactions, nil
+}
+
+func (r *pointTransactionRepository) ExpirePoints(ctx context.Context, userID uint, asOf time.Time) (*entity.PointTransaction, error) {
+	var transaction *entity.PointTransaction
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormMembership GormUserMembership
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			First(&gormMembership).Error; err != nil {
+			return err
+		}
+
+		lots, err := lockPointLots(tx.Where("user_id = ? AND expires_at IS NOT NULL AND expires_at <= ?", userID, asOf))
+		if err != nil {
+			return err
+		}
+
+		membership := UserMembershipGormToEntity(&gormMembership)
+		transaction = membership.ExpirePoints(lots)
+		if err := savePointLots(tx, lots); err != nil {
+			return err
+		}
+		if transaction == nil {
+			return nil
+		}
+
+		if err := tx.Save(UserMembershipEntityToGorm(membership)).Error; err != nil {
+			return err
+		}
+
+		gormTransaction := PointTransactionEntityToGorm(transaction)
+		if err := tx.Create(gormTransaction).Error; err != nil {
+			return err
+		}
+		transaction.ID = gormTransaction.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func recordPointTransaction(tx *gorm.DB, transaction *entity.PointTransaction) (*entity.UserMembership, error) {
+	var gormMembership GormUserMembership
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", transaction.UserID).
+		First(&gormMembership).Error; err != nil {
+		return nil, err
+	}
+
+	membership := UserMembershipGormToEntity(&gormMembership)
+	if err := membership.ApplyPointTransaction(transaction); err != nil {
+		return nil, err
+	}
+
+	if transaction.Points < 0 {
+		lots, err := lockPointLots(tx.Where("user_id = ?", transaction.UserID))
+		if err != nil {
+			return nil, err
+		}
+		if err := savePointLots(tx, entity.ConsumePointLots(lots, -transaction.Points)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Save(UserMembershipEntityToGorm(membership)).Error; err != nil {
+		return nil, err
+	}
+
+	gormTransaction := PointTransactionEntityToGorm(transaction)
+	if err := tx.Create(gormTransaction).Error; err != nil {
+		return nil, err
+	}
+	transaction.ID = gormTransaction.ID
+	return membership, nil
+}
+
+func lockPointLots(query *gorm.DB) ([]*entity.PointTransaction, error) {
+	var gormLots []GormPointTransaction
+	if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("remaining_points > 0").
+		Order("created_at ASC, id ASC").
+		Find(&gormLots).Error; err != nil {
+		return nil, err
+	}
+
+	lots := make([]*entity.PointTransaction, len(gormLots))
+	for i, gormLot := range gormLots {
+		lots[i] = PointTransactionGormToEntity(&gormLot)
+	}
+	return lots, nil
+}
+
+func savePointLots(tx *gorm.DB, lots []*entity.PointTransaction) error {
+	for _, lot := range lots {
+		if err := tx.Model(&GormPointTransaction{}).
+			Where("id = ?", lot.ID).
+			Update("remaining_points", lot.RemainingPoints).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type purchaseRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseRepository(db *gorm.DB) repository.PurchaseRepository {
+	return &purchaseRepository{db: db}
+}
+
+func (r *purchaseRepository) GetByID(ctx context.Context, id string) (*entity.Purchase, error) {
+	var gormPurchase GormPurchase
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormPurchase).Error; err != nil {
+		return nil, err
+	}
+	return PurchaseGormToEntity(&gormPurchase), nil
+}
+
+func (r *purchaseRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error) {
+	var gormPurchases []GormPurchase
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&GormPurchase{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&gormPurchases).Error; err != nil {
+		return nil, 0, err
+	}
+
+	purchases := make([]*entity.Purchase, len(gormPurchases))
+	for i, gormPurchase := range gormPurchases {
+		purchases[i] = PurchaseGormToEntity(&gormPurchase)
+	}
+
+	return purchases, total, nil
+}
+
+func (r *purchaseRepository) Record(ctx context.Context, purchase *entity.Purchase, transaction *entity.PointTransaction) (*entity.UserMembership, error) {
+	var membership *entity.UserMembership
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormMembership GormUserMembership
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", purchase.UserID).
+			First(&gormMembership).Error; err != nil {
+			return err
+		}
+
+		membership = UserMembershipGormToEntity(&gormMembership)
+		if transaction != nil {
+			if err := membership.ApplyPointTransaction(transaction); err != nil {
+				return err
+			}
+		}
+		membership.UpdateTotalSpent(purchase.ChargedAmount())
+
+		if err := tx.Save(UserMembershipEntityToGorm(membership)).Error; err != nil {
+			return err
+		}
+
+		if transaction != nil {
+			gormTransaction := PointTransactionEntityToGorm(transaction)
+			if err := tx.Create(gormTransaction).Error; err != nil {
+				return err
+			}
+			transaction.ID = gormTransaction.ID
+			purchase.PointTransactionID = &gormTransaction.ID
+		}
+
+		return tx.Create(PurchaseEntityToGorm(purchase)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *purchaseRepository) Refund(ctx context.Context, purchaseID string) (*entity.Purchase, *entity.UserMembership, error) {
+	var purchase *entity.Purchase
+	var membership *entity.UserMembership
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gormPurchase GormPurchase
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", purchaseID).
+			First(&gormPurchase).Error; err != nil {
+			return err
+		}
+		purchase = PurchaseGormToEntity(&gormPurchase)
+
+		var gormMembership GormUserMembership
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", purchase.UserID).
+			First(&gormMembership).Error; err != nil {
+			return err
+		}
+		membership = UserMembershipGormToEntity(&gormMembership)
+
+		var lot *entity.PointTransaction
+		if purchase.PointTransactionID != nil {
+			var gormLot GormPointTransaction
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", *purchase.PointTransactionID).
+				First(&gormLot).Error; err != nil {
+				return err
+			}
+			lot = PointTransactionGormToEntity(&gormLot)
+		}
+
+		reversal, err := membership.RefundPurchase(purchase, lot)
+		if err != nil {
+			return err
+		}
+
+		if reversal != nil {
+			if err := savePointLots(tx, []*entity.PointTransaction{lot}); err != nil {
+				return err
+			}
+			if err := tx.Create(PointTransactionEntityToGorm(reversal)).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Save(UserMembershipEntityToGorm(membership)).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&GormPurchase{}).
+			Where("id = ?", purchase.ID).
+			Updates(map[string]interface{}{
+				"status":      purchase.Status,
+				"refunded_at": purchase.RefundedAt,
+				"updated_at":  purchase.UpdatedAt,
+			}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return purchase, membership, nil
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	gormNotification := NotificationEntityToGorm(notification)
+	if err := r.db.WithContext(ctx).Create(gormNotification).Error; err != nil {
+		return err
+	}
+	notification.ID = gormNotification.ID
+	return nil
+}
+
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error) {
+	var gormNotifications []GormNotification
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&GormNotification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&gormNotifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	notifications := make([]*entity.Notification, len(gormNotifications))
+	for i, gormNotification := range gormNotifications {
+		notifications[i] = NotificationGormToEntity(&gormNotification)
+	}
+
+	return notifications, total, nil
+}
+
+func (r *notificationRepository) GetByUserIDAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error) {
+	var gormNotifications []GormNotification
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND id > ?", userID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&gormNotifications).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*entity.Notification, len(gormNotifications))
+	for i, gormNotification := range gormNotifications {
+		notifications[i] = NotificationGormToEntity(&gormNotification)
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) GetByID(ctx context.Context, id uint) (*entity.Notification, error) {
+	var gormNotification GormNotification
+	if err := r.db.WithContext(ctx).First(&gormNotification, id).Error; err != nil {
+		return nil, err
+	}
+	return NotificationGormToEntity(&gormNotification), nil
+}
+
+func (r *notificationRepository) Update(ctx context.Context, notification *entity.Notification) error {
+	gormNotification := NotificationEntityToGorm(notification)
+	return r.db.WithContext(ctx).Save(gormNotification).Error
+}
+
+func (r *notificationRepository) Delete(ctx context.Context, userID, notificationID uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Delete(&GormNotification{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *notificationRepository) MarkAsRead(ctx context.Context, userID, notificationID uint) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&GormNotification{}).
+		Where("id = ? AND user_id = ? AND is_read = ?", notificationID, userID, false).
+		Updates(map[string]interface{}{
+			"is_read":    true,
+			"read_at":    now,
+			"updated_at": now,
+		}).Error
+}
+
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&GormNotification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{
+			"is_read":    true,
+			"read_at":    now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&GormNotification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
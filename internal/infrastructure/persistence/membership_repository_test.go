@@ -60,6 +60,7 @@ func TestUserMembershipRepositoryCreate(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -154,6 +155,7 @@ func TestUserMembershipRepositoryUpdate(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -263,7 +265,7 @@ func TestUserMembershipRepositoryList(t *testing.T) {
 		)
 	}
 
-	mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE `user_memberships`.`deleted_at` IS NULL LIMIT \\?").
+	mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE `user_memberships`.`deleted_at` IS NULL ORDER BY id ASC LIMIT \\?").
 		WithArgs(limit).
 		WillReturnRows(rows)
 
@@ -439,3 +441,552 @@ func TestUserProfileRepositoryDelete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func membershipRows(points int) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows([]string{
+		"id", "user_id", "tier_id", "points", "total_spent",
+		"joined_at", "last_activity_at", "expires_at", "is_active",
+		"created_at", "updated_at", "deleted_at",
+	}).AddRow(1, 1, 1, points, 0.0, now, nil, nil, true, now, now, nil)
+}
+
+type lotRow struct {
+	id        uint
+	remaining int
+}
+
+func pointLotRows(lots ...lotRow) *sqlmock.Rows {
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "type", "points", "remaining_points", "balance_after", "description",
+		"reference_type", "reference_id", "expires_at", "created_at", "deleted_at",
+	})
+	for _, lot := range lots {
+		rows.AddRow(lot.id, 1, entity.PointTransactionTypeEarn, 100, lot.remaining, 100, "", "", nil, now.Add(-time.Hour), now.AddDate(-1, 0, 0), nil)
+	}
+	return rows
+}
+
+func TestPointTransactionRepositoryRecord(t *testing.T) {
+	t.Run("会員残高の更新と取引の登録を同一トランザクションで行う", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPointTransactionRepository(gormDB)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeSpend, -30, "使用")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\? AND `user_memberships`.`deleted_at` IS NULL ORDER BY `user_memberships`.`id` LIMIT \\? FOR UPDATE").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(100))
+		mock.ExpectQuery("SELECT \\* FROM `point_transactions` WHERE user_id = \\? AND remaining_points > 0 AND `point_transactions`.`deleted_at` IS NULL ORDER BY created_at ASC, id ASC FOR UPDATE").
+			WithArgs(uint(1)).
+			WillReturnRows(pointLotRows(lotRow{id: 10, remaining: 20}, lotRow{id: 11, remaining: 60}))
+		mock.ExpectExec("UPDATE `point_transactions` SET `remaining_points`=\\? WHERE id = \\?").
+			WithArgs(0, uint(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `point_transactions` SET `remaining_points`=\\? WHERE id = \\?").
+			WithArgs(50, uint(11)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `user_memberships`").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `point_transactions`").
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		membership, err := repo.Record(context.Background(), transaction)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 70, membership.Points)
+		assert.Equal(t, 70, transaction.BalanceAfter)
+		assert.Equal(t, uint(5), transaction.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("残高不足の場合はロールバックする", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPointTransactionRepository(gormDB)
+		transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeSpend, -300, "使用")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships`").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(100))
+		mock.ExpectRollback()
+
+		membership, err := repo.Record(context.Background(), transaction)
+
+		assert.ErrorIs(t, err, entity.ErrInsufficientPoints)
+		assert.Nil(t, membership)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPointTransactionRepositoryGetByUserID(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPointTransactionRepository(gormDB)
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	filter := &entity.PointTransactionFilter{Type: entity.PointTransactionTypeEarn, From: &from}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `point_transactions` WHERE user_id = \\? AND type = \\? AND created_at >= \\?").
+		WithArgs(uint(1), entity.PointTransactionTypeEarn, from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT \\* FROM `point_transactions` WHERE user_id = \\? AND type = \\? AND created_at >= \\? .* ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs(uint(1), entity.PointTransactionTypeEarn, from, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "type", "points", "remaining_points", "balance_after", "description",
+			"reference_type", "reference_id", "expires_at", "created_at", "deleted_at",
+		}).AddRow(3, 1, entity.PointTransactionTypeEarn, 100, 100, 600, "獲得", "", nil, nil, now, nil))
+
+	transactions, total, err := repo.GetByUserID(context.Background(), 1, filter, 10, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, 600, transactions[0].BalanceAfter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPointTransactionRepositoryGetSummary(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPointTransactionRepository(gormDB)
+
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CASE WHEN points > 0 THEN points ELSE 0 END\\), 0\\) AS total_earned, .* FROM `point_transactions` WHERE user_id = \\?").
+		WithArgs(uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"total_earned", "total_spent"}).AddRow(1500, 400))
+	mock.ExpectQuery("SELECT `points` FROM `user_memberships` WHERE user_id = \\?").
+		WithArgs(uint(1), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"points"}).AddRow(1100))
+
+	summary, err := repo.GetSummary(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1100, summary.Balance)
+	assert.Equal(t, 1500, summary.TotalEarned)
+	assert.Equal(t, 400, summary.TotalSpent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPointTransactionRepositoryExpirePoints(t *testing.T) {
+	t.Run("期限切れロットを失効させて取引を記録する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPointTransactionRepository(gormDB)
+		asOf := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\? .* FOR UPDATE").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(500))
+		mock.ExpectQuery("SELECT \\* FROM `point_transactions` WHERE \\(user_id = \\? AND expires_at IS NOT NULL AND expires_at <= \\?\\) AND remaining_points > 0 .* FOR UPDATE").
+			WithArgs(uint(1), asOf).
+			WillReturnRows(pointLotRows(lotRow{id: 10, remaining: 70}))
+		mock.ExpectExec("UPDATE `point_transactions` SET `remaining_points`=\\? WHERE id = \\?").
+			WithArgs(0, uint(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `user_memberships`").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `point_transactions`").
+			WillReturnResult(sqlmock.NewResult(20, 1))
+		mock.ExpectCommit()
+
+		transaction, err := repo.ExpirePoints(context.Background(), 1, asOf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(20), transaction.ID)
+		assert.Equal(t, -70, transaction.Points)
+		assert.Equal(t, 430, transaction.BalanceAfter)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("期限切れロットがない場合は何もしない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPointTransactionRepository(gormDB)
+		asOf := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships`").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(500))
+		mock.ExpectQuery("SELECT \\* FROM `point_transactions`").
+			WithArgs(uint(1), asOf).
+			WillReturnRows(pointLotRows())
+		mock.ExpectCommit()
+
+		transaction, err := repo.ExpirePoints(context.Background(), 1, asOf)
+
+		assert.NoError(t, err)
+		assert.Nil(t, transaction)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPointTransactionRepositoryListExpiredLots(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPointTransactionRepository(gormDB)
+	asOf := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM `point_transactions` WHERE \\(remaining_points > 0 AND expires_at IS NOT NULL AND expires_at <= \\?\\) .* ORDER BY user_id ASC, created_at ASC, id ASC").
+		WithArgs(asOf).
+		WillReturnRows(pointLotRows(lotRow{id: 10, remaining: 70}, lotRow{id: 11, remaining: 5}))
+
+	lots, err := repo.ListExpiredLots(context.Background(), asOf)
+
+	assert.NoError(t, err)
+	assert.Len(t, lots, 2)
+	assert.Equal(t, 70, lots[0].RemainingPoints)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewNotificationRepository(gormDB)
+	notification := entity.NewNotification(1, "points_expired", "Points expired", "100 points have expired.", nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `notifications`").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), notification)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), notification.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryGetUnreadCount(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewNotificationRepository(gormDB)
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `notifications` WHERE \\(user_id = \\? AND is_read = \\?\\)").
+		WithArgs(uint(1), false).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.GetUnreadCount(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserMembershipRepositoryUpdateTier(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserMembershipRepository(gormDB)
+	membership := entity.NewUserMembership(1, 2)
+	membership.ID = 5
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_memberships` SET `tier_grace_ends_at`=\\?,`tier_id`=\\?,`updated_at`=\\? WHERE id = \\?").
+		WithArgs(nil, uint(2), sqlmock.AnyArg(), uint(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateTier(context.Background(), membership)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserMembershipRepositoryCountByTierID(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserMembershipRepository(gormDB)
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `user_memberships` WHERE tier_id = \\?").
+		WithArgs(uint(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	count, err := repo.CountByTierID(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMembershipTierRepositoryList(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewMembershipTierRepository(gormDB)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "level", "description", "benefits", "requirements", "is_active", "created_at", "updated_at"}).
+		AddRow(1, "Bronze", 1, "", "{}", `{"min_points":0}`, true, now, now).
+		AddRow(2, "Silver", 2, "", "{}", `{"min_points":1000}`, true, now, now)
+	mock.ExpectQuery("SELECT \\* FROM `membership_tiers` WHERE `membership_tiers`.`deleted_at` IS NULL ORDER BY level ASC, id ASC").
+		WillReturnRows(rows)
+
+	tiers, err := repo.List(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, tiers, 2)
+	assert.Equal(t, "Bronze", tiers[0].Name)
+	assert.Equal(t, 2, tiers[1].Level)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMembershipTierHistoryRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewMembershipTierHistoryRepository(gormDB)
+	history := entity.NewMembershipTierHistory(1, 1, 2, entity.TierChangeReasonPromotion)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `membership_tier_histories`").
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), history)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), history.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMembershipTierHistoryRepositoryRecord(t *testing.T) {
+	t.Run("会員のティア変更と履歴を同一トランザクションで書き込む", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewMembershipTierHistoryRepository(gormDB)
+		history := entity.NewMembershipTierHistory(1, 1, 2, entity.TierChangeReasonPromotion)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\? AND `user_memberships`.`deleted_at` IS NULL ORDER BY `user_memberships`.`id` LIMIT \\? FOR UPDATE").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(100))
+		mock.ExpectExec("UPDATE `user_memberships` SET `tier_grace_ends_at`=\\?,`tier_id`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(nil, uint(2), sqlmock.AnyArg(), uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `membership_tier_histories`").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		recorded, err := repo.Record(context.Background(), history)
+
+		assert.NoError(t, err)
+		assert.True(t, recorded)
+		assert.Equal(t, uint(3), history.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("既に別のティアへ移っていれば書き込まない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewMembershipTierHistoryRepository(gormDB)
+		history := entity.NewMembershipTierHistory(1, 2, 3, entity.TierChangeReasonPromotion)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\? .* FOR UPDATE").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(100))
+		mock.ExpectCommit()
+
+		recorded, err := repo.Record(context.Background(), history)
+
+		assert.NoError(t, err)
+		assert.False(t, recorded)
+		assert.Zero(t, history.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func purchaseRows(status string, pointTransactionID *uint) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows([]string{
+		"id", "user_id", "store_id", "total_amount", "points_earned", "tier_discount_applied",
+		"point_transaction_id", "status", "refunded_at", "created_at", "updated_at", "deleted_at",
+	}).AddRow("order-1", 1, 10, 10000.0, 100, 0.0, pointTransactionID, status, nil, now, now, nil)
+}
+
+func TestPurchaseRepositoryRecord(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPurchaseRepository(gormDB)
+	purchase := entity.NewPurchase("order-1", 1, 10, 10000)
+	purchase.PointsEarned = 100
+	transaction := entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "Purchase: order-1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `user_memberships` WHERE user_id = \\? AND `user_memberships`.`deleted_at` IS NULL ORDER BY `user_memberships`.`id` LIMIT \\? FOR UPDATE").
+		WithArgs(uint(1), 1).
+		WillReturnRows(membershipRows(50))
+	mock.ExpectExec("UPDATE `user_memberships`").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `point_transactions`").
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec("INSERT INTO `purchases`").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	membership, err := repo.Record(context.Background(), purchase, transaction)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 150, membership.Points)
+	assert.Equal(t, float64(10000), membership.TotalSpent)
+	assert.Equal(t, 100, transaction.RemainingPoints)
+	require.NotNil(t, purchase.PointTransactionID)
+	assert.Equal(t, uint(8), *purchase.PointTransactionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurchaseRepositoryRefund(t *testing.T) {
+	t.Run("獲得ポイントを取り消して返金済みにする", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPurchaseRepository(gormDB)
+		lotID := uint(10)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `purchases` WHERE id = \\? AND `purchases`.`deleted_at` IS NULL ORDER BY `purchases`.`id` LIMIT \\? FOR UPDATE").
+			WithArgs("order-1", 1).
+			WillReturnRows(purchaseRows(entity.PurchaseStatusCompleted, &lotID))
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships`").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(150))
+		mock.ExpectQuery("SELECT \\* FROM `point_transactions` WHERE id = \\?").
+			WithArgs(lotID, 1).
+			WillReturnRows(pointLotRows(lotRow{id: lotID, remaining: 100}))
+		mock.ExpectExec("UPDATE `point_transactions` SET `remaining_points`=\\? WHERE id = \\?").
+			WithArgs(0, lotID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `point_transactions`").
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec("UPDATE `user_memberships`").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE `purchases` SET `refunded_at`=\\?,`status`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(sqlmock.AnyArg(), entity.PurchaseStatusRefunded, sqlmock.AnyArg(), "order-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		purchase, membership, err := repo.Refund(context.Background(), "order-1")
+
+		assert.NoError(t, err)
+		assert.True(t, purchase.IsRefunded())
+		assert.Equal(t, 50, membership.Points)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("獲得ポイントが使用済みの場合はロールバックする", func(t *testing.T) {
+		gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPurchaseRepository(gormDB)
+		lotID := uint(10)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `purchases`").
+			WithArgs("order-1", 1).
+			WillReturnRows(purchaseRows(entity.PurchaseStatusCompleted, &lotID))
+		mock.ExpectQuery("SELECT \\* FROM `user_memberships`").
+			WithArgs(uint(1), 1).
+			WillReturnRows(membershipRows(150))
+		mock.ExpectQuery("SELECT \\* FROM `point_transactions`").
+			WithArgs(lotID, 1).
+			WillReturnRows(pointLotRows(lotRow{id: lotID, remaining: 30}))
+		mock.ExpectRollback()
+
+		_, _, err := repo.Refund(context.Background(), "order-1")
+
+		assert.ErrorIs(t, err, entity.ErrPurchasePointsSpent)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNotificationRepositoryDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expected     bool
+	}{
+		{name: "自分の通知を削除", rowsAffected: 1, expected: true},
+		{name: "他ユーザーの通知は削除されない", rowsAffected: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+			defer cleanup()
+
+			repo := persistence.NewNotificationRepository(gormDB)
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `notifications` SET `deleted_at`=\\? WHERE \\(id = \\? AND user_id = \\?\\) AND `notifications`.`deleted_at` IS NULL").
+				WithArgs(sqlmock.AnyArg(), uint(5), uint(1)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			deleted, err := repo.Delete(context.Background(), 1, 5)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, deleted)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationRepositoryMarkAllAsRead(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewNotificationRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `notifications` SET `is_read`=\\?,`read_at`=\\?,`updated_at`=\\? WHERE \\(user_id = \\? AND is_read = \\?\\)").
+		WithArgs(true, sqlmock.AnyArg(), sqlmock.AnyArg(), uint(1), false).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	updated, err := repo.MarkAllAsRead(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepositoryGetByUserIDAfter(t *testing.T) {
+	gormDB, mock, cleanup := setupMembershipRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewNotificationRepository(gormDB)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "message", "priority", "is_read"}).
+		AddRow(11, 1, "system", "タイトル1", "本文", "medium", false).
+		AddRow(12, 1, "system", "タイトル2", "本文", "high", false)
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE \\(user_id = \\? AND id > \\?\\) AND `notifications`.`deleted_at` IS NULL ORDER BY id ASC LIMIT \\?").
+		WithArgs(uint(1), uint(10), 100).
+		WillReturnRows(rows)
+
+	notifications, err := repo.GetByUserIDAfter(context.Background(), 1, 10, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, uint(11), notifications[0].ID)
+	assert.Equal(t, "high", notifications[1].Priority)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oauthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) repository.OAuthClientRepository {
+	return &oauthClientRepository{db: db}
+}
+
+func (r *oauthClientRepository) Create(ctx context.Context, client *entity.OAuthClient) error {
+	gormClient := OAuthClientEntityToGorm(client)
+	if err := r.db.WithContext(ctx).Create(gormClient).Error; err != nil {
+		return err
+	}
+	client.ID = gormClient.ID
+	return nil
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*entity.OAuthClient, error) {
+	var gormClient GormOAuthClient
+	if err := r.db.WithContext(ctx).Where("client_id = ?", clientID).First(&gormClient).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrOAuthClientNotFound
+		}
+		return nil, err
+	}
+	return OAuthClientGormToEntity(&gormClient), nil
+}
+
+func (r *oauthClientRepository) List(ctx context.Context) ([]*entity.OAuthClient, error) {
+	var gormClients []GormOAuthClient
+	if err := r.db.WithContext(ctx).Order("id").Find(&gormClients).Error; err != nil {
+		return nil, err
+	}
+
+	clients := make([]*entity.OAuthClient, len(gormClients))
+	for i := range gormClients {
+		clients[i] = OAuthClientGormToEntity(&gormClients[i])
+	}
+	return clients, nil
+}
+
+func (r *oauthClientRepository) Delete(ctx context.Context, clientID string) error {
+	result := r.db.WithContext(ctx).Where("client_id = ?", clientID).Delete(&GormOAuthClient{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrOAuthClientNotFound
+	}
+	return nil
+}
+
+type oauthAuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthAuthorizationCodeRepository(db *gorm.DB) repository.OAuthAuthorizationCodeRepository {
+	return &oauthAuthorizationCodeRepository{db: db}
+}
+
+func (r *oauthAuthorizationCodeRepository) Create(ctx context.Context, code *entity.OAuthAuthorizationCode) error {
+	gormCode := OAuthAuthorizationCodeEntityToGorm(code)
+	if err := r.db.WithContext(ctx).Create(gormCode).Error; err != nil {
+		return err
+	}
+	code.ID = gormCode.ID
+	return nil
+}
+
+func (r *oauthAuthorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*entity.OAuthAuthorizationCode, error) {
+	var gormCode GormOAuthAuthorizationCode
+	if err := r.db.WithContext(ctx).Where("code_hash = ?", codeHash).First(&gormCode).Error; err != nil {
+		return nil, err
+	}
+	return OAuthAuthorizationCodeGormToEntity(&gormCode), nil
+}
+
+func (r *oauthAuthorizationCodeRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&GormOAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *oauthAuthorizationCodeRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("expires_at < NOW()").Delete(&GormOAuthAuthorizationCode{}).Error
+}
+
+type oauthRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthRefreshTokenRepository(db *gorm.DB) repository.OAuthRefreshTokenRepository {
+	return &oauthRefreshTokenRepository{db: db}
+}
+
+func (r *oauthRefreshTokenRepository) Create(ctx context.Context, token *entity.OAuthRefreshToken) error {
+	gormToken := OAuthRefreshTokenEntityToGorm(token)
+	if err := r.db.WithContext(ctx).Create(gormToken).Error; err != nil {
+		return err
+	}
+	token.ID = gormToken.ID
+	return nil
+}
+
+func (r *oauthRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.OAuthRefreshToken, error) {
+	var gormToken GormOAuthRefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&gormToken).Error; err != nil {
+		return nil, err
+	}
+	return OAuthRefreshTokenGormToEntity(&gormToken), nil
+}
+
+func (r *oauthRefreshTokenRepository) MarkRotated(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&GormOAuthRefreshToken{}).
+		Where("id = ? AND is_revoked = ?", id, false).
+		Updates(map[string]interface{}{
+			"is_revoked": true,
+			"rotated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *oauthRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&GormOAuthRefreshToken{}).
+		Where("family_id = ?", familyID).
+		Update("is_revoked", true).Error
+}
+
+func (r *oauthRefreshTokenRepository) RevokeByClientAndUser(ctx context.Context, clientID string, userID uint) error {
+	return r.db.WithContext(ctx).Model(&GormOAuthRefreshToken{}).
+		Where("client_id = ? AND user_id = ?", clientID, userID).
+		Update("is_revoked", true).Error
+}
+
+func (r *oauthRefreshTokenRepository) RevokeByClient(ctx context.Context, clientID string) error {
+	return r.db.WithContext(ctx).Model(&GormOAuthRefreshToken{}).
+		Where("client_id = ?", clientID).
+		Update("is_revoked", true).Error
+}
+
+type oauthConsentRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthConsentRepository(db *gorm.DB) repository.OAuthConsentRepository {
+	return &oauthConsentRepository{db: db}
+}
+
+func (r *oauthConsentRepository) Get(ctx context.Context, userID uint, clientID string) (*entity.OAuthConsent, error) {
+	var gormConsent GormOAuthConsent
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND client_id = ?", userID, clientID).First(&gormConsent).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrOAuthConsentNotFound
+		}
+		return nil, err
+	}
+	return OAuthConsentGormToEntity(&gormConsent), nil
+}
+
+func (r *oauthConsentRepository) ListByUser(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	var gormConsents []GormOAuthConsent
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&gormConsents).Error; err != nil {
+		return nil, err
+	}
+
+	consents := make([]*entity.OAuthConsent, len(gormConsents))
+	for i := range gormConsents {
+		consents[i] = OAuthConsentGormToEntity(&gormConsents[i])
+	}
+	return consents, nil
+}
+
+func (r *oauthConsentRepository) Upsert(ctx context.Context, consent *entity.OAuthConsent) error {
+	gormConsent := OAuthConsentEntityToGorm(consent)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scope", "updated_at"}),
+	}).Create(gormConsent).Error; err != nil {
+		return err
+	}
+	consent.ID = gormConsent.ID
+	return nil
+}
+
+func (r *oauthConsentRepository) Delete(ctx context.Context, userID uint, clientID string) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND client_id = ?", userID, clientID).Delete(&GormOAuthConsent{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrOAuthConsentNotFound
+	}
+	return nil
+}
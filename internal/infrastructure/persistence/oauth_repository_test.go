@@ -0,0 +1,142 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthClientRepositoryGetByClientID(t *testing.T) {
+	t.Run("JSON列を展開する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewOAuthClientRepository(gormDB)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "client_id", "client_secret_hash", "name", "redirect_uris", "grant_types", "scopes", "confidential", "created_by", "created_at", "updated_at", "deleted_at",
+		}).AddRow(3, "oc_abc", "hash", "Shop App", `["https://shop.example.com/callback"]`, `["authorization_code","refresh_token"]`, `["profile"]`, true, 1, time.Now(), time.Now(), nil)
+		mock.ExpectQuery("SELECT \\* FROM `oauth_clients` WHERE client_id = \\? AND `oauth_clients`.`deleted_at` IS NULL").
+			WithArgs("oc_abc", 1).
+			WillReturnRows(rows)
+
+		client, err := repo.GetByClientID(context.Background(), "oc_abc")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://shop.example.com/callback"}, client.RedirectURIs)
+		assert.Equal(t, []string{"authorization_code", "refresh_token"}, client.GrantTypes)
+		assert.Equal(t, []string{"profile"}, client.Scopes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("存在しないクライアント", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewOAuthClientRepository(gormDB)
+
+		mock.ExpectQuery("SELECT \\* FROM `oauth_clients` WHERE client_id = \\?").
+			WithArgs("oc_unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		client, err := repo.GetByClientID(context.Background(), "oc_unknown")
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, entity.ErrOAuthClientNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOAuthAuthorizationCodeRepositoryMarkUsed(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expected     bool
+	}{
+		{name: "未使用のコード", rowsAffected: 1, expected: true},
+		{name: "使用済みのコード", rowsAffected: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+			defer cleanup()
+
+			repo := persistence.NewOAuthAuthorizationCodeRepository(gormDB)
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `oauth_authorization_codes` SET `used_at`=\\? WHERE id = \\? AND used_at IS NULL").
+				WithArgs(sqlmock.AnyArg(), uint(5)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			used, err := repo.MarkUsed(context.Background(), 5)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, used)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOAuthRefreshTokenRepositoryRevokeByClientAndUser(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewOAuthRefreshTokenRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `oauth_refresh_tokens` SET `is_revoked`=\\?,`updated_at`=\\? WHERE client_id = \\? AND user_id = \\?").
+		WithArgs(true, sqlmock.AnyArg(), "oc_abc", uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.RevokeByClientAndUser(context.Background(), "oc_abc", 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOAuthConsentRepositoryUpsert(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewOAuthConsentRepository(gormDB)
+	consent := entity.NewOAuthConsent(1, "oc_abc", []string{"profile", "points:read"})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `oauth_consents` .* ON DUPLICATE KEY UPDATE `scope`=VALUES\\(`scope`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+		WithArgs(uint(1), "oc_abc", "profile points:read", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	err := repo.Upsert(context.Background(), consent)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), consent.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOAuthConsentRepositoryDelete(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewOAuthConsentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `oauth_consents` WHERE user_id = \\? AND client_id = \\?").
+		WithArgs(uint(1), "oc_abc").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Delete(context.Background(), 1, "oc_abc")
+
+	assert.ErrorIs(t, err, entity.ErrOAuthConsentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
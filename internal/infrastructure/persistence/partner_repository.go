@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type partnerAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewPartnerAPIKeyRepository(db *gorm.DB) repository.PartnerAPIKeyRepository {
+	return &partnerAPIKeyRepository{db: db}
+}
+
+func (r *partnerAPIKeyRepository) Create(ctx context.Context, key *entity.PartnerAPIKey) error {
+	gormKey := PartnerAPIKeyEntityToGorm(key)
+	if err := r.db.WithContext(ctx).Create(gormKey).Error; err != nil {
+		return err
+	}
+	key.ID = gormKey.ID
+	return nil
+}
+
+func (r *partnerAPIKeyRepository) GetByID(ctx context.Context, id uint) (*entity.PartnerAPIKey, error) {
+	var gormKey GormPartnerAPIKey
+	if err := r.db.WithContext(ctx).First(&gormKey, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrPartnerAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return PartnerAPIKeyGormToEntity(&gormKey), nil
+}
+
+func (r *partnerAPIKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entity.PartnerAPIKey, error) {
+	var gormKey GormPartnerAPIKey
+	if err := r.db.WithContext(ctx).Where("api_key = ?", keyHash).First(&gormKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrPartnerAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return PartnerAPIKeyGormToEntity(&gormKey), nil
+}
+
+func (r *partnerAPIKeyRepository) List(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error) {
+	query := r.db.WithContext(ctx).Order("id")
+	if partnerID != "" {
+		query = query.Where("partner_id = ?", partnerID)
+	}
+
+	var gormKeys []GormPartnerAPIKey
+	if err := query.Find(&gormKeys).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*entity.PartnerAPIKey, len(gormKeys))
+	for i := range gormKeys {
+		keys[i] = PartnerAPIKeyGormToEntity(&gormKeys[i])
+	}
+	return keys, nil
+}
+
+func (r *partnerAPIKeyRepository) Update(ctx context.Context, key *entity.PartnerAPIKey) error {
+	return r.db.WithContext(ctx).Save(PartnerAPIKeyEntityToGorm(key)).Error
+}
+
+type partnerQuotaRepository struct {
+	db *gorm.DB
+}
+
+func NewPartnerQuotaRepository(db *gorm.DB) repository.PartnerQuotaRepository {
+	return &partnerQuotaRepository{db: db}
+}
+
+func (r *partnerQuotaRepository) ListByPartner(ctx context.Context, partnerID string) ([]*entity.PartnerQuota, error) {
+	var gormQuotas []GormPartnerQuota
+	if err := r.db.WithContext(ctx).Where("partner_id = ?", partnerID).Order("id").Find(&gormQuotas).Error; err != nil {
+		return nil, err
+	}
+
+	quotas := make([]*entity.PartnerQuota, len(gormQuotas))
+	for i := range gormQuotas {
+		quotas[i] = PartnerQuotaGormToEntity(&gormQuotas[i])
+	}
+	return quotas, nil
+}
+
+func (r *partnerQuotaRepository) Upsert(ctx context.Context, quota *entity.PartnerQuota) error {
+	gormQuota := PartnerQuotaEntityToGorm(quota)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "partner_id"}, {Name: "endpoint"}},
+		DoUpdates: clause.AssignmentColumns([]string{"limit_per_hour", "limit_per_day", "updated_at"}),
+	}).Create(gormQuota).Error; err != nil {
+		return err
+	}
+	quota.ID = gormQuota.ID
+	return nil
+}
+
+func (r *partnerQuotaRepository) Consume(ctx context.Context, quotaID uint, now time.Time) (*entity.PartnerQuota, bool, error) {
+	hourReset, dayStart := entity.PartnerQuotaWindow(now)
+
+	// The counters and the limit check share one conditional UPDATE so
+	// concurrent requests cannot overshoot. MySQL applies assignments left to
+	// right; GORM orders map keys alphabetically, which keeps reset_time after
+	// the two counters that still need to read its previous value.
+	result := r.db.WithContext(ctx).Model(&GormPartnerQuota{}).
+		Where("id = ?", quotaID).
+		Where("reset_time IS NULL OR reset_time <= ? OR current_hour_count < limit_per_hour", now).
+		Where("reset_time IS NULL OR reset_time <= ? OR current_day_count < limit_per_day", dayStart).
+		Updates(map[string]interface{}{
+			"current_day_count":  gorm.Expr("CASE WHEN reset_time IS NULL OR reset_time <= ? THEN 1 ELSE current_day_count + 1 END", dayStart),
+			"current_hour_count": gorm.Expr("CASE WHEN reset_time IS NULL OR reset_time <= ? THEN 1 ELSE current_hour_count + 1 END", now),
+			"reset_time":         hourReset,
+		})
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	var gormQuota GormPartnerQuota
+	if err := r.db.WithContext(ctx).First(&gormQuota, quotaID).Error; err != nil {
+		return nil, false, err
+	}
+	return PartnerQuotaGormToEntity(&gormQuota), result.RowsAffected > 0, nil
+}
@@ -0,0 +1,153 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func partnerQuotaRows(hourCount, dayCount int, resetTime time.Time) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "partner_id", "endpoint", "limit_per_hour", "limit_per_day",
+		"current_hour_count", "current_day_count", "reset_time", "created_at", "updated_at",
+	}).AddRow(1, "PARTNER_001", "/api/v1/integration/*", 100, 1000, hourCount, dayCount, resetTime, time.Now(), time.Now())
+}
+
+func TestPartnerAPIKeyRepositoryCreate(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPartnerAPIKeyRepository(gormDB)
+	key, plaintext, err := entity.NewPartnerAPIKey("PARTNER_001", "POS", []string{"integration:read"}, 100)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `partner_api_keys`").
+		WithArgs("PARTNER_001", entity.HashPartnerAPIKey(plaintext), key.KeyPrefix, "POS", `["integration:read:*"]`, 100, true,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectCommit()
+
+	err = repo.Create(context.Background(), key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), key.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPartnerAPIKeyRepositoryGetByKeyHash(t *testing.T) {
+	t.Run("ハッシュで検索する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPartnerAPIKeyRepository(gormDB)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "partner_id", "api_key", "key_prefix", "partner_name", "permissions", "rate_limit", "enabled", "created_at", "updated_at", "deleted_at",
+		}).AddRow(4, "PARTNER_001", "hash", "pk_0123abcd", "POS", `["integration:read:*"]`, 100, true, time.Now(), time.Now(), nil)
+		mock.ExpectQuery("SELECT \\* FROM `partner_api_keys` WHERE api_key = \\?").
+			WithArgs("hash", 1).
+			WillReturnRows(rows)
+
+		key, err := repo.GetByKeyHash(context.Background(), "hash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pk_0123abcd", key.KeyPrefix)
+		assert.Equal(t, []string{"integration:read:*"}, key.Permissions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("存在しないキー", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPartnerAPIKeyRepository(gormDB)
+
+		mock.ExpectQuery("SELECT \\* FROM `partner_api_keys` WHERE api_key = \\?").
+			WithArgs("unknown", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		key, err := repo.GetByKeyHash(context.Background(), "unknown")
+
+		assert.Nil(t, key)
+		assert.ErrorIs(t, err, entity.ErrPartnerAPIKeyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPartnerQuotaRepositoryUpsert(t *testing.T) {
+	gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewPartnerQuotaRepository(gormDB)
+	quota, err := entity.NewPartnerQuota("PARTNER_001", entity.PartnerQuotaEndpointAll, 100, 1000)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `rate_limits` .* ON DUPLICATE KEY UPDATE `limit_per_hour`=VALUES\\(`limit_per_hour`\\),`limit_per_day`=VALUES\\(`limit_per_day`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	err = repo.Upsert(context.Background(), quota)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPartnerQuotaRepositoryConsume(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 30, 0, 0, time.Local)
+	hourReset, dayStart := entity.PartnerQuotaWindow(now)
+
+	t.Run("枠内なら時間・日次のカウンタを加算する", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPartnerQuotaRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `rate_limits` SET `current_day_count`=CASE .* END,`current_hour_count`=CASE .* END,`reset_time`=\\?,`updated_at`=\\? "+
+			"WHERE id = \\? AND \\(reset_time IS NULL OR reset_time <= \\? OR current_hour_count < limit_per_hour\\) "+
+			"AND \\(reset_time IS NULL OR reset_time <= \\? OR current_day_count < limit_per_day\\)").
+			WithArgs(dayStart, now, hourReset, sqlmock.AnyArg(), uint(1), now, dayStart).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery("SELECT \\* FROM `rate_limits` WHERE `rate_limits`.`id` = \\?").
+			WithArgs(uint(1), 1).
+			WillReturnRows(partnerQuotaRows(5, 50, hourReset))
+
+		quota, allowed, err := repo.Consume(context.Background(), 1, now)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		assert.Equal(t, 95, quota.RemainingHour(now))
+		assert.Equal(t, 950, quota.RemainingDay(now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("上限に達していれば加算しない", func(t *testing.T) {
+		gormDB, mock, cleanup := setupAdminRepositoryTest(t)
+		defer cleanup()
+
+		repo := persistence.NewPartnerQuotaRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `rate_limits`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		mock.ExpectQuery("SELECT \\* FROM `rate_limits`").
+			WillReturnRows(partnerQuotaRows(100, 300, hourReset))
+
+		quota, allowed, err := repo.Consume(context.Background(), 1, now)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, 0, quota.RemainingHour(now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,10 +2,12 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -28,6 +30,9 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 func (r *userRepository) GetByID(ctx context.Context, id uint) (*entity.User, error) {
 	var gormUser GormUser
 	if err := r.db.WithContext(ctx).First(&gormUser, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrUserNotFound
+		}
 		return nil, err
 	}
 	return UserGormToEntity(&gormUser), nil
@@ -75,3 +80,47 @@ func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool,
 	err := r.db.WithContext(ctx).Model(&GormUser{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+type userPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewUserPreferenceRepository(db *gorm.DB) repository.UserPreferenceRepository {
+	return &userPreferenceRepository{db: db}
+}
+
+func (r *userPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.UserPreference, error) {
+	var gormPreferences []GormUserPreference
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&gormPreferences).Error; err != nil {
+		return nil, err
+	}
+
+	preferences := make([]*entity.UserPreference, len(gormPreferences))
+	for i, gormPreference := range gormPreferences {
+		preferences[i] = UserPreferenceGormToEntity(&gormPreference)
+	}
+	return preferences, nil
+}
+
+func (r *userPreferenceRepository) Upsert(ctx context.Context, preferences []*entity.UserPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	gormPreferences := make([]*GormUserPreference, len(preferences))
+	for i, preference := range preferences {
+		gormPreferences[i] = UserPreferenceEntityToGorm(preference)
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"category", "value", "updated_at"})}).
+		Create(&gormPreferences).Error
+}
+
+func (r *userPreferenceRepository) DeleteByKeys(ctx context.Context, userID uint, keys []string) error {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if len(keys) > 0 {
+		query = query.Where("`key` IN ?", keys)
+	}
+	return query.Delete(&GormUserPreference{}).Error
+}
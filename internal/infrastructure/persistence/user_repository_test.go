@@ -2,6 +2,7 @@ package persistence_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"testing"
 	"time"
 
@@ -108,6 +109,23 @@ func TestUserRepositoryGetByID(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryGetByIDNotFound(t *testing.T) {
+	gormDB, mock, cleanup := setupUserRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserRepository(gormDB)
+
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT \\?").
+		WithArgs(uint(9), 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := repo.GetByID(context.Background(), 9)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepositoryGetByEmail(t *testing.T) {
 	gormDB, mock, cleanup := setupUserRepositoryTest(t)
 	defer cleanup()
@@ -303,3 +321,71 @@ func TestUserRepositoryExistsByEmail(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestUserPreferenceRepositoryUpsert(t *testing.T) {
+	gormDB, mock, cleanup := setupUserRepositoryTest(t)
+	defer cleanup()
+
+	repo := persistence.NewUserPreferenceRepository(gormDB)
+	theme, _ := entity.LookupPreferenceDefinition("theme")
+	push, _ := entity.LookupPreferenceDefinition("notifications_push")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `user_preferences` \\(`user_id`,`category`,`key`,`value`,`created_at`,`updated_at`\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?\\),\\(\\?,\\?,\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `category`=VALUES\\(`category`\\),`value`=VALUES\\(`value`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+		WithArgs(uint(1), "display", "theme", "dark", sqlmock.AnyArg(), sqlmock.AnyArg(),
+			uint(1), "notifications", "notifications_push", "true", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err := repo.Upsert(context.Background(), []*entity.UserPreference{
+		entity.NewUserPreference(1, theme, "dark"),
+		entity.NewUserPreference(1, push, "true"),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserPreferenceRepositoryDeleteByKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		query string
+		args  []interface{}
+	}{
+		{
+			name:  "指定したキーのみ削除",
+			keys:  []string{"theme", "language"},
+			query: "DELETE FROM `user_preferences` WHERE user_id = \\? AND `key` IN \\(\\?,\\?\\)",
+			args:  []interface{}{uint(1), "theme", "language"},
+		},
+		{
+			name:  "キー指定がなければ全て削除",
+			query: "DELETE FROM `user_preferences` WHERE user_id = \\?$",
+			args:  []interface{}{uint(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDB, mock, cleanup := setupUserRepositoryTest(t)
+			defer cleanup()
+
+			repo := persistence.NewUserPreferenceRepository(gormDB)
+
+			args := make([]driver.Value, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = arg
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.query).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+
+			err := repo.DeleteByKeys(context.Background(), 1, tt.keys)
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
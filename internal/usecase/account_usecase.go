@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type AccountUsecase struct {
+	accountDomainService service.AccountDomainServiceInterface
+	fraudDomainService   service.FraudDomainServiceInterface
+	dashboard            DashboardInvalidator
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func NewAccountUsecase(accountDomainService service.AccountDomainServiceInterface, fraudDomainService service.FraudDomainServiceInterface, dashboard DashboardInvalidator) *AccountUsecase {
+	return &AccountUsecase{
+		accountDomainService: accountDomainService,
+		fraudDomainService:   fraudDomainService,
+		dashboard:            dashboard,
+	}
+}
+
+func (u *AccountUsecase) RequestPasswordReset(ctx context.Context, req ForgotPasswordRequest, ipAddress, userAgent string) error {
+	userID, err := u.accountDomainService.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+
+	if userID != 0 {
+		_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "PASSWORD_RESET_REQUESTED",
+			"Password reset requested", ipAddress, userAgent, "LOW")
+	}
+
+	return nil
+}
+
+func (u *AccountUsecase) ResetPassword(ctx context.Context, req ResetPasswordRequest, ipAddress, userAgent string) error {
+	userID, err := u.accountDomainService.ResetPassword(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "PASSWORD_RESET",
+		"User reset password via email link", ipAddress, userAgent, "MEDIUM")
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+
+	return nil
+}
+
+func (u *AccountUsecase) RequestEmailVerification(ctx context.Context, userID uint) error {
+	return u.accountDomainService.RequestEmailVerification(ctx, userID)
+}
+
+func (u *AccountUsecase) VerifyEmail(ctx context.Context, req VerifyEmailRequest, ipAddress, userAgent string) error {
+	userID, err := u.accountDomainService.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "EMAIL_VERIFIED",
+		"User verified email address", ipAddress, userAgent, "LOW")
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+
+	return nil
+}
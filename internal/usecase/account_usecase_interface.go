@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+)
+
+type AccountUsecaseInterface interface {
+	RequestPasswordReset(ctx context.Context, req ForgotPasswordRequest, ipAddress, userAgent string) error
+	ResetPassword(ctx context.Context, req ResetPasswordRequest, ipAddress, userAgent string) error
+	RequestEmailVerification(ctx context.Context, userID uint) error
+	VerifyEmail(ctx context.Context, req VerifyEmailRequest, ipAddress, userAgent string) error
+}
@@ -0,0 +1,118 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountUsecaseRequestPasswordReset(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		setupMock func(*MockAccountDomainService, *MockFraudDomainService)
+		wantErr   error
+	}{
+		{
+			name:  "登録済みメールアドレスはセキュリティイベントを記録",
+			email: "test@example.com",
+			setupMock: func(accountService *MockAccountDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				accountService.On("RequestPasswordReset", ctx, "test@example.com").Return(uint(1), nil)
+				fraudService.On("CreateSecurityEvent", ctx, &[]uint{1}[0], "PASSWORD_RESET_REQUESTED", "Password reset requested", "192.168.1.1", "test-agent", "LOW").Return(nil)
+			},
+		},
+		{
+			name:  "未登録メールアドレスは何も記録しない",
+			email: "unknown@example.com",
+			setupMock: func(accountService *MockAccountDomainService, fraudService *MockFraudDomainService) {
+				accountService.On("RequestPasswordReset", context.Background(), "unknown@example.com").Return(uint(0), nil)
+			},
+		},
+		{
+			name:  "リクエスト回数超過",
+			email: "test@example.com",
+			setupMock: func(accountService *MockAccountDomainService, fraudService *MockFraudDomainService) {
+				accountService.On("RequestPasswordReset", context.Background(), "test@example.com").Return(uint(0), service.ErrTooManyRequests)
+			},
+			wantErr: service.ErrTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountService := new(MockAccountDomainService)
+			fraudService := new(MockFraudDomainService)
+			tt.setupMock(accountService, fraudService)
+
+			uc := usecase.NewAccountUsecase(accountService, fraudService, nil)
+
+			err := uc.RequestPasswordReset(context.Background(), usecase.ForgotPasswordRequest{Email: tt.email}, "192.168.1.1", "test-agent")
+
+			assert.Equal(t, tt.wantErr, err)
+			accountService.AssertExpectations(t)
+			fraudService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountUsecaseResetPassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*MockAccountDomainService, *MockFraudDomainService)
+		wantErr   error
+	}{
+		{
+			name: "正常なパスワード再設定",
+			setupMock: func(accountService *MockAccountDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				accountService.On("ResetPassword", ctx, "token", "newpassword123").Return(uint(1), nil)
+				fraudService.On("CreateSecurityEvent", ctx, &[]uint{1}[0], "PASSWORD_RESET", "User reset password via email link", "192.168.1.1", "test-agent", "MEDIUM").Return(nil)
+			},
+		},
+		{
+			name: "無効なトークン",
+			setupMock: func(accountService *MockAccountDomainService, fraudService *MockFraudDomainService) {
+				accountService.On("ResetPassword", context.Background(), "token", "newpassword123").Return(uint(0), service.ErrInvalidToken)
+			},
+			wantErr: service.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountService := new(MockAccountDomainService)
+			fraudService := new(MockFraudDomainService)
+			tt.setupMock(accountService, fraudService)
+
+			uc := usecase.NewAccountUsecase(accountService, fraudService, nil)
+
+			req := usecase.ResetPasswordRequest{Token: "token", NewPassword: "newpassword123"}
+			err := uc.ResetPassword(context.Background(), req, "192.168.1.1", "test-agent")
+
+			assert.Equal(t, tt.wantErr, err)
+			accountService.AssertExpectations(t)
+			fraudService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountUsecaseVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	accountService := new(MockAccountDomainService)
+	fraudService := new(MockFraudDomainService)
+
+	accountService.On("VerifyEmail", ctx, "token").Return(uint(1), nil)
+	fraudService.On("CreateSecurityEvent", ctx, &[]uint{1}[0], "EMAIL_VERIFIED", "User verified email address", "192.168.1.1", "test-agent", "LOW").Return(nil)
+
+	uc := usecase.NewAccountUsecase(accountService, fraudService, nil)
+
+	err := uc.VerifyEmail(ctx, usecase.VerifyEmailRequest{Token: "token"}, "192.168.1.1", "test-agent")
+
+	assert.NoError(t, err)
+	accountService.AssertExpectations(t)
+	fraudService.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const (
+	AdminPointTypeGrant  = "admin_grant"
+	AdminPointTypeDeduct = "admin_deduct"
+
+	maxAdminPointsPerTransaction = 10000
+	maxIdempotencyKeyLength      = 255
+)
+
+type AdminPointUsecase struct {
+	adminPointDomainService service.AdminPointDomainServiceInterface
+}
+
+func NewAdminPointUsecase(adminPointDomainService service.AdminPointDomainServiceInterface) *AdminPointUsecase {
+	return &AdminPointUsecase{
+		adminPointDomainService: adminPointDomainService,
+	}
+}
+
+func (u *AdminPointUsecase) AdjustUserPoints(ctx context.Context, adminUserID, userID uint, req *dto.AdminPointAdjustmentRequest, idempotencyKey string) (*service.AdminPointAdjustment, error) {
+	if idempotencyKey == "" || len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("invalid idempotency key: must be 1-%d characters", maxIdempotencyKeyLength)
+	}
+	if req.Points <= 0 || req.Points > maxAdminPointsPerTransaction {
+		return nil, fmt.Errorf("invalid points: must be between 1 and %d", maxAdminPointsPerTransaction)
+	}
+
+	var (
+		adjustment *service.AdminPointAdjustment
+		err        error
+	)
+	switch req.Type {
+	case "", AdminPointTypeGrant:
+		adjustment, err = u.adminPointDomainService.GrantPoints(ctx, adminUserID, userID, req.Points, req.Description, idempotencyKey)
+	case AdminPointTypeDeduct:
+		adjustment, err = u.adminPointDomainService.DeductPoints(ctx, adminUserID, userID, req.Points, req.Description, idempotencyKey)
+	default:
+		return nil, fmt.Errorf("invalid type: %s", req.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust points: %w", err)
+	}
+	return adjustment, nil
+}
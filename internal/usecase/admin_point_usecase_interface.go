@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type AdminPointUsecaseInterface interface {
+	AdjustUserPoints(ctx context.Context, adminUserID, userID uint, req *dto.AdminPointAdjustmentRequest, idempotencyKey string) (*service.AdminPointAdjustment, error)
+}
@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminPointUsecaseAdjustUserPoints(t *testing.T) {
+	adjustment := &service.AdminPointAdjustment{
+		Action:      entity.NewAdminPointAction(9, 1, 100, "補填", "key-1"),
+		Transaction: entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "補填"),
+	}
+
+	tests := []struct {
+		name           string
+		req            dto.AdminPointAdjustmentRequest
+		idempotencyKey string
+		setupMock      func(*MockAdminPointDomainService)
+		expectedError  string
+	}{
+		{
+			name:           "種別未指定の場合は付与",
+			req:            dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填"},
+			idempotencyKey: "key-1",
+			setupMock: func(m *MockAdminPointDomainService) {
+				m.On("GrantPoints", mock.Anything, uint(9), uint(1), 100, "補填", "key-1").Return(adjustment, nil)
+			},
+		},
+		{
+			name:           "減算",
+			req:            dto.AdminPointAdjustmentRequest{Points: 50, Description: "訂正", Type: usecase.AdminPointTypeDeduct},
+			idempotencyKey: "key-2",
+			setupMock: func(m *MockAdminPointDomainService) {
+				m.On("DeductPoints", mock.Anything, uint(9), uint(1), 50, "訂正", "key-2").Return(adjustment, nil)
+			},
+		},
+		{
+			name:           "冪等キーが空",
+			req:            dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填"},
+			idempotencyKey: "",
+			setupMock:      func(m *MockAdminPointDomainService) {},
+			expectedError:  "invalid idempotency key",
+		},
+		{
+			name:           "冪等キーが長すぎる",
+			req:            dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填"},
+			idempotencyKey: strings.Repeat("k", 256),
+			setupMock:      func(m *MockAdminPointDomainService) {},
+			expectedError:  "invalid idempotency key",
+		},
+		{
+			name:           "1回あたりの上限を超える",
+			req:            dto.AdminPointAdjustmentRequest{Points: 10001, Description: "補填"},
+			idempotencyKey: "key-3",
+			setupMock:      func(m *MockAdminPointDomainService) {},
+			expectedError:  "invalid points",
+		},
+		{
+			name:           "不明な種別",
+			req:            dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填", Type: "bonus"},
+			idempotencyKey: "key-4",
+			setupMock:      func(m *MockAdminPointDomainService) {},
+			expectedError:  "invalid type",
+		},
+		{
+			name:           "予算超過",
+			req:            dto.AdminPointAdjustmentRequest{Points: 100, Description: "補填"},
+			idempotencyKey: "key-5",
+			setupMock: func(m *MockAdminPointDomainService) {
+				m.On("GrantPoints", mock.Anything, uint(9), uint(1), 100, "補填", "key-5").Return(nil, entity.ErrAdminPointBudgetExceeded)
+			},
+			expectedError: "budget exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domainService := new(MockAdminPointDomainService)
+			tt.setupMock(domainService)
+
+			uc := usecase.NewAdminPointUsecase(domainService)
+
+			result, err := uc.AdjustUserPoints(context.Background(), 9, 1, &tt.req, tt.idempotencyKey)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, adjustment, result)
+			}
+			domainService.AssertExpectations(t)
+		})
+	}
+}
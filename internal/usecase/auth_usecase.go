@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
@@ -21,6 +23,13 @@ type LoginResponse struct {
 	RefreshToken string       `json:"refresh_token"`
 	ExpiresIn    int64        `json:"expires_in"`
 	User         *entity.User `json:"user"`
+	MFARequired  bool         `json:"mfa_required,omitempty"`
+	MFAToken     string       `json:"mfa_token,omitempty"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
 }
 
 type RegisterRequest struct {
@@ -44,6 +53,20 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=6"`
 }
 
+type VerifyTwoFactorRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
 func NewAuthUsecase(authDomainService service.AuthDomainServiceInterface, fraudDomainService service.FraudDomainServiceInterface, cacheService *external.CacheService) *AuthUsecase {
 	return &AuthUsecase{
 		authDomainService:  authDomainService,
@@ -80,7 +103,7 @@ func (u *AuthUsecase) Register(ctx context.Context, req RegisterRequest, ipAddre
 		return &LoginResponse{User: user}, nil
 	}
 
-	accessToken, err := u.authDomainService.GenerateAccessToken(auth.UserID, auth.Email, roles)
+	accessToken, err := u.authDomainService.GenerateAccessToken(ctx, auth.UserID, auth.Email, roles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -117,12 +140,27 @@ func (u *AuthUsecase) Login(ctx context.Context, req LoginRequest, ipAddress, us
 		return nil, err
 	}
 
+	if auth.TwoFactorEnabled {
+		mfaToken, err := u.authDomainService.GenerateMFAToken(auth.UserID, auth.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate MFA token: %w", err)
+		}
+
+		return &LoginResponse{
+			ExpiresIn:   int64(service.MFATokenTTL.Seconds()),
+			User:        &entity.User{ID: auth.UserID, Email: auth.Email},
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
 	_ = u.fraudDomainService.RecordLoginAttempt(ctx, req.Email, ipAddress, userAgent, true, "")
 
 	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &auth.UserID, "LOGIN",
 		"User logged in successfully", ipAddress, userAgent, "LOW")
+	u.invalidateDashboard(ctx, auth.UserID)
 
-	accessToken, err := u.authDomainService.GenerateAccessToken(auth.UserID, auth.Email, roles)
+	accessToken, err := u.authDomainService.GenerateAccessToken(ctx, auth.UserID, auth.Email, roles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -145,13 +183,104 @@ func (u *AuthUsecase) Login(ctx context.Context, req LoginRequest, ipAddress, us
 	}, nil
 }
 
-func (u *AuthUsecase) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*LoginResponse, error) {
+func (u *AuthUsecase) VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest, ipAddress, userAgent string) (*LoginResponse, error) {
+	claims, err := u.authDomainService.ValidateMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, roles, err := u.authDomainService.CompleteTwoFactorLogin(ctx, claims, req.Code)
+	if err != nil {
+		_ = u.fraudDomainService.RecordLoginAttempt(ctx, claims.Email, ipAddress, userAgent, false, err.Error())
+		_ = u.fraudDomainService.CreateSecurityEvent(ctx, &claims.UserID, "TWO_FACTOR_FAILED",
+			"Two-factor verification failed", ipAddress, userAgent, "MEDIUM")
+		return nil, err
+	}
+
+	_ = u.fraudDomainService.RecordLoginAttempt(ctx, auth.Email, ipAddress, userAgent, true, "")
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &auth.UserID, "LOGIN",
+		"User logged in with two-factor authentication", ipAddress, userAgent, "LOW")
+	u.invalidateDashboard(ctx, auth.UserID)
+
+	accessToken, err := u.authDomainService.GenerateAccessToken(ctx, auth.UserID, auth.Email, roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := u.authDomainService.GenerateRefreshToken(ctx, auth.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    3600,
+		User:         &entity.User{ID: auth.UserID, Email: auth.Email},
+	}, nil
+}
+
+func (u *AuthUsecase) SetupTwoFactor(ctx context.Context, userID uint) (*TwoFactorSetupResponse, error) {
+	secret, uri, err := u.authDomainService.SetupTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: uri,
+	}, nil
+}
+
+func (u *AuthUsecase) EnableTwoFactor(ctx context.Context, userID uint, req TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error) {
+	recoveryCodes, err := u.authDomainService.EnableTwoFactor(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "TWO_FACTOR_ENABLED",
+		"User enabled two-factor authentication", ipAddress, userAgent, "MEDIUM")
+	u.invalidateDashboard(ctx, userID)
+
+	return recoveryCodes, nil
+}
+
+func (u *AuthUsecase) DisableTwoFactor(ctx context.Context, userID uint, req DisableTwoFactorRequest, ipAddress, userAgent string) error {
+	if err := u.authDomainService.DisableTwoFactor(ctx, userID, req.Password, req.Code); err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "TWO_FACTOR_DISABLED",
+		"User disabled two-factor authentication", ipAddress, userAgent, "HIGH")
+	u.invalidateDashboard(ctx, userID)
+
+	return nil
+}
+
+func (u *AuthUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, req TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error) {
+	recoveryCodes, err := u.authDomainService.RegenerateRecoveryCodes(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "RECOVERY_CODES_REGENERATED",
+		"User regenerated two-factor recovery codes", ipAddress, userAgent, "MEDIUM")
+
+	return recoveryCodes, nil
+}
+
+func (u *AuthUsecase) RefreshToken(ctx context.Context, req RefreshTokenRequest, ipAddress, userAgent string) (*LoginResponse, error) {
 	auth, roles, newRefreshToken, err := u.authDomainService.RefreshToken(ctx, req.RefreshToken)
 	if err != nil {
+		var reuseErr *service.RefreshTokenReuseError
+		if errors.As(err, &reuseErr) {
+			u.containRefreshTokenReuse(ctx, reuseErr.UserID, ipAddress, userAgent)
+		}
 		return nil, err
 	}
 
-	accessToken, err := u.authDomainService.GenerateAccessToken(auth.UserID, auth.Email, roles)
+	accessToken, err := u.authDomainService.GenerateAccessToken(ctx, auth.UserID, auth.Email, roles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -169,6 +298,23 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, req RefreshTokenRequest)
 	}, nil
 }
 
+// containRefreshTokenReuse also revokes access tokens already issued from the
+// compromised family, since revoking the family only stops further refreshes.
+func (u *AuthUsecase) containRefreshTokenReuse(ctx context.Context, userID uint, ipAddress, userAgent string) {
+	if u.cacheService != nil {
+		if _, err := u.cacheService.IncrementTokenVersion(ctx, userID); err != nil {
+			log.Printf("Failed to revoke access tokens for user %d after refresh token reuse: %v", userID, err)
+		}
+	}
+	if err := u.fraudDomainService.DeactivateUserSessions(ctx, userID); err != nil {
+		log.Printf("Failed to deactivate sessions for user %d after refresh token reuse: %v", userID, err)
+	}
+	if err := u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "REFRESH_TOKEN_REUSE",
+		"Rotated refresh token was reused; token family revoked", ipAddress, userAgent, "HIGH"); err != nil {
+		log.Printf("Failed to record refresh token reuse for user %d: %v", userID, err)
+	}
+}
+
 func (u *AuthUsecase) ChangePassword(ctx context.Context, userID uint, req ChangePasswordRequest, ipAddress, userAgent string) error {
 	err := u.authDomainService.ChangePassword(ctx, userID, req.CurrentPassword, req.NewPassword)
 	if err != nil {
@@ -177,6 +323,7 @@ func (u *AuthUsecase) ChangePassword(ctx context.Context, userID uint, req Chang
 
 	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "PASSWORD_CHANGE",
 		"User changed password", ipAddress, userAgent, "MEDIUM")
+	u.invalidateDashboard(ctx, userID)
 
 	return nil
 }
@@ -200,6 +347,66 @@ func (u *AuthUsecase) Logout(ctx context.Context, userID uint, token, sessionID,
 	return nil
 }
 
-func (u *AuthUsecase) ValidateToken(tokenString string) (*service.JWTClaims, error) {
-	return u.authDomainService.ValidateToken(tokenString)
+func (u *AuthUsecase) LogoutAll(ctx context.Context, userID uint, ipAddress, userAgent string) error {
+	if err := u.authDomainService.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.DeactivateUserSessions(ctx, userID)
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "LOGOUT_ALL",
+		"User logged out from all sessions", ipAddress, userAgent, "MEDIUM")
+
+	return nil
+}
+
+func (u *AuthUsecase) SuspendUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error {
+	if adminUserID == userID {
+		return fmt.Errorf("invalid request: cannot suspend yourself")
+	}
+
+	if err := u.authDomainService.SuspendUser(ctx, userID); err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.DeactivateUserSessions(ctx, userID)
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "USER_SUSPENDED",
+		fmt.Sprintf("User suspended by admin %d", adminUserID), ipAddress, userAgent, "HIGH")
+	u.invalidateDashboard(ctx, userID)
+
+	return nil
+}
+
+func (u *AuthUsecase) ReactivateUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error {
+	if err := u.authDomainService.ReactivateUser(ctx, userID); err != nil {
+		return err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "USER_REACTIVATED",
+		fmt.Sprintf("User reactivated by admin %d", adminUserID), ipAddress, userAgent, "MEDIUM")
+	u.invalidateDashboard(ctx, userID)
+
+	return nil
+}
+
+func (u *AuthUsecase) invalidateDashboard(ctx context.Context, userID uint) {
+	if u.cacheService != nil {
+		_ = u.cacheService.DeleteUserDashboard(ctx, userID)
+	}
+}
+
+func (u *AuthUsecase) GetJWKS() (*service.JWKS, error) {
+	return u.authDomainService.JWKS()
+}
+
+func (u *AuthUsecase) ValidateToken(ctx context.Context, tokenString string) (*service.JWTClaims, error) {
+	claims, err := u.authDomainService.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authDomainService.CheckTokenVersion(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
 }
@@ -9,8 +9,17 @@ import (
 type AuthUsecaseInterface interface {
 	Register(ctx context.Context, req RegisterRequest, ipAddress, userAgent string) (*LoginResponse, error)
 	Login(ctx context.Context, req LoginRequest, ipAddress, userAgent string) (*LoginResponse, error)
-	RefreshToken(ctx context.Context, req RefreshTokenRequest) (*LoginResponse, error)
+	RefreshToken(ctx context.Context, req RefreshTokenRequest, ipAddress, userAgent string) (*LoginResponse, error)
 	ChangePassword(ctx context.Context, userID uint, req ChangePasswordRequest, ipAddress, userAgent string) error
 	Logout(ctx context.Context, userID uint, token, sessionID, ipAddress, userAgent string) error
-	ValidateToken(tokenString string) (*service.JWTClaims, error)
+	LogoutAll(ctx context.Context, userID uint, ipAddress, userAgent string) error
+	SuspendUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error
+	ReactivateUser(ctx context.Context, adminUserID, userID uint, ipAddress, userAgent string) error
+	ValidateToken(ctx context.Context, tokenString string) (*service.JWTClaims, error)
+	GetJWKS() (*service.JWKS, error)
+	VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest, ipAddress, userAgent string) (*LoginResponse, error)
+	SetupTwoFactor(ctx context.Context, userID uint) (*TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, userID uint, req TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uint, req DisableTwoFactorRequest, ipAddress, userAgent string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, req TwoFactorCodeRequest, ipAddress, userAgent string) ([]string, error)
 }
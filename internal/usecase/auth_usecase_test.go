@@ -2,11 +2,16 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/go-redis/redismock/v8"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthUsecaseRegister(t *testing.T) {
@@ -40,7 +45,7 @@ func TestAuthUsecaseRegister(t *testing.T) {
 				fraudService.On("RecordLoginAttempt", ctx, "test@example.com", "192.168.1.1", "test-agent", true, "").Return(nil)
 				fraudService.On("CreateSecurityEvent", ctx, &user.ID, "USER_REGISTRATION", "New user registered", "192.168.1.1", "test-agent", "LOW").Return(nil)
 				authService.On("Login", ctx, "test@example.com", "password123").Return(auth, roles, nil)
-				authService.On("GenerateAccessToken", uint(1), "test@example.com", roles).Return("access-token", nil)
+				authService.On("GenerateAccessToken", mock.Anything, uint(1), "test@example.com", roles).Return("access-token", nil)
 				authService.On("GenerateRefreshToken", ctx, uint(1)).Return("refresh-token", nil)
 			},
 			wantErr: false,
@@ -122,7 +127,7 @@ func TestAuthUsecaseLogin(t *testing.T) {
 				authService.On("Login", ctx, "test@example.com", "password123").Return(auth, roles, nil)
 				fraudService.On("RecordLoginAttempt", ctx, "test@example.com", "192.168.1.1", "test-agent", true, "").Return(nil)
 				fraudService.On("CreateSecurityEvent", ctx, &auth.UserID, "LOGIN", "User logged in successfully", "192.168.1.1", "test-agent", "LOW").Return(nil)
-				authService.On("GenerateAccessToken", uint(1), "test@example.com", roles).Return("access-token", nil)
+				authService.On("GenerateAccessToken", mock.Anything, uint(1), "test@example.com", roles).Return("access-token", nil)
 				authService.On("GenerateRefreshToken", ctx, uint(1)).Return("refresh-token", nil)
 			},
 			wantErr: false,
@@ -193,7 +198,7 @@ func TestAuthUsecaseRefreshToken(t *testing.T) {
 				newRefreshToken := "new-refresh-token"
 
 				authService.On("RefreshToken", ctx, "valid-refresh-token").Return(auth, roles, newRefreshToken, nil)
-				authService.On("GenerateAccessToken", uint(1), "test@example.com", roles).Return("new-access-token", nil)
+				authService.On("GenerateAccessToken", mock.Anything, uint(1), "test@example.com", roles).Return("new-access-token", nil)
 			},
 			wantErr: false,
 		},
@@ -208,6 +213,20 @@ func TestAuthUsecaseRefreshToken(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ローテーション済みトークンの再利用",
+			req: usecase.RefreshTokenRequest{
+				RefreshToken: "reused-refresh-token",
+			},
+			setupMock: func(authService *MockAuthDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				reuseErr := &service.RefreshTokenReuseError{UserID: 1, FamilyID: "family-1"}
+				authService.On("RefreshToken", ctx, "reused-refresh-token").Return((*entity.Auth)(nil), []string(nil), "", reuseErr)
+				fraudService.On("DeactivateUserSessions", ctx, uint(1)).Return(nil)
+				fraudService.On("CreateSecurityEvent", ctx, mock.AnythingOfType("*uint"), "REFRESH_TOKEN_REUSE", mock.AnythingOfType("string"), "192.168.1.1", "test-agent", "HIGH").Return(nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -219,7 +238,7 @@ func TestAuthUsecaseRefreshToken(t *testing.T) {
 			usecase := usecase.NewAuthUsecase(authService, fraudService, nil)
 
 			ctx := context.Background()
-			result, err := usecase.RefreshToken(ctx, tt.req)
+			result, err := usecase.RefreshToken(ctx, tt.req, "192.168.1.1", "test-agent")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -232,10 +251,34 @@ func TestAuthUsecaseRefreshToken(t *testing.T) {
 			}
 
 			authService.AssertExpectations(t)
+			fraudService.AssertExpectations(t)
 		})
 	}
 }
 
+func TestAuthUsecaseRefreshTokenReuseRevokesAccessTokens(t *testing.T) {
+	ctx := context.Background()
+	db, redisMock := redismock.NewClientMock()
+	defer func() { _ = db.Close() }()
+	redisMock.ExpectIncr("token_version:user:1").SetVal(2)
+
+	authService := new(MockAuthDomainService)
+	fraudService := new(MockFraudDomainService)
+	reuseErr := &service.RefreshTokenReuseError{UserID: 1, FamilyID: "family-1"}
+	authService.On("RefreshToken", ctx, "reused-refresh-token").Return((*entity.Auth)(nil), []string(nil), "", reuseErr)
+	fraudService.On("DeactivateUserSessions", ctx, uint(1)).Return(errors.New("database error"))
+	fraudService.On("CreateSecurityEvent", ctx, mock.AnythingOfType("*uint"), "REFRESH_TOKEN_REUSE", mock.AnythingOfType("string"), "192.168.1.1", "test-agent", "HIGH").Return(nil)
+
+	uc := usecase.NewAuthUsecase(authService, fraudService, external.NewCacheService(external.NewRedisClientFromClient(db)))
+	result, err := uc.RefreshToken(ctx, usecase.RefreshTokenRequest{RefreshToken: "reused-refresh-token"}, "192.168.1.1", "test-agent")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+	assert.NoError(t, redisMock.ExpectationsWereMet())
+	authService.AssertExpectations(t)
+	fraudService.AssertExpectations(t)
+}
+
 func TestAuthUsecaseChangePassword(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -362,3 +405,230 @@ func TestAuthUsecaseLogout(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthUsecaseLogoutAll(t *testing.T) {
+	ctx := context.Background()
+	authService := new(MockAuthDomainService)
+	fraudService := new(MockFraudDomainService)
+
+	authService.On("RevokeUserTokens", ctx, uint(1)).Return(nil)
+	fraudService.On("DeactivateUserSessions", ctx, uint(1)).Return(nil)
+	fraudService.On("CreateSecurityEvent", ctx, &[]uint{1}[0], "LOGOUT_ALL", "User logged out from all sessions", "192.168.1.1", "test-agent", "MEDIUM").Return(nil)
+
+	uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+	err := uc.LogoutAll(ctx, 1, "192.168.1.1", "test-agent")
+
+	assert.NoError(t, err)
+	authService.AssertExpectations(t)
+	fraudService.AssertExpectations(t)
+}
+
+func TestAuthUsecaseSuspendUser(t *testing.T) {
+	t.Run("ユーザーを停止する", func(t *testing.T) {
+		ctx := context.Background()
+		authService := new(MockAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+
+		authService.On("SuspendUser", ctx, uint(2)).Return(nil)
+		fraudService.On("DeactivateUserSessions", ctx, uint(2)).Return(nil)
+		fraudService.On("CreateSecurityEvent", ctx, &[]uint{2}[0], "USER_SUSPENDED", "User suspended by admin 1", "192.168.1.1", "test-agent", "HIGH").Return(nil)
+
+		uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+		err := uc.SuspendUser(ctx, 1, 2, "192.168.1.1", "test-agent")
+
+		assert.NoError(t, err)
+		authService.AssertExpectations(t)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("自分自身は停止できない", func(t *testing.T) {
+		authService := new(MockAuthDomainService)
+		uc := usecase.NewAuthUsecase(authService, new(MockFraudDomainService), nil)
+
+		err := uc.SuspendUser(context.Background(), 1, 1, "192.168.1.1", "test-agent")
+
+		assert.ErrorContains(t, err, "invalid")
+		authService.AssertNotCalled(t, "SuspendUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthUsecaseValidateToken(t *testing.T) {
+	claims := &service.JWTClaims{UserID: 1, Email: "test@example.com", TokenVersion: 0}
+
+	tests := []struct {
+		name         string
+		versionError error
+	}{
+		{name: "有効なトークン"},
+		{name: "失効したトークン", versionError: service.ErrTokenRevoked},
+		{name: "バージョンを取得できなければ拒否する", versionError: errors.New("redis unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			authService := new(MockAuthDomainService)
+			authService.On("ValidateToken", "token").Return(claims, nil)
+			authService.On("CheckTokenVersion", ctx, claims).Return(tt.versionError)
+
+			uc := usecase.NewAuthUsecase(authService, new(MockFraudDomainService), nil)
+			result, err := uc.ValidateToken(ctx, "token")
+
+			if tt.versionError != nil {
+				assert.ErrorIs(t, err, tt.versionError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, claims, result)
+			}
+		})
+	}
+}
+
+func TestAuthUsecaseLoginRequiresTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	authService := new(MockAuthDomainService)
+	fraudService := new(MockFraudDomainService)
+
+	auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+	auth.TwoFactorEnabled = true
+	fraudAnalysis := entity.NewFraudAnalysis(0.1, []string{"normal pattern"})
+
+	fraudService.On("AnalyzeFraud", ctx, (*uint)(nil), "test@example.com", "192.168.1.1", "test-agent").Return(fraudAnalysis, nil)
+	authService.On("Login", ctx, "test@example.com", "password123").Return(auth, nil, nil)
+	authService.On("GenerateMFAToken", uint(1), "test@example.com").Return("mfa-token", nil)
+
+	uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+
+	result, err := uc.Login(ctx, usecase.LoginRequest{Email: "test@example.com", Password: "password123"}, "192.168.1.1", "test-agent")
+
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Equal(t, "mfa-token", result.MFAToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+
+	authService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	authService.AssertExpectations(t)
+	fraudService.AssertExpectations(t)
+}
+
+func TestAuthUsecaseVerifyTwoFactor(t *testing.T) {
+	claims := &service.MFAClaims{UserID: 1, Email: "test@example.com"}
+
+	tests := []struct {
+		name      string
+		req       usecase.VerifyTwoFactorRequest
+		setupMock func(*MockAuthDomainService, *MockFraudDomainService)
+		wantErr   error
+	}{
+		{
+			name: "正しいコードでログイン完了",
+			req:  usecase.VerifyTwoFactorRequest{MFAToken: "mfa-token", Code: "123456"},
+			setupMock: func(authService *MockAuthDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				auth, _ := entity.NewAuth(1, "test@example.com", "password123")
+				roles := []string{"user"}
+
+				authService.On("ValidateMFAToken", ctx, "mfa-token").Return(claims, nil)
+				authService.On("CompleteTwoFactorLogin", ctx, claims, "123456").Return(auth, roles, nil)
+				fraudService.On("RecordLoginAttempt", ctx, "test@example.com", "192.168.1.1", "test-agent", true, "").Return(nil)
+				fraudService.On("CreateSecurityEvent", ctx, &auth.UserID, "LOGIN", "User logged in with two-factor authentication", "192.168.1.1", "test-agent", "LOW").Return(nil)
+				authService.On("GenerateAccessToken", mock.Anything, uint(1), "test@example.com", roles).Return("access-token", nil)
+				authService.On("GenerateRefreshToken", ctx, uint(1)).Return("refresh-token", nil)
+			},
+		},
+		{
+			name: "誤ったコードは失敗として記録",
+			req:  usecase.VerifyTwoFactorRequest{MFAToken: "mfa-token", Code: "000000"},
+			setupMock: func(authService *MockAuthDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				authService.On("ValidateMFAToken", ctx, "mfa-token").Return(claims, nil)
+				authService.On("CompleteTwoFactorLogin", ctx, claims, "000000").Return(nil, nil, entity.ErrInvalidTwoFactorCode)
+				fraudService.On("RecordLoginAttempt", ctx, "test@example.com", "192.168.1.1", "test-agent", false, "invalid two-factor code").Return(nil)
+				fraudService.On("CreateSecurityEvent", ctx, &claims.UserID, "TWO_FACTOR_FAILED", "Two-factor verification failed", "192.168.1.1", "test-agent", "MEDIUM").Return(nil)
+			},
+			wantErr: entity.ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "無効なMFAトークン",
+			req:  usecase.VerifyTwoFactorRequest{MFAToken: "bad-token", Code: "123456"},
+			setupMock: func(authService *MockAuthDomainService, fraudService *MockFraudDomainService) {
+				ctx := context.Background()
+				authService.On("ValidateMFAToken", ctx, "bad-token").Return(nil, service.ErrInvalidToken)
+			},
+			wantErr: service.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := new(MockAuthDomainService)
+			fraudService := new(MockFraudDomainService)
+			tt.setupMock(authService, fraudService)
+
+			uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+
+			result, err := uc.VerifyTwoFactor(context.Background(), tt.req, "192.168.1.1", "test-agent")
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access-token", result.AccessToken)
+				assert.Equal(t, "refresh-token", result.RefreshToken)
+			}
+
+			authService.AssertExpectations(t)
+			fraudService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecaseTwoFactorSecurityEvents(t *testing.T) {
+	ctx := context.Background()
+	userID := uint(1)
+
+	t.Run("有効化でセキュリティイベントを記録", func(t *testing.T) {
+		authService := new(MockAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+
+		authService.On("EnableTwoFactor", ctx, userID, "123456").Return([]string{"aaaaa-11111"}, nil)
+		fraudService.On("CreateSecurityEvent", ctx, &userID, "TWO_FACTOR_ENABLED", "User enabled two-factor authentication", "192.168.1.1", "test-agent", "MEDIUM").Return(nil)
+
+		uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+		codes, err := uc.EnableTwoFactor(ctx, userID, usecase.TwoFactorCodeRequest{Code: "123456"}, "192.168.1.1", "test-agent")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"aaaaa-11111"}, codes)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("無効化でセキュリティイベントを記録", func(t *testing.T) {
+		authService := new(MockAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+
+		authService.On("DisableTwoFactor", ctx, userID, "password123", "123456").Return(nil)
+		fraudService.On("CreateSecurityEvent", ctx, &userID, "TWO_FACTOR_DISABLED", "User disabled two-factor authentication", "192.168.1.1", "test-agent", "HIGH").Return(nil)
+
+		uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+		err := uc.DisableTwoFactor(ctx, userID, usecase.DisableTwoFactorRequest{Password: "password123", Code: "123456"}, "192.168.1.1", "test-agent")
+
+		assert.NoError(t, err)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("失敗時はイベントを記録しない", func(t *testing.T) {
+		authService := new(MockAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+
+		authService.On("EnableTwoFactor", ctx, userID, "000000").Return(nil, entity.ErrInvalidTwoFactorCode)
+
+		uc := usecase.NewAuthUsecase(authService, fraudService, nil)
+		_, err := uc.EnableTwoFactor(ctx, userID, usecase.TwoFactorCodeRequest{Code: "000000"}, "192.168.1.1", "test-agent")
+
+		assert.Equal(t, entity.ErrInvalidTwoFactorCode, err)
+		fraudService.AssertNotCalled(t, "CreateSecurityEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const (
+	recentFailedLoginWindow     = 30 * 24 * time.Hour
+	recentSecurityEventsOnBoard = 5
+)
+
+type DashboardCache interface {
+	GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error)
+	SetUserDashboard(ctx context.Context, dashboard *entity.UserDashboard) error
+	DeleteUserDashboard(ctx context.Context, userID uint) error
+}
+
+type DashboardInvalidator interface {
+	InvalidateUserDashboard(ctx context.Context, userID uint) error
+}
+
+type DashboardUsecase struct {
+	authRepo              repository.AuthRepository
+	userProfileRepo       repository.UserProfileRepository
+	loginAttemptRepo      repository.LoginAttemptRepository
+	deviceFingerprintRepo repository.DeviceFingerprintRepository
+	securityEventRepo     repository.SecurityEventRepository
+	notificationRepo      repository.NotificationRepository
+	dashboardCache        DashboardCache
+}
+
+func NewDashboardUsecase(
+	authRepo repository.AuthRepository,
+	userProfileRepo repository.UserProfileRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	deviceFingerprintRepo repository.DeviceFingerprintRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	notificationRepo repository.NotificationRepository,
+	dashboardCache DashboardCache,
+) *DashboardUsecase {
+	return &DashboardUsecase{
+		authRepo:              authRepo,
+		userProfileRepo:       userProfileRepo,
+		loginAttemptRepo:      loginAttemptRepo,
+		deviceFingerprintRepo: deviceFingerprintRepo,
+		securityEventRepo:     securityEventRepo,
+		notificationRepo:      notificationRepo,
+		dashboardCache:        dashboardCache,
+	}
+}
+
+func (u *DashboardUsecase) GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	dashboard, err := u.cachedDashboard(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.UnreadNotifications, err = u.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread notifications: %w", err)
+	}
+	_, dashboard.TotalNotifications, err = u.notificationRepo.GetByUserID(ctx, userID, 0, 1, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	return dashboard, nil
+}
+
+func (u *DashboardUsecase) InvalidateUserDashboard(ctx context.Context, userID uint) error {
+	if u.dashboardCache == nil {
+		return nil
+	}
+	return u.dashboardCache.DeleteUserDashboard(ctx, userID)
+}
+
+func invalidateUserDashboard(ctx context.Context, invalidator DashboardInvalidator, userID uint) {
+	if invalidator != nil {
+		_ = invalidator.InvalidateUserDashboard(ctx, userID)
+	}
+}
+
+func (u *DashboardUsecase) cachedDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	if u.dashboardCache != nil {
+		if dashboard, err := u.dashboardCache.GetUserDashboard(ctx, userID); err == nil {
+			return dashboard, nil
+		}
+	}
+
+	dashboard, err := u.buildDashboard(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.dashboardCache != nil {
+		_ = u.dashboardCache.SetUserDashboard(ctx, dashboard)
+	}
+	return dashboard, nil
+}
+
+func (u *DashboardUsecase) buildDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	auth, err := u.authRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, service.ErrUserNotFound
+	}
+
+	now := time.Now()
+	dashboard := &entity.UserDashboard{
+		UserID:            userID,
+		Email:             auth.Email,
+		AccountCreatedAt:  auth.CreatedAt,
+		LastLoginAt:       auth.LastLoginAt,
+		TwoFactorEnabled:  auth.TwoFactorEnabled,
+		PasswordChangedAt: auth.PasswordChangedAt,
+		GeneratedAt:       now,
+	}
+
+	dashboard.TotalLogins, err = u.loginAttemptRepo.CountSuccessfulAttempts(ctx, auth.Email, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count logins: %w", err)
+	}
+	dashboard.RecentFailedLogins, err = u.loginAttemptRepo.CountFailedAttempts(ctx, auth.Email, now.Add(-recentFailedLoginWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed logins: %w", err)
+	}
+
+	if profile, err := u.userProfileRepo.GetByUserID(ctx, userID); err == nil {
+		dashboard.ProfileCompletion = profile.CompletionRate()
+	}
+
+	devices, err := u.deviceFingerprintRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+	for _, device := range devices {
+		if device.IsTrusted {
+			dashboard.TrustedDevicesCount++
+		}
+	}
+	dashboard.SecurityScore = auth.SecurityScore(dashboard.TrustedDevicesCount, now)
+
+	dashboard.RecentSecurityEvents, _, err = u.securityEventRepo.GetByUserID(ctx, userID, 0, recentSecurityEventsOnBoard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security events: %w", err)
+	}
+
+	return dashboard, nil
+}
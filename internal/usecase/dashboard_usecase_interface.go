@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type DashboardUsecaseInterface interface {
+	GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error)
+	InvalidateUserDashboard(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,402 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepository) Create(ctx context.Context, auth *entity.Auth) error {
+	args := m.Called(ctx, auth)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetByUserID(ctx context.Context, userID uint) (*entity.Auth, error) {
+	args := m.Called(ctx, userID)
+	if auth, ok := args.Get(0).(*entity.Auth); ok {
+		return auth, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthRepository) GetByEmail(ctx context.Context, email string) (*entity.Auth, error) {
+	args := m.Called(ctx, email)
+	if auth, ok := args.Get(0).(*entity.Auth); ok {
+		return auth, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthRepository) Update(ctx context.Context, auth *entity.Auth) error {
+	args := m.Called(ctx, auth)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) ConsumeTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuthRepository) Delete(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockLoginAttemptRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginAttemptRepository) Create(ctx context.Context, attempt *entity.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) GetByEmail(ctx context.Context, email string, since time.Time) ([]*entity.LoginAttempt, error) {
+	args := m.Called(ctx, email, since)
+	if attempts, ok := args.Get(0).([]*entity.LoginAttempt); ok {
+		return attempts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) GetByIP(ctx context.Context, ipAddress string, since time.Time) ([]*entity.LoginAttempt, error) {
+	args := m.Called(ctx, ipAddress, since)
+	if attempts, ok := args.Get(0).([]*entity.LoginAttempt); ok {
+		return attempts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) CountFailedAttempts(ctx context.Context, email string, since time.Time) (int64, error) {
+	args := m.Called(ctx, email, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) CountSuccessfulAttempts(ctx context.Context, email string, since time.Time) (int64, error) {
+	args := m.Called(ctx, email, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) List(ctx context.Context, offset, limit int) ([]*entity.LoginAttempt, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if attempts, ok := args.Get(0).([]*entity.LoginAttempt); ok {
+		return attempts, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockLoginAttemptRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) CleanupOld(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+type MockDeviceFingerprintRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceFingerprintRepository) Create(ctx context.Context, fingerprint *entity.DeviceFingerprint) error {
+	args := m.Called(ctx, fingerprint)
+	return args.Error(0)
+}
+
+func (m *MockDeviceFingerprintRepository) GetByFingerprint(ctx context.Context, fingerprint string) (*entity.DeviceFingerprint, error) {
+	args := m.Called(ctx, fingerprint)
+	if device, ok := args.Get(0).(*entity.DeviceFingerprint); ok {
+		return device, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDeviceFingerprintRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.DeviceFingerprint, error) {
+	args := m.Called(ctx, userID)
+	if devices, ok := args.Get(0).([]*entity.DeviceFingerprint); ok {
+		return devices, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDeviceFingerprintRepository) List(ctx context.Context, offset, limit int) ([]*entity.DeviceFingerprint, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if devices, ok := args.Get(0).([]*entity.DeviceFingerprint); ok {
+		return devices, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockDeviceFingerprintRepository) Update(ctx context.Context, fingerprint *entity.DeviceFingerprint) error {
+	args := m.Called(ctx, fingerprint)
+	return args.Error(0)
+}
+
+func (m *MockDeviceFingerprintRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDeviceFingerprintRepository) IsTrustedDevice(ctx context.Context, userID uint, fingerprint string) (bool, error) {
+	args := m.Called(ctx, userID, fingerprint)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockSecurityEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockSecurityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockSecurityEventRepository) GetByID(ctx context.Context, id uint) (*entity.SecurityEvent, error) {
+	args := m.Called(ctx, id)
+	if event, ok := args.Get(0).(*entity.SecurityEvent); ok {
+		return event, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSecurityEventRepository) List(ctx context.Context, offset, limit int) ([]*entity.SecurityEvent, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if events, ok := args.Get(0).([]*entity.SecurityEvent); ok {
+		return events, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockSecurityEventRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int) ([]*entity.SecurityEvent, int64, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if events, ok := args.Get(0).([]*entity.SecurityEvent); ok {
+		return events, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockSecurityEventRepository) GetBySeverity(ctx context.Context, severity string, offset, limit int) ([]*entity.SecurityEvent, int64, error) {
+	args := m.Called(ctx, severity, offset, limit)
+	if events, ok := args.Get(0).([]*entity.SecurityEvent); ok {
+		return events, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockSecurityEventRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByUserID(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error) {
+	args := m.Called(ctx, userID, offset, limit, unreadOnly)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockNotificationRepository) GetByUserIDAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error) {
+	args := m.Called(ctx, userID, afterID, limit)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetByID(ctx context.Context, id uint) (*entity.Notification, error) {
+	args := m.Called(ctx, id)
+	if notification, ok := args.Get(0).(*entity.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) Update(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) Delete(ctx context.Context, userID, notificationID uint) (bool, error) {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsRead(ctx context.Context, userID, notificationID uint) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockDashboardCache struct {
+	mock.Mock
+}
+
+func (m *MockDashboardCache) GetUserDashboard(ctx context.Context, userID uint) (*entity.UserDashboard, error) {
+	args := m.Called(ctx, userID)
+	if dashboard, ok := args.Get(0).(*entity.UserDashboard); ok {
+		return dashboard, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDashboardCache) SetUserDashboard(ctx context.Context, dashboard *entity.UserDashboard) error {
+	args := m.Called(ctx, dashboard)
+	return args.Error(0)
+}
+
+func (m *MockDashboardCache) DeleteUserDashboard(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type dashboardMocks struct {
+	auth         *MockAuthRepository
+	profile      *MockUserProfileRepository
+	loginAttempt *MockLoginAttemptRepository
+	device       *MockDeviceFingerprintRepository
+	event        *MockSecurityEventRepository
+	notification *MockNotificationRepository
+	cache        *MockDashboardCache
+}
+
+func setupDashboardUsecase() (*usecase.DashboardUsecase, *dashboardMocks) {
+	mocks := &dashboardMocks{
+		auth:         new(MockAuthRepository),
+		profile:      new(MockUserProfileRepository),
+		loginAttempt: new(MockLoginAttemptRepository),
+		device:       new(MockDeviceFingerprintRepository),
+		event:        new(MockSecurityEventRepository),
+		notification: new(MockNotificationRepository),
+		cache:        new(MockDashboardCache),
+	}
+	uc := usecase.NewDashboardUsecase(mocks.auth, mocks.profile, mocks.loginAttempt, mocks.device, mocks.event, mocks.notification, mocks.cache)
+	return uc, mocks
+}
+
+func TestDashboardUsecaseGetUserDashboard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("キャッシュがない場合は集計してキャッシュする", func(t *testing.T) {
+		uc, mocks := setupDashboardUsecase()
+
+		passwordChangedAt := time.Now().Add(-24 * time.Hour)
+		auth := &entity.Auth{UserID: 1, Email: "test@example.com", TwoFactorEnabled: true, PasswordChangedAt: &passwordChangedAt}
+		profile := entity.NewUserProfile(1)
+		profile.UpdateProfile("太郎", "山田", "", "", "", nil)
+		events := []*entity.SecurityEvent{{ID: 2, UserID: &auth.UserID, EventType: "LOGIN_SUCCESS"}}
+
+		mocks.cache.On("GetUserDashboard", ctx, uint(1)).Return(nil, errors.New("cache miss"))
+		mocks.auth.On("GetByUserID", ctx, uint(1)).Return(auth, nil)
+		mocks.loginAttempt.On("CountSuccessfulAttempts", ctx, "test@example.com", time.Time{}).Return(int64(12), nil)
+		mocks.loginAttempt.On("CountFailedAttempts", ctx, "test@example.com", mock.AnythingOfType("time.Time")).Return(int64(2), nil)
+		mocks.profile.On("GetByUserID", ctx, uint(1)).Return(profile, nil)
+		mocks.device.On("GetByUserID", ctx, uint(1)).Return([]*entity.DeviceFingerprint{{IsTrusted: true}, {IsTrusted: false}}, nil)
+		mocks.event.On("GetByUserID", ctx, uint(1), 0, 5).Return(events, int64(1), nil)
+		mocks.cache.On("SetUserDashboard", ctx, mock.AnythingOfType("*entity.UserDashboard")).Return(nil)
+		mocks.notification.On("GetUnreadCount", ctx, uint(1)).Return(int64(3), nil)
+		mocks.notification.On("GetByUserID", ctx, uint(1), 0, 1, false).Return([]*entity.Notification{}, int64(8), nil)
+
+		dashboard, err := uc.GetUserDashboard(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), dashboard.TotalLogins)
+		assert.Equal(t, int64(2), dashboard.RecentFailedLogins)
+		assert.Equal(t, 25, dashboard.ProfileCompletion)
+		assert.Equal(t, 1, dashboard.TrustedDevicesCount)
+		assert.Equal(t, 100, dashboard.SecurityScore)
+		assert.Equal(t, events, dashboard.RecentSecurityEvents)
+		assert.Equal(t, int64(3), dashboard.UnreadNotifications)
+		assert.Equal(t, int64(8), dashboard.TotalNotifications)
+		mocks.cache.AssertExpectations(t)
+		mocks.loginAttempt.AssertExpectations(t)
+	})
+
+	t.Run("キャッシュがある場合は通知数のみ取得する", func(t *testing.T) {
+		uc, mocks := setupDashboardUsecase()
+
+		cached := &entity.UserDashboard{UserID: 1, SecurityScore: 70, UnreadNotifications: 99}
+		mocks.cache.On("GetUserDashboard", ctx, uint(1)).Return(cached, nil)
+		mocks.notification.On("GetUnreadCount", ctx, uint(1)).Return(int64(1), nil)
+		mocks.notification.On("GetByUserID", ctx, uint(1), 0, 1, false).Return([]*entity.Notification{}, int64(4), nil)
+
+		dashboard, err := uc.GetUserDashboard(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 70, dashboard.SecurityScore)
+		assert.Equal(t, int64(1), dashboard.UnreadNotifications)
+		assert.Equal(t, int64(4), dashboard.TotalNotifications)
+		mocks.auth.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+		mocks.cache.AssertNotCalled(t, "SetUserDashboard", mock.Anything, mock.Anything)
+	})
+
+	t.Run("認証情報が存在しない", func(t *testing.T) {
+		uc, mocks := setupDashboardUsecase()
+
+		mocks.cache.On("GetUserDashboard", ctx, uint(1)).Return(nil, errors.New("cache miss"))
+		mocks.auth.On("GetByUserID", ctx, uint(1)).Return(nil, errors.New("record not found"))
+
+		dashboard, err := uc.GetUserDashboard(ctx, 1)
+
+		assert.Nil(t, dashboard)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+		mocks.cache.AssertNotCalled(t, "SetUserDashboard", mock.Anything, mock.Anything)
+	})
+
+	t.Run("未読数の取得に失敗", func(t *testing.T) {
+		uc, mocks := setupDashboardUsecase()
+
+		mocks.cache.On("GetUserDashboard", ctx, uint(1)).Return(&entity.UserDashboard{UserID: 1}, nil)
+		mocks.notification.On("GetUnreadCount", ctx, uint(1)).Return(int64(0), errors.New("database error"))
+
+		dashboard, err := uc.GetUserDashboard(ctx, 1)
+
+		assert.Nil(t, dashboard)
+		assert.Contains(t, err.Error(), "failed to get unread notifications")
+	})
+}
+
+func TestDashboardUsecaseInvalidateUserDashboard(t *testing.T) {
+	uc, mocks := setupDashboardUsecase()
+	mocks.cache.On("DeleteUserDashboard", mock.Anything, uint(1)).Return(nil)
+
+	err := uc.InvalidateUserDashboard(context.Background(), 1)
+
+	assert.NoError(t, err)
+	mocks.cache.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
 	"regexp"
 	"strings"
 
@@ -12,22 +13,30 @@ import (
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
 )
 
+const (
+	minIPv4RangeBits = 8
+	minIPv6RangeBits = 32
+)
+
 type FraudUsecase struct {
 	fraudDomainService service.FraudDomainServiceInterface
+	dashboard          DashboardInvalidator
 	allowPrivateIPs    bool
 	allowedPrivateIPs  map[string]bool
 }
 
-func NewFraudUsecase(fraudDomainService service.FraudDomainServiceInterface) FraudUsecaseInterface {
+func NewFraudUsecase(fraudDomainService service.FraudDomainServiceInterface, dashboard DashboardInvalidator) FraudUsecaseInterface {
 	return &FraudUsecase{
 		fraudDomainService: fraudDomainService,
+		dashboard:          dashboard,
 		allowPrivateIPs:    true,
 		allowedPrivateIPs:  make(map[string]bool),
 	}
 }
 
 func (u *FraudUsecase) AddIPToBlacklist(ctx context.Context, ip string, reason string, adminID uint) error {
-	if err := u.validateIPAddress(ip); err != nil {
+	ip, err := u.validateIPRange(ip)
+	if err != nil {
 		return fmt.Errorf("invalid IP address: %w", err)
 	}
 
@@ -39,7 +48,7 @@ func (u *FraudUsecase) AddIPToBlacklist(ctx context.Context, ip string, reason s
 		return fmt.Errorf("reason too long (max 500 characters)")
 	}
 
-	_, err := u.fraudDomainService.GetBlacklistedIPs(ctx, 1, 1000)
+	_, err = u.fraudDomainService.GetBlacklistedIPs(ctx, 1, 1000)
 	if err != nil {
 		return fmt.Errorf("failed to check existing blacklist: %w", err)
 	}
@@ -51,7 +60,8 @@ func (u *FraudUsecase) AddIPToBlacklist(ctx context.Context, ip string, reason s
 }
 
 func (u *FraudUsecase) RemoveIPFromBlacklist(ctx context.Context, ip string) error {
-	if err := u.validateIPAddress(ip); err != nil {
+	ip, err := u.validateIPRange(ip)
+	if err != nil {
 		return fmt.Errorf("invalid IP address: %w", err)
 	}
 
@@ -80,6 +90,57 @@ func (u *FraudUsecase) GetBlacklistedIPs(ctx context.Context) ([]*entity.IPBlack
 	return ips, nil
 }
 
+func (u *FraudUsecase) AddIPToAllowlist(ctx context.Context, ip string, description string) error {
+	prefix, err := u.parseIPRange(ip)
+	if err != nil {
+		return fmt.Errorf("invalid IP address: %w", err)
+	}
+
+	if strings.TrimSpace(description) == "" {
+		return fmt.Errorf("description cannot be empty")
+	}
+
+	if len(description) > 255 {
+		return fmt.Errorf("description too long (max 255 characters)")
+	}
+
+	clientIP := "127.0.0.1"
+	userAgent := "Admin-Panel"
+
+	return u.fraudDomainService.AddIPToAllowlist(ctx, entity.FormatIPRange(prefix), description, clientIP, userAgent)
+}
+
+func (u *FraudUsecase) RemoveIPFromAllowlist(ctx context.Context, ip string) error {
+	prefix, err := u.parseIPRange(ip)
+	if err != nil {
+		return fmt.Errorf("invalid IP address: %w", err)
+	}
+
+	clientIP := "127.0.0.1"
+	userAgent := "Admin-Panel"
+
+	return u.fraudDomainService.RemoveIPFromAllowlist(ctx, entity.FormatIPRange(prefix), clientIP, userAgent)
+}
+
+func (u *FraudUsecase) GetAllowlistedIPs(ctx context.Context) ([]*entity.IPAllowlist, error) {
+	result, err := u.fraudDomainService.GetAllowlistedIPs(ctx, 0, 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowlisted IPs: %w", err)
+	}
+
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type from domain service")
+	}
+
+	ips, ok := resultMap["ips"].([]*entity.IPAllowlist)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ips type in result")
+	}
+
+	return ips, nil
+}
+
 func (u *FraudUsecase) GetSecurityEvents(ctx context.Context, limit, offset int) ([]*entity.SecurityEvent, error) {
 	if limit <= 0 || limit > 1000 {
 		limit = 50
@@ -129,32 +190,39 @@ func (u *FraudUsecase) CreateSecurityEvent(ctx context.Context, req *dto.CreateS
 	)
 }
 
-func (u *FraudUsecase) CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) error {
+func (u *FraudUsecase) CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) (*entity.RateLimitRule, error) {
 	if req == nil {
-		return fmt.Errorf("request cannot be nil")
+		return nil, fmt.Errorf("request cannot be nil")
 	}
 
 	if err := u.validateRateLimitRuleRequest(req); err != nil {
-		return fmt.Errorf("invalid rate limit rule request: %w", err)
+		return nil, fmt.Errorf("invalid rate limit rule request: %w", err)
 	}
 
-	return u.fraudDomainService.CreateRateLimitRule(ctx, req.RuleType, req.Identifier, req.MaxRequests, req.WindowSize)
+	name := fmt.Sprintf("%s:%s", req.RuleType, req.Identifier)
+	resource := entity.RateLimitResource(req.RuleType, req.Identifier)
+	return u.fraudDomainService.CreateRateLimitRule(ctx, name, resource, req.Algorithm, req.MaxRequests, req.WindowSize)
 }
 
-func (u *FraudUsecase) UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) error {
+func (u *FraudUsecase) UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) (*entity.RateLimitRule, error) {
 	if id == 0 {
-		return fmt.Errorf("invalid rule ID")
+		return nil, fmt.Errorf("invalid rule ID")
 	}
 
 	if req == nil {
-		return fmt.Errorf("request cannot be nil")
+		return nil, fmt.Errorf("request cannot be nil")
 	}
 
 	if err := u.validateUpdateRateLimitRuleRequest(req); err != nil {
-		return fmt.Errorf("invalid update rate limit rule request: %w", err)
+		return nil, fmt.Errorf("invalid update rate limit rule request: %w", err)
 	}
 
-	return u.fraudDomainService.UpdateRateLimitRule(ctx, id, req.RuleType, req.Identifier, req.MaxRequests, req.WindowSize)
+	var name, resource string
+	if req.RuleType != "" {
+		name = fmt.Sprintf("%s:%s", req.RuleType, req.Identifier)
+		resource = entity.RateLimitResource(req.RuleType, req.Identifier)
+	}
+	return u.fraudDomainService.UpdateRateLimitRule(ctx, id, name, resource, req.Algorithm, req.MaxRequests, req.WindowSize)
 }
 
 func (u *FraudUsecase) DeleteRateLimitRule(ctx context.Context, id uint) error {
@@ -184,6 +252,43 @@ func (u *FraudUsecase) GetRateLimitRules(ctx context.Context) ([]*entity.RateLim
 	return rules, nil
 }
 
+func (u *FraudUsecase) GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	rules, err := u.fraudDomainService.GetFraudRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fraud rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (u *FraudUsecase) UpdateFraudRule(ctx context.Context, name string, req *dto.UpdateFraudRuleRequest) (*entity.FraudRuleConfig, error) {
+	if name == "" {
+		return nil, fmt.Errorf("invalid fraud rule name")
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	if err := u.validateUpdateFraudRuleRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid update fraud rule request: %w", err)
+	}
+
+	return u.fraudDomainService.UpdateFraudRule(ctx, name, req.Enabled, req.Weight, req.Threshold, req.WindowSeconds)
+}
+
+func (u *FraudUsecase) AnalyzeFraud(ctx context.Context, req *dto.AnalyzeFraudRequest) (*entity.FraudAnalysis, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	if err := u.validateIPAddress(req.IPAddress); err != nil {
+		return nil, fmt.Errorf("invalid IP address: %w", err)
+	}
+
+	return u.fraudDomainService.AnalyzeFraud(ctx, req.UserID, req.Email, req.IPAddress, req.UserAgent)
+}
+
 func (u *FraudUsecase) GetActiveSessions(ctx context.Context) ([]*entity.UserSession, error) {
 	result, err := u.fraudDomainService.GetActiveSessions(ctx)
 	if err != nil {
@@ -195,16 +300,11 @@ func (u *FraudUsecase) GetActiveSessions(ctx context.Context) ([]*entity.UserSes
 		return nil, fmt.Errorf("unexpected result type from domain service")
 	}
 
-	sessionsData, ok := resultMap["sessions"].([]map[string]interface{})
+	sessions, ok := resultMap["sessions"].([]*entity.UserSession)
 	if !ok {
 		return nil, fmt.Errorf("unexpected sessions type in result")
 	}
 
-	sessions := make([]*entity.UserSession, 0, len(sessionsData))
-	for range sessionsData {
-		sessions = append(sessions, &entity.UserSession{})
-	}
-
 	return sessions, nil
 }
 
@@ -227,16 +327,11 @@ func (u *FraudUsecase) GetDevices(ctx context.Context) ([]*entity.DeviceFingerpr
 		return nil, fmt.Errorf("unexpected result type from domain service")
 	}
 
-	devicesData, ok := resultMap["devices"].([]map[string]interface{})
+	devices, ok := resultMap["devices"].([]*entity.DeviceFingerprint)
 	if !ok {
 		return nil, fmt.Errorf("unexpected devices type in result")
 	}
 
-	devices := make([]*entity.DeviceFingerprint, 0, len(devicesData))
-	for range devicesData {
-		devices = append(devices, &entity.DeviceFingerprint{})
-	}
-
 	return devices, nil
 }
 
@@ -245,7 +340,13 @@ func (u *FraudUsecase) TrustDevice(ctx context.Context, fingerprint string) erro
 		return fmt.Errorf("fingerprint cannot be empty")
 	}
 
-	return u.fraudDomainService.TrustDevice(ctx, fingerprint)
+	device, err := u.fraudDomainService.TrustDevice(ctx, fingerprint)
+	if err != nil {
+		return err
+	}
+	invalidateUserDashboard(ctx, u.dashboard, device.UserID)
+
+	return nil
 }
 
 func (u *FraudUsecase) CleanupExpiredData(ctx context.Context) error {
@@ -275,6 +376,47 @@ func (u *FraudUsecase) validateIPAddress(ip string) error {
 	return nil
 }
 
+func (u *FraudUsecase) validateIPRange(value string) (string, error) {
+	prefix, err := u.parseIPRange(value)
+	if err != nil {
+		return "", err
+	}
+
+	normalized := entity.FormatIPRange(prefix)
+	if prefix.Addr().IsPrivate() {
+		if allowed, exists := u.allowedPrivateIPs[normalized]; exists {
+			if !allowed {
+				return "", fmt.Errorf("private IP address %s is specifically denied", normalized)
+			}
+		} else if !u.allowPrivateIPs {
+			return "", fmt.Errorf("private IP addresses are not allowed")
+		}
+	}
+
+	return normalized, nil
+}
+
+func (u *FraudUsecase) parseIPRange(value string) (netip.Prefix, error) {
+	if strings.TrimSpace(value) == "" {
+		return netip.Prefix{}, fmt.Errorf("IP address cannot be empty")
+	}
+
+	prefix, err := entity.ParseIPRange(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address format")
+	}
+
+	minBits := minIPv4RangeBits
+	if prefix.Addr().Is6() {
+		minBits = minIPv6RangeBits
+	}
+	if prefix.Bits() < minBits {
+		return netip.Prefix{}, fmt.Errorf("CIDR range too broad (minimum /%d)", minBits)
+	}
+
+	return prefix, nil
+}
+
 func (u *FraudUsecase) validateSecurityEventRequest(req *dto.CreateSecurityEventRequest) error {
 	if strings.TrimSpace(req.EventType) == "" {
 		return fmt.Errorf("event type cannot be empty")
@@ -324,6 +466,10 @@ func (u *FraudUsecase) validateRateLimitRuleRequest(req *dto.CreateRateLimitRule
 		return err
 	}
 
+	if err := u.validateRateLimitAlgorithm(req.Algorithm); err != nil {
+		return err
+	}
+
 	return u.validateRateLimitRuleIdentifier(req.RuleType, req.Identifier)
 }
 
@@ -356,7 +502,7 @@ func (u *FraudUsecase) validateRateLimitRuleBasicFields(req *dto.CreateRateLimit
 }
 
 func (u *FraudUsecase) validateRateLimitRuleType(ruleType string) error {
-	validRuleTypes := []string{"IP", "USER", "ENDPOINT", "GLOBAL"}
+	validRuleTypes := []string{"IP", "USER", "ROLE", "ENDPOINT", "GLOBAL"}
 	for _, validType := range validRuleTypes {
 		if ruleType == validType {
 			return nil
@@ -365,6 +511,13 @@ func (u *FraudUsecase) validateRateLimitRuleType(ruleType string) error {
 	return fmt.Errorf("invalid rule type: %s", ruleType)
 }
 
+func (u *FraudUsecase) validateRateLimitAlgorithm(algorithm string) error {
+	if algorithm != "" && !entity.IsValidRateLimitAlgorithm(algorithm) {
+		return fmt.Errorf("invalid algorithm: %s", algorithm)
+	}
+	return nil
+}
+
 func (u *FraudUsecase) validateRateLimitRuleIdentifier(ruleType, identifier string) error {
 	switch ruleType {
 	case "IP":
@@ -375,13 +528,22 @@ func (u *FraudUsecase) validateRateLimitRuleIdentifier(ruleType, identifier stri
 		if matched, _ := regexp.MatchString(`^\d+$`, identifier); !matched {
 			return fmt.Errorf("user identifier must be numeric")
 		}
+	case "ENDPOINT":
+		fields := strings.Fields(identifier)
+		if len(fields) > 2 || !strings.HasPrefix(fields[len(fields)-1], "/") {
+			return fmt.Errorf("endpoint identifier must be a route template optionally prefixed with a method")
+		}
 	}
 	return nil
 }
 
 func (u *FraudUsecase) validateUpdateRateLimitRuleRequest(req *dto.UpdateRateLimitRuleRequest) error {
+	if (req.RuleType == "") != (strings.TrimSpace(req.Identifier) == "") {
+		return fmt.Errorf("rule type and identifier must be updated together")
+	}
+
 	if req.RuleType != "" {
-		validRuleTypes := []string{"IP", "USER", "ENDPOINT", "GLOBAL"}
+		validRuleTypes := []string{"IP", "USER", "ROLE", "ENDPOINT", "GLOBAL"}
 		isValidRuleType := false
 		for _, validType := range validRuleTypes {
 			if req.RuleType == validType {
@@ -393,6 +555,14 @@ func (u *FraudUsecase) validateUpdateRateLimitRuleRequest(req *dto.UpdateRateLim
 		if !isValidRuleType {
 			return fmt.Errorf("invalid rule type: %s", req.RuleType)
 		}
+
+		if err := u.validateRateLimitRuleIdentifier(req.RuleType, req.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if err := u.validateRateLimitAlgorithm(req.Algorithm); err != nil {
+		return err
 	}
 
 	if req.MaxRequests < 0 {
@@ -414,6 +584,26 @@ func (u *FraudUsecase) validateUpdateRateLimitRuleRequest(req *dto.UpdateRateLim
 	return nil
 }
 
+func (u *FraudUsecase) validateUpdateFraudRuleRequest(req *dto.UpdateFraudRuleRequest) error {
+	if req.Weight != nil && (*req.Weight < 0 || *req.Weight > 1) {
+		return fmt.Errorf("weight must be between 0 and 1")
+	}
+
+	if req.Threshold != nil && *req.Threshold < 0 {
+		return fmt.Errorf("threshold cannot be negative")
+	}
+
+	if req.WindowSeconds != nil && *req.WindowSeconds < 0 {
+		return fmt.Errorf("window seconds cannot be negative")
+	}
+
+	if req.WindowSeconds != nil && *req.WindowSeconds > 86400 {
+		return fmt.Errorf("window seconds too large (max 24 hours)")
+	}
+
+	return nil
+}
+
 func (u *FraudUsecase) determineSeverity(eventType string) string {
 	highSeverityEvents := []string{
 		"ACCOUNT_LOCKED", "SUSPICIOUS_ACTIVITY", "IP_BLACKLISTED",
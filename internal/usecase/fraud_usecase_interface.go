@@ -11,12 +11,18 @@ type FraudUsecaseInterface interface {
 	AddIPToBlacklist(ctx context.Context, ip string, reason string, adminID uint) error
 	RemoveIPFromBlacklist(ctx context.Context, ip string) error
 	GetBlacklistedIPs(ctx context.Context) ([]*entity.IPBlacklist, error)
+	AddIPToAllowlist(ctx context.Context, ip string, description string) error
+	RemoveIPFromAllowlist(ctx context.Context, ip string) error
+	GetAllowlistedIPs(ctx context.Context) ([]*entity.IPAllowlist, error)
 	GetSecurityEvents(ctx context.Context, limit, offset int) ([]*entity.SecurityEvent, error)
 	CreateSecurityEvent(ctx context.Context, req *dto.CreateSecurityEventRequest) error
-	CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) error
-	UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) error
+	CreateRateLimitRule(ctx context.Context, req *dto.CreateRateLimitRuleRequest) (*entity.RateLimitRule, error)
+	UpdateRateLimitRule(ctx context.Context, id uint, req *dto.UpdateRateLimitRuleRequest) (*entity.RateLimitRule, error)
 	DeleteRateLimitRule(ctx context.Context, id uint) error
 	GetRateLimitRules(ctx context.Context) ([]*entity.RateLimitRule, error)
+	GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error)
+	UpdateFraudRule(ctx context.Context, name string, req *dto.UpdateFraudRuleRequest) (*entity.FraudRuleConfig, error)
+	AnalyzeFraud(ctx context.Context, req *dto.AnalyzeFraudRequest) (*entity.FraudAnalysis, error)
 	GetActiveSessions(ctx context.Context) ([]*entity.UserSession, error)
 	DeactivateSession(ctx context.Context, sessionID string) error
 	GetDevices(ctx context.Context) ([]*entity.DeviceFingerprint, error)
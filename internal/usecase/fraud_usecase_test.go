@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
@@ -12,7 +13,7 @@ import (
 
 func TestFraudUsecaseAddIPToBlacklist(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	ip := "192.168.1.100"
@@ -33,7 +34,7 @@ func TestFraudUsecaseAddIPToBlacklist(t *testing.T) {
 
 func TestFraudUsecaseAddIPToBlacklistInvalidIP(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	invalidIP := "invalid-ip"
@@ -48,7 +49,7 @@ func TestFraudUsecaseAddIPToBlacklistInvalidIP(t *testing.T) {
 
 func TestFraudUsecaseAddIPToBlacklistEmptyReason(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	ip := "192.168.1.100"
@@ -61,9 +62,80 @@ func TestFraudUsecaseAddIPToBlacklistEmptyReason(t *testing.T) {
 	assert.Contains(t, err.Error(), "reason cannot be empty")
 }
 
+func TestFraudUsecaseAddIPToBlacklistCIDR(t *testing.T) {
+	tests := []struct {
+		name        string
+		ip          string
+		normalized  string
+		expectedErr string
+	}{
+		{name: "IPv4 CIDRは正規化される", ip: "203.0.113.77/24", normalized: "203.0.113.0/24"},
+		{name: "IPv6 CIDR", ip: "2001:db8::/48", normalized: "2001:db8::/48"},
+		{name: "範囲が広すぎるIPv4", ip: "10.0.0.0/7", expectedErr: "CIDR range too broad"},
+		{name: "範囲が広すぎるIPv6", ip: "2001::/16", expectedErr: "CIDR range too broad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomainService := &MockFraudDomainService{}
+			fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+			ctx := context.Background()
+
+			if tt.expectedErr == "" {
+				mockDomainService.On("GetBlacklistedIPs", ctx, 1, 1000).Return(map[string]interface{}{}, nil)
+				mockDomainService.On("AddIPToBlacklist", ctx, tt.normalized, "botnet", "127.0.0.1", "Admin-Panel").Return(nil)
+			}
+
+			err := fraudUsecase.AddIPToBlacklist(ctx, tt.ip, "botnet", 1)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.ErrorContains(t, err, "invalid IP address")
+				return
+			}
+			assert.NoError(t, err)
+			mockDomainService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudUsecaseAddIPToAllowlist(t *testing.T) {
+	mockDomainService := &MockFraudDomainService{}
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+	ctx := context.Background()
+
+	mockDomainService.On("AddIPToAllowlist", ctx, "10.20.0.0/16", "Tokyo office", "127.0.0.1", "Admin-Panel").Return(nil)
+
+	err := fraudUsecase.AddIPToAllowlist(ctx, "10.20.30.40/16", "Tokyo office")
+	assert.NoError(t, err)
+
+	err = fraudUsecase.AddIPToAllowlist(ctx, "10.20.0.0/16", " ")
+	assert.ErrorContains(t, err, "description cannot be empty")
+
+	mockDomainService.AssertExpectations(t)
+}
+
+func TestFraudUsecaseGetAllowlistedIPs(t *testing.T) {
+	mockDomainService := &MockFraudDomainService{}
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+	ctx := context.Background()
+
+	allowlists := []*entity.IPAllowlist{entity.NewIPAllowlist("198.51.100.0/24", "partner")}
+	mockDomainService.On("GetAllowlistedIPs", ctx, 0, 100).Return(map[string]interface{}{
+		"ips":   allowlists,
+		"total": int64(1),
+	}, nil)
+
+	result, err := fraudUsecase.GetAllowlistedIPs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, allowlists, result)
+	mockDomainService.AssertExpectations(t)
+}
+
 func TestFraudUsecaseRemoveIPFromBlacklist(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	ip := "192.168.1.100"
@@ -78,7 +150,7 @@ func TestFraudUsecaseRemoveIPFromBlacklist(t *testing.T) {
 
 func TestFraudUsecaseGetBlacklistedIPs(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	expectedIPs := []*entity.IPBlacklist{
@@ -103,7 +175,7 @@ func TestFraudUsecaseGetBlacklistedIPs(t *testing.T) {
 
 func TestFraudUsecaseGetSecurityEvents(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	limit := 50
@@ -134,7 +206,7 @@ func TestFraudUsecaseGetSecurityEvents(t *testing.T) {
 
 func TestFraudUsecaseCreateSecurityEvent(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	userID := uint(1)
@@ -156,7 +228,7 @@ func TestFraudUsecaseCreateSecurityEvent(t *testing.T) {
 
 func TestFraudUsecaseCreateSecurityEventInvalidEventType(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	userID := uint(1)
@@ -175,28 +247,268 @@ func TestFraudUsecaseCreateSecurityEventInvalidEventType(t *testing.T) {
 }
 
 func TestFraudUsecaseCreateRateLimitRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *dto.CreateRateLimitRuleRequest
+		wantName     string
+		wantResource string
+		wantErr      string
+	}{
+		{
+			name:         "IPルールはip:プレフィックス付きのリソースになる",
+			req:          &dto.CreateRateLimitRuleRequest{RuleType: "IP", Identifier: "192.168.1.1", MaxRequests: 100, WindowSize: 3600},
+			wantName:     "IP:192.168.1.1",
+			wantResource: "ip:192.168.1.1",
+		},
+		{
+			name:         "ENDPOINTルールはルートテンプレートをそのまま使う",
+			req:          &dto.CreateRateLimitRuleRequest{RuleType: "ENDPOINT", Identifier: "POST /api/v1/auth/login", MaxRequests: 5, WindowSize: 60},
+			wantName:     "ENDPOINT:POST /api/v1/auth/login",
+			wantResource: "POST /api/v1/auth/login",
+		},
+		{
+			name:         "ROLEルールはrole:プレフィックス付きのリソースになる",
+			req:          &dto.CreateRateLimitRuleRequest{RuleType: "ROLE", Identifier: "premium", MaxRequests: 1000, WindowSize: 60},
+			wantName:     "ROLE:premium",
+			wantResource: "role:premium",
+		},
+		{
+			name:         "アルゴリズムを指定できる",
+			req:          &dto.CreateRateLimitRuleRequest{RuleType: "ENDPOINT", Identifier: "/api/v1/stats", MaxRequests: 60, WindowSize: 60, Algorithm: "token_bucket"},
+			wantName:     "ENDPOINT:/api/v1/stats",
+			wantResource: "/api/v1/stats",
+		},
+		{
+			name:    "未対応のアルゴリズムはエラー",
+			req:     &dto.CreateRateLimitRuleRequest{RuleType: "ENDPOINT", Identifier: "/api/v1/stats", MaxRequests: 60, WindowSize: 60, Algorithm: "leaky_bucket"},
+			wantErr: "invalid algorithm",
+		},
+		{
+			name:         "GLOBALルールはワイルドカードになる",
+			req:          &dto.CreateRateLimitRuleRequest{RuleType: "GLOBAL", Identifier: "default", MaxRequests: 300, WindowSize: 60},
+			wantName:     "GLOBAL:default",
+			wantResource: "*",
+		},
+		{
+			name:    "ルートで始まらないENDPOINTはエラー",
+			req:     &dto.CreateRateLimitRuleRequest{RuleType: "ENDPOINT", Identifier: "login", MaxRequests: 5, WindowSize: 60},
+			wantErr: "endpoint identifier must be a route template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomainService := &MockFraudDomainService{}
+			fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+			ctx := context.Background()
+
+			if tt.wantErr == "" {
+				stored := entity.NewRateLimitRule(tt.wantName, tt.wantResource, int(tt.req.MaxRequests), int(tt.req.WindowSize))
+				stored.ID = 1
+				mockDomainService.On("CreateRateLimitRule", ctx, tt.wantName, tt.wantResource, tt.req.Algorithm, tt.req.MaxRequests, tt.req.WindowSize).Return(stored, nil)
+			}
+
+			rule, err := fraudUsecase.CreateRateLimitRule(ctx, tt.req)
+
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, rule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(1), rule.ID)
+				assert.Equal(t, tt.wantResource, rule.Resource)
+			}
+			mockDomainService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudUsecaseUpdateRateLimitRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *dto.UpdateRateLimitRuleRequest
+		wantName     string
+		wantResource string
+		wantErr      string
+	}{
+		{
+			name: "上限のみの更新は対象を変えない",
+			req:  &dto.UpdateRateLimitRuleRequest{MaxRequests: 50},
+		},
+		{
+			name:         "種別と識別子から名前と対象を組み立てる",
+			req:          &dto.UpdateRateLimitRuleRequest{RuleType: "USER", Identifier: "42"},
+			wantName:     "USER:42",
+			wantResource: "user:42",
+		},
+		{
+			name:    "識別子のみの更新はエラー",
+			req:     &dto.UpdateRateLimitRuleRequest{Identifier: "42"},
+			wantErr: "rule type and identifier must be updated together",
+		},
+		{
+			name:    "識別子の形式が種別に合わなければエラー",
+			req:     &dto.UpdateRateLimitRuleRequest{RuleType: "ENDPOINT", Identifier: "login"},
+			wantErr: "endpoint identifier must be a route template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomainService := &MockFraudDomainService{}
+			fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+			ctx := context.Background()
+
+			if tt.wantErr == "" {
+				stored := entity.NewRateLimitRule(tt.wantName, tt.wantResource, 50, 60)
+				mockDomainService.On("UpdateRateLimitRule", ctx, uint(1), tt.wantName, tt.wantResource, tt.req.Algorithm, tt.req.MaxRequests, tt.req.WindowSize).Return(stored, nil)
+			}
+
+			rule, err := fraudUsecase.UpdateRateLimitRule(ctx, 1, tt.req)
+
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, rule)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, rule)
+			}
+			mockDomainService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudUsecaseUpdateFraudRule(t *testing.T) {
+	validWeight := 0.5
+	invalidWeight := 1.5
+	negative := -1
+	tests := []struct {
+		name        string
+		ruleName    string
+		req         *dto.UpdateFraudRuleRequest
+		expectCall  bool
+		expectedErr string
+	}{
+		{
+			name:       "重みを更新できる",
+			ruleName:   "ip_blacklisted",
+			req:        &dto.UpdateFraudRuleRequest{Weight: &validWeight},
+			expectCall: true,
+		},
+		{
+			name:        "重みが1を超える",
+			ruleName:    "ip_blacklisted",
+			req:         &dto.UpdateFraudRuleRequest{Weight: &invalidWeight},
+			expectedErr: "weight must be between 0 and 1",
+		},
+		{
+			name:        "閾値が負数",
+			ruleName:    "failed_logins",
+			req:         &dto.UpdateFraudRuleRequest{Threshold: &negative},
+			expectedErr: "threshold cannot be negative",
+		},
+		{
+			name:        "ルール名が空",
+			req:         &dto.UpdateFraudRuleRequest{},
+			expectedErr: "invalid fraud rule name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDomainService := &MockFraudDomainService{}
+			fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+			ctx := context.Background()
+
+			if tt.expectCall {
+				mockDomainService.On("UpdateFraudRule", ctx, tt.ruleName, tt.req.Enabled, tt.req.Weight, tt.req.Threshold, tt.req.WindowSeconds).
+					Return(entity.NewFraudRuleConfig(tt.ruleName, *tt.req.Weight, 0, 0), nil)
+			}
+
+			result, err := fraudUsecase.UpdateFraudRule(ctx, tt.ruleName, tt.req)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				mockDomainService.AssertNotCalled(t, "UpdateFraudRule")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, validWeight, result.Weight)
+			mockDomainService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFraudUsecaseAnalyzeFraud(t *testing.T) {
+	mockDomainService := &MockFraudDomainService{}
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+	ctx := context.Background()
+
+	analysis := entity.NewFraudAnalysis(0.8, []string{"IP address is blacklisted"})
+	mockDomainService.On("AnalyzeFraud", ctx, (*uint)(nil), "test@example.com", "203.0.113.10", "Mozilla/5.0").Return(analysis, nil)
+
+	result, err := fraudUsecase.AnalyzeFraud(ctx, &dto.AnalyzeFraudRequest{
+		Email:     "test@example.com",
+		IPAddress: "203.0.113.10",
+		UserAgent: "Mozilla/5.0",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, analysis, result)
+
+	_, err = fraudUsecase.AnalyzeFraud(ctx, &dto.AnalyzeFraudRequest{IPAddress: "not-an-ip"})
+	assert.ErrorContains(t, err, "invalid IP address")
+	mockDomainService.AssertExpectations(t)
+}
+
+func TestFraudUsecaseGetActiveSessions(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
-	req := &dto.CreateRateLimitRuleRequest{
-		RuleType:    "IP",
-		Identifier:  "192.168.1.1",
-		MaxRequests: 100,
-		WindowSize:  3600,
+	sessions := []*entity.UserSession{
+		entity.NewUserSession(1, "session-123", "192.168.1.1", "Mozilla/5.0", time.Now().Add(time.Hour)),
 	}
+	mockDomainService.On("GetActiveSessions", ctx).Return(map[string]interface{}{
+		"sessions": sessions,
+		"total":    int64(1),
+	}, nil)
 
-	mockDomainService.On("CreateRateLimitRule", ctx, req.RuleType, req.Identifier, req.MaxRequests, req.WindowSize).Return(nil)
+	result, err := fraudUsecase.GetActiveSessions(ctx)
 
-	err := fraudUsecase.CreateRateLimitRule(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "session-123", result[0].SessionID)
+	mockDomainService.AssertExpectations(t)
+}
+
+func TestFraudUsecaseGetDevices(t *testing.T) {
+	mockDomainService := &MockFraudDomainService{}
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
+	ctx := context.Background()
+
+	devices := []*entity.DeviceFingerprint{
+		entity.NewDeviceFingerprint(1, "192.168.1.1_Mozilla/5.0", nil),
+	}
+	mockDomainService.On("GetDevices", ctx).Return(map[string]interface{}{
+		"devices": devices,
+		"total":   int64(1),
+	}, nil)
+
+	result, err := fraudUsecase.GetDevices(ctx)
 
 	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "192.168.1.1_Mozilla/5.0", result[0].Fingerprint)
 	mockDomainService.AssertExpectations(t)
 }
 
 func TestFraudUsecaseCleanupExpiredData(t *testing.T) {
 	mockDomainService := &MockFraudDomainService{}
-	fraudUsecase := usecase.NewFraudUsecase(mockDomainService)
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, nil)
 	ctx := context.Background()
 
 	mockDomainService.On("CleanupExpiredData", ctx).Return(nil)
@@ -206,3 +518,21 @@ func TestFraudUsecaseCleanupExpiredData(t *testing.T) {
 	assert.NoError(t, err)
 	mockDomainService.AssertExpectations(t)
 }
+
+func TestFraudUsecaseTrustDeviceInvalidatesDashboard(t *testing.T) {
+	mockDomainService := &MockFraudDomainService{}
+	dashboard := &MockDashboardInvalidator{}
+	fraudUsecase := usecase.NewFraudUsecase(mockDomainService, dashboard)
+	ctx := context.Background()
+
+	device := entity.NewDeviceFingerprint(7, "fingerprint", nil)
+	device.Trust()
+	mockDomainService.On("TrustDevice", ctx, "fingerprint").Return(device, nil)
+	dashboard.On("InvalidateUserDashboard", ctx, uint(7)).Return(nil)
+
+	err := fraudUsecase.TrustDevice(ctx, "fingerprint")
+
+	assert.NoError(t, err)
+	mockDomainService.AssertExpectations(t)
+	dashboard.AssertExpectations(t)
+}
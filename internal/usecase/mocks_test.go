@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
 	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
@@ -39,8 +40,8 @@ func (m *MockAuthDomainService) Login(ctx context.Context, email, password strin
 	return auth, roles, args.Error(2)
 }
 
-func (m *MockAuthDomainService) GenerateAccessToken(userID uint, email string, roles []string) (string, error) {
-	args := m.Called(userID, email, roles)
+func (m *MockAuthDomainService) GenerateAccessToken(ctx context.Context, userID uint, email string, roles []string) (string, error) {
+	args := m.Called(ctx, userID, email, roles)
 	return args.String(0), args.Error(1)
 }
 
@@ -49,6 +50,19 @@ func (m *MockAuthDomainService) GenerateRefreshToken(ctx context.Context, userID
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAuthDomainService) GenerateOAuthAccessToken(ctx context.Context, userID uint, clientID string, scopes []string) (string, error) {
+	args := m.Called(ctx, userID, clientID, scopes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthDomainService) ValidateOAuthAccessToken(ctx context.Context, tokenString string) (*service.OAuthClaims, error) {
+	args := m.Called(ctx, tokenString)
+	if claims, ok := args.Get(0).(*service.OAuthClaims); ok {
+		return claims, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockAuthDomainService) ValidateToken(tokenString string) (*service.JWTClaims, error) {
 	args := m.Called(tokenString)
 	if args.Get(0) == nil {
@@ -60,6 +74,34 @@ func (m *MockAuthDomainService) ValidateToken(tokenString string) (*service.JWTC
 	return nil, args.Error(1)
 }
 
+func (m *MockAuthDomainService) CheckTokenVersion(ctx context.Context, claims *service.JWTClaims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockAuthDomainService) RevokeUserTokens(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthDomainService) SuspendUser(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthDomainService) ReactivateUser(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthDomainService) JWKS() (*service.JWKS, error) {
+	args := m.Called()
+	if jwks, ok := args.Get(0).(*service.JWKS); ok {
+		return jwks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockAuthDomainService) RefreshToken(ctx context.Context, refreshTokenStr string) (*entity.Auth, []string, string, error) {
 	args := m.Called(ctx, refreshTokenStr)
 	if args.Get(0) == nil {
@@ -86,6 +128,280 @@ func (m *MockAuthDomainService) Logout(ctx context.Context, userID uint, token s
 	return args.Error(0)
 }
 
+func (m *MockAuthDomainService) SetupTwoFactor(ctx context.Context, userID uint) (string, string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthDomainService) EnableTwoFactor(ctx context.Context, userID uint, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if codes, ok := args.Get(0).([]string); ok {
+		return codes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthDomainService) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	args := m.Called(ctx, userID, password, code)
+	return args.Error(0)
+}
+
+func (m *MockAuthDomainService) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if codes, ok := args.Get(0).([]string); ok {
+		return codes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthDomainService) GenerateMFAToken(userID uint, email string) (string, error) {
+	args := m.Called(userID, email)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthDomainService) ValidateMFAToken(ctx context.Context, tokenString string) (*service.MFAClaims, error) {
+	args := m.Called(ctx, tokenString)
+	if claims, ok := args.Get(0).(*service.MFAClaims); ok {
+		return claims, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthDomainService) CompleteTwoFactorLogin(ctx context.Context, claims *service.MFAClaims, code string) (*entity.Auth, []string, error) {
+	args := m.Called(ctx, claims, code)
+	var auth *entity.Auth
+	if a, ok := args.Get(0).(*entity.Auth); ok {
+		auth = a
+	}
+	var roles []string
+	if r, ok := args.Get(1).([]string); ok {
+		roles = r
+	}
+	return auth, roles, args.Error(2)
+}
+
+type MockAccountDomainService struct {
+	mock.Mock
+}
+
+func (m *MockAccountDomainService) RequestPasswordReset(ctx context.Context, email string) (uint, error) {
+	args := m.Called(ctx, email)
+	if userID, ok := args.Get(0).(uint); ok {
+		return userID, args.Error(1)
+	}
+	return 0, args.Error(1)
+}
+
+func (m *MockAccountDomainService) ResetPassword(ctx context.Context, token, newPassword string) (uint, error) {
+	args := m.Called(ctx, token, newPassword)
+	if userID, ok := args.Get(0).(uint); ok {
+		return userID, args.Error(1)
+	}
+	return 0, args.Error(1)
+}
+
+func (m *MockAccountDomainService) RequestEmailVerification(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountDomainService) VerifyEmail(ctx context.Context, token string) (uint, error) {
+	args := m.Called(ctx, token)
+	if userID, ok := args.Get(0).(uint); ok {
+		return userID, args.Error(1)
+	}
+	return 0, args.Error(1)
+}
+
+type MockPointDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPointDomainService) EarnPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error) {
+	args := m.Called(ctx, userID, points, description, referenceType, referenceID)
+	if transaction, ok := args.Get(0).(*entity.PointTransaction); ok {
+		return transaction, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointDomainService) SpendPoints(ctx context.Context, userID uint, points int, description, referenceType string, referenceID *uint) (*entity.PointTransaction, error) {
+	args := m.Called(ctx, userID, points, description, referenceType, referenceID)
+	if transaction, ok := args.Get(0).(*entity.PointTransaction); ok {
+		return transaction, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointDomainService) GetPointTransactions(ctx context.Context, userID uint, filter *entity.PointTransactionFilter, offset, limit int) ([]*entity.PointTransaction, int64, error) {
+	args := m.Called(ctx, userID, filter, offset, limit)
+	if transactions, ok := args.Get(0).([]*entity.PointTransaction); ok {
+		return transactions, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPointDomainService) GetPointSummary(ctx context.Context, userID uint) (*entity.PointSummary, error) {
+	args := m.Called(ctx, userID)
+	if summary, ok := args.Get(0).(*entity.PointSummary); ok {
+		return summary, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPointDomainService) ExpirePoints(ctx context.Context, asOf time.Time, dryRun bool) (*entity.PointExpirationResult, error) {
+	args := m.Called(ctx, asOf, dryRun)
+	if result, ok := args.Get(0).(*entity.PointExpirationResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockTierDomainService struct {
+	mock.Mock
+}
+
+func (m *MockTierDomainService) GetTiers(ctx context.Context) ([]*entity.MembershipTier, error) {
+	args := m.Called(ctx)
+	if tiers, ok := args.Get(0).([]*entity.MembershipTier); ok {
+		return tiers, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierDomainService) GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	args := m.Called(ctx, id)
+	if tier, ok := args.Get(0).(*entity.MembershipTier); ok {
+		return tier, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierDomainService) CreateTier(ctx context.Context, tier *entity.MembershipTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockTierDomainService) UpdateTier(ctx context.Context, tier *entity.MembershipTier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockTierDomainService) DeleteTier(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTierDomainService) EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error) {
+	args := m.Called(ctx, userID)
+	if change, ok := args.Get(0).(*entity.TierChange); ok {
+		return change, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTierDomainService) EvaluateAllTiers(ctx context.Context) (*entity.TierEvaluationResult, error) {
+	args := m.Called(ctx)
+	if result, ok := args.Get(0).(*entity.TierEvaluationResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockPurchaseDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseDomainService) RecordPurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, bool, error) {
+	args := m.Called(ctx, purchase)
+	if recorded, ok := args.Get(0).(*entity.Purchase); ok {
+		return recorded, args.Bool(1), args.Error(2)
+	}
+	return nil, false, args.Error(2)
+}
+
+func (m *MockPurchaseDomainService) RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error) {
+	args := m.Called(ctx, purchaseID)
+	if purchase, ok := args.Get(0).(*entity.Purchase); ok {
+		return purchase, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPurchaseDomainService) GetUserPurchases(ctx context.Context, userID uint, offset, limit int) ([]*entity.Purchase, int64, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if purchases, ok := args.Get(0).([]*entity.Purchase); ok {
+		return purchases, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+type MockNotificationDomainService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationDomainService) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationDomainService) GetNotifications(ctx context.Context, userID uint, offset, limit int, unreadOnly bool) ([]*entity.Notification, int64, error) {
+	args := m.Called(ctx, userID, offset, limit, unreadOnly)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockNotificationDomainService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationDomainService) MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error) {
+	args := m.Called(ctx, userID, notificationID)
+	if notification, ok := args.Get(0).(*entity.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationDomainService) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationDomainService) DeleteNotification(ctx context.Context, userID, notificationID uint) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationDomainService) GetNotificationsAfter(ctx context.Context, userID, afterID uint, limit int) ([]*entity.Notification, error) {
+	args := m.Called(ctx, userID, afterID, limit)
+	if notifications, ok := args.Get(0).([]*entity.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationDomainService) OpenStream(ctx context.Context, userID uint, streamID string) (service.NotificationSubscription, error) {
+	args := m.Called(ctx, userID, streamID)
+	if subscription, ok := args.Get(0).(service.NotificationSubscription); ok {
+		return subscription, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationDomainService) KeepStreamAlive(ctx context.Context, userID uint, streamID string) error {
+	args := m.Called(ctx, userID, streamID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationDomainService) CloseStream(ctx context.Context, userID uint, streamID string, subscription service.NotificationSubscription) error {
+	args := m.Called(ctx, userID, streamID, subscription)
+	return args.Error(0)
+}
+
 type MockFraudDomainService struct {
 	mock.Mock
 }
@@ -116,6 +432,11 @@ func (m *MockFraudDomainService) DeactivateUserSession(ctx context.Context, sess
 	return args.Error(0)
 }
 
+func (m *MockFraudDomainService) DeactivateUserSessions(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *MockFraudDomainService) GetFraudStats(ctx context.Context) (map[string]interface{}, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -127,6 +448,22 @@ func (m *MockFraudDomainService) GetFraudStats(ctx context.Context) (map[string]
 	return nil, args.Error(1)
 }
 
+func (m *MockFraudDomainService) GetFraudRules(ctx context.Context) ([]*entity.FraudRuleConfig, error) {
+	args := m.Called(ctx)
+	if configs, ok := args.Get(0).([]*entity.FraudRuleConfig); ok {
+		return configs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFraudDomainService) UpdateFraudRule(ctx context.Context, name string, enabled *bool, weight *float64, threshold, windowSeconds *int) (*entity.FraudRuleConfig, error) {
+	args := m.Called(ctx, name, enabled, weight, threshold, windowSeconds)
+	if config, ok := args.Get(0).(*entity.FraudRuleConfig); ok {
+		return config, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockFraudDomainService) AddIPToBlacklist(ctx context.Context, ip, reason, clientIP, userAgent string) error {
 	args := m.Called(ctx, ip, reason, clientIP, userAgent)
 	return args.Error(0)
@@ -142,6 +479,21 @@ func (m *MockFraudDomainService) GetBlacklistedIPs(ctx context.Context, page, li
 	return args.Get(0), args.Error(1)
 }
 
+func (m *MockFraudDomainService) AddIPToAllowlist(ctx context.Context, ip, description, clientIP, userAgent string) error {
+	args := m.Called(ctx, ip, description, clientIP, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockFraudDomainService) RemoveIPFromAllowlist(ctx context.Context, ip, clientIP, userAgent string) error {
+	args := m.Called(ctx, ip, clientIP, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockFraudDomainService) GetAllowlistedIPs(ctx context.Context, page, limit int) (interface{}, error) {
+	args := m.Called(ctx, page, limit)
+	return args.Get(0), args.Error(1)
+}
+
 func (m *MockFraudDomainService) GetSecurityEvents(ctx context.Context, page, limit int) (interface{}, error) {
 	args := m.Called(ctx, page, limit)
 	if args.Get(0) == nil {
@@ -150,14 +502,20 @@ func (m *MockFraudDomainService) GetSecurityEvents(ctx context.Context, page, li
 	return args.Get(0), args.Error(1)
 }
 
-func (m *MockFraudDomainService) CreateRateLimitRule(ctx context.Context, name, pattern string, maxRequests, windowSize int64) error {
-	args := m.Called(ctx, name, pattern, maxRequests, windowSize)
-	return args.Error(0)
+func (m *MockFraudDomainService) CreateRateLimitRule(ctx context.Context, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error) {
+	args := m.Called(ctx, name, pattern, algorithm, maxRequests, windowSize)
+	if rule, ok := args.Get(0).(*entity.RateLimitRule); ok {
+		return rule, args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockFraudDomainService) UpdateRateLimitRule(ctx context.Context, id uint, name, pattern string, maxRequests, windowSize int64) error {
-	args := m.Called(ctx, id, name, pattern, maxRequests, windowSize)
-	return args.Error(0)
+func (m *MockFraudDomainService) UpdateRateLimitRule(ctx context.Context, id uint, name, pattern, algorithm string, maxRequests, windowSize int64) (*entity.RateLimitRule, error) {
+	args := m.Called(ctx, id, name, pattern, algorithm, maxRequests, windowSize)
+	if rule, ok := args.Get(0).(*entity.RateLimitRule); ok {
+		return rule, args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func (m *MockFraudDomainService) DeleteRateLimitRule(ctx context.Context, id uint) error {
@@ -191,12 +549,341 @@ func (m *MockFraudDomainService) GetDevices(ctx context.Context) (interface{}, e
 	return result, args.Error(1)
 }
 
-func (m *MockFraudDomainService) TrustDevice(ctx context.Context, fingerprint string) error {
+func (m *MockFraudDomainService) TrustDevice(ctx context.Context, fingerprint string) (*entity.DeviceFingerprint, error) {
 	args := m.Called(ctx, fingerprint)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DeviceFingerprint), args.Error(1)
 }
 
 func (m *MockFraudDomainService) CleanupExpiredData(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
+
+type MockPreferenceDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceDomainService) GetPreferences(ctx context.Context, userID uint) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID)
+	if values, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return values, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceDomainService) SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, values)
+	if result, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPreferenceDomainService) ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error) {
+	args := m.Called(ctx, userID, keys)
+	if values, ok := args.Get(0).([]entity.PreferenceValue); ok {
+		return values, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockAdminPointDomainService struct {
+	mock.Mock
+}
+
+func (m *MockAdminPointDomainService) GrantPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*service.AdminPointAdjustment, error) {
+	args := m.Called(ctx, adminUserID, userID, points, description, idempotencyKey)
+	if adjustment, ok := args.Get(0).(*service.AdminPointAdjustment); ok {
+		return adjustment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminPointDomainService) DeductPoints(ctx context.Context, adminUserID, userID uint, points int, description, idempotencyKey string) (*service.AdminPointAdjustment, error) {
+	args := m.Called(ctx, adminUserID, userID, points, description, idempotencyKey)
+	if adjustment, ok := args.Get(0).(*service.AdminPointAdjustment); ok {
+		return adjustment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockPermissionDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPermissionDomainService) ResolvePermissions(ctx context.Context, userID uint) (entity.PermissionSet, error) {
+	args := m.Called(ctx, userID)
+	if permissions, ok := args.Get(0).(entity.PermissionSet); ok {
+		return permissions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionDomainService) HasPermission(ctx context.Context, userID uint, required entity.PermissionGrant) (bool, error) {
+	args := m.Called(ctx, userID, required)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPermissionDomainService) GrantScope(ctx context.Context, userID uint, scopeName string, expiresAt time.Time) (*entity.UserScope, error) {
+	args := m.Called(ctx, userID, scopeName, expiresAt)
+	if userScope, ok := args.Get(0).(*entity.UserScope); ok {
+		return userScope, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPermissionDomainService) RevokeScope(ctx context.Context, userID uint, scopeName string) error {
+	args := m.Called(ctx, userID, scopeName)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) AssignRole(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) RemoveRole(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockPermissionDomainService) InvalidateUserPermissions(ctx context.Context, userID uint) {
+	m.Called(ctx, userID)
+}
+
+type MockRoleDomainService struct {
+	mock.Mock
+}
+
+func (m *MockRoleDomainService) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	args := m.Called(ctx)
+	if roles, ok := args.Get(0).([]*entity.Role); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleDomainService) GetRole(ctx context.Context, roleID uint) (*entity.Role, error) {
+	args := m.Called(ctx, roleID)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleDomainService) ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, roleID, offset, limit)
+	var users []*entity.User
+	if u, ok := args.Get(0).([]*entity.User); ok {
+		users = u
+	}
+	var total int64
+	if t, ok := args.Get(1).(int64); ok {
+		total = t
+	}
+	return users, total, args.Error(2)
+}
+
+func (m *MockRoleDomainService) CreateRole(ctx context.Context, actor service.RoleActor, name, description string, permissionNames []string) (*entity.Role, error) {
+	args := m.Called(ctx, actor, name, description, permissionNames)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleDomainService) UpdateRole(ctx context.Context, actor service.RoleActor, roleID uint, name, description *string, permissionNames []string) (*entity.Role, error) {
+	args := m.Called(ctx, actor, roleID, name, description, permissionNames)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleDomainService) DeleteRole(ctx context.Context, actor service.RoleActor, roleID uint) error {
+	args := m.Called(ctx, actor, roleID)
+	return args.Error(0)
+}
+
+func (m *MockRoleDomainService) AssignRole(ctx context.Context, actor service.RoleActor, userID, roleID uint) error {
+	args := m.Called(ctx, actor, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockRoleDomainService) RevokeRole(ctx context.Context, actor service.RoleActor, userID, roleID uint) error {
+	args := m.Called(ctx, actor, userID, roleID)
+	return args.Error(0)
+}
+
+type MockPartnerDomainService struct {
+	mock.Mock
+}
+
+func (m *MockPartnerDomainService) ListAPIKeys(ctx context.Context, partnerID string) ([]*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, partnerID)
+	if keys, ok := args.Get(0).([]*entity.PartnerAPIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) CreateAPIKey(ctx context.Context, partnerID, partnerName string, permissions []string, limitPerHour, limitPerDay int) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, partnerID, partnerName, permissions, limitPerHour, limitPerDay)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerDomainService) RotateAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, string, error) {
+	args := m.Called(ctx, keyID)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockPartnerDomainService) RevokeAPIKey(ctx context.Context, keyID uint) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, keyID)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) SetQuota(ctx context.Context, partnerID, endpoint string, limitPerHour, limitPerDay int) (*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID, endpoint, limitPerHour, limitPerDay)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) Authenticate(ctx context.Context, plaintext string) (*entity.PartnerAPIKey, error) {
+	args := m.Called(ctx, plaintext)
+	if key, ok := args.Get(0).(*entity.PartnerAPIKey); ok {
+		return key, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) ConsumeQuota(ctx context.Context, partnerID, endpoint string) (*entity.PartnerQuota, error) {
+	args := m.Called(ctx, partnerID, endpoint)
+	if quota, ok := args.Get(0).(*entity.PartnerQuota); ok {
+		return quota, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPartnerDomainService) ConsumeKeyRateLimit(ctx context.Context, key *entity.PartnerAPIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockOAuthDomainService struct {
+	mock.Mock
+}
+
+func (m *MockOAuthDomainService) RegisterClient(ctx context.Context, name string, redirectURIs, grantTypes, scopes []string, confidential bool, createdBy uint) (*entity.OAuthClient, string, error) {
+	args := m.Called(ctx, name, redirectURIs, grantTypes, scopes, confidential, createdBy)
+	if client, ok := args.Get(0).(*entity.OAuthClient); ok {
+		return client, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockOAuthDomainService) ListClients(ctx context.Context) ([]*entity.OAuthClient, error) {
+	args := m.Called(ctx)
+	if clients, ok := args.Get(0).([]*entity.OAuthClient); ok {
+		return clients, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) DeleteClient(ctx context.Context, clientID string) error {
+	args := m.Called(ctx, clientID)
+	return args.Error(0)
+}
+
+func (m *MockOAuthDomainService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error) {
+	args := m.Called(ctx, clientID, clientSecret)
+	if client, ok := args.Get(0).(*entity.OAuthClient); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) PrepareAuthorization(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (*entity.OAuthClient, []string, bool, error) {
+	args := m.Called(ctx, userID, req)
+	client, _ := args.Get(0).(*entity.OAuthClient)
+	scopes, _ := args.Get(1).([]string)
+	return client, scopes, args.Bool(2), args.Error(3)
+}
+
+func (m *MockOAuthDomainService) Authorize(ctx context.Context, userID uint, req *entity.OAuthAuthorizationRequest) (string, []string, error) {
+	args := m.Called(ctx, userID, req)
+	scopes, _ := args.Get(1).([]string)
+	return args.String(0), scopes, args.Error(2)
+}
+
+func (m *MockOAuthDomainService) ExchangeAuthorizationCode(ctx context.Context, client *entity.OAuthClient, code, redirectURI, codeVerifier string) (*entity.OAuthTokenSet, error) {
+	args := m.Called(ctx, client, code, redirectURI, codeVerifier)
+	if tokenSet, ok := args.Get(0).(*entity.OAuthTokenSet); ok {
+		return tokenSet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) ClientCredentials(ctx context.Context, client *entity.OAuthClient, scope string) (*entity.OAuthTokenSet, error) {
+	args := m.Called(ctx, client, scope)
+	if tokenSet, ok := args.Get(0).(*entity.OAuthTokenSet); ok {
+		return tokenSet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) RefreshAccessToken(ctx context.Context, client *entity.OAuthClient, refreshToken, scope string) (*entity.OAuthTokenSet, error) {
+	args := m.Called(ctx, client, refreshToken, scope)
+	if tokenSet, ok := args.Get(0).(*entity.OAuthTokenSet); ok {
+		return tokenSet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) Introspect(ctx context.Context, token, tokenTypeHint string) (*entity.OAuthTokenInfo, error) {
+	args := m.Called(ctx, token, tokenTypeHint)
+	if info, ok := args.Get(0).(*entity.OAuthTokenInfo); ok {
+		return info, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) Revoke(ctx context.Context, client *entity.OAuthClient, token, tokenTypeHint string) error {
+	args := m.Called(ctx, client, token, tokenTypeHint)
+	return args.Error(0)
+}
+
+func (m *MockOAuthDomainService) ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	args := m.Called(ctx, userID)
+	if consents, ok := args.Get(0).([]*entity.OAuthConsent); ok {
+		return consents, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockOAuthDomainService) RevokeConsent(ctx context.Context, userID uint, clientID string) error {
+	args := m.Called(ctx, userID, clientID)
+	return args.Error(0)
+}
+
+type MockDashboardInvalidator struct {
+	mock.Mock
+}
+
+func (m *MockDashboardInvalidator) InvalidateUserDashboard(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/google/uuid"
+)
+
+const (
+	maxNotificationFieldLength        = 255
+	notificationStreamBacklogPageSize = 100
+)
+
+type NotificationUsecase struct {
+	notificationDomainService service.NotificationDomainServiceInterface
+	userRepo                  repository.UserRepository
+	dashboard                 DashboardInvalidator
+}
+
+type NotificationListResponse struct {
+	Notifications []*entity.Notification
+	UnreadCount   int64
+	Total         int64
+	Page          int
+	Limit         int
+}
+
+type NotificationStream struct {
+	ID           string
+	UserID       uint
+	Backlog      []*entity.Notification
+	UnreadCount  int64
+	Subscription service.NotificationSubscription
+}
+
+func NewNotificationUsecase(notificationDomainService service.NotificationDomainServiceInterface, userRepo repository.UserRepository, dashboard DashboardInvalidator) *NotificationUsecase {
+	return &NotificationUsecase{
+		notificationDomainService: notificationDomainService,
+		userRepo:                  userRepo,
+		dashboard:                 dashboard,
+	}
+}
+
+func (u *NotificationUsecase) GetNotifications(ctx context.Context, userID uint, query *dto.NotificationQuery) (*NotificationListResponse, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := u.notificationDomainService.GetNotifications(ctx, userID, (page-1)*limit, limit, query.UnreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unreadCount, err := u.notificationDomainService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	return &NotificationListResponse{
+		Notifications: notifications,
+		UnreadCount:   unreadCount,
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+	}, nil
+}
+
+func (u *NotificationUsecase) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return u.notificationDomainService.GetUnreadCount(ctx, userID)
+}
+
+func (u *NotificationUsecase) MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error) {
+	notification, err := u.notificationDomainService.MarkAsRead(ctx, userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+	return notification, nil
+}
+
+func (u *NotificationUsecase) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	updated, err := u.notificationDomainService.MarkAllAsRead(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+	return updated, nil
+}
+
+func (u *NotificationUsecase) DeleteNotification(ctx context.Context, userID, notificationID uint) error {
+	if err := u.notificationDomainService.DeleteNotification(ctx, userID, notificationID); err != nil {
+		return err
+	}
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+	return nil
+}
+
+func (u *NotificationUsecase) CreateNotification(ctx context.Context, userID uint, req *dto.CreateNotificationRequest) (*entity.Notification, error) {
+	notificationType := strings.TrimSpace(req.Type)
+	title := strings.TrimSpace(req.Title)
+	message := strings.TrimSpace(req.Message)
+	if notificationType == "" || utf8.RuneCountInString(notificationType) > maxNotificationFieldLength {
+		return nil, fmt.Errorf("invalid notification type")
+	}
+	if title == "" || utf8.RuneCountInString(title) > maxNotificationFieldLength {
+		return nil, fmt.Errorf("invalid title: must be 1-%d characters", maxNotificationFieldLength)
+	}
+	if message == "" || utf8.RuneCountInString(message) > maxNotificationFieldLength {
+		return nil, fmt.Errorf("invalid message: must be 1-%d characters", maxNotificationFieldLength)
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = entity.NotificationPriorityMedium
+	}
+	if !entity.IsValidNotificationPriority(priority) {
+		return nil, fmt.Errorf("invalid priority level: %s", req.Priority)
+	}
+
+	var data *string
+	if len(bytes.TrimSpace(req.Data)) > 0 {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(req.Data, &payload); err != nil {
+			return nil, fmt.Errorf("invalid data: must be a JSON object")
+		}
+		encoded := string(req.Data)
+		data = &encoded
+	}
+
+	if _, err := u.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, service.ErrUserNotFound
+	}
+
+	notification := entity.NewNotification(userID, notificationType, title, message, data)
+	notification.Priority = priority
+	if err := u.notificationDomainService.CreateNotification(ctx, notification); err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+	invalidateUserDashboard(ctx, u.dashboard, userID)
+	return notification, nil
+}
+
+func (u *NotificationUsecase) OpenStream(ctx context.Context, userID uint, lastEventID string) (*NotificationStream, error) {
+	var afterID uint
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last event ID: %s", lastEventID)
+		}
+		afterID = uint(id)
+	}
+
+	streamID := uuid.NewString()
+	subscription, err := u.notificationDomainService.OpenStream(ctx, userID, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &NotificationStream{
+		ID:           streamID,
+		UserID:       userID,
+		Subscription: subscription,
+	}
+
+	if afterID > 0 {
+		stream.Backlog, err = u.missedNotifications(ctx, userID, afterID)
+		if err != nil {
+			_ = u.CloseStream(ctx, stream)
+			return nil, fmt.Errorf("failed to get missed notifications: %w", err)
+		}
+	}
+
+	stream.UnreadCount, err = u.notificationDomainService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		_ = u.CloseStream(ctx, stream)
+		return nil, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	return stream, nil
+}
+
+// missedNotifications pages through every notification after afterID so a reconnect never drops events.
+func (u *NotificationUsecase) missedNotifications(ctx context.Context, userID, afterID uint) ([]*entity.Notification, error) {
+	var backlog []*entity.Notification
+	for {
+		page, err := u.notificationDomainService.GetNotificationsAfter(ctx, userID, afterID, notificationStreamBacklogPageSize)
+		if err != nil {
+			return nil, err
+		}
+		backlog = append(backlog, page...)
+		if len(page) < notificationStreamBacklogPageSize {
+			return backlog, nil
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+func (u *NotificationUsecase) KeepStreamAlive(ctx context.Context, stream *NotificationStream) error {
+	return u.notificationDomainService.KeepStreamAlive(ctx, stream.UserID, stream.ID)
+}
+
+func (u *NotificationUsecase) CloseStream(ctx context.Context, stream *NotificationStream) error {
+	return u.notificationDomainService.CloseStream(ctx, stream.UserID, stream.ID, stream.Subscription)
+}
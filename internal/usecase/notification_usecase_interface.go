@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type NotificationUsecaseInterface interface {
+	GetNotifications(ctx context.Context, userID uint, query *dto.NotificationQuery) (*NotificationListResponse, error)
+	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
+	MarkAsRead(ctx context.Context, userID, notificationID uint) (*entity.Notification, error)
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	DeleteNotification(ctx context.Context, userID, notificationID uint) error
+	CreateNotification(ctx context.Context, userID uint, req *dto.CreateNotificationRequest) (*entity.Notification, error)
+	OpenStream(ctx context.Context, userID uint, lastEventID string) (*NotificationStream, error)
+	KeepStreamAlive(ctx context.Context, stream *NotificationStream) error
+	CloseStream(ctx context.Context, stream *NotificationStream) error
+}
@@ -0,0 +1,264 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationUsecaseGetNotifications(t *testing.T) {
+	notificationService := new(MockNotificationDomainService)
+	notificationService.On("GetNotifications", mock.Anything, uint(1), 0, 20, true).
+		Return([]*entity.Notification{entity.NewNotification(1, "system", "タイトル", "本文", nil)}, int64(1), nil)
+	notificationService.On("GetUnreadCount", mock.Anything, uint(1)).Return(int64(3), nil)
+
+	uc := usecase.NewNotificationUsecase(notificationService, new(MockUserRepository), nil)
+
+	response, err := uc.GetNotifications(context.Background(), 1, &dto.NotificationQuery{Limit: 500, UnreadOnly: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Page)
+	assert.Equal(t, 20, response.Limit)
+	assert.Equal(t, int64(3), response.UnreadCount)
+	assert.Len(t, response.Notifications, 1)
+	notificationService.AssertExpectations(t)
+}
+
+func TestNotificationUsecaseCreateNotification(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          dto.CreateNotificationRequest
+		setupMock    func(*MockNotificationDomainService, *MockUserRepository)
+		wantErr      string
+		wantPriority string
+	}{
+		{
+			name: "既定の優先度で作成",
+			req:  dto.CreateNotificationRequest{Type: "system", Title: "お知らせ", Message: "メンテナンスのお知らせ"},
+			setupMock: func(notificationService *MockNotificationDomainService, userRepo *MockUserRepository) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&entity.User{ID: 1}, nil)
+				notificationService.On("CreateNotification", mock.Anything, mock.MatchedBy(func(notification *entity.Notification) bool {
+					return notification.UserID == 1 && notification.Priority == entity.NotificationPriorityMedium
+				})).Return(nil)
+			},
+			wantPriority: entity.NotificationPriorityMedium,
+		},
+		{
+			name: "データ付きで作成",
+			req:  dto.CreateNotificationRequest{Type: "campaign", Title: "お知らせ", Message: "本文", Priority: "high", Data: json.RawMessage(`{"campaign_id":7}`)},
+			setupMock: func(notificationService *MockNotificationDomainService, userRepo *MockUserRepository) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&entity.User{ID: 1}, nil)
+				notificationService.On("CreateNotification", mock.Anything, mock.MatchedBy(func(notification *entity.Notification) bool {
+					return notification.Data != nil && *notification.Data == `{"campaign_id":7}`
+				})).Return(nil)
+			},
+			wantPriority: entity.NotificationPriorityHigh,
+		},
+		{
+			name:      "不正な優先度",
+			req:       dto.CreateNotificationRequest{Type: "system", Title: "お知らせ", Message: "本文", Priority: "critical"},
+			setupMock: func(*MockNotificationDomainService, *MockUserRepository) {},
+			wantErr:   "invalid priority level: critical",
+		},
+		{
+			name:      "タイトルが長すぎる",
+			req:       dto.CreateNotificationRequest{Type: "system", Title: strings.Repeat("あ", 256), Message: "本文"},
+			setupMock: func(*MockNotificationDomainService, *MockUserRepository) {},
+			wantErr:   "invalid title: must be 1-255 characters",
+		},
+		{
+			name:      "データがJSONオブジェクトではない",
+			req:       dto.CreateNotificationRequest{Type: "system", Title: "お知らせ", Message: "本文", Data: json.RawMessage(`"text"`)},
+			setupMock: func(*MockNotificationDomainService, *MockUserRepository) {},
+			wantErr:   "invalid data: must be a JSON object",
+		},
+		{
+			name: "存在しないユーザー",
+			req:  dto.CreateNotificationRequest{Type: "system", Title: "お知らせ", Message: "本文"},
+			setupMock: func(notificationService *MockNotificationDomainService, userRepo *MockUserRepository) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(nil, errors.New("record not found"))
+			},
+			wantErr: service.ErrUserNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationService := new(MockNotificationDomainService)
+			userRepo := new(MockUserRepository)
+			tt.setupMock(notificationService, userRepo)
+
+			uc := usecase.NewNotificationUsecase(notificationService, userRepo, nil)
+
+			notification, err := uc.CreateNotification(context.Background(), 1, &tt.req)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, notification)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPriority, notification.Priority)
+			}
+			notificationService.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+type stubNotificationSubscription struct {
+	events chan *entity.NotificationEvent
+}
+
+func (s *stubNotificationSubscription) Events() <-chan *entity.NotificationEvent {
+	return s.events
+}
+
+func (s *stubNotificationSubscription) Close() error {
+	return nil
+}
+
+func TestNotificationUsecaseOpenStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		lastEventID   string
+		setupMock     func(*MockNotificationDomainService, service.NotificationSubscription)
+		expectedError string
+		backlogSize   int
+	}{
+		{
+			name:        "Last-Event-ID以降の通知を取得する",
+			lastEventID: "10",
+			setupMock: func(notificationService *MockNotificationDomainService, subscription service.NotificationSubscription) {
+				notificationService.On("OpenStream", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(subscription, nil)
+				notificationService.On("GetNotificationsAfter", mock.Anything, uint(1), uint(10), 100).
+					Return([]*entity.Notification{
+						entity.NewNotification(1, "system", "タイトル1", "本文", nil),
+						entity.NewNotification(1, "system", "タイトル2", "本文", nil),
+					}, nil)
+				notificationService.On("GetUnreadCount", mock.Anything, uint(1)).Return(int64(5), nil)
+			},
+			backlogSize: 2,
+		},
+		{
+			name:        "取りこぼしが1ページを超えても全件を取得する",
+			lastEventID: "10",
+			setupMock: func(notificationService *MockNotificationDomainService, subscription service.NotificationSubscription) {
+				firstPage := make([]*entity.Notification, 100)
+				for i := range firstPage {
+					firstPage[i] = entity.NewNotification(1, "system", "タイトル", "本文", nil)
+					firstPage[i].ID = uint(11 + i)
+				}
+				notificationService.On("OpenStream", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(subscription, nil)
+				notificationService.On("GetNotificationsAfter", mock.Anything, uint(1), uint(10), 100).Return(firstPage, nil)
+				notificationService.On("GetNotificationsAfter", mock.Anything, uint(1), uint(110), 100).
+					Return([]*entity.Notification{entity.NewNotification(1, "system", "タイトル", "本文", nil)}, nil)
+				notificationService.On("GetUnreadCount", mock.Anything, uint(1)).Return(int64(101), nil)
+			},
+			backlogSize: 101,
+		},
+		{
+			name: "初回接続では過去の通知を取得しない",
+			setupMock: func(notificationService *MockNotificationDomainService, subscription service.NotificationSubscription) {
+				notificationService.On("OpenStream", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(subscription, nil)
+				notificationService.On("GetUnreadCount", mock.Anything, uint(1)).Return(int64(0), nil)
+			},
+		},
+		{
+			name:          "不正なLast-Event-ID",
+			lastEventID:   "abc",
+			setupMock:     func(*MockNotificationDomainService, service.NotificationSubscription) {},
+			expectedError: "invalid last event ID",
+		},
+		{
+			name: "同時接続数の上限",
+			setupMock: func(notificationService *MockNotificationDomainService, _ service.NotificationSubscription) {
+				notificationService.On("OpenStream", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(nil, service.ErrNotificationStreamLimitExceeded)
+			},
+			expectedError: service.ErrNotificationStreamLimitExceeded.Error(),
+		},
+		{
+			name: "未読数の取得に失敗した場合はストリームを閉じる",
+			setupMock: func(notificationService *MockNotificationDomainService, subscription service.NotificationSubscription) {
+				notificationService.On("OpenStream", mock.Anything, uint(1), mock.AnythingOfType("string")).Return(subscription, nil)
+				notificationService.On("GetUnreadCount", mock.Anything, uint(1)).Return(int64(0), errors.New("database error"))
+				notificationService.On("CloseStream", mock.Anything, uint(1), mock.AnythingOfType("string"), subscription).Return(nil)
+			},
+			expectedError: "failed to get unread count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationService := new(MockNotificationDomainService)
+			subscription := &stubNotificationSubscription{events: make(chan *entity.NotificationEvent)}
+			tt.setupMock(notificationService, subscription)
+
+			uc := usecase.NewNotificationUsecase(notificationService, new(MockUserRepository), nil)
+
+			stream, err := uc.OpenStream(context.Background(), 1, tt.lastEventID)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, stream)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, stream.ID)
+				assert.Len(t, stream.Backlog, tt.backlogSize)
+				assert.Equal(t, subscription, stream.Subscription)
+			}
+			notificationService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationUsecaseMarkAllAsReadInvalidatesDashboard(t *testing.T) {
+	tests := []struct {
+		name           string
+		markErr        error
+		wantInvalidate bool
+	}{
+		{
+			name:           "既読にしたらダッシュボードのキャッシュを破棄する",
+			wantInvalidate: true,
+		},
+		{
+			name:    "既読化に失敗したらキャッシュを破棄しない",
+			markErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationService := new(MockNotificationDomainService)
+			notificationService.On("MarkAllAsRead", mock.Anything, uint(1)).Return(int64(3), tt.markErr)
+			dashboard := new(MockDashboardInvalidator)
+			if tt.wantInvalidate {
+				dashboard.On("InvalidateUserDashboard", mock.Anything, uint(1)).Return(errors.New("redis error"))
+			}
+
+			uc := usecase.NewNotificationUsecase(notificationService, new(MockUserRepository), dashboard)
+
+			updated, err := uc.MarkAllAsRead(context.Background(), 1)
+
+			if tt.markErr != nil {
+				assert.ErrorIs(t, err, tt.markErr)
+				dashboard.AssertNotCalled(t, "InvalidateUserDashboard", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(3), updated)
+			}
+			notificationService.AssertExpectations(t)
+			dashboard.AssertExpectations(t)
+		})
+	}
+}
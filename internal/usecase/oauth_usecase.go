@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type OAuthUsecase struct {
+	oauthDomainService service.OAuthDomainServiceInterface
+	fraudDomainService service.FraudDomainServiceInterface
+}
+
+func NewOAuthUsecase(oauthDomainService service.OAuthDomainServiceInterface, fraudDomainService service.FraudDomainServiceInterface) *OAuthUsecase {
+	return &OAuthUsecase{
+		oauthDomainService: oauthDomainService,
+		fraudDomainService: fraudDomainService,
+	}
+}
+
+func (u *OAuthUsecase) RegisterClient(ctx context.Context, adminUserID uint, req *dto.RegisterOAuthClientRequest, ipAddress, userAgent string) (*entity.OAuthClient, string, error) {
+	confidential := true
+	if req.Confidential != nil {
+		confidential = *req.Confidential
+	}
+
+	client, secret, err := u.oauthDomainService.RegisterClient(ctx,
+		strings.TrimSpace(req.Name),
+		normalizePermissionNames(req.RedirectURIs),
+		normalizePermissionNames(req.GrantTypes),
+		normalizePermissionNames(req.Scopes),
+		confidential,
+		adminUserID,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to register oauth client: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &adminUserID, entity.SecurityEventOAuthClientRegistered,
+		fmt.Sprintf("OAuth client %s (%s) registered by admin %d with grants %v and scopes %v", client.ClientID, client.Name, adminUserID, client.GrantTypes, client.Scopes),
+		ipAddress, userAgent, "MEDIUM")
+
+	return client, secret, nil
+}
+
+func (u *OAuthUsecase) ListClients(ctx context.Context) ([]*entity.OAuthClient, error) {
+	clients, err := u.oauthDomainService.ListClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+	return clients, nil
+}
+
+func (u *OAuthUsecase) DeleteClient(ctx context.Context, adminUserID uint, clientID, ipAddress, userAgent string) error {
+	if err := u.oauthDomainService.DeleteClient(ctx, clientID); err != nil {
+		return fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &adminUserID, entity.SecurityEventOAuthClientDeleted,
+		fmt.Sprintf("OAuth client %s deleted by admin %d", clientID, adminUserID),
+		ipAddress, userAgent, "MEDIUM")
+
+	return nil
+}
+
+func (u *OAuthUsecase) PrepareAuthorization(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest) (*entity.OAuthClient, []string, bool, error) {
+	return u.oauthDomainService.PrepareAuthorization(ctx, userID, oauthAuthorizationRequest(req))
+}
+
+func (u *OAuthUsecase) Authorize(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest, ipAddress, userAgent string) (string, error) {
+	code, scopes, err := u.oauthDomainService.Authorize(ctx, userID, oauthAuthorizationRequest(req))
+	if err != nil {
+		return "", err
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, entity.SecurityEventOAuthConsentGranted,
+		fmt.Sprintf("User %d authorized OAuth client %s for scopes %v", userID, req.ClientID, scopes),
+		ipAddress, userAgent, "LOW")
+
+	return code, nil
+}
+
+func (u *OAuthUsecase) Token(ctx context.Context, req *dto.OAuthTokenRequest, ipAddress, userAgent string) (*entity.OAuthTokenSet, error) {
+	if req.GrantType == "" {
+		return nil, fmt.Errorf("%w: grant_type is required", entity.ErrOAuthInvalidRequest)
+	}
+
+	client, err := u.oauthDomainService.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenSet *entity.OAuthTokenSet
+	switch req.GrantType {
+	case entity.OAuthGrantTypeAuthorizationCode:
+		tokenSet, err = u.oauthDomainService.ExchangeAuthorizationCode(ctx, client, req.Code, req.RedirectURI, req.CodeVerifier)
+	case entity.OAuthGrantTypeClientCredentials:
+		tokenSet, err = u.oauthDomainService.ClientCredentials(ctx, client, req.Scope)
+	case entity.OAuthGrantTypeRefreshToken:
+		tokenSet, err = u.oauthDomainService.RefreshAccessToken(ctx, client, req.RefreshToken, req.Scope)
+	default:
+		return nil, entity.ErrOAuthUnsupportedGrantType
+	}
+
+	var reuseErr *service.OAuthTokenReuseError
+	if errors.As(err, &reuseErr) {
+		_ = u.fraudDomainService.CreateSecurityEvent(ctx, &reuseErr.UserID, entity.SecurityEventOAuthTokenReuse,
+			fmt.Sprintf("Reused %s grant for OAuth client %s; refresh tokens of the grant revoked", req.GrantType, reuseErr.ClientID),
+			ipAddress, userAgent, "HIGH")
+	}
+	return tokenSet, err
+}
+
+func (u *OAuthUsecase) Introspect(ctx context.Context, req *dto.OAuthIntrospectionRequest) (*entity.OAuthTokenInfo, error) {
+	client, err := u.oauthDomainService.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.Confidential {
+		return nil, entity.ErrOAuthInvalidClient
+	}
+
+	return u.oauthDomainService.Introspect(ctx, req.Token, req.TokenTypeHint)
+}
+
+func (u *OAuthUsecase) Revoke(ctx context.Context, req *dto.OAuthRevocationRequest) error {
+	client, err := u.oauthDomainService.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	return u.oauthDomainService.Revoke(ctx, client, req.Token, req.TokenTypeHint)
+}
+
+func (u *OAuthUsecase) ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error) {
+	consents, err := u.oauthDomainService.ListConsents(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+	return consents, nil
+}
+
+func (u *OAuthUsecase) RevokeConsent(ctx context.Context, userID uint, clientID, ipAddress, userAgent string) error {
+	if err := u.oauthDomainService.RevokeConsent(ctx, userID, clientID); err != nil {
+		return fmt.Errorf("failed to revoke oauth consent: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, entity.SecurityEventOAuthConsentRevoked,
+		fmt.Sprintf("User %d revoked consent for OAuth client %s", userID, clientID),
+		ipAddress, userAgent, "LOW")
+
+	return nil
+}
+
+func oauthAuthorizationRequest(req *dto.OAuthAuthorizeRequest) *entity.OAuthAuthorizationRequest {
+	return &entity.OAuthAuthorizationRequest{
+		ResponseType:        req.ResponseType,
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		State:               req.State,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+	}
+}
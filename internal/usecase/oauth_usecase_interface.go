@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type OAuthUsecaseInterface interface {
+	RegisterClient(ctx context.Context, adminUserID uint, req *dto.RegisterOAuthClientRequest, ipAddress, userAgent string) (*entity.OAuthClient, string, error)
+	ListClients(ctx context.Context) ([]*entity.OAuthClient, error)
+	DeleteClient(ctx context.Context, adminUserID uint, clientID, ipAddress, userAgent string) error
+	PrepareAuthorization(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest) (*entity.OAuthClient, []string, bool, error)
+	Authorize(ctx context.Context, userID uint, req *dto.OAuthAuthorizeRequest, ipAddress, userAgent string) (string, error)
+	Token(ctx context.Context, req *dto.OAuthTokenRequest, ipAddress, userAgent string) (*entity.OAuthTokenSet, error)
+	Introspect(ctx context.Context, req *dto.OAuthIntrospectionRequest) (*entity.OAuthTokenInfo, error)
+	Revoke(ctx context.Context, req *dto.OAuthRevocationRequest) error
+	ListConsents(ctx context.Context, userID uint) ([]*entity.OAuthConsent, error)
+	RevokeConsent(ctx context.Context, userID uint, clientID, ipAddress, userAgent string) error
+}
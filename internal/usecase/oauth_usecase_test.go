@@ -0,0 +1,184 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOAuthUsecaseRegisterClient(t *testing.T) {
+	t.Run("既定では機密クライアントとして登録する", func(t *testing.T) {
+		oauthService := new(MockOAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+		uc := usecase.NewOAuthUsecase(oauthService, fraudService)
+		client := &entity.OAuthClient{ClientID: "oc_shop", Name: "Shop App", Confidential: true}
+
+		oauthService.On("RegisterClient", mock.Anything, "Shop App", []string{"https://shop.example.com/callback"},
+			[]string{"authorization_code"}, []string{"profile"}, true, uint(1)).Return(client, "secret", nil)
+		fraudService.On("CreateSecurityEvent", mock.Anything, mock.Anything, entity.SecurityEventOAuthClientRegistered,
+			mock.Anything, "192.0.2.1", "test-agent", "MEDIUM").Return(nil)
+
+		result, secret, err := uc.RegisterClient(context.Background(), 1, &dto.RegisterOAuthClientRequest{
+			Name:         " Shop App ",
+			RedirectURIs: []string{" https://shop.example.com/callback "},
+			GrantTypes:   []string{"authorization_code", "authorization_code"},
+			Scopes:       []string{"profile"},
+		}, "192.0.2.1", "test-agent")
+
+		assert.NoError(t, err)
+		assert.Equal(t, client, result)
+		assert.Equal(t, "secret", secret)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("不正なリダイレクトURI", func(t *testing.T) {
+		oauthService := new(MockOAuthDomainService)
+		fraudService := new(MockFraudDomainService)
+		uc := usecase.NewOAuthUsecase(oauthService, fraudService)
+		confidential := false
+
+		oauthService.On("RegisterClient", mock.Anything, "CLI", []string{"http://example.com"}, []string{"authorization_code"}, []string{"profile"}, false, uint(1)).
+			Return(nil, "", entity.ErrOAuthInvalidRedirectURI)
+
+		_, _, err := uc.RegisterClient(context.Background(), 1, &dto.RegisterOAuthClientRequest{
+			Name:         "CLI",
+			RedirectURIs: []string{"http://example.com"},
+			GrantTypes:   []string{"authorization_code"},
+			Scopes:       []string{"profile"},
+			Confidential: &confidential,
+		}, "", "")
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidRedirectURI)
+		fraudService.AssertNotCalled(t, "CreateSecurityEvent")
+	})
+}
+
+func TestOAuthUsecaseToken(t *testing.T) {
+	client := &entity.OAuthClient{ClientID: "oc_shop", Confidential: true}
+	tokenSet := &entity.OAuthTokenSet{AccessToken: "access", TokenType: entity.OAuthTokenTypeBearer, ExpiresIn: 3600}
+
+	tests := []struct {
+		name        string
+		req         *dto.OAuthTokenRequest
+		setupMock   func(*MockOAuthDomainService, *MockFraudDomainService)
+		expectedErr error
+	}{
+		{
+			name: "認可コードを交換する",
+			req:  &dto.OAuthTokenRequest{GrantType: "authorization_code", Code: "code", RedirectURI: "https://shop.example.com/callback", CodeVerifier: "verifier", ClientID: "oc_shop", ClientSecret: "secret"},
+			setupMock: func(o *MockOAuthDomainService, f *MockFraudDomainService) {
+				o.On("AuthenticateClient", mock.Anything, "oc_shop", "secret").Return(client, nil)
+				o.On("ExchangeAuthorizationCode", mock.Anything, client, "code", "https://shop.example.com/callback", "verifier").Return(tokenSet, nil)
+			},
+		},
+		{
+			name: "クライアントクレデンシャル",
+			req:  &dto.OAuthTokenRequest{GrantType: "client_credentials", Scope: "inventory:read", ClientID: "oc_shop", ClientSecret: "secret"},
+			setupMock: func(o *MockOAuthDomainService, f *MockFraudDomainService) {
+				o.On("AuthenticateClient", mock.Anything, "oc_shop", "secret").Return(client, nil)
+				o.On("ClientCredentials", mock.Anything, client, "inventory:read").Return(tokenSet, nil)
+			},
+		},
+		{
+			name: "クライアント認証に失敗",
+			req:  &dto.OAuthTokenRequest{GrantType: "client_credentials", ClientID: "oc_shop", ClientSecret: "wrong"},
+			setupMock: func(o *MockOAuthDomainService, f *MockFraudDomainService) {
+				o.On("AuthenticateClient", mock.Anything, "oc_shop", "wrong").Return(nil, entity.ErrOAuthInvalidClient)
+			},
+			expectedErr: entity.ErrOAuthInvalidClient,
+		},
+		{
+			name: "未対応のグラントタイプ",
+			req:  &dto.OAuthTokenRequest{GrantType: "password", ClientID: "oc_shop", ClientSecret: "secret"},
+			setupMock: func(o *MockOAuthDomainService, f *MockFraudDomainService) {
+				o.On("AuthenticateClient", mock.Anything, "oc_shop", "secret").Return(client, nil)
+			},
+			expectedErr: entity.ErrOAuthUnsupportedGrantType,
+		},
+		{
+			name:        "グラントタイプがない",
+			req:         &dto.OAuthTokenRequest{ClientID: "oc_shop"},
+			setupMock:   func(o *MockOAuthDomainService, f *MockFraudDomainService) {},
+			expectedErr: entity.ErrOAuthInvalidRequest,
+		},
+		{
+			name: "リフレッシュトークンの再利用を記録する",
+			req:  &dto.OAuthTokenRequest{GrantType: "refresh_token", RefreshToken: "old", ClientID: "oc_shop", ClientSecret: "secret"},
+			setupMock: func(o *MockOAuthDomainService, f *MockFraudDomainService) {
+				o.On("AuthenticateClient", mock.Anything, "oc_shop", "secret").Return(client, nil)
+				o.On("RefreshAccessToken", mock.Anything, client, "old", "").Return(nil, &service.OAuthTokenReuseError{UserID: 7, ClientID: "oc_shop"})
+				f.On("CreateSecurityEvent", mock.Anything, &[]uint{7}[0], entity.SecurityEventOAuthTokenReuse, mock.Anything, "192.0.2.1", "test-agent", "HIGH").Return(nil)
+			},
+			expectedErr: entity.ErrOAuthInvalidGrant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oauthService := new(MockOAuthDomainService)
+			fraudService := new(MockFraudDomainService)
+			tt.setupMock(oauthService, fraudService)
+			uc := usecase.NewOAuthUsecase(oauthService, fraudService)
+
+			result, err := uc.Token(context.Background(), tt.req, "192.0.2.1", "test-agent")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tokenSet, result)
+			}
+			oauthService.AssertExpectations(t)
+			fraudService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthUsecaseIntrospect(t *testing.T) {
+	t.Run("公開クライアントは問い合わせできない", func(t *testing.T) {
+		oauthService := new(MockOAuthDomainService)
+		uc := usecase.NewOAuthUsecase(oauthService, new(MockFraudDomainService))
+
+		oauthService.On("AuthenticateClient", mock.Anything, "oc_cli", "").Return(&entity.OAuthClient{ClientID: "oc_cli"}, nil)
+
+		_, err := uc.Introspect(context.Background(), &dto.OAuthIntrospectionRequest{Token: "token", ClientID: "oc_cli"})
+
+		assert.ErrorIs(t, err, entity.ErrOAuthInvalidClient)
+		oauthService.AssertNotCalled(t, "Introspect", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("機密クライアントの問い合わせ", func(t *testing.T) {
+		oauthService := new(MockOAuthDomainService)
+		uc := usecase.NewOAuthUsecase(oauthService, new(MockFraudDomainService))
+		info := &entity.OAuthTokenInfo{Active: true}
+
+		oauthService.On("AuthenticateClient", mock.Anything, "oc_api", "secret").Return(&entity.OAuthClient{ClientID: "oc_api", Confidential: true}, nil)
+		oauthService.On("Introspect", mock.Anything, "token", "access_token").Return(info, nil)
+
+		result, err := uc.Introspect(context.Background(), &dto.OAuthIntrospectionRequest{Token: "token", TokenTypeHint: "access_token", ClientID: "oc_api", ClientSecret: "secret"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, info, result)
+	})
+}
+
+func TestOAuthUsecaseRevokeConsent(t *testing.T) {
+	oauthService := new(MockOAuthDomainService)
+	fraudService := new(MockFraudDomainService)
+	uc := usecase.NewOAuthUsecase(oauthService, fraudService)
+
+	oauthService.On("RevokeConsent", mock.Anything, uint(1), "oc_shop").Return(entity.ErrOAuthConsentNotFound)
+
+	err := uc.RevokeConsent(context.Background(), 1, "oc_shop", "", "")
+
+	assert.ErrorIs(t, err, entity.ErrOAuthConsentNotFound)
+	assert.Contains(t, err.Error(), "not found")
+	fraudService.AssertNotCalled(t, "CreateSecurityEvent")
+}
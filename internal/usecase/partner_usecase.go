@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type PartnerUsecase struct {
+	partnerDomainService service.PartnerDomainServiceInterface
+	fraudDomainService   service.FraudDomainServiceInterface
+}
+
+func NewPartnerUsecase(partnerDomainService service.PartnerDomainServiceInterface, fraudDomainService service.FraudDomainServiceInterface) *PartnerUsecase {
+	return &PartnerUsecase{
+		partnerDomainService: partnerDomainService,
+		fraudDomainService:   fraudDomainService,
+	}
+}
+
+func (u *PartnerUsecase) ListAPIKeys(ctx context.Context, query *dto.PartnerAPIKeysQuery) ([]*entity.PartnerAPIKey, error) {
+	keys, err := u.partnerDomainService.ListAPIKeys(ctx, strings.TrimSpace(query.PartnerID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partner api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (u *PartnerUsecase) CreateAPIKey(ctx context.Context, adminUserID uint, req *dto.CreatePartnerAPIKeyRequest, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error) {
+	key, plaintext, err := u.partnerDomainService.CreateAPIKey(ctx,
+		strings.TrimSpace(req.PartnerID),
+		strings.TrimSpace(req.PartnerName),
+		normalizePermissionNames(req.Permissions),
+		req.LimitPerHour,
+		req.LimitPerDay,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create partner api key: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &adminUserID, entity.SecurityEventPartnerAPIKeyCreated,
+		fmt.Sprintf("Partner API key %s issued to %s by admin %d with permissions %v", key.KeyPrefix, key.PartnerID, adminUserID, key.Permissions),
+		ipAddress, userAgent, "MEDIUM")
+
+	return key, plaintext, nil
+}
+
+func (u *PartnerUsecase) RotateAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error) {
+	key, plaintext, err := u.partnerDomainService.RotateAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to rotate partner api key: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &adminUserID, entity.SecurityEventPartnerAPIKeyRotated,
+		fmt.Sprintf("Partner API key %d of %s rotated to %s by admin %d", key.ID, key.PartnerID, key.KeyPrefix, adminUserID),
+		ipAddress, userAgent, "MEDIUM")
+
+	return key, plaintext, nil
+}
+
+func (u *PartnerUsecase) RevokeAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) error {
+	key, err := u.partnerDomainService.RevokeAPIKey(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke partner api key: %w", err)
+	}
+
+	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &adminUserID, entity.SecurityEventPartnerAPIKeyRevoked,
+		fmt.Sprintf("Partner API key %s of %s revoked by admin %d", key.KeyPrefix, key.PartnerID, adminUserID),
+		ipAddress, userAgent, "MEDIUM")
+
+	return nil
+}
+
+func (u *PartnerUsecase) SetQuota(ctx context.Context, partnerID string, req *dto.SetPartnerQuotaRequest) (*entity.PartnerQuota, error) {
+	quota, err := u.partnerDomainService.SetQuota(ctx, strings.TrimSpace(partnerID), strings.TrimSpace(req.Endpoint), req.LimitPerHour, req.LimitPerDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set partner quota: %w", err)
+	}
+	return quota, nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PartnerUsecaseInterface interface {
+	ListAPIKeys(ctx context.Context, query *dto.PartnerAPIKeysQuery) ([]*entity.PartnerAPIKey, error)
+	CreateAPIKey(ctx context.Context, adminUserID uint, req *dto.CreatePartnerAPIKeyRequest, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error)
+	RotateAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) (*entity.PartnerAPIKey, string, error)
+	RevokeAPIKey(ctx context.Context, adminUserID, keyID uint, ipAddress, userAgent string) error
+	SetQuota(ctx context.Context, partnerID string, req *dto.SetPartnerQuotaRequest) (*entity.PartnerQuota, error)
+}
@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPartnerUsecaseCreateAPIKey(t *testing.T) {
+	t.Run("入力を正規化して発行しイベントを記録する", func(t *testing.T) {
+		partnerService := new(MockPartnerDomainService)
+		fraudService := new(MockFraudDomainService)
+		uc := usecase.NewPartnerUsecase(partnerService, fraudService)
+		key := &entity.PartnerAPIKey{ID: 4, PartnerID: "PARTNER_001", KeyPrefix: "pk_0123abcd", Enabled: true}
+
+		partnerService.On("CreateAPIKey", mock.Anything, "PARTNER_001", "POS", []string{"integration:read"}, 100, 1000).
+			Return(key, "pk_0123abcd.secret", nil)
+		fraudService.On("CreateSecurityEvent", mock.Anything, mock.Anything, entity.SecurityEventPartnerAPIKeyCreated,
+			mock.Anything, "192.0.2.1", "test-agent", "MEDIUM").Return(nil)
+
+		result, plaintext, err := uc.CreateAPIKey(context.Background(), 1, &dto.CreatePartnerAPIKeyRequest{
+			PartnerID:    " PARTNER_001 ",
+			PartnerName:  "POS",
+			Permissions:  []string{"integration:read", " integration:read "},
+			LimitPerHour: 100,
+			LimitPerDay:  1000,
+		}, "192.0.2.1", "test-agent")
+
+		assert.NoError(t, err)
+		assert.Equal(t, key, result)
+		assert.Equal(t, "pk_0123abcd.secret", plaintext)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("失敗時はイベントを記録しない", func(t *testing.T) {
+		partnerService := new(MockPartnerDomainService)
+		fraudService := new(MockFraudDomainService)
+		uc := usecase.NewPartnerUsecase(partnerService, fraudService)
+
+		partnerService.On("CreateAPIKey", mock.Anything, "PARTNER_001", "", []string{"bad"}, 100, 1000).
+			Return(nil, "", entity.ErrInvalidPermission)
+
+		_, _, err := uc.CreateAPIKey(context.Background(), 1, &dto.CreatePartnerAPIKeyRequest{
+			PartnerID:    "PARTNER_001",
+			Permissions:  []string{"bad"},
+			LimitPerHour: 100,
+			LimitPerDay:  1000,
+		}, "", "")
+
+		assert.ErrorIs(t, err, entity.ErrInvalidPermission)
+		fraudService.AssertNotCalled(t, "CreateSecurityEvent")
+	})
+}
+
+func TestPartnerUsecaseRevokeAPIKey(t *testing.T) {
+	t.Run("失効してイベントを記録する", func(t *testing.T) {
+		partnerService := new(MockPartnerDomainService)
+		fraudService := new(MockFraudDomainService)
+		uc := usecase.NewPartnerUsecase(partnerService, fraudService)
+
+		partnerService.On("RevokeAPIKey", mock.Anything, uint(4)).
+			Return(&entity.PartnerAPIKey{ID: 4, PartnerID: "PARTNER_001", KeyPrefix: "pk_0123abcd"}, nil)
+		fraudService.On("CreateSecurityEvent", mock.Anything, mock.Anything, entity.SecurityEventPartnerAPIKeyRevoked,
+			mock.Anything, mock.Anything, mock.Anything, "MEDIUM").Return(nil)
+
+		err := uc.RevokeAPIKey(context.Background(), 1, 4, "", "")
+
+		assert.NoError(t, err)
+		fraudService.AssertExpectations(t)
+	})
+
+	t.Run("存在しないキー", func(t *testing.T) {
+		partnerService := new(MockPartnerDomainService)
+		uc := usecase.NewPartnerUsecase(partnerService, new(MockFraudDomainService))
+
+		partnerService.On("RevokeAPIKey", mock.Anything, uint(9)).Return(nil, entity.ErrPartnerAPIKeyNotFound)
+
+		err := uc.RevokeAPIKey(context.Background(), 1, 9, "", "")
+
+		assert.ErrorIs(t, err, entity.ErrPartnerAPIKeyNotFound)
+	})
+}
+
+func TestPartnerUsecaseSetQuota(t *testing.T) {
+	partnerService := new(MockPartnerDomainService)
+	uc := usecase.NewPartnerUsecase(partnerService, new(MockFraudDomainService))
+
+	partnerService.On("SetQuota", mock.Anything, "PARTNER_001", "/api/v1/integration/*", 10, 100).
+		Return(nil, errors.New("db error"))
+
+	_, err := uc.SetQuota(context.Background(), "PARTNER_001", &dto.SetPartnerQuotaRequest{
+		Endpoint:     " /api/v1/integration/* ",
+		LimitPerHour: 10,
+		LimitPerDay:  100,
+	})
+
+	assert.EqualError(t, err, "failed to set partner quota: db error")
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const MaxUserScopeDuration = 90 * 24 * time.Hour
+
+type PermissionUsecase struct {
+	permissionDomainService service.PermissionDomainServiceInterface
+}
+
+func NewPermissionUsecase(permissionDomainService service.PermissionDomainServiceInterface) *PermissionUsecase {
+	return &PermissionUsecase{
+		permissionDomainService: permissionDomainService,
+	}
+}
+
+func (u *PermissionUsecase) GetUserPermissions(ctx context.Context, userID uint) (entity.PermissionSet, error) {
+	permissions, err := u.permissionDomainService.ResolvePermissions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+func (u *PermissionUsecase) GrantUserScope(ctx context.Context, userID uint, req *dto.GrantScopeRequest) (*entity.UserScope, error) {
+	scopeName := strings.TrimSpace(req.Scope)
+	if scopeName == "" {
+		return nil, fmt.Errorf("invalid scope: name is required")
+	}
+	if req.ExpiresAt.After(time.Now().Add(MaxUserScopeDuration)) {
+		return nil, fmt.Errorf("invalid scope expiry: must be within %d days", int(MaxUserScopeDuration.Hours()/24))
+	}
+
+	userScope, err := u.permissionDomainService.GrantScope(ctx, userID, scopeName, req.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant scope: %w", err)
+	}
+	return userScope, nil
+}
+
+func (u *PermissionUsecase) RevokeUserScope(ctx context.Context, userID uint, scopeName string) error {
+	if err := u.permissionDomainService.RevokeScope(ctx, userID, scopeName); err != nil {
+		return fmt.Errorf("failed to revoke scope: %w", err)
+	}
+	return nil
+}
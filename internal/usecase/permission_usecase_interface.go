@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PermissionUsecaseInterface interface {
+	GetUserPermissions(ctx context.Context, userID uint) (entity.PermissionSet, error)
+	GrantUserScope(ctx context.Context, userID uint, req *dto.GrantScopeRequest) (*entity.UserScope, error)
+	RevokeUserScope(ctx context.Context, userID uint, scopeName string) error
+}
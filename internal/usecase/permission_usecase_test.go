@@ -0,0 +1,110 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPermissionUsecaseGetUserPermissions(t *testing.T) {
+	t.Run("解決した権限を返す", func(t *testing.T) {
+		permissionService := &MockPermissionDomainService{}
+		uc := usecase.NewPermissionUsecase(permissionService)
+		permissions := entity.NewPermissionSet(entity.MustParsePermissionGrant("fraud:write"))
+
+		permissionService.On("ResolvePermissions", mock.Anything, uint(1)).Return(permissions, nil)
+
+		result, err := uc.GetUserPermissions(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, permissions, result)
+	})
+
+	t.Run("解決に失敗", func(t *testing.T) {
+		permissionService := &MockPermissionDomainService{}
+		uc := usecase.NewPermissionUsecase(permissionService)
+
+		permissionService.On("ResolvePermissions", mock.Anything, uint(1)).Return(nil, errors.New("db error"))
+
+		_, err := uc.GetUserPermissions(context.Background(), 1)
+
+		assert.ErrorContains(t, err, "failed to resolve permissions")
+	})
+}
+
+func TestPermissionUsecaseGrantUserScope(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	userScope := &entity.UserScope{ID: 1, UserID: 1, ScopeID: 4, ExpiresAt: &expiresAt}
+
+	tests := []struct {
+		name          string
+		req           dto.GrantScopeRequest
+		setupMock     func(*MockPermissionDomainService)
+		expectedError string
+	}{
+		{
+			name: "正常に付与",
+			req:  dto.GrantScopeRequest{Scope: " fraud ", ExpiresAt: expiresAt},
+			setupMock: func(m *MockPermissionDomainService) {
+				m.On("GrantScope", mock.Anything, uint(1), "fraud", expiresAt).Return(userScope, nil)
+			},
+		},
+		{
+			name:          "スコープ名が空",
+			req:           dto.GrantScopeRequest{Scope: " ", ExpiresAt: expiresAt},
+			setupMock:     func(m *MockPermissionDomainService) {},
+			expectedError: "invalid scope",
+		},
+		{
+			name:          "有効期限が長すぎる",
+			req:           dto.GrantScopeRequest{Scope: "fraud", ExpiresAt: time.Now().Add(usecase.MaxUserScopeDuration + time.Hour)},
+			setupMock:     func(m *MockPermissionDomainService) {},
+			expectedError: "invalid scope expiry",
+		},
+		{
+			name: "存在しないスコープ",
+			req:  dto.GrantScopeRequest{Scope: "unknown", ExpiresAt: expiresAt},
+			setupMock: func(m *MockPermissionDomainService) {
+				m.On("GrantScope", mock.Anything, uint(1), "unknown", expiresAt).Return(nil, entity.ErrScopeNotFound)
+			},
+			expectedError: "scope not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionService := &MockPermissionDomainService{}
+			tt.setupMock(permissionService)
+			uc := usecase.NewPermissionUsecase(permissionService)
+
+			result, err := uc.GrantUserScope(context.Background(), 1, &tt.req)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userScope, result)
+			permissionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPermissionUsecaseRevokeUserScope(t *testing.T) {
+	permissionService := &MockPermissionDomainService{}
+	uc := usecase.NewPermissionUsecase(permissionService)
+
+	permissionService.On("RevokeScope", mock.Anything, uint(1), "fraud").Return(nil)
+
+	err := uc.RevokeUserScope(context.Background(), 1, "fraud")
+
+	assert.NoError(t, err)
+	permissionService.AssertExpectations(t)
+}
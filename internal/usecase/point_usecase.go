@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const pointDateLayout = "2006-01-02"
+
+type PointUsecase struct {
+	pointDomainService service.PointDomainServiceInterface
+}
+
+type PointTransactionListResponse struct {
+	Transactions []*entity.PointTransaction
+	Summary      *entity.PointSummary
+	Total        int64
+	Page         int
+	Limit        int
+}
+
+func NewPointUsecase(pointDomainService service.PointDomainServiceInterface) *PointUsecase {
+	return &PointUsecase{
+		pointDomainService: pointDomainService,
+	}
+}
+
+func (u *PointUsecase) GetPointTransactions(ctx context.Context, userID uint, query *dto.PointTransactionQuery) (*PointTransactionListResponse, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := &entity.PointTransactionFilter{}
+	if query.Type != "" {
+		filter.Type = strings.ToUpper(query.Type)
+		if !entity.IsValidPointTransactionType(filter.Type) {
+			return nil, fmt.Errorf("invalid transaction type: %s", query.Type)
+		}
+	}
+
+	from, err := parsePointDate(query.From, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %s", query.From)
+	}
+	to, err := parsePointDate(query.To, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %s", query.To)
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, fmt.Errorf("invalid date range: from must be before to")
+	}
+	filter.From = from
+	filter.To = to
+
+	transactions, total, err := u.pointDomainService.GetPointTransactions(ctx, userID, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point transactions: %w", err)
+	}
+
+	summary, err := u.pointDomainService.GetPointSummary(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point summary: %w", err)
+	}
+
+	return &PointTransactionListResponse{
+		Transactions: transactions,
+		Summary:      summary,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+	}, nil
+}
+
+func (u *PointUsecase) ExpirePoints(ctx context.Context, req *dto.ExpirePointsRequest) (*entity.PointExpirationResult, error) {
+	now := time.Now()
+	asOf := now
+	if req.ExpirationDate != "" {
+		parsed, err := parsePointDate(req.ExpirationDate, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiration date: %s", req.ExpirationDate)
+		}
+		asOf = *parsed
+	}
+	if asOf.After(now) && !req.DryRun {
+		return nil, fmt.Errorf("invalid expiration date: future dates are only allowed for dry runs")
+	}
+
+	result, err := u.pointDomainService.ExpirePoints(ctx, asOf, req.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire points: %w", err)
+	}
+	return result, nil
+}
+
+func parsePointDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+
+	parsed, err := time.Parse(pointDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PointUsecaseInterface interface {
+	GetPointTransactions(ctx context.Context, userID uint, query *dto.PointTransactionQuery) (*PointTransactionListResponse, error)
+	ExpirePoints(ctx context.Context, req *dto.ExpirePointsRequest) (*entity.PointExpirationResult, error)
+}
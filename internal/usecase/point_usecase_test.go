@@ -0,0 +1,145 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPointUsecaseGetPointTransactions(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     dto.PointTransactionQuery
+		setupMock func(*MockPointDomainService)
+		wantErr   string
+		wantPage  int
+		wantLimit int
+	}{
+		{
+			name:  "既定のページングで取得",
+			query: dto.PointTransactionQuery{},
+			setupMock: func(pointService *MockPointDomainService) {
+				pointService.On("GetPointTransactions", mock.Anything, uint(1), &entity.PointTransactionFilter{}, 0, 20).
+					Return([]*entity.PointTransaction{entity.NewPointTransaction(1, entity.PointTransactionTypeEarn, 100, "")}, int64(1), nil)
+				pointService.On("GetPointSummary", mock.Anything, uint(1)).Return(&entity.PointSummary{Balance: 100, TotalEarned: 100}, nil)
+			},
+			wantPage:  1,
+			wantLimit: 20,
+		},
+		{
+			name:  "種別と期間で絞り込み",
+			query: dto.PointTransactionQuery{Page: 2, Limit: 10, Type: "spend", From: "2025-09-01", To: "2025-09-30"},
+			setupMock: func(pointService *MockPointDomainService) {
+				from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+				filter := &entity.PointTransactionFilter{Type: entity.PointTransactionTypeSpend, From: &from, To: &to}
+				pointService.On("GetPointTransactions", mock.Anything, uint(1), filter, 10, 10).
+					Return([]*entity.PointTransaction{}, int64(0), nil)
+				pointService.On("GetPointSummary", mock.Anything, uint(1)).Return(&entity.PointSummary{}, nil)
+			},
+			wantPage:  2,
+			wantLimit: 10,
+		},
+		{
+			name:      "不明な取引種別",
+			query:     dto.PointTransactionQuery{Type: "gift"},
+			setupMock: func(pointService *MockPointDomainService) {},
+			wantErr:   "invalid transaction type: gift",
+		},
+		{
+			name:      "不正な日付",
+			query:     dto.PointTransactionQuery{From: "yesterday"},
+			setupMock: func(pointService *MockPointDomainService) {},
+			wantErr:   "invalid from date: yesterday",
+		},
+		{
+			name:      "開始日が終了日より後",
+			query:     dto.PointTransactionQuery{From: "2025-09-30", To: "2025-09-01"},
+			setupMock: func(pointService *MockPointDomainService) {},
+			wantErr:   "invalid date range: from must be before to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pointService := new(MockPointDomainService)
+			tt.setupMock(pointService)
+
+			uc := usecase.NewPointUsecase(pointService)
+
+			response, err := uc.GetPointTransactions(context.Background(), 1, &tt.query)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, response)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPage, response.Page)
+				assert.Equal(t, tt.wantLimit, response.Limit)
+				assert.NotNil(t, response.Summary)
+			}
+			pointService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPointUsecaseExpirePoints(t *testing.T) {
+	t.Run("日付指定なしは現在時刻で実行", func(t *testing.T) {
+		pointService := new(MockPointDomainService)
+		result := entity.NewPointExpirationResult(time.Now(), false)
+		pointService.On("ExpirePoints", mock.Anything, mock.MatchedBy(func(asOf time.Time) bool {
+			return time.Since(asOf) < time.Minute
+		}), false).Return(result, nil)
+
+		uc := usecase.NewPointUsecase(pointService)
+
+		got, err := uc.ExpirePoints(context.Background(), &dto.ExpirePointsRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, result, got)
+		pointService.AssertExpectations(t)
+	})
+
+	t.Run("日付指定は当日の終わりまでを対象にする", func(t *testing.T) {
+		pointService := new(MockPointDomainService)
+		asOf := time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+		pointService.On("ExpirePoints", mock.Anything, asOf, false).Return(entity.NewPointExpirationResult(asOf, false), nil)
+
+		uc := usecase.NewPointUsecase(pointService)
+
+		_, err := uc.ExpirePoints(context.Background(), &dto.ExpirePointsRequest{ExpirationDate: "2025-09-01"})
+
+		assert.NoError(t, err)
+		pointService.AssertExpectations(t)
+	})
+
+	t.Run("未来日はドライランのみ許可", func(t *testing.T) {
+		pointService := new(MockPointDomainService)
+		future := time.Now().AddDate(1, 0, 0).Format(time.RFC3339)
+		pointService.On("ExpirePoints", mock.Anything, mock.AnythingOfType("time.Time"), true).
+			Return(entity.NewPointExpirationResult(time.Now(), true), nil)
+
+		uc := usecase.NewPointUsecase(pointService)
+
+		_, err := uc.ExpirePoints(context.Background(), &dto.ExpirePointsRequest{ExpirationDate: future})
+		assert.EqualError(t, err, "invalid expiration date: future dates are only allowed for dry runs")
+
+		_, err = uc.ExpirePoints(context.Background(), &dto.ExpirePointsRequest{ExpirationDate: future, DryRun: true})
+		assert.NoError(t, err)
+		pointService.AssertExpectations(t)
+	})
+
+	t.Run("不正な日付", func(t *testing.T) {
+		uc := usecase.NewPointUsecase(new(MockPointDomainService))
+
+		_, err := uc.ExpirePoints(context.Background(), &dto.ExpirePointsRequest{ExpirationDate: "someday"})
+
+		assert.EqualError(t, err, "invalid expiration date: someday")
+	})
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type PreferenceUsecase struct {
+	preferenceDomainService service.PreferenceDomainServiceInterface
+}
+
+func NewPreferenceUsecase(preferenceDomainService service.PreferenceDomainServiceInterface) *PreferenceUsecase {
+	return &PreferenceUsecase{
+		preferenceDomainService: preferenceDomainService,
+	}
+}
+
+func (u *PreferenceUsecase) GetPreferences(ctx context.Context, userID uint, category string) ([]entity.PreferenceValue, error) {
+	if category != "" && !entity.IsValidPreferenceCategory(category) {
+		return nil, fmt.Errorf("invalid preference category: %s", category)
+	}
+
+	values, err := u.preferenceDomainService.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if category == "" {
+		return values, nil
+	}
+
+	filtered := make([]entity.PreferenceValue, 0, len(values))
+	for _, value := range values {
+		if value.Definition.Category == category {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered, nil
+}
+
+func (u *PreferenceUsecase) SetPreference(ctx context.Context, userID uint, key string, value interface{}) ([]entity.PreferenceValue, error) {
+	return u.SetPreferences(ctx, userID, map[string]interface{}{key: value})
+}
+
+func (u *PreferenceUsecase) SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("invalid preferences: at least one value is required")
+	}
+	return u.preferenceDomainService.SetPreferences(ctx, userID, values)
+}
+
+func (u *PreferenceUsecase) ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error) {
+	return u.preferenceDomainService.ResetPreferences(ctx, userID, keys)
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PreferenceUsecaseInterface interface {
+	GetPreferences(ctx context.Context, userID uint, category string) ([]entity.PreferenceValue, error)
+	SetPreference(ctx context.Context, userID uint, key string, value interface{}) ([]entity.PreferenceValue, error)
+	SetPreferences(ctx context.Context, userID uint, values map[string]interface{}) ([]entity.PreferenceValue, error)
+	ResetPreferences(ctx context.Context, userID uint, keys []string) ([]entity.PreferenceValue, error)
+}
@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreferenceUsecaseGetPreferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		category      string
+		expectedKeys  []string
+		expectedError string
+	}{
+		{
+			name:         "全カテゴリ",
+			expectedKeys: []string{"theme", "language", "notifications_email", "notifications_push", "privacy_profile", "data_sharing", "auto_logout_minutes"},
+		},
+		{
+			name:         "カテゴリで絞り込む",
+			category:     entity.PreferenceCategoryNotifications,
+			expectedKeys: []string{"notifications_email", "notifications_push"},
+		},
+		{
+			name:          "存在しないカテゴリ",
+			category:      "billing",
+			expectedError: "invalid preference category: billing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferenceService := new(MockPreferenceDomainService)
+			preferenceService.On("GetPreferences", mock.Anything, uint(1)).Return(entity.ResolvePreferences(nil), nil).Maybe()
+
+			uc := usecase.NewPreferenceUsecase(preferenceService)
+
+			values, err := uc.GetPreferences(context.Background(), 1, tt.category)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				preferenceService.AssertNotCalled(t, "GetPreferences", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			keys := make([]string, len(values))
+			for i, value := range values {
+				keys[i] = value.Definition.Key
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestPreferenceUsecaseSetPreferences(t *testing.T) {
+	t.Run("単一のキーを設定する", func(t *testing.T) {
+		preferenceService := new(MockPreferenceDomainService)
+		preferenceService.On("SetPreferences", mock.Anything, uint(1), map[string]interface{}{"theme": "dark"}).
+			Return(entity.ResolvePreferences(map[string]string{"theme": "dark"}), nil)
+
+		uc := usecase.NewPreferenceUsecase(preferenceService)
+
+		values, err := uc.SetPreference(context.Background(), 1, "theme", "dark")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dark", values[0].Value)
+		preferenceService.AssertExpectations(t)
+	})
+
+	t.Run("空の更新は拒否する", func(t *testing.T) {
+		preferenceService := new(MockPreferenceDomainService)
+		uc := usecase.NewPreferenceUsecase(preferenceService)
+
+		_, err := uc.SetPreferences(context.Background(), 1, map[string]interface{}{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid preferences")
+		preferenceService.AssertNotCalled(t, "SetPreferences", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const (
+	maxPurchaseIDLength = 255
+	maxPurchaseAmount   = 99999999.99
+)
+
+type PurchaseUsecase struct {
+	purchaseDomainService service.PurchaseDomainServiceInterface
+}
+
+type PurchaseListResponse struct {
+	Purchases []*entity.Purchase
+	Total     int64
+	Page      int
+	Limit     int
+}
+
+func NewPurchaseUsecase(purchaseDomainService service.PurchaseDomainServiceInterface) *PurchaseUsecase {
+	return &PurchaseUsecase{
+		purchaseDomainService: purchaseDomainService,
+	}
+}
+
+func (u *PurchaseUsecase) RecordPurchase(ctx context.Context, req *dto.RecordPurchaseRequest) (*entity.Purchase, bool, error) {
+	purchaseID := strings.TrimSpace(req.PurchaseID)
+	if purchaseID == "" || len(purchaseID) > maxPurchaseIDLength {
+		return nil, false, fmt.Errorf("invalid purchase ID")
+	}
+	if req.TotalAmount <= 0 || req.TotalAmount > maxPurchaseAmount {
+		return nil, false, fmt.Errorf("invalid purchase amount: %.2f", req.TotalAmount)
+	}
+
+	purchase := entity.NewPurchase(purchaseID, req.UserID, req.StoreID, req.TotalAmount)
+	return u.purchaseDomainService.RecordPurchase(ctx, purchase)
+}
+
+func (u *PurchaseUsecase) RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error) {
+	purchaseID = strings.TrimSpace(purchaseID)
+	if purchaseID == "" {
+		return nil, fmt.Errorf("invalid purchase ID")
+	}
+	return u.purchaseDomainService.RefundPurchase(ctx, purchaseID)
+}
+
+func (u *PurchaseUsecase) GetUserPurchases(ctx context.Context, userID uint, query *dto.PurchaseQuery) (*PurchaseListResponse, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	purchases, total, err := u.purchaseDomainService.GetUserPurchases(ctx, userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchases: %w", err)
+	}
+
+	return &PurchaseListResponse{
+		Purchases: purchases,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type PurchaseUsecaseInterface interface {
+	RecordPurchase(ctx context.Context, req *dto.RecordPurchaseRequest) (*entity.Purchase, bool, error)
+	RefundPurchase(ctx context.Context, purchaseID string) (*entity.Purchase, error)
+	GetUserPurchases(ctx context.Context, userID uint, query *dto.PurchaseQuery) (*PurchaseListResponse, error)
+}
@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurchaseUsecaseRecordPurchase(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         dto.RecordPurchaseRequest
+		setupMock   func(*MockPurchaseDomainService)
+		wantErr     string
+		wantCreated bool
+	}{
+		{
+			name: "購入を記録",
+			req:  dto.RecordPurchaseRequest{PurchaseID: " order-1 ", UserID: 1, StoreID: 10, TotalAmount: 5000},
+			setupMock: func(purchaseService *MockPurchaseDomainService) {
+				purchaseService.On("RecordPurchase", mock.Anything, mock.MatchedBy(func(purchase *entity.Purchase) bool {
+					return purchase.ID == "order-1" && purchase.UserID == 1 && purchase.StoreID == 10 && purchase.TotalAmount == 5000
+				})).Return(entity.NewPurchase("order-1", 1, 10, 5000), true, nil)
+			},
+			wantCreated: true,
+		},
+		{
+			name:      "購入IDが空",
+			req:       dto.RecordPurchaseRequest{PurchaseID: "  ", UserID: 1, StoreID: 10, TotalAmount: 5000},
+			setupMock: func(purchaseService *MockPurchaseDomainService) {},
+			wantErr:   "invalid purchase ID",
+		},
+		{
+			name:      "購入IDが長すぎる",
+			req:       dto.RecordPurchaseRequest{PurchaseID: strings.Repeat("a", 256), UserID: 1, StoreID: 10, TotalAmount: 5000},
+			setupMock: func(purchaseService *MockPurchaseDomainService) {},
+			wantErr:   "invalid purchase ID",
+		},
+		{
+			name:      "金額が負",
+			req:       dto.RecordPurchaseRequest{PurchaseID: "order-1", UserID: 1, StoreID: 10, TotalAmount: -1},
+			setupMock: func(purchaseService *MockPurchaseDomainService) {},
+			wantErr:   "invalid purchase amount: -1.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseService := new(MockPurchaseDomainService)
+			tt.setupMock(purchaseService)
+
+			uc := usecase.NewPurchaseUsecase(purchaseService)
+
+			purchase, created, err := uc.RecordPurchase(context.Background(), &tt.req)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, purchase)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCreated, created)
+			}
+			purchaseService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPurchaseUsecaseGetUserPurchases(t *testing.T) {
+	purchaseService := new(MockPurchaseDomainService)
+	purchaseService.On("GetUserPurchases", mock.Anything, uint(1), 10, 10).
+		Return([]*entity.Purchase{entity.NewPurchase("order-1", 1, 10, 5000)}, int64(11), nil)
+
+	uc := usecase.NewPurchaseUsecase(purchaseService)
+
+	response, err := uc.GetUserPurchases(context.Background(), 1, &dto.PurchaseQuery{Page: 2, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Page)
+	assert.Equal(t, int64(11), response.Total)
+	assert.Len(t, response.Purchases, 1)
+	purchaseService.AssertExpectations(t)
+}
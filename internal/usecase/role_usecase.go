@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type RoleUsecase struct {
+	roleDomainService service.RoleDomainServiceInterface
+}
+
+type RoleMembersResult struct {
+	Role    *entity.Role
+	Members []*entity.User
+	Total   int64
+	Page    int
+	Limit   int
+}
+
+func NewRoleUsecase(roleDomainService service.RoleDomainServiceInterface) *RoleUsecase {
+	return &RoleUsecase{
+		roleDomainService: roleDomainService,
+	}
+}
+
+func (u *RoleUsecase) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	roles, err := u.roleDomainService.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (u *RoleUsecase) GetRole(ctx context.Context, roleID uint) (*entity.Role, error) {
+	role, err := u.roleDomainService.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return role, nil
+}
+
+func (u *RoleUsecase) ListMembers(ctx context.Context, roleID uint, query *dto.RoleMembersQuery) (*RoleMembersResult, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	role, err := u.roleDomainService.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	members, total, err := u.roleDomainService.ListMembers(ctx, roleID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role members: %w", err)
+	}
+
+	return &RoleMembersResult{
+		Role:    role,
+		Members: members,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+	}, nil
+}
+
+func (u *RoleUsecase) CreateRole(ctx context.Context, actor service.RoleActor, req *dto.CreateRoleRequest) (*entity.Role, error) {
+	role, err := u.roleDomainService.CreateRole(ctx, actor, strings.TrimSpace(req.Name), strings.TrimSpace(req.Description), normalizePermissionNames(req.Permissions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	return role, nil
+}
+
+func (u *RoleUsecase) UpdateRole(ctx context.Context, actor service.RoleActor, roleID uint, req *dto.UpdateRoleRequest) (*entity.Role, error) {
+	if req.Name == nil && req.Description == nil && req.Permissions == nil {
+		return nil, fmt.Errorf("invalid role update: no changes requested")
+	}
+
+	var name, description *string
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		name = &trimmed
+	}
+	if req.Description != nil {
+		trimmed := strings.TrimSpace(*req.Description)
+		description = &trimmed
+	}
+
+	var permissions []string
+	if req.Permissions != nil {
+		permissions = normalizePermissionNames(req.Permissions)
+	}
+
+	role, err := u.roleDomainService.UpdateRole(ctx, actor, roleID, name, description, permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	return role, nil
+}
+
+func (u *RoleUsecase) DeleteRole(ctx context.Context, actor service.RoleActor, roleID uint) error {
+	if err := u.roleDomainService.DeleteRole(ctx, actor, roleID); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	return nil
+}
+
+func (u *RoleUsecase) AssignRole(ctx context.Context, actor service.RoleActor, userID uint, req *dto.AssignRoleRequest) error {
+	if err := u.roleDomainService.AssignRole(ctx, actor, userID, req.RoleID); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
+}
+
+func (u *RoleUsecase) RevokeRole(ctx context.Context, actor service.RoleActor, userID, roleID uint) error {
+	if err := u.roleDomainService.RevokeRole(ctx, actor, userID, roleID); err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	return nil
+}
+
+func normalizePermissionNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type RoleUsecaseInterface interface {
+	ListRoles(ctx context.Context) ([]*entity.Role, error)
+	GetRole(ctx context.Context, roleID uint) (*entity.Role, error)
+	ListMembers(ctx context.Context, roleID uint, query *dto.RoleMembersQuery) (*RoleMembersResult, error)
+	CreateRole(ctx context.Context, actor service.RoleActor, req *dto.CreateRoleRequest) (*entity.Role, error)
+	UpdateRole(ctx context.Context, actor service.RoleActor, roleID uint, req *dto.UpdateRoleRequest) (*entity.Role, error)
+	DeleteRole(ctx context.Context, actor service.RoleActor, roleID uint) error
+	AssignRole(ctx context.Context, actor service.RoleActor, userID uint, req *dto.AssignRoleRequest) error
+	RevokeRole(ctx context.Context, actor service.RoleActor, userID, roleID uint) error
+}
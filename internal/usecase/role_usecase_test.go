@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRoleUsecaseListMembers(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          dto.RoleMembersQuery
+		expectedOffset int
+		expectedLimit  int
+	}{
+		{name: "既定のページング", query: dto.RoleMembersQuery{}, expectedOffset: 0, expectedLimit: 20},
+		{name: "指定したページ", query: dto.RoleMembersQuery{Page: 3, Limit: 10}, expectedOffset: 20, expectedLimit: 10},
+		{name: "上限を超えるlimit", query: dto.RoleMembersQuery{Page: 1, Limit: 500}, expectedOffset: 0, expectedLimit: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleService := &MockRoleDomainService{}
+			uc := usecase.NewRoleUsecase(roleService)
+			role := &entity.Role{ID: 1, Name: "admin"}
+
+			roleService.On("GetRole", mock.Anything, uint(1)).Return(role, nil)
+			roleService.On("ListMembers", mock.Anything, uint(1), tt.expectedOffset, tt.expectedLimit).
+				Return([]*entity.User{{ID: 2}}, int64(1), nil)
+
+			result, err := uc.ListMembers(context.Background(), 1, &tt.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, role, result.Role)
+			assert.Equal(t, tt.expectedLimit, result.Limit)
+			assert.Equal(t, int64(1), result.Total)
+		})
+	}
+}
+
+func TestRoleUsecaseCreateRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+
+	t.Run("入力を正規化して作成する", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+		role := &entity.Role{ID: 3, Name: "moderator"}
+
+		roleService.On("CreateRole", mock.Anything, actor, "moderator", "Moderators", []string{"fraud.read", "user.read"}).
+			Return(role, nil)
+
+		result, err := uc.CreateRole(context.Background(), actor, &dto.CreateRoleRequest{
+			Name:        " moderator ",
+			Description: "Moderators ",
+			Permissions: []string{"fraud.read", " user.read", "fraud.read", ""},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, role, result)
+	})
+
+	t.Run("ドメインエラーをラップする", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+
+		roleService.On("CreateRole", mock.Anything, actor, "moderator", "", []string{}).
+			Return(nil, entity.ErrRoleAlreadyExists)
+
+		_, err := uc.CreateRole(context.Background(), actor, &dto.CreateRoleRequest{Name: "moderator"})
+
+		assert.ErrorIs(t, err, entity.ErrRoleAlreadyExists)
+		assert.ErrorContains(t, err, "failed to create role")
+	})
+}
+
+func TestRoleUsecaseUpdateRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+
+	t.Run("変更がない", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+
+		_, err := uc.UpdateRole(context.Background(), actor, 3, &dto.UpdateRoleRequest{})
+
+		assert.ErrorContains(t, err, "invalid")
+		roleService.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("権限を空にする", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+		role := &entity.Role{ID: 3, Name: "moderator"}
+
+		roleService.On("UpdateRole", mock.Anything, actor, uint(3), (*string)(nil), (*string)(nil), []string{}).Return(role, nil)
+
+		result, err := uc.UpdateRole(context.Background(), actor, 3, &dto.UpdateRoleRequest{Permissions: []string{}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, role, result)
+	})
+}
+
+func TestRoleUsecaseAssignRole(t *testing.T) {
+	actor := service.RoleActor{UserID: 1}
+
+	t.Run("正常に付与", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+
+		roleService.On("AssignRole", mock.Anything, actor, uint(2), uint(3)).Return(nil)
+
+		err := uc.AssignRole(context.Background(), actor, 2, &dto.AssignRoleRequest{RoleID: 3})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("付与に失敗", func(t *testing.T) {
+		roleService := &MockRoleDomainService{}
+		uc := usecase.NewRoleUsecase(roleService)
+
+		roleService.On("AssignRole", mock.Anything, actor, uint(2), uint(3)).Return(errors.New("db error"))
+
+		err := uc.AssignRole(context.Background(), actor, 2, &dto.AssignRoleRequest{RoleID: 3})
+
+		assert.ErrorContains(t, err, "failed to assign role")
+	})
+}
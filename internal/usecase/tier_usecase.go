@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+type TierUsecase struct {
+	tierDomainService service.TierDomainServiceInterface
+}
+
+func NewTierUsecase(tierDomainService service.TierDomainServiceInterface) *TierUsecase {
+	return &TierUsecase{
+		tierDomainService: tierDomainService,
+	}
+}
+
+func (u *TierUsecase) GetTiers(ctx context.Context) ([]*entity.MembershipTier, error) {
+	return u.tierDomainService.GetTiers(ctx)
+}
+
+func (u *TierUsecase) GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error) {
+	return u.tierDomainService.GetTier(ctx, id)
+}
+
+func (u *TierUsecase) CreateTier(ctx context.Context, req *dto.CreateTierRequest) (*entity.MembershipTier, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("invalid tier name")
+	}
+	if req.Level < 1 {
+		return nil, fmt.Errorf("invalid tier level: must be positive")
+	}
+
+	benefits, err := normalizeTierBenefits(req.Benefits)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := encodeTierRequirements(&req.Requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := entity.NewMembershipTier(name, req.Level, req.Description, benefits, requirements)
+	if err := u.tierDomainService.CreateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+	return tier, nil
+}
+
+func (u *TierUsecase) UpdateTier(ctx context.Context, id uint, req *dto.UpdateTierRequest) (*entity.MembershipTier, error) {
+	tier, err := u.tierDomainService.GetTier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := tier.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid tier name")
+		}
+	}
+	level := tier.Level
+	if req.Level != nil {
+		if *req.Level < 1 {
+			return nil, fmt.Errorf("invalid tier level: must be positive")
+		}
+		level = *req.Level
+	}
+	description := tier.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+	benefits := tier.Benefits
+	if req.Benefits != nil {
+		if benefits, err = normalizeTierBenefits(req.Benefits); err != nil {
+			return nil, err
+		}
+	}
+	requirements := tier.Requirements
+	if req.Requirements != nil {
+		if requirements, err = encodeTierRequirements(req.Requirements); err != nil {
+			return nil, err
+		}
+	}
+	isActive := tier.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	tier.Update(name, level, description, benefits, requirements, isActive)
+	if err := u.tierDomainService.UpdateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+	return tier, nil
+}
+
+func (u *TierUsecase) DeleteTier(ctx context.Context, id uint) error {
+	return u.tierDomainService.DeleteTier(ctx, id)
+}
+
+func (u *TierUsecase) EvaluateTiers(ctx context.Context) (*entity.TierEvaluationResult, error) {
+	return u.tierDomainService.EvaluateAllTiers(ctx)
+}
+
+func (u *TierUsecase) EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error) {
+	return u.tierDomainService.EvaluateUserTier(ctx, userID)
+}
+
+func normalizeTierBenefits(raw json.RawMessage) (string, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return "{}", nil
+	}
+
+	var benefits map[string]interface{}
+	if err := json.Unmarshal(raw, &benefits); err != nil {
+		return "", fmt.Errorf("invalid benefits: must be a JSON object")
+	}
+
+	encoded, err := json.Marshal(benefits)
+	if err != nil {
+		return "", fmt.Errorf("invalid benefits: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func encodeTierRequirements(req *dto.TierRequirementsRequest) (string, error) {
+	requirements := &entity.TierRequirements{
+		MinPoints:        req.MinPoints,
+		MinSpent:         req.MinSpent,
+		ActiveWithinDays: req.ActiveWithinDays,
+	}
+	if !requirements.IsValid() {
+		return "", fmt.Errorf("invalid requirements: values must not be negative")
+	}
+
+	encoded, err := json.Marshal(requirements)
+	if err != nil {
+		return "", fmt.Errorf("invalid requirements: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type TierUsecaseInterface interface {
+	GetTiers(ctx context.Context) ([]*entity.MembershipTier, error)
+	GetTier(ctx context.Context, id uint) (*entity.MembershipTier, error)
+	CreateTier(ctx context.Context, req *dto.CreateTierRequest) (*entity.MembershipTier, error)
+	UpdateTier(ctx context.Context, id uint, req *dto.UpdateTierRequest) (*entity.MembershipTier, error)
+	DeleteTier(ctx context.Context, id uint) error
+	EvaluateTiers(ctx context.Context) (*entity.TierEvaluationResult, error)
+	EvaluateUserTier(ctx context.Context, userID uint) (*entity.TierChange, error)
+}
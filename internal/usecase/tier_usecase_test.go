@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/application/dto"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTierUsecaseCreateTier(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       dto.CreateTierRequest
+		setupMock func(*MockTierDomainService)
+		wantErr   string
+	}{
+		{
+			name: "ティアを作成",
+			req: dto.CreateTierRequest{
+				Name:         " Platinum ",
+				Level:        4,
+				Benefits:     json.RawMessage(`{"earn_rate": 2.0}`),
+				Requirements: dto.TierRequirementsRequest{MinPoints: 10000, MinSpent: 200000},
+			},
+			setupMock: func(tierService *MockTierDomainService) {
+				tierService.On("CreateTier", mock.Anything, mock.MatchedBy(func(tier *entity.MembershipTier) bool {
+					return tier.Name == "Platinum" &&
+						tier.Level == 4 &&
+						tier.Benefits == `{"earn_rate":2}` &&
+						tier.Requirements == `{"min_points":10000,"min_spent":200000}`
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "レベルが0以下",
+			req:       dto.CreateTierRequest{Name: "Platinum", Level: 0},
+			setupMock: func(tierService *MockTierDomainService) {},
+			wantErr:   "invalid tier level: must be positive",
+		},
+		{
+			name:      "特典がJSONオブジェクトではない",
+			req:       dto.CreateTierRequest{Name: "Platinum", Level: 4, Benefits: json.RawMessage(`[1,2]`)},
+			setupMock: func(tierService *MockTierDomainService) {},
+			wantErr:   "invalid benefits: must be a JSON object",
+		},
+		{
+			name:      "条件が負の値",
+			req:       dto.CreateTierRequest{Name: "Platinum", Level: 4, Requirements: dto.TierRequirementsRequest{MinPoints: -1}},
+			setupMock: func(tierService *MockTierDomainService) {},
+			wantErr:   "invalid requirements: values must not be negative",
+		},
+		{
+			name: "同名のティアが存在",
+			req:  dto.CreateTierRequest{Name: "Gold", Level: 4},
+			setupMock: func(tierService *MockTierDomainService) {
+				tierService.On("CreateTier", mock.Anything, mock.Anything).Return(service.ErrTierAlreadyExists)
+			},
+			wantErr: "tier already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tierService := new(MockTierDomainService)
+			tt.setupMock(tierService)
+
+			uc := usecase.NewTierUsecase(tierService)
+
+			tier, err := uc.CreateTier(context.Background(), &tt.req)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, tier)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, tier)
+			}
+			tierService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTierUsecaseUpdateTier(t *testing.T) {
+	t.Run("指定したフィールドのみ更新", func(t *testing.T) {
+		tierService := new(MockTierDomainService)
+		tier := entity.NewMembershipTier("Gold", 3, "ゴールド会員", `{"discount":5}`, `{"min_points":5000}`)
+		tier.ID = 3
+		isActive := false
+
+		tierService.On("GetTier", mock.Anything, uint(3)).Return(tier, nil)
+		tierService.On("UpdateTier", mock.Anything, tier).Return(nil)
+
+		uc := usecase.NewTierUsecase(tierService)
+
+		updated, err := uc.UpdateTier(context.Background(), 3, &dto.UpdateTierRequest{
+			Requirements: &dto.TierRequirementsRequest{MinPoints: 6000},
+			IsActive:     &isActive,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Gold", updated.Name)
+		assert.Equal(t, `{"discount":5}`, updated.Benefits)
+		assert.Equal(t, `{"min_points":6000,"min_spent":0}`, updated.Requirements)
+		assert.False(t, updated.IsActive)
+		tierService.AssertExpectations(t)
+	})
+
+	t.Run("存在しないティア", func(t *testing.T) {
+		tierService := new(MockTierDomainService)
+		tierService.On("GetTier", mock.Anything, uint(99)).Return(nil, service.ErrTierNotFound)
+
+		uc := usecase.NewTierUsecase(tierService)
+
+		_, err := uc.UpdateTier(context.Background(), 99, &dto.UpdateTierRequest{})
+
+		assert.ErrorIs(t, err, service.ErrTierNotFound)
+		tierService.AssertNotCalled(t, "UpdateTier", mock.Anything, mock.Anything)
+	})
+}
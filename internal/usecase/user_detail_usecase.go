@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/repository"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+)
+
+const (
+	UserDetailSectionProfile        = "profile"
+	UserDetailSectionMembership     = "membership"
+	UserDetailSectionRoles          = "roles"
+	UserDetailSectionLoginAttempts  = "login_attempts"
+	UserDetailSectionSecurityEvents = "security_events"
+	UserDetailSectionDevices        = "devices"
+	UserDetailSectionSessions       = "sessions"
+)
+
+const (
+	userDetailLoginAttemptWindow = 30 * 24 * time.Hour
+	userDetailLoginAttemptLimit  = 20
+	userDetailSecurityEventLimit = 20
+)
+
+type UserDetailUsecase struct {
+	userRepo              repository.UserRepository
+	userProfileRepo       repository.UserProfileRepository
+	userMembershipRepo    repository.UserMembershipRepository
+	roleRepo              repository.RoleRepository
+	loginAttemptRepo      repository.LoginAttemptRepository
+	securityEventRepo     repository.SecurityEventRepository
+	deviceFingerprintRepo repository.DeviceFingerprintRepository
+	userSessionRepo       repository.UserSessionRepository
+}
+
+func NewUserDetailUsecase(
+	userRepo repository.UserRepository,
+	userProfileRepo repository.UserProfileRepository,
+	userMembershipRepo repository.UserMembershipRepository,
+	roleRepo repository.RoleRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	deviceFingerprintRepo repository.DeviceFingerprintRepository,
+	userSessionRepo repository.UserSessionRepository,
+) *UserDetailUsecase {
+	return &UserDetailUsecase{
+		userRepo:              userRepo,
+		userProfileRepo:       userProfileRepo,
+		userMembershipRepo:    userMembershipRepo,
+		roleRepo:              roleRepo,
+		loginAttemptRepo:      loginAttemptRepo,
+		securityEventRepo:     securityEventRepo,
+		deviceFingerprintRepo: deviceFingerprintRepo,
+		userSessionRepo:       userSessionRepo,
+	}
+}
+
+func (u *UserDetailUsecase) GetUserDetail(ctx context.Context, userID uint) (*entity.UserDetail, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, service.ErrUserNotFound) {
+		return nil, service.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	detail := &entity.UserDetail{User: user}
+	detail.SectionErrors = loadSections(ctx, map[string]func(context.Context) error{
+		UserDetailSectionProfile: func(ctx context.Context) (err error) {
+			detail.Profile, err = u.userProfileRepo.GetByUserID(ctx, userID)
+			return err
+		},
+		UserDetailSectionMembership: func(ctx context.Context) (err error) {
+			detail.Membership, err = u.userMembershipRepo.GetByUserID(ctx, userID)
+			return err
+		},
+		UserDetailSectionRoles: func(ctx context.Context) (err error) {
+			detail.Roles, err = u.roleRepo.GetUserRoles(ctx, userID)
+			return err
+		},
+		UserDetailSectionLoginAttempts: func(ctx context.Context) error {
+			attempts, err := u.loginAttemptRepo.GetByEmail(ctx, user.Email, time.Now().Add(-userDetailLoginAttemptWindow))
+			if err != nil {
+				return err
+			}
+			if len(attempts) > userDetailLoginAttemptLimit {
+				attempts = attempts[:userDetailLoginAttemptLimit]
+			}
+			detail.LoginAttempts = attempts
+			return nil
+		},
+		UserDetailSectionSecurityEvents: func(ctx context.Context) (err error) {
+			detail.SecurityEvents, _, err = u.securityEventRepo.GetByUserID(ctx, userID, 0, userDetailSecurityEventLimit)
+			return err
+		},
+		UserDetailSectionDevices: func(ctx context.Context) (err error) {
+			detail.Devices, err = u.deviceFingerprintRepo.GetByUserID(ctx, userID)
+			return err
+		},
+		UserDetailSectionSessions: func(ctx context.Context) (err error) {
+			detail.Sessions, err = u.userSessionRepo.GetByUserID(ctx, userID)
+			return err
+		},
+	})
+
+	return detail, nil
+}
+
+// loadSections runs every loader concurrently and collects failures per section instead of aborting.
+func loadSections(ctx context.Context, loaders map[string]func(context.Context) error) map[string]error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures = make(map[string]error)
+	)
+
+	for section, load := range loaders {
+		wg.Add(1)
+		go func(section string, load func(context.Context) error) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mu.Lock()
+					failures[section] = fmt.Errorf("panic while loading %s: %v", section, r)
+					mu.Unlock()
+				}
+			}()
+
+			if err := load(ctx); err != nil {
+				mu.Lock()
+				failures[section] = fmt.Errorf("failed to load %s: %w", section, err)
+				mu.Unlock()
+			}
+		}(section, load)
+	}
+
+	wg.Wait()
+	return failures
+}
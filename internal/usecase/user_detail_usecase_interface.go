@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+)
+
+type UserDetailUsecaseInterface interface {
+	GetUserDetail(ctx context.Context, userID uint) (*entity.UserDetail, error)
+}
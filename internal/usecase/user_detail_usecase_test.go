@@ -0,0 +1,297 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/entity"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) Create(ctx context.Context, role *entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) CreateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, role, permissionIDs, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) UpdateWithPermissions(ctx context.Context, role *entity.Role, permissionIDs []uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, role, permissionIDs, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetByID(ctx context.Context, id uint) (*entity.Role, error) {
+	args := m.Called(ctx, id)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	args := m.Called(ctx, name)
+	if role, ok := args.Get(0).(*entity.Role); ok {
+		return role, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	args := m.Called(ctx)
+	if roles, ok := args.Get(0).([]*entity.Role); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleRepository) Update(ctx context.Context, role *entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) AssignToUser(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) RemoveFromUser(ctx context.Context, userID, roleID uint) error {
+	args := m.Called(ctx, userID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetUserRoles(ctx context.Context, userID uint) ([]*entity.Role, error) {
+	args := m.Called(ctx, userID)
+	if roles, ok := args.Get(0).([]*entity.Role); ok {
+		return roles, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleRepository) GetUserRoleNames(ctx context.Context, userID uint) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if names, ok := args.Get(0).([]string); ok {
+		return names, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRoleRepository) HasUserRole(ctx context.Context, userID, roleID uint) (bool, error) {
+	args := m.Called(ctx, userID, roleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleRepository) RemoveFromUserUnlessLast(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) DeleteWithEvent(ctx context.Context, id uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, id, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) AssignToUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) RemoveFromUserWithEvent(ctx context.Context, userID, roleID uint, event *entity.SecurityEvent) error {
+	args := m.Called(ctx, userID, roleID, event)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) ListMembers(ctx context.Context, roleID uint, offset, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, roleID, offset, limit)
+	if users, ok := args.Get(0).([]*entity.User); ok {
+		return users, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockRoleRepository) GetMemberIDs(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if userIDs, ok := args.Get(0).([]uint); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockUserSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSessionRepository) Create(ctx context.Context, session *entity.UserSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) GetBySessionID(ctx context.Context, sessionID string) (*entity.UserSession, error) {
+	args := m.Called(ctx, sessionID)
+	if session, ok := args.Get(0).(*entity.UserSession); ok {
+		return session, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserSessionRepository) GetByUserID(ctx context.Context, userID uint) ([]*entity.UserSession, error) {
+	args := m.Called(ctx, userID)
+	if sessions, ok := args.Get(0).([]*entity.UserSession); ok {
+		return sessions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserSessionRepository) ListActive(ctx context.Context, offset, limit int) ([]*entity.UserSession, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if sessions, ok := args.Get(0).([]*entity.UserSession); ok {
+		return sessions, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockUserSessionRepository) Update(ctx context.Context, session *entity.UserSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) Delete(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) DeactivateByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) CleanupExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type userDetailMocks struct {
+	user         *MockUserRepository
+	profile      *MockUserProfileRepository
+	membership   *MockUserMembershipRepository
+	role         *MockRoleRepository
+	loginAttempt *MockLoginAttemptRepository
+	event        *MockSecurityEventRepository
+	device       *MockDeviceFingerprintRepository
+	session      *MockUserSessionRepository
+}
+
+func setupUserDetailUsecase() (*usecase.UserDetailUsecase, *userDetailMocks) {
+	mocks := &userDetailMocks{
+		user:         new(MockUserRepository),
+		profile:      new(MockUserProfileRepository),
+		membership:   new(MockUserMembershipRepository),
+		role:         new(MockRoleRepository),
+		loginAttempt: new(MockLoginAttemptRepository),
+		event:        new(MockSecurityEventRepository),
+		device:       new(MockDeviceFingerprintRepository),
+		session:      new(MockUserSessionRepository),
+	}
+	uc := usecase.NewUserDetailUsecase(mocks.user, mocks.profile, mocks.membership, mocks.role,
+		mocks.loginAttempt, mocks.event, mocks.device, mocks.session)
+	return uc, mocks
+}
+
+func TestUserDetailUsecaseGetUserDetail(t *testing.T) {
+	ctx := context.Background()
+	user := &entity.User{ID: 1, Name: "テストユーザー", Email: "test@example.com"}
+
+	t.Run("全セクションを取得する", func(t *testing.T) {
+		uc, mocks := setupUserDetailUsecase()
+
+		attempts := make([]*entity.LoginAttempt, 25)
+		for i := range attempts {
+			attempts[i] = entity.NewLoginAttempt(user.Email, "127.0.0.1", "test", true, "")
+		}
+
+		mocks.user.On("GetByID", ctx, uint(1)).Return(user, nil)
+		mocks.profile.On("GetByUserID", mock.Anything, uint(1)).Return(entity.NewUserProfile(1), nil)
+		mocks.membership.On("GetByUserID", mock.Anything, uint(1)).Return(entity.NewUserMembership(1, 2), nil)
+		mocks.role.On("GetUserRoles", mock.Anything, uint(1)).Return([]*entity.Role{entity.NewRole("admin", "")}, nil)
+		mocks.loginAttempt.On("GetByEmail", mock.Anything, user.Email, mock.AnythingOfType("time.Time")).Return(attempts, nil)
+		mocks.event.On("GetByUserID", mock.Anything, uint(1), 0, 20).Return([]*entity.SecurityEvent{{ID: 3}}, int64(1), nil)
+		mocks.device.On("GetByUserID", mock.Anything, uint(1)).Return([]*entity.DeviceFingerprint{{ID: 4}}, nil)
+		mocks.session.On("GetByUserID", mock.Anything, uint(1)).Return([]*entity.UserSession{{ID: 5}}, nil)
+
+		detail, err := uc.GetUserDetail(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, detail.User)
+		assert.NotNil(t, detail.Profile)
+		assert.Equal(t, uint(2), detail.Membership.TierID)
+		assert.Len(t, detail.Roles, 1)
+		assert.Len(t, detail.LoginAttempts, 20)
+		assert.Len(t, detail.SecurityEvents, 1)
+		assert.Len(t, detail.Devices, 1)
+		assert.Len(t, detail.Sessions, 1)
+		assert.Empty(t, detail.UnavailableSections())
+	})
+
+	t.Run("失敗したセクションのみ欠落する", func(t *testing.T) {
+		uc, mocks := setupUserDetailUsecase()
+
+		mocks.user.On("GetByID", ctx, uint(1)).Return(user, nil)
+		mocks.profile.On("GetByUserID", mock.Anything, uint(1)).Return(entity.NewUserProfile(1), nil)
+		mocks.membership.On("GetByUserID", mock.Anything, uint(1)).Return(nil, errors.New("database error"))
+		mocks.role.On("GetUserRoles", mock.Anything, uint(1)).Return([]*entity.Role{entity.NewRole("user", "")}, nil)
+		mocks.loginAttempt.On("GetByEmail", mock.Anything, user.Email, mock.AnythingOfType("time.Time")).Return([]*entity.LoginAttempt{}, nil)
+		mocks.event.On("GetByUserID", mock.Anything, uint(1), 0, 20).Return(nil, int64(0), errors.New("timeout"))
+		mocks.device.On("GetByUserID", mock.Anything, uint(1)).Return([]*entity.DeviceFingerprint{}, nil)
+		mocks.session.On("GetByUserID", mock.Anything, uint(1)).Return([]*entity.UserSession{}, nil)
+
+		detail, err := uc.GetUserDetail(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, detail.Profile)
+		assert.Nil(t, detail.Membership)
+		assert.Len(t, detail.Roles, 1)
+		assert.Equal(t, []string{usecase.UserDetailSectionMembership, usecase.UserDetailSectionSecurityEvents}, detail.UnavailableSections())
+		assert.ErrorContains(t, detail.SectionErrors[usecase.UserDetailSectionSecurityEvents], "timeout")
+	})
+
+	t.Run("ユーザーが存在しない", func(t *testing.T) {
+		uc, mocks := setupUserDetailUsecase()
+
+		mocks.user.On("GetByID", ctx, uint(9)).Return(nil, entity.ErrUserNotFound)
+
+		detail, err := uc.GetUserDetail(ctx, 9)
+
+		assert.Nil(t, detail)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+		mocks.profile.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ユーザーの取得に失敗した場合は見つからない扱いにしない", func(t *testing.T) {
+		uc, mocks := setupUserDetailUsecase()
+
+		dbErr := errors.New("database error")
+		mocks.user.On("GetByID", ctx, uint(9)).Return(nil, dbErr)
+
+		detail, err := uc.GetUserDetail(ctx, 9)
+
+		assert.Nil(t, detail)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NotErrorIs(t, err, service.ErrUserNotFound)
+		mocks.profile.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+	})
+}
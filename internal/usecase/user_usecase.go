@@ -16,6 +16,7 @@ type UserUsecase struct {
 	userMembershipRepo repository.UserMembershipRepository
 	fraudDomainService service.FraudDomainServiceInterface
 	redisClient        *external.RedisClient
+	dashboard          DashboardInvalidator
 }
 
 type CreateUserRequest struct {
@@ -44,6 +45,7 @@ func NewUserUsecase(
 	userMembershipRepo repository.UserMembershipRepository,
 	fraudDomainService service.FraudDomainServiceInterface,
 	redisClient *external.RedisClient,
+	dashboard DashboardInvalidator,
 ) *UserUsecase {
 	return &UserUsecase{
 		userRepo:           userRepo,
@@ -51,6 +53,7 @@ func NewUserUsecase(
 		userMembershipRepo: userMembershipRepo,
 		fraudDomainService: fraudDomainService,
 		redisClient:        redisClient,
+		dashboard:          dashboard,
 	}
 }
 
@@ -152,6 +155,7 @@ func (u *UserUsecase) UpdateUser(ctx context.Context, userID uint, req UpdateUse
 
 	_ = u.fraudDomainService.CreateSecurityEvent(ctx, &userID, "USER_UPDATED",
 		"User profile updated", ipAddress, userAgent, "LOW")
+	invalidateUserDashboard(ctx, u.dashboard, userID)
 
 	return user, nil
 }
@@ -135,6 +135,16 @@ func (m *MockUserMembershipRepository) Update(ctx context.Context, membership *e
 	return args.Error(0)
 }
 
+func (m *MockUserMembershipRepository) UpdateTier(ctx context.Context, membership *entity.UserMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockUserMembershipRepository) CountByTierID(ctx context.Context, tierID uint) (int64, error) {
+	args := m.Called(ctx, tierID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUserMembershipRepository) Delete(ctx context.Context, userID uint) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -170,7 +180,7 @@ func createTestUserUsecase() *usecase.UserUsecase {
 	userMembershipRepo := &MockUserMembershipRepository{}
 	fraudService := &MockFraudDomainService{}
 
-	return usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+	return usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 }
 
 func TestNewUserUsecase(t *testing.T) {
@@ -187,7 +197,7 @@ func TestUserUsecaseCreateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		req := usecase.CreateUserRequest{
 			Name:  "テストユーザー",
@@ -219,7 +229,7 @@ func TestUserUsecaseCreateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		req := usecase.CreateUserRequest{
 			Name:  "テストユーザー",
@@ -244,7 +254,7 @@ func TestUserUsecaseCreateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		req := usecase.CreateUserRequest{
 			Name:  "テストユーザー",
@@ -269,7 +279,7 @@ func TestUserUsecaseCreateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		req := usecase.CreateUserRequest{
 			Name:  "テストユーザー",
@@ -333,7 +343,7 @@ func TestUserUsecaseGetUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		expectedUser := &entity.User{
 			ID:    1,
@@ -358,7 +368,7 @@ func TestUserUsecaseGetUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, errors.New("user not found"))
 
@@ -379,7 +389,7 @@ func TestUserUsecaseGetUsers(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		expectedUsers := []*entity.User{
 			{ID: 1, Name: "ユーザー1", Email: "user1@example.com", Age: 25},
@@ -407,7 +417,7 @@ func TestUserUsecaseGetUsers(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 20).Return([]*entity.User{}, int64(0), nil)
 
@@ -426,7 +436,7 @@ func TestUserUsecaseGetUsers(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 20).Return([]*entity.User{}, int64(0), nil)
 
@@ -446,7 +456,7 @@ func TestUserUsecaseUpdateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		existingUser := &entity.User{
 			ID:    1,
@@ -483,7 +493,7 @@ func TestUserUsecaseUpdateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		req := usecase.UpdateUserRequest{
 			Name: "新名前",
@@ -502,7 +512,7 @@ func TestUserUsecaseUpdateUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		existingUser := &entity.User{
 			ID:    1,
@@ -535,7 +545,7 @@ func TestUserUsecaseDeleteUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		existingUser := &entity.User{
 			ID:    1,
@@ -565,7 +575,7 @@ func TestUserUsecaseDeleteUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		err := uc.DeleteUser(context.Background(), 1, 2, "192.168.1.1", "test-agent")
 
@@ -579,7 +589,7 @@ func TestUserUsecaseDeleteUser(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, errors.New("user not found"))
 
@@ -599,7 +609,7 @@ func TestUserUsecaseGetUserStats(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 1).Return([]*entity.User{}, int64(100), nil)
 		userMembershipRepo.On("GetStats", mock.Anything).Return(map[string]interface{}{
@@ -625,7 +635,7 @@ func TestUserUsecaseGetUserStats(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 1).Return([]*entity.User{}, int64(50), nil)
 		userMembershipRepo.On("GetStats", mock.Anything).Return(nil, errors.New("membership stats error"))
@@ -649,7 +659,7 @@ func TestUserUsecaseHealthCheck(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 1).Return([]*entity.User{}, int64(0), nil)
 
@@ -668,7 +678,7 @@ func TestUserUsecaseHealthCheck(t *testing.T) {
 		userMembershipRepo := &MockUserMembershipRepository{}
 		fraudService := &MockFraudDomainService{}
 
-		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil)
+		uc := usecase.NewUserUsecase(userRepo, userProfileRepo, userMembershipRepo, fraudService, nil, nil)
 
 		userRepo.On("List", mock.Anything, 0, 1).Return(nil, int64(0), errors.New("database connection error"))
 
@@ -215,6 +215,12 @@ CREATE TABLE IF NOT EXISTS `auths` (
   `password_hash` varchar(255) NOT NULL,
   `is_active` tinyint(1) DEFAULT '1',
   `last_login_at` datetime(3) DEFAULT NULL,
+  `two_factor_enabled` tinyint(1) DEFAULT '0',
+  `two_factor_secret` varchar(512) DEFAULT NULL,
+  `two_factor_recovery_codes` json DEFAULT NULL,
+  `two_factor_last_step` bigint DEFAULT '0',
+  `two_factor_enabled_at` datetime(3) DEFAULT NULL,
+  `password_changed_at` datetime(3) DEFAULT NULL,
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
@@ -299,14 +305,17 @@ CREATE TABLE IF NOT EXISTS `role_permissions` (
 CREATE TABLE IF NOT EXISTS `refresh_tokens` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `user_id` bigint unsigned NOT NULL,
-  `token` varchar(255) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `family_id` varchar(36) NOT NULL,
   `expires_at` datetime(3) DEFAULT NULL,
   `is_revoked` tinyint(1) DEFAULT '0',
+  `rotated_at` datetime(3) DEFAULT NULL,
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `idx_refresh_tokens_token` (`token`),
+  UNIQUE KEY `idx_refresh_tokens_token_hash` (`token_hash`),
+  KEY `idx_refresh_tokens_family_id` (`family_id`),
   KEY `idx_refresh_tokens_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
@@ -321,6 +330,7 @@ CREATE TABLE IF NOT EXISTS `user_tokens` (
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `idx_user_tokens_user_id` (`user_id`),
+  UNIQUE KEY `idx_user_tokens_token_hash` (`token_hash`),
   KEY `idx_user_tokens_token_type` (`token_type`),
   KEY `idx_user_tokens_expires_at` (`expires_at`),
   KEY `idx_user_tokens_deleted_at` (`deleted_at`)
@@ -352,6 +362,7 @@ CREATE TABLE IF NOT EXISTS `user_memberships` (
   `joined_at` datetime(3) DEFAULT NULL,
   `last_activity_at` datetime(3) DEFAULT NULL,
   `expires_at` datetime(3) DEFAULT NULL,
+  `tier_grace_ends_at` datetime(3) DEFAULT NULL,
   `is_active` tinyint(1) DEFAULT '1',
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
@@ -361,11 +372,25 @@ CREATE TABLE IF NOT EXISTS `user_memberships` (
   KEY `idx_user_memberships_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+CREATE TABLE IF NOT EXISTS `membership_tier_histories` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `from_tier_id` bigint unsigned NOT NULL,
+  `to_tier_id` bigint unsigned NOT NULL,
+  `reason` varchar(50) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_membership_tier_histories_user_id` (`user_id`),
+  KEY `idx_membership_tier_histories_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 CREATE TABLE IF NOT EXISTS `point_transactions` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `user_id` bigint unsigned NOT NULL,
   `type` varchar(255) NOT NULL,
   `points` int NOT NULL,
+  `remaining_points` int NOT NULL DEFAULT '0',
+  `balance_after` int NOT NULL DEFAULT '0',
   `description` varchar(255) DEFAULT NULL,
   `reference_type` varchar(255) DEFAULT NULL,
   `reference_id` bigint unsigned DEFAULT NULL,
@@ -423,6 +448,7 @@ CREATE TABLE IF NOT EXISTS `notifications` (
   `title` varchar(255) NOT NULL,
   `message` varchar(255) NOT NULL,
   `data` json DEFAULT (JSON_OBJECT()),
+  `priority` varchar(50) NOT NULL DEFAULT 'medium',
   `is_read` tinyint(1) DEFAULT '0',
   `read_at` datetime(3) DEFAULT NULL,
   `created_at` datetime(3) DEFAULT NULL,
@@ -430,6 +456,7 @@ CREATE TABLE IF NOT EXISTS `notifications` (
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `idx_notifications_user_id` (`user_id`),
+  KEY `idx_notifications_user_id_is_read` (`user_id`,`is_read`),
   KEY `idx_notifications_type` (`type`),
   KEY `idx_notifications_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -444,6 +471,7 @@ CREATE TABLE IF NOT EXISTS `user_preferences` (
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_user_preferences_user_id_key` (`user_id`, `key`),
   KEY `idx_user_preferences_user_id` (`user_id`),
   KEY `idx_user_preferences_category` (`category`),
   KEY `idx_user_preferences_deleted_at` (`deleted_at`)
@@ -478,6 +506,19 @@ CREATE TABLE IF NOT EXISTS `ip_blacklists` (
   KEY `idx_ip_blacklists_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+CREATE TABLE IF NOT EXISTS `ip_allowlists` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `ip_address` varchar(255) NOT NULL,
+  `description` varchar(255) DEFAULT NULL,
+  `is_active` tinyint(1) DEFAULT '1',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_ip_allowlists_ip_address` (`ip_address`),
+  KEY `idx_ip_allowlists_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 CREATE TABLE IF NOT EXISTS `user_sessions` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `user_id` bigint unsigned NOT NULL,
@@ -520,6 +561,7 @@ CREATE TABLE IF NOT EXISTS `rate_limit_rules` (
   `resource` varchar(255) NOT NULL,
   `max_requests` int NOT NULL,
   `window_size` int NOT NULL,
+  `algorithm` varchar(32) NOT NULL DEFAULT '',
   `is_active` tinyint(1) DEFAULT '1',
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
@@ -547,6 +589,21 @@ CREATE TABLE IF NOT EXISTS `rate_limit_logs` (
   KEY `idx_rate_limit_logs_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+CREATE TABLE IF NOT EXISTS `fraud_rules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT '1',
+  `weight` double NOT NULL,
+  `threshold` int NOT NULL DEFAULT '0',
+  `window_seconds` int NOT NULL DEFAULT '0',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_fraud_rules_name` (`name`),
+  KEY `idx_fraud_rules_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 CREATE TABLE IF NOT EXISTS `device_fingerprints` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `user_id` bigint unsigned NOT NULL,
@@ -644,9 +701,12 @@ CREATE TABLE IF NOT EXISTS `admin_actions` (
   `target_id` varchar(255) DEFAULT NULL,
   `description` text DEFAULT NULL,
   `result` varchar(255) NOT NULL,
+  `idempotency_key` varchar(255) DEFAULT NULL,
+  `point_transaction_id` bigint unsigned DEFAULT NULL,
   `created_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_admin_actions_admin_user_id_idempotency_key` (`admin_user_id`, `idempotency_key`),
   KEY `idx_admin_actions_admin_user_id` (`admin_user_id`),
   KEY `idx_admin_actions_action_type` (`action_type`),
   KEY `idx_admin_actions_target_type` (`target_type`),
@@ -923,6 +983,7 @@ CREATE TABLE IF NOT EXISTS `partner_api_keys` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `partner_id` varchar(255) NOT NULL,
   `api_key` varchar(255) NOT NULL,
+  `key_prefix` varchar(32) NOT NULL DEFAULT '',
   `partner_name` varchar(255) DEFAULT NULL,
   `permissions` json DEFAULT (JSON_ARRAY()),
   `rate_limit` int DEFAULT NULL,
@@ -933,9 +994,76 @@ CREATE TABLE IF NOT EXISTS `partner_api_keys` (
   PRIMARY KEY (`id`),
   UNIQUE KEY `idx_partner_api_keys_api_key` (`api_key`),
   KEY `idx_partner_api_keys_partner_id` (`partner_id`),
+  KEY `idx_partner_api_keys_key_prefix` (`key_prefix`),
   KEY `idx_partner_api_keys_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 
+CREATE TABLE IF NOT EXISTS `oauth_clients` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `client_id` varchar(64) NOT NULL,
+  `client_secret_hash` varchar(64) DEFAULT NULL,
+  `name` varchar(255) NOT NULL,
+  `redirect_uris` json DEFAULT (JSON_ARRAY()),
+  `grant_types` json DEFAULT (JSON_ARRAY()),
+  `scopes` json DEFAULT (JSON_ARRAY()),
+  `confidential` tinyint(1) DEFAULT '1',
+  `created_by` bigint unsigned DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_oauth_clients_client_id` (`client_id`),
+  KEY `idx_oauth_clients_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `oauth_authorization_codes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `code_hash` varchar(64) NOT NULL,
+  `client_id` varchar(64) NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `redirect_uri` varchar(2048) NOT NULL,
+  `scope` varchar(1024) DEFAULT NULL,
+  `code_challenge` varchar(128) NOT NULL,
+  `code_challenge_method` varchar(16) NOT NULL,
+  `expires_at` datetime(3) DEFAULT NULL,
+  `used_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_oauth_authorization_codes_code_hash` (`code_hash`),
+  KEY `idx_oauth_authorization_codes_client_id` (`client_id`),
+  KEY `idx_oauth_authorization_codes_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `oauth_refresh_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `token_hash` varchar(64) NOT NULL,
+  `client_id` varchar(64) NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `scope` varchar(1024) DEFAULT NULL,
+  `family_id` varchar(36) NOT NULL,
+  `token_version` bigint DEFAULT '0',
+  `expires_at` datetime(3) DEFAULT NULL,
+  `is_revoked` tinyint(1) DEFAULT '0',
+  `rotated_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_oauth_refresh_tokens_token_hash` (`token_hash`),
+  KEY `idx_oauth_refresh_tokens_client_user` (`client_id`, `user_id`),
+  KEY `idx_oauth_refresh_tokens_family_id` (`family_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `oauth_consents` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `client_id` varchar(64) NOT NULL,
+  `scope` varchar(1024) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_oauth_consents_user_client` (`user_id`, `client_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
 CREATE TABLE IF NOT EXISTS `external_systems` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
   `name` varchar(255) NOT NULL,
@@ -1121,12 +1249,16 @@ CREATE TABLE IF NOT EXISTS `purchases` (
   `total_amount` decimal(10,2) NOT NULL,
   `points_earned` int DEFAULT '0',
   `tier_discount_applied` decimal(3,2) DEFAULT '0.00',
+  `point_transaction_id` bigint unsigned DEFAULT NULL,
+  `status` varchar(50) NOT NULL DEFAULT 'completed',
+  `refunded_at` datetime(3) DEFAULT NULL,
   `created_at` datetime(3) DEFAULT NULL,
   `updated_at` datetime(3) DEFAULT NULL,
   `deleted_at` datetime(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
   KEY `idx_purchases_user_id` (`user_id`),
   KEY `idx_purchases_store_id` (`store_id`),
+  KEY `idx_purchases_status` (`status`),
   KEY `idx_purchases_created_at` (`created_at`),
   KEY `idx_purchases_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -1223,6 +1355,8 @@ ALTER TABLE `refresh_tokens` ADD CONSTRAINT `fk_refresh_tokens_user_id` FOREIGN
 
 ALTER TABLE `user_tokens` ADD CONSTRAINT `fk_user_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE;
 
+ALTER TABLE `membership_tier_histories` ADD CONSTRAINT `fk_membership_tier_histories_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
 ALTER TABLE `point_transactions` ADD CONSTRAINT `fk_point_transactions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE;
 
 ALTER TABLE `user_sessions` ADD CONSTRAINT `fk_user_sessions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -1303,6 +1437,8 @@ CREATE INDEX `idx_login_attempts_created_at` ON `login_attempts` (`created_at`);
 CREATE INDEX `idx_ip_blacklists_is_active` ON `ip_blacklists` (`is_active`);
 CREATE INDEX `idx_ip_blacklists_expires_at` ON `ip_blacklists` (`expires_at`);
 
+CREATE INDEX `idx_ip_allowlists_is_active` ON `ip_allowlists` (`is_active`);
+
 CREATE INDEX `idx_user_sessions_expires_at` ON `user_sessions` (`expires_at`);
 CREATE INDEX `idx_user_sessions_is_active` ON `user_sessions` (`is_active`);
 
@@ -1374,6 +1510,7 @@ CREATE INDEX `idx_users_email_deleted_at` ON `users` (`email`, `deleted_at`);
 CREATE INDEX `idx_auths_email_is_active` ON `auths` (`email`, `is_active`);
 CREATE INDEX `idx_user_memberships_user_tier_active` ON `user_memberships` (`user_id`, `tier_id`, `is_active`);
 CREATE INDEX `idx_point_transactions_user_type_created` ON `point_transactions` (`user_id`, `type`, `created_at`);
+CREATE INDEX `idx_point_transactions_remaining_expires` ON `point_transactions` (`remaining_points`, `expires_at`);
 CREATE INDEX `idx_notifications_user_read_created` ON `notifications` (`user_id`, `is_read`, `created_at`);
 CREATE INDEX `idx_login_attempts_email_success_created` ON `login_attempts` (`email`, `success`, `created_at`);
 CREATE INDEX `idx_security_events_user_severity_created` ON `security_events` (`user_id`, `severity`, `created_at`);
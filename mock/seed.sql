@@ -33,18 +33,18 @@ WHERE (r.name = 'admin' AND p.name IN ('user.read', 'user.write', 'user.delete',
 ON DUPLICATE KEY UPDATE `created_at` = NOW();
 
 INSERT INTO `membership_tiers` (`name`, `level`, `description`, `benefits`, `requirements`, `is_active`, `created_at`, `updated_at`) VALUES
-('Bronze', 1, 'ブロンズ会員', '{"point_multiplier": 1.0, "features": ["basic_support"]}', '{"min_points": 0, "min_spent": 0}', 1, NOW(), NOW()),
-('Silver', 2, 'シルバー会員', '{"point_multiplier": 1.2, "features": ["priority_support", "exclusive_content"]}', '{"min_points": 1000, "min_spent": 10000}', 1, NOW(), NOW()),
-('Gold', 3, 'ゴールド会員', '{"point_multiplier": 1.5, "features": ["premium_support", "exclusive_content", "early_access"]}', '{"min_points": 5000, "min_spent": 50000}', 1, NOW(), NOW()),
-('Platinum', 4, 'プラチナ会員', '{"point_multiplier": 2.0, "features": ["vip_support", "all_exclusive_content", "early_access", "personal_advisor"]}', '{"min_points": 20000, "min_spent": 200000}', 1, NOW(), NOW())
+('Bronze', 1, 'ブロンズ会員', '{"point_multiplier": 1.0, "discount_rate": 0, "features": ["basic_support"]}', '{"min_points": 0, "min_spent": 0}', 1, NOW(), NOW()),
+('Silver', 2, 'シルバー会員', '{"point_multiplier": 1.2, "discount_rate": 0.02, "features": ["priority_support", "exclusive_content"]}', '{"min_points": 1000, "min_spent": 10000}', 1, NOW(), NOW()),
+('Gold', 3, 'ゴールド会員', '{"point_multiplier": 1.5, "discount_rate": 0.05, "features": ["premium_support", "exclusive_content", "early_access"]}', '{"min_points": 5000, "min_spent": 50000}', 1, NOW(), NOW()),
+('Platinum', 4, 'プラチナ会員', '{"point_multiplier": 2.0, "discount_rate": 0.1, "features": ["vip_support", "all_exclusive_content", "early_access", "personal_advisor"]}', '{"min_points": 20000, "min_spent": 200000}', 1, NOW(), NOW())
 ON DUPLICATE KEY UPDATE `updated_at` = NOW();
 
-INSERT INTO `rate_limit_rules` (`name`, `resource`, `max_requests`, `window_size`, `is_active`, `created_at`, `updated_at`) VALUES
-('login_attempts', '/api/v1/auth/login', 5, 300, 1, NOW(), NOW()),
-('register_attempts', '/api/v1/auth/register', 3, 3600, 1, NOW(), NOW()),
-('password_reset', '/api/v1/auth/reset-password', 3, 3600, 1, NOW(), NOW()),
-('api_general', '/api/v1/*', 100, 60, 1, NOW(), NOW()),
-('admin_api', '/api/v1/admin/*', 50, 60, 1, NOW(), NOW())
+INSERT INTO `rate_limit_rules` (`name`, `resource`, `max_requests`, `window_size`, `algorithm`, `is_active`, `created_at`, `updated_at`) VALUES
+('login_attempts', '/api/v1/auth/login', 5, 300, 'sliding_log', 1, NOW(), NOW()),
+('register_attempts', '/api/v1/auth/register', 3, 3600, 'sliding_log', 1, NOW(), NOW()),
+('password_reset', '/api/v1/auth/password/reset', 3, 3600, 'sliding_log', 1, NOW(), NOW()),
+('api_general', '/api/v1/*', 100, 60, 'sliding_window', 1, NOW(), NOW()),
+('admin_api', '/api/v1/admin/*', 50, 60, 'token_bucket', 1, NOW(), NOW())
 ON DUPLICATE KEY UPDATE `updated_at` = NOW();
 
 INSERT INTO `users` (`name`, `email`, `age`, `created_at`, `updated_at`) VALUES
@@ -125,8 +125,8 @@ FROM `users` u
 WHERE u.email IN ('admin@example.com', 'user@example.com')
 ON DUPLICATE KEY UPDATE `updated_at` = NOW();
 
-INSERT INTO `point_transactions` (`user_id`, `type`, `points`, `description`, `reference_type`, `created_at`)
-SELECT u.id, 'EARN', 1000, '新規登録ボーナス', 'registration', NOW()
+INSERT INTO `point_transactions` (`user_id`, `type`, `points`, `remaining_points`, `balance_after`, `description`, `reference_type`, `expires_at`, `created_at`)
+SELECT u.id, 'EARN', 1000, 1000, 1000, '新規登録ボーナス', 'registration', DATE_ADD(NOW(), INTERVAL 1 YEAR), NOW()
 FROM `users` u
 WHERE u.email IN ('admin@example.com', 'user@example.com')
 ON DUPLICATE KEY UPDATE `created_at` = NOW();
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +13,9 @@ import (
 	"strings"
 	"time"
 	"unicode"
+
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/domain/service"
+	"github.com/ageha734/dmm-go-2025-09-17-go-task/internal/infrastructure/external"
 )
 
 const (
@@ -20,6 +24,13 @@ const (
 	recommendedMinLength = 32
 )
 
+const (
+	modeSecret          = "secret"
+	modeKeys            = "keys"
+	defaultKeyAlgorithm = service.SigningAlgorithmES256
+	defaultKeyOverlap   = 24 * time.Hour
+)
+
 const (
 	colorReset  = "\033[0m"
 	colorRed    = "\033[31m"
@@ -29,11 +40,16 @@ const (
 )
 
 type Config struct {
-	Length     int
-	UpdateEnv  bool
-	Force      bool
-	OutputOnly bool
-	Help       bool
+	Length      int
+	UpdateEnv   bool
+	Force       bool
+	OutputOnly  bool
+	Help        bool
+	Mode        string
+	Algorithm   string
+	KeySetFile  string
+	Overlap     time.Duration
+	PublishLead time.Duration
 }
 
 type JWTSecretGenerator struct {
@@ -83,6 +99,11 @@ func showUsage() {
 	fmt.Println("  -update-env          Update .env file with the generated secret")
 	fmt.Println("  -force               Force update even if JWT_SECRET already exists")
 	fmt.Println("  -output-only         Only output the secret without any messages")
+	fmt.Println("  -mode <secret|keys>  Generate an HS256 secret or rotate an asymmetric key set (default: secret)")
+	fmt.Println("  -alg <algorithm>     Key algorithm for -mode keys: RS256, ES256 or EdDSA (default: ES256)")
+	fmt.Println("  -keyset-file <path>  Key set file for -mode keys (default: $JWT_KEYSET_FILE or jwt-keyset.json)")
+	fmt.Println("  -overlap <duration>  How long retired keys keep verifying tokens (default: 24h)")
+	fmt.Println("  -publish-lead <duration> How long a new key is published before it signs (default: 10m)")
 	fmt.Println("  -help                Show this help message")
 	fmt.Println("")
 	fmt.Println("Examples:")
@@ -90,6 +111,7 @@ func showUsage() {
 	fmt.Println("  go run scripts/generate-jwt-secret/main.go -length 128        # Generate a 128-character secret")
 	fmt.Println("  go run scripts/generate-jwt-secret/main.go -update-env        # Generate and update .env file")
 	fmt.Println("  go run scripts/generate-jwt-secret/main.go -output-only       # Only output the secret (useful for scripts)")
+	fmt.Println("  go run scripts/generate-jwt-secret/main.go -mode keys -alg RS256 # Generate or rotate an RS256 signing key")
 	fmt.Println("")
 }
 
@@ -387,6 +409,81 @@ func updateJWTSecretInFile(envFile, secret string) error {
 	return writer.Flush()
 }
 
+func defaultKeySetFile() string {
+	if path := os.Getenv("JWT_KEYSET_FILE"); path != "" {
+		return path
+	}
+	return "jwt-keyset.json"
+}
+
+func (g *JWTSecretGenerator) rotateKeySet() error {
+	if g.config.Overlap < time.Hour {
+		return fmt.Errorf("overlap must be at least 1h so issued access tokens stay verifiable")
+	}
+
+	path := g.config.KeySetFile
+	if !filepath.IsAbs(path) {
+		projectRoot, err := findProjectRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find project root: %w", err)
+		}
+		path = filepath.Join(projectRoot, path)
+	}
+
+	if err := validateFilePath(path); err != nil {
+		return fmt.Errorf("invalid key set path: %w", err)
+	}
+
+	keySet, err := external.LoadJWTKeySet(path, g.config.Algorithm, g.config.Overlap, g.config.PublishLead)
+	if err != nil {
+		return fmt.Errorf("failed to load key set: %w", err)
+	}
+
+	previous, previousErr := keySet.ActiveKey()
+
+	key, err := keySet.Rotate()
+	if err != nil {
+		return fmt.Errorf("failed to rotate key set: %w", err)
+	}
+
+	if g.config.OutputOnly {
+		fmt.Println(key.KeyID)
+		return nil
+	}
+
+	printSuccess(fmt.Sprintf("Generated %s signing key: %s", key.Algorithm, key.KeyID))
+	if previousErr == nil {
+		printInfo(fmt.Sprintf("Key %s starts signing at %s", key.KeyID, key.ActivatesAt.Format(time.RFC3339)))
+		printInfo(fmt.Sprintf("Retired key %s stays valid for verification for %s after that", previous.KeyID, g.config.Overlap))
+	}
+	printInfo(fmt.Sprintf("Key set written to %s", path))
+
+	jwks := service.JWKS{Keys: []service.JWK{}}
+	for _, verificationKey := range keySet.VerificationKeys() {
+		jwk, err := verificationKey.PublicJWK()
+		if err != nil {
+			return fmt.Errorf("failed to encode public key: %w", err)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	encoded, err := json.MarshalIndent(jwks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JWKS: %w", err)
+	}
+
+	fmt.Println()
+	printInfo("Public JWKS:")
+	fmt.Println(string(encoded))
+	fmt.Println()
+	printInfo("To sign tokens with this key set, set in your .env file:")
+	fmt.Printf("  JWT_SIGNING_ALG=%s\n", key.Algorithm)
+	fmt.Printf("  JWT_KEYSET_FILE=%s\n", path)
+	printWarning("Running instances reload the key set within a minute; keep the file private")
+
+	return nil
+}
+
 func parseFlags() Config {
 	var config Config
 
@@ -395,6 +492,11 @@ func parseFlags() Config {
 	flag.BoolVar(&config.Force, "force", false, "Force update even if JWT_SECRET already exists")
 	flag.BoolVar(&config.OutputOnly, "output-only", false, "Only output the secret without any messages")
 	flag.BoolVar(&config.Help, "help", false, "Show help message")
+	flag.StringVar(&config.Mode, "mode", modeSecret, "Generate an HS256 secret or rotate an asymmetric key set")
+	flag.StringVar(&config.Algorithm, "alg", defaultKeyAlgorithm, "Key algorithm for -mode keys")
+	flag.StringVar(&config.KeySetFile, "keyset-file", defaultKeySetFile(), "Key set file for -mode keys")
+	flag.DurationVar(&config.Overlap, "overlap", defaultKeyOverlap, "How long retired keys keep verifying tokens")
+	flag.DurationVar(&config.PublishLead, "publish-lead", external.DefaultKeyPublishLead, "How long a new key is published before it signs")
 
 	flag.Parse()
 
@@ -409,6 +511,14 @@ func (g *JWTSecretGenerator) Run() error {
 		return nil
 	}
 
+	switch g.config.Mode {
+	case modeSecret:
+	case modeKeys:
+		return g.rotateKeySet()
+	default:
+		return fmt.Errorf("unknown mode: %s", g.config.Mode)
+	}
+
 	if g.config.Length < minLength {
 		return fmt.Errorf("length must be >= %d", minLength)
 	}